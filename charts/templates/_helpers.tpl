@@ -83,6 +83,17 @@ Validate required values that must not remain as placeholders.
 {{- end -}}
 {{- end }}
 
+{{/*
+Name and namespace of the leader election Lease
+*/}}
+{{- define "sentinel.leaseName" -}}
+{{- .Values.leaderElection.leaseName | default (include "sentinel.fullname" .) }}
+{{- end }}
+
+{{- define "sentinel.leaseNamespace" -}}
+{{- .Values.leaderElection.namespace | default .Release.Namespace }}
+{{- end }}
+
 {{/*
 Create the name of the secret to use
 */}}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+const (
+	formatYAML = "yaml"
+	formatJSON = "json"
+)
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the sentinel configuration",
+	}
+	cmd.AddCommand(newConfigEffectiveCommand())
+	return cmd
+}
+
+func newConfigEffectiveCommand() *cobra.Command {
+	var configFile, format string
+
+	cmd := &cobra.Command{
+		Use:   "effective",
+		Short: "Print the fully resolved sentinel configuration",
+		Long: `Load the sentinel configuration from config file, environment variables,
+and CLI flags, apply defaults, validate it, and print the result to stdout.
+Secrets such as proxy passwords are redacted.
+
+With --format json the output uses the same keys as the config file, with
+durations as strings (e.g. "5s") and object keys sorted, so that
+infrastructure-as-code pipelines can compare it against the intended
+configuration. Exits with code 0 on success, non-zero on error.
+
+Priority order (lowest to highest): defaults < config file < env vars < CLI flags`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printEffectiveConfig(configFile, cmd.Flags(), format)
+		},
+	}
+
+	cmd.Flags().StringVarP(&configFile, "config", "c", "", "Path to configuration file (YAML)")
+	cmd.Flags().StringVar(&format, "format", formatYAML, "Output format: yaml, json")
+	addConfigOverrideFlags(cmd)
+
+	return cmd
+}
+
+// printEffectiveConfig loads the full sentinel configuration and prints it to
+// stdout in the given format.
+func printEffectiveConfig(configFile string, flags *pflag.FlagSet, format string) error {
+	if format != formatYAML && format != formatJSON {
+		return fmt.Errorf("unsupported format %q, must be %s or %s", format, formatYAML, formatJSON)
+	}
+
+	// Keep stdout parseable: configuration loading logs go to stderr.
+	logCfg := logger.DefaultConfig()
+	logCfg.Output = os.Stderr
+	logger.SetGlobalConfig(logCfg)
+
+	cfg, err := config.LoadConfig(configFile, flags)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(cfg.RedactedCopy())
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if format == formatJSON {
+		if data, err = yamlToJSON(data); err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+	}
+	fmt.Print(string(data))
+	return nil
+}
+
+// yamlToJSON converts a YAML document to indented JSON. Going through YAML
+// keeps the config file's key names and duration strings, which the config
+// structs only declare for YAML.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false) // CEL expressions contain && and <
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultAdminBindAddress binds the admin server to loopback so that only
+	// processes inside the pod (e.g. via kubectl exec) can drain the instance.
+	defaultAdminBindAddress = "127.0.0.1:8081"
+
+	drainPath = "/drain"
+)
+
+// drainRequest is handed from the admin server to runServe. runServe sends the
+// handoff result on done once the polling loop has stopped.
+type drainRequest struct {
+	done chan error
+}
+
+// drainResponse is the JSON response for POST /drain.
+type drainResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// newDrainHandler returns the POST /drain handler. It queues a single drain
+// request on drainCh and blocks until runServe reports the handoff result, so
+// the caller learns whether the final heartbeat was published.
+func newDrainHandler(drainCh chan<- drainRequest) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := drainRequest{done: make(chan error, 1)}
+		select {
+		case drainCh <- req:
+		default:
+			writeDrainResponse(w, http.StatusConflict, drainResponse{Status: "drain already in progress"})
+			return
+		}
+
+		select {
+		case err := <-req.done:
+			if err != nil {
+				writeDrainResponse(w, http.StatusInternalServerError, drainResponse{Status: "error", Error: err.Error()})
+				return
+			}
+			writeDrainResponse(w, http.StatusOK, drainResponse{Status: "drained"})
+		case <-r.Context().Done():
+		}
+	}
+}
+
+func writeDrainResponse(w http.ResponseWriter, statusCode int, v drainResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v) //nolint:errcheck // client may have gone away; nothing left to do
+}
+
+func newDrainShardCommand() *cobra.Command {
+	var (
+		adminAddress string
+		timeout      time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "drain-shard",
+		Short: "Drain a running Sentinel so its shard can be handed to another instance",
+		Long: `Ask the Sentinel serving on --admin-address to stop publishing for its
+resource selector, publish a final handoff heartbeat
+(com.redhat.hyperfleet.sentinel.handoff) to its topic, and exit cleanly.
+
+Run it inside the Sentinel pod, for example:
+  kubectl exec deploy/my-sentinel -- sentinel drain-shard
+
+Update the instance's resource_selector before it restarts so that it comes
+back serving its new shard.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDrainShard(cmd.Context(), adminAddress, timeout)
+		},
+	}
+
+	cmd.Flags().StringVar(&adminAddress, "admin-address", defaultAdminBindAddress,
+		"Admin server address of the Sentinel to drain")
+	cmd.Flags().DurationVar(&timeout, "timeout", 60*time.Second, "How long to wait for the drain to complete")
+
+	return cmd
+}
+
+// runDrainShard requests a drain from the admin server and waits for the result.
+func runDrainShard(ctx context.Context, adminAddress string, timeout time.Duration) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+adminAddress+drainPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build drain request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach sentinel admin server at %s: %w", adminAddress, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // read-only response body
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read drain response: %w", err)
+	}
+
+	var dr drainResponse
+	if err := json.Unmarshal(body, &dr); err != nil {
+		return fmt.Errorf("unexpected drain response (status %d): %s", resp.StatusCode, string(body))
+	}
+	if resp.StatusCode != http.StatusOK {
+		if dr.Error != "" {
+			return fmt.Errorf("drain failed: %s: %s", dr.Status, dr.Error)
+		}
+		return fmt.Errorf("drain failed: %s", dr.Status)
+	}
+
+	fmt.Printf("Sentinel at %s drained; handoff heartbeat published\n", adminAddress)
+	return nil
+}
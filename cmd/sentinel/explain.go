@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/sentinel"
+)
+
+const (
+	explainPath = "/debug/decisions"
+
+	// maxExplainRequestBytes bounds the request body, which may carry a
+	// whole resource.
+	maxExplainRequestBytes = 1 << 20
+)
+
+// explainRequest is the body of POST /debug/decisions. Either Resource is
+// explained as given, or the resource with ID is fetched from Region. Watcher
+// names the watcher whose decision is explained, and may be empty without
+// watchers.
+type explainRequest struct {
+	Resource *client.Resource `json:"resource,omitempty"`
+	ID       string           `json:"id,omitempty"`
+	Region   string           `json:"region,omitempty"`
+	Watcher  string           `json:"watcher,omitempty"`
+}
+
+// explainDecision is the JSON representation of an engine.Decision.
+type explainDecision struct {
+	Reason        string `json:"reason"`
+	Rule          string `json:"rule,omitempty"`
+	Condition     string `json:"condition,omitempty"`
+	Window        string `json:"window,omitempty"`
+	Error         string `json:"error,omitempty"`
+	ClockSkew     string `json:"clock_skew,omitempty"`
+	ShouldPublish bool   `json:"should_publish"`
+}
+
+// explainResponse is the JSON response for POST /debug/decisions.
+type explainResponse struct {
+	*engine.Explanation
+	Decision   *explainDecision `json:"decision,omitempty"`
+	ResourceID string           `json:"resource_id,omitempty"`
+	Region     string           `json:"region,omitempty"`
+	Watcher    string           `json:"watcher,omitempty"`
+	Error      string           `json:"error,omitempty"`
+	// Paused reports a Sentinel-wide pause, which skips every resource
+	// after the decision.
+	Paused bool `json:"paused"`
+}
+
+// newExplainHandler returns the POST /debug/decisions handler. It explains
+// the decision for a resource given in the request, or fetched by ID, at the
+// current time, so operators can see why a resource is or is not published.
+func newExplainHandler(watchers watcherSet) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req explainRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxExplainRequestBytes)).Decode(&req); err != nil {
+			writeAdminJSON(w, http.StatusBadRequest, explainResponse{Error: fmt.Sprintf("invalid request body: %v", err)})
+			return
+		}
+		if (req.Resource == nil) == (req.ID == "") {
+			writeAdminJSON(w, http.StatusBadRequest, explainResponse{Error: "exactly one of resource or id is required"})
+			return
+		}
+		watcher, err := watchers.find(req.Watcher)
+		if err != nil {
+			writeAdminJSON(w, http.StatusBadRequest, explainResponse{Error: err.Error()})
+			return
+		}
+		s := watcher.sentinel
+
+		now := time.Now()
+		resource := req.Resource
+		var explanation *engine.Explanation
+		if resource != nil {
+			explanation = s.ExplainResource(r.Context(), resource, now)
+		} else {
+			resource, explanation, err = s.Explain(r.Context(), req.Region, req.ID, now)
+			if err != nil {
+				writeAdminJSON(w, explainErrorStatus(err), explainResponse{ResourceID: req.ID, Error: err.Error()})
+				return
+			}
+		}
+
+		decision := explanation.Decision
+		resp := explainResponse{
+			Explanation: explanation,
+			ResourceID:  resource.ID,
+			Region:      resource.Region,
+			Watcher:     watcher.name,
+			Paused:      s.Paused(),
+			Decision: &explainDecision{
+				ShouldPublish: decision.ShouldPublish,
+				Reason:        string(decision.Reason),
+				Rule:          decision.Rule,
+				Condition:     decision.Condition,
+				Window:        decision.Window,
+			},
+		}
+		if decision.Err != nil {
+			resp.Decision.Error = decision.Err.Error()
+		}
+		if decision.ClockSkew > 0 {
+			resp.Decision.ClockSkew = decision.ClockSkew.String()
+		}
+		writeAdminJSON(w, http.StatusOK, resp)
+	}
+}
+
+func explainErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, sentinel.ErrUnknownRegion):
+		return http.StatusBadRequest
+	case client.IsNotFound(err):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadGateway
+	}
+}
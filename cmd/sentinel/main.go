@@ -9,6 +9,9 @@ import (
 	"strings"
 	"syscall"
 	"time"
+	// Embed the time zone database: the ubi9-micro runtime image has none,
+	// and maintenance window time zones are loaded by name.
+	_ "time/tzdata"
 
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/telemetry"
 	"github.com/prometheus/client_golang/prometheus"
@@ -21,10 +24,17 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-broker/broker"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/decisionstream"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/fips"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/health"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/leader"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/outbox"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/publisher"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/sentinel"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/state"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/statusui"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
 )
 
@@ -44,6 +54,11 @@ reconciliation events to a message broker based on configurable max age interval
 
 	rootCmd.AddCommand(newServeCommand())
 	rootCmd.AddCommand(newConfigDumpCommand())
+	rootCmd.AddCommand(newConfigCommand())
+	rootCmd.AddCommand(newValidateCommand())
+	rootCmd.AddCommand(newDrainShardCommand())
+	rootCmd.AddCommand(newPauseCommand())
+	rootCmd.AddCommand(newResumeCommand())
 	rootCmd.AddCommand(newVersionCommand())
 
 	if err := rootCmd.Execute(); err != nil {
@@ -74,6 +89,7 @@ func newServeCommand() *cobra.Command {
 		configFile         string
 		healthBindAddress  string
 		metricsBindAddress string
+		adminBindAddress   string
 	)
 
 	cmd := &cobra.Command{
@@ -96,7 +112,8 @@ func newServeCommand() *cobra.Command {
 				return fmt.Errorf("failed to initialize logging: %w", err)
 			}
 
-			return runServe(cfg, logCfg, healthBindAddress, metricsBindAddress)
+			load := func() (*config.SentinelConfig, error) { return config.LoadConfig(configFile, cmd.Flags()) }
+			return runServe(cfg, logCfg, load, healthBindAddress, metricsBindAddress, adminBindAddress)
 		},
 	}
 
@@ -106,6 +123,8 @@ func newServeCommand() *cobra.Command {
 	// Server bind address flags
 	cmd.Flags().StringVar(&healthBindAddress, "health-server-bindaddress", ":8080", "Health server bind address")
 	cmd.Flags().StringVar(&metricsBindAddress, "metrics-server-bindaddress", ":9090", "Metrics server bind address")
+	cmd.Flags().StringVar(&adminBindAddress, "admin-server-bindaddress", defaultAdminBindAddress,
+		"Admin server bind address for drain-shard, pause, resume, and the /ui status page (empty disables the admin server)")
 
 	// Add config override flags
 	addConfigOverrideFlags(cmd)
@@ -141,6 +160,8 @@ Priority order (lowest to highest): config file < env vars < CLI flags`,
 func addConfigOverrideFlags(cmd *cobra.Command) {
 	// General
 	cmd.Flags().Bool("debug-config", false, "Log the full merged configuration after load. Env: HYPERFLEET_DEBUG_CONFIG")
+	cmd.Flags().Bool("dry-run", false,
+		"Evaluate resources and log the events that would be published without publishing them. Env: HYPERFLEET_DRY_RUN")
 
 	// Sentinel
 	cmd.Flags().StringP("name", "n", "", "Sentinel component name. Env: HYPERFLEET_SENTINEL_NAME")
@@ -204,7 +225,8 @@ func initLogging(logCfg *config.LogConfig, tracingEnabled bool) (*logger.LogConf
 }
 
 func runServe(
-	cfg *config.SentinelConfig, logCfg *logger.LogConfig, healthBindAddress, metricsBindAddress string,
+	cfg *config.SentinelConfig, logCfg *logger.LogConfig, load configLoader,
+	healthBindAddress, metricsBindAddress, adminBindAddress string,
 ) error {
 	// Initialize context and logger
 	ctx := context.Background()
@@ -250,6 +272,16 @@ func runServe(
 		}
 	}
 
+	// FIPS mode relies on the Go FIPS 140-3 module, which restricts every TLS
+	// connection in the process (API and broker) to approved algorithms.
+	if cfg.FIPSMode {
+		if err := fips.Check(); err != nil {
+			log.Errorf(ctx, "FIPS mode verification failed: %v", err)
+			return err
+		}
+		log.Extra("fips_module_version", fips.ModuleVersion()).Info(ctx, "FIPS mode enabled")
+	}
+
 	// Initialize Prometheus metrics registry
 	registry := prometheus.NewRegistry()
 	// Register metrics once (uses sync.Once internally)
@@ -262,27 +294,129 @@ func runServe(
 		tokenPath = cfg.Clients.HyperFleetAPI.Auth.TokenPath
 		tokenCacheTTL = cfg.Clients.HyperFleetAPI.Auth.TokenCacheTTL
 	}
-	hyperfleetClient, err := client.NewHyperFleetClient(
-		cfg.Clients.HyperFleetAPI.BaseURL, cfg.Clients.HyperFleetAPI.Timeout,
-		cfg.Sentinel.Name, version, cfg.Clients.HyperFleetAPI.PageSize,
-		tokenPath, tokenCacheTTL,
-	)
-	if err != nil {
-		log.Errorf(ctx, "Failed to initialize OpenAPI client: %v", err)
-		return fmt.Errorf("failed to initialize OpenAPI client: %w", err)
+	var clientOpts []client.Option
+	if tlsCfg := cfg.Clients.HyperFleetAPI.TLS; tlsCfg != nil || cfg.FIPSMode {
+		if tlsCfg == nil {
+			tlsCfg = &config.HyperFleetAPITLSConfig{}
+		}
+		apiTLS, tlsErr := client.NewTLSConfig(client.TLSOptions{
+			CAFile:             tlsCfg.CAFile,
+			CertFile:           tlsCfg.CertFile,
+			KeyFile:            tlsCfg.KeyFile,
+			MinVersion:         tlsCfg.MinVersion,
+			InsecureSkipVerify: tlsCfg.InsecureSkipVerify,
+			FIPS:               cfg.FIPSMode,
+		})
+		if tlsErr != nil {
+			log.Errorf(ctx, "Failed to build HyperFleet API TLS config: %v", tlsErr)
+			return fmt.Errorf("failed to build HyperFleet API TLS config: %w", tlsErr)
+		}
+		if tlsCfg.InsecureSkipVerify {
+			log.Warn(ctx, "TLS certificate verification is disabled for the HyperFleet API client")
+		}
+		clientOpts = append(clientOpts, client.WithTLSConfig(apiTLS))
+	}
+	if cbCfg := cfg.Clients.HyperFleetAPI.CircuitBreaker; cbCfg != nil {
+		clientOpts = append(clientOpts, client.WithCircuitBreaker(cbCfg.FailureThreshold, cbCfg.Cooldown))
+	}
+	if rlCfg := cfg.Clients.HyperFleetAPI.RateLimit; rlCfg != nil {
+		clientOpts = append(clientOpts, client.WithRateLimit(rlCfg.QPS, rlCfg.Burst))
+	}
+	if rtCfg := cfg.Clients.HyperFleetAPI.Retry; rtCfg != nil {
+		clientOpts = append(clientOpts,
+			client.WithRetryStrategy(retryStrategy(rtCfg)), client.WithMaxRetryTime(rtCfg.MaxElapsedTime))
 	}
+	if trCfg := cfg.Clients.HyperFleetAPI.Transport; trCfg != nil {
+		clientOpts = append(clientOpts, client.WithTransport(client.TransportSettings{
+			HTTPProxy:           trCfg.HTTPProxy,
+			HTTPSProxy:          trCfg.HTTPSProxy,
+			NoProxy:             trCfg.NoProxy,
+			DialTimeout:         trCfg.DialTimeout,
+			IdleConnTimeout:     trCfg.IdleConnTimeout,
+			MaxIdleConns:        trCfg.MaxIdleConns,
+			MaxIdleConnsPerHost: trCfg.MaxIdleConnsPerHost,
+		}))
+	}
+	if v := cfg.Clients.HyperFleetAPI.Version; v != "" {
+		clientOpts = append(clientOpts, client.WithAPIVersion(v))
+	}
+	if n := cfg.Clients.HyperFleetAPI.PageConcurrency; n > 1 {
+		clientOpts = append(clientOpts, client.WithPageConcurrency(n))
+	}
+	if apiCfg := cfg.Clients.HyperFleetAPI; apiCfg.MaxBodyBytes > 0 || apiCfg.MaxItems > 0 {
+		clientOpts = append(clientOpts, client.WithResponseLimits(apiCfg.MaxBodyBytes, apiCfg.MaxItems))
+	}
+	if cfg.Clients.HyperFleetAPI.StreamingDecode {
+		clientOpts = append(clientOpts, client.WithStreamingDecode())
+	}
+	if cfg.ResourceTagging != nil {
+		clientOpts = append(clientOpts, client.WithWrites())
+	}
+	if len(cfg.ResourceTypes) > 0 {
+		endpoints := make(map[string]client.ResourceEndpoint, len(cfg.ResourceTypes))
+		for name, rt := range cfg.ResourceTypes {
+			endpoints[name] = client.ResourceEndpoint{
+				Path:        rt.EndpointPath(cfg.Clients.HyperFleetAPI.Version),
+				Kind:        rt.Kind,
+				StatusField: rt.StatusField,
+			}
+		}
+		clientOpts = append(clientOpts, client.WithResourceEndpoints(endpoints))
+	}
+	watcherCfgs := cfg.WatcherConfigs()
+	clientOpts = append(clientOpts, client.WithRequestMetrics(
+		watcherCfgs[0].ResourceType, metrics.GetResourceSelectorLabel(watcherCfgs[0].ResourceSelector)))
+
+	// One client per endpoint: a single base_url, or one per configured region.
+	// Each client has its own circuit breaker and rate limiter, and is shared
+	// by all watchers.
+	clients := make(map[string]*client.HyperFleetClient)
+	for _, wcfg := range watcherCfgs {
+		for _, rc := range regionConfigs(wcfg) {
+			hyperfleetClient, ok := clients[rc.BaseURL]
+			if !ok {
+				var clientErr error
+				hyperfleetClient, clientErr = client.NewHyperFleetClient(
+					rc.BaseURL, cfg.Clients.HyperFleetAPI.Timeout,
+					cfg.Sentinel.Name, version, cfg.Clients.HyperFleetAPI.PageSize,
+					tokenPath, tokenCacheTTL, clientOpts...,
+				)
+				if clientErr != nil {
+					log.Errorf(ctx, "Failed to initialize OpenAPI client: %v", clientErr)
+					return fmt.Errorf("failed to initialize OpenAPI client: %w", clientErr)
+				}
+				clients[rc.BaseURL] = hyperfleetClient
+			}
 
-	// verify HyperFleet client connectivity
-	if err = hyperfleetClient.VerifyConnectivity(ctx, cfg.ResourceType); err != nil {
-		log.Errorf(ctx, "Failed to verify HyperFleet client connectivity: %v", err)
-		return fmt.Errorf("failed to verify HyperFleet client connectivity: %w", err)
+			// verify HyperFleet client connectivity
+			verifyCtx := client.WithMetricsLabels(ctx, wcfg.ResourceType,
+				metrics.GetResourceSelectorLabel(wcfg.ResourceSelector))
+			if verifyErr := hyperfleetClient.VerifyConnectivity(verifyCtx, wcfg.ResourceType); verifyErr != nil {
+				log.Errorf(ctx, "Failed to verify HyperFleet client connectivity: %v", verifyErr)
+				return fmt.Errorf("failed to verify HyperFleet client connectivity: %w", verifyErr)
+			}
+			if ok {
+				continue
+			}
+			if rc.Name != "" {
+				log.Infof(ctx, "Initialized HyperFleet client region=%s topic=%s", rc.Name, rc.Topic)
+			} else {
+				log.Info(ctx, "Initialized HyperFleet client")
+			}
+		}
 	}
-	log.Info(ctx, "Initialized HyperFleet client")
 
-	decisionEngine, err := engine.NewDecisionEngine(cfg.MessageDecision)
-	if err != nil {
-		log.Errorf(ctx, "Failed to create decision engine: %v", err)
-		return fmt.Errorf("failed to create decision engine: %w", err)
+	// A decision policy is shared by all watchers; message_decision engines
+	// are built per watcher below.
+	var policyDecider engine.Decider
+	if dp := cfg.DecisionPolicy; dp != nil {
+		var err error
+		policyDecider, err = engine.NewPolicyDecider(dp)
+		if err != nil {
+			log.Errorf(ctx, "Failed to create decision policy: %v", err)
+			return fmt.Errorf("failed to create decision policy: %w", err)
+		}
+		log.Infof(ctx, "Using decision policy url=%s path=%s", dp.RedactedURL(), dp.Path)
 	}
 
 	// Initialize broker metrics recorder
@@ -292,10 +426,38 @@ func runServe(
 
 	// Initialize publisher using hyperfleet-broker library
 	// Configuration is loaded from broker.yaml or BROKER_CONFIG_FILE env var
-	pub, err := broker.NewPublisher(log, brokerMetrics)
-	if err != nil {
-		log.Errorf(ctx, "Failed to initialize broker publisher: %v", err)
-		return fmt.Errorf("failed to initialize broker publisher: %w", err)
+	// A dry run never publishes, so it does not connect to the broker, and a
+	// webhook or file sink replaces the broker entirely.
+	var (
+		pub publisher.Publisher
+		err error
+	)
+	if cfg.DryRun {
+		pub = publisher.NewMockPublisher()
+		log.Warn(ctx, "Dry-run mode: events that would be published are logged and counted but never published")
+	} else if cfg.Clients.Broker != nil && cfg.Clients.Broker.Webhook != nil {
+		wh := cfg.Clients.Broker.Webhook
+		pub, err = publisher.NewWebhookPublisher(wh, brokerMetrics)
+		if err != nil {
+			log.Errorf(ctx, "Failed to initialize webhook publisher: %v", err)
+			return fmt.Errorf("failed to initialize webhook publisher: %w", err)
+		}
+		log.Infof(ctx, "Initialized webhook publisher url=%s", wh.RedactedURL())
+	} else if cfg.Clients.Broker != nil && cfg.Clients.Broker.File != nil {
+		fc := cfg.Clients.Broker.File
+		pub, err = publisher.NewFilePublisher(fc, brokerMetrics)
+		if err != nil {
+			log.Errorf(ctx, "Failed to initialize file publisher: %v", err)
+			return fmt.Errorf("failed to initialize file publisher: %w", err)
+		}
+		log.Infof(ctx, "Initialized file publisher path=%s", fc.Path)
+	} else {
+		pub, err = newBrokerPublisher(ctx, log, brokerMetrics, cfg.Clients.Broker, nil, cfg.FIPSMode)
+		if err != nil {
+			log.Errorf(ctx, "Failed to initialize broker publisher: %v", err)
+			return fmt.Errorf("failed to initialize broker publisher: %w", err)
+		}
+		log.Info(ctx, "Initialized broker publisher")
 	}
 	if pub != nil {
 		defer func() {
@@ -304,7 +466,58 @@ func runServe(
 			}
 		}()
 	}
-	log.Info(ctx, "Initialized broker publisher")
+	if b := cfg.Clients.Broker; b != nil && len(b.FanOut) > 0 && !cfg.DryRun {
+		// The fan-out publisher closes the primary one with its targets.
+		fanOut, fanOutErr := newFanOutPublisher(ctx, log, brokerMetrics, b, pub, cfg.FIPSMode,
+			watcherCfgs[0].ResourceType, metrics.GetResourceSelectorLabel(watcherCfgs[0].ResourceSelector))
+		if fanOutErr != nil {
+			log.Errorf(ctx, "Failed to initialize fan-out publisher: %v", fanOutErr)
+			return fmt.Errorf("failed to initialize fan-out publisher: %w", fanOutErr)
+		}
+		pub = fanOut
+	}
+	if b := cfg.Clients.Broker; b != nil && len(b.Encodings) > 0 && !cfg.DryRun {
+		pub = publisher.NewEncodingPublisher(pub, b)
+		log.Infof(ctx, "Encoding event payloads per topic encodings=%v", b.Encodings)
+	}
+	if b := cfg.Clients.Broker; b != nil && b.Batch != nil && !cfg.DryRun {
+		// The batch publisher flushes pending batches and closes the
+		// publisher it wraps.
+		pub = publisher.NewBatchPublisher(pub, b.Batch,
+			watcherCfgs[0].ResourceType, metrics.GetResourceSelectorLabel(watcherCfgs[0].ResourceSelector))
+		log.Infof(ctx, "Publishing events in batches max_size=%d flush_interval=%s",
+			b.Batch.MaxSize, b.Batch.FlushInterval)
+	}
+	if b := cfg.Clients.Broker; b != nil && b.AsyncPublish != nil && !cfg.DryRun {
+		// The async publisher drains its queue and closes the publisher it
+		// wraps.
+		pub = publisher.NewAsyncPublisher(pub, b.AsyncPublish,
+			watcherCfgs[0].ResourceType, metrics.GetResourceSelectorLabel(watcherCfgs[0].ResourceSelector), log)
+		log.Infof(ctx, "Publishing events asynchronously max_in_flight=%d queue_size=%d max_attempts=%d",
+			b.AsyncPublish.MaxInFlight, b.AsyncPublish.QueueSize, b.AsyncPublish.MaxAttempts)
+	}
+
+	// With leader election, only the replica holding the lease runs the poll
+	// loops; the others keep their clients and publisher ready to take over.
+	var elector *leader.Elector
+	if lecfg := cfg.LeaderElection; lecfg != nil {
+		leaseClient, leaseErr := leader.NewInClusterLeaseClient()
+		if leaseErr != nil {
+			log.Errorf(ctx, "Failed to initialize leader election: %v", leaseErr)
+			return fmt.Errorf("failed to initialize leader election: %w", leaseErr)
+		}
+		elector, err = leader.NewElector(lecfg, leaseClient, log)
+		if err != nil {
+			log.Errorf(ctx, "Failed to initialize leader election: %v", err)
+			return fmt.Errorf("failed to initialize leader election: %w", err)
+		}
+		log.Infof(ctx, "Using leader election lease=%s identity=%s", elector.Lease(), elector.Identity())
+	}
+	leading := func() bool { return elector == nil || elector.IsLeader() }
+
+	if sh := cfg.Shard; sh != nil {
+		log.Infof(ctx, "Processing resources of shard shard=%s", sh)
+	}
 
 	// Initialize readiness checker with dependency checks.
 	// Checks are evaluated on each /readyz request.
@@ -315,28 +528,149 @@ func runServe(
 		}
 		return pub.Health(ctx)
 	})
-	readiness.SetReady(true)
 
 	// Setup graceful shutdown
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	// Initialize sentinel
-	s, err := sentinel.NewSentinel(cfg, hyperfleetClient, decisionEngine, pub, log)
-	if err != nil {
-		return fmt.Errorf("failed to initialize sentinel: %w", err)
+	// Initialize one sentinel per watcher
+	watchers := make(watcherSet, 0, len(watcherCfgs))
+	for i, wcfg := range watcherCfgs {
+		regionCfgs := regionConfigs(wcfg)
+		regions := make([]sentinel.Region, 0, len(regionCfgs))
+		for _, rc := range regionCfgs {
+			regions = append(regions, sentinel.Region{Client: clients[rc.BaseURL], Name: rc.Name, Topic: rc.Topic})
+		}
+
+		decider := policyDecider
+		if decider == nil {
+			decisionEngine, err := engine.NewDecisionEngine(wcfg.MessageDecision)
+			if err != nil {
+				log.Errorf(ctx, "Failed to create decision engine: %v", err)
+				return fmt.Errorf("failed to create decision engine: %w", err)
+			}
+			decider = decisionEngine
+		}
+
+		s, err := sentinel.NewMultiRegionSentinel(wcfg, regions, decider, pub, log)
+		if err != nil {
+			return fmt.Errorf("failed to initialize sentinel: %w", err)
+		}
+		w := watcher{sentinel: s, cfg: wcfg}
+		if len(cfg.Watchers) > 0 {
+			w.name = cfg.Watchers[i].ID()
+			log.Infof(ctx, "Initialized watcher name=%s resource_type=%s resource_selector=%s topic=%s",
+				w.name, wcfg.ResourceType, metrics.GetResourceSelectorLabel(wcfg.ResourceSelector), regions[0].Topic)
+		}
+		watchers = append(watchers, w)
+	}
+
+	if dsCfg := cfg.DecisionStream; dsCfg != nil {
+		ds, dsErr := decisionstream.Listen(dsCfg.SocketPath, dsCfg.BufferSize, log)
+		if dsErr != nil {
+			log.Errorf(ctx, "Failed to start decision stream: %v", dsErr)
+			return fmt.Errorf("failed to start decision stream: %w", dsErr)
+		}
+		for _, w := range watchers {
+			w.sentinel.SetDecisionStream(ds)
+		}
+		go func() {
+			log.Infof(ctx, "Starting decision stream on %s", ds.Path())
+			if err := ds.Serve(ctx); err != nil {
+				log.Errorf(ctx, "Decision stream error: %v", err)
+			}
+		}()
+	}
+
+	// The state store is shared by all watchers; records are keyed by
+	// resource type.
+	if ssCfg := cfg.StateStore; ssCfg != nil {
+		store, ssErr := state.New(ssCfg)
+		if ssErr != nil {
+			log.Errorf(ctx, "Failed to initialize state store: %v", ssErr)
+			return fmt.Errorf("failed to initialize state store: %w", ssErr)
+		}
+		defer func() {
+			if closeErr := store.Close(); closeErr != nil {
+				log.Errorf(ctx, "Error closing state store: %v", closeErr)
+			}
+		}()
+		for _, w := range watchers {
+			w.sentinel.SetStateStore(store)
+		}
+		log.Infof(ctx, "Using state store type=%s", ssCfg.StoreType())
+	}
+
+	// The outbox is shared by all watchers, each spooling to its own queue.
+	// A dry run never fails to publish, and replaying into it would discard
+	// the spooled events, so it does not use the outbox.
+	if cfg.Clients.Broker != nil && cfg.Clients.Broker.Outbox != nil && !cfg.DryRun {
+		obCfg := cfg.Clients.Broker.Outbox
+		ob, obErr := outbox.New(obCfg)
+		if obErr != nil {
+			log.Errorf(ctx, "Failed to initialize outbox: %v", obErr)
+			return fmt.Errorf("failed to initialize outbox: %w", obErr)
+		}
+		defer func() {
+			if closeErr := ob.Close(); closeErr != nil {
+				log.Errorf(ctx, "Error closing outbox: %v", closeErr)
+			}
+		}()
+		for _, w := range watchers {
+			w.sentinel.SetOutbox(ob)
+		}
+		log.Infof(ctx, "Using outbox path=%s", obCfg.Path)
 	}
 
+	if cfg.Clients.HyperFleetAPI.CircuitBreaker != nil {
+		readiness.AddCheck("hyperfleet_api", func() error {
+			if watchers.circuitState() == client.CircuitOpen {
+				return client.ErrCircuitOpen
+			}
+			return nil
+		})
+	}
+
+	readiness.AddCheck("broker_auth", func() error {
+		return watchers.joinErrors((*sentinel.Sentinel).BrokerAuthError)
+	})
+	if cfg.Clients.Broker != nil && cfg.Clients.Broker.Backpressure != nil {
+		readiness.AddCheck("broker_backpressure", func() error {
+			return watchers.joinErrors((*sentinel.Sentinel).BrokerBackpressureError)
+		})
+	}
+	if cfg.Clients.Broker != nil && cfg.Clients.Broker.ProbeTopics {
+		// A failed probe does not stop startup: the topic may be created
+		// later. Readiness fails until the topic accepts an event; failed
+		// topics are probed again on every poll cycle.
+		for _, w := range watchers {
+			if probeErr := w.sentinel.ProbeTopics(ctx); probeErr != nil {
+				log.Errorf(ctx, "Broker topic probe failed: %v", probeErr)
+			}
+		}
+		readiness.AddCheck("broker_topics", func() error {
+			return watchers.joinErrors((*sentinel.Sentinel).TopicError)
+		})
+	}
 	readiness.AddCheck("sentinel_poll", func() error {
-		if s.LastSuccessfulPoll().IsZero() {
+		if leading() && watchers.lastSuccessfulPoll().IsZero() {
 			return fmt.Errorf("no successful poll completed yet")
 		}
 		return nil
 	})
+	readiness.SetReady(true)
+
+	// A follower does not poll, so it reports no poll to the liveness check.
+	lastSuccessfulPoll := func() time.Time {
+		if !leading() {
+			return time.Time{}
+		}
+		return watchers.lastSuccessfulPoll()
+	}
 
 	// Health server on port 8080 (/healthz, /readyz)
 	healthMux := http.NewServeMux()
-	healthMux.HandleFunc("/healthz", readiness.HealthzHandler(s.LastSuccessfulPoll, 3*cfg.PollInterval))
+	healthMux.HandleFunc("/healthz", readiness.HealthzHandler(lastSuccessfulPoll, 3*cfg.MaxPollInterval()))
 	healthMux.HandleFunc("/readyz", readiness.ReadyzHandler())
 
 	healthServer := &http.Server{
@@ -374,17 +708,83 @@ func runServe(
 		}
 	}()
 
+	// Admin server on loopback (POST /drain, /resource-types), used by the
+	// drain-shard, pause, and resume commands, the GET /ui status page and
+	// POST /debug/decisions
+	drainCh := make(chan drainRequest, 1)
+	var adminServer *http.Server
+	if adminBindAddress != "" {
+		adminMux := http.NewServeMux()
+		adminMux.HandleFunc("POST "+drainPath, newDrainHandler(drainCh))
+		registerPauseHandlers(ctx, adminMux, watchers)
+		adminMux.HandleFunc("POST "+explainPath, newExplainHandler(watchers))
+		// The status page shows the first watcher; with watchers, each one
+		// also has its own page at /ui/{name}.
+		adminMux.Handle("GET "+statusui.Path, statusui.Handler(watchers[0].cfg, watchers[0].sentinel.Status))
+		for _, w := range watchers {
+			if w.name != "" {
+				adminMux.Handle("GET "+statusui.Path+"/"+w.name, statusui.Handler(w.cfg, w.sentinel.Status))
+			}
+		}
+
+		adminServer = &http.Server{
+			Addr:         adminBindAddress,
+			Handler:      adminMux,
+			ReadTimeout:  5 * time.Second,
+			WriteTimeout: 60 * time.Second,
+			IdleTimeout:  120 * time.Second,
+		}
+
+		go func() {
+			log.Infof(ctx, "Starting admin server on %s", adminBindAddress)
+			if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Errorf(ctx, "Admin server error: %v", err)
+			}
+		}()
+	}
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go handleReloads(ctx, log, load, cfg, policyDecider, watchers)
+
+	// pendingDrain carries an accepted drain request to the main goroutine
+	// once the sentinel loop has been asked to stop.
+	pendingDrain := make(chan drainRequest, 1)
+	serversStopped := make(chan struct{})
+	// loopsStopped is closed once the sentinel loops have returned.
+	loopsStopped := make(chan struct{})
 
 	go func() {
-		<-sigChan
-		log.Info(ctx, "Received shutdown signal")
+		defer close(serversStopped)
+
+		select {
+		case <-sigChan:
+			log.Info(ctx, "Received shutdown signal")
+		case req := <-drainCh:
+			log.Info(ctx, "Received drain request, stopping publishing for this shard")
+			pendingDrain <- req
+		}
 		// Set readiness to false so /readyz returns 503 during shutdown
 		readiness.SetReady(false)
+
+		// Let the poll cycles in progress finish their publishes instead of
+		// abandoning resources that were fetched but not published yet. A
+		// follower has nothing in flight.
+		if cfg.DrainTimeout > 0 && leading() {
+			log.Infof(ctx, "Draining sentinel loops drain_timeout=%s", cfg.DrainTimeout)
+			watchers.stop()
+			select {
+			case <-loopsStopped:
+				log.Info(ctx, "Sentinel loops drained")
+			case <-time.After(cfg.DrainTimeout):
+				log.Warnf(ctx, "Drain timed out, canceling the poll cycles in progress drain_timeout=%s",
+					cfg.DrainTimeout)
+			}
+		}
 		cancel()
 
-		// Shutdown HTTP servers (20s timeout per graceful-shutdown standard)
+		// Shutdown HTTP servers (20s timeout per graceful-shutdown standard).
+		// The admin server waits for an in-flight drain response to be written.
 		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 20*time.Second)
 		defer shutdownCancel()
 		if err := healthServer.Shutdown(shutdownCtx); err != nil {
@@ -393,29 +793,214 @@ func runServe(
 		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
 			log.Errorf(shutdownCtx, "Metrics server shutdown error: %v", err)
 		}
+		if adminServer != nil {
+			if err := adminServer.Shutdown(shutdownCtx); err != nil {
+				log.Errorf(shutdownCtx, "Admin server shutdown error: %v", err)
+			}
+		}
 	}()
 
 	// Start sentinel
-	log.Info(ctx, "Starting sentinel loop")
-	if err := s.Start(ctx); err != nil && err != context.Canceled {
+	log.Infof(ctx, "Starting sentinel loops watchers=%d", len(watchers))
+	// ledAtShutdown reports whether the loops were running when ctx was
+	// canceled, i.e. whether this replica was publishing for the shard.
+	ledAtShutdown := elector == nil
+	if elector == nil {
+		err = watchers.start(ctx)
+	} else {
+		err = elector.Run(ctx, func(leadCtx context.Context) error {
+			startErr := watchers.start(leadCtx)
+			// The loops return with the lease still held only when drained.
+			ledAtShutdown = ctx.Err() != nil || leadCtx.Err() == nil
+			return startErr
+		})
+	}
+	close(loopsStopped)
+	if err != nil {
 		return fmt.Errorf("sentinel failed: %w", err)
 	}
 
+	drained := false
+	select {
+	case req := <-pendingDrain:
+		// The loops have stopped, so no further reconcile events are
+		// published for these selectors. Announce the handoff before the
+		// publisher closes; a follower published nothing to hand off.
+		var err error
+		if ledAtShutdown {
+			handoffCtx, handoffCancel := context.WithTimeout(context.Background(), 10*time.Second)
+			err = watchers.joinErrors(func(s *sentinel.Sentinel) error { return s.PublishHandoff(handoffCtx) })
+			handoffCancel()
+		}
+		if err != nil {
+			log.Extra("error", err).Error(ctx, "Failed to publish handoff event")
+		}
+		req.done <- err
+		<-serversStopped
+		drained = true
+	default:
+	}
+
+	// The loop has stopped, so the summary and the pushed metrics include
+	// the last poll cycle.
+	for _, w := range watchers {
+		w.sentinel.LogSummary(ctx)
+	}
+	if pushCfg := cfg.MetricsPush; pushCfg != nil {
+		if err := metrics.Push(context.Background(), pushCfg, registry, cfg.Sentinel.Name); err != nil {
+			log.Extra("error", err).Error(ctx, "Failed to push final metrics")
+		} else {
+			log.Infof(ctx, "Pushed final metrics to %s", pushCfg.RedactedURL())
+		}
+	}
+
+	if drained {
+		log.Info(ctx, "Sentinel drained")
+		return nil
+	}
 	log.Info(ctx, "Sentinel stopped gracefully")
 	return nil
 }
 
-// runConfigDump loads the full sentinel configuration and prints it as YAML to stdout.
-func runConfigDump(configFile string, flags *pflag.FlagSet) error {
-	cfg, err := config.LoadConfig(configFile, flags)
+// regionConfigs returns the API endpoints polled with cfg: the configured
+// regions, or a single one for base_url publishing to clients.broker.topic.
+func regionConfigs(cfg *config.SentinelConfig) []config.HyperFleetAPIRegionConfig {
+	if regions := cfg.Clients.HyperFleetAPI.Regions; len(regions) > 0 {
+		return regions
+	}
+	topic := ""
+	if cfg.Clients.Broker != nil {
+		topic = cfg.Clients.Broker.Topic
+	}
+	return []config.HyperFleetAPIRegionConfig{{BaseURL: cfg.Clients.HyperFleetAPI.BaseURL, Topic: topic}}
+}
+
+// newBrokerPublisher creates a broker library publisher configured by
+// configMap, or by broker.yaml when it is nil. Since the broker library
+// cannot set Pub/Sub ordering keys or AMQP properties, Google Pub/Sub with
+// message ordering enabled is published to by a publisher.PubSubPublisher
+// instead, and RabbitMQ with clients.broker.message_options by a
+// publisher.AMQPPublisher. The broker library
+// ignores the context of a publish, so each publish is bounded by
+// clients.broker.publish_timeout so that a hung broker respects cycle
+// deadlines and shutdown; transient failures are retried as configured by
+// clients.broker.retry. With fipsMode, a broker connection without TLS is an
+// error.
+func newBrokerPublisher(
+	ctx context.Context, log logger.HyperFleetLogger, brokerMetrics *broker.MetricsRecorder,
+	b *config.BrokerConfig, configMap map[string]string, fipsMode bool,
+) (publisher.Publisher, error) {
+	if fipsMode {
+		if err := publisher.CheckFIPSBrokerConfig(configMap); err != nil {
+			return nil, err
+		}
+	}
+	pubsubCfg, err := publisher.PubSubBrokerConfig(configMap)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	var amqpCfg *publisher.AMQPConfig
+	if b != nil && len(b.MessageOptions) > 0 {
+		if amqpCfg, err = publisher.RabbitMQConfig(configMap); err != nil {
+			return nil, err
+		}
+	}
+	var brokerPub publisher.Publisher
+	switch {
+	case pubsubCfg != nil && pubsubCfg.EnableMessageOrdering:
+		brokerPub, err = publisher.NewPubSubPublisher(pubsubCfg, log, brokerMetrics)
+		if err == nil {
+			log.Infof(ctx, "Publishing to Google Pub/Sub with ordering keys project_id=%s", pubsubCfg.ProjectID)
+		}
+	case amqpCfg != nil:
+		brokerPub, err = publisher.NewAMQPPublisher(amqpCfg, log, brokerMetrics)
+		if err == nil {
+			log.Info(ctx, "Publishing to RabbitMQ with message_options as AMQP properties and headers")
+		}
+	default:
+		brokerPub, err = broker.NewPublisher(log, brokerMetrics, configMap)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var publishTimeout time.Duration
+	if b != nil {
+		publishTimeout = b.PublishTimeout
+	}
+	var pub publisher.Publisher = publisher.NewTimeoutPublisher(brokerPub, publishTimeout)
+	if b != nil && b.Retry != nil {
+		pub = publisher.NewRetryPublisher(pub, b.Retry)
+		log.Infof(ctx, "Retrying transient publish failures max_attempts=%d", b.Retry.MaxAttempts)
+	}
+	return pub, nil
+}
+
+// newFanOutPublisher creates the publishers of the clients.broker.fan_out
+// targets and returns a publisher that publishes to them and to primary. On
+// error, the targets already created are closed; primary is left to the
+// caller.
+func newFanOutPublisher(
+	ctx context.Context, log logger.HyperFleetLogger, brokerMetrics *broker.MetricsRecorder,
+	b *config.BrokerConfig, primary publisher.Publisher, fipsMode bool, resourceType, resourceSelector string,
+) (publisher.Publisher, error) {
+	targets := []publisher.FanOutTarget{{Name: config.PrimaryTargetName, Publisher: primary}}
+	closeTargets := func() {
+		for _, t := range targets[1:] {
+			_ = t.Publisher.Close()
+		}
+	}
+	for _, tc := range b.FanOut {
+		var (
+			pub publisher.Publisher
+			err error
+		)
+		if tc.Webhook != nil {
+			pub, err = publisher.NewWebhookPublisher(tc.Webhook, brokerMetrics)
+		} else {
+			var configMap map[string]string
+			configMap, err = publisher.LoadBrokerConfigFile(tc.ConfigFile)
+			if err == nil {
+				pub, err = newBrokerPublisher(ctx, log, brokerMetrics, b, configMap, fipsMode)
+			}
+		}
+		if err != nil {
+			closeTargets()
+			return nil, fmt.Errorf("target %s: %w", tc.Name, err)
+		}
+		targets = append(targets, publisher.FanOutTarget{Name: tc.Name, Publisher: pub, Optional: tc.Optional})
+		log.Infof(ctx, "Initialized fan-out target target=%s broker_type=%s optional=%t",
+			tc.Name, pub.BrokerType(), tc.Optional)
 	}
 
-	data, err := yaml.Marshal(cfg.RedactedCopy())
+	pub, err := publisher.NewFanOutPublisher(targets, resourceType, resourceSelector, log)
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		closeTargets()
+		return nil, err
 	}
-	fmt.Print(string(data))
-	return nil
+	return pub, nil
+}
+
+// retryStrategy builds the client retry strategy for a retry config. Unset
+// intervals keep the client defaults.
+func retryStrategy(cfg *config.HyperFleetAPIRetryConfig) client.RetryStrategy {
+	initial, maxInterval := cfg.InitialInterval, cfg.MaxInterval
+	if initial == 0 {
+		initial = client.DefaultInitialInterval
+	}
+	if maxInterval == 0 {
+		maxInterval = client.DefaultMaxInterval
+	}
+	switch cfg.Strategy {
+	case "constant":
+		return client.ConstantRetry(initial)
+	case "decorrelated_jitter":
+		return client.DecorrelatedJitterRetry(initial, maxInterval)
+	default:
+		return client.ExponentialRetry(initial, maxInterval, client.DefaultMultiplier, client.DefaultRandomizationFactor)
+	}
+}
+
+// runConfigDump loads the full sentinel configuration and prints it as YAML to stdout.
+func runConfigDump(configFile string, flags *pflag.FlagSet) error {
+	return printEffectiveConfig(configFile, flags, formatYAML)
 }
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/sentinel"
+)
+
+const resourceTypesPath = "/resource-types"
+
+// resourceTypeStatus is the JSON representation of a watched resource type on
+// the admin server.
+type resourceTypeStatus struct {
+	ResourceType string `json:"resource_type"`
+	Error        string `json:"error,omitempty"`
+	Paused       bool   `json:"paused"`
+}
+
+// registerPauseHandlers adds the resource type endpoints to the admin server:
+//
+//	GET  /resource-types                 lists the watched resource types
+//	POST /resource-types/{type}/pause    pauses publishing for a resource type
+//	POST /resource-types/{type}/resume   resumes publishing for a resource type
+//
+// Pausing is per resource type so that the Sentinel watching another type
+// keeps publishing. Pausing a type pauses every watcher of that type, and a
+// type is listed as paused while all of them are. Types not watched are
+// answered with 404.
+func registerPauseHandlers(ctx context.Context, mux *http.ServeMux, watchers watcherSet) {
+	var resourceTypes []string
+	byType := make(map[string][]*sentinel.Sentinel)
+	for _, w := range watchers {
+		rt := w.cfg.ResourceType
+		if _, ok := byType[rt]; !ok {
+			resourceTypes = append(resourceTypes, rt)
+		}
+		byType[rt] = append(byType[rt], w.sentinel)
+	}
+	paused := func(rt string) bool {
+		for _, s := range byType[rt] {
+			if !s.Paused() {
+				return false
+			}
+		}
+		return true
+	}
+
+	mux.HandleFunc("GET "+resourceTypesPath, func(w http.ResponseWriter, r *http.Request) {
+		statuses := make([]resourceTypeStatus, 0, len(resourceTypes))
+		for _, rt := range resourceTypes {
+			statuses = append(statuses, resourceTypeStatus{ResourceType: rt, Paused: paused(rt)})
+		}
+		writeAdminJSON(w, http.StatusOK, statuses)
+	})
+
+	setPaused := func(pause bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			rt := r.PathValue("type")
+			sentinels, ok := byType[rt]
+			if !ok {
+				writeAdminJSON(w, http.StatusNotFound, resourceTypeStatus{
+					ResourceType: rt,
+					Error: fmt.Sprintf("resource type not watched by this sentinel (watching %s)",
+						strings.Join(resourceTypes, ", ")),
+				})
+				return
+			}
+			for _, s := range sentinels {
+				s.SetPaused(ctx, pause)
+			}
+			writeAdminJSON(w, http.StatusOK, resourceTypeStatus{ResourceType: rt, Paused: paused(rt)})
+		}
+	}
+	mux.HandleFunc("POST "+resourceTypesPath+"/{type}/pause", setPaused(true))
+	mux.HandleFunc("POST "+resourceTypesPath+"/{type}/resume", setPaused(false))
+}
+
+func writeAdminJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v) //nolint:errcheck // client may have gone away; nothing left to do
+}
+
+func newPauseCommand() *cobra.Command {
+	return newSetPausedCommand(true)
+}
+
+func newResumeCommand() *cobra.Command {
+	return newSetPausedCommand(false)
+}
+
+func newSetPausedCommand(paused bool) *cobra.Command {
+	var (
+		adminAddress string
+		timeout      time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pause <resource-type>",
+		Short: "Pause publishing for a resource type on a running Sentinel",
+		Long: `Ask the Sentinel serving on --admin-address to stop publishing events for
+the given resource type. The Sentinel keeps polling and evaluating resources,
+so metrics and health checks stay current; resources that would have been
+published are skipped with reason "paused". Sentinels watching other resource
+types are not affected.
+
+The pause lasts until "sentinel resume" is run or the Sentinel restarts. Set
+paused: true in the configuration to keep a resource type paused across
+restarts.
+
+Run it inside the Sentinel pod, for example:
+  kubectl exec deploy/nodepools-sentinel -- sentinel pause nodepools`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSetPaused(cmd.Context(), adminAddress, args[0], paused, timeout)
+		},
+	}
+	if !paused {
+		cmd.Use = "resume <resource-type>"
+		cmd.Short = "Resume publishing for a paused resource type on a running Sentinel"
+		cmd.Long = `Ask the Sentinel serving on --admin-address to resume publishing events for
+the given resource type. Resources that still need reconciliation are published
+on the next poll cycle.
+
+Run it inside the Sentinel pod, for example:
+  kubectl exec deploy/nodepools-sentinel -- sentinel resume nodepools`
+	}
+
+	cmd.Flags().StringVar(&adminAddress, "admin-address", defaultAdminBindAddress,
+		"Admin server address of the Sentinel")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Second, "How long to wait for the Sentinel to respond")
+
+	return cmd
+}
+
+// runSetPaused pauses or resumes resourceType through the admin server.
+func runSetPaused(ctx context.Context, adminAddress, resourceType string, paused bool, timeout time.Duration) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	action := "resume"
+	if paused {
+		action = "pause"
+	}
+	url := fmt.Sprintf("http://%s%s/%s/%s", adminAddress, resourceTypesPath, resourceType, action)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build %s request: %w", action, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach sentinel admin server at %s: %w", adminAddress, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // read-only response body
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", action, err)
+	}
+
+	var status resourceTypeStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return fmt.Errorf("unexpected %s response (status %d): %s", action, resp.StatusCode, string(body))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s failed: %s", action, status.Error)
+	}
+
+	state := "resumed"
+	if status.Paused {
+		state = "paused"
+	}
+	fmt.Printf("Publishing for %s %s on sentinel at %s\n", status.ResourceType, state, adminAddress)
+	return nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/sentinel"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+// configLoader loads the configuration again from the sources the serve
+// command started with.
+type configLoader func() (*config.SentinelConfig, error)
+
+// handleReloads reloads the configuration on SIGHUP until ctx is canceled.
+func handleReloads(
+	ctx context.Context, log logger.HyperFleetLogger, load configLoader, startup *config.SentinelConfig,
+	policyDecider engine.Decider, watchers watcherSet,
+) {
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	defer signal.Stop(hupChan)
+
+	rt := watchers[0].cfg.ResourceType
+	rs := metrics.GetResourceSelectorLabel(watchers[0].cfg.ResourceSelector)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hupChan:
+		}
+		log.Info(ctx, "Received SIGHUP, reloading configuration")
+		if err := reloadWatchers(load, startup, policyDecider, watchers); err != nil {
+			metrics.UpdateConfigReloadsMetric(rt, rs, "failure")
+			log.Extra("error", err).Error(ctx, "Rejected configuration reload, keeping the current configuration")
+			continue
+		}
+		metrics.UpdateConfigReloadsMetric(rt, rs, "success")
+	}
+}
+
+// reloadWatchers loads the configuration and hands the reloadable settings of
+// each watcher to its Sentinel. Either all watchers are reloaded or, if the new
+// configuration is invalid or changes a setting that requires a restart, none.
+func reloadWatchers(
+	load configLoader, startup *config.SentinelConfig, policyDecider engine.Decider, watchers watcherSet,
+) error {
+	cfg, err := load()
+	if err != nil {
+		return err
+	}
+	watcherCfgs := cfg.WatcherConfigs()
+	if len(watcherCfgs) != len(watchers) {
+		return fmt.Errorf("the number of watchers changed from %d to %d, which requires a restart",
+			len(watchers), len(watcherCfgs))
+	}
+	if len(cfg.Watchers) != len(startup.Watchers) {
+		return errors.New("switching between watchers and a top-level resource_type requires a restart")
+	}
+
+	reloads := make([]*sentinel.Reload, len(watchers))
+	for i, w := range watchers {
+		wcfg := watcherCfgs[i]
+		if len(cfg.Watchers) > 0 && cfg.Watchers[i].ID() != w.name {
+			return fmt.Errorf("watcher %s was renamed to %s, which requires a restart", w.name, cfg.Watchers[i].ID())
+		}
+		if err := checkRestartRequired(w.cfg, wcfg); err != nil {
+			return watcherError(w.name, err)
+		}
+
+		// A decision policy is not reloaded; message_decision engines are.
+		// A message_decision is not used with a decision policy, so a change
+		// to it would be counted as reloaded without taking effect.
+		var decider engine.Decider
+		if policyDecider != nil && !reflect.DeepEqual(wcfg.MessageDecision, w.cfg.MessageDecision) {
+			return watcherError(w.name, errors.New("message_decision changed, but it is not used while "+
+				"decision_policy is set"))
+		}
+		if policyDecider == nil {
+			decider, err = engine.NewDecisionEngine(wcfg.MessageDecision)
+			if err != nil {
+				return watcherError(w.name, fmt.Errorf("failed to create decision engine: %w", err))
+			}
+		}
+		reloads[i], err = w.sentinel.PrepareReload(wcfg, decider)
+		if err != nil {
+			return watcherError(w.name, err)
+		}
+	}
+	for i, w := range watchers {
+		w.sentinel.Reload(reloads[i])
+	}
+	return nil
+}
+
+// checkRestartRequired returns an error if next differs from cur in a setting
+// other than those a Sentinel reloads.
+func checkRestartRequired(cur, next *config.SentinelConfig) error {
+	a, err := restartRequiredSettings(cur)
+	if err != nil {
+		return err
+	}
+	b, err := restartRequiredSettings(next)
+	if err != nil {
+		return err
+	}
+	if a != b {
+		return errors.New("settings other than poll_interval, resource_selector, message_decision, " +
+			"and message_data changed, which requires a restart")
+	}
+	return nil
+}
+
+// restartRequiredSettings renders the settings of cfg that are not reloaded.
+func restartRequiredSettings(cfg *config.SentinelConfig) (string, error) {
+	c := *cfg
+	c.PollInterval = 0
+	c.ResourceSelector = nil
+	c.MessageDecision = nil
+	c.MessageData = nil
+	out, err := yaml.Marshal(&c)
+	if err != nil {
+		return "", fmt.Errorf("failed to compare configurations: %w", err)
+	}
+	return string(out), nil
+}
+
+func watcherError(name string, err error) error {
+	if name == "" {
+		return err
+	}
+	return fmt.Errorf("watcher %s: %w", name, err)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/publisher"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/sentinel"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+func newReloadTestConfig() *config.SentinelConfig {
+	cfg := config.NewSentinelConfig()
+	cfg.ResourceType = "clusters"
+	cfg.MessageDecision = config.DefaultMessageDecision()
+	cfg.Clients.HyperFleetAPI.BaseURL = "http://hyperfleet-api:8000"
+	cfg.Clients.Broker.Topic = "clusters"
+	return cfg
+}
+
+func TestReloadWatchers_DecisionPolicy(t *testing.T) {
+	startup := newReloadTestConfig()
+	startup.DecisionPolicy = &config.DecisionPolicyConfig{URL: "http://opa:8181", Path: "sentinel/publish"}
+	policyDecider, err := engine.NewPolicyDecider(startup.DecisionPolicy)
+	if err != nil {
+		t.Fatalf("NewPolicyDecider failed: %v", err)
+	}
+	s, err := sentinel.NewSentinel(startup, &clienttest.Fetcher{}, policyDecider, publisher.NewMockPublisher(),
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	watchers := watcherSet{{sentinel: s, cfg: startup}}
+
+	tests := []struct {
+		modify  func(cfg *config.SentinelConfig)
+		name    string
+		wantErr bool
+	}{
+		{
+			name:   "poll_interval changed",
+			modify: func(cfg *config.SentinelConfig) { cfg.PollInterval = time.Minute },
+		},
+		{
+			name: "message_decision changed",
+			modify: func(cfg *config.SentinelConfig) {
+				cfg.MessageDecision.Result = "is_new_resource"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			load := func() (*config.SentinelConfig, error) {
+				cfg := newReloadTestConfig()
+				cfg.DecisionPolicy = &config.DecisionPolicyConfig{URL: "http://opa:8181", Path: "sentinel/publish"}
+				tt.modify(cfg)
+				return cfg, nil
+			}
+			err := reloadWatchers(load, startup, policyDecider, watchers)
+			if tt.wantErr && err == nil {
+				t.Error("Expected the reload to be rejected")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/sentinel"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+// errInvalidConfig is returned by the validate command for an invalid
+// configuration, after the report was printed.
+var errInvalidConfig = errors.New("configuration is invalid")
+
+// validationReport is the output of the validate command.
+type validationReport struct {
+	// Errors are the errors of loading and validating the configuration.
+	// Errors of a watcher are reported with the watcher.
+	Errors   []string        `json:"errors" yaml:"errors"`
+	Watchers []watcherReport `json:"watchers" yaml:"watchers"`
+	Valid    bool            `json:"valid" yaml:"valid"`
+}
+
+// watcherReport is the validation result of one watcher.
+type watcherReport struct {
+	Errors           []string `json:"errors" yaml:"errors"`
+	Topics           []string `json:"topics" yaml:"topics"`
+	Name             string   `json:"name,omitempty" yaml:"name,omitempty"`
+	ResourceType     string   `json:"resource_type" yaml:"resource_type"`
+	ResourceSelector string   `json:"resource_selector" yaml:"resource_selector"`
+}
+
+func newValidateCommand() *cobra.Command {
+	var configFile, format string
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the sentinel configuration",
+		Long: `Load the sentinel configuration from config file, environment variables,
+and CLI flags, and check it as the serve command does at startup: the config
+schema, resource selectors, broker settings and topic routing, the
+message_decision and message_data CEL expressions, and the message_schema.
+The HyperFleet API and the broker are not contacted.
+
+Prints a report with the errors of the configuration and of each watcher to
+stdout. Exits with code 0 if the configuration is valid and 1 otherwise, so
+that CI pipelines can gate configuration changes.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runValidate(configFile, cmd.Flags(), format)
+		},
+	}
+
+	cmd.Flags().StringVarP(&configFile, "config", "c", "", "Path to configuration file (YAML)")
+	cmd.Flags().StringVar(&format, "format", formatYAML, "Output format: yaml, json")
+	addConfigOverrideFlags(cmd)
+
+	return cmd
+}
+
+// runValidate validates the sentinel configuration and prints the report to
+// stdout in the given format. It returns errInvalidConfig if the
+// configuration is invalid.
+func runValidate(configFile string, flags *pflag.FlagSet, format string) error {
+	if format != formatYAML && format != formatJSON {
+		return fmt.Errorf("unsupported format %q, must be %s or %s", format, formatYAML, formatJSON)
+	}
+
+	// Keep stdout parseable: configuration loading logs go to stderr.
+	logCfg := logger.DefaultConfig()
+	logCfg.Output = os.Stderr
+	logger.SetGlobalConfig(logCfg)
+
+	report := validateConfig(configFile, flags, logger.NewHyperFleetLoggerWithConfig(logCfg))
+
+	data, err := yaml.Marshal(report)
+	if err == nil && format == formatJSON {
+		data, err = yamlToJSON(data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	fmt.Print(string(data))
+
+	if !report.Valid {
+		return errInvalidConfig
+	}
+	return nil
+}
+
+// validateConfig loads the configuration and checks each watcher.
+func validateConfig(configFile string, flags *pflag.FlagSet, log logger.HyperFleetLogger) *validationReport {
+	report := &validationReport{Errors: []string{}, Watchers: []watcherReport{}}
+	cfg, err := config.LoadConfig(configFile, flags)
+	if err != nil {
+		report.Errors = errorMessages(err)
+		return report
+	}
+	if cfg.DecisionPolicy != nil {
+		if _, err := engine.NewPolicyDecider(cfg.DecisionPolicy); err != nil {
+			report.Errors = errorMessages(fmt.Errorf("failed to create decision policy: %w", err))
+		}
+	}
+
+	report.Valid = len(report.Errors) == 0
+	for i, wcfg := range cfg.WatcherConfigs() {
+		w := watcherReport{
+			ResourceType:     wcfg.ResourceType,
+			ResourceSelector: metrics.GetResourceSelectorLabel(wcfg.ResourceSelector),
+			Errors:           []string{},
+			Topics:           []string{},
+		}
+		if len(cfg.Watchers) > 0 {
+			w.Name = cfg.Watchers[i].ID()
+		}
+		for _, rc := range regionConfigs(wcfg) {
+			if rc.Topic != "" {
+				w.Topics = append(w.Topics, wcfg.Clients.Broker.ResolveTopic("", rc.Topic))
+			}
+		}
+
+		var errs []error
+		if cfg.DecisionPolicy == nil {
+			if _, err := engine.NewDecisionEngine(wcfg.MessageDecision); err != nil {
+				errs = append(errs, fmt.Errorf("failed to create decision engine: %w", err))
+			}
+		}
+		errs = append(errs, sentinel.CheckConfig(wcfg, log))
+		if err := errors.Join(errs...); err != nil {
+			w.Errors = errorMessages(err)
+			report.Valid = false
+		}
+		report.Watchers = append(report.Watchers, w)
+	}
+	return report
+}
+
+// errorMessages returns the messages of the errors joined in err, or the
+// message of err itself.
+func errorMessages(err error) []string {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []string{err.Error()}
+	}
+	var msgs []string
+	for _, e := range joined.Unwrap() {
+		msgs = append(msgs, errorMessages(e)...)
+	}
+	return msgs
+}
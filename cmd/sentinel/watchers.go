@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/sentinel"
+)
+
+// watcher is one poll loop of the serve command: the top-level resource type,
+// or one entry of watchers. All watchers share the API clients and the
+// publisher.
+type watcher struct {
+	sentinel *sentinel.Sentinel
+	cfg      *config.SentinelConfig
+	// name identifies the watcher on the admin server. It is empty without
+	// watchers.
+	name string
+}
+
+// watcherSet is the watchers of the serve command, in configuration order.
+type watcherSet []watcher
+
+// find returns the watcher with the given name. The name may be empty when
+// there is a single watcher.
+func (ws watcherSet) find(name string) (*watcher, error) {
+	if name == "" && len(ws) == 1 {
+		return &ws[0], nil
+	}
+	for i := range ws {
+		if name != "" && ws[i].name == name {
+			return &ws[i], nil
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("a watcher is required when running %d watchers", len(ws))
+	}
+	return nil, fmt.Errorf("unknown watcher %q", name)
+}
+
+// start runs every watcher's loop until ctx is done or stop is called. A loop
+// that fails, such as one that exhausted max_consecutive_failures, cancels
+// the others, so that the process exits and is restarted.
+func (ws watcherSet) start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	errs := make([]error, len(ws))
+	for i := range ws {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := ws[i].sentinel.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				errs[i] = err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// stop asks every watcher's loop to return after its poll cycle in progress.
+func (ws watcherSet) stop() {
+	for i := range ws {
+		ws[i].sentinel.Stop()
+	}
+}
+
+// lastSuccessfulPoll returns the oldest last successful poll across the
+// watchers, or zero while any watcher has not polled successfully yet, so
+// that a stuck watcher fails the liveness check.
+func (ws watcherSet) lastSuccessfulPoll() time.Time {
+	var oldest time.Time
+	for i := range ws {
+		last := ws[i].sentinel.LastSuccessfulPoll()
+		if last.IsZero() {
+			return time.Time{}
+		}
+		if oldest.IsZero() || last.Before(oldest) {
+			oldest = last
+		}
+	}
+	return oldest
+}
+
+// circuitState reports the most severe API circuit breaker state across the
+// watchers.
+func (ws watcherSet) circuitState() client.CircuitState {
+	state := client.CircuitClosed
+	for i := range ws {
+		switch ws[i].sentinel.CircuitState() {
+		case client.CircuitOpen:
+			return client.CircuitOpen
+		case client.CircuitHalfOpen:
+			state = client.CircuitHalfOpen
+		default:
+		}
+	}
+	return state
+}
+
+// joinErrors joins the errors reported by check for each watcher.
+func (ws watcherSet) joinErrors(check func(*sentinel.Sentinel) error) error {
+	errs := make([]error, 0, len(ws))
+	for i := range ws {
+		if err := check(ws[i].sentinel); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
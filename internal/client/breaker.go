@@ -0,0 +1,130 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	apierrors "github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/errors"
+)
+
+// ErrCircuitOpen is returned by FetchResources while the circuit breaker is
+// open and the cooldown period has not yet elapsed.
+var ErrCircuitOpen = errors.New("circuit breaker open: skipping HyperFleet API call")
+
+// CircuitState is the state of the HyperFleet API circuit breaker.
+// The numeric values are exported as the api_circuit_breaker_state metric.
+type CircuitState int
+
+const (
+	// CircuitClosed allows all fetches through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects fetches until the cooldown elapses.
+	CircuitOpen
+	// CircuitHalfOpen allows a single trial fetch after the cooldown.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// circuitBreaker opens after threshold consecutive failed fetches and rejects
+// further fetches for cooldown. Once the cooldown elapses the next fetch is
+// let through as a trial, and other fetches are rejected until it completes:
+// a retriable failure re-opens the circuit, any other outcome closes it.
+//
+// Only retriable failures (5xx, 429, timeouts, network errors) count towards
+// the threshold; client errors such as 4xx or an unreadable token indicate a
+// misconfiguration rather than an unavailable API.
+type circuitBreaker struct {
+	openedAt      time.Time
+	now           func() time.Time
+	mu            sync.Mutex
+	cooldown      time.Duration
+	threshold     int
+	failures      int
+	state         CircuitState
+	trialInFlight bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow returns ErrCircuitOpen if a fetch must be skipped. It moves an open
+// circuit to half-open once the cooldown has elapsed and lets the caller
+// through as the trial; while the trial is in flight, other callers are
+// rejected.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = CircuitHalfOpen
+	case CircuitHalfOpen:
+		if b.trialInFlight {
+			return ErrCircuitOpen
+		}
+	default:
+		return nil
+	}
+	b.trialInFlight = true
+	return nil
+}
+
+// record updates the breaker with the outcome of a fetch. In the half-open
+// state the outcome settles the trial.
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.trialInFlight = false
+		if err != nil && apierrors.IsRetriable(err) {
+			b.state = CircuitOpen
+			b.openedAt = b.now()
+			return
+		}
+		b.failures = 0
+		b.state = CircuitClosed
+		return
+	}
+	if err == nil {
+		b.failures = 0
+		b.state = CircuitClosed
+		return
+	}
+	if !apierrors.IsRetriable(err) {
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.state = CircuitOpen
+		b.openedAt = b.now()
+	}
+}
+
+func (b *circuitBreaker) currentState() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
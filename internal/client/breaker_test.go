@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var (
+	errUnavailable = &APIError{StatusCode: http.StatusServiceUnavailable, Message: "unavailable", Retriable: true}
+	errBadRequest  = &APIError{StatusCode: http.StatusBadRequest, Message: "bad request", Retriable: false}
+)
+
+// newTestBreaker returns a breaker whose clock is controlled by the returned pointer.
+func newTestBreaker(threshold int, cooldown time.Duration) (*circuitBreaker, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(threshold, cooldown)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		b.record(errUnavailable)
+		if b.currentState() != CircuitClosed {
+			t.Fatalf("expected closed after %d failures, got %s", i+1, b.currentState())
+		}
+	}
+	b.record(errUnavailable)
+	if b.currentState() != CircuitOpen {
+		t.Fatalf("expected open after threshold, got %s", b.currentState())
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	b, _ := newTestBreaker(2, time.Minute)
+
+	b.record(errUnavailable)
+	b.record(nil)
+	b.record(errUnavailable)
+	if b.currentState() != CircuitClosed {
+		t.Errorf("expected closed, failures are not consecutive; got %s", b.currentState())
+	}
+}
+
+func TestCircuitBreaker_IgnoresNonRetriableErrors(t *testing.T) {
+	b, _ := newTestBreaker(1, time.Minute)
+
+	b.record(errBadRequest)
+	b.record(&TokenError{cause: errors.New("missing")})
+	if b.currentState() != CircuitClosed {
+		t.Errorf("expected non-retriable errors to leave the circuit closed, got %s", b.currentState())
+	}
+}
+
+func TestCircuitBreaker_HalfOpenTrial(t *testing.T) {
+	tests := []struct {
+		trialErr  error
+		name      string
+		wantState CircuitState
+	}{
+		{name: "trial succeeds", trialErr: nil, wantState: CircuitClosed},
+		{name: "trial fails", trialErr: errUnavailable, wantState: CircuitOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, now := newTestBreaker(3, time.Minute)
+			for i := 0; i < 3; i++ {
+				b.record(errUnavailable)
+			}
+
+			*now = now.Add(59 * time.Second)
+			if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("expected ErrCircuitOpen before cooldown, got %v", err)
+			}
+
+			*now = now.Add(time.Second)
+			if err := b.allow(); err != nil {
+				t.Fatalf("expected trial fetch to be allowed after cooldown, got %v", err)
+			}
+			if b.currentState() != CircuitHalfOpen {
+				t.Fatalf("expected half-open, got %s", b.currentState())
+			}
+
+			b.record(tt.trialErr)
+			if b.currentState() != tt.wantState {
+				t.Errorf("expected %s after trial, got %s", tt.wantState, b.currentState())
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker_HalfOpenAllowsSingleTrial(t *testing.T) {
+	b, now := newTestBreaker(1, time.Minute)
+	b.record(errUnavailable)
+	*now = now.Add(time.Minute)
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if b.allow() == nil {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := allowed.Load(); n != 1 {
+		t.Fatalf("expected a single trial fetch to be allowed, got %d", n)
+	}
+
+	b.record(nil)
+	if err := b.allow(); err != nil {
+		t.Errorf("expected fetches to be allowed after the trial closed the circuit, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_NonRetriableTrialClosesCircuit(t *testing.T) {
+	b, now := newTestBreaker(1, time.Minute)
+	b.record(errUnavailable)
+	*now = now.Add(time.Minute)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected trial fetch to be allowed after cooldown, got %v", err)
+	}
+	b.record(errBadRequest)
+	if b.currentState() != CircuitClosed {
+		t.Fatalf("expected a non-retriable trial result to close the circuit, got %s", b.currentState())
+	}
+	if err := b.allow(); err != nil {
+		t.Errorf("expected fetches to be allowed after the trial, got %v", err)
+	}
+}
+
+func TestGetResource_BypassesCircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c, err := NewHyperFleetClient(server.URL, 5*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithCircuitBreaker(1, time.Hour))
+	if err != nil {
+		t.Fatalf("NewHyperFleetClient: %v", err)
+	}
+	c.breaker.record(errUnavailable)
+
+	if _, err := c.GetResource(context.Background(), "clusters", "cluster-1"); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected GetResource to bypass the open circuit, got %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 API request, got %d", n)
+	}
+	if c.CircuitState() != CircuitOpen {
+		t.Errorf("expected GetResource to leave the circuit open, got %s", c.CircuitState())
+	}
+}
+
+func TestFetchResources_CircuitOpenSkipsAPI(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := NewHyperFleetClient(server.URL, 5*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithCircuitBreaker(1, time.Hour))
+	if err != nil {
+		t.Fatalf("NewHyperFleetClient: %v", err)
+	}
+	c.breaker.record(errUnavailable)
+
+	_, _, err = c.FetchResources(context.Background(), "clusters", nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("expected no API requests while circuit is open, got %d", n)
+	}
+	if c.CircuitState() != CircuitOpen {
+		t.Errorf("expected CircuitState open, got %s", c.CircuitState())
+	}
+}
+
+func TestCircuitState_NoBreaker(t *testing.T) {
+	c := newTestClient(t, "http://localhost", 5*time.Second)
+	if c.CircuitState() != CircuitClosed {
+		t.Errorf("expected closed without breaker, got %s", c.CircuitState())
+	}
+}
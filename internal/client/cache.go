@@ -0,0 +1,52 @@
+package client
+
+import (
+	"sync"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/api/openapi"
+)
+
+// pageCacheEntry holds the HTTP validators and decoded items of a previously
+// fetched list page.
+type pageCacheEntry struct {
+	etag         string
+	lastModified string
+	items        []openapi.Resource
+	total        int64
+}
+
+// pageCache remembers list pages by request URL so that the next poll can send
+// a conditional request (If-None-Match / If-Modified-Since) and reuse the
+// decoded page on 304 Not Modified. It lives only in memory and is empty after
+// a restart, so the Sentinel stays stateless.
+type pageCache struct {
+	entries map[string]*pageCacheEntry
+	mu      sync.Mutex
+}
+
+func newPageCache() *pageCache {
+	return &pageCache{entries: make(map[string]*pageCacheEntry)}
+}
+
+func (pc *pageCache) get(url string) *pageCacheEntry {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return pc.entries[url]
+}
+
+// put stores a page if the response carried at least one validator; pages
+// without validators cannot be revalidated and are dropped from the cache.
+func (pc *pageCache) put(url, etag, lastModified string, items []openapi.Resource, total int64) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if etag == "" && lastModified == "" {
+		delete(pc.entries, url)
+		return
+	}
+	pc.entries[url] = &pageCacheEntry{
+		etag:         etag,
+		lastModified: lastModified,
+		items:        items,
+		total:        total,
+	}
+}
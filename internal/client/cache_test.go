@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchResources_ConditionalGET(t *testing.T) {
+	const etag = `"v1"`
+	const lastModified = "Wed, 01 Jan 2025 10:00:00 GMT"
+
+	tests := []struct {
+		isNotChanged func(r *http.Request) bool
+		name         string
+		etag         string
+		lastModified string
+	}{
+		{
+			name:         "etag",
+			etag:         etag,
+			isNotChanged: func(r *http.Request) bool { return r.Header.Get("If-None-Match") == etag },
+		},
+		{
+			name:         "last-modified",
+			lastModified: lastModified,
+			isNotChanged: func(r *http.Request) bool { return r.Header.Get("If-Modified-Since") == lastModified },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var notModified atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.isNotChanged(r) {
+					notModified.Add(1)
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				if tt.etag != "" {
+					w.Header().Set("ETag", tt.etag)
+				}
+				if tt.lastModified != "" {
+					w.Header().Set("Last-Modified", tt.lastModified)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				list := createMockResourceList([]map[string]interface{}{createMockResource("cluster-1", "Cluster")}, 1, 1)
+				if err := json.NewEncoder(w).Encode(list); err != nil {
+					t.Errorf("failed to encode response: %v", err)
+				}
+			}))
+			defer server.Close()
+
+			c := newTestClient(t, server.URL, 5*time.Second)
+
+			first, _, err := c.FetchResources(context.Background(), "clusters", nil)
+			if err != nil {
+				t.Fatalf("first fetch: %v", err)
+			}
+			second, _, err := c.FetchResources(context.Background(), "clusters", nil)
+			if err != nil {
+				t.Fatalf("second fetch: %v", err)
+			}
+
+			if notModified.Load() != 1 {
+				t.Errorf("expected second fetch to be answered with 304, got %d not-modified responses",
+					notModified.Load())
+			}
+			if len(second) != 1 || second[0].ID != first[0].ID {
+				t.Errorf("expected cached resources to be reused, got %+v", second)
+			}
+		})
+	}
+}
+
+func TestFetchResources_NoValidatorsNoConditionalRequest(t *testing.T) {
+	var conditional atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+			conditional.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(createMockResourceList(nil, 1, 0)); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, 5*time.Second)
+	for i := 0; i < 2; i++ {
+		if _, _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
+			t.Fatalf("fetch %d: %v", i, err)
+		}
+	}
+	if conditional.Load() != 0 {
+		t.Errorf("expected no conditional requests without validators, got %d", conditional.Load())
+	}
+}
+
+func TestFetchResources_UnexpectedNotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, 5*time.Second)
+	if _, _, err := c.FetchResources(context.Background(), "clusters", nil); err == nil {
+		t.Fatal("expected error for 304 on an unconditional request, got nil")
+	}
+}
@@ -2,21 +2,24 @@ package client
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
 	"github.com/cenkalti/backoff/v5"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/api/openapi"
+	apierrors "github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/errors"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/time/rate"
 )
 
 // Retry configuration constants
@@ -37,14 +40,125 @@ const (
 	DefaultPageSize int32 = 20
 )
 
+// requestIDHeader carries the operation ID of the poll cycle that sent a request.
+const requestIDHeader = "X-Request-ID"
+
+// ResourceFetcher is the read access to the HyperFleet API that the Sentinel
+// needs. HyperFleetClient implements it; tests can substitute an in-memory
+// implementation such as clienttest.Fetcher instead of an httptest server.
+type ResourceFetcher interface {
+	// FetchResources lists the resources matching labelSelector and the
+	// optional TSL filters, with the pagination metadata of the list.
+	FetchResources(
+		ctx context.Context, resourceType string, labelSelector map[string]string, additionalFilters ...string,
+	) ([]Resource, ListMeta, error)
+	// FetchResourcesUpdatedAfter lists the resources matching labelSelector
+	// whose updated_time is later than updatedAfter.
+	FetchResourcesUpdatedAfter(
+		ctx context.Context, resourceType string, labelSelector map[string]string, updatedAfter time.Time,
+	) ([]Resource, ListMeta, error)
+	// GetResource fetches a single resource by ID.
+	GetResource(ctx context.Context, resourceType, id string) (*Resource, error)
+	// CircuitState reports the state of the API circuit breaker.
+	CircuitState() CircuitState
+}
+
+var _ ResourceFetcher = (*HyperFleetClient)(nil)
+
+// ListMeta is the pagination metadata of a list request.
+type ListMeta struct {
+	// Total is the number of matching resources reported by the API in the
+	// last page fetched.
+	Total int64
+	// Fetched is the number of resources returned.
+	Fetched int64
+	// Pages is the number of pages fetched.
+	Pages int32
+	// PageSize is the page size requested.
+	PageSize int32
+}
+
+// Truncated reports whether the API reported more matching resources than
+// were fetched, which happens when a page comes back empty before the total
+// is reached, for example because of a server-side limit.
+func (m ListMeta) Truncated() bool {
+	return m.Fetched < m.Total
+}
+
 // HyperFleetClient wraps the HTTP client for the HyperFleet API
 type HyperFleetClient struct {
-	httpClient  *http.Client
-	log         logger.HyperFleetLogger
-	tokenSource *fileTokenSource
-	baseURL     string
-	userAgent   string
-	pageSize    int32
+	httpClient      *http.Client
+	log             logger.HyperFleetLogger
+	tokenSource     *fileTokenSource
+	breaker         *circuitBreaker
+	limiter         *rate.Limiter
+	pages           *pageCache
+	endpoints       map[string]ResourceEndpoint
+	retryStrategy   RetryStrategy
+	baseURL         string
+	userAgent       string
+	apiVersion      string
+	maxRetryTime    time.Duration
+	pageConcurrency int
+	typeConcurrency int
+	maxItems        int
+	maxBodyBytes    int64
+	pageSize        int32
+	streaming       bool
+	writes          bool
+}
+
+// Option configures optional HyperFleetClient behavior.
+type Option func(*clientOptions)
+
+// clientOptions collects the settings applied by Option values.
+type clientOptions struct {
+	tlsConfig               *tls.Config
+	transport               *TransportSettings
+	endpoints               map[string]ResourceEndpoint
+	retryStrategy           RetryStrategy
+	metricsResourceType     string
+	metricsResourceSelector string
+	apiVersion              string
+	breakerCooldown         time.Duration
+	maxRetryTime            time.Duration
+	rateLimitQPS            float64
+	breakerThreshold        int
+	rateLimitBurst          int
+	pageConcurrency         int
+	typeConcurrency         int
+	maxItems                int
+	maxBodyBytes            int64
+	streamingDecode         bool
+	writes                  bool
+}
+
+// WithTLSConfig sets the TLS configuration used for HTTPS connections to the API,
+// e.g. a custom CA bundle or a client certificate for mutual TLS.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(o *clientOptions) {
+		o.tlsConfig = cfg
+	}
+}
+
+// WithCircuitBreaker enables a circuit breaker around FetchResources. After
+// threshold consecutive failed fetches, calls return ErrCircuitOpen without
+// contacting the API until cooldown has elapsed. A threshold <= 0 disables it.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(o *clientOptions) {
+		o.breakerThreshold = threshold
+		o.breakerCooldown = cooldown
+	}
+}
+
+// WithRateLimit limits requests to the API with a token bucket that refills at
+// qps tokens per second and holds up to burst tokens. Every HTTP request,
+// including each page and each retry, takes one token. A qps <= 0 disables it.
+func WithRateLimit(qps float64, burst int) Option {
+	return func(o *clientOptions) {
+		o.rateLimitQPS = qps
+		o.rateLimitBurst = burst
+	}
 }
 
 // NewHyperFleetClient creates a new HyperFleet API client.
@@ -54,7 +168,7 @@ type HyperFleetClient struct {
 // the token is cached before the file is re-read; 0 disables caching and re-reads the file on every request.
 func NewHyperFleetClient(
 	endpoint string, timeout time.Duration, sentinelName, version string, pageSize int32,
-	tokenPath string, tokenCacheTTL time.Duration,
+	tokenPath string, tokenCacheTTL time.Duration, opts ...Option,
 ) (*HyperFleetClient, error) {
 	u, err := url.ParseRequestURI(endpoint)
 	if err != nil {
@@ -70,9 +184,36 @@ func NewHyperFleetClient(
 		return nil, fmt.Errorf("failed to create client: endpoint must not contain a query string")
 	}
 
+	o := clientOptions{apiVersion: DefaultAPIVersion}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := CheckAPIVersion(o.apiVersion); err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	transport := http.DefaultTransport
+	if o.tlsConfig != nil || o.transport != nil {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		if o.tlsConfig != nil {
+			t.TLSClientConfig = o.tlsConfig
+		}
+		if o.transport != nil {
+			o.transport.apply(t)
+		}
+		transport = t
+	}
+	if o.metricsResourceType != "" {
+		transport = &metricsTransport{
+			next:             transport,
+			resourceType:     o.metricsResourceType,
+			resourceSelector: o.metricsResourceSelector,
+		}
+	}
+
 	httpClient := &http.Client{
 		Timeout:   timeout,
-		Transport: otelhttp.NewTransport(http.DefaultTransport),
+		Transport: otelhttp.NewTransport(transport),
 	}
 
 	var ts *fileTokenSource
@@ -80,13 +221,49 @@ func NewHyperFleetClient(
 		ts = newFileTokenSource(tokenPath, tokenCacheTTL)
 	}
 
+	var cb *circuitBreaker
+	if o.breakerThreshold > 0 {
+		cb = newCircuitBreaker(o.breakerThreshold, o.breakerCooldown)
+	}
+
+	var limiter *rate.Limiter
+	if o.rateLimitQPS > 0 {
+		limiter = rate.NewLimiter(rate.Limit(o.rateLimitQPS), max(o.rateLimitBurst, 1))
+	}
+
+	typeConcurrency := o.typeConcurrency
+	if typeConcurrency <= 0 {
+		typeConcurrency = DefaultTypeConcurrency
+	}
+	retryStrategy := o.retryStrategy
+	if retryStrategy == nil {
+		retryStrategy = DefaultRetryStrategy()
+	}
+	maxRetryTime := o.maxRetryTime
+	if maxRetryTime <= 0 {
+		maxRetryTime = DefaultMaxElapsedTime
+	}
+
 	return &HyperFleetClient{
-		httpClient:  httpClient,
-		baseURL:     strings.TrimRight(endpoint, "/"),
-		userAgent:   fmt.Sprintf("hyperfleet-sentinel/%s (%s)", version, sentinelName),
-		log:         logger.NewHyperFleetLogger(),
-		pageSize:    pageSize,
-		tokenSource: ts,
+		httpClient:      httpClient,
+		baseURL:         strings.TrimRight(endpoint, "/"),
+		userAgent:       fmt.Sprintf("hyperfleet-sentinel/%s (%s)", version, sentinelName),
+		log:             logger.NewHyperFleetLogger(),
+		pageSize:        pageSize,
+		tokenSource:     ts,
+		breaker:         cb,
+		limiter:         limiter,
+		pages:           newPageCache(),
+		endpoints:       o.endpoints,
+		retryStrategy:   retryStrategy,
+		maxRetryTime:    maxRetryTime,
+		apiVersion:      o.apiVersion,
+		streaming:       o.streamingDecode,
+		pageConcurrency: o.pageConcurrency,
+		typeConcurrency: typeConcurrency,
+		maxBodyBytes:    o.maxBodyBytes,
+		maxItems:        o.maxItems,
+		writes:          o.writes,
 	}, nil
 }
 
@@ -110,6 +287,7 @@ type Resource struct {
 	Href            string                       `json:"href"`
 	Kind            string                       `json:"kind"`
 	Name            string                       `json:"name"`
+	Region          string                       `json:"region,omitempty"` // set by multi-region Sentinels
 	Status          ResourceStatus               `json:"status"`
 	Generation      int32                        `json:"generation"`
 }
@@ -208,6 +386,10 @@ func (r *Resource) ToMap() map[string]interface{} {
 		m["metadata"] = r.Metadata
 	}
 
+	if r.Region != "" {
+		m["region"] = r.Region
+	}
+
 	return m
 }
 
@@ -241,6 +423,13 @@ func validateResourceType(resourceType string) error {
 //   - Automatically retries on transient failures (5xx, timeouts, network errors)
 //   - Does NOT retry on client errors (4xx) as they are not retriable
 //
+// Rate limiting (when enabled with WithRateLimit):
+//   - Every page request and every retry waits for a token from the shared limiter
+//
+// Circuit breaker (when enabled with WithCircuitBreaker):
+//   - Returns ErrCircuitOpen immediately while the circuit is open
+//   - Each fetch that still fails after retries counts as one failure
+//
 // Graceful degradation:
 //   - Resources with nil status are logged and skipped
 //   - This maintains service availability during resource provisioning/deletion
@@ -250,51 +439,243 @@ func validateResourceType(resourceType string) error {
 // (e.g., "status.conditions.Reconciled='False'") that are combined with label
 // selectors using "and" to form the final search query.
 //
-// Returns a slice of resources and an error if the fetch operation fails.
+// Label keys may contain only alphanumerics, '-', '_', '.' and '/', and label
+// values must not contain control characters; other selectors are rejected
+// before any request is made.
+//
+// Returns the resources, the pagination metadata of the list, and an error
+// if the fetch operation fails.
 func (c *HyperFleetClient) FetchResources(
 	ctx context.Context,
 	resourceType string,
 	labelSelector map[string]string,
 	additionalFilters ...string,
-) ([]Resource, error) {
+) ([]Resource, ListMeta, error) {
+	if err := validateLabelSelector(labelSelector); err != nil {
+		return nil, ListMeta{}, err
+	}
+	return c.fetchWithRetry(ctx, resourceType, buildSearchString(labelSelector, additionalFilters), true)
+}
+
+// FetchResourcesUpdatedAfter fetches the resources matching labelSelector whose
+// updated_time is later than updatedAfter. It adds an updated_time condition to
+// the search query and otherwise behaves like FetchResources, except that its
+// pages bypass the conditional request cache: every incremental query has a
+// different URL, so caching them would only grow the cache.
+func (c *HyperFleetClient) FetchResourcesUpdatedAfter(
+	ctx context.Context,
+	resourceType string,
+	labelSelector map[string]string,
+	updatedAfter time.Time,
+) ([]Resource, ListMeta, error) {
+	if err := validateLabelSelector(labelSelector); err != nil {
+		return nil, ListMeta{}, err
+	}
+	searchParam := buildSearchString(labelSelector, []string{updatedAfterFilter(updatedAfter)})
+	return c.fetchWithRetry(ctx, resourceType, searchParam, false)
+}
+
+// updatedAfterFilter returns the TSL condition selecting resources updated
+// after t, e.g. "updated_time>'2026-01-02T15:04:05Z'".
+func updatedAfterFilter(t time.Time) string {
+	return fmt.Sprintf("updated_time>'%s'", t.UTC().Format(time.RFC3339Nano))
+}
+
+// OwnerFilter returns the TSL condition selecting the resources owned by the
+// resource with ID ownerID, e.g. "owner_references.id='abc'", for
+// FetchResources.
+func OwnerFilter(ownerID string) string {
+	return fmt.Sprintf("owner_references.id='%s'", strings.ReplaceAll(ownerID, "'", "''"))
+}
+
+// fetchWithRetry fetches every page of a list query with retries and circuit
+// breaker accounting. cachePages enables conditional requests for the pages.
+func (c *HyperFleetClient) fetchWithRetry(
+	ctx context.Context,
+	resourceType string,
+	searchParam string,
+	cachePages bool,
+) ([]Resource, ListMeta, error) {
 	if ctx == nil {
-		return nil, fmt.Errorf("context cannot be nil")
+		return nil, ListMeta{}, fmt.Errorf("context cannot be nil")
 	}
 
-	if err := validateResourceType(resourceType); err != nil {
-		return nil, err
+	if _, _, err := c.collectionPath(resourceType); err != nil {
+		return nil, ListMeta{}, err
 	}
 
-	b := backoff.NewExponentialBackOff()
-	b.InitialInterval = DefaultInitialInterval
-	b.MaxInterval = DefaultMaxInterval
-	b.Multiplier = DefaultMultiplier
-	b.RandomizationFactor = DefaultRandomizationFactor
+	if c.breaker != nil {
+		if err := c.breaker.allow(); err != nil {
+			return nil, ListMeta{}, err
+		}
+	}
 
+	attempts := 0
+	var lastErr error
+	var meta ListMeta
 	operation := func() ([]Resource, error) {
-		resources, err := c.fetchResourcesOnce(ctx, resourceType, labelSelector, additionalFilters)
+		attempts++
+		resources, m, err := c.fetchResources(withAttempt(ctx, attempts), resourceType, searchParam, cachePages)
+		lastErr = err
 		if err != nil {
-			if isRetriable(err) {
+			if apierrors.IsRetriable(err) {
 				c.log.Debugf(ctx, "Retriable error fetching %s: %v (will retry)", resourceType, err)
-				return nil, err
+				return nil, honorRetryAfter(err)
 			}
 			c.log.Debugf(ctx, "Non-retriable error fetching %s: %v (will not retry)", resourceType, err)
 			return nil, backoff.Permanent(err)
 		}
+		meta = m
 		return resources, nil
 	}
 
 	resources, err := backoff.Retry(
 		ctx,
 		operation,
-		backoff.WithBackOff(b),
-		backoff.WithMaxElapsedTime(DefaultMaxElapsedTime),
+		c.retryOptions()...,
+	)
+	if c.breaker != nil {
+		c.breaker.record(err)
+	}
+	if err != nil {
+		err = withLastAttempt(err, lastErr, attempts)
+		return nil, ListMeta{}, fmt.Errorf("failed to fetch %s: %w", resourceType, err)
+	}
+
+	return resources, meta, nil
+}
+
+// GetResource fetches a single resource by ID from GET /api/hyperfleet/{version}/{resourceType}/{id}.
+//
+// It uses the same retry and rate limit behavior as FetchResources. It
+// bypasses the circuit breaker: a single-resource lookup, such as one by the
+// explain endpoint, is neither skipped by an open circuit nor counted towards
+// opening it. A missing resource returns an error for which IsNotFound
+// reports true.
+func (c *HyperFleetClient) GetResource(ctx context.Context, resourceType, id string) (*Resource, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context cannot be nil")
+	}
+	if _, _, err := c.collectionPath(resourceType); err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	attempts := 0
+	var lastErr error
+	operation := func() (*Resource, error) {
+		attempts++
+		resource, err := c.getResourceOnce(withAttempt(ctx, attempts), resourceType, id)
+		lastErr = err
+		if err != nil {
+			if apierrors.IsRetriable(err) {
+				c.log.Debugf(ctx, "Retriable error getting %s/%s: %v (will retry)", resourceType, id, err)
+				return nil, honorRetryAfter(err)
+			}
+			return nil, backoff.Permanent(err)
+		}
+		return resource, nil
+	}
+
+	resource, err := backoff.Retry(
+		ctx,
+		operation,
+		c.retryOptions()...,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch %s after retries: %w", resourceType, err)
+		err = withLastAttempt(err, lastErr, attempts)
+		return nil, fmt.Errorf("failed to get %s/%s: %w", resourceType, id, err)
+	}
+
+	return resource, nil
+}
+
+func (c *HyperFleetClient) getResourceOnce(
+	ctx context.Context, resourceType, id string,
+) (resource *Resource, err error) {
+	path, ep, err := c.collectionPath(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	reqURL := fmt.Sprintf("%s%s/%s", c.baseURL, path, url.PathEscape(id))
+	defer func() { c.annotateRequest(err, http.MethodGet, reqURL) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, &APIError{StatusCode: 0, Message: fmt.Sprintf("failed to create request: %v", err), Retriable: false}
+	}
+	c.setRequestHeaders(req)
+	if authErr := c.setAuthHeader(req); authErr != nil {
+		return nil, &APIError{StatusCode: 0, Message: authErr.Error(), Retriable: false, Cause: authErr}
+	}
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, wrapNetworkError(err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			c.log.Debugf(ctx, "failed to close response body: %v", closeErr)
+		}
+	}()
+
+	if httpErr := checkHTTPStatus(resp); httpErr != nil {
+		return nil, httpErr
 	}
 
-	return resources, nil
+	body, err := io.ReadAll(c.limitBody(resp.Body))
+	if err != nil {
+		return nil, c.bodyError("read", err)
+	}
+
+	item, err := decodeResource(body, ep)
+	if err != nil {
+		return nil, &APIError{StatusCode: 0, Message: fmt.Sprintf("failed to decode response: %v", err), Retriable: false}
+	}
+
+	converted := convertResource(item)
+	return &converted, nil
+}
+
+// IsNotFound reports whether err was caused by a 404 Not Found API response.
+func IsNotFound(err error) bool {
+	return apierrors.IsNotFound(err)
+}
+
+// CircuitState reports the current state of the circuit breaker. It always
+// returns CircuitClosed when no breaker is configured.
+func (c *HyperFleetClient) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.currentState()
+}
+
+// waitForRateLimit blocks until the rate limiter grants a request token. It
+// returns a non-retriable *APIError if ctx ends first.
+func (c *HyperFleetClient) waitForRateLimit(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+	if err := c.limiter.Wait(ctx); err != nil {
+		return &APIError{StatusCode: 0, Message: fmt.Sprintf("rate limiter: %v", err), Retriable: false, Cause: err}
+	}
+	return nil
+}
+
+// setRequestHeaders sets the User-Agent header and, when the request context
+// carries an operation ID, the X-Request-ID header, so that API server logs
+// can be correlated with the Sentinel poll cycle that sent the request.
+func (c *HyperFleetClient) setRequestHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", c.userAgent)
+	if opID := logger.GetOpID(req.Context()); opID != "" {
+		req.Header.Set(requestIDHeader, opID)
+	}
 }
 
 // setAuthHeader attaches the Authorization header to req if a token source is configured.
@@ -313,24 +694,28 @@ func (c *HyperFleetClient) setAuthHeader(req *http.Request) error {
 
 // VerifyConnectivity checks the client connectivity by calling the API for the given resource type
 func (c *HyperFleetClient) VerifyConnectivity(ctx context.Context, resourceType string) error {
-	if err := validateResourceType(resourceType); err != nil {
+	path, _, err := c.collectionPath(resourceType)
+	if err != nil {
 		return fmt.Errorf("could not verify connectivity: %w", err)
 	}
 
 	search := labelSelectorToSearchString(map[string]string{"non_existing_label": "value"})
 	size := int32(1)
 
-	reqURL := fmt.Sprintf("%s/api/hyperfleet/v1/%s?search=%s&size=%d",
-		c.baseURL, resourceType, url.QueryEscape(search), size)
+	reqURL := fmt.Sprintf("%s%s?search=%s&size=%d",
+		c.baseURL, path, url.QueryEscape(search), size)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return fmt.Errorf("could not verify connectivity: %w", err)
 	}
-	req.Header.Set("User-Agent", c.userAgent)
+	c.setRequestHeaders(req)
 	if authErr := c.setAuthHeader(req); authErr != nil {
 		return fmt.Errorf("bearer token unavailable: %w", authErr)
 	}
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return fmt.Errorf("could not verify connectivity: %w", err)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -348,6 +733,25 @@ func (c *HyperFleetClient) VerifyConnectivity(ctx context.Context, resourceType
 	return fmt.Errorf("could not verify connectivity: response status code %d", resp.StatusCode)
 }
 
+// validateLabelSelector rejects label selectors that cannot be expressed
+// safely in a TSL search. Keys are written unquoted, so they are limited to
+// label key characters; values are quoted, but control characters in them are
+// never meaningful.
+func validateLabelSelector(labelSelector map[string]string) error {
+	for k, v := range labelSelector {
+		if k == "" || strings.ContainsFunc(k, func(r rune) bool {
+			return !(r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))) &&
+				r != '-' && r != '_' && r != '.' && r != '/'
+		}) {
+			return fmt.Errorf("invalid label selector: label key %q contains characters not allowed in a label key", k)
+		}
+		if strings.ContainsFunc(v, unicode.IsControl) {
+			return fmt.Errorf("invalid label selector: value for label %q contains a control character", k)
+		}
+	}
+	return nil
+}
+
 // labelSelectorToSearchString converts a label selector map to TSL (Tree Search Language) search parameter string
 // Format: "labels.key1='value1' and labels.key2='value2'"
 // TSL syntax requires:
@@ -388,26 +792,20 @@ func buildSearchString(labelSelector map[string]string, additionalFilters []stri
 	return strings.Join(parts, " and ")
 }
 
-func (c *HyperFleetClient) fetchResourcesOnce(
-	ctx context.Context,
-	resourceType string,
-	labelSelector map[string]string,
-	additionalFilters []string,
-) ([]Resource, error) {
-	searchParam := buildSearchString(labelSelector, additionalFilters)
-	return c.fetchResources(ctx, resourceType, searchParam)
-}
-
-func (c *HyperFleetClient) fetchResources(ctx context.Context, resourceType, searchParam string) ([]Resource, error) {
+func (c *HyperFleetClient) fetchResources(
+	ctx context.Context, resourceType, searchParam string, cachePages bool,
+) ([]Resource, ListMeta, error) {
 	return fetchPaginated(ctx, c, searchParam,
 		func(ctx context.Context, page, pageSize int32, search string) ([]openapi.Resource, int64, error) {
-			return c.fetchResourcesPage(ctx, resourceType, page, pageSize, search)
+			return c.fetchResourcesPage(ctx, resourceType, page, pageSize, search, cachePages)
 		},
 		convertResource, resourceType)
 }
 
 // fetchPaginated iterates through all pages of an API endpoint, collecting
-// resources until every item has been fetched.
+// resources until every item has been fetched or a page comes back empty.
+// With WithPageConcurrency, the pages after the first are fetched in
+// concurrent batches.
 func fetchPaginated[T any](
 	ctx context.Context,
 	c *HyperFleetClient,
@@ -415,15 +813,15 @@ func fetchPaginated[T any](
 	fetchPage func(ctx context.Context, page, pageSize int32, searchParam string) ([]T, int64, error),
 	convert func(T) Resource,
 	resourceLabel string,
-) ([]Resource, error) {
+) ([]Resource, ListMeta, error) {
 	var allResources []Resource
-	page := int32(1)
+	meta := ListMeta{PageSize: c.pageSize}
 
-	for {
-		items, total, err := fetchPage(ctx, page, c.pageSize, searchParam)
-		if err != nil {
-			return nil, err
-		}
+	// add appends a page and reports whether the list is complete. It fails
+	// once the list exceeds the client's item limit.
+	add := func(page int32, items []T, total int64) (bool, error) {
+		meta.Pages = page
+		meta.Total = total
 
 		if allResources == nil {
 			allResources = make([]Resource, 0, len(items))
@@ -435,31 +833,95 @@ func fetchPaginated[T any](
 
 		c.log.Debugf(ctx, "Fetched %s page=%d size=%d total=%d", resourceLabel, page, len(items), total)
 
-		if int64(len(allResources)) >= total || len(items) == 0 {
-			break
+		if err := c.checkItemLimit(int64(len(allResources)), total); err != nil {
+			return false, err
+		}
+		return int64(len(allResources)) >= total || len(items) == 0, nil
+	}
+
+	items, total, err := fetchPage(ctx, 1, c.pageSize, searchParam)
+	if err != nil {
+		return nil, ListMeta{}, err
+	}
+	done, err := add(1, items, total)
+	if err != nil {
+		return nil, ListMeta{}, err
+	}
+
+	for page := int32(2); !done; {
+		if c.pageConcurrency <= 1 {
+			items, total, err := fetchPage(ctx, page, c.pageSize, searchParam)
+			if err != nil {
+				return nil, ListMeta{}, err
+			}
+			if done, err = add(page, items, total); err != nil {
+				return nil, ListMeta{}, err
+			}
+			page++
+			continue
+		}
+
+		end := batchEnd(page, c.pageConcurrency, meta.Total, c.pageSize)
+		results, err := fetchPageBatch(ctx, page, end,
+			func(ctx context.Context, p int32) ([]T, int64, error) {
+				return fetchPage(ctx, p, c.pageSize, searchParam)
+			})
+		if err != nil {
+			return nil, ListMeta{}, err
+		}
+		for _, r := range results {
+			if done, err = add(page, r.items, r.total); err != nil {
+				return nil, ListMeta{}, err
+			}
+			if done {
+				break
+			}
+			page++
 		}
-		page++
 	}
 
-	return allResources, nil
+	meta.Fetched = int64(len(allResources))
+	return allResources, meta, nil
 }
 
 func (c *HyperFleetClient) fetchResourcesPage(
-	ctx context.Context, resourceType string, page, pageSize int32, searchParam string,
-) ([]openapi.Resource, int64, error) {
-	reqURL := fmt.Sprintf("%s/api/hyperfleet/v1/%s?page=%d&size=%d",
-		c.baseURL, resourceType, page, pageSize)
+	ctx context.Context, resourceType string, page, pageSize int32, searchParam string, cachePages bool,
+) (items []openapi.Resource, total int64, err error) {
+	path, ep, err := c.collectionPath(resourceType)
+	if err != nil {
+		return nil, 0, err
+	}
+	reqURL := fmt.Sprintf("%s%s?page=%d&size=%d",
+		c.baseURL, path, page, pageSize)
 	if searchParam != "" {
 		reqURL += "&search=" + url.QueryEscape(searchParam)
 	}
+	defer func() { c.annotateRequest(err, http.MethodGet, reqURL) }()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, 0, &APIError{StatusCode: 0, Message: fmt.Sprintf("failed to create request: %v", err), Retriable: false}
 	}
-	req.Header.Set("User-Agent", c.userAgent)
+	c.setRequestHeaders(req)
 	if authErr := c.setAuthHeader(req); authErr != nil {
-		return nil, 0, &APIError{StatusCode: 0, Message: authErr.Error(), Retriable: false, cause: authErr}
+		return nil, 0, &APIError{StatusCode: 0, Message: authErr.Error(), Retriable: false, Cause: authErr}
+	}
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	// Revalidate the previously fetched page instead of downloading it again.
+	var cached *pageCacheEntry
+	if cachePages {
+		cached = c.pages.get(reqURL)
+	}
+	if cached != nil {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
 	}
 
 	resp, err := c.httpClient.Do(req)
@@ -472,20 +934,39 @@ func (c *HyperFleetClient) fetchResourcesPage(
 		}
 	}()
 
+	if resp.StatusCode == http.StatusNotModified {
+		if cached == nil {
+			return nil, 0, &APIError{
+				StatusCode: resp.StatusCode,
+				Message:    "API returned 304 Not Modified for an unconditional request",
+				Retriable:  false,
+			}
+		}
+		c.log.Debugf(ctx, "Page not modified, reusing cached %s page=%d", resourceType, page)
+		return cached.items, cached.total, nil
+	}
+
 	if httpErr := checkHTTPStatus(resp); httpErr != nil {
 		return nil, 0, httpErr
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	var resourceList openapi.ResourceList
+	if c.streaming {
+		resourceList, err = decodeResourceListStream(c.limitBody(resp.Body), ep)
+	} else {
+		body, readErr := io.ReadAll(c.limitBody(resp.Body))
+		if readErr != nil {
+			return nil, 0, c.bodyError("read", readErr)
+		}
+		resourceList, err = decodeResourceList(body, ep)
+	}
 	if err != nil {
-		msg := fmt.Sprintf("failed to read response body: %v", err)
-		return nil, 0, &APIError{StatusCode: 0, Message: msg, Retriable: false}
+		return nil, 0, c.bodyError("decode", err)
 	}
 
-	var resourceList openapi.ResourceList
-	if err := json.Unmarshal(body, &resourceList); err != nil {
-		msg := fmt.Sprintf("failed to decode response: %v", err)
-		return nil, 0, &APIError{StatusCode: 0, Message: msg, Retriable: false}
+	if cachePages {
+		c.pages.put(reqURL, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"),
+			resourceList.Items, resourceList.Total)
 	}
 
 	return resourceList.Items, resourceList.Total, nil
@@ -577,43 +1058,99 @@ func wrapNetworkError(err error) *APIError {
 }
 
 // checkHTTPStatus validates the HTTP response status code and returns an
-// APIError for error status codes (>= 400).
+// APIError for error status codes (>= 400), keeping the start of the
+// response body as its ResponseSnippet.
 func checkHTTPStatus(resp *http.Response) error {
 	if resp != nil && resp.StatusCode >= 400 {
+		var snippet []byte
+		if resp.Body != nil {
+			//nolint:errcheck // the snippet is best-effort diagnostics
+			snippet, _ = io.ReadAll(io.LimitReader(resp.Body, apierrors.MaxResponseSnippet))
+		}
 		return &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    fmt.Sprintf("API request failed with status %d", resp.StatusCode),
-			Retriable:  isHTTPStatusRetriable(resp.StatusCode),
+			StatusCode:      resp.StatusCode,
+			Message:         fmt.Sprintf("API request failed with status %d", resp.StatusCode),
+			ResponseSnippet: strings.TrimSpace(string(snippet)),
+			RetryAfter:      responseRetryAfter(resp, time.Now()),
+			Retriable:       isHTTPStatusRetriable(resp.StatusCode),
 		}
 	}
 	return nil
 }
 
-// APIError represents an API error with retry information
-type APIError struct {
-	cause      error
-	Message    string
-	StatusCode int
-	Retriable  bool
+// responseRetryAfter returns the delay requested by the Retry-After header of
+// a 429 or 503 response, or 0 if there is none. The header holds either a
+// number of seconds or an HTTP date.
+func responseRetryAfter(resp *http.Response, now time.Time) time.Duration {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0
+	}
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// retryAfterError makes the retry loop wait for the delay requested by the
+// server instead of the next exponential interval. Its message and chain are
+// those of the wrapped error.
+type retryAfterError struct {
+	err   error
+	delay *backoff.RetryAfterError
 }
 
-func (e *APIError) Error() string {
-	if e.StatusCode > 0 {
-		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+func (e *retryAfterError) Error() string   { return e.err.Error() }
+func (e *retryAfterError) Unwrap() []error { return []error{e.err, e.delay} }
+
+// honorRetryAfter wraps a retriable error that carries a Retry-After delay so
+// that backoff.Retry waits for it.
+func honorRetryAfter(err error) error {
+	delay, ok := apierrors.RetryAfter(err)
+	if !ok {
+		return err
 	}
-	return e.Message
+	return &retryAfterError{err: err, delay: &backoff.RetryAfterError{Duration: delay}}
 }
 
-func (e *APIError) Unwrap() error { return e.cause }
+// annotateRequest records the request method and path on the *APIError in
+// err's chain, if any, unless they are already set.
+func (c *HyperFleetClient) annotateRequest(err error, method, reqURL string) {
+	apiErr, ok := apierrors.AsAPIError(err)
+	if !ok || apiErr.Path != "" {
+		return
+	}
+	apiErr.Method = method
+	apiErr.Path = strings.TrimPrefix(reqURL, c.baseURL)
+}
 
-func isRetriable(err error) bool {
-	var apiErr *APIError
-	if errors.As(err, &apiErr) {
-		return apiErr.Retriable
+// withLastAttempt stores the number of attempts made on the *APIError of the
+// last attempt. When the retry loop ended because ctx was done, err carries
+// only the context error, so the last attempt's error is appended to keep the
+// failed request visible.
+func withLastAttempt(err, lastErr error, attempts int) error {
+	if apiErr, ok := apierrors.AsAPIError(lastErr); ok {
+		apiErr.Attempts = attempts
 	}
-	return false
+	if lastErr != nil && !errors.Is(err, lastErr) {
+		return fmt.Errorf("%w; last attempt: %w", err, lastErr)
+	}
+	return err
 }
 
+// APIError represents an API error with retry information and request
+// metadata. Classify it with the helpers in pkg/errors.
+type APIError = apierrors.APIError
+
 func isHTTPStatusRetriable(statusCode int) bool {
 	if statusCode >= 500 && statusCode < 600 {
 		return true
@@ -10,12 +10,16 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	apierrors "github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
 )
 
 const (
@@ -148,7 +152,7 @@ func TestFetchResources_Success(t *testing.T) {
 	client := newTestClient(t, server.URL, 10*time.Second)
 
 	ctx := context.Background()
-	resources, err := client.FetchResources(ctx, "clusters", nil)
+	resources, _, err := client.FetchResources(ctx, "clusters", nil)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -181,7 +185,7 @@ func TestFetchResources_EmptyList(t *testing.T) {
 
 	client := newTestClient(t, server.URL, 10*time.Second)
 
-	resources, err := client.FetchResources(context.Background(), "clusters", nil)
+	resources, _, err := client.FetchResources(context.Background(), "clusters", nil)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -201,7 +205,7 @@ func TestFetchResources_404NotFound(t *testing.T) {
 
 	client := newTestClient(t, server.URL, 10*time.Second)
 
-	_, err := client.FetchResources(context.Background(), "clusters", nil)
+	_, _, err := client.FetchResources(context.Background(), "clusters", nil)
 
 	if err == nil {
 		t.Fatal("Expected error, got nil")
@@ -225,7 +229,7 @@ func TestFetchResources_500ServerError(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	_, err := client.FetchResources(ctx, "clusters", nil)
+	_, _, err := client.FetchResources(ctx, "clusters", nil)
 
 	if err == nil {
 		t.Fatal("Expected error, got nil")
@@ -234,6 +238,24 @@ func TestFetchResources_500ServerError(t *testing.T) {
 		t.Errorf("Expected at least 2 attempts due to retries, got %d", attemptCount)
 	}
 	t.Logf("Server received %d requests (initial + retries)", attemptCount)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError in chain, got %T", err)
+	}
+	if apiErr.Method != http.MethodGet || !strings.HasPrefix(apiErr.Path, "/api/hyperfleet/v1/clusters?page=1") {
+		t.Errorf("Expected request metadata GET /api/hyperfleet/v1/clusters?page=1..., got %s %s",
+			apiErr.Method, apiErr.Path)
+	}
+	if apiErr.Attempts != attemptCount {
+		t.Errorf("Expected Attempts=%d, got %d", attemptCount, apiErr.Attempts)
+	}
+	if apiErr.ResponseSnippet != `{"error": "internal server error"}` {
+		t.Errorf("Unexpected response snippet %q", apiErr.ResponseSnippet)
+	}
+	if !strings.Contains(err.Error(), "internal server error") {
+		t.Errorf("Expected response snippet in error message, got %q", err.Error())
+	}
 }
 
 func TestFetchResources_503ServiceUnavailable_ThenSuccess(t *testing.T) {
@@ -261,7 +283,7 @@ func TestFetchResources_503ServiceUnavailable_ThenSuccess(t *testing.T) {
 
 	client := newTestClient(t, server.URL, 10*time.Second)
 
-	resources, err := client.FetchResources(context.Background(), "clusters", nil)
+	resources, _, err := client.FetchResources(context.Background(), "clusters", nil)
 	if err != nil {
 		t.Fatalf("Expected no error after retry, got %v", err)
 	}
@@ -296,7 +318,7 @@ func TestFetchResources_429RateLimited(t *testing.T) {
 
 	client := newTestClient(t, server.URL, 10*time.Second)
 
-	_, err := client.FetchResources(context.Background(), "clusters", nil)
+	_, _, err := client.FetchResources(context.Background(), "clusters", nil)
 	if err != nil {
 		t.Fatalf("Expected no error after retry, got %v", err)
 	}
@@ -305,6 +327,105 @@ func TestFetchResources_429RateLimited(t *testing.T) {
 	}
 }
 
+func TestFetchResources_429RetryAfter(t *testing.T) {
+	attemptCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		if attemptCount < 2 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		response := createMockResourceList([]map[string]interface{}{}, 1, 0)
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, 10*time.Second)
+
+	start := time.Now()
+	_, _, err := client.FetchResources(context.Background(), "clusters", nil)
+	if err != nil {
+		t.Fatalf("Expected no error after retry, got %v", err)
+	}
+	// The exponential schedule would retry after about 500ms.
+	if elapsed := time.Since(start); elapsed < 1900*time.Millisecond {
+		t.Errorf("Expected the retry to wait for Retry-After (2s), took %s", elapsed)
+	}
+	if attemptCount != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attemptCount)
+	}
+}
+
+func TestFetchResources_RetryAfterBeyondMaxElapsedTime(t *testing.T) {
+	attemptCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, 10*time.Second)
+
+	start := time.Now()
+	_, _, err := client.FetchResources(context.Background(), "clusters", nil)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected to give up without waiting, took %s", elapsed)
+	}
+	if attemptCount != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attemptCount)
+	}
+	delay, ok := apierrors.RetryAfter(err)
+	if !ok || delay != 2*time.Minute {
+		t.Errorf("Expected RetryAfter 2m on the error, got %s, %v", delay, ok)
+	}
+	if !apierrors.IsRateLimited(err) {
+		t.Errorf("Expected a rate limited error, got %v", err)
+	}
+}
+
+func TestResponseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+		status int
+	}{
+		{name: "seconds", status: http.StatusTooManyRequests, header: "7", want: 7 * time.Second},
+		{
+			name:   "http date",
+			status: http.StatusServiceUnavailable,
+			header: now.Add(90 * time.Second).Format(http.TimeFormat),
+			want:   90 * time.Second,
+		},
+		{name: "date in the past", status: http.StatusTooManyRequests, header: now.Add(-time.Minute).Format(http.TimeFormat)},
+		{name: "negative", status: http.StatusTooManyRequests, header: "-1"},
+		{name: "invalid", status: http.StatusTooManyRequests, header: "soon"},
+		{name: "missing", status: http.StatusTooManyRequests},
+		{name: "other status", status: http.StatusInternalServerError, header: "7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+			if got := responseRetryAfter(resp, now); got != tt.want {
+				t.Errorf("responseRetryAfter() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFetchResources_Timeout(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(2 * time.Second)
@@ -317,7 +438,7 @@ func TestFetchResources_Timeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 
-	_, err := client.FetchResources(ctx, "clusters", nil)
+	_, _, err := client.FetchResources(ctx, "clusters", nil)
 
 	if err == nil {
 		t.Fatal("Expected timeout error, got nil")
@@ -341,7 +462,7 @@ func TestFetchResources_ContextCancellation(t *testing.T) {
 		cancel()
 	}()
 
-	_, err := client.FetchResources(ctx, "clusters", nil)
+	_, _, err := client.FetchResources(ctx, "clusters", nil)
 
 	if err == nil {
 		t.Fatal("Expected context cancellation error, got nil")
@@ -360,7 +481,7 @@ func TestFetchResources_MalformedJSON(t *testing.T) {
 
 	client := newTestClient(t, server.URL, 10*time.Second)
 
-	_, err := client.FetchResources(context.Background(), "clusters", nil)
+	_, _, err := client.FetchResources(context.Background(), "clusters", nil)
 
 	if err == nil {
 		t.Fatal("Expected error for malformed JSON, got nil")
@@ -373,7 +494,7 @@ func TestFetchResources_NilContext(t *testing.T) {
 
 	// nolint:staticcheck // Testing nil context validation
 	var nilCtx context.Context
-	_, err := client.FetchResources(nilCtx, "clusters", nil)
+	_, _, err := client.FetchResources(nilCtx, "clusters", nil)
 
 	if err == nil {
 		t.Fatal("Expected error for nil context, got nil")
@@ -386,7 +507,7 @@ func TestFetchResources_NilContext(t *testing.T) {
 func TestFetchResources_EmptyResourceType(t *testing.T) {
 	client := newTestClient(t, "http://localhost", 10*time.Second)
 
-	_, err := client.FetchResources(context.Background(), "", nil)
+	_, _, err := client.FetchResources(context.Background(), "", nil)
 
 	if err == nil {
 		t.Fatal("Expected error for empty resourceType, got nil")
@@ -418,7 +539,7 @@ func TestFetchResources_InvalidResourceType(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := client.FetchResources(context.Background(), tt.resourceType, nil)
+			_, _, err := client.FetchResources(context.Background(), tt.resourceType, nil)
 			if err == nil {
 				t.Fatalf("Expected error for resourceType %q, got nil", tt.resourceType)
 			}
@@ -444,7 +565,7 @@ func TestFetchResources_CustomResourceType(t *testing.T) {
 
 	client := newTestClient(t, server.URL, 10*time.Second)
 
-	resources, err := client.FetchResources(context.Background(), "wifconfigs", nil)
+	resources, _, err := client.FetchResources(context.Background(), "wifconfigs", nil)
 	if err != nil {
 		t.Fatalf("Expected no error for custom resource type, got %v", err)
 	}
@@ -493,7 +614,7 @@ func TestFetchResources_WithReferences(t *testing.T) {
 
 	client := newTestClient(t, server.URL, 10*time.Second)
 
-	resources, err := client.FetchResources(context.Background(), "clusters", nil)
+	resources, _, err := client.FetchResources(context.Background(), "clusters", nil)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -529,7 +650,7 @@ func TestFetchResources_WithOwnerReferences(t *testing.T) {
 
 	client := newTestClient(t, server.URL, 10*time.Second)
 
-	resources, err := client.FetchResources(context.Background(), "nodepools", nil)
+	resources, _, err := client.FetchResources(context.Background(), "nodepools", nil)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -564,7 +685,7 @@ func TestFetchResources_NilStatus(t *testing.T) {
 
 	client := newTestClient(t, server.URL, 10*time.Second)
 
-	resources, err := client.FetchResources(context.Background(), "clusters", nil)
+	resources, _, err := client.FetchResources(context.Background(), "clusters", nil)
 	if err != nil {
 		t.Fatalf("Expected no error (graceful degradation), got %v", err)
 	}
@@ -591,7 +712,7 @@ func TestFetchResources_MissingTokenFile(t *testing.T) {
 		t.Fatalf("Failed to create client: %v", err)
 	}
 
-	_, err = client.FetchResources(context.Background(), "clusters", nil)
+	_, _, err = client.FetchResources(context.Background(), "clusters", nil)
 	if err == nil {
 		t.Fatal("Expected error for missing token file, got nil")
 	}
@@ -710,7 +831,7 @@ func TestFetchResources_NodePools(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(t, server.URL, 10*time.Second)
-	resources, err := client.FetchResources(context.Background(), "nodepools", nil)
+	resources, _, err := client.FetchResources(context.Background(), "nodepools", nil)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -761,7 +882,7 @@ func TestNewHyperFleetClient_UserAgent(t *testing.T) {
 		t.Fatalf("NewHyperFleetClient: %v", err)
 	}
 
-	if _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
+	if _, _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
 		t.Fatalf("FetchResources: %v", err)
 	}
 
@@ -771,6 +892,34 @@ func TestNewHyperFleetClient_UserAgent(t *testing.T) {
 	}
 }
 
+func TestFetchResources_RequestIDFromOpID(t *testing.T) {
+	var receivedIDs []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedIDs = append(receivedIDs, r.Header.Get("X-Request-ID"))
+		response := createMockResourceList([]map[string]interface{}{}, 1, 0)
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, 10*time.Second)
+
+	ctx := logger.WithOpID(context.Background(), "op-123")
+	if _, _, err := c.FetchResources(ctx, "clusters", nil); err != nil {
+		t.Fatalf("FetchResources: %v", err)
+	}
+	if _, _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
+		t.Fatalf("FetchResources: %v", err)
+	}
+
+	if len(receivedIDs) != 2 || receivedIDs[0] != "op-123" || receivedIDs[1] != "" {
+		t.Errorf("X-Request-ID headers = %q, want [op-123, \"\"]", receivedIDs)
+	}
+}
+
 func TestFetchResources_WithLabelSelector(t *testing.T) {
 	var receivedSearchParam string
 
@@ -794,7 +943,7 @@ func TestFetchResources_WithLabelSelector(t *testing.T) {
 		testLabelEnv:    "production",
 	}
 
-	resources, err := client.FetchResources(context.Background(), "clusters", labelSelector)
+	resources, _, err := client.FetchResources(context.Background(), "clusters", labelSelector)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -956,6 +1105,70 @@ func TestVerifyConnectivity_SendsAuthHeader(t *testing.T) {
 	}
 }
 
+func TestFetchResources_InvalidLabelSelector(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	selectors := []map[string]string{
+		{"region='x' or labels.env": testLabelValue},
+		{"": testLabelValue},
+		{testLabelRegion: "us-east\r\n"},
+	}
+	for _, selector := range selectors {
+		if _, _, err := c.FetchResources(context.Background(), "clusters", selector); err == nil ||
+			!strings.Contains(err.Error(), "invalid label selector") {
+			t.Errorf("FetchResources(%q) error = %v, want invalid label selector", selector, err)
+		}
+		if _, _, err := c.FetchResourcesUpdatedAfter(context.Background(), "clusters", selector, time.Now()); err == nil {
+			t.Errorf("FetchResourcesUpdatedAfter(%q) succeeded, want error", selector)
+		}
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("Expected no requests for invalid selectors, got %d", n)
+	}
+}
+
+// FuzzLabelSelectorToSearchString checks that every selector accepted by
+// validateLabelSelector becomes a single well-formed TSL condition whose
+// quoted value decodes back to the original.
+func FuzzLabelSelectorToSearchString(f *testing.F) {
+	f.Add(testLabelRegion, testRegionUSEast)
+	f.Add("hyperfleet.io/shard", "1")
+	f.Add(keyName, "test'value")
+	f.Add("a' or '1'='1", "x")
+	f.Add(testLabelEnv, "'' and labels.x='")
+	f.Add(testLabelEnv, "\x00")
+
+	f.Fuzz(func(t *testing.T, key, value string) {
+		selector := map[string]string{key: value}
+		if validateLabelSelector(selector) != nil {
+			return
+		}
+		got := labelSelectorToSearchString(selector)
+
+		prefix := "labels." + key + "='"
+		if !strings.HasPrefix(got, prefix) || !strings.HasSuffix(got, "'") || len(got) < len(prefix)+1 {
+			t.Fatalf("labelSelectorToSearchString(%q) = %q, not a single quoted condition", selector, got)
+		}
+		quoted := got[len(prefix) : len(got)-1]
+		if strings.Count(strings.ReplaceAll(quoted, "''", ""), "'") != 0 {
+			t.Fatalf("labelSelectorToSearchString(%q) = %q, value escapes its quotes", selector, got)
+		}
+		if decoded := strings.ReplaceAll(quoted, "''", "'"); decoded != value {
+			t.Fatalf("labelSelectorToSearchString(%q) value decodes to %q, want %q", selector, decoded, value)
+		}
+	})
+}
+
 func TestBuildSearchString(t *testing.T) {
 	staleTimeFilter := "status.conditions.Reconciled.last_updated_time<='2025-01-01T00:00:00Z'"
 	tests := []struct {
@@ -994,6 +1207,15 @@ func TestBuildSearchString(t *testing.T) {
 	}
 }
 
+func TestOwnerFilter(t *testing.T) {
+	if got, want := OwnerFilter("cluster-1"), "owner_references.id='cluster-1'"; got != want {
+		t.Errorf("OwnerFilter() = %q, want %q", got, want)
+	}
+	if got, want := OwnerFilter("it's"), "owner_references.id='it''s'"; got != want {
+		t.Errorf("OwnerFilter() = %q, want %q", got, want)
+	}
+}
+
 func newTestClient(t *testing.T, url string, timeout time.Duration) *HyperFleetClient {
 	t.Helper()
 	client, err := NewHyperFleetClient(url, timeout, "test-sentinel", "test", DefaultPageSize, "", 0)
@@ -1048,7 +1270,7 @@ func TestNewHyperFleetClient_HTTPInstrumentation(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	resources, err := client.FetchResources(ctx, "clusters", nil)
+	resources, _, err := client.FetchResources(ctx, "clusters", nil)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -1146,7 +1368,7 @@ func TestNewHyperFleetClient_HTTPInstrumentation_ErrorCase(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	_, err = client.FetchResources(ctx, "clusters", nil)
+	_, _, err = client.FetchResources(ctx, "clusters", nil)
 
 	if err == nil {
 		t.Fatal("Expected error, got nil")
@@ -1180,7 +1402,7 @@ func TestFetchResources_WithAdditionalFilters(t *testing.T) {
 	client, _ := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0)
 	labelSelector := map[string]string{testLabelShard: "1"}
 
-	_, err := client.FetchResources(
+	_, _, err := client.FetchResources(
 		context.Background(), "clusters", labelSelector,
 		testReconciledFilter,
 	)
@@ -1194,6 +1416,46 @@ func TestFetchResources_WithAdditionalFilters(t *testing.T) {
 	}
 }
 
+func TestFetchResourcesUpdatedAfter(t *testing.T) {
+	var receivedSearchParam string
+	var conditional bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedSearchParam = r.URL.Query().Get("search")
+		if r.Header.Get("If-None-Match") != "" {
+			conditional = true
+		}
+
+		response := createMockResourceList([]map[string]interface{}{}, 1, 0)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Logf("Error encoding response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0)
+	labelSelector := map[string]string{testLabelShard: "1"}
+	updatedAfter := time.Date(2026, 1, 2, 15, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	for range 2 {
+		if _, _, err := client.FetchResourcesUpdatedAfter(
+			context.Background(), "clusters", labelSelector, updatedAfter,
+		); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	expectedSearch := "labels.shard='1' and updated_time>'2026-01-02T14:04:05Z'"
+	if receivedSearchParam != expectedSearch {
+		t.Errorf("Expected search parameter %q, got %q", expectedSearch, receivedSearchParam)
+	}
+	if conditional {
+		t.Error("Expected incremental fetches to bypass the conditional request cache")
+	}
+}
+
 func TestFetchResources_WithConditionFilterOnly(t *testing.T) {
 	var receivedSearchParam string
 
@@ -1210,7 +1472,7 @@ func TestFetchResources_WithConditionFilterOnly(t *testing.T) {
 
 	client, _ := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0)
 
-	_, err := client.FetchResources(
+	_, _, err := client.FetchResources(
 		context.Background(), "clusters", nil,
 		"status.conditions.Reconciled='True'",
 	)
@@ -1254,7 +1516,7 @@ func TestFetchResources_Pagination(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(t, server.URL, 10*time.Second)
-	resources, err := client.FetchResources(context.Background(), "clusters", nil)
+	resources, meta, err := client.FetchResources(context.Background(), "clusters", nil)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -1262,6 +1524,13 @@ func TestFetchResources_Pagination(t *testing.T) {
 	if len(resources) != 54 {
 		t.Fatalf("Expected 54 resources, got %d", len(resources))
 	}
+	want := ListMeta{Total: 54, Fetched: 54, Pages: 3, PageSize: DefaultPageSize}
+	if meta != want {
+		t.Errorf("Expected list metadata %+v, got %+v", want, meta)
+	}
+	if meta.Truncated() {
+		t.Error("Expected complete list not to be truncated")
+	}
 	if len(requestedPages) != 3 {
 		t.Fatalf("Expected 3 page requests, got %d: %v", len(requestedPages), requestedPages)
 	}
@@ -1273,6 +1542,41 @@ func TestFetchResources_Pagination(t *testing.T) {
 	}
 }
 
+func TestFetchResources_PaginationTruncated(t *testing.T) {
+	// The server reports 100 resources but stops returning items after the
+	// first page, as a server-side result limit would.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get(keyPage))
+		items := []map[string]interface{}{}
+		if page == 1 {
+			for i := 0; i < int(DefaultPageSize); i++ {
+				items = append(items, createMockResource(fmt.Sprintf("cluster-%d", i+1), testKindCluster))
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(createMockResourceList(items, page, 100)); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, 10*time.Second)
+	resources, meta, err := client.FetchResources(context.Background(), "clusters", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resources) != int(DefaultPageSize) {
+		t.Fatalf("Expected %d resources, got %d", DefaultPageSize, len(resources))
+	}
+	want := ListMeta{Total: 100, Fetched: int64(DefaultPageSize), Pages: 2, PageSize: DefaultPageSize}
+	if meta != want {
+		t.Errorf("Expected list metadata %+v, got %+v", want, meta)
+	}
+	if !meta.Truncated() {
+		t.Error("Expected list to be reported as truncated")
+	}
+}
+
 func TestFetchResources_PaginationSinglePage(t *testing.T) {
 	requestCount := 0
 
@@ -1291,7 +1595,7 @@ func TestFetchResources_PaginationSinglePage(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(t, server.URL, 10*time.Second)
-	resources, err := client.FetchResources(context.Background(), "clusters", nil)
+	resources, _, err := client.FetchResources(context.Background(), "clusters", nil)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -1329,7 +1633,7 @@ func TestFetchResources_PaginationErrorOnSecondPage(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(t, server.URL, 10*time.Second)
-	resources, err := client.FetchResources(context.Background(), "clusters", nil)
+	resources, _, err := client.FetchResources(context.Background(), "clusters", nil)
 
 	if err == nil {
 		t.Fatal("Expected error on second page, got nil")
@@ -1364,7 +1668,7 @@ func TestFetchResources_PaginationSendsSize(t *testing.T) {
 			defer server.Close()
 
 			client := newTestClient(t, server.URL, 10*time.Second)
-			_, err := client.FetchResources(context.Background(), tt.resourceType, nil)
+			_, _, err := client.FetchResources(context.Background(), tt.resourceType, nil)
 			if err != nil {
 				t.Fatalf("Expected no error, got %v", err)
 			}
@@ -1376,3 +1680,123 @@ func TestFetchResources_PaginationSendsSize(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchResources_RateLimit(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(createMockResourceList(nil, 1, 0)); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	// 20 qps with burst 1: three requests need at least two 50ms refills.
+	c, err := NewHyperFleetClient(server.URL, 5*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithRateLimit(20, 1))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
+			t.Fatalf("fetch %d: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected requests to be rate limited, 3 fetches took %v", elapsed)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", requests.Load())
+	}
+}
+
+func TestFetchResources_RateLimitContextDeadline(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(createMockResourceList(nil, 1, 0)); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewHyperFleetClient(server.URL, 5*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithRateLimit(0.1, 1))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+
+	// The next token arrives in 10s, well past the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, _, err := c.FetchResources(ctx, "clusters", nil); err == nil {
+		t.Fatal("expected rate limiter error, got nil")
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected the second fetch not to reach the API, got %d requests", requests.Load())
+	}
+}
+
+func TestGetResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != testClustersAPIPath+"/cluster-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(createMockResource("cluster-1", "Cluster")); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, 5*time.Second)
+
+	resource, err := c.GetResource(context.Background(), "clusters", "cluster-1")
+	if err != nil {
+		t.Fatalf("GetResource: %v", err)
+	}
+	if resource.ID != "cluster-1" || resource.Kind != "Cluster" {
+		t.Errorf("unexpected resource: %+v", resource)
+	}
+
+	_, err = c.GetResource(context.Background(), "clusters", "missing")
+	if !IsNotFound(err) {
+		t.Errorf("expected not-found error, got %v", err)
+	}
+}
+
+func TestGetResource_InvalidArguments(t *testing.T) {
+	c := newTestClient(t, "http://localhost:8000", 5*time.Second)
+
+	if _, err := c.GetResource(context.Background(), "clusters", ""); err == nil {
+		t.Error("expected error for empty id")
+	}
+	if _, err := c.GetResource(context.Background(), "clusters/x", "id"); err == nil {
+		t.Error("expected error for invalid resource type")
+	}
+}
+
+func TestGetResource_EscapesID(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, 5*time.Second)
+	if _, err := c.GetResource(context.Background(), "clusters", "a/b"); !IsNotFound(err) {
+		t.Fatalf("expected not-found error, got %v", err)
+	}
+	if gotPath != testClustersAPIPath+"/a%2Fb" {
+		t.Errorf("expected escaped id in path, got %q", gotPath)
+	}
+}
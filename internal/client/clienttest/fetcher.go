@@ -0,0 +1,171 @@
+// Package clienttest provides an in-memory client.ResourceFetcher for unit
+// tests that should not depend on an HTTP server.
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+)
+
+// Call records one invocation of a Fetcher method.
+type Call struct {
+	UpdatedAfter      time.Time
+	LabelSelector     map[string]string
+	Labels            map[string]string
+	Method            string
+	ResourceType      string
+	ID                string
+	AdditionalFilters []string
+}
+
+// Fetcher is a client.ResourceFetcher that serves Resources from memory.
+//
+// List methods return the resources whose labels match the label selector;
+// FetchResourcesUpdatedAfter additionally keeps only resources with a later
+// UpdatedTime. Lists are reported as a single page whose total is the number
+// of resources returned, unless Total is set. TSL filters are recorded but
+// not evaluated. PatchResourceLabels updates the labels of the stored
+// resource. Every call is appended to Calls. A Fetcher is safe for concurrent use; set its fields
+// before sharing it.
+type Fetcher struct {
+	// Err, when non-nil, is returned by every fetch instead of resources.
+	Err error
+	// PatchErr, when non-nil, is returned by PatchResourceLabels.
+	PatchErr  error
+	Resources []client.Resource
+	Calls     []Call
+	// State is reported by CircuitState.
+	State client.CircuitState
+	// Total, when positive, is reported as ListMeta.Total by the list
+	// methods, e.g. to simulate a truncated list.
+	Total int64
+	mu    sync.Mutex
+}
+
+var (
+	_ client.ResourceFetcher = (*Fetcher)(nil)
+	_ client.ResourceLabeler = (*Fetcher)(nil)
+)
+
+// FetchResources returns the resources matching labelSelector.
+func (f *Fetcher) FetchResources(
+	_ context.Context, resourceType string, labelSelector map[string]string, additionalFilters ...string,
+) ([]client.Resource, client.ListMeta, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, Call{
+		Method:            "FetchResources",
+		ResourceType:      resourceType,
+		LabelSelector:     labelSelector,
+		AdditionalFilters: additionalFilters,
+	})
+	if f.Err != nil {
+		return nil, client.ListMeta{}, f.Err
+	}
+	return f.list(labelSelector, time.Time{})
+}
+
+// FetchResourcesUpdatedAfter returns the resources matching labelSelector
+// whose UpdatedTime is later than updatedAfter.
+func (f *Fetcher) FetchResourcesUpdatedAfter(
+	_ context.Context, resourceType string, labelSelector map[string]string, updatedAfter time.Time,
+) ([]client.Resource, client.ListMeta, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, Call{
+		Method:        "FetchResourcesUpdatedAfter",
+		ResourceType:  resourceType,
+		LabelSelector: labelSelector,
+		UpdatedAfter:  updatedAfter,
+	})
+	if f.Err != nil {
+		return nil, client.ListMeta{}, f.Err
+	}
+	return f.list(labelSelector, updatedAfter)
+}
+
+// GetResource returns the resource with the given ID, or an error for which
+// client.IsNotFound reports true.
+func (f *Fetcher) GetResource(_ context.Context, resourceType, id string) (*client.Resource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, Call{Method: "GetResource", ResourceType: resourceType, ID: id})
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	for i := range f.Resources {
+		if f.Resources[i].ID == id {
+			r := f.Resources[i]
+			return &r, nil
+		}
+	}
+	return nil, &client.APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("%s %q not found", resourceType, id),
+	}
+}
+
+// PatchResourceLabels replaces the labels of the resource with the given ID,
+// or returns an error for which client.IsNotFound reports true.
+func (f *Fetcher) PatchResourceLabels(_ context.Context, resourceType, id string, labels map[string]string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, Call{Method: "PatchResourceLabels", ResourceType: resourceType, ID: id, Labels: labels})
+	if f.PatchErr != nil {
+		return f.PatchErr
+	}
+	for i := range f.Resources {
+		if f.Resources[i].ID == id {
+			f.Resources[i].Labels = labels
+			return nil
+		}
+	}
+	return &client.APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("%s %q not found", resourceType, id),
+	}
+}
+
+// CircuitState returns State.
+func (f *Fetcher) CircuitState() client.CircuitState {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.State
+}
+
+// list returns copies of the matching resources and their list metadata.
+// Callers must hold f.mu.
+func (f *Fetcher) list(
+	labelSelector map[string]string, updatedAfter time.Time,
+) ([]client.Resource, client.ListMeta, error) {
+	resources := make([]client.Resource, 0, len(f.Resources))
+	for _, r := range f.Resources {
+		if !matchesLabels(r.Labels, labelSelector) {
+			continue
+		}
+		if !updatedAfter.IsZero() && !r.UpdatedTime.After(updatedAfter) {
+			continue
+		}
+		resources = append(resources, r)
+	}
+	n := int64(len(resources))
+	meta := client.ListMeta{Total: n, Fetched: n, Pages: 1}
+	if f.Total > 0 {
+		meta.Total = f.Total
+	}
+	return resources, meta, nil
+}
+
+func matchesLabels(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+)
+
+func TestFetcher(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := &Fetcher{
+		Resources: []client.Resource{
+			{ID: "a", Labels: map[string]string{"shard": "1"}, UpdatedTime: base},
+			{ID: "b", Labels: map[string]string{"shard": "1"}, UpdatedTime: base.Add(time.Hour)},
+			{ID: "c", Labels: map[string]string{"shard": "2"}, UpdatedTime: base.Add(time.Hour)},
+		},
+	}
+
+	got, _, err := f.FetchResources(ctx, "clusters", map[string]string{"shard": "1"})
+	if err != nil || len(got) != 2 {
+		t.Fatalf("FetchResources = %d resources, %v; want 2, nil", len(got), err)
+	}
+
+	got, _, err = f.FetchResourcesUpdatedAfter(ctx, "clusters", map[string]string{"shard": "1"}, base)
+	if err != nil || len(got) != 1 || got[0].ID != "b" {
+		t.Fatalf("FetchResourcesUpdatedAfter = %+v, %v; want [b]", got, err)
+	}
+
+	if r, err := f.GetResource(ctx, "clusters", "c"); err != nil || r.ID != "c" {
+		t.Errorf("GetResource(c) = %+v, %v", r, err)
+	}
+	if _, err := f.GetResource(ctx, "clusters", "missing"); !client.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	if len(f.Calls) != 4 || f.Calls[1].Method != "FetchResourcesUpdatedAfter" {
+		t.Errorf("unexpected recorded calls: %+v", f.Calls)
+	}
+
+	f.Err = errors.New("boom")
+	if _, _, err := f.FetchResources(ctx, "clusters", nil); !errors.Is(err, f.Err) {
+		t.Errorf("expected configured error, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/api/openapi"
+)
+
+// defaultStatusField is the JSON field of a resource holding its status
+// conditions.
+const defaultStatusField = "status"
+
+// DefaultAPIVersion is the HyperFleet API version used unless WithAPIVersion
+// selects another one.
+const DefaultAPIVersion = "v1"
+
+// apiVersionModels maps each supported HyperFleet API version to how its
+// resources map into Resource, expressed like a ResourceEndpoint without a
+// path. hyperfleet-api-spec only defines v1. A version added here needs a
+// response fixture in testdata/<version>/cluster-list.json and an entry in
+// config.SupportedAPIVersions.
+var apiVersionModels = map[string]ResourceEndpoint{
+	"v1": {},
+}
+
+// ResourceEndpoint describes a resource type that is not served at the default
+// /api/hyperfleet/{version}/{resourceType} path or whose items differ in shape from
+// the HyperFleet resource schema.
+type ResourceEndpoint struct {
+	// Path is the collection path below the base URL, e.g.
+	// "/api/hyperfleet/v1/machinepools". Single resources are fetched from
+	// Path + "/" + id.
+	Path string
+	// Kind is the kind of the listed items. Items that omit their kind get it;
+	// items of another kind are rejected, which catches a Path that points at
+	// the wrong collection.
+	Kind string
+	// StatusField is the JSON field holding the item's status conditions, if
+	// it is not "status".
+	StatusField string
+}
+
+// WithAPIVersion selects the HyperFleet API version, e.g. "v1", used for
+// resource types without a registered endpoint. NewHyperFleetClient rejects
+// versions it has no resource model for.
+func WithAPIVersion(version string) Option {
+	return func(o *clientOptions) {
+		o.apiVersion = version
+	}
+}
+
+// CheckAPIVersion returns an error if the client has no resource model for the
+// HyperFleet API version.
+func CheckAPIVersion(version string) error {
+	if _, ok := apiVersionModels[version]; !ok {
+		return fmt.Errorf("unsupported API version %q", version)
+	}
+	return nil
+}
+
+// WithResourceEndpoints registers endpoints for resource types, keyed by the
+// resourceType passed to FetchResources and GetResource. Resource types that
+// are not registered use the default path.
+func WithResourceEndpoints(endpoints map[string]ResourceEndpoint) Option {
+	return func(o *clientOptions) {
+		o.endpoints = endpoints
+	}
+}
+
+// collectionPath returns the path of the resourceType collection and the
+// endpoint describing its items: the registered endpoint, if any, or else the
+// resource model of the client's API version.
+func (c *HyperFleetClient) collectionPath(resourceType string) (string, *ResourceEndpoint, error) {
+	if ep, ok := c.endpoints[resourceType]; ok {
+		return ep.Path, &ep, nil
+	}
+	if err := validateResourceType(resourceType); err != nil {
+		return "", nil, err
+	}
+	ep := apiVersionModels[c.apiVersion]
+	ep.Path = "/api/hyperfleet/" + c.apiVersion + "/" + resourceType
+	return ep.Path, &ep, nil
+}
+
+// decodeResourceList decodes a list response, applying the endpoint's status
+// field mapping and kind. ep may be nil.
+func decodeResourceList(body []byte, ep *ResourceEndpoint) (openapi.ResourceList, error) {
+	var list openapi.ResourceList
+	if ep == nil || ep.StatusField == "" || ep.StatusField == defaultStatusField {
+		if err := json.Unmarshal(body, &list); err != nil {
+			return list, err
+		}
+	} else {
+		var raw struct {
+			Items []json.RawMessage `json:"items"`
+			Page  int32             `json:"page"`
+			Size  int32             `json:"size"`
+			Total int64             `json:"total"`
+		}
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return list, err
+		}
+		list = openapi.ResourceList{
+			Items: make([]openapi.Resource, len(raw.Items)),
+			Page:  raw.Page,
+			Size:  raw.Size,
+			Total: raw.Total,
+		}
+		for i, item := range raw.Items {
+			if err := decodeMappedResource(item, ep.StatusField, &list.Items[i]); err != nil {
+				return list, err
+			}
+		}
+	}
+
+	for i := range list.Items {
+		if err := applyKind(&list.Items[i], ep); err != nil {
+			return list, err
+		}
+	}
+	return list, nil
+}
+
+// decodeResource decodes a single resource response like decodeResourceList.
+func decodeResource(body []byte, ep *ResourceEndpoint) (openapi.Resource, error) {
+	var item openapi.Resource
+	var err error
+	if ep == nil || ep.StatusField == "" || ep.StatusField == defaultStatusField {
+		err = json.Unmarshal(body, &item)
+	} else {
+		err = decodeMappedResource(body, ep.StatusField, &item)
+	}
+	if err != nil {
+		return item, err
+	}
+	return item, applyKind(&item, ep)
+}
+
+// decodeMappedResource decodes data into item, reading the status from
+// statusField instead of "status".
+func decodeMappedResource(data []byte, statusField string, item *openapi.Resource) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	delete(fields, defaultStatusField)
+	if status, ok := fields[statusField]; ok {
+		fields[defaultStatusField] = status
+		delete(fields, statusField)
+	}
+	remapped, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(remapped, item)
+}
+
+// applyKind sets the endpoint kind on an item that omits it and rejects an
+// item of another kind.
+func applyKind(item *openapi.Resource, ep *ResourceEndpoint) error {
+	if ep == nil || ep.Kind == "" {
+		return nil
+	}
+	if item.Kind == "" {
+		item.Kind = ep.Kind
+		return nil
+	}
+	if item.Kind != ep.Kind {
+		return fmt.Errorf("unexpected kind %q for resource %s, expected %q", item.Kind, item.Id, ep.Kind)
+	}
+	return nil
+}
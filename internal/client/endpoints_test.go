@@ -0,0 +1,226 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/api/openapi"
+)
+
+const testAddonsPath = "/api/addons/v1/addons"
+
+// newAddonServer serves an addon collection whose items omit their kind and
+// report their conditions under "state" instead of "status".
+func newAddonServer(t *testing.T, kind string) *httptest.Server {
+	t.Helper()
+	addon := func(id string) map[string]interface{} {
+		a := createMockResource(id, kind)
+		a["state"] = a[keyStatus]
+		delete(a, keyStatus)
+		return a
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response interface{}
+		switch r.URL.Path {
+		case testAddonsPath:
+			response = createMockResourceList([]map[string]interface{}{addon("addon-1")}, 1, 1)
+		case testAddonsPath + "/addon-1":
+			response = addon("addon-1")
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+}
+
+func newAddonClient(t *testing.T, serverURL string) *HyperFleetClient {
+	t.Helper()
+	c, err := NewHyperFleetClient(serverURL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithResourceEndpoints(map[string]ResourceEndpoint{
+			"addons": {Path: testAddonsPath, Kind: "Addon", StatusField: "state"},
+		}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c
+}
+
+func TestFetchResources_APIVersion(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		var response interface{} = createMockResourceList(
+			[]map[string]interface{}{createMockResource("cluster-1", testKindCluster)}, 1, 1)
+		if strings.HasSuffix(r.URL.Path, "/cluster-1") {
+			response = createMockResource("cluster-1", testKindCluster)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithAPIVersion(DefaultAPIVersion))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resources, _, err := c.FetchResources(context.Background(), "clusters", nil)
+	if err != nil {
+		t.Fatalf("FetchResources failed: %v", err)
+	}
+	if len(resources) != 1 || len(resources[0].Status.Conditions) != 2 {
+		t.Fatalf("Expected 1 resource with 2 conditions, got %+v", resources)
+	}
+	if _, err := c.GetResource(context.Background(), "clusters", "cluster-1"); err != nil {
+		t.Fatalf("GetResource failed: %v", err)
+	}
+
+	want := []string{"/api/hyperfleet/v1/clusters", "/api/hyperfleet/v1/clusters/cluster-1"}
+	if len(paths) != 2 || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("Requested paths = %q, want %q", paths, want)
+	}
+}
+
+// TestAPIVersionModels_DecodeFixtures feeds a list response of each supported
+// API version through the decoders with that version's resource model.
+func TestAPIVersionModels_DecodeFixtures(t *testing.T) {
+	for version, model := range apiVersionModels {
+		t.Run(version, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", version, "cluster-list.json"))
+			if err != nil {
+				t.Fatalf("Failed to read the %s fixture: %v", version, err)
+			}
+			ep := model
+			decoded, err := decodeResourceList(body, &ep)
+			if err != nil {
+				t.Fatalf("decodeResourceList failed: %v", err)
+			}
+			streamed, err := decodeResourceListStream(bytes.NewReader(body), &ep)
+			if err != nil {
+				t.Fatalf("decodeResourceListStream failed: %v", err)
+			}
+			for name, list := range map[string]openapi.ResourceList{"buffered": decoded, "streaming": streamed} {
+				if list.Total != 1 || len(list.Items) != 1 {
+					t.Fatalf("%s: expected 1 item of 1, got %d of %d", name, len(list.Items), list.Total)
+				}
+				item := list.Items[0]
+				if item.Id == "" || item.Kind != testKindCluster || item.Generation != 3 {
+					t.Errorf("%s: unexpected item %+v", name, item)
+				}
+				conds := item.Status.Conditions
+				if len(conds) != 1 || conds[0].Type != "Reconciled" || conds[0].ObservedGeneration != 3 ||
+					conds[0].LastUpdatedTime.IsZero() {
+					t.Errorf("%s: unexpected conditions %+v", name, conds)
+				}
+			}
+		})
+	}
+}
+
+func TestNewHyperFleetClient_UnsupportedAPIVersion(t *testing.T) {
+	_, err := NewHyperFleetClient("http://localhost:8000", 10*time.Second, "test-sentinel", "test",
+		DefaultPageSize, "", 0, WithAPIVersion("v9"))
+	if err == nil || !strings.Contains(err.Error(), `unsupported API version "v9"`) {
+		t.Errorf("Expected unsupported API version error, got %v", err)
+	}
+}
+
+// TestAPIVersionModels_MatchConfig checks that config validation accepts
+// exactly the API versions the client has a resource model for.
+func TestAPIVersionModels_MatchConfig(t *testing.T) {
+	if len(config.SupportedAPIVersions) != len(apiVersionModels) {
+		t.Errorf("config.SupportedAPIVersions = %q, want one entry per model in %v",
+			config.SupportedAPIVersions, apiVersionModels)
+	}
+	for _, version := range config.SupportedAPIVersions {
+		if err := CheckAPIVersion(version); err != nil {
+			t.Errorf("config accepts version %q: %v", version, err)
+		}
+	}
+}
+
+func TestFetchResources_ResourceEndpoint(t *testing.T) {
+	server := newAddonServer(t, "")
+	defer server.Close()
+	c := newAddonClient(t, server.URL)
+
+	resources, _, err := c.FetchResources(context.Background(), "addons", nil)
+	if err != nil {
+		t.Fatalf("FetchResources failed: %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("Expected 1 resource, got %d", len(resources))
+	}
+	if resources[0].Kind != "Addon" {
+		t.Errorf("Expected kind Addon from the endpoint, got %q", resources[0].Kind)
+	}
+	if len(resources[0].Status.Conditions) != 2 {
+		t.Errorf("Expected 2 conditions mapped from state, got %d", len(resources[0].Status.Conditions))
+	}
+
+	resource, err := c.GetResource(context.Background(), "addons", "addon-1")
+	if err != nil {
+		t.Fatalf("GetResource failed: %v", err)
+	}
+	if resource.Kind != "Addon" || len(resource.Status.Conditions) != 2 {
+		t.Errorf("Unexpected resource: kind=%q conditions=%d", resource.Kind, len(resource.Status.Conditions))
+	}
+
+	if err := c.VerifyConnectivity(context.Background(), "addons"); err != nil {
+		t.Errorf("VerifyConnectivity failed: %v", err)
+	}
+}
+
+func TestFetchResources_ResourceEndpointKindMismatch(t *testing.T) {
+	server := newAddonServer(t, testKindCluster)
+	defer server.Close()
+	c := newAddonClient(t, server.URL)
+
+	_, _, err := c.FetchResources(context.Background(), "addons", nil)
+	if err == nil {
+		t.Fatal("Expected an error for items of another kind")
+	}
+	if !strings.Contains(err.Error(), `unexpected kind "Cluster"`) {
+		t.Errorf("Expected kind mismatch error, got %v", err)
+	}
+}
+
+func TestFetchResources_UnregisteredTypeUsesDefaultPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != testClustersAPIPath {
+			t.Errorf("Expected path %s, got %s", testClustersAPIPath, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		response := createMockResourceList([]map[string]interface{}{createMockResource("cluster-1", testKindCluster)}, 1, 1)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+	c := newAddonClient(t, server.URL)
+
+	resources, _, err := c.FetchResources(context.Background(), "clusters", nil)
+	if err != nil {
+		t.Fatalf("FetchResources failed: %v", err)
+	}
+	if len(resources) != 1 || resources[0].Kind != testKindCluster {
+		t.Errorf("Unexpected resources: %+v", resources)
+	}
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+)
+
+// attemptKey is the context key under which the retry loop stores the
+// attempt number of a request.
+type attemptKey struct{}
+
+// withAttempt returns a copy of ctx carrying the 1-based attempt number.
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// attemptFromContext returns the attempt number stored by withAttempt, or 0
+// for requests sent outside the retry loop.
+func attemptFromContext(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptKey{}).(int) //nolint:errcheck // type assertion, not an error
+	return attempt
+}
+
+// WithRequestMetrics records the latency, status code, and retries of every
+// HTTP request in the api_request_duration_seconds and
+// api_request_retries_total metrics, labelled with resourceType and
+// resourceSelector. metrics.NewSentinelMetrics must have been called.
+func WithRequestMetrics(resourceType, resourceSelector string) Option {
+	return func(o *clientOptions) {
+		o.metricsResourceType = resourceType
+		o.metricsResourceSelector = resourceSelector
+	}
+}
+
+// metricsLabelsKey is the context key under which WithMetricsLabels stores
+// request metric labels.
+type metricsLabelsKey struct{}
+
+// metricsLabels are the resource type and selector labels of request metrics.
+type metricsLabels struct {
+	resourceType     string
+	resourceSelector string
+}
+
+// WithMetricsLabels returns a copy of ctx whose requests are recorded with
+// resourceType and resourceSelector instead of the labels given to
+// WithRequestMetrics, so that poll loops sharing a client keep their own
+// labels. It has no effect on a client without request metrics.
+func WithMetricsLabels(ctx context.Context, resourceType, resourceSelector string) context.Context {
+	return context.WithValue(ctx, metricsLabelsKey{}, metricsLabels{
+		resourceType:     resourceType,
+		resourceSelector: resourceSelector,
+	})
+}
+
+// metricsTransport is an http.RoundTripper that records request metrics.
+type metricsTransport struct {
+	next             http.RoundTripper
+	resourceType     string
+	resourceSelector string
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resourceType, resourceSelector := t.resourceType, t.resourceSelector
+	if labels, ok := req.Context().Value(metricsLabelsKey{}).(metricsLabels); ok {
+		resourceType, resourceSelector = labels.resourceType, labels.resourceSelector
+	}
+	if attemptFromContext(req.Context()) > 1 {
+		metrics.UpdateAPIRequestRetriesMetric(resourceType, resourceSelector)
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+		if delay := responseRetryAfter(resp, time.Now()); delay > 0 {
+			metrics.UpdateAPIRetryAfterMetric(resourceType, resourceSelector, status, delay.Seconds())
+		}
+	}
+	metrics.UpdateAPIRequestDurationMetric(resourceType, resourceSelector, req.Method, status,
+		time.Since(start).Seconds())
+
+	return resp, err
+}
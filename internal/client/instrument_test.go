@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWithRequestMetrics(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+	t.Cleanup(metrics.ResetSentinelMetrics)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(createMockResourceList(nil, 1, 0)); err != nil {
+			t.Logf("Error encoding response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithRequestMetrics("clusters", "all"))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, status := range []string{"503", "200"} {
+		h := m.APIRequestDuration.WithLabelValues("clusters", "all", http.MethodGet, status)
+		if got := testutil.CollectAndCount(h.(prometheus.Collector)); got != 1 {
+			t.Errorf("Expected an api_request_duration_seconds series for status %s", status)
+		}
+	}
+	if got := testutil.ToFloat64(m.APIRequestRetries.WithLabelValues("clusters", "all")); got != 1 {
+		t.Errorf("Expected 1 retry, got %f", got)
+	}
+}
+
+func TestWithMetricsLabels(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+	t.Cleanup(metrics.ResetSentinelMetrics)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(createMockResourceList(nil, 1, 0)); err != nil {
+			t.Logf("Error encoding response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithRequestMetrics("clusters", "all"))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := WithMetricsLabels(context.Background(), "nodepools", "shard:1")
+	if _, _, err := c.FetchResources(ctx, "nodepools", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := testutil.CollectAndCount(m.APIRequestDuration); got != 1 {
+		t.Fatalf("Expected 1 api_request_duration_seconds series, got %d", got)
+	}
+	// Looking up the recorded series does not add one.
+	m.APIRequestDuration.WithLabelValues("nodepools", "shard:1", http.MethodGet, "200")
+	if got := testutil.CollectAndCount(m.APIRequestDuration); got != 1 {
+		t.Error("Expected the request to be recorded with the context labels")
+	}
+}
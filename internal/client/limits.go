@@ -0,0 +1,81 @@
+package client
+
+import (
+	"fmt"
+	"io"
+
+	apierrors "github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/errors"
+)
+
+// WithResponseLimits bounds what the client accepts from the API so that an
+// oversized response fails the request instead of exhausting the memory of
+// the Sentinel. maxBodyBytes caps the body of each response and maxItems caps
+// the number of resources in a list, across all pages; a list whose reported
+// total is already above maxItems fails after its first page. A limit <= 0 is
+// not enforced.
+//
+// Exceeding a limit returns a non-retriable *APIError for which
+// errors.IsResponseTooLarge reports true.
+func WithResponseLimits(maxBodyBytes int64, maxItems int) Option {
+	return func(o *clientOptions) {
+		o.maxBodyBytes = maxBodyBytes
+		o.maxItems = maxItems
+	}
+}
+
+// limitBody returns body limited to the client's maximum body size.
+func (c *HyperFleetClient) limitBody(body io.Reader) io.Reader {
+	if c.maxBodyBytes <= 0 {
+		return body
+	}
+	return &limitedReader{r: body, remaining: c.maxBodyBytes}
+}
+
+// limitedReader reads from r and fails with ErrResponseTooLarge once more
+// than remaining bytes have been read. Unlike io.LimitReader, it reports the
+// overflow instead of silently truncating the body.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, apierrors.ErrResponseTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, apierrors.ErrResponseTooLarge
+	}
+	return n, err
+}
+
+// bodyError converts an error from reading or decoding a response body into
+// an *APIError, reporting an exceeded body limit as such.
+func (c *HyperFleetClient) bodyError(action string, err error) *APIError {
+	if apierrors.IsResponseTooLarge(err) {
+		return &APIError{
+			Message:   fmt.Sprintf("response body exceeds the limit of %d bytes", c.maxBodyBytes),
+			Cause:     apierrors.ErrResponseTooLarge,
+			Retriable: false,
+		}
+	}
+	return &APIError{Message: fmt.Sprintf("failed to %s response: %v", action, err), Retriable: false}
+}
+
+// checkItemLimit fails once a list holds, or reports, more items than the
+// client accepts.
+func (c *HyperFleetClient) checkItemLimit(fetched, total int64) error {
+	if c.maxItems <= 0 || max(fetched, total) <= int64(c.maxItems) {
+		return nil
+	}
+	return &APIError{
+		Message:   fmt.Sprintf("list has %d items, more than the limit of %d", max(fetched, total), c.maxItems),
+		Cause:     apierrors.ErrResponseTooLarge,
+		Retriable: false,
+	}
+}
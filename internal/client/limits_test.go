@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/errors"
+)
+
+func newLimitedTestClient(t *testing.T, url string, opts ...Option) *HyperFleetClient {
+	t.Helper()
+	c, err := NewHyperFleetClient(url, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0, opts...)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c
+}
+
+func TestFetchResources_MaxBodyBytes(t *testing.T) {
+	server := httptest.NewServer(&pagedServer{total: 20})
+	defer server.Close()
+
+	for _, streaming := range []bool{false, true} {
+		opts := []Option{WithResponseLimits(512, 0)}
+		if streaming {
+			opts = append(opts, WithStreamingDecode())
+		}
+		resources, _, err := newLimitedTestClient(t, server.URL, opts...).
+			FetchResources(context.Background(), "clusters", nil)
+		if err == nil {
+			t.Fatalf("streaming=%v: expected error for oversized body, got %d resources", streaming, len(resources))
+		}
+		if !apierrors.IsResponseTooLarge(err) {
+			t.Errorf("streaming=%v: expected IsResponseTooLarge, got %v", streaming, err)
+		}
+		if apierrors.IsRetriable(err) {
+			t.Errorf("streaming=%v: expected oversized body not to be retried", streaming)
+		}
+		if !strings.Contains(err.Error(), "512 bytes") {
+			t.Errorf("streaming=%v: expected error to name the limit, got %v", streaming, err)
+		}
+	}
+}
+
+func TestFetchResources_MaxBodyBytesWithinLimit(t *testing.T) {
+	server := httptest.NewServer(&pagedServer{total: 20})
+	defer server.Close()
+
+	resources, _, err := newLimitedTestClient(t, server.URL, WithResponseLimits(1<<20, 0)).
+		FetchResources(context.Background(), "clusters", nil)
+	if err != nil {
+		t.Fatalf("FetchResources failed: %v", err)
+	}
+	if len(resources) != 20 {
+		t.Errorf("Expected 20 resources, got %d", len(resources))
+	}
+}
+
+func TestFetchResources_MaxItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		maxItems  int
+		wantPages int32
+		wantErr   bool
+	}{
+		{name: "within limit", total: 50, maxItems: 50, wantPages: 3},
+		{name: "reported total over limit", total: 200, maxItems: 50, wantPages: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pages int32
+			ps := &pagedServer{total: tt.total}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pages++
+				ps.ServeHTTP(w, r)
+			}))
+			defer server.Close()
+
+			resources, _, err := newLimitedTestClient(t, server.URL, WithResponseLimits(0, tt.maxItems)).
+				FetchResources(context.Background(), "clusters", nil)
+			if tt.wantErr {
+				if !apierrors.IsResponseTooLarge(err) {
+					t.Fatalf("Expected IsResponseTooLarge, got %v (%d resources)", err, len(resources))
+				}
+			} else if err != nil {
+				t.Fatalf("FetchResources failed: %v", err)
+			}
+			if pages != tt.wantPages {
+				t.Errorf("Expected %d page requests, got %d", tt.wantPages, pages)
+			}
+		})
+	}
+}
+
+func TestFetchResources_MaxItemsUnderreportedTotal(t *testing.T) {
+	// The API reports a total within the limit but keeps returning items.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		items := make([]map[string]interface{}, DefaultPageSize)
+		for i := range items {
+			items[i] = createMockResource("cluster", testKindCluster)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(createMockResourceList(items, 1, 10))
+	}))
+	defer server.Close()
+
+	_, _, err := newLimitedTestClient(t, server.URL, WithResponseLimits(0, 10)).
+		FetchResources(context.Background(), "clusters", nil)
+	if !apierrors.IsResponseTooLarge(err) {
+		t.Errorf("Expected IsResponseTooLarge, got %v", err)
+	}
+}
+
+func TestGetResource_MaxBodyBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(createMockResource("cluster-1", testKindCluster))
+	}))
+	defer server.Close()
+
+	_, err := newLimitedTestClient(t, server.URL, WithResponseLimits(64, 0)).
+		GetResource(context.Background(), "clusters", "cluster-1")
+	if !apierrors.IsResponseTooLarge(err) {
+		t.Errorf("Expected IsResponseTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pagedServer serves total resources in pages of the requested size and
+// records the peak number of concurrent requests. Each request takes delay so
+// that concurrent requests overlap. Pages listed in failPages get a 400
+// response; pages after emptyAfter (when positive) come back empty.
+type pagedServer struct {
+	failPages  map[int]bool
+	delay      time.Duration
+	total      int
+	emptyAfter int
+	inFlight   atomic.Int32
+	peak       atomic.Int32
+}
+
+func (s *pagedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		p := s.peak.Load()
+		if n <= p || s.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	time.Sleep(s.delay)
+
+	page, _ := strconv.Atoi(r.URL.Query().Get(keyPage))
+	size, _ := strconv.Atoi(r.URL.Query().Get(keySize))
+	if s.failPages[page] {
+		http.Error(w, "bad page", http.StatusBadRequest)
+		return
+	}
+	items := []map[string]interface{}{}
+	if s.emptyAfter <= 0 || page <= s.emptyAfter {
+		for i := (page - 1) * size; i < page*size && i < s.total; i++ {
+			items = append(items, createMockResource(fmt.Sprintf("cluster-%d", i+1), testKindCluster))
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(createMockResourceList(items, page, s.total))
+}
+
+func newConcurrentTestClient(t *testing.T, url string, concurrency int) *HyperFleetClient {
+	t.Helper()
+	c, err := NewHyperFleetClient(url, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithPageConcurrency(concurrency))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c
+}
+
+func TestFetchResources_PageConcurrency(t *testing.T) {
+	ps := &pagedServer{total: 154, delay: 20 * time.Millisecond}
+	server := httptest.NewServer(ps)
+	defer server.Close()
+
+	resources, meta, err := newConcurrentTestClient(t, server.URL, 4).
+		FetchResources(context.Background(), "clusters", nil)
+	if err != nil {
+		t.Fatalf("FetchResources failed: %v", err)
+	}
+	if len(resources) != 154 {
+		t.Fatalf("Expected 154 resources, got %d", len(resources))
+	}
+	for i, r := range resources {
+		if want := fmt.Sprintf("cluster-%d", i+1); r.ID != want {
+			t.Fatalf("Expected resource %d to be %s, got %s", i, want, r.ID)
+		}
+	}
+	want := ListMeta{Total: 154, Fetched: 154, Pages: 8, PageSize: DefaultPageSize}
+	if meta != want {
+		t.Errorf("Expected list metadata %+v, got %+v", want, meta)
+	}
+	if p := ps.peak.Load(); p < 2 || p > 4 {
+		t.Errorf("Expected between 2 and 4 concurrent page requests, got %d", p)
+	}
+}
+
+func TestFetchResources_PageConcurrencyPageError(t *testing.T) {
+	server := httptest.NewServer(&pagedServer{total: 100, failPages: map[int]bool{3: true}})
+	defer server.Close()
+
+	resources, _, err := newConcurrentTestClient(t, server.URL, 4).
+		FetchResources(context.Background(), "clusters", nil)
+	if err == nil {
+		t.Fatalf("Expected error for failed page, got %d resources", len(resources))
+	}
+	if !strings.Contains(err.Error(), "page=3") {
+		t.Errorf("Expected error to name the failed page request, got %v", err)
+	}
+}
+
+func TestFetchResources_PageConcurrencyTruncated(t *testing.T) {
+	server := httptest.NewServer(&pagedServer{total: 200, emptyAfter: 3})
+	defer server.Close()
+
+	resources, meta, err := newConcurrentTestClient(t, server.URL, 4).
+		FetchResources(context.Background(), "clusters", nil)
+	if err != nil {
+		t.Fatalf("FetchResources failed: %v", err)
+	}
+	if len(resources) != 60 {
+		t.Fatalf("Expected 60 resources, got %d", len(resources))
+	}
+	want := ListMeta{Total: 200, Fetched: 60, Pages: 4, PageSize: DefaultPageSize}
+	if meta != want {
+		t.Errorf("Expected list metadata %+v, got %+v", want, meta)
+	}
+}
+
+// typesServer serves count resources for every collection path, named after
+// the last path element, and records the peak number of concurrent requests.
+// Collections listed in fail get a 400 response.
+type typesServer struct {
+	fail     map[string]bool
+	delay    time.Duration
+	count    int
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (s *typesServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		p := s.peak.Load()
+		if n <= p || s.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	time.Sleep(s.delay)
+
+	plural := path.Base(r.URL.Path)
+	if s.fail[plural] {
+		http.Error(w, "bad collection", http.StatusBadRequest)
+		return
+	}
+	items := make([]map[string]interface{}, s.count)
+	for i := range items {
+		items[i] = createMockResource(fmt.Sprintf("%s-%d", plural, i+1), testKindCluster)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(createMockResourceList(items, 1, s.count))
+}
+
+func TestFetchResourceTypes(t *testing.T) {
+	ts := &typesServer{count: 2, delay: 20 * time.Millisecond}
+	server := httptest.NewServer(ts)
+	defer server.Close()
+
+	types := []string{"clusters", "nodepools", "wifconfigs", "idps", "machines"}
+	c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithTypeConcurrency(2))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resources, metas, err := c.FetchResourceTypes(context.Background(), types, nil)
+	if err != nil {
+		t.Fatalf("FetchResourceTypes failed: %v", err)
+	}
+	if len(resources) != 2*len(types) {
+		t.Fatalf("Expected %d resources, got %d", 2*len(types), len(resources))
+	}
+	for i, r := range resources {
+		if want := fmt.Sprintf("%s-%d", types[i/2], i%2+1); r.ID != want {
+			t.Errorf("Expected resource %d to be %s, got %s", i, want, r.ID)
+		}
+	}
+	for _, rt := range types {
+		if meta := metas[rt]; meta.Total != 2 || meta.Fetched != 2 {
+			t.Errorf("Expected list metadata for %s with total and fetched 2, got %+v", rt, meta)
+		}
+	}
+	if p := ts.peak.Load(); p != 2 {
+		t.Errorf("Expected 2 concurrent type fetches, got %d", p)
+	}
+}
+
+func TestFetchResourceTypes_Error(t *testing.T) {
+	server := httptest.NewServer(&typesServer{count: 1, fail: map[string]bool{"nodepools": true}})
+	defer server.Close()
+
+	resources, metas, err := newConcurrentTestClient(t, server.URL, 0).
+		FetchResourceTypes(context.Background(), []string{"clusters", "nodepools"}, nil)
+	if err == nil {
+		t.Fatalf("Expected error for failed type, got %d resources and %d metas", len(resources), len(metas))
+	}
+	if !strings.Contains(err.Error(), "failed to fetch nodepools") {
+		t.Errorf("Expected error to name the failed type, got %v", err)
+	}
+}
+
+func TestBatchEnd(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int64
+		concurrency int
+		page        int32
+		want        int32
+	}{
+		{name: "full batch", page: 2, concurrency: 4, total: 1000, want: 5},
+		{name: "stops at last page", page: 2, concurrency: 4, total: 54, want: 3},
+		{name: "exact last page", page: 2, concurrency: 4, total: 60, want: 3},
+		{name: "total already fetched", page: 4, concurrency: 4, total: 20, want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := batchEnd(tt.page, tt.concurrency, tt.total, 20); got != tt.want {
+				t.Errorf("batchEnd(%d, %d, %d, 20) = %d, want %d", tt.page, tt.concurrency, tt.total, got, tt.want)
+			}
+		})
+	}
+}
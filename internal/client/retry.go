@@ -0,0 +1,119 @@
+package client
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+)
+
+// StopRetrying is returned by Retryer.NextDelay to give up on a request.
+const StopRetrying time.Duration = -1
+
+// Retryer computes the waits between the attempts of one API request.
+type Retryer interface {
+	// NextDelay returns the wait before the next attempt, or StopRetrying.
+	NextDelay() time.Duration
+}
+
+// RetryStrategy creates the Retryer for each API request. Retryers may keep
+// state, such as the previous delay, so each request gets its own.
+type RetryStrategy func() Retryer
+
+// WithRetryStrategy replaces the default exponential backoff between retries
+// of an API request. Retries still stop after the maximum retry time (see
+// WithMaxRetryTime), and a Retry-After header still takes precedence.
+func WithRetryStrategy(strategy RetryStrategy) Option {
+	return func(o *clientOptions) {
+		o.retryStrategy = strategy
+	}
+}
+
+// WithMaxRetryTime bounds the total time spent retrying one API request.
+// A value <= 0 keeps DefaultMaxElapsedTime.
+func WithMaxRetryTime(d time.Duration) Option {
+	return func(o *clientOptions) {
+		o.maxRetryTime = d
+	}
+}
+
+// ExponentialRetry multiplies the delay by multiplier after each attempt,
+// starting at initial and capped at maxDelay, and randomizes each delay by
+// up to jitter (0.1 is ±10%).
+func ExponentialRetry(initial, maxDelay time.Duration, multiplier, jitter float64) RetryStrategy {
+	return func() Retryer {
+		b := backoff.NewExponentialBackOff()
+		b.InitialInterval = initial
+		b.MaxInterval = maxDelay
+		b.Multiplier = multiplier
+		b.RandomizationFactor = jitter
+		return backOffRetryer{b}
+	}
+}
+
+// ConstantRetry waits delay before every retry.
+func ConstantRetry(delay time.Duration) RetryStrategy {
+	return func() Retryer {
+		return backOffRetryer{backoff.NewConstantBackOff(delay)}
+	}
+}
+
+// DecorrelatedJitterRetry waits a random delay between base and three times
+// the previous delay, capped at maxDelay. Compared with exponential backoff,
+// it spreads the retries of many clients that failed at the same time.
+func DecorrelatedJitterRetry(base, maxDelay time.Duration) RetryStrategy {
+	return func() Retryer {
+		return &decorrelatedJitter{base: base, maxDelay: maxDelay, prev: base}
+	}
+}
+
+// DefaultRetryStrategy is the exponential backoff used when no strategy is
+// configured.
+func DefaultRetryStrategy() RetryStrategy {
+	return ExponentialRetry(DefaultInitialInterval, DefaultMaxInterval, DefaultMultiplier, DefaultRandomizationFactor)
+}
+
+// backOffRetryer adapts a backoff.BackOff to Retryer.
+type backOffRetryer struct {
+	b backoff.BackOff
+}
+
+func (r backOffRetryer) NextDelay() time.Duration {
+	return r.b.NextBackOff()
+}
+
+type decorrelatedJitter struct {
+	base     time.Duration
+	maxDelay time.Duration
+	prev     time.Duration
+}
+
+func (d *decorrelatedJitter) NextDelay() time.Duration {
+	upper := max(d.prev*3, d.base)
+	// Jitter only spreads retries; it does not need a cryptographic source.
+	next := d.base + time.Duration(rand.Int64N(int64(upper-d.base)+1)) //nolint:gosec // see above
+	d.prev = min(next, d.maxDelay)
+	return d.prev
+}
+
+// retryBackOff adapts the Retryer of one request to backoff.Retry.
+type retryBackOff struct {
+	r Retryer
+}
+
+func (b retryBackOff) NextBackOff() time.Duration {
+	if d := b.r.NextDelay(); d >= 0 {
+		return d
+	}
+	return backoff.Stop
+}
+
+func (retryBackOff) Reset() {}
+
+// retryOptions returns the backoff.Retry options for one request.
+func (c *HyperFleetClient) retryOptions() []backoff.RetryOption {
+	return []backoff.RetryOption{
+		backoff.WithBackOff(retryBackOff{c.retryStrategy()}),
+		backoff.WithMaxElapsedTime(c.maxRetryTime),
+	}
+}
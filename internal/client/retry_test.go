@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apierrors "github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/errors"
+)
+
+// countingRetryer returns delay for every retry and records how often it was
+// asked, or stops after stopAfter retries when stopAfter > 0.
+type countingRetryer struct {
+	calls     *atomic.Int32
+	delay     time.Duration
+	stopAfter int32
+}
+
+func (r countingRetryer) NextDelay() time.Duration {
+	n := r.calls.Add(1)
+	if r.stopAfter > 0 && n > r.stopAfter {
+		return StopRetrying
+	}
+	return r.delay
+}
+
+// flakyServer fails the first failures requests with 503 and then serves one
+// resource.
+func flakyServer(t *testing.T, failures int32, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) <= failures {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(createMockResourceList(
+			[]map[string]interface{}{createMockResource("cluster-1", testKindCluster)}, 1, 1))
+	}))
+}
+
+func TestFetchResources_RetryStrategy(t *testing.T) {
+	var requests, retries atomic.Int32
+	server := flakyServer(t, 3, &requests)
+	defer server.Close()
+
+	strategy := func() Retryer { return countingRetryer{calls: &retries} }
+	c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithRetryStrategy(strategy))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	start := time.Now()
+	resources, _, err := c.FetchResources(context.Background(), "clusters", nil)
+	if err != nil {
+		t.Fatalf("FetchResources failed: %v", err)
+	}
+	if len(resources) != 1 {
+		t.Errorf("Expected 1 resource, got %d", len(resources))
+	}
+	if requests.Load() != 4 || retries.Load() != 3 {
+		t.Errorf("Expected 4 requests and 3 retries, got %d and %d", requests.Load(), retries.Load())
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected retries without delay, took %s", elapsed)
+	}
+}
+
+func TestFetchResources_RetryStrategyStops(t *testing.T) {
+	var requests, retries atomic.Int32
+	server := flakyServer(t, 10, &requests)
+	defer server.Close()
+
+	strategy := func() Retryer { return countingRetryer{calls: &retries, stopAfter: 2} }
+	c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithRetryStrategy(strategy))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, _, err = c.FetchResources(context.Background(), "clusters", nil)
+	if err == nil {
+		t.Fatal("Expected error once the strategy stops retrying")
+	}
+	if requests.Load() != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests.Load())
+	}
+	if apiErr, ok := apierrors.AsAPIError(err); !ok || apiErr.Attempts != 3 {
+		t.Errorf("Expected APIError with 3 attempts, got %v", err)
+	}
+}
+
+func TestGetResource_MaxRetryTime(t *testing.T) {
+	var requests atomic.Int32
+	server := flakyServer(t, 100, &requests)
+	defer server.Close()
+
+	c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithRetryStrategy(ConstantRetry(20*time.Millisecond)), WithMaxRetryTime(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	start := time.Now()
+	if _, err := c.GetResource(context.Background(), "clusters", "cluster-1"); err == nil {
+		t.Fatal("Expected error after the maximum retry time")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected retries to stop after about 100ms, took %s", elapsed)
+	}
+	if n := requests.Load(); n < 2 {
+		t.Errorf("Expected several attempts, got %d", n)
+	}
+}
+
+func TestExponentialRetry(t *testing.T) {
+	r := ExponentialRetry(100*time.Millisecond, 400*time.Millisecond, 2, 0)()
+	want := []time.Duration{100, 200, 400, 400}
+	for i, w := range want {
+		if got := r.NextDelay(); got != w*time.Millisecond {
+			t.Errorf("delay %d = %s, want %s", i, got, w*time.Millisecond)
+		}
+	}
+}
+
+func TestConstantRetry(t *testing.T) {
+	r := ConstantRetry(250 * time.Millisecond)()
+	for i := range 3 {
+		if got := r.NextDelay(); got != 250*time.Millisecond {
+			t.Errorf("delay %d = %s, want 250ms", i, got)
+		}
+	}
+}
+
+func TestDecorrelatedJitterRetry(t *testing.T) {
+	base, maxDelay := 100*time.Millisecond, time.Second
+	r := DecorrelatedJitterRetry(base, maxDelay)()
+	prev := base
+	for i := range 50 {
+		got := r.NextDelay()
+		if got < base || got > maxDelay || got > 3*prev {
+			t.Fatalf("delay %d = %s, want between %s and min(%s, 3*%s)", i, got, base, maxDelay, prev)
+		}
+		prev = got
+	}
+}
+
+func TestDecorrelatedJitterRetry_Independent(t *testing.T) {
+	strategy := DecorrelatedJitterRetry(time.Millisecond, time.Hour)
+	a := strategy()
+	for range 20 {
+		a.NextDelay()
+	}
+	// A new request starts again from the base delay.
+	if got := strategy().NextDelay(); got > 3*time.Millisecond {
+		t.Errorf("Expected a new Retryer to start near the base delay, got %s", got)
+	}
+}
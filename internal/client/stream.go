@@ -0,0 +1,118 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/api/openapi"
+)
+
+// WithStreamingDecode makes list requests decode each page straight from the
+// response body, one item at a time, instead of reading the whole body into
+// memory first. It bounds the memory used per page to the decoded items,
+// which matters for large page sizes on fleets with tens of thousands of
+// resources. Decoded results are identical either way.
+func WithStreamingDecode() Option {
+	return func(o *clientOptions) {
+		o.streamingDecode = true
+	}
+}
+
+// decodeResourceListStream decodes a list response from r like
+// decodeResourceList, without buffering the body.
+func decodeResourceListStream(r io.Reader, ep *ResourceEndpoint) (openapi.ResourceList, error) {
+	var list openapi.ResourceList
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return list, err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return list, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return list, fmt.Errorf("expected object key, got %v", tok)
+		}
+		switch {
+		case strings.EqualFold(key, "items"):
+			items, err := decodeItemsStream(dec, ep)
+			if err != nil {
+				return list, err
+			}
+			list.Items = items
+		case strings.EqualFold(key, "page"):
+			err = dec.Decode(&list.Page)
+		case strings.EqualFold(key, "size"):
+			err = dec.Decode(&list.Size)
+		case strings.EqualFold(key, "total"):
+			err = dec.Decode(&list.Total)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return list, err
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return list, err
+	}
+	return list, nil
+}
+
+// decodeItemsStream decodes the items array of a list response, applying the
+// endpoint's status field mapping and kind to each item. A null array yields
+// no items.
+func decodeItemsStream(dec *json.Decoder, ep *ResourceEndpoint) ([]openapi.Resource, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("expected items array, got %v", tok)
+	}
+
+	mapped := ep != nil && ep.StatusField != "" && ep.StatusField != defaultStatusField
+	var items []openapi.Resource
+	for dec.More() {
+		var item openapi.Resource
+		if mapped {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, err
+			}
+			err = decodeMappedResource(raw, ep.StatusField, &item)
+		} else {
+			err = dec.Decode(&item)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := applyKind(&item, ep); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token and fails unless it is want.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %q, got %v", want, tok)
+	}
+	return nil
+}
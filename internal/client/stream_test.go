@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeResourceListStream_MatchesDecodeResourceList(t *testing.T) {
+	items := []map[string]interface{}{
+		createMockResource("cluster-1", testKindCluster),
+		createMockResource("cluster-2", ""),
+	}
+	items[0]["extra"] = map[string]interface{}{"nested": []int{1, 2}}
+	body, err := json.Marshal(createMockResourceList(items, 1, 2))
+	if err != nil {
+		t.Fatalf("Failed to marshal list: %v", err)
+	}
+
+	mappedItem := createMockResource("addon-1", "")
+	mappedItem["state"] = mappedItem[keyStatus]
+	delete(mappedItem, keyStatus)
+	mappedBody, err := json.Marshal(createMockResourceList([]map[string]interface{}{mappedItem}, 1, 1))
+	if err != nil {
+		t.Fatalf("Failed to marshal list: %v", err)
+	}
+
+	tests := []struct {
+		ep   *ResourceEndpoint
+		name string
+		body string
+	}{
+		{name: "default endpoint", body: string(body)},
+		{name: "kind applied", body: string(body), ep: &ResourceEndpoint{Kind: testKindCluster}},
+		{name: "status field mapping", body: string(mappedBody), ep: &ResourceEndpoint{Kind: "Addon", StatusField: "state"}},
+		{name: "null items", body: `{"items":null,"page":1,"size":0,"total":0}`},
+		{name: "empty items", body: `{"items":[],"page":1,"size":0,"total":0,"kind":"ClusterList"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := decodeResourceList([]byte(tt.body), tt.ep)
+			if err != nil {
+				t.Fatalf("decodeResourceList failed: %v", err)
+			}
+			got, err := decodeResourceListStream(strings.NewReader(tt.body), tt.ep)
+			if err != nil {
+				t.Fatalf("decodeResourceListStream failed: %v", err)
+			}
+			if len(got.Items) != len(want.Items) || (len(want.Items) > 0 && !reflect.DeepEqual(got, want)) {
+				t.Errorf("streaming decode differs:\ngot  %+v\nwant %+v", got, want)
+			}
+			if got.Page != want.Page || got.Size != want.Size || got.Total != want.Total {
+				t.Errorf("page metadata differs: got %d/%d/%d, want %d/%d/%d",
+					got.Page, got.Size, got.Total, want.Page, want.Size, want.Total)
+			}
+		})
+	}
+}
+
+func TestDecodeResourceListStream_Errors(t *testing.T) {
+	tests := []struct {
+		ep   *ResourceEndpoint
+		name string
+		body string
+	}{
+		{name: "not an object", body: `[]`},
+		{name: "items not an array", body: `{"items":{}}`},
+		{name: "truncated", body: `{"items":[{"id":"cluster-1"`},
+		{name: "non-string key", body: `{1:[]}`},
+		{name: "missing colon", body: `{"page" 1,"items":[]}`},
+		{name: "trailing comma", body: `{"items":[],}`},
+		{
+			name: "wrong kind",
+			body: `{"items":[{"id":"cluster-1","kind":"NodePool"}]}`,
+			ep:   &ResourceEndpoint{Kind: "Cluster"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeResourceListStream(strings.NewReader(tt.body), tt.ep); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestFetchResources_StreamingDecode(t *testing.T) {
+	const total = 45
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get(keyPage))
+		start := (page - 1) * 20
+		end := min(start+20, total)
+		items := make([]map[string]interface{}, 0, end-start)
+		for i := start; i < end; i++ {
+			items = append(items, createMockResource(fmt.Sprintf("cluster-%d", i), testKindCluster))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(createMockResourceList(items, page, total)); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", 20, "", 0,
+		WithStreamingDecode())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resources, _, err := c.FetchResources(context.Background(), "clusters", nil)
+	if err != nil {
+		t.Fatalf("FetchResources failed: %v", err)
+	}
+	if len(resources) != total {
+		t.Fatalf("Expected %d resources, got %d", total, len(resources))
+	}
+	if resources[44].ID != "cluster-44" || len(resources[44].Status.Conditions) == 0 {
+		t.Errorf("Unexpected last resource: %+v", resources[44])
+	}
+}
+
+func TestFetchResources_SpecAndLabels(t *testing.T) {
+	item := createMockResource("cluster-1", testKindCluster)
+	item[keySpec] = map[string]interface{}{
+		"region":   "us-east-1",
+		"platform": map[string]interface{}{"type": "gcp"},
+	}
+	item["labels"] = map[string]string{"shard": "3"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(createMockResourceList([]map[string]interface{}{item}, 1, 1))
+	}))
+	defer server.Close()
+
+	for _, streaming := range []bool{false, true} {
+		var opts []Option
+		if streaming {
+			opts = append(opts, WithStreamingDecode())
+		}
+		c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+			opts...)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		resources, _, err := c.FetchResources(context.Background(), "clusters", nil)
+		if err != nil || len(resources) != 1 {
+			t.Fatalf("streaming=%v: FetchResources returned %d resources, err %v", streaming, len(resources), err)
+		}
+
+		m := resources[0].ToMap()
+		spec, ok := m["spec"].(map[string]interface{})
+		if !ok || spec["region"] != "us-east-1" {
+			t.Errorf("streaming=%v: expected spec.region in the resource map, got %v", streaming, m["spec"])
+		}
+		if platform, ok := spec["platform"].(map[string]interface{}); !ok || platform["type"] != "gcp" {
+			t.Errorf("streaming=%v: expected nested spec fields to be kept, got %v", streaming, spec["platform"])
+		}
+		if labels, ok := m["labels"].(map[string]interface{}); !ok || labels["shard"] != "3" {
+			t.Errorf("streaming=%v: expected labels in the resource map, got %v", streaming, m["labels"])
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSOptions describes the TLS settings used for HTTPS connections to the
+// HyperFleet API. CertFile and KeyFile enable mutual TLS and must be set
+// together; CAFile replaces the system trust store when non-empty. FIPS
+// restricts the configuration to FIPS 140-3 approved algorithms.
+type TLSOptions struct {
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	MinVersion         string // "1.2" or "1.3"; empty defaults to TLS 1.2
+	InsecureSkipVerify bool
+	FIPS               bool
+}
+
+// fipsCipherSuites are the FIPS-approved TLS 1.2 cipher suites. TLS 1.3
+// suites are not configurable; the Go FIPS module limits them to AES-GCM.
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// fipsCurves are the FIPS-approved key exchange groups.
+var fipsCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+// fipsMinRSABits is the smallest RSA key accepted for a client certificate in FIPS mode.
+const fipsMinRSABits = 2048
+
+// tlsVersions maps the configured min_version strings to crypto/tls constants.
+var tlsVersions = map[string]uint16{
+	"":    tls.VersionTLS12,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// NewTLSConfig builds a *tls.Config from opts. Certificate and CA files are
+// read once here, so a missing or malformed file fails at startup rather than
+// on the first request.
+func NewTLSConfig(opts TLSOptions) (*tls.Config, error) {
+	minVersion, ok := tlsVersions[opts.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TLS min version %q (valid: 1.2, 1.3)", opts.MinVersion)
+	}
+
+	if opts.FIPS && opts.InsecureSkipVerify {
+		return nil, fmt.Errorf("insecure_skip_verify is not allowed in FIPS mode")
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         minVersion,
+		InsecureSkipVerify: opts.InsecureSkipVerify, //nolint:gosec // explicit operator opt-in for test environments
+	}
+	if opts.FIPS {
+		cfg.CipherSuites = fipsCipherSuites
+		cfg.CurvePreferences = fipsCurves
+	}
+
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file %s: %w", opts.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA file %s contains no valid PEM certificates", opts.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if (opts.CertFile == "") != (opts.KeyFile == "") {
+		return nil, fmt.Errorf("client certificate and key must be set together")
+	}
+	if opts.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		if opts.FIPS {
+			if key, ok := cert.PrivateKey.(*rsa.PrivateKey); ok && key.N.BitLen() < fipsMinRSABits {
+				return nil, fmt.Errorf("client certificate RSA key is %d bits; FIPS mode requires at least %d",
+					key.N.BitLen(), fipsMinRSABits)
+			}
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
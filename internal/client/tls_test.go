@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeClientCert generates a self-signed client certificate and writes the
+// PEM-encoded cert and key into dir. It returns the file paths and the parsed
+// certificate so a test server can trust it.
+func writeClientCert(t *testing.T, dir string) (certFile, keyFile string, cert *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-sentinel"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile, cert
+}
+
+// writeServerCA writes the TLS test server's certificate as a CA bundle.
+func writeServerCA(t *testing.T, dir string, server *httptest.Server) string {
+	t.Helper()
+	caFile := filepath.Join(dir, "ca.crt")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return caFile
+}
+
+func TestNewTLSConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+	badPEM := filepath.Join(dir, "bad.pem")
+	if err := os.WriteFile(badPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		wantErr string
+		opts    TLSOptions
+	}{
+		{
+			name:    "unsupported min version",
+			opts:    TLSOptions{MinVersion: "1.0"},
+			wantErr: "unsupported TLS min version",
+		},
+		{
+			name:    "missing CA file",
+			opts:    TLSOptions{CAFile: filepath.Join(dir, "missing.pem")},
+			wantErr: "reading CA file",
+		},
+		{
+			name:    "CA file without certificates",
+			opts:    TLSOptions{CAFile: badPEM},
+			wantErr: "contains no valid PEM certificates",
+		},
+		{
+			name:    "cert without key",
+			opts:    TLSOptions{CertFile: badPEM},
+			wantErr: "must be set together",
+		},
+		{
+			name:    "invalid key pair",
+			opts:    TLSOptions{CertFile: badPEM, KeyFile: badPEM},
+			wantErr: "loading client certificate",
+		},
+		{
+			name:    "insecure skip verify in FIPS mode",
+			opts:    TLSOptions{InsecureSkipVerify: true, FIPS: true},
+			wantErr: "not allowed in FIPS mode",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTLSConfig(tt.opts)
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestNewTLSConfig_MinVersion(t *testing.T) {
+	tests := []struct {
+		minVersion string
+		want       uint16
+	}{
+		{minVersion: "", want: tls.VersionTLS12},
+		{minVersion: "1.2", want: tls.VersionTLS12},
+		{minVersion: "1.3", want: tls.VersionTLS13},
+	}
+	for _, tt := range tests {
+		t.Run("min_version="+tt.minVersion, func(t *testing.T) {
+			cfg, err := NewTLSConfig(TLSOptions{MinVersion: tt.minVersion})
+			if err != nil {
+				t.Fatalf("NewTLSConfig: %v", err)
+			}
+			if cfg.MinVersion != tt.want {
+				t.Errorf("MinVersion = %x, want %x", cfg.MinVersion, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTLSConfig_FIPS(t *testing.T) {
+	cfg, err := NewTLSConfig(TLSOptions{FIPS: true})
+	if err != nil {
+		t.Fatalf("NewTLSConfig: %v", err)
+	}
+	if len(cfg.CipherSuites) != len(fipsCipherSuites) {
+		t.Errorf("CipherSuites = %v, want %v", cfg.CipherSuites, fipsCipherSuites)
+	}
+	for _, id := range cfg.CipherSuites {
+		name := tls.CipherSuiteName(id)
+		if !strings.Contains(name, "_GCM_") || !strings.HasPrefix(name, "TLS_ECDHE_") {
+			t.Errorf("non-approved cipher suite %s in FIPS mode", name)
+		}
+	}
+	if len(cfg.CurvePreferences) != len(fipsCurves) {
+		t.Errorf("CurvePreferences = %v, want %v", cfg.CurvePreferences, fipsCurves)
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile, _ := writeClientCert(t, dir)
+	if _, err := NewTLSConfig(TLSOptions{CertFile: certFile, KeyFile: keyFile, FIPS: true}); err != nil {
+		t.Errorf("expected ECDSA P-256 client certificate to be accepted in FIPS mode, got %v", err)
+	}
+
+	plain, err := NewTLSConfig(TLSOptions{})
+	if err != nil {
+		t.Fatalf("NewTLSConfig: %v", err)
+	}
+	if plain.CipherSuites != nil || plain.CurvePreferences != nil {
+		t.Error("expected default cipher suites and curves outside FIPS mode")
+	}
+}
+
+func TestNewHyperFleetClient_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, clientCert := writeClientCert(t, dir)
+
+	var peerCN string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			peerCN = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(createMockResourceList(nil, 1, 0)); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := writeServerCA(t, dir, server)
+
+	t.Run("with client certificate", func(t *testing.T) {
+		tlsCfg, err := NewTLSConfig(TLSOptions{CAFile: caFile, CertFile: certFile, KeyFile: keyFile})
+		if err != nil {
+			t.Fatalf("NewTLSConfig: %v", err)
+		}
+		c, err := NewHyperFleetClient(server.URL, 5*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+			WithTLSConfig(tlsCfg))
+		if err != nil {
+			t.Fatalf("NewHyperFleetClient: %v", err)
+		}
+		if _, _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
+			t.Fatalf("FetchResources: %v", err)
+		}
+		if peerCN != "test-sentinel" {
+			t.Errorf("server saw client CN %q, want %q", peerCN, "test-sentinel")
+		}
+	})
+
+	t.Run("without client certificate", func(t *testing.T) {
+		tlsCfg, err := NewTLSConfig(TLSOptions{CAFile: caFile})
+		if err != nil {
+			t.Fatalf("NewTLSConfig: %v", err)
+		}
+		c, err := NewHyperFleetClient(server.URL, 5*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+			WithTLSConfig(tlsCfg))
+		if err != nil {
+			t.Fatalf("NewHyperFleetClient: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := c.VerifyConnectivity(ctx, "clusters"); err == nil {
+			t.Fatal("expected handshake failure without client certificate, got nil")
+		}
+	})
+}
@@ -151,7 +151,7 @@ func TestIsTokenError(t *testing.T) {
 	}{
 		{err: tokenErr, name: "direct TokenError", want: true},
 		{err: fmt.Errorf("outer: %w", tokenErr), name: "TokenError wrapped with fmt.Errorf", want: true},
-		{err: &APIError{cause: tokenErr, Message: "msg"}, name: "TokenError buried in APIError cause", want: true},
+		{err: &APIError{Cause: tokenErr, Message: "msg"}, name: "TokenError buried in APIError cause", want: true},
 		{err: fmt.Errorf("something else"), name: "plain error", want: false},
 		{err: nil, name: "nil", want: false},
 	}
@@ -214,7 +214,7 @@ func TestNewHyperFleetClient_BearerToken(t *testing.T) {
 		t.Fatalf("NewHyperFleetClient: %v", err)
 	}
 
-	if _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
+	if _, _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
 		t.Fatalf("FetchResources: %v", err)
 	}
 
@@ -247,7 +247,7 @@ func TestNewHyperFleetClient_NoAuthHeader_WhenNoTokenPath(t *testing.T) {
 		t.Fatalf("NewHyperFleetClient: %v", err)
 	}
 
-	if _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
+	if _, _, err := c.FetchResources(context.Background(), "clusters", nil); err != nil {
 		t.Fatalf("FetchResources: %v", err)
 	}
 
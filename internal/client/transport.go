@@ -0,0 +1,69 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// TransportSettings tunes the HTTP transport used to reach the API. Zero
+// values keep the net/http defaults.
+type TransportSettings struct {
+	// HTTPProxy, HTTPSProxy, and NoProxy override the HTTP_PROXY, HTTPS_PROXY,
+	// and NO_PROXY environment variables. An empty field falls back to the
+	// environment variable.
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+	// DialTimeout bounds establishing the TCP connection.
+	DialTimeout time.Duration
+	// IdleConnTimeout is how long an idle keep-alive connection is kept open.
+	IdleConnTimeout time.Duration
+	// MaxIdleConns limits idle connections across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits idle connections to the API host.
+	MaxIdleConnsPerHost int
+}
+
+// WithTransport applies proxy, dial timeout, and connection pool settings to
+// the HTTP transport.
+func WithTransport(settings TransportSettings) Option {
+	return func(o *clientOptions) {
+		o.transport = &settings
+	}
+}
+
+// apply sets the non-zero settings on t.
+func (s *TransportSettings) apply(t *http.Transport) {
+	proxy := httpproxy.FromEnvironment()
+	if s.HTTPProxy != "" {
+		proxy.HTTPProxy = s.HTTPProxy
+	}
+	if s.HTTPSProxy != "" {
+		proxy.HTTPSProxy = s.HTTPSProxy
+	}
+	if s.NoProxy != "" {
+		proxy.NoProxy = s.NoProxy
+	}
+	proxyFunc := proxy.ProxyFunc()
+	t.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	if s.DialTimeout > 0 {
+		dialer := &net.Dialer{Timeout: s.DialTimeout, KeepAlive: 30 * time.Second}
+		t.DialContext = dialer.DialContext
+	}
+	if s.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = s.IdleConnTimeout
+	}
+	if s.MaxIdleConns > 0 {
+		t.MaxIdleConns = s.MaxIdleConns
+	}
+	if s.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = s.MaxIdleConnsPerHost
+	}
+}
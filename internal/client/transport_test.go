@@ -0,0 +1,80 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTransportSettings_Proxy(t *testing.T) {
+	t.Setenv("HTTP_PROXY", "")
+	t.Setenv("HTTPS_PROXY", "http://env-proxy:3128")
+	t.Setenv("NO_PROXY", "")
+
+	settings := TransportSettings{
+		HTTPProxy: "http://config-proxy:8080",
+		NoProxy:   "internal.example.com",
+	}
+	tr := &http.Transport{}
+	settings.apply(tr)
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "http://api.example.com/clusters", want: "http://config-proxy:8080"},
+		{target: "https://api.example.com/clusters", want: "http://env-proxy:3128"},
+		{target: "http://api.internal.example.com/clusters", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.target, nil)
+			if err != nil {
+				t.Fatalf("NewRequest failed: %v", err)
+			}
+			proxyURL, err := tr.Proxy(req)
+			if err != nil {
+				t.Fatalf("Proxy returned error: %v", err)
+			}
+			got := ""
+			if proxyURL != nil {
+				got = proxyURL.String()
+			}
+			if got != tt.want {
+				t.Errorf("proxy for %s = %q, want %q", tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransportSettings_ConnectionPool(t *testing.T) {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	defaults := tr.Clone()
+
+	settings := TransportSettings{
+		DialTimeout:         2 * time.Second,
+		IdleConnTimeout:     45 * time.Second,
+		MaxIdleConns:        7,
+		MaxIdleConnsPerHost: 3,
+	}
+	settings.apply(tr)
+
+	if tr.IdleConnTimeout != 45*time.Second {
+		t.Errorf("IdleConnTimeout = %s, want 45s", tr.IdleConnTimeout)
+	}
+	if tr.MaxIdleConns != 7 {
+		t.Errorf("MaxIdleConns = %d, want 7", tr.MaxIdleConns)
+	}
+	if tr.MaxIdleConnsPerHost != 3 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 3", tr.MaxIdleConnsPerHost)
+	}
+	if tr.DialContext == nil {
+		t.Error("expected DialContext to be set")
+	}
+
+	// Zero values keep the defaults.
+	(&TransportSettings{}).apply(defaults)
+	if defaults.MaxIdleConns != http.DefaultTransport.(*http.Transport).MaxIdleConns {
+		t.Errorf("MaxIdleConns = %d, want default", defaults.MaxIdleConns)
+	}
+}
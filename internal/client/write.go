@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/api/openapi"
+)
+
+// ErrWritesDisabled is returned by the write methods of a client created
+// without WithWrites.
+var ErrWritesDisabled = errors.New("writes to the HyperFleet API are disabled")
+
+// ResourceLabeler is implemented by clients that can update the labels of a
+// resource. The Sentinel uses it to tag the resources it publishes events for.
+type ResourceLabeler interface {
+	PatchResourceLabels(ctx context.Context, resourceType, id string, labels map[string]string) error
+}
+
+// WithWrites allows the client to modify resources through its write methods.
+// The Sentinel only reads from the API by default; without this option every
+// write method fails with ErrWritesDisabled before contacting the API.
+func WithWrites() Option {
+	return func(o *clientOptions) {
+		o.writes = true
+	}
+}
+
+// PatchResourceLabels replaces the labels of a resource with labels through
+// PATCH /api/hyperfleet/{version}/{resourceType}/{id}. The API replaces the
+// whole label map, so labels must hold every label the resource should keep.
+//
+// The request is sent once, without retries, and does not count towards the
+// circuit breaker: writes are best effort and a missing write permission must
+// not stop the Sentinel from polling. It waits for the rate limiter like any
+// other request.
+func (c *HyperFleetClient) PatchResourceLabels(
+	ctx context.Context, resourceType, id string, labels map[string]string,
+) (err error) {
+	if !c.writes {
+		return ErrWritesDisabled
+	}
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	path, _, err := c.collectionPath(resourceType)
+	if err != nil {
+		return err
+	}
+	if err := validateLabelSelector(labels); err != nil {
+		return fmt.Errorf("invalid labels: %w", err)
+	}
+
+	body, err := json.Marshal(openapi.ResourcePatchRequest{Labels: &labels})
+	if err != nil {
+		return fmt.Errorf("failed to encode patch request: %w", err)
+	}
+
+	reqURL := fmt.Sprintf("%s%s/%s", c.baseURL, path, url.PathEscape(id))
+	defer func() {
+		c.annotateRequest(err, http.MethodPatch, reqURL)
+		if err != nil {
+			err = fmt.Errorf("failed to patch labels of %s/%s: %w", resourceType, id, err)
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return &APIError{StatusCode: 0, Message: fmt.Sprintf("failed to create request: %v", err), Retriable: false}
+	}
+	c.setRequestHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+	if authErr := c.setAuthHeader(req); authErr != nil {
+		return &APIError{StatusCode: 0, Message: authErr.Error(), Retriable: false, Cause: authErr}
+	}
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return wrapNetworkError(err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			c.log.Debugf(ctx, "failed to close response body: %v", closeErr)
+		}
+	}()
+
+	if httpErr := checkHTTPStatus(resp); httpErr != nil {
+		return httpErr
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPatchResourceLabels(t *testing.T) {
+	var (
+		method, path, contentType string
+		body                      map[string]map[string]string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, contentType = r.Method, r.URL.Path, r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode patch body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(createMockResource("cluster-1", testKindCluster))
+	}))
+	defer server.Close()
+
+	c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithWrites())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	labels := map[string]string{"shard": "1", "sentinel.hyperfleet.io/last-triggered": "20261015T120000Z"}
+	if err := c.PatchResourceLabels(context.Background(), "clusters", "cluster-1", labels); err != nil {
+		t.Fatalf("PatchResourceLabels failed: %v", err)
+	}
+	if method != http.MethodPatch || path != "/api/hyperfleet/v1/clusters/cluster-1" {
+		t.Errorf("Expected PATCH /api/hyperfleet/v1/clusters/cluster-1, got %s %s", method, path)
+	}
+	if contentType != "application/json" {
+		t.Errorf("Expected Content-Type application/json, got %q", contentType)
+	}
+	if got := body["labels"]; len(got) != 2 || got["shard"] != "1" {
+		t.Errorf("Expected the full label map in the patch body, got %v", body)
+	}
+}
+
+func TestPatchResourceLabels_WritesDisabled(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	err := newTestClient(t, server.URL, 10*time.Second).
+		PatchResourceLabels(context.Background(), "clusters", "cluster-1", map[string]string{"a": "b"})
+	if !errors.Is(err, ErrWritesDisabled) {
+		t.Errorf("Expected ErrWritesDisabled, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request without WithWrites, got %d", requests)
+	}
+}
+
+func TestPatchResourceLabels_Errors(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	c, err := NewHyperFleetClient(server.URL, 10*time.Second, "test-sentinel", "test", DefaultPageSize, "", 0,
+		WithWrites(), WithCircuitBreaker(1, time.Minute))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	err = c.PatchResourceLabels(context.Background(), "clusters", "cluster-1", map[string]string{"a": "b"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden || apiErr.Method != http.MethodPatch {
+		t.Fatalf("Expected a 403 APIError for the PATCH request, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "clusters/cluster-1") {
+		t.Errorf("Expected error to name the resource, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected a single attempt, got %d", requests)
+	}
+	if state := c.CircuitState(); state != CircuitClosed {
+		t.Errorf("Expected failed writes not to open the circuit, got %s", state)
+	}
+
+	err = c.PatchResourceLabels(context.Background(), "clusters", "cluster-1", map[string]string{"bad key": "b"})
+	if err == nil || requests != 1 {
+		t.Errorf("Expected invalid labels to be rejected before a request, got %v", err)
+	}
+}
@@ -4,11 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"text/template"
 	"time"
+	"unicode"
 
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/fips"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/schedule"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -18,102 +27,1930 @@ const (
 	defaultConfigFile = "/etc/hyperfleet/config.yaml"
 )
 
+// Limits on config input. Parts of the configuration, such as resource
+// selectors and message_data, may come from tenant-provided fragments; the
+// limits keep a malformed fragment from making loading or CEL compilation
+// arbitrarily expensive.
+const (
+	// maxConfigFileSize is the largest config file LoadConfig reads.
+	maxConfigFileSize = 1 << 20
+	// maxExpressionLength is the longest CEL expression accepted.
+	maxExpressionLength = 4096
+	// maxMessageDataDepth is the deepest nesting accepted in message_data.
+	maxMessageDataDepth = 16
+	// maxLabelValueLength is the longest resource_selector value accepted.
+	maxLabelValueLength = 63
+)
+
 // EnvPrefix is the prefix for all environment variables that override sentinel config
 const EnvPrefix = "HYPERFLEET"
 
-// LabelSelector represents a label key-value pair for resource filtering
-type LabelSelector struct {
-	Label string `yaml:"label" mapstructure:"label"`
-	Value string `yaml:"value" mapstructure:"value"`
+// LabelSelector represents a label key-value pair for resource filtering
+type LabelSelector struct {
+	Label string `yaml:"label" mapstructure:"label"`
+	Value string `yaml:"value" mapstructure:"value"`
+}
+
+// LabelSelectorList is a list of label selectors
+type LabelSelectorList []LabelSelector
+
+// Param is a named CEL expression. Params must be listed in dependency order:
+// if param B references param A, A must appear before B so the CEL runtime
+// can resolve it during evaluation. Out-of-order references cause a runtime
+// evaluation error (not a startup error).
+type Param struct {
+	Name string `mapstructure:"name"`
+	Expr string `mapstructure:"expr"`
+}
+
+// Rule is a named CEL expression that evaluates to a boolean. A resource is
+// published by the first rule that is true, and the rule's name is reported
+// with the decision.
+type Rule struct {
+	Name string `mapstructure:"name"`
+	Expr string `mapstructure:"expr"`
+}
+
+// PausedLabel is the well-known resource label that pauses publishing for a
+// resource while its value is "true". Unlike MaintenanceLabel it needs no
+// configuration, so SREs can exclude a problem resource from any Sentinel.
+const PausedLabel = "sentinel.hyperfleet.io/paused"
+
+// MaxAgeLabelPrefix prefixes the resource labels read by the max_age CEL
+// function: max_age("ready", ...) reads sentinel.hyperfleet.io/max-age-ready.
+const MaxAgeLabelPrefix = "sentinel.hyperfleet.io/max-age-"
+
+// MaxAgeOverridesConfig enables per-resource max age overrides. The max_age
+// CEL function then returns the duration in a resource's max-age label,
+// clamped to [Min, Max], instead of its default.
+type MaxAgeOverridesConfig struct {
+	Min time.Duration `yaml:"min" mapstructure:"min"`
+	Max time.Duration `yaml:"max" mapstructure:"max"`
+}
+
+// Validate returns an error if the max age bounds are invalid.
+func (m *MaxAgeOverridesConfig) Validate() error {
+	if m.Min <= 0 {
+		return fmt.Errorf("min must be positive, got %s", m.Min)
+	}
+	if m.Max < m.Min {
+		return fmt.Errorf("max (%s) must not be less than min (%s)", m.Max, m.Min)
+	}
+	return nil
+}
+
+// Clamp returns d limited to [Min, Max].
+func (m *MaxAgeOverridesConfig) Clamp(d time.Duration) time.Duration {
+	return min(max(d, m.Min), m.Max)
+}
+
+// Condition actions bind a resource status condition to a decision that is
+// taken before message_decision params and result are evaluated.
+const (
+	// ConditionActionSkip skips a resource while the condition matches.
+	ConditionActionSkip = "skip"
+	// ConditionActionPublish publishes a resource while the condition matches,
+	// regardless of the result expression.
+	ConditionActionPublish = "publish"
+)
+
+// ConditionAction binds a status condition to an action. The binding matches
+// a resource whose condition of type Type has status Status ("True" when
+// empty). Action is ConditionActionSkip or ConditionActionPublish.
+type ConditionAction struct {
+	Type   string `yaml:"type" mapstructure:"type"`
+	Status string `yaml:"status,omitempty" mapstructure:"status"`
+	Action string `yaml:"action" mapstructure:"action"`
+}
+
+// MatchStatus returns the condition status the binding matches.
+func (a ConditionAction) MatchStatus() string {
+	if a.Status == "" {
+		return "True"
+	}
+	return a.Status
+}
+
+// DefaultStuckDeletionPhases are the deletion phases checked by stuck_deletion
+// when it lists none.
+var DefaultStuckDeletionPhases = []string{"Deleting", "Deprovisioning"}
+
+// StuckDeletionConfig detects resources stuck in a deletion phase. A resource
+// whose condition of one of Phases has been True for at least Timeout is
+// published with a stuck event type instead of a reconcile event.
+type StuckDeletionConfig struct {
+	Phases  []string      `yaml:"phases,omitempty" mapstructure:"phases"`
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// Validate returns an error if the stuck deletion settings are invalid.
+func (s *StuckDeletionConfig) Validate() error {
+	if s.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive, got %s", s.Timeout)
+	}
+	seen := make(map[string]bool, len(s.Phases))
+	for i, phase := range s.Phases {
+		if phase == "" || strings.ContainsFunc(phase, unicode.IsSpace) {
+			return fmt.Errorf("phases[%d]: must be non-empty without whitespace, got %q", i, phase)
+		}
+		if seen[phase] {
+			return fmt.Errorf("phase %q is listed more than once", phase)
+		}
+		seen[phase] = true
+	}
+	return nil
+}
+
+// PhaseTypes returns the condition types of the deletion phases.
+func (s *StuckDeletionConfig) PhaseTypes() []string {
+	if len(s.Phases) == 0 {
+		return DefaultStuckDeletionPhases
+	}
+	return s.Phases
+}
+
+// ClockSkewConfig bounds how far ahead of the Sentinel's clock the last
+// updated time of a resource condition may be. A time further ahead than
+// Tolerance is reported as clock skew; with FallbackToCreatedTime it is
+// replaced by the resource's created time for evaluation.
+type ClockSkewConfig struct {
+	Tolerance             time.Duration `yaml:"tolerance" mapstructure:"tolerance"`
+	FallbackToCreatedTime bool          `yaml:"fallback_to_created_time,omitempty" mapstructure:"fallback_to_created_time"`
+}
+
+// Validate returns an error if the clock skew settings are invalid.
+func (c *ClockSkewConfig) Validate() error {
+	if c.Tolerance < 0 {
+		return fmt.Errorf("tolerance must not be negative, got %s", c.Tolerance)
+	}
+	return nil
+}
+
+// MaxMaintenanceWindowDuration bounds the length of a maintenance window.
+const MaxMaintenanceWindowDuration = 7 * 24 * time.Hour
+
+// MaintenanceWindow suppresses publishing for the resources matching Selector
+// (every resource when empty) for Duration after each time Schedule, a
+// five-field cron expression, fires in Timezone (UTC when empty).
+type MaintenanceWindow struct {
+	Name     string            `yaml:"name" mapstructure:"name"`
+	Schedule string            `yaml:"schedule" mapstructure:"schedule"`
+	Timezone string            `yaml:"timezone,omitempty" mapstructure:"timezone"`
+	Selector LabelSelectorList `yaml:"selector,omitempty" mapstructure:"selector"`
+	Duration time.Duration     `yaml:"duration" mapstructure:"duration"`
+}
+
+// Validate returns an error if the maintenance window is invalid.
+func (w *MaintenanceWindow) Validate() error {
+	if w.Name == "" || strings.ContainsFunc(w.Name, unicode.IsSpace) || strings.ContainsFunc(w.Name, unicode.IsControl) {
+		return fmt.Errorf("name must be non-empty without whitespace, got %q", w.Name)
+	}
+	if _, err := schedule.Parse(w.Schedule); err != nil {
+		return fmt.Errorf("schedule: %w", err)
+	}
+	if _, err := w.Location(); err != nil {
+		return fmt.Errorf("timezone: %w", err)
+	}
+	if err := w.Selector.validate("selector"); err != nil {
+		return err
+	}
+	if w.Duration <= 0 || w.Duration > MaxMaintenanceWindowDuration {
+		return fmt.Errorf("duration must be positive and at most %s, got %s", MaxMaintenanceWindowDuration, w.Duration)
+	}
+	return nil
+}
+
+// Location returns the time zone the schedule is evaluated in.
+func (w *MaintenanceWindow) Location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(w.Timezone)
+}
+
+// MessageDecisionConfig represents configurable CEL-based decision logic.
+// Params are evaluated in the order they are defined.
+// Result is a CEL expression that evaluates to a boolean. Rules replace
+// Result with an ordered list of named boolean expressions; exactly one of
+// the two must be set.
+// MaintenanceLabel optionally names a resource label that pauses publishing for
+// a resource while its value is "true", regardless of Result.
+// ConditionActions are checked in order after the maintenance label; the first
+// binding whose condition matches decides, and Result or Rules are evaluated
+// only when none matches.
+// MaxAgeOverrides optionally lets resources override the max_age durations of
+// the expressions through labels, within its bounds.
+// MaintenanceWindows skip the resources they select while a window is open,
+// after the maintenance label and before ConditionActions.
+// TerminalConditions lists condition types that mark a resource as done for
+// good, such as Deleted; a resource with one of them True is never published.
+// StuckDeletion optionally publishes a stuck event for resources that stay in
+// a deletion phase for too long, before ConditionActions.
+// ClockSkew optionally reports condition last updated times in the future.
+type MessageDecisionConfig struct {
+	MaxAgeOverrides    *MaxAgeOverridesConfig `yaml:"max_age_overrides,omitempty" mapstructure:"max_age_overrides"`
+	StuckDeletion      *StuckDeletionConfig   `yaml:"stuck_deletion,omitempty" mapstructure:"stuck_deletion"`
+	ClockSkew          *ClockSkewConfig       `yaml:"clock_skew,omitempty" mapstructure:"clock_skew"`
+	Result             string                 `mapstructure:"result"`
+	MaintenanceLabel   string                 `yaml:"maintenance_label,omitempty" mapstructure:"maintenance_label"`
+	Params             []Param                `mapstructure:"params"`
+	Rules              []Rule                 `yaml:"rules,omitempty" mapstructure:"rules"`
+	ConditionActions   []ConditionAction      `yaml:"condition_actions,omitempty" mapstructure:"condition_actions"`
+	MaintenanceWindows []MaintenanceWindow    `yaml:"maintenance_windows,omitempty" mapstructure:"maintenance_windows"`
+	TerminalConditions []string               `yaml:"terminal_conditions,omitempty" mapstructure:"terminal_conditions"`
+}
+
+// SentinelConfig represents the Sentinel configuration
+type SentinelConfig struct {
+	Log              LogConfig                     `yaml:"log,omitempty" mapstructure:"log"`
+	Sentinel         SentinelInfo                  `yaml:"sentinel" mapstructure:"sentinel"`
+	ResourceType     string                        `yaml:"resource_type" mapstructure:"resource_type"`
+	ResourceTypes    map[string]ResourceTypeConfig `yaml:"resource_types,omitempty" mapstructure:"resource_types"`
+	Watchers         []WatcherConfig               `yaml:"watchers,omitempty" mapstructure:"watchers"`
+	Clients          ClientsConfig                 `yaml:"clients" mapstructure:"clients"`
+	MessageData      map[string]interface{}        `yaml:"message_data,omitempty" mapstructure:"message_data"`
+	MessageSchema    *MessageSchemaConfig          `yaml:"message_schema,omitempty" mapstructure:"message_schema"`
+	MessageDecision  *MessageDecisionConfig        `yaml:"message_decision,omitempty" mapstructure:"message_decision"`
+	DecisionPolicy   *DecisionPolicyConfig         `yaml:"decision_policy,omitempty" mapstructure:"decision_policy"`
+	IncrementalFetch *IncrementalFetchConfig       `yaml:"incremental_fetch,omitempty" mapstructure:"incremental_fetch"`
+	AdaptiveInterval *AdaptiveIntervalConfig       `yaml:"adaptive_interval,omitempty" mapstructure:"adaptive_interval"`
+	EvaluationCache  *EvaluationCacheConfig        `yaml:"evaluation_cache,omitempty" mapstructure:"evaluation_cache"`
+	RepublishBackoff *RepublishBackoffConfig       `yaml:"republish_backoff,omitempty" mapstructure:"republish_backoff"`
+	Dedup            *DedupConfig                  `yaml:"dedup,omitempty" mapstructure:"dedup"`
+	UpdateCooldown   *UpdateCooldownConfig         `yaml:"update_cooldown,omitempty" mapstructure:"update_cooldown"`
+	StateStore       *StateStoreConfig             `yaml:"state_store,omitempty" mapstructure:"state_store"`
+	GenerationDrift  *GenerationDriftConfig        `yaml:"generation_drift,omitempty" mapstructure:"generation_drift"`
+	FlapDetection    *FlapDetectionConfig          `yaml:"flap_detection,omitempty" mapstructure:"flap_detection"`
+	PublishStagger   *PublishStaggerConfig         `yaml:"publish_stagger,omitempty" mapstructure:"publish_stagger"`
+	PublishRateLimit *PublishRateLimitConfig       `yaml:"publish_rate_limit,omitempty" mapstructure:"publish_rate_limit"`
+	Workers          *WorkersConfig                `yaml:"workers,omitempty" mapstructure:"workers"`
+	LeaderElection   *LeaderElectionConfig         `yaml:"leader_election,omitempty" mapstructure:"leader_election"`
+	Shard            *ShardConfig                  `yaml:"shard,omitempty" mapstructure:"shard"`
+	DecisionStream   *DecisionStreamConfig         `yaml:"decision_stream,omitempty" mapstructure:"decision_stream"`
+	MetricsPush      *MetricsPushConfig            `yaml:"metrics_push,omitempty" mapstructure:"metrics_push"`
+	ResourceTagging  *ResourceTaggingConfig        `yaml:"resource_tagging,omitempty" mapstructure:"resource_tagging"`
+	Cascade          *CascadeConfig                `yaml:"cascade,omitempty" mapstructure:"cascade"`
+	ResourceSelector LabelSelectorList             `yaml:"resource_selector,omitempty" mapstructure:"resource_selector"`
+	PollInterval     time.Duration                 `yaml:"poll_interval" mapstructure:"poll_interval"`
+	DrainTimeout     time.Duration                 `yaml:"drain_timeout" mapstructure:"drain_timeout"`
+	CycleTimeout     time.Duration                 `yaml:"cycle_timeout,omitempty" mapstructure:"cycle_timeout"`
+	// MaxConsecutiveFailures makes the Sentinel exit with an error after
+	// this many consecutive failed poll cycles; 0 disables the limit.
+	MaxConsecutiveFailures int  `yaml:"max_consecutive_failures,omitempty" mapstructure:"max_consecutive_failures"`
+	DebugConfig            bool `yaml:"debug_config,omitempty" mapstructure:"debug_config"`
+	TracingEnabled         bool `yaml:"tracing_enabled,omitempty" mapstructure:"tracing_enabled"`
+	FIPSMode               bool `yaml:"fips_mode,omitempty" mapstructure:"fips_mode"`
+	Paused                 bool `yaml:"paused,omitempty" mapstructure:"paused"`
+	DryRun                 bool `yaml:"dry_run,omitempty" mapstructure:"dry_run"`
+}
+
+// IncrementalFetchConfig enables incremental polling: between full lists, the
+// Sentinel fetches only resources whose updated_time is later than its last
+// successful fetch. A full list runs every FullListInterval so that
+// time-based decisions (e.g. max age) still see unchanged resources.
+type IncrementalFetchConfig struct {
+	FullListInterval time.Duration `yaml:"full_list_interval" mapstructure:"full_list_interval"`
+}
+
+// AdaptiveIntervalConfig adapts the poll interval to the backlog. The
+// first cycle runs after poll_interval; after a cycle that found pending
+// resources the interval halves, down to Min, and after a cycle that
+// found none it doubles, up to Max.
+type AdaptiveIntervalConfig struct {
+	Min time.Duration `yaml:"min" mapstructure:"min"`
+	Max time.Duration `yaml:"max" mapstructure:"max"`
+}
+
+// Validate returns an error if the adaptive poll interval config is invalid.
+func (a *AdaptiveIntervalConfig) Validate() error {
+	if a.Min <= 0 {
+		return fmt.Errorf("min must be positive, got %s", a.Min)
+	}
+	if a.Max < a.Min {
+		return fmt.Errorf("max (%s) must not be shorter than min (%s)", a.Max, a.Min)
+	}
+	return nil
+}
+
+// EvaluationCacheConfig enables the per-resource evaluation cache. A resource
+// whose content is unchanged since an evaluation that decided not to publish
+// is not evaluated again until RevalidateAfter has elapsed, so time-based
+// rules such as max age still fire, at most RevalidateAfter late.
+type EvaluationCacheConfig struct {
+	RevalidateAfter time.Duration `yaml:"revalidate_after" mapstructure:"revalidate_after"`
+}
+
+// Validate returns an error if the evaluation cache config is invalid.
+func (e *EvaluationCacheConfig) Validate() error {
+	if e.RevalidateAfter <= 0 {
+		return fmt.Errorf("revalidate_after must be positive, got %s", e.RevalidateAfter)
+	}
+	return nil
+}
+
+// MessageSchemaConfig validates the data of every reconcile and stuck event
+// against a JSON Schema before it is published, when Enabled is set. Path
+// names the schema file; empty uses the schema shipped with the Sentinel,
+// which checks the conventional fields decoded by pkg/events. Events whose
+// data does not match are not published.
+type MessageSchemaConfig struct {
+	Path    string `yaml:"path,omitempty" mapstructure:"path"`
+	Enabled bool   `yaml:"enabled" mapstructure:"enabled"`
+}
+
+// Validate returns an error if the message schema config is invalid.
+func (m *MessageSchemaConfig) Validate() error {
+	if m.Path != "" && !m.Enabled {
+		return fmt.Errorf("path is set but enabled is false")
+	}
+	if m.Path != "" && !filepath.IsAbs(m.Path) {
+		return fmt.Errorf("path must be an absolute path, got %q", m.Path)
+	}
+	return nil
+}
+
+// RepublishBackoffConfig enables the per-resource republish backoff. After an
+// event is published for a resource, another event for the same generation is
+// held back for InitialInterval; each further publish doubles the wait, up to
+// MaxInterval. A new generation, or a decision not to publish, resets it. This
+// keeps a resource whose adapters are stuck from being published every cycle.
+type RepublishBackoffConfig struct {
+	InitialInterval time.Duration `yaml:"initial_interval" mapstructure:"initial_interval"`
+	MaxInterval     time.Duration `yaml:"max_interval" mapstructure:"max_interval"`
+}
+
+// Validate returns an error if the republish backoff config is invalid.
+func (r *RepublishBackoffConfig) Validate() error {
+	if r.InitialInterval <= 0 {
+		return fmt.Errorf("initial_interval must be positive, got %s", r.InitialInterval)
+	}
+	if r.MaxInterval < r.InitialInterval {
+		return fmt.Errorf("max_interval (%s) must not be less than initial_interval (%s)",
+			r.MaxInterval, r.InitialInterval)
+	}
+	return nil
+}
+
+// DedupConfig suppresses duplicate publishes across poll cycles. After an
+// event is published for a resource, another event for the same generation
+// and reason is skipped until Window has elapsed, even when the resource's
+// conditions still look stale because adapters are slow to update them. A new
+// generation or a different reason is published right away.
+type DedupConfig struct {
+	Window time.Duration `yaml:"window" mapstructure:"window"`
+}
+
+// Validate returns an error if the dedup config is invalid.
+func (d *DedupConfig) Validate() error {
+	if d.Window <= 0 {
+		return fmt.Errorf("window must be positive, got %s", d.Window)
+	}
+	return nil
+}
+
+// UpdateCooldownConfig holds back publishes for a resource after an event was
+// published for it until the resource's status is updated: until one of its
+// conditions' last_updated_time advances past the publish, its generation
+// changes, or Timeout elapses. Adapters bump last_updated_time when they
+// process an event; while they fail to, every cycle would otherwise see the
+// resource as stale and publish it again.
+type UpdateCooldownConfig struct {
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// Validate returns an error if the update cooldown config is invalid.
+func (u *UpdateCooldownConfig) Validate() error {
+	if u.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive, got %s", u.Timeout)
+	}
+	return nil
+}
+
+// State store types.
+const (
+	StateStoreMemory = "memory"
+	StateStoreRedis  = "redis"
+	StateStoreBolt   = "bolt"
+)
+
+// StateStoreConfig persists the last publish of each resource, so that the
+// republish backoff and dedup window survive restarts and, with Redis, are
+// shared by the replicas that take over from each other. Type is memory (the
+// default), redis, or bolt. Redis is reached at URL (redis:// or rediss://)
+// and its keys start with KeyPrefix; bolt stores a BoltDB file at Path.
+// Records expire TTL after their publish; 0 uses the default. Timeout bounds
+// each store operation; 0 uses the default.
+type StateStoreConfig struct {
+	Type      string        `yaml:"type,omitempty" mapstructure:"type"`
+	URL       string        `yaml:"url,omitempty" mapstructure:"url"`
+	KeyPrefix string        `yaml:"key_prefix,omitempty" mapstructure:"key_prefix"`
+	Path      string        `yaml:"path,omitempty" mapstructure:"path"`
+	TTL       time.Duration `yaml:"ttl,omitempty" mapstructure:"ttl"`
+	Timeout   time.Duration `yaml:"timeout,omitempty" mapstructure:"timeout"`
+}
+
+// StoreType returns Type, or memory when it is not set.
+func (s *StateStoreConfig) StoreType() string {
+	if s.Type == "" {
+		return StateStoreMemory
+	}
+	return s.Type
+}
+
+// RedactedURL returns URL with its password replaced.
+func (s *StateStoreConfig) RedactedURL() string {
+	return redactURL(s.URL)
+}
+
+// Validate returns an error if the state store config is invalid.
+func (s *StateStoreConfig) Validate() error {
+	switch s.StoreType() {
+	case StateStoreMemory:
+	case StateStoreRedis:
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Host == "" {
+			return fmt.Errorf("url must be a redis:// or rediss:// URL for type redis, got %q", redactURL(s.URL))
+		}
+	case StateStoreBolt:
+		if s.Path == "" {
+			return fmt.Errorf("path is required for type bolt")
+		}
+	default:
+		return fmt.Errorf("type must be one of memory, redis, bolt, got %q", s.Type)
+	}
+	if s.URL != "" && s.StoreType() != StateStoreRedis {
+		return fmt.Errorf("url is only used by type redis")
+	}
+	if s.Path != "" && s.StoreType() != StateStoreBolt {
+		return fmt.Errorf("path is only used by type bolt")
+	}
+	if s.TTL < 0 {
+		return fmt.Errorf("ttl must not be negative, got %s", s.TTL)
+	}
+	if s.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative, got %s", s.Timeout)
+	}
+	return nil
+}
+
+// DefaultGenerationDriftCondition is the condition whose observed generation
+// is compared with the resource generation when generation_drift does not
+// name one.
+const DefaultGenerationDriftCondition = "Reconciled"
+
+// GenerationDriftConfig holds back publishes for a resource whose generation is
+// ahead of the observed generation of Condition (Reconciled when empty) until
+// the drift has persisted for Cycles consecutive poll cycles and for Duration.
+// Zero leaves either threshold off. This avoids racing adapters that are
+// already reconciling the new generation.
+type GenerationDriftConfig struct {
+	Condition string        `yaml:"condition,omitempty" mapstructure:"condition"`
+	Duration  time.Duration `yaml:"duration,omitempty" mapstructure:"duration"`
+	Cycles    int           `yaml:"cycles,omitempty" mapstructure:"cycles"`
+}
+
+// Validate returns an error if the generation drift config is invalid.
+func (g *GenerationDriftConfig) Validate() error {
+	if g.Cycles < 0 {
+		return fmt.Errorf("cycles must not be negative, got %d", g.Cycles)
+	}
+	if g.Duration < 0 {
+		return fmt.Errorf("duration must not be negative, got %s", g.Duration)
+	}
+	if g.Cycles == 0 && g.Duration == 0 {
+		return fmt.Errorf("cycles or duration must be set")
+	}
+	if strings.ContainsFunc(g.Condition, unicode.IsSpace) {
+		return fmt.Errorf("condition must not contain whitespace, got %q", g.Condition)
+	}
+	return nil
+}
+
+// ConditionType returns the condition whose observed generation is checked.
+func (g *GenerationDriftConfig) ConditionType() string {
+	if g.Condition == "" {
+		return DefaultGenerationDriftCondition
+	}
+	return g.Condition
+}
+
+// DefaultFlapDetectionCondition is the condition whose status changes are
+// counted when flap_detection does not name one.
+const DefaultFlapDetectionCondition = "Reconciled"
+
+// FlapDetectionConfig dampens publishing for a resource whose Condition
+// (Reconciled when empty) changes status at least Flips times within Window,
+// so that a flapping resource does not dominate the reconcile queue. While it
+// flaps, the resource is published at most once per PublishInterval.
+type FlapDetectionConfig struct {
+	Condition       string        `yaml:"condition,omitempty" mapstructure:"condition"`
+	Window          time.Duration `yaml:"window" mapstructure:"window"`
+	PublishInterval time.Duration `yaml:"publish_interval" mapstructure:"publish_interval"`
+	Flips           int           `yaml:"flips" mapstructure:"flips"`
+}
+
+// Validate returns an error if the flap detection config is invalid.
+func (f *FlapDetectionConfig) Validate() error {
+	if f.Window <= 0 {
+		return fmt.Errorf("window must be positive, got %s", f.Window)
+	}
+	if f.Flips < 2 {
+		return fmt.Errorf("flips must be at least 2, got %d", f.Flips)
+	}
+	if f.PublishInterval <= 0 {
+		return fmt.Errorf("publish_interval must be positive, got %s", f.PublishInterval)
+	}
+	if strings.ContainsFunc(f.Condition, unicode.IsSpace) {
+		return fmt.Errorf("condition must not contain whitespace, got %q", f.Condition)
+	}
+	return nil
+}
+
+// ConditionType returns the condition whose status changes are counted.
+func (f *FlapDetectionConfig) ConditionType() string {
+	if f.Condition == "" {
+		return DefaultFlapDetectionCondition
+	}
+	return f.Condition
+}
+
+// PublishStaggerConfig spreads the publishes of a poll cycle so that adapters
+// are not hit by a burst when many resources need an event at once, e.g. after
+// the Sentinel was down. The first Burst publishes of a cycle are sent right
+// away; each further publish waits Interval, randomized by up to ±Jitter (a
+// fraction between 0 and 1).
+type PublishStaggerConfig struct {
+	Interval time.Duration `yaml:"interval" mapstructure:"interval"`
+	Jitter   float64       `yaml:"jitter,omitempty" mapstructure:"jitter"`
+	Burst    int           `yaml:"burst,omitempty" mapstructure:"burst"`
+}
+
+// Validate returns an error if the publish stagger config is invalid.
+func (p *PublishStaggerConfig) Validate() error {
+	if p.Interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", p.Interval)
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("jitter must be between 0 and 1, got %g", p.Jitter)
+	}
+	if p.Burst < 0 {
+		return fmt.Errorf("burst must not be negative, got %d", p.Burst)
+	}
+	return nil
+}
+
+// PublishRateLimitConfig caps the publishes of the Sentinel. A poll cycle
+// publishes at most MaxEventsPerCycle events, and at most MaxEventsPerSecond
+// events per second across cycles; zero leaves either limit off. Resources
+// over the limit are deferred to the next poll cycle.
+type PublishRateLimitConfig struct {
+	MaxEventsPerSecond float64 `yaml:"max_events_per_second,omitempty" mapstructure:"max_events_per_second"`
+	MaxEventsPerCycle  int     `yaml:"max_events_per_cycle,omitempty" mapstructure:"max_events_per_cycle"`
+}
+
+// Validate returns an error if the publish rate limit config is invalid.
+func (p *PublishRateLimitConfig) Validate() error {
+	if p.MaxEventsPerCycle < 0 {
+		return fmt.Errorf("max_events_per_cycle must not be negative, got %d", p.MaxEventsPerCycle)
+	}
+	if p.MaxEventsPerSecond < 0 {
+		return fmt.Errorf("max_events_per_second must not be negative, got %g", p.MaxEventsPerSecond)
+	}
+	if p.MaxEventsPerCycle == 0 && p.MaxEventsPerSecond == 0 {
+		return fmt.Errorf("max_events_per_cycle or max_events_per_second must be set")
+	}
+	return nil
+}
+
+// maxWorkerConcurrency bounds workers.concurrency.
+const maxWorkerConcurrency = 256
+
+// WorkersConfig evaluates and publishes the resources of a poll cycle on up
+// to Concurrency goroutines instead of one after another, for large fleets
+// or slow brokers. Outcomes are still recorded in resource order.
+type WorkersConfig struct {
+	Concurrency int `yaml:"concurrency" mapstructure:"concurrency"`
+}
+
+// Validate returns an error if the workers config is invalid.
+func (w *WorkersConfig) Validate() error {
+	if w.Concurrency < 1 || w.Concurrency > maxWorkerConcurrency {
+		return fmt.Errorf("concurrency must be between 1 and %d, got %d", maxWorkerConcurrency, w.Concurrency)
+	}
+	return nil
+}
+
+// Leader election timing used when leader_election leaves a duration at 0.
+// They match the defaults of Kubernetes controllers.
+const (
+	DefaultLeaseDuration = 15 * time.Second
+	DefaultRenewDeadline = 10 * time.Second
+	DefaultRetryPeriod   = 2 * time.Second
+)
+
+// LeaderElectionConfig runs the poll loops only on the replica that holds the
+// Kubernetes Lease (coordination.k8s.io/v1) Name in Namespace, so that the
+// Sentinel can run with several replicas for high availability without
+// publishing duplicate events. The other replicas stay connected and ready,
+// and take the lease over once the leader has not renewed it for
+// LeaseDuration. Namespace defaults to the namespace of the pod's service
+// account and Identity to the host name, i.e. the pod name.
+type LeaderElectionConfig struct {
+	Name          string        `yaml:"name" mapstructure:"name"`
+	Namespace     string        `yaml:"namespace,omitempty" mapstructure:"namespace"`
+	Identity      string        `yaml:"identity,omitempty" mapstructure:"identity"`
+	LeaseDuration time.Duration `yaml:"lease_duration,omitempty" mapstructure:"lease_duration"`
+	RenewDeadline time.Duration `yaml:"renew_deadline,omitempty" mapstructure:"renew_deadline"`
+	RetryPeriod   time.Duration `yaml:"retry_period,omitempty" mapstructure:"retry_period"`
+}
+
+// Validate returns an error if the leader election config is invalid.
+func (l *LeaderElectionConfig) Validate() error {
+	if !isDNSSubdomain(l.Name) {
+		return fmt.Errorf("name must be a DNS subdomain, got %q", l.Name)
+	}
+	if l.Namespace != "" && !isDNSLabel(l.Namespace) {
+		return fmt.Errorf("namespace must be a DNS label, got %q", l.Namespace)
+	}
+	if strings.ContainsFunc(l.Identity, unicode.IsSpace) {
+		return fmt.Errorf("identity must not contain whitespace, got %q", l.Identity)
+	}
+	if l.LeaseDuration < 0 || l.RenewDeadline < 0 || l.RetryPeriod < 0 {
+		return fmt.Errorf("lease_duration, renew_deadline, and retry_period must not be negative")
+	}
+	leaseDuration, renewDeadline, retryPeriod := l.Timing()
+	if leaseDuration < time.Second {
+		return fmt.Errorf("lease_duration must be at least 1s, got %s", leaseDuration)
+	}
+	if renewDeadline >= leaseDuration {
+		return fmt.Errorf("renew_deadline (%s) must be shorter than lease_duration (%s)", renewDeadline, leaseDuration)
+	}
+	if retryPeriod >= renewDeadline {
+		return fmt.Errorf("retry_period (%s) must be shorter than renew_deadline (%s)", retryPeriod, renewDeadline)
+	}
+	return nil
+}
+
+// Timing returns the lease duration, renew deadline, and retry period, with
+// the defaults in place of zero values.
+func (l *LeaderElectionConfig) Timing() (leaseDuration, renewDeadline, retryPeriod time.Duration) {
+	leaseDuration, renewDeadline, retryPeriod = l.LeaseDuration, l.RenewDeadline, l.RetryPeriod
+	if leaseDuration == 0 {
+		leaseDuration = DefaultLeaseDuration
+	}
+	if renewDeadline == 0 {
+		renewDeadline = DefaultRenewDeadline
+	}
+	if retryPeriod == 0 {
+		retryPeriod = DefaultRetryPeriod
+	}
+	return leaseDuration, renewDeadline, retryPeriod
+}
+
+// maxShards bounds shard.total.
+const maxShards = 1024
+
+// ShardConfig splits the resources matched by resource_selector between
+// Total Sentinel replicas with the same configuration: each replica only
+// evaluates and publishes the resources whose hashed ID falls in shard Index,
+// 0 to Total-1. Every resource belongs to exactly one shard, so the replicas
+// scale a large fleet without publishing duplicate events. Index is typically
+// set from the pod index of a StatefulSet through HYPERFLEET_SHARD_INDEX.
+type ShardConfig struct {
+	Index int `yaml:"index" mapstructure:"index"`
+	Total int `yaml:"total" mapstructure:"total"`
+}
+
+// Validate returns an error if the shard config is invalid.
+func (s *ShardConfig) Validate() error {
+	if s.Total < 1 || s.Total > maxShards {
+		return fmt.Errorf("total must be between 1 and %d, got %d", maxShards, s.Total)
+	}
+	if s.Index < 0 || s.Index >= s.Total {
+		return fmt.Errorf("index must be between 0 and %d, got %d", s.Total-1, s.Index)
+	}
+	return nil
+}
+
+// String returns the shard as index/total.
+func (s *ShardConfig) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Total)
+}
+
+// ResourceTaggingConfig enables resource tagging: after publishing an event
+// for a resource, the Sentinel writes the time of the publish into Label on
+// the resource through the HyperFleet API (e.g.
+// sentinel.hyperfleet.io/last-triggered), so that people and tools without
+// access to the broker can see when a resource was last triggered. It is the
+// only feature that writes to the API and requires write permission there.
+//
+// With MinInterval, a resource whose label shows a publish within MinInterval
+// is not published again, whichever replica published it, so the label also
+// deduplicates publishes across replicas.
+type ResourceTaggingConfig struct {
+	Label       string        `yaml:"label" mapstructure:"label"`
+	MinInterval time.Duration `yaml:"min_interval,omitempty" mapstructure:"min_interval"`
+}
+
+// Validate returns an error if the resource tagging config is invalid.
+func (r *ResourceTaggingConfig) Validate() error {
+	if !isLabelKey(r.Label) {
+		return fmt.Errorf("label must be a valid label key, got %q", r.Label)
+	}
+	if r.MinInterval < 0 {
+		return fmt.Errorf("min_interval must not be negative, got %s", r.MinInterval)
+	}
+	return nil
+}
+
+// CascadeConfig enables cascade mode: after publishing an event for a
+// resource, the Sentinel lists the ResourceType resources whose
+// owner_references point at it (e.g. the nodepools of a cluster) and publishes
+// a reconcile event for each, so that children follow changes to their
+// parent's spec without waiting for their own max age.
+type CascadeConfig struct {
+	ResourceType string `yaml:"resource_type" mapstructure:"resource_type"`
+}
+
+// Validate returns an error if the cascade config is invalid.
+func (c *CascadeConfig) Validate() error {
+	if c.ResourceType == "" {
+		return fmt.Errorf("resource_type is required")
+	}
+	if !isDNSLabel(c.ResourceType) {
+		return fmt.Errorf("resource_type must be a lowercase DNS label (a-z, 0-9, '-'), got %q", c.ResourceType)
+	}
+	return nil
+}
+
+// DecisionStreamConfig enables the local decision stream: every decision and
+// publish result is written as a JSON line to clients of a Unix socket at
+// SocketPath. BufferSize is the number of events queued per client before
+// events are dropped; 0 uses the default.
+type DecisionStreamConfig struct {
+	SocketPath string `yaml:"socket_path" mapstructure:"socket_path"`
+	BufferSize int    `yaml:"buffer_size,omitempty" mapstructure:"buffer_size"`
+}
+
+// maxSocketPathLen is the longest Unix socket path accepted on all supported
+// platforms (sun_path is 104 bytes on macOS, including the terminating NUL).
+const maxSocketPathLen = 103
+
+// Validate returns an error if the decision stream config is invalid.
+func (d *DecisionStreamConfig) Validate() error {
+	if d.SocketPath == "" {
+		return fmt.Errorf("socket_path is required")
+	}
+	if !filepath.IsAbs(d.SocketPath) {
+		return fmt.Errorf("socket_path must be absolute, got %q", d.SocketPath)
+	}
+	if len(d.SocketPath) > maxSocketPathLen {
+		return fmt.Errorf("socket_path must be at most %d bytes, got %d", maxSocketPathLen, len(d.SocketPath))
+	}
+	if d.BufferSize < 0 {
+		return fmt.Errorf("buffer_size must not be negative, got %d", d.BufferSize)
+	}
+	return nil
+}
+
+// DecisionPolicyConfig replaces message_decision with a Rego policy evaluated
+// by an Open Policy Agent server at URL. The policy document at Path, a
+// slash-separated package path such as hyperfleet/sentinel/decision, is
+// queried for every resource and must be an object with a boolean publish and
+// an optional string reason. Timeout bounds each query; 0 uses the default.
+type DecisionPolicyConfig struct {
+	URL     string        `yaml:"url" mapstructure:"url"`
+	Path    string        `yaml:"path" mapstructure:"path"`
+	Timeout time.Duration `yaml:"timeout,omitempty" mapstructure:"timeout"`
+}
+
+// RedactedURL returns URL with its password replaced.
+func (d *DecisionPolicyConfig) RedactedURL() string {
+	return redactURL(d.URL)
+}
+
+// Validate returns an error if the decision policy config is invalid.
+func (d *DecisionPolicyConfig) Validate() error {
+	u, err := url.Parse(d.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL, got %q", redactURL(d.URL))
+	}
+	if d.Path == "" || strings.HasPrefix(d.Path, "/") || strings.HasSuffix(d.Path, "/") ||
+		strings.Contains(d.Path, "//") || strings.ContainsFunc(d.Path, unicode.IsSpace) {
+		return fmt.Errorf("path must be a slash-separated package path such as hyperfleet/sentinel/decision, got %q",
+			d.Path)
+	}
+	if d.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative, got %s", d.Timeout)
+	}
+	return nil
+}
+
+// MetricsPushConfig enables pushing the final metric values to a Prometheus
+// Pushgateway at URL when the Sentinel shuts down, so that the last poll
+// cycles of a short-lived or preempted pod are not lost between scrapes.
+// Metrics are pushed under Job (the default job when empty), grouped by the
+// Sentinel name. Timeout bounds the push; 0 uses the default.
+type MetricsPushConfig struct {
+	URL     string        `yaml:"url" mapstructure:"url"`
+	Job     string        `yaml:"job,omitempty" mapstructure:"job"`
+	Timeout time.Duration `yaml:"timeout,omitempty" mapstructure:"timeout"`
+}
+
+// RedactedURL returns URL with its password replaced.
+func (m *MetricsPushConfig) RedactedURL() string {
+	return redactURL(m.URL)
+}
+
+// Validate returns an error if the metrics push config is invalid.
+func (m *MetricsPushConfig) Validate() error {
+	u, err := url.Parse(m.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL, got %q", redactURL(m.URL))
+	}
+	if strings.Contains(m.Job, "/") {
+		return fmt.Errorf("job must not contain '/', got %q", m.Job)
+	}
+	if m.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative, got %s", m.Timeout)
+	}
+	return nil
+}
+
+// ResourceTypeConfig registers a resource type served by an API other than
+// the default /api/hyperfleet/{version}/{resource_type} collection. Path is
+// the collection path below base_url and may contain "{version}", replaced by
+// clients.hyperfleet_api.version. Kind is the kind of the listed items, and
+// StatusField the item field holding the status conditions if not "status".
+type ResourceTypeConfig struct {
+	Path        string `yaml:"path" mapstructure:"path"`
+	Kind        string `yaml:"kind" mapstructure:"kind"`
+	StatusField string `yaml:"status_field,omitempty" mapstructure:"status_field"`
+}
+
+// Validate returns an error if the resource type config is invalid.
+func (r *ResourceTypeConfig) Validate() error {
+	if !strings.HasPrefix(r.Path, "/") {
+		return fmt.Errorf("path must start with '/', got %q", r.Path)
+	}
+	if strings.ContainsAny(r.Path, "?#%\\") || strings.Contains(r.Path, "..") || strings.HasSuffix(r.Path, "/") {
+		return fmt.Errorf("path must be a plain URL path without query, '..', or trailing '/', got %q", r.Path)
+	}
+	if r.Kind == "" {
+		return fmt.Errorf("kind is required")
+	}
+	if strings.ContainsAny(r.StatusField, " .") {
+		return fmt.Errorf("status_field must be a top-level field name, got %q", r.StatusField)
+	}
+	return nil
+}
+
+// EndpointPath returns Path with "{version}" replaced by version.
+func (r *ResourceTypeConfig) EndpointPath(version string) string {
+	return strings.ReplaceAll(r.Path, "{version}", version)
+}
+
+// WatcherConfig is one entry of watchers: a resource type polled by its own
+// loop, with its own selector, message decision, and topic. Name identifies
+// the watcher on the admin server and defaults to ResourceType. An unset
+// MessageDecision uses the top-level message_decision, and an empty Topic
+// clients.broker.topic.
+type WatcherConfig struct {
+	MessageDecision  *MessageDecisionConfig `yaml:"message_decision,omitempty" mapstructure:"message_decision"`
+	Name             string                 `yaml:"name,omitempty" mapstructure:"name"`
+	ResourceType     string                 `yaml:"resource_type" mapstructure:"resource_type"`
+	Topic            string                 `yaml:"topic,omitempty" mapstructure:"topic"`
+	ResourceSelector LabelSelectorList      `yaml:"resource_selector,omitempty" mapstructure:"resource_selector"`
+}
+
+// ID returns Name, or ResourceType when Name is empty.
+func (w *WatcherConfig) ID() string {
+	if w.Name != "" {
+		return w.Name
+	}
+	return w.ResourceType
+}
+
+// Validate returns an error if the watcher config is invalid.
+func (w *WatcherConfig) Validate() error {
+	if w.ResourceType == "" {
+		return fmt.Errorf("resource_type is required")
+	}
+	if !isDNSLabel(w.ID()) {
+		return fmt.Errorf("name must be a lowercase DNS label (a-z, 0-9, '-'), got %q", w.ID())
+	}
+	if err := w.ResourceSelector.validate("resource_selector"); err != nil {
+		return err
+	}
+	if w.MessageDecision != nil {
+		if err := w.MessageDecision.Validate(); err != nil {
+			return fmt.Errorf("message_decision: %w", err)
+		}
+	}
+	return nil
+}
+
+// SentinelInfo contains basic sentinel information
+type SentinelInfo struct {
+	Name string `yaml:"name" mapstructure:"name"`
+}
+
+// LogConfig contains logging configuration.
+// Priority (lowest to highest): config file < HYPERFLEET_LOG_* env vars < --log-* CLI flags
+type LogConfig struct {
+	Level  string `yaml:"level,omitempty" mapstructure:"level"`
+	Format string `yaml:"format,omitempty" mapstructure:"format"`
+	Output string `yaml:"output,omitempty" mapstructure:"output"`
+}
+
+// ClientsConfig contains all client configurations
+type ClientsConfig struct {
+	HyperFleetAPI *HyperFleetAPIConfig `yaml:"hyperfleet_api" mapstructure:"hyperfleet_api"`
+	Broker        *BrokerConfig        `yaml:"broker,omitempty" mapstructure:"broker"`
+}
+
+// HyperFleetAPIAuthConfig defines optional JWT authentication via a Kubernetes
+// projected service account token. When set, the bearer token is read from
+// TokenPath and injected into every API request.
+type HyperFleetAPIAuthConfig struct {
+	TokenPath     string        `yaml:"token_path" mapstructure:"token_path"`
+	TokenCacheTTL time.Duration `yaml:"token_cache_ttl" mapstructure:"token_cache_ttl"`
+}
+
+// Validate returns an error if the auth config is incomplete.
+func (a *HyperFleetAPIAuthConfig) Validate() error {
+	if a.TokenPath == "" {
+		return fmt.Errorf("token_path is required")
+	}
+	if !filepath.IsAbs(a.TokenPath) {
+		return fmt.Errorf("token_path must be an absolute path, got %q", a.TokenPath)
+	}
+	if a.TokenCacheTTL < 0 {
+		return fmt.Errorf("token_cache_ttl must not be negative")
+	}
+	return nil
+}
+
+// HyperFleetAPITLSConfig configures TLS for connections to the HyperFleet API.
+// CertFile and KeyFile enable mutual TLS and must be set together. CAFile
+// replaces the system trust store when set.
+type HyperFleetAPITLSConfig struct {
+	CAFile             string `yaml:"ca_file,omitempty" mapstructure:"ca_file"`
+	CertFile           string `yaml:"cert_file,omitempty" mapstructure:"cert_file"`
+	KeyFile            string `yaml:"key_file,omitempty" mapstructure:"key_file"`
+	MinVersion         string `yaml:"min_version,omitempty" mapstructure:"min_version"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty" mapstructure:"insecure_skip_verify"`
+}
+
+// maxPageConcurrency bounds clients.hyperfleet_api.page_concurrency.
+const maxPageConcurrency = 16
+
+// defaultMaxBodyBytes is the default for
+// clients.hyperfleet_api.max_body_bytes: far above any page the API
+// serves, but low enough to fail before a runaway response exhausts memory.
+const defaultMaxBodyBytes = 64 << 20
+
+// SupportedAPIVersions lists the HyperFleet API versions the client has a
+// resource model for. hyperfleet-api-spec only defines v1.
+var SupportedAPIVersions = []string{"v1"}
+
+// supportedTLSMinVersions lists the accepted values for tls.min_version.
+var supportedTLSMinVersions = map[string]bool{"1.2": true, "1.3": true}
+
+// Validate returns an error if the TLS config is inconsistent.
+func (t *HyperFleetAPITLSConfig) Validate() error {
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return fmt.Errorf("cert_file and key_file must be set together")
+	}
+	if t.MinVersion != "" && !supportedTLSMinVersions[t.MinVersion] {
+		return fmt.Errorf("min_version must be one of 1.2, 1.3, got %q", t.MinVersion)
+	}
+	return nil
+}
+
+// HyperFleetAPICircuitBreakerConfig enables a circuit breaker around API fetches.
+// After FailureThreshold consecutive failed polls, the Sentinel skips the API
+// for Cooldown and reports not-ready, then lets a single trial fetch through.
+type HyperFleetAPICircuitBreakerConfig struct {
+	FailureThreshold int           `yaml:"failure_threshold" mapstructure:"failure_threshold"`
+	Cooldown         time.Duration `yaml:"cooldown" mapstructure:"cooldown"`
+}
+
+// Validate returns an error if the circuit breaker config is invalid.
+func (cb *HyperFleetAPICircuitBreakerConfig) Validate() error {
+	if cb.FailureThreshold < 1 {
+		return fmt.Errorf("failure_threshold must be at least 1, got %d", cb.FailureThreshold)
+	}
+	if cb.Cooldown <= 0 {
+		return fmt.Errorf("cooldown must be positive, got %s", cb.Cooldown)
+	}
+	return nil
+}
+
+// HyperFleetAPIRateLimitConfig limits the rate of requests sent to the API with
+// a token bucket shared by all fetches of the Sentinel instance.
+type HyperFleetAPIRateLimitConfig struct {
+	QPS   float64 `yaml:"qps" mapstructure:"qps"`
+	Burst int     `yaml:"burst" mapstructure:"burst"`
+}
+
+// Validate returns an error if the rate limit config is invalid.
+func (rl *HyperFleetAPIRateLimitConfig) Validate() error {
+	if rl.QPS <= 0 {
+		return fmt.Errorf("qps must be positive, got %g", rl.QPS)
+	}
+	if rl.Burst < 1 {
+		return fmt.Errorf("burst must be at least 1, got %d", rl.Burst)
+	}
+	return nil
+}
+
+// HyperFleetAPIRetryConfig selects how the API client waits between retries
+// of a failed request. Strategy is one of exponential (the default without
+// this block), constant, or decorrelated_jitter. InitialInterval is the first
+// delay (the constant delay for constant), MaxInterval caps each delay, and
+// MaxElapsedTime bounds the retries of one request; zero values keep the
+// client defaults.
+type HyperFleetAPIRetryConfig struct {
+	Strategy        string        `yaml:"strategy" mapstructure:"strategy"`
+	InitialInterval time.Duration `yaml:"initial_interval,omitempty" mapstructure:"initial_interval"`
+	MaxInterval     time.Duration `yaml:"max_interval,omitempty" mapstructure:"max_interval"`
+	MaxElapsedTime  time.Duration `yaml:"max_elapsed_time,omitempty" mapstructure:"max_elapsed_time"`
+}
+
+// supportedRetryStrategies lists the accepted values for retry.strategy.
+var supportedRetryStrategies = map[string]bool{"exponential": true, "constant": true, "decorrelated_jitter": true}
+
+// Validate returns an error if the retry config is invalid.
+func (r *HyperFleetAPIRetryConfig) Validate() error {
+	if !supportedRetryStrategies[r.Strategy] {
+		return fmt.Errorf("strategy must be one of exponential, constant, decorrelated_jitter, got %q", r.Strategy)
+	}
+	if r.InitialInterval < 0 || r.MaxInterval < 0 || r.MaxElapsedTime < 0 {
+		return fmt.Errorf("initial_interval, max_interval, and max_elapsed_time must not be negative")
+	}
+	if r.InitialInterval > 0 && r.MaxInterval > 0 && r.InitialInterval > r.MaxInterval {
+		return fmt.Errorf("initial_interval (%s) must not exceed max_interval (%s)", r.InitialInterval, r.MaxInterval)
+	}
+	return nil
+}
+
+// HyperFleetAPITransportConfig tunes the HTTP transport of the API client.
+// Empty proxy fields fall back to the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY
+// environment variables; other zero values keep the net/http defaults.
+type HyperFleetAPITransportConfig struct {
+	HTTPProxy           string        `yaml:"http_proxy,omitempty" mapstructure:"http_proxy"`
+	HTTPSProxy          string        `yaml:"https_proxy,omitempty" mapstructure:"https_proxy"`
+	NoProxy             string        `yaml:"no_proxy,omitempty" mapstructure:"no_proxy"`
+	DialTimeout         time.Duration `yaml:"dial_timeout,omitempty" mapstructure:"dial_timeout"`
+	IdleConnTimeout     time.Duration `yaml:"idle_conn_timeout,omitempty" mapstructure:"idle_conn_timeout"`
+	MaxIdleConns        int           `yaml:"max_idle_conns,omitempty" mapstructure:"max_idle_conns"`
+	MaxIdleConnsPerHost int           `yaml:"max_idle_conns_per_host,omitempty" mapstructure:"max_idle_conns_per_host"`
+}
+
+// Validate returns an error if the transport config is invalid.
+func (t *HyperFleetAPITransportConfig) Validate() error {
+	for _, p := range []struct{ name, value string }{
+		{"http_proxy", t.HTTPProxy},
+		{"https_proxy", t.HTTPSProxy},
+	} {
+		if p.value == "" {
+			continue
+		}
+		u, err := url.Parse(p.value)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("%s must be a URL with a host, got %q", p.name, redactURL(p.value))
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("%s must use http, https, or socks5 scheme, got %q", p.name, u.Scheme)
+		}
+	}
+	if t.DialTimeout < 0 {
+		return fmt.Errorf("dial_timeout must not be negative, got %s", t.DialTimeout)
+	}
+	if t.IdleConnTimeout < 0 {
+		return fmt.Errorf("idle_conn_timeout must not be negative, got %s", t.IdleConnTimeout)
+	}
+	if t.MaxIdleConns < 0 {
+		return fmt.Errorf("max_idle_conns must not be negative, got %d", t.MaxIdleConns)
+	}
+	if t.MaxIdleConnsPerHost < 0 {
+		return fmt.Errorf("max_idle_conns_per_host must not be negative, got %d", t.MaxIdleConnsPerHost)
+	}
+	return nil
+}
+
+// redactURL replaces the password of a URL with "xxxxx". Strings that do not
+// parse as a URL are replaced entirely, since they may still hold credentials.
+func redactURL(raw string) string {
+	if raw == "" {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "xxxxx"
+	}
+	return u.Redacted()
+}
+
+// HyperFleetAPIRegionConfig is one HyperFleet API endpoint polled in
+// multi-region mode. Resources fetched from it are tagged with Name and their
+// events are published to Topic. All regions share the remaining
+// clients.hyperfleet_api settings (timeout, auth, TLS, ...).
+type HyperFleetAPIRegionConfig struct {
+	Name    string `yaml:"name" mapstructure:"name"`
+	BaseURL string `yaml:"base_url" mapstructure:"base_url"`
+	Topic   string `yaml:"topic" mapstructure:"topic"`
+}
+
+// Validate returns an error if the region config is incomplete.
+func (r *HyperFleetAPIRegionConfig) Validate() error {
+	if !isDNSLabel(r.Name) {
+		return fmt.Errorf("name must be a lowercase DNS label (a-z, 0-9, '-'), got %q", r.Name)
+	}
+	if r.BaseURL == "" {
+		return fmt.Errorf("region %q: base_url is required", r.Name)
+	}
+	if r.Topic == "" {
+		return fmt.Errorf("region %q: topic is required", r.Name)
+	}
+	return nil
+}
+
+// isDNSLabel reports whether s is a valid RFC 1123 DNS label.
+func isDNSLabel(s string) bool {
+	if s == "" || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// isDNSSubdomain reports whether s is a DNS subdomain of at most 253
+// characters, e.g. the name of a Kubernetes object.
+func isDNSSubdomain(s string) bool {
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if !isDNSLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLabelKey reports whether s is a valid label key: an optional DNS
+// subdomain prefix and "/", followed by a name of at most 63 characters that
+// starts and ends with an alphanumeric character and otherwise contains only
+// alphanumerics, '-', '_' and '.'. Label keys are sent unquoted in search
+// queries, so nothing else is allowed.
+func isLabelKey(s string) bool {
+	name := s
+	if i := strings.LastIndexByte(s, '/'); i >= 0 {
+		prefix := s[:i]
+		if prefix == "" || len(prefix) > 253 {
+			return false
+		}
+		for _, label := range strings.Split(prefix, ".") {
+			if !isDNSLabel(label) {
+				return false
+			}
+		}
+		name = s[i+1:]
+	}
+	if name == "" || len(name) > 63 || !isAlphanumeric(name[0]) || !isAlphanumeric(name[len(name)-1]) {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; !isAlphanumeric(c) && c != '-' && c != '_' && c != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// validateExpression checks a CEL expression against the input limits.
+// Tabs and line breaks are allowed so that expressions can span lines;
+// other control characters are not.
+func validateExpression(expr string) error {
+	if len(expr) > maxExpressionLength {
+		return fmt.Errorf("expression is %d bytes long, limit is %d", len(expr), maxExpressionLength)
+	}
+	if i := strings.IndexFunc(expr, func(r rune) bool {
+		return unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r'
+	}); i >= 0 {
+		return fmt.Errorf("expression contains a control character at byte %d", i)
+	}
+	return nil
+}
+
+// validate checks that every selector has a valid label key
+// and a value without control characters. field names the list in errors.
+func (ls LabelSelectorList) validate(field string) error {
+	for i, s := range ls {
+		if !isLabelKey(s.Label) {
+			return fmt.Errorf("%s[%d]: label must be a valid label key, got %q", field, i, s.Label)
+		}
+		if len(s.Value) > maxLabelValueLength {
+			return fmt.Errorf("%s[%d]: value for label %q is longer than %d characters",
+				field, i, s.Label, maxLabelValueLength)
+		}
+		if strings.ContainsFunc(s.Value, unicode.IsControl) {
+			return fmt.Errorf("%s[%d]: value for label %q contains a control character", field, i, s.Label)
+		}
+	}
+	return nil
 }
 
-// LabelSelectorList is a list of label selectors
-type LabelSelectorList []LabelSelector
+// Matches reports whether labels carry every selector's label and value.
+// An empty list matches every resource.
+func (ls LabelSelectorList) Matches(labels map[string]string) bool {
+	for _, s := range ls {
+		if v, ok := labels[s.Label]; !ok || v != s.Value {
+			return false
+		}
+	}
+	return true
+}
 
-// Param is a named CEL expression. Params must be listed in dependency order:
-// if param B references param A, A must appear before B so the CEL runtime
-// can resolve it during evaluation. Out-of-order references cause a runtime
-// evaluation error (not a startup error).
-type Param struct {
-	Name string `mapstructure:"name"`
-	Expr string `mapstructure:"expr"`
+// HyperFleetAPIConfig defines the HyperFleet API client configuration
+type HyperFleetAPIConfig struct {
+	Auth            *HyperFleetAPIAuthConfig           `yaml:"auth,omitempty" mapstructure:"auth"`
+	TLS             *HyperFleetAPITLSConfig            `yaml:"tls,omitempty" mapstructure:"tls"`
+	CircuitBreaker  *HyperFleetAPICircuitBreakerConfig `yaml:"circuit_breaker,omitempty" mapstructure:"circuit_breaker"`
+	RateLimit       *HyperFleetAPIRateLimitConfig      `yaml:"rate_limit,omitempty" mapstructure:"rate_limit"`
+	Retry           *HyperFleetAPIRetryConfig          `yaml:"retry,omitempty" mapstructure:"retry"`
+	Transport       *HyperFleetAPITransportConfig      `yaml:"transport,omitempty" mapstructure:"transport"`
+	BaseURL         string                             `yaml:"base_url" mapstructure:"base_url"`
+	Version         string                             `yaml:"version,omitempty" mapstructure:"version"`
+	Regions         []HyperFleetAPIRegionConfig        `yaml:"regions,omitempty" mapstructure:"regions"`
+	Timeout         time.Duration                      `yaml:"timeout" mapstructure:"timeout"`
+	MaxBodyBytes    int64                              `yaml:"max_body_bytes,omitempty" mapstructure:"max_body_bytes"`
+	PageConcurrency int                                `yaml:"page_concurrency,omitempty" mapstructure:"page_concurrency"`
+	MaxItems        int                                `yaml:"max_items,omitempty" mapstructure:"max_items"`
+	PageSize        int32                              `yaml:"page_size,omitempty" mapstructure:"page_size"`
+	StreamingDecode bool                               `yaml:"streaming_decode,omitempty" mapstructure:"streaming_decode"`
 }
 
-// MessageDecisionConfig represents configurable CEL-based decision logic.
-// Params are evaluated in the order they are defined.
-// Result is a CEL expression that evaluates to a boolean.
-type MessageDecisionConfig struct {
-	Result string  `mapstructure:"result"`
-	Params []Param `mapstructure:"params"`
+// Event ID modes.
+const (
+	EventIDModeRandom        = "random"
+	EventIDModeDeterministic = "deterministic"
+)
+
+// BrokerConfig contains broker configuration
+type BrokerConfig struct {
+	Topic string `yaml:"topic,omitempty" mapstructure:"topic"`
+	// Source is a text/template for the CloudEvent source of published
+	// events, rendered once at startup with EventSourceData, e.g.
+	// "hyperfleet-sentinel/{{.Instance}}/{{.Shard}}". Empty uses
+	// events.Source.
+	Source string `yaml:"source,omitempty" mapstructure:"source"`
+	// EventType is a text/template for the CloudEvent type of reconcile
+	// events, rendered per resource kind with EventSourceData, e.g.
+	// "com.example.tenant-a.{{.Kind}}.reconcile". Stuck events append
+	// ".stuck". Empty uses events.EventType.
+	EventType string `yaml:"event_type,omitempty" mapstructure:"event_type"`
+	// DataSchema is a text/template for the CloudEvent dataschema of reconcile
+	// and stuck events, rendered per resource kind with EventSourceData. It
+	// must render an absolute URI. Empty sets no dataschema.
+	DataSchema string `yaml:"data_schema,omitempty" mapstructure:"data_schema"`
+	// ProbeTopics publishes a probe event to every topic at startup and keeps
+	// the Sentinel not-ready while a topic is unreachable.
+	ProbeTopics bool `yaml:"probe_topics,omitempty" mapstructure:"probe_topics"`
+	// Topics routes events by resource kind: events of a kind listed here are
+	// published to its topic instead of Topic. Kinds match case-insensitively.
+	Topics map[string]string `yaml:"topics,omitempty" mapstructure:"topics"`
+	// TopicPrefix is prepended to every topic published to, including region
+	// and watcher topics, e.g. "staging-" for a broker shared by environments.
+	TopicPrefix string `yaml:"topic_prefix,omitempty" mapstructure:"topic_prefix"`
+	// Encodings selects the payload encoding per topic, before TopicPrefix:
+	// EncodingJSON (default) or EncodingProtobuf. Topics match
+	// case-insensitively.
+	Encodings map[string]string `yaml:"encodings,omitempty" mapstructure:"encodings"`
+	// MessageOptions sets a TTL, a priority, and headers on the reconcile
+	// and stuck events published to a topic, named before TopicPrefix. Topics
+	// match case-insensitively.
+	MessageOptions map[string]BrokerMessageOptionsConfig `yaml:"message_options,omitempty" mapstructure:"message_options"`
+	// EventIDMode selects how reconcile event IDs are generated: random
+	// (default) gives every publish a new UUID; deterministic derives the ID
+	// from the resource, its generation and status, and the reason, so that
+	// consumers can recognize redelivered and repeated events.
+	EventIDMode string `yaml:"event_id_mode,omitempty" mapstructure:"event_id_mode"`
+	// CycleSummaryTopic, when set, receives an events.CycleSummaryEventType
+	// event after every poll cycle. TopicPrefix applies to it.
+	CycleSummaryTopic string `yaml:"cycle_summary_topic,omitempty" mapstructure:"cycle_summary_topic"`
+	// PublishTimeout bounds one publish through the broker library, whose
+	// publishers do not honor the context on their own. Zero uses the
+	// publisher default.
+	PublishTimeout time.Duration `yaml:"publish_timeout,omitempty" mapstructure:"publish_timeout"`
+	// Retry retries publishes that fail with a transient broker error before
+	// they count as failed.
+	Retry *BrokerRetryConfig `yaml:"retry,omitempty" mapstructure:"retry"`
+	// Backpressure pauses publishing while the broker keeps failing.
+	Backpressure *BrokerBackpressureConfig `yaml:"backpressure,omitempty" mapstructure:"backpressure"`
+	// Outbox, when set, spools events whose publish failed to disk and
+	// replays them once the broker recovers.
+	Outbox *BrokerOutboxConfig `yaml:"outbox,omitempty" mapstructure:"outbox"`
+	// DeadLetter, when set, routes the event of a resource whose publishes
+	// keep failing to a dead letter topic or file.
+	DeadLetter *BrokerDeadLetterConfig `yaml:"dead_letter,omitempty" mapstructure:"dead_letter"`
+	// Webhook, when set, POSTs events to an HTTP endpoint instead of
+	// publishing them through the broker library; broker.yaml is not read.
+	Webhook *BrokerWebhookConfig `yaml:"webhook,omitempty" mapstructure:"webhook"`
+	// File, when set, writes events to a file or stdout instead of publishing
+	// them, for local development; broker.yaml is not read.
+	File *BrokerFileConfig `yaml:"file,omitempty" mapstructure:"file"`
+	// FanOut publishes every event also to each target, e.g. to the new
+	// broker while migrating between brokers.
+	FanOut []BrokerTargetConfig `yaml:"fan_out,omitempty" mapstructure:"fan_out"`
+	// Batch, when set, collects the events published to a topic into batches
+	// to cut the per-message overhead when many resources are published in
+	// one cycle.
+	Batch *BrokerBatchConfig `yaml:"batch,omitempty" mapstructure:"batch"`
+	// AsyncPublish, when set, queues events and publishes them in the
+	// background, so that a poll cycle does not wait for broker confirms.
+	AsyncPublish *BrokerAsyncPublishConfig `yaml:"async_publish,omitempty" mapstructure:"async_publish"`
 }
 
-// SentinelConfig represents the Sentinel configuration
-type SentinelConfig struct {
-	Log              LogConfig              `yaml:"log,omitempty" mapstructure:"log"`
-	Sentinel         SentinelInfo           `yaml:"sentinel" mapstructure:"sentinel"`
-	ResourceType     string                 `yaml:"resource_type" mapstructure:"resource_type"`
-	Clients          ClientsConfig          `yaml:"clients" mapstructure:"clients"`
-	MessageData      map[string]interface{} `yaml:"message_data,omitempty" mapstructure:"message_data"`
-	MessageDecision  *MessageDecisionConfig `yaml:"message_decision,omitempty" mapstructure:"message_decision"`
-	ResourceSelector LabelSelectorList      `yaml:"resource_selector,omitempty" mapstructure:"resource_selector"`
-	PollInterval     time.Duration          `yaml:"poll_interval" mapstructure:"poll_interval"`
-	DebugConfig      bool                   `yaml:"debug_config,omitempty" mapstructure:"debug_config"`
-	TracingEnabled   bool                   `yaml:"tracing_enabled,omitempty" mapstructure:"tracing_enabled"`
+// Payload encodings.
+const (
+	EncodingJSON     = "json"
+	EncodingProtobuf = "protobuf"
+)
+
+// maxMessagePriority is the highest priority AMQP 0-9-1 allows.
+const maxMessagePriority = 255
+
+// BrokerMessageOptionsConfig is set on the reconcile and stuck events
+// published to a topic as CloudEvent extension attributes: TTL as the
+// events.ExpiryTimeExtension timestamp, Priority as
+// events.PriorityExtension, and each header under its name. A header value is
+// a text/template rendered with MessageHeaderData; a header that renders
+// empty is not set. Zero TTL and Priority are not set.
+type BrokerMessageOptionsConfig struct {
+	Headers  map[string]string `yaml:"headers,omitempty" mapstructure:"headers"`
+	TTL      time.Duration     `yaml:"ttl,omitempty" mapstructure:"ttl"`
+	Priority int               `yaml:"priority,omitempty" mapstructure:"priority"`
 }
 
-// SentinelInfo contains basic sentinel information
-type SentinelInfo struct {
-	Name string `yaml:"name" mapstructure:"name"`
+// MessageHeaderData is the data available to the templates of
+// clients.broker.message_options headers.
+type MessageHeaderData struct {
+	// Labels are the labels of the resource; a missing label renders empty.
+	Labels map[string]string
+	// ID is the ID of the resource.
+	ID string
+	// Kind is the kind of the resource, e.g. "Cluster".
+	Kind string
 }
 
-// LogConfig contains logging configuration.
-// Priority (lowest to highest): config file < HYPERFLEET_LOG_* env vars < --log-* CLI flags
-type LogConfig struct {
-	Level  string `yaml:"level,omitempty" mapstructure:"level"`
-	Format string `yaml:"format,omitempty" mapstructure:"format"`
-	Output string `yaml:"output,omitempty" mapstructure:"output"`
+// messageHeaderName matches the CloudEvent extension attribute names a
+// header may use.
+var messageHeaderName = regexp.MustCompile(`^[a-z0-9]{1,20}$`)
+
+// Validate returns an error if the message options are invalid.
+func (m *BrokerMessageOptionsConfig) Validate() error {
+	if m.TTL < 0 {
+		return fmt.Errorf("ttl must not be negative, got %s", m.TTL)
+	}
+	if m.Priority < 0 || m.Priority > maxMessagePriority {
+		return fmt.Errorf("priority must be between 0 and %d, got %d", maxMessagePriority, m.Priority)
+	}
+	for name, value := range m.Headers {
+		if !messageHeaderName.MatchString(name) {
+			return fmt.Errorf("headers: name must be 1 to 20 lower-case letters or digits, got %q", name)
+		}
+		if events.IsReservedAttribute(name) {
+			return fmt.Errorf("headers: name %q is reserved", name)
+		}
+		if _, err := ParseMessageHeader(name, value); err != nil {
+			return fmt.Errorf("headers: %s: %w", name, err)
+		}
+	}
+	return nil
 }
 
-// ClientsConfig contains all client configurations
-type ClientsConfig struct {
-	HyperFleetAPI *HyperFleetAPIConfig `yaml:"hyperfleet_api" mapstructure:"hyperfleet_api"`
-	Broker        *BrokerConfig        `yaml:"broker,omitempty" mapstructure:"broker"`
+// ParseMessageHeader parses the template of a message_options header.
+func ParseMessageHeader(name, value string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
 }
 
-// HyperFleetAPIAuthConfig defines optional JWT authentication via a Kubernetes
-// projected service account token. When set, the bearer token is read from
-// TokenPath and injected into every API request.
-type HyperFleetAPIAuthConfig struct {
-	TokenPath     string        `yaml:"token_path" mapstructure:"token_path"`
-	TokenCacheTTL time.Duration `yaml:"token_cache_ttl" mapstructure:"token_cache_ttl"`
+// PrimaryTargetName is the target name of the broker or webhook configured by
+// BrokerConfig itself when FanOut is set.
+const PrimaryTargetName = "primary"
+
+// BrokerTargetConfig is a publish target in addition to the primary one.
+// Exactly one of ConfigFile, a broker library configuration like broker.yaml,
+// and Webhook is set. A failed publish to an Optional target is only logged
+// and counted; any other failure fails the publish.
+type BrokerTargetConfig struct {
+	Name       string               `yaml:"name" mapstructure:"name"`
+	ConfigFile string               `yaml:"config_file,omitempty" mapstructure:"config_file"`
+	Webhook    *BrokerWebhookConfig `yaml:"webhook,omitempty" mapstructure:"webhook"`
+	Optional   bool                 `yaml:"optional,omitempty" mapstructure:"optional"`
 }
 
-// Validate returns an error if the auth config is incomplete.
-func (a *HyperFleetAPIAuthConfig) Validate() error {
-	if a.TokenPath == "" {
-		return fmt.Errorf("token_path is required")
+// Validate returns an error if the target config is invalid.
+func (t *BrokerTargetConfig) Validate() error {
+	if t.Name == "" || strings.ContainsFunc(t.Name, unicode.IsSpace) {
+		return fmt.Errorf("name must be non-empty and contain no whitespace, got %q", t.Name)
 	}
-	if !filepath.IsAbs(a.TokenPath) {
-		return fmt.Errorf("token_path must be an absolute path, got %q", a.TokenPath)
+	if t.Name == PrimaryTargetName {
+		return fmt.Errorf("name %q is reserved for the primary target", PrimaryTargetName)
 	}
-	if a.TokenCacheTTL < 0 {
-		return fmt.Errorf("token_cache_ttl must not be negative")
+	if (t.ConfigFile == "") == (t.Webhook == nil) {
+		return fmt.Errorf("exactly one of config_file and webhook is required")
+	}
+	if t.Webhook != nil {
+		if err := t.Webhook.Validate(); err != nil {
+			return fmt.Errorf("webhook: %w", err)
+		}
 	}
 	return nil
 }
 
-// HyperFleetAPIConfig defines the HyperFleet API client configuration
-type HyperFleetAPIConfig struct {
-	Auth     *HyperFleetAPIAuthConfig `yaml:"auth,omitempty" mapstructure:"auth"`
-	BaseURL  string                   `yaml:"base_url" mapstructure:"base_url"`
-	Version  string                   `yaml:"version,omitempty" mapstructure:"version"`
-	Timeout  time.Duration            `yaml:"timeout" mapstructure:"timeout"`
-	PageSize int32                    `yaml:"page_size,omitempty" mapstructure:"page_size"`
+// BrokerOutboxConfig spools events whose publish failed to a BoltDB file at
+// Path. Each watcher keeps at most MaxEvents of them, the latest per
+// resource, dropping the oldest when full; 0 uses the default. Spooled events
+// are replayed at the end of every poll cycle.
+type BrokerOutboxConfig struct {
+	Path      string `yaml:"path" mapstructure:"path"`
+	MaxEvents int    `yaml:"max_events,omitempty" mapstructure:"max_events"`
 }
 
-// BrokerConfig contains broker configuration
-type BrokerConfig struct {
-	Topic string `yaml:"topic,omitempty" mapstructure:"topic"`
+// Validate returns an error if the outbox config is invalid.
+func (o *BrokerOutboxConfig) Validate() error {
+	if o.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+	if o.MaxEvents < 0 {
+		return fmt.Errorf("max_events must not be negative, got %d", o.MaxEvents)
+	}
+	return nil
+}
+
+// BrokerDeadLetterConfig routes the event of a resource to a dead letter sink
+// once MaxAttempts publishes for the same generation of the resource have
+// failed in a row. The event is published to Topic or appended as a line of
+// JSON to the file at Path; exactly one of them is set. Each generation is
+// dead-lettered at most once, and the resource keeps being retried.
+type BrokerDeadLetterConfig struct {
+	MaxAttempts int    `yaml:"max_attempts" mapstructure:"max_attempts"`
+	Topic       string `yaml:"topic,omitempty" mapstructure:"topic"`
+	Path        string `yaml:"path,omitempty" mapstructure:"path"`
+}
+
+// Validate returns an error if the dead letter config is invalid.
+func (d *BrokerDeadLetterConfig) Validate() error {
+	if d.MaxAttempts < 1 {
+		return fmt.Errorf("max_attempts must be at least 1, got %d", d.MaxAttempts)
+	}
+	if (d.Topic == "") == (d.Path == "") {
+		return fmt.Errorf("exactly one of topic and path is required")
+	}
+	if strings.ContainsFunc(d.Topic, unicode.IsSpace) {
+		return fmt.Errorf("topic must not contain whitespace, got %q", d.Topic)
+	}
+	return nil
+}
+
+// Webhook encoding modes.
+const (
+	WebhookModeStructured = "structured"
+	WebhookModeBinary     = "binary"
+)
+
+// Webhook signature algorithms, the hash of the HMAC.
+const (
+	WebhookSignatureSHA256 = "sha256"
+	WebhookSignatureSHA512 = "sha512"
+	WebhookSignatureSHA1   = "sha1"
+)
+
+// BrokerWebhookConfig configures the HTTP webhook sink. Events are POSTed to
+// URL in the CloudEvents HTTP binding's Mode, structured (the default) or
+// binary. When SecretPath is set, the body is signed with an HMAC using the
+// key read from that file and the hash of SignatureAlgorithm, SHA-256 when
+// empty. Each attempt is bounded by Timeout, and a publish is tried up to
+// MaxAttempts times; zero values keep the publisher defaults.
+type BrokerWebhookConfig struct {
+	URL                string        `yaml:"url" mapstructure:"url"`
+	Mode               string        `yaml:"mode,omitempty" mapstructure:"mode"`
+	SecretPath         string        `yaml:"secret_path,omitempty" mapstructure:"secret_path"`
+	SignatureAlgorithm string        `yaml:"signature_algorithm,omitempty" mapstructure:"signature_algorithm"`
+	Timeout            time.Duration `yaml:"timeout,omitempty" mapstructure:"timeout"`
+	MaxAttempts        int           `yaml:"max_attempts,omitempty" mapstructure:"max_attempts"`
+}
+
+// RedactedURL returns URL with its password replaced.
+func (w *BrokerWebhookConfig) RedactedURL() string {
+	return redactURL(w.URL)
+}
+
+// Signature returns SignatureAlgorithm, or sha256 when it is not set.
+func (w *BrokerWebhookConfig) Signature() string {
+	if w.SignatureAlgorithm == "" {
+		return WebhookSignatureSHA256
+	}
+	return w.SignatureAlgorithm
+}
+
+// validateFIPS returns an error if the webhook would send events without TLS
+// or sign them with a hash that is not FIPS-approved.
+func (w *BrokerWebhookConfig) validateFIPS() error {
+	if err := fips.CheckURL(w.URL); err != nil {
+		return fmt.Errorf("url: %w", err)
+	}
+	if w.SecretPath != "" {
+		if err := fips.CheckSignatureAlgorithm(w.Signature()); err != nil {
+			return fmt.Errorf("signature_algorithm: %w", err)
+		}
+	}
+	return nil
+}
+
+// FileStdout is the BrokerFileConfig.Path that writes events to stdout.
+const FileStdout = "-"
+
+// BrokerFileConfig configures the file sink, which appends every event as a
+// line of JSON to Path, or writes it to stdout when Path is FileStdout.
+type BrokerFileConfig struct {
+	Path string `yaml:"path" mapstructure:"path"`
+}
+
+// Validate returns an error if the file config is invalid.
+func (f *BrokerFileConfig) Validate() error {
+	if f.Path != FileStdout && !filepath.IsAbs(f.Path) {
+		return fmt.Errorf("path must be %q or an absolute path, got %q", FileStdout, f.Path)
+	}
+	return nil
+}
+
+// Validate returns an error if the webhook config is invalid.
+func (w *BrokerWebhookConfig) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL, got %q", redactURL(w.URL))
+	}
+	switch w.Mode {
+	case "", WebhookModeStructured, WebhookModeBinary:
+	default:
+		return fmt.Errorf("mode must be one of structured, binary, got %q", w.Mode)
+	}
+	if w.SecretPath != "" && !filepath.IsAbs(w.SecretPath) {
+		return fmt.Errorf("secret_path must be an absolute path, got %q", w.SecretPath)
+	}
+	switch w.SignatureAlgorithm {
+	case "", WebhookSignatureSHA256, WebhookSignatureSHA512, WebhookSignatureSHA1:
+	default:
+		return fmt.Errorf("signature_algorithm must be one of sha256, sha512, sha1, got %q", w.SignatureAlgorithm)
+	}
+	if w.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative, got %s", w.Timeout)
+	}
+	if w.MaxAttempts < 0 {
+		return fmt.Errorf("max_attempts must not be negative, got %d", w.MaxAttempts)
+	}
+	return nil
+}
+
+// BrokerRetryConfig retries a publish that failed with a transient broker
+// error up to MaxAttempts attempts in all, waiting InitialInterval before the
+// first retry and doubling the wait up to MaxInterval. Zero intervals keep
+// the publisher defaults.
+type BrokerRetryConfig struct {
+	MaxAttempts     int           `yaml:"max_attempts" mapstructure:"max_attempts"`
+	InitialInterval time.Duration `yaml:"initial_interval,omitempty" mapstructure:"initial_interval"`
+	MaxInterval     time.Duration `yaml:"max_interval,omitempty" mapstructure:"max_interval"`
+}
+
+// Validate returns an error if the retry config is invalid.
+func (r *BrokerRetryConfig) Validate() error {
+	if r.MaxAttempts < 1 {
+		return fmt.Errorf("max_attempts must be at least 1, got %d", r.MaxAttempts)
+	}
+	if r.InitialInterval < 0 {
+		return fmt.Errorf("initial_interval must not be negative, got %s", r.InitialInterval)
+	}
+	if r.MaxInterval < 0 {
+		return fmt.Errorf("max_interval must not be negative, got %s", r.MaxInterval)
+	}
+	if r.InitialInterval > 0 && r.MaxInterval > 0 && r.MaxInterval < r.InitialInterval {
+		return fmt.Errorf("max_interval (%s) must not be less than initial_interval (%s)",
+			r.MaxInterval, r.InitialInterval)
+	}
+	return nil
+}
+
+// maxBatchSize bounds batch.max_size. A poll cycle publishes up to max_size
+// resources at once to fill batches, so it shares the bound of
+// workers.concurrency.
+const maxBatchSize = maxWorkerConcurrency
+
+// BrokerBatchConfig publishes the events of a topic in batches of up to
+// MaxSize events. A batch is flushed once it is full or FlushInterval after
+// its first event, whichever comes first.
+type BrokerBatchConfig struct {
+	MaxSize       int           `yaml:"max_size" mapstructure:"max_size"`
+	FlushInterval time.Duration `yaml:"flush_interval" mapstructure:"flush_interval"`
+}
+
+// Validate returns an error if the batch config is invalid.
+func (bc *BrokerBatchConfig) Validate() error {
+	if bc.MaxSize < 2 || bc.MaxSize > maxBatchSize {
+		return fmt.Errorf("max_size must be between 2 and %d, got %d", maxBatchSize, bc.MaxSize)
+	}
+	if bc.FlushInterval <= 0 {
+		return fmt.Errorf("flush_interval must be positive, got %s", bc.FlushInterval)
+	}
+	return nil
+}
+
+// BrokerAsyncPublishConfig queues up to QueueSize events and publishes them
+// on MaxInFlight goroutines. An event whose publish fails is re-queued until
+// it has been attempted MaxAttempts times, then dropped.
+type BrokerAsyncPublishConfig struct {
+	MaxInFlight int `yaml:"max_in_flight" mapstructure:"max_in_flight"`
+	QueueSize   int `yaml:"queue_size" mapstructure:"queue_size"`
+	MaxAttempts int `yaml:"max_attempts" mapstructure:"max_attempts"`
+}
+
+// Validate returns an error if the async publish config is invalid.
+func (a *BrokerAsyncPublishConfig) Validate() error {
+	if a.MaxInFlight < 1 || a.MaxInFlight > maxWorkerConcurrency {
+		return fmt.Errorf("max_in_flight must be between 1 and %d, got %d", maxWorkerConcurrency, a.MaxInFlight)
+	}
+	if a.QueueSize < 1 {
+		return fmt.Errorf("queue_size must be at least 1, got %d", a.QueueSize)
+	}
+	if a.MaxAttempts < 1 {
+		return fmt.Errorf("max_attempts must be at least 1, got %d", a.MaxAttempts)
+	}
+	return nil
+}
+
+// BrokerBackpressureConfig pauses publishing once ErrorThreshold publishes
+// have failed within Window. For Cooldown, resources that would be published
+// are skipped and the Sentinel reports not-ready; then publishing resumes.
+type BrokerBackpressureConfig struct {
+	ErrorThreshold int           `yaml:"error_threshold" mapstructure:"error_threshold"`
+	Window         time.Duration `yaml:"window" mapstructure:"window"`
+	Cooldown       time.Duration `yaml:"cooldown" mapstructure:"cooldown"`
+}
+
+// Validate returns an error if the backpressure config is invalid.
+func (bp *BrokerBackpressureConfig) Validate() error {
+	if bp.ErrorThreshold < 1 {
+		return fmt.Errorf("error_threshold must be at least 1, got %d", bp.ErrorThreshold)
+	}
+	if bp.Window <= 0 {
+		return fmt.Errorf("window must be positive, got %s", bp.Window)
+	}
+	if bp.Cooldown <= 0 {
+		return fmt.Errorf("cooldown must be positive, got %s", bp.Cooldown)
+	}
+	return nil
+}
+
+// DeterministicEventIDs reports whether EventIDMode is deterministic.
+func (b *BrokerConfig) DeterministicEventIDs() bool {
+	return b != nil && b.EventIDMode == EventIDModeDeterministic
+}
+
+// Validate returns an error if a topic route, the topic prefix, the cycle
+// summary topic, a topic encoding or message options, the event ID mode, the
+// publish timeout, the retry, backpressure, outbox, dead letter, webhook,
+// file, batch, or async publish block, or a fan-out target is invalid.
+func (b *BrokerConfig) Validate() error {
+	switch b.EventIDMode {
+	case "", EventIDModeRandom, EventIDModeDeterministic:
+	default:
+		return fmt.Errorf("event_id_mode must be one of random, deterministic, got %q", b.EventIDMode)
+	}
+	if b.PublishTimeout < 0 {
+		return fmt.Errorf("publish_timeout must not be negative, got %s", b.PublishTimeout)
+	}
+	if strings.ContainsFunc(b.CycleSummaryTopic, unicode.IsSpace) {
+		return fmt.Errorf("cycle_summary_topic must not contain whitespace, got %q", b.CycleSummaryTopic)
+	}
+	if b.Retry != nil {
+		if err := b.Retry.Validate(); err != nil {
+			return fmt.Errorf("retry: %w", err)
+		}
+	}
+	if b.Backpressure != nil {
+		if err := b.Backpressure.Validate(); err != nil {
+			return fmt.Errorf("backpressure: %w", err)
+		}
+	}
+	if b.Outbox != nil {
+		if err := b.Outbox.Validate(); err != nil {
+			return fmt.Errorf("outbox: %w", err)
+		}
+	}
+	if b.DeadLetter != nil {
+		if err := b.DeadLetter.Validate(); err != nil {
+			return fmt.Errorf("dead_letter: %w", err)
+		}
+	}
+	if b.Webhook != nil {
+		if err := b.Webhook.Validate(); err != nil {
+			return fmt.Errorf("webhook: %w", err)
+		}
+	}
+	if b.File != nil {
+		if b.Webhook != nil {
+			return fmt.Errorf("file and webhook are mutually exclusive")
+		}
+		if err := b.File.Validate(); err != nil {
+			return fmt.Errorf("file: %w", err)
+		}
+	}
+	if b.Batch != nil {
+		if err := b.Batch.Validate(); err != nil {
+			return fmt.Errorf("batch: %w", err)
+		}
+	}
+	if b.AsyncPublish != nil {
+		if err := b.AsyncPublish.Validate(); err != nil {
+			return fmt.Errorf("async_publish: %w", err)
+		}
+	}
+	names := make(map[string]bool, len(b.FanOut))
+	for i := range b.FanOut {
+		t := &b.FanOut[i]
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("fan_out[%d]: %w", i, err)
+		}
+		if names[t.Name] {
+			return fmt.Errorf("fan_out[%d]: duplicate target name %q", i, t.Name)
+		}
+		names[t.Name] = true
+	}
+	if strings.ContainsFunc(b.TopicPrefix, unicode.IsSpace) {
+		return fmt.Errorf("topic_prefix must not contain whitespace, got %q", b.TopicPrefix)
+	}
+	for kind, topic := range b.Topics {
+		if strings.TrimSpace(kind) == "" {
+			return fmt.Errorf("topics: resource kind must not be empty")
+		}
+		if topic == "" || strings.ContainsFunc(topic, unicode.IsSpace) {
+			return fmt.Errorf("topics: topic for kind %q must be non-empty and contain no whitespace, got %q", kind, topic)
+		}
+	}
+	for topic, encoding := range b.Encodings {
+		if topic == "" || strings.ContainsFunc(topic, unicode.IsSpace) {
+			return fmt.Errorf("encodings: topic must be non-empty and contain no whitespace, got %q", topic)
+		}
+		switch encoding {
+		case EncodingJSON, EncodingProtobuf:
+		case "avro":
+			// Avro needs a schema registry, which only Kafka deployments
+			// provide, and the broker library has no Kafka support.
+			return fmt.Errorf("encodings: avro for topic %q is not supported: it requires a Kafka schema registry", topic)
+		default:
+			return fmt.Errorf("encodings: encoding for topic %q must be one of json, protobuf, got %q", topic, encoding)
+		}
+	}
+	for topic, opts := range b.MessageOptions {
+		if topic == "" || strings.ContainsFunc(topic, unicode.IsSpace) {
+			return fmt.Errorf("message_options: topic must be non-empty and contain no whitespace, got %q", topic)
+		}
+		if err := opts.Validate(); err != nil {
+			return fmt.Errorf("message_options: %s: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// TopicEncoding returns the payload encoding of events published to topic,
+// which includes TopicPrefix: its Encodings entry, or EncodingJSON.
+func (b *BrokerConfig) TopicEncoding(topic string) string {
+	if b == nil {
+		return EncodingJSON
+	}
+	for t, encoding := range b.Encodings {
+		if strings.EqualFold(b.TopicPrefix+t, topic) {
+			return encoding
+		}
+	}
+	return EncodingJSON
+}
+
+// ResolveTopic returns the topic for events of a resource kind whose default
+// topic is topic: the Topics entry for kind if there is one, with TopicPrefix
+// prepended.
+func (b *BrokerConfig) ResolveTopic(kind, topic string) string {
+	if b == nil {
+		return topic
+	}
+	for k, t := range b.Topics {
+		if strings.EqualFold(k, kind) {
+			topic = t
+			break
+		}
+	}
+	return b.TopicPrefix + topic
+}
+
+// EventSourceData is the data available to the clients.broker.source,
+// event_type, and data_schema templates.
+type EventSourceData struct {
+	// Name is sentinel.name.
+	Name string
+	// Instance identifies the process, normally the pod name.
+	Instance string
+	// Shard is the resource selector as in the resource_selector metric
+	// label, e.g. "shard:1", or "all" without a selector.
+	Shard string
+	// ResourceType is resource_type.
+	ResourceType string
+	// Kind is the lower-cased kind of the resource an event is published
+	// for, e.g. "cluster". It is empty for events about the Sentinel itself,
+	// such as cycle summaries.
+	Kind string
+}
+
+// EventSource renders Source with data. The result must be events.Source or
+// start with events.Source followed by "/", so that consumers using
+// pkg/events still recognize the events.
+func (b *BrokerConfig) EventSource(data EventSourceData) (string, error) {
+	if b == nil || b.Source == "" {
+		return events.Source, nil
+	}
+	source, err := renderEventTemplate(b.Source, data)
+	if err != nil {
+		return "", err
+	}
+	if !events.IsSource(source) {
+		return "", fmt.Errorf("must be %q or start with %q, got %q", events.Source, events.Source+"/", source)
+	}
+	if _, err := url.Parse(source); err != nil {
+		return "", fmt.Errorf("must be a URI reference, got %q", source)
+	}
+	return source, nil
+}
+
+// ReconcileEventType renders EventType with data, whose Kind must be set.
+// The result must be non-empty and contain no whitespace.
+func (b *BrokerConfig) ReconcileEventType(data EventSourceData) (string, error) {
+	if b == nil || b.EventType == "" {
+		return events.EventType(data.Kind), nil
+	}
+	eventType, err := renderEventTemplate(b.EventType, data)
+	if err != nil {
+		return "", err
+	}
+	if eventType == "" || strings.ContainsFunc(eventType, unicode.IsSpace) {
+		return "", fmt.Errorf("must be non-empty and contain no whitespace, got %q", eventType)
+	}
+	return eventType, nil
+}
+
+// EventDataSchema renders DataSchema with data, whose Kind must be set. The
+// result must be an absolute URI. It returns "" when DataSchema is not set.
+func (b *BrokerConfig) EventDataSchema(data EventSourceData) (string, error) {
+	if b == nil || b.DataSchema == "" {
+		return "", nil
+	}
+	schema, err := renderEventTemplate(b.DataSchema, data)
+	if err != nil {
+		return "", err
+	}
+	if u, err := url.Parse(schema); err != nil || !u.IsAbs() {
+		return "", fmt.Errorf("must be an absolute URI, got %q", schema)
+	}
+	return schema, nil
+}
+
+// renderEventTemplate renders the text/template text with data. Unknown
+// fields are errors.
+func renderEventTemplate(text string, data EventSourceData) (string, error) {
+	tmpl, err := template.New("event").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	return out.String(), nil
 }
 
 // ToMap converts label selectors to a map for filtering
@@ -141,10 +1978,13 @@ func DefaultMessageDecision() *MessageDecisionConfig {
 			{Name: "has_ref_time", Expr: `ref_time != ""`},
 			{Name: "is_new_resource", Expr: `resource.generation == 1 && !has_ref_time`},
 			{Name: "generation_mismatch", Expr: `resource.generation > condition("Reconciled").observed_generation`},
-			{Name: "reconciled_and_stale", Expr: `is_reconciled && has_ref_time && now - timestamp(ref_time) > duration("30m")`},
+			{
+				Name: "reconciled_and_stale",
+				Expr: `is_reconciled && has_ref_time && now - timestamp(ref_time) > max_age("ready", duration("30m"))`,
+			},
 			{
 				Name: "not_reconciled_and_debounced",
-				Expr: `!is_reconciled && has_ref_time && now - timestamp(ref_time) > duration("10s")`,
+				Expr: `!is_reconciled && has_ref_time && now - timestamp(ref_time) > max_age("not-ready", duration("10s"))`,
 			},
 		},
 		Result: "is_new_resource || generation_mismatch || reconciled_and_stale || not_reconciled_and_debounced",
@@ -166,14 +2006,16 @@ func NewSentinelConfig() *SentinelConfig {
 		},
 		Clients: ClientsConfig{
 			HyperFleetAPI: &HyperFleetAPIConfig{
-				Version:  "v1",
-				Timeout:  10 * time.Second,
-				PageSize: 20,
+				Version:      "v1",
+				Timeout:      10 * time.Second,
+				PageSize:     20,
+				MaxBodyBytes: defaultMaxBodyBytes,
 			},
 			Broker: &BrokerConfig{},
 		},
 		// ResourceType is required and must be set in config file
 		PollInterval:     5 * time.Second,
+		DrainTimeout:     10 * time.Second,
 		ResourceSelector: []LabelSelector{}, // Empty means watch all resources
 	}
 }
@@ -183,27 +2025,144 @@ func NewSentinelConfig() *SentinelConfig {
 // Note: Uses "::" as key delimiter to avoid conflicts with dots in YAML keys
 // Complex types (maps, slices) are intentionally excluded — they cannot be expressed as scalar env vars.
 var viperKeyMappings = map[string]string{
-	"debug_config":                                   "DEBUG_CONFIG",
-	"sentinel::name":                                 "SENTINEL_NAME",
-	"log::level":                                     "LOG_LEVEL",
-	"log::format":                                    "LOG_FORMAT",
-	"log::output":                                    "LOG_OUTPUT",
-	"clients::hyperfleet_api::base_url":              "API_BASE_URL",
-	"clients::hyperfleet_api::version":               "API_VERSION",
-	"clients::hyperfleet_api::timeout":               "API_TIMEOUT",
-	"clients::hyperfleet_api::page_size":             "API_PAGE_SIZE",
-	"clients::hyperfleet_api::auth::token_path":      "API_AUTH_TOKEN_PATH",
-	"clients::hyperfleet_api::auth::token_cache_ttl": "API_AUTH_TOKEN_CACHE_TTL",
-	"clients::broker::topic":                         "BROKER_TOPIC",
-	"resource_type":                                  "RESOURCE_TYPE",
-	"poll_interval":                                  "POLL_INTERVAL",
-	"tracing_enabled":                                "TRACING_ENABLED",
+	"debug_config":                                                "DEBUG_CONFIG",
+	"fips_mode":                                                   "FIPS_MODE",
+	"paused":                                                      "PAUSED",
+	"sentinel::name":                                              "SENTINEL_NAME",
+	"log::level":                                                  "LOG_LEVEL",
+	"log::format":                                                 "LOG_FORMAT",
+	"log::output":                                                 "LOG_OUTPUT",
+	"clients::hyperfleet_api::base_url":                           "API_BASE_URL",
+	"clients::hyperfleet_api::version":                            "API_VERSION",
+	"clients::hyperfleet_api::timeout":                            "API_TIMEOUT",
+	"clients::hyperfleet_api::page_size":                          "API_PAGE_SIZE",
+	"clients::hyperfleet_api::page_concurrency":                   "API_PAGE_CONCURRENCY",
+	"clients::hyperfleet_api::max_body_bytes":                     "API_MAX_BODY_BYTES",
+	"clients::hyperfleet_api::max_items":                          "API_MAX_ITEMS",
+	"clients::hyperfleet_api::streaming_decode":                   "API_STREAMING_DECODE",
+	"clients::hyperfleet_api::auth::token_path":                   "API_AUTH_TOKEN_PATH",
+	"clients::hyperfleet_api::auth::token_cache_ttl":              "API_AUTH_TOKEN_CACHE_TTL",
+	"clients::hyperfleet_api::tls::ca_file":                       "API_TLS_CA_FILE",
+	"clients::hyperfleet_api::tls::cert_file":                     "API_TLS_CERT_FILE",
+	"clients::hyperfleet_api::tls::key_file":                      "API_TLS_KEY_FILE",
+	"clients::hyperfleet_api::tls::min_version":                   "API_TLS_MIN_VERSION",
+	"clients::hyperfleet_api::tls::insecure_skip_verify":          "API_TLS_INSECURE_SKIP_VERIFY",
+	"clients::hyperfleet_api::circuit_breaker::failure_threshold": "API_CIRCUIT_BREAKER_FAILURE_THRESHOLD",
+	"clients::hyperfleet_api::circuit_breaker::cooldown":          "API_CIRCUIT_BREAKER_COOLDOWN",
+	"clients::hyperfleet_api::rate_limit::qps":                    "API_RATE_LIMIT_QPS",
+	"clients::hyperfleet_api::rate_limit::burst":                  "API_RATE_LIMIT_BURST",
+	"clients::hyperfleet_api::retry::strategy":                    "API_RETRY_STRATEGY",
+	"clients::hyperfleet_api::retry::initial_interval":            "API_RETRY_INITIAL_INTERVAL",
+	"clients::hyperfleet_api::retry::max_interval":                "API_RETRY_MAX_INTERVAL",
+	"clients::hyperfleet_api::retry::max_elapsed_time":            "API_RETRY_MAX_ELAPSED_TIME",
+	"clients::hyperfleet_api::transport::http_proxy":              "API_TRANSPORT_HTTP_PROXY",
+	"clients::hyperfleet_api::transport::https_proxy":             "API_TRANSPORT_HTTPS_PROXY",
+	"clients::hyperfleet_api::transport::no_proxy":                "API_TRANSPORT_NO_PROXY",
+	"clients::hyperfleet_api::transport::dial_timeout":            "API_TRANSPORT_DIAL_TIMEOUT",
+	"clients::hyperfleet_api::transport::idle_conn_timeout":       "API_TRANSPORT_IDLE_CONN_TIMEOUT",
+	"clients::hyperfleet_api::transport::max_idle_conns":          "API_TRANSPORT_MAX_IDLE_CONNS",
+	"clients::hyperfleet_api::transport::max_idle_conns_per_host": "API_TRANSPORT_MAX_IDLE_CONNS_PER_HOST",
+	"message_decision::maintenance_label":                         "MAINTENANCE_LABEL",
+	"message_decision::max_age_overrides::min":                    "MAX_AGE_OVERRIDES_MIN",
+	"message_decision::max_age_overrides::max":                    "MAX_AGE_OVERRIDES_MAX",
+	"message_decision::terminal_conditions":                       "TERMINAL_CONDITIONS",
+	"message_decision::stuck_deletion::timeout":                   "STUCK_DELETION_TIMEOUT",
+	"message_decision::clock_skew::tolerance":                     "CLOCK_SKEW_TOLERANCE",
+	"message_decision::clock_skew::fallback_to_created_time":      "CLOCK_SKEW_FALLBACK_TO_CREATED_TIME",
+	"clients::broker::topic":                                      "BROKER_TOPIC",
+	"clients::broker::source":                                     "BROKER_SOURCE",
+	"clients::broker::event_type":                                 "BROKER_EVENT_TYPE",
+	"clients::broker::data_schema":                                "BROKER_DATA_SCHEMA",
+	"clients::broker::probe_topics":                               "BROKER_PROBE_TOPICS",
+	"clients::broker::topic_prefix":                               "BROKER_TOPIC_PREFIX",
+	"clients::broker::event_id_mode":                              "BROKER_EVENT_ID_MODE",
+	"clients::broker::cycle_summary_topic":                        "BROKER_CYCLE_SUMMARY_TOPIC",
+	"clients::broker::backpressure::error_threshold":              "BROKER_BACKPRESSURE_ERROR_THRESHOLD",
+	"clients::broker::backpressure::window":                       "BROKER_BACKPRESSURE_WINDOW",
+	"clients::broker::backpressure::cooldown":                     "BROKER_BACKPRESSURE_COOLDOWN",
+	"clients::broker::publish_timeout":                            "BROKER_PUBLISH_TIMEOUT",
+	"clients::broker::retry::max_attempts":                        "BROKER_RETRY_MAX_ATTEMPTS",
+	"clients::broker::retry::initial_interval":                    "BROKER_RETRY_INITIAL_INTERVAL",
+	"clients::broker::retry::max_interval":                        "BROKER_RETRY_MAX_INTERVAL",
+	"clients::broker::outbox::path":                               "BROKER_OUTBOX_PATH",
+	"clients::broker::outbox::max_events":                         "BROKER_OUTBOX_MAX_EVENTS",
+	"clients::broker::dead_letter::max_attempts":                  "BROKER_DEAD_LETTER_MAX_ATTEMPTS",
+	"clients::broker::dead_letter::topic":                         "BROKER_DEAD_LETTER_TOPIC",
+	"clients::broker::dead_letter::path":                          "BROKER_DEAD_LETTER_PATH",
+	"clients::broker::webhook::url":                               "BROKER_WEBHOOK_URL",
+	"clients::broker::webhook::mode":                              "BROKER_WEBHOOK_MODE",
+	"clients::broker::webhook::secret_path":                       "BROKER_WEBHOOK_SECRET_PATH",
+	"clients::broker::webhook::signature_algorithm":               "BROKER_WEBHOOK_SIGNATURE_ALGORITHM",
+	"clients::broker::webhook::timeout":                           "BROKER_WEBHOOK_TIMEOUT",
+	"clients::broker::webhook::max_attempts":                      "BROKER_WEBHOOK_MAX_ATTEMPTS",
+	"clients::broker::file::path":                                 "BROKER_FILE_PATH",
+	"clients::broker::batch::max_size":                            "BROKER_BATCH_MAX_SIZE",
+	"clients::broker::batch::flush_interval":                      "BROKER_BATCH_FLUSH_INTERVAL",
+	"clients::broker::async_publish::max_in_flight":               "BROKER_ASYNC_PUBLISH_MAX_IN_FLIGHT",
+	"clients::broker::async_publish::queue_size":                  "BROKER_ASYNC_PUBLISH_QUEUE_SIZE",
+	"clients::broker::async_publish::max_attempts":                "BROKER_ASYNC_PUBLISH_MAX_ATTEMPTS",
+	"resource_type":                                               "RESOURCE_TYPE",
+	"poll_interval":                                               "POLL_INTERVAL",
+	"drain_timeout":                                               "DRAIN_TIMEOUT",
+	"cycle_timeout":                                               "CYCLE_TIMEOUT",
+	"max_consecutive_failures":                                    "MAX_CONSECUTIVE_FAILURES",
+	"incremental_fetch::full_list_interval":                       "INCREMENTAL_FETCH_FULL_LIST_INTERVAL",
+	"adaptive_interval::min":                                      "ADAPTIVE_INTERVAL_MIN",
+	"adaptive_interval::max":                                      "ADAPTIVE_INTERVAL_MAX",
+	"evaluation_cache::revalidate_after":                          "EVALUATION_CACHE_REVALIDATE_AFTER",
+	"message_schema::enabled":                                     "MESSAGE_SCHEMA_ENABLED",
+	"message_schema::path":                                        "MESSAGE_SCHEMA_PATH",
+	"republish_backoff::initial_interval":                         "REPUBLISH_BACKOFF_INITIAL_INTERVAL",
+	"republish_backoff::max_interval":                             "REPUBLISH_BACKOFF_MAX_INTERVAL",
+	"dedup::window":                                               "DEDUP_WINDOW",
+	"update_cooldown::timeout":                                    "UPDATE_COOLDOWN_TIMEOUT",
+	"state_store::type":                                           "STATE_STORE_TYPE",
+	"state_store::url":                                            "STATE_STORE_URL",
+	"state_store::key_prefix":                                     "STATE_STORE_KEY_PREFIX",
+	"state_store::path":                                           "STATE_STORE_PATH",
+	"state_store::ttl":                                            "STATE_STORE_TTL",
+	"state_store::timeout":                                        "STATE_STORE_TIMEOUT",
+	"generation_drift::condition":                                 "GENERATION_DRIFT_CONDITION",
+	"generation_drift::cycles":                                    "GENERATION_DRIFT_CYCLES",
+	"generation_drift::duration":                                  "GENERATION_DRIFT_DURATION",
+	"flap_detection::condition":                                   "FLAP_DETECTION_CONDITION",
+	"flap_detection::window":                                      "FLAP_DETECTION_WINDOW",
+	"flap_detection::flips":                                       "FLAP_DETECTION_FLIPS",
+	"flap_detection::publish_interval":                            "FLAP_DETECTION_PUBLISH_INTERVAL",
+	"publish_stagger::interval":                                   "PUBLISH_STAGGER_INTERVAL",
+	"publish_stagger::jitter":                                     "PUBLISH_STAGGER_JITTER",
+	"publish_stagger::burst":                                      "PUBLISH_STAGGER_BURST",
+	"publish_rate_limit::max_events_per_cycle":                    "PUBLISH_RATE_LIMIT_MAX_EVENTS_PER_CYCLE",
+	"publish_rate_limit::max_events_per_second":                   "PUBLISH_RATE_LIMIT_MAX_EVENTS_PER_SECOND",
+	"workers::concurrency":                                        "WORKERS_CONCURRENCY",
+	"leader_election::name":                                       "LEADER_ELECTION_NAME",
+	"leader_election::namespace":                                  "LEADER_ELECTION_NAMESPACE",
+	"leader_election::identity":                                   "LEADER_ELECTION_IDENTITY",
+	"leader_election::lease_duration":                             "LEADER_ELECTION_LEASE_DURATION",
+	"leader_election::renew_deadline":                             "LEADER_ELECTION_RENEW_DEADLINE",
+	"leader_election::retry_period":                               "LEADER_ELECTION_RETRY_PERIOD",
+	"shard::index":                                                "SHARD_INDEX",
+	"shard::total":                                                "SHARD_TOTAL",
+	"decision_stream::socket_path":                                "DECISION_STREAM_SOCKET_PATH",
+	"decision_stream::buffer_size":                                "DECISION_STREAM_BUFFER_SIZE",
+	"decision_policy::url":                                        "DECISION_POLICY_URL",
+	"decision_policy::path":                                       "DECISION_POLICY_PATH",
+	"decision_policy::timeout":                                    "DECISION_POLICY_TIMEOUT",
+	"metrics_push::url":                                           "METRICS_PUSH_URL",
+	"metrics_push::job":                                           "METRICS_PUSH_JOB",
+	"metrics_push::timeout":                                       "METRICS_PUSH_TIMEOUT",
+	"resource_tagging::label":                                     "RESOURCE_TAGGING_LABEL",
+	"resource_tagging::min_interval":                              "RESOURCE_TAGGING_MIN_INTERVAL",
+	"cascade::resource_type":                                      "CASCADE_RESOURCE_TYPE",
+	"tracing_enabled":                                             "TRACING_ENABLED",
+	"dry_run":                                                     "DRY_RUN",
 }
 
 // cliFlags defines mappings from CLI flag names to config paths
 // Note: Uses "::" as key delimiter to avoid conflicts with dots in YAML keys
 var cliFlags = map[string]string{
 	"debug-config":             "debug_config",
+	"dry-run":                  "dry_run",
 	"name":                     "sentinel::name",
 	"hyperfleet-api-base-url":  "clients::hyperfleet_api::base_url",
 	"hyperfleet-api-version":   "clients::hyperfleet_api::version",
@@ -240,6 +2199,10 @@ func LoadConfig(configFile string, flags *pflag.FlagSet) (*SentinelConfig, error
 	v.SetConfigFile(configFile)
 
 	// Read the YAML file
+	if info, err := os.Stat(configFile); err == nil && info.Size() > maxConfigFileSize {
+		return nil, fmt.Errorf("failed to read config file: %s is %d bytes, limit is %d",
+			configFile, info.Size(), maxConfigFileSize)
+	}
 	if err := v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
@@ -270,14 +2233,21 @@ func LoadConfig(configFile string, flags *pflag.FlagSet) (*SentinelConfig, error
 	// mapstructure silently drops nil-valued keys during Unmarshal — meaning
 	// a blank `id:` in the YAML disappears before Validate() ever sees it.
 	if rawMD, ok := v.Get("message_data").(map[string]interface{}); ok {
-		if err := validateMessageDataLeaves(rawMD, "message_data"); err != nil {
+		if err := validateMessageDataLeaves(rawMD, "message_data", 1); err != nil {
 			return nil, fmt.Errorf("invalid config: %w", err)
 		}
 	}
 
-	// Apply default message_decision if not configured
+	// Apply default message_decision if not configured.
 	if cfg.MessageDecision == nil {
 		cfg.MessageDecision = DefaultMessageDecision()
+	} else {
+		cfg.MessageDecision = withDefaultExpressions(cfg.MessageDecision)
+	}
+	for i := range cfg.Watchers {
+		if md := cfg.Watchers[i].MessageDecision; md != nil {
+			cfg.Watchers[i].MessageDecision = withDefaultExpressions(md)
+		}
 	}
 
 	// Validate configuration
@@ -285,12 +2255,70 @@ func LoadConfig(configFile string, flags *pflag.FlagSet) (*SentinelConfig, error
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	log.Infof(ctx, "Configuration loaded successfully: name=%s resource_type=%s",
-		cfg.Sentinel.Name, cfg.ResourceType)
+	log.Infof(ctx, "Configuration loaded successfully: name=%s resource_type=%s watchers=%d",
+		cfg.Sentinel.Name, cfg.ResourceType, len(cfg.Watchers))
 
 	return cfg, nil
 }
 
+// withDefaultExpressions returns md, or, when md sets none of params, result,
+// and rules, the default params and result with md's maintenance_label,
+// condition_actions, max_age_overrides, maintenance_windows,
+// terminal_conditions, stuck_deletion, and clock_skew.
+func withDefaultExpressions(md *MessageDecisionConfig) *MessageDecisionConfig {
+	if md.Result != "" || len(md.Params) > 0 || len(md.Rules) > 0 {
+		return md
+	}
+	def := DefaultMessageDecision()
+	def.MaintenanceLabel = md.MaintenanceLabel
+	def.ConditionActions = md.ConditionActions
+	def.MaxAgeOverrides = md.MaxAgeOverrides
+	def.MaintenanceWindows = md.MaintenanceWindows
+	def.TerminalConditions = md.TerminalConditions
+	def.StuckDeletion = md.StuckDeletion
+	def.ClockSkew = md.ClockSkew
+	return def
+}
+
+// MaxPollInterval returns the longest time between two poll cycles:
+// adaptive_interval.max when set, or else poll_interval.
+func (c *SentinelConfig) MaxPollInterval() time.Duration {
+	if c.AdaptiveInterval != nil {
+		return c.AdaptiveInterval.Max
+	}
+	return c.PollInterval
+}
+
+// WatcherConfigs returns the config of each poll loop: c itself without
+// watchers, or else one copy per watcher with the watcher's resource type,
+// selector, message decision, and topic in place of the top-level ones.
+func (c *SentinelConfig) WatcherConfigs() []*SentinelConfig {
+	if len(c.Watchers) == 0 {
+		return []*SentinelConfig{c}
+	}
+	cfgs := make([]*SentinelConfig, 0, len(c.Watchers))
+	for i := range c.Watchers {
+		w := &c.Watchers[i]
+		wc := *c
+		wc.Watchers = nil
+		wc.ResourceType = w.ResourceType
+		wc.ResourceSelector = w.ResourceSelector
+		if w.MessageDecision != nil {
+			wc.MessageDecision = w.MessageDecision
+		}
+		if w.Topic != "" {
+			var broker BrokerConfig
+			if c.Clients.Broker != nil {
+				broker = *c.Clients.Broker
+			}
+			broker.Topic = w.Topic
+			wc.Clients.Broker = &broker
+		}
+		cfgs = append(cfgs, &wc)
+	}
+	return cfgs
+}
+
 // fieldRemediation describes how a user can set a configuration field.
 type fieldRemediation struct {
 	Flag string // empty if not settable via CLI flag
@@ -318,11 +2346,33 @@ var fieldRemediations = map[string]fieldRemediation{
 		Env:  "HYPERFLEET_API_BASE_URL",
 		File: "clients.hyperfleet_api.base_url",
 	},
+	"clients.hyperfleet_api.version": {
+		Flag: "--hyperfleet-api-version",
+		Env:  "HYPERFLEET_API_VERSION",
+		File: "clients.hyperfleet_api.version",
+	},
 	"clients.hyperfleet_api.page_size": {
 		Flag: "--hyperfleet-api-page-size",
 		Env:  "HYPERFLEET_API_PAGE_SIZE",
 		File: "clients.hyperfleet_api.page_size",
 	},
+	"clients.hyperfleet_api.page_concurrency": {
+		Env:  "HYPERFLEET_API_PAGE_CONCURRENCY",
+		File: "clients.hyperfleet_api.page_concurrency",
+	},
+	"clients.hyperfleet_api.max_body_bytes": {
+		Env:  "HYPERFLEET_API_MAX_BODY_BYTES",
+		File: "clients.hyperfleet_api.max_body_bytes",
+	},
+	"clients.hyperfleet_api.max_items": {
+		Env:  "HYPERFLEET_API_MAX_ITEMS",
+		File: "clients.hyperfleet_api.max_items",
+	},
+	"clients.broker.topic": {
+		Flag: "--broker-topic",
+		Env:  "HYPERFLEET_BROKER_TOPIC",
+		File: "clients.broker.topic",
+	},
 	"poll_interval": {
 		Flag: "--poll-interval",
 		Env:  "HYPERFLEET_POLL_INTERVAL",
@@ -334,6 +2384,18 @@ var fieldRemediations = map[string]fieldRemediation{
 	"message_data": {
 		File: "message_data",
 	},
+	"drain_timeout": {
+		Env:  "HYPERFLEET_DRAIN_TIMEOUT",
+		File: "drain_timeout",
+	},
+	"cycle_timeout": {
+		Env:  "HYPERFLEET_CYCLE_TIMEOUT",
+		File: "cycle_timeout",
+	},
+	"max_consecutive_failures": {
+		Env:  "HYPERFLEET_MAX_CONSECUTIVE_FAILURES",
+		File: "max_consecutive_failures",
+	},
 	"tracing_enabled": {
 		Flag: "--tracing-enabled",
 		Env:  "HYPERFLEET_TRACING_ENABLED",
@@ -372,15 +2434,36 @@ func (c *SentinelConfig) Validate() error {
 		return validationErr("sentinel.name", "required")
 	}
 
-	if c.ResourceType == "" {
+	if len(c.Watchers) > 0 {
+		if err := c.validateWatchers(); err != nil {
+			return err
+		}
+	} else if c.ResourceType == "" {
 		return validationErr("resource_type", "required")
 	}
 
+	for name, rt := range c.ResourceTypes {
+		if !isDNSLabel(name) {
+			return fmt.Errorf("resource_types: name must be a lowercase DNS label (a-z, 0-9, '-'), got %q", name)
+		}
+		if err := rt.Validate(); err != nil {
+			return fmt.Errorf("resource_types.%s: %w", name, err)
+		}
+	}
+
+	if err := c.ResourceSelector.validate("resource_selector"); err != nil {
+		return err
+	}
+
 	if c.Clients.HyperFleetAPI == nil {
 		return validationErr("clients.hyperfleet_api", "required")
 	}
 
-	if c.Clients.HyperFleetAPI.BaseURL == "" {
+	if len(c.Clients.HyperFleetAPI.Regions) > 0 {
+		if err := c.validateRegions(); err != nil {
+			return err
+		}
+	} else if c.Clients.HyperFleetAPI.BaseURL == "" {
 		return validationErr("clients.hyperfleet_api.base_url", "required")
 	}
 
@@ -395,16 +2478,230 @@ func (c *SentinelConfig) Validate() error {
 			fmt.Sprintf("%d", c.Clients.HyperFleetAPI.PageSize))
 	}
 
+	if n := c.Clients.HyperFleetAPI.PageConcurrency; n < 0 || n > maxPageConcurrency {
+		return validationErr("clients.hyperfleet_api.page_concurrency",
+			fmt.Sprintf("must be between 0 and %d", maxPageConcurrency), fmt.Sprintf("%d", n))
+	}
+
+	if n := c.Clients.HyperFleetAPI.MaxBodyBytes; n < 0 {
+		return validationErr("clients.hyperfleet_api.max_body_bytes", "must not be negative", fmt.Sprintf("%d", n))
+	}
+
+	if n := c.Clients.HyperFleetAPI.MaxItems; n < 0 {
+		return validationErr("clients.hyperfleet_api.max_items", "must not be negative", fmt.Sprintf("%d", n))
+	}
+
+	if v := c.Clients.HyperFleetAPI.Version; v != "" && !slices.Contains(SupportedAPIVersions, v) {
+		return validationErr("clients.hyperfleet_api.version",
+			"must be one of "+strings.Join(SupportedAPIVersions, ", "), v)
+	}
+
 	if c.Clients.HyperFleetAPI.Auth != nil {
 		if err := c.Clients.HyperFleetAPI.Auth.Validate(); err != nil {
 			return fmt.Errorf("clients.hyperfleet_api.auth: %w", err)
 		}
 	}
 
+	if c.Clients.HyperFleetAPI.TLS != nil {
+		if err := c.Clients.HyperFleetAPI.TLS.Validate(); err != nil {
+			return fmt.Errorf("clients.hyperfleet_api.tls: %w", err)
+		}
+		if strings.HasPrefix(strings.ToLower(c.Clients.HyperFleetAPI.BaseURL), "http://") {
+			return fmt.Errorf("clients.hyperfleet_api.tls: base_url must use https when tls is configured")
+		}
+		for _, r := range c.Clients.HyperFleetAPI.Regions {
+			if strings.HasPrefix(strings.ToLower(r.BaseURL), "http://") {
+				return fmt.Errorf("clients.hyperfleet_api.tls: region %q base_url must use https when tls is configured",
+					r.Name)
+			}
+		}
+	}
+
+	if c.FIPSMode {
+		if err := c.validateFIPS(); err != nil {
+			return err
+		}
+	}
+
+	if c.Clients.HyperFleetAPI.CircuitBreaker != nil {
+		if err := c.Clients.HyperFleetAPI.CircuitBreaker.Validate(); err != nil {
+			return fmt.Errorf("clients.hyperfleet_api.circuit_breaker: %w", err)
+		}
+	}
+
+	if c.Clients.HyperFleetAPI.RateLimit != nil {
+		if err := c.Clients.HyperFleetAPI.RateLimit.Validate(); err != nil {
+			return fmt.Errorf("clients.hyperfleet_api.rate_limit: %w", err)
+		}
+	}
+
+	if c.Clients.HyperFleetAPI.Retry != nil {
+		if err := c.Clients.HyperFleetAPI.Retry.Validate(); err != nil {
+			return fmt.Errorf("clients.hyperfleet_api.retry: %w", err)
+		}
+	}
+
+	if c.Clients.HyperFleetAPI.Transport != nil {
+		if err := c.Clients.HyperFleetAPI.Transport.Validate(); err != nil {
+			return fmt.Errorf("clients.hyperfleet_api.transport: %w", err)
+		}
+	}
+
+	if err := c.validateBroker(); err != nil {
+		return err
+	}
+
+	sample := EventSourceData{
+		Name: c.Sentinel.Name, Instance: "instance", Shard: "shard", ResourceType: c.ResourceType,
+	}
+	if _, err := c.Clients.Broker.EventSource(sample); err != nil {
+		return fmt.Errorf("clients.broker.source: %w", err)
+	}
+	sample.Kind = "kind"
+	if _, err := c.Clients.Broker.ReconcileEventType(sample); err != nil {
+		return fmt.Errorf("clients.broker.event_type: %w", err)
+	}
+	if _, err := c.Clients.Broker.EventDataSchema(sample); err != nil {
+		return fmt.Errorf("clients.broker.data_schema: %w", err)
+	}
+
 	if c.PollInterval <= 0 {
 		return validationErr("poll_interval", "must be positive", c.PollInterval.String())
 	}
 
+	if c.DrainTimeout < 0 {
+		return validationErr("drain_timeout", "must not be negative", c.DrainTimeout.String())
+	}
+
+	if c.CycleTimeout < 0 {
+		return validationErr("cycle_timeout", "must not be negative", c.CycleTimeout.String())
+	}
+
+	if c.MaxConsecutiveFailures < 0 {
+		return validationErr("max_consecutive_failures", "must not be negative",
+			fmt.Sprintf("%d", c.MaxConsecutiveFailures))
+	}
+
+	if c.IncrementalFetch != nil && c.IncrementalFetch.FullListInterval < c.PollInterval {
+		return fmt.Errorf("incremental_fetch: full_list_interval (%s) must not be shorter than poll_interval (%s)",
+			c.IncrementalFetch.FullListInterval, c.PollInterval)
+	}
+
+	if a := c.AdaptiveInterval; a != nil {
+		if err := a.Validate(); err != nil {
+			return fmt.Errorf("adaptive_interval: %w", err)
+		}
+		if c.PollInterval < a.Min || c.PollInterval > a.Max {
+			return fmt.Errorf("adaptive_interval: poll_interval (%s) must be between min (%s) and max (%s)",
+				c.PollInterval, a.Min, a.Max)
+		}
+	}
+
+	if c.RepublishBackoff != nil {
+		if err := c.RepublishBackoff.Validate(); err != nil {
+			return fmt.Errorf("republish_backoff: %w", err)
+		}
+	}
+
+	if c.Dedup != nil {
+		if err := c.Dedup.Validate(); err != nil {
+			return fmt.Errorf("dedup: %w", err)
+		}
+	}
+
+	if c.UpdateCooldown != nil {
+		if err := c.UpdateCooldown.Validate(); err != nil {
+			return fmt.Errorf("update_cooldown: %w", err)
+		}
+	}
+
+	if c.StateStore != nil {
+		if err := c.StateStore.Validate(); err != nil {
+			return fmt.Errorf("state_store: %w", err)
+		}
+	}
+
+	if c.GenerationDrift != nil {
+		if err := c.GenerationDrift.Validate(); err != nil {
+			return fmt.Errorf("generation_drift: %w", err)
+		}
+	}
+
+	if c.FlapDetection != nil {
+		if err := c.FlapDetection.Validate(); err != nil {
+			return fmt.Errorf("flap_detection: %w", err)
+		}
+	}
+
+	if c.PublishStagger != nil {
+		if err := c.PublishStagger.Validate(); err != nil {
+			return fmt.Errorf("publish_stagger: %w", err)
+		}
+	}
+
+	if c.PublishRateLimit != nil {
+		if err := c.PublishRateLimit.Validate(); err != nil {
+			return fmt.Errorf("publish_rate_limit: %w", err)
+		}
+	}
+
+	if c.Workers != nil {
+		if err := c.Workers.Validate(); err != nil {
+			return fmt.Errorf("workers: %w", err)
+		}
+	}
+
+	if c.LeaderElection != nil {
+		if err := c.LeaderElection.Validate(); err != nil {
+			return fmt.Errorf("leader_election: %w", err)
+		}
+	}
+
+	if c.Shard != nil {
+		if err := c.Shard.Validate(); err != nil {
+			return fmt.Errorf("shard: %w", err)
+		}
+	}
+
+	if c.EvaluationCache != nil {
+		if err := c.EvaluationCache.Validate(); err != nil {
+			return fmt.Errorf("evaluation_cache: %w", err)
+		}
+	}
+
+	if c.DecisionStream != nil {
+		if err := c.DecisionStream.Validate(); err != nil {
+			return fmt.Errorf("decision_stream: %w", err)
+		}
+	}
+
+	if c.DecisionPolicy != nil {
+		if err := c.DecisionPolicy.Validate(); err != nil {
+			return fmt.Errorf("decision_policy: %w", err)
+		}
+	}
+
+	if c.MetricsPush != nil {
+		if err := c.MetricsPush.Validate(); err != nil {
+			return fmt.Errorf("metrics_push: %w", err)
+		}
+	}
+
+	if c.ResourceTagging != nil {
+		if err := c.ResourceTagging.Validate(); err != nil {
+			return fmt.Errorf("resource_tagging: %w", err)
+		}
+	}
+
+	if c.Cascade != nil {
+		if err := c.Cascade.Validate(); err != nil {
+			return fmt.Errorf("cascade: %w", err)
+		}
+		if c.Cascade.ResourceType == c.ResourceType {
+			return fmt.Errorf("cascade: resource_type must differ from the watched resource_type %q", c.ResourceType)
+		}
+	}
+
 	if c.MessageDecision == nil {
 		return validationErr("message_decision", "required")
 	}
@@ -417,17 +2714,159 @@ func (c *SentinelConfig) Validate() error {
 		return validationErr("message_data", "required")
 	}
 
-	if err := validateMessageDataLeaves(c.MessageData, "message_data"); err != nil {
+	if err := validateMessageDataLeaves(c.MessageData, "message_data", 1); err != nil {
 		return err
 	}
 
+	if c.MessageSchema != nil {
+		if err := c.MessageSchema.Validate(); err != nil {
+			return fmt.Errorf("message_schema: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateFIPS returns an error for settings that fips_mode does not allow:
+// skipping TLS verification of the HyperFleet API, and webhooks that send
+// events without TLS or sign them with a hash that is not FIPS-approved.
+// Broker library connections are checked when their publishers are created,
+// since broker.yaml is not part of this configuration.
+func (c *SentinelConfig) validateFIPS() error {
+	if c.Clients.HyperFleetAPI.TLS != nil && c.Clients.HyperFleetAPI.TLS.InsecureSkipVerify {
+		return fmt.Errorf("clients.hyperfleet_api.tls: insecure_skip_verify is not allowed when fips_mode is enabled")
+	}
+	b := c.Clients.Broker
+	if b == nil {
+		return nil
+	}
+	if b.Webhook != nil {
+		if err := b.Webhook.validateFIPS(); err != nil {
+			return fmt.Errorf("clients.broker.webhook: %w", err)
+		}
+	}
+	for i := range b.FanOut {
+		if w := b.FanOut[i].Webhook; w != nil {
+			if err := w.validateFIPS(); err != nil {
+				return fmt.Errorf("clients.broker.fan_out[%d].webhook: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateWatchers checks the watcher list. It replaces resource_type and
+// resource_selector, and each watcher must differ from the others in name and
+// in resource type or selector, otherwise two loops would publish the same
+// events under the same metric labels.
+func (c *SentinelConfig) validateWatchers() error {
+	if c.ResourceType != "" || len(c.ResourceSelector) > 0 {
+		return fmt.Errorf("watchers: resource_type and resource_selector must be set on each watcher instead")
+	}
+	if c.Clients.HyperFleetAPI != nil && len(c.Clients.HyperFleetAPI.Regions) > 0 {
+		return fmt.Errorf("watchers: not supported with clients.hyperfleet_api.regions")
+	}
+
+	names := make(map[string]bool, len(c.Watchers))
+	selected := make(map[string]bool, len(c.Watchers))
+	for i := range c.Watchers {
+		w := &c.Watchers[i]
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("watchers[%d]: %w", i, err)
+		}
+		if names[w.ID()] {
+			return fmt.Errorf("watchers[%d]: watcher %q is defined more than once", i, w.ID())
+		}
+		key := fmt.Sprint(w.ResourceType, w.ResourceSelector.ToMap())
+		if selected[key] {
+			return fmt.Errorf("watchers[%d]: resource_type %q with the same resource_selector is watched by another watcher",
+				i, w.ResourceType)
+		}
+		names[w.ID()] = true
+		selected[key] = true
+	}
+	return nil
+}
+
+// validateBroker checks the broker block and its kind-to-topic routes. Routing
+// by kind applies to the single clients.broker.topic, so it cannot be combined
+// with regions, and that topic is still required for kinds without a route.
+func (c *SentinelConfig) validateBroker() error {
+	b := c.Clients.Broker
+	if b == nil {
+		return nil
+	}
+	if err := b.Validate(); err != nil {
+		return fmt.Errorf("clients.broker: %w", err)
+	}
+	if len(b.Topics) == 0 {
+		return nil
+	}
+	if len(c.Clients.HyperFleetAPI.Regions) > 0 {
+		return fmt.Errorf("clients.broker.topics cannot be combined with clients.hyperfleet_api.regions")
+	}
+	if b.Topic == "" {
+		return validationErr("clients.broker.topic", "is required with clients.broker.topics for kinds without a route", "")
+	}
+	return nil
+}
+
+// validateRegions checks the multi-region endpoint list. base_url and regions
+// are mutually exclusive, region names must be unique, and no two regions may
+// share a topic, otherwise consumers could not tell their events apart.
+func (c *SentinelConfig) validateRegions() error {
+	api := c.Clients.HyperFleetAPI
+	if api.BaseURL != "" {
+		return fmt.Errorf("clients.hyperfleet_api: base_url and regions are mutually exclusive")
+	}
+
+	names := make(map[string]bool, len(api.Regions))
+	topics := make(map[string]bool, len(api.Regions))
+	for i := range api.Regions {
+		r := &api.Regions[i]
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("clients.hyperfleet_api.regions[%d]: %w", i, err)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("clients.hyperfleet_api.regions[%d]: region %q is defined more than once", i, r.Name)
+		}
+		if topics[r.Topic] {
+			return fmt.Errorf("clients.hyperfleet_api.regions[%d]: topic %q is used by another region", i, r.Topic)
+		}
+		names[r.Name] = true
+		topics[r.Topic] = true
+	}
 	return nil
 }
 
 // Validate validates the message decision configuration.
 func (md *MessageDecisionConfig) Validate() error {
-	if md.Result == "" {
+	switch {
+	case md.Result != "" && len(md.Rules) > 0:
+		return fmt.Errorf("result and rules are mutually exclusive")
+	case md.Result == "" && len(md.Rules) == 0:
 		return fmt.Errorf("result expression is required")
+	case md.Result != "":
+		if err := validateExpression(md.Result); err != nil {
+			return fmt.Errorf("result: %w", err)
+		}
+	}
+
+	seenRules := make(map[string]bool, len(md.Rules))
+	for i, r := range md.Rules {
+		if r.Name == "" || strings.ContainsFunc(r.Name, unicode.IsSpace) || strings.ContainsFunc(r.Name, unicode.IsControl) {
+			return fmt.Errorf("rules[%d]: name must be non-empty without whitespace, got %q", i, r.Name)
+		}
+		if r.Expr == "" {
+			return fmt.Errorf("rule %q has empty expression", r.Name)
+		}
+		if err := validateExpression(r.Expr); err != nil {
+			return fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+		if seenRules[r.Name] {
+			return fmt.Errorf("rule %q is defined more than once", r.Name)
+		}
+		seenRules[r.Name] = true
 	}
 
 	seenNames := make(map[string]bool, len(md.Params))
@@ -438,22 +2877,94 @@ func (md *MessageDecisionConfig) Validate() error {
 		if p.Expr == "" {
 			return fmt.Errorf("param %q has empty expression", p.Name)
 		}
+		if err := validateExpression(p.Expr); err != nil {
+			return fmt.Errorf("param %q: %w", p.Name, err)
+		}
 		if seenNames[p.Name] {
 			return fmt.Errorf("param %q is defined more than once", p.Name)
 		}
 		seenNames[p.Name] = true
 	}
 
+	if strings.ContainsFunc(md.MaintenanceLabel, unicode.IsSpace) {
+		return fmt.Errorf("maintenance_label must not contain whitespace, got %q", md.MaintenanceLabel)
+	}
+
+	if md.MaxAgeOverrides != nil {
+		if err := md.MaxAgeOverrides.Validate(); err != nil {
+			return fmt.Errorf("max_age_overrides: %w", err)
+		}
+	}
+
+	if md.StuckDeletion != nil {
+		if err := md.StuckDeletion.Validate(); err != nil {
+			return fmt.Errorf("stuck_deletion: %w", err)
+		}
+	}
+
+	if md.ClockSkew != nil {
+		if err := md.ClockSkew.Validate(); err != nil {
+			return fmt.Errorf("clock_skew: %w", err)
+		}
+	}
+
+	seenTerminal := make(map[string]bool, len(md.TerminalConditions))
+	for i, condition := range md.TerminalConditions {
+		if condition == "" || strings.ContainsFunc(condition, unicode.IsSpace) {
+			return fmt.Errorf("terminal_conditions[%d]: must be non-empty without whitespace, got %q", i, condition)
+		}
+		if seenTerminal[condition] {
+			return fmt.Errorf("terminal condition %q is listed more than once", condition)
+		}
+		seenTerminal[condition] = true
+	}
+
+	seenWindows := make(map[string]bool, len(md.MaintenanceWindows))
+	for i := range md.MaintenanceWindows {
+		w := &md.MaintenanceWindows[i]
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("maintenance_windows[%d]: %w", i, err)
+		}
+		if seenWindows[w.Name] {
+			return fmt.Errorf("maintenance window %q is defined more than once", w.Name)
+		}
+		seenWindows[w.Name] = true
+	}
+
+	seenBindings := make(map[string]bool, len(md.ConditionActions))
+	for i, a := range md.ConditionActions {
+		if a.Type == "" || strings.ContainsFunc(a.Type, unicode.IsSpace) {
+			return fmt.Errorf("condition_actions[%d]: type must be non-empty without whitespace, got %q", i, a.Type)
+		}
+		if a.Action != ConditionActionSkip && a.Action != ConditionActionPublish {
+			return fmt.Errorf("condition_actions[%d]: action must be %q or %q, got %q",
+				i, ConditionActionSkip, ConditionActionPublish, a.Action)
+		}
+		binding := a.Type + "=" + a.MatchStatus()
+		if seenBindings[binding] {
+			return fmt.Errorf("condition_actions[%d]: condition %s is bound more than once", i, binding)
+		}
+		seenBindings[binding] = true
+	}
+
 	return nil
 }
 
 // validateMessageDataLeaves recursively checks that every leaf value in a
 // message_data map is a non-empty string (CEL expression). nil values and
 // empty strings are rejected early so that the error is reported at config
-// load time rather than silently producing a broken payload.
-func validateMessageDataLeaves(data map[string]interface{}, path string) error {
+// load time rather than silently producing a broken payload. Expressions must
+// also pass validateExpression, keys must not contain control characters, and
+// maps may nest at most maxMessageDataDepth levels deep.
+func validateMessageDataLeaves(data map[string]interface{}, path string, depth int) error {
+	if depth > maxMessageDataDepth {
+		return fmt.Errorf("%s: nested more than %d levels deep", path, maxMessageDataDepth)
+	}
 	for k, v := range data {
 		fullKey := path + "." + k
+		if strings.ContainsFunc(k, unicode.IsControl) {
+			return fmt.Errorf("%q: key contains a control character", fullKey)
+		}
 		switch val := v.(type) {
 		case nil:
 			return fmt.Errorf(
@@ -468,8 +2979,11 @@ func validateMessageDataLeaves(data map[string]interface{}, path string) error {
 					fullKey,
 				)
 			}
+			if err := validateExpression(val); err != nil {
+				return fmt.Errorf("%s: %w", fullKey, err)
+			}
 		case map[string]interface{}:
-			if err := validateMessageDataLeaves(val, fullKey); err != nil {
+			if err := validateMessageDataLeaves(val, fullKey, depth+1); err != nil {
 				return err
 			}
 		}
@@ -480,17 +2994,205 @@ func validateMessageDataLeaves(data map[string]interface{}, path string) error {
 // RedactedCopy returns a deep copy of the config. Use this copy when logging
 // the merged configuration at startup so that any future sensitive fields are
 // never accidentally shared by reference.
-// Currently there are no sensitive params in the configuration, so this function is doing just a deep copy
+// Passwords embedded in proxy URLs are redacted.
 func (c *SentinelConfig) RedactedCopy() *SentinelConfig {
 	cp := *c
 
 	if cp.Clients.HyperFleetAPI != nil {
 		api := *cp.Clients.HyperFleetAPI
+		if api.TLS != nil {
+			tlsCfg := *api.TLS
+			api.TLS = &tlsCfg
+		}
+		if api.CircuitBreaker != nil {
+			cb := *api.CircuitBreaker
+			api.CircuitBreaker = &cb
+		}
+		if api.RateLimit != nil {
+			rl := *api.RateLimit
+			api.RateLimit = &rl
+		}
+		if api.Retry != nil {
+			r := *api.Retry
+			api.Retry = &r
+		}
+		if api.Transport != nil {
+			tr := *api.Transport
+			tr.HTTPProxy = redactURL(tr.HTTPProxy)
+			tr.HTTPSProxy = redactURL(tr.HTTPSProxy)
+			api.Transport = &tr
+		}
+		if api.Regions != nil {
+			api.Regions = append([]HyperFleetAPIRegionConfig(nil), api.Regions...)
+		}
 		cp.Clients.HyperFleetAPI = &api
 	}
 
+	if cp.IncrementalFetch != nil {
+		inc := *cp.IncrementalFetch
+		cp.IncrementalFetch = &inc
+	}
+
+	if cp.AdaptiveInterval != nil {
+		ap := *cp.AdaptiveInterval
+		cp.AdaptiveInterval = &ap
+	}
+
+	if cp.RepublishBackoff != nil {
+		rb := *cp.RepublishBackoff
+		cp.RepublishBackoff = &rb
+	}
+
+	if cp.Dedup != nil {
+		dd := *cp.Dedup
+		cp.Dedup = &dd
+	}
+
+	if cp.UpdateCooldown != nil {
+		uc := *cp.UpdateCooldown
+		cp.UpdateCooldown = &uc
+	}
+
+	if cp.StateStore != nil {
+		ss := *cp.StateStore
+		ss.URL = redactURL(ss.URL)
+		cp.StateStore = &ss
+	}
+	if cp.GenerationDrift != nil {
+		gd := *cp.GenerationDrift
+		cp.GenerationDrift = &gd
+	}
+	if cp.FlapDetection != nil {
+		fd := *cp.FlapDetection
+		cp.FlapDetection = &fd
+	}
+
+	if cp.PublishStagger != nil {
+		ps := *cp.PublishStagger
+		cp.PublishStagger = &ps
+	}
+	if cp.PublishRateLimit != nil {
+		rl := *cp.PublishRateLimit
+		cp.PublishRateLimit = &rl
+	}
+	if cp.Workers != nil {
+		w := *cp.Workers
+		cp.Workers = &w
+	}
+
+	if cp.LeaderElection != nil {
+		le := *cp.LeaderElection
+		cp.LeaderElection = &le
+	}
+
+	if cp.Shard != nil {
+		sh := *cp.Shard
+		cp.Shard = &sh
+	}
+
+	if cp.EvaluationCache != nil {
+		ec := *cp.EvaluationCache
+		cp.EvaluationCache = &ec
+	}
+
+	if cp.MessageSchema != nil {
+		ms := *cp.MessageSchema
+		cp.MessageSchema = &ms
+	}
+
+	if cp.ResourceTagging != nil {
+		rt := *cp.ResourceTagging
+		cp.ResourceTagging = &rt
+	}
+
+	if cp.Cascade != nil {
+		cc := *cp.Cascade
+		cp.Cascade = &cc
+	}
+
+	if c.ResourceTypes != nil {
+		rts := make(map[string]ResourceTypeConfig, len(c.ResourceTypes))
+		for name, rt := range c.ResourceTypes {
+			rts[name] = rt
+		}
+		cp.ResourceTypes = rts
+	}
+
+	if cp.DecisionStream != nil {
+		ds := *cp.DecisionStream
+		cp.DecisionStream = &ds
+	}
+
+	if cp.DecisionPolicy != nil {
+		dp := *cp.DecisionPolicy
+		dp.URL = dp.RedactedURL()
+		cp.DecisionPolicy = &dp
+	}
+
+	if cp.MetricsPush != nil {
+		mp := *cp.MetricsPush
+		mp.URL = mp.RedactedURL()
+		cp.MetricsPush = &mp
+	}
+
 	if cp.Clients.Broker != nil {
 		b := *cp.Clients.Broker
+		if b.Topics != nil {
+			b.Topics = maps.Clone(b.Topics)
+		}
+		if b.Encodings != nil {
+			b.Encodings = maps.Clone(b.Encodings)
+		}
+		if b.MessageOptions != nil {
+			b.MessageOptions = maps.Clone(b.MessageOptions)
+			for topic, opts := range b.MessageOptions {
+				opts.Headers = maps.Clone(opts.Headers)
+				b.MessageOptions[topic] = opts
+			}
+		}
+		if b.Backpressure != nil {
+			bp := *b.Backpressure
+			b.Backpressure = &bp
+		}
+		if b.Retry != nil {
+			r := *b.Retry
+			b.Retry = &r
+		}
+		if b.Outbox != nil {
+			ob := *b.Outbox
+			b.Outbox = &ob
+		}
+		if b.DeadLetter != nil {
+			dl := *b.DeadLetter
+			b.DeadLetter = &dl
+		}
+		if b.Webhook != nil {
+			wh := *b.Webhook
+			wh.URL = wh.RedactedURL()
+			b.Webhook = &wh
+		}
+		if b.FanOut != nil {
+			b.FanOut = slices.Clone(b.FanOut)
+			for i := range b.FanOut {
+				if wh := b.FanOut[i].Webhook; wh != nil {
+					redacted := *wh
+					redacted.URL = redacted.RedactedURL()
+					b.FanOut[i].Webhook = &redacted
+				}
+			}
+		}
+		if b.File != nil {
+			f := *b.File
+			b.File = &f
+		}
+		if b.Batch != nil {
+			bc := *b.Batch
+			b.Batch = &bc
+		}
+		if b.AsyncPublish != nil {
+			ap := *b.AsyncPublish
+			b.AsyncPublish = &ap
+		}
 		cp.Clients.Broker = &b
 	}
 
@@ -500,6 +3202,17 @@ func (c *SentinelConfig) RedactedCopy() *SentinelConfig {
 		cp.ResourceSelector = rs
 	}
 
+	if c.Watchers != nil {
+		ws := make([]WatcherConfig, len(c.Watchers))
+		for i, w := range c.Watchers {
+			if w.ResourceSelector != nil {
+				w.ResourceSelector = append(LabelSelectorList(nil), w.ResourceSelector...)
+			}
+			ws[i] = w
+		}
+		cp.Watchers = ws
+	}
+
 	if c.MessageData != nil {
 		md := make(map[string]interface{}, len(c.MessageData))
 		for k, v := range c.MessageData {
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -40,6 +43,7 @@ func makeFlags(t *testing.T, pairs map[string]string) *pflag.FlagSet {
 
 	// General
 	fs.Bool("debug-config", false, "")
+	fs.Bool("dry-run", false, "")
 	// Sentinel
 	fs.String("name", "", "")
 	// Log
@@ -138,10 +142,10 @@ func TestLoadConfig_EnvVarOverrides(t *testing.T) {
 		{
 			name:     "clients::hyperfleet_api::version",
 			envVar:   "HYPERFLEET_API_VERSION",
-			envValue: "v2",
+			envValue: "v1",
 			check: func(t *testing.T, cfg *SentinelConfig) {
-				if cfg.Clients.HyperFleetAPI.Version != "v2" {
-					t.Errorf("expected Version=%q, got %q", "v2", cfg.Clients.HyperFleetAPI.Version)
+				if cfg.Clients.HyperFleetAPI.Version != "v1" {
+					t.Errorf("expected Version=%q, got %q", "v1", cfg.Clients.HyperFleetAPI.Version)
 				}
 			},
 		},
@@ -250,6 +254,18 @@ func TestLoadConfig_CLIFlagOverrides(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:      "dry-run beats env",
+			envVar:    "HYPERFLEET_DRY_RUN",
+			envValue:  "false",
+			flagName:  "dry-run",
+			flagValue: "true",
+			check: func(t *testing.T, cfg *SentinelConfig) {
+				if !cfg.DryRun {
+					t.Error("expected DryRun=true (flag wins), got false")
+				}
+			},
+		},
 		{
 			name:      "log-level beats file",
 			flagName:  "log-level",
@@ -366,3 +382,80 @@ func TestLoadConfig_PriorityChain(t *testing.T) {
 		}
 	})
 }
+
+// FuzzLoadConfig feeds arbitrary YAML to LoadConfig. Loading must fail cleanly
+// or produce a config that passes Validate; it must never panic.
+func FuzzLoadConfig(f *testing.F) {
+	for _, name := range []string{"minimal.yaml", "full-workflow.yaml", "valid-complete.yaml", "max-age-overrides.yaml"} {
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			f.Fatalf("Failed to read seed %s: %v", name, err)
+		}
+		f.Add(data)
+	}
+	f.Add([]byte(baseConfig))
+	f.Add([]byte("resource_selector:\n  - label: \"a' or '1'='1\"\n    value: x\n"))
+	f.Add([]byte("message_data:\n  id: \"resource.id\\x00\"\n"))
+	f.Add([]byte("a: &a [*a, *a]\n"))
+
+	dir := f.TempDir()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		configPath := filepath.Join(dir, "config.yaml")
+		if err := os.WriteFile(configPath, data, 0o600); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		cfg, err := LoadConfig(configPath, nil)
+		if err != nil {
+			return
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("LoadConfig returned a config that fails Validate: %v", err)
+		}
+	})
+}
+
+// TestViperKeyMappings_CoverScalarFields checks that every scalar setting can
+// be overridden by an environment variable, so new settings are not added
+// without one.
+func TestViperKeyMappings_CoverScalarFields(t *testing.T) {
+	// CEL expressions are set alongside their params in the config file.
+	fileOnly := map[string]bool{"message_decision::result": true}
+
+	var paths []string
+	scalarPaths(reflect.TypeOf(SentinelConfig{}), "", &paths)
+	for _, path := range paths {
+		if _, ok := viperKeyMappings[path]; !ok && !fileOnly[path] {
+			t.Errorf("%s has no environment variable in viperKeyMappings", path)
+		}
+	}
+}
+
+// scalarPaths appends the viper paths of the scalar fields of typ, following
+// nested structs and skipping lists and maps.
+func scalarPaths(typ reflect.Type, prefix string, paths *[]string) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		path := tag
+		if prefix != "" {
+			path = prefix + "::" + tag
+		}
+		ft := field.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		switch ft.Kind() {
+		case reflect.Struct:
+			scalarPaths(ft, path, paths)
+		case reflect.Map, reflect.Slice:
+		default:
+			*paths = append(*paths, path)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Package decisionstream serves the Sentinel's decisions and publish results
+// on a local Unix socket, so that co-located tools such as debuggers and test
+// harnesses can follow them in real time without access to the broker.
+//
+// Every client connected to the socket receives each event as one line of
+// JSON. Clients only read; anything they write is ignored. Events are sent
+// from a bounded per-client buffer, so a slow client loses events rather than
+// slowing down the poll loop. Lost events are reported to the client with a
+// "dropped" event carrying their count.
+package decisionstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+// DefaultBufferSize is the number of events buffered per client when Listen
+// is given a buffer size <= 0.
+const DefaultBufferSize = 256
+
+// writeTimeout bounds writing one event to a client. A client that does not
+// read for this long is disconnected.
+const writeTimeout = 5 * time.Second
+
+// Event types.
+const (
+	// TypeDecision is sent for every evaluated resource.
+	TypeDecision = "decision"
+	// TypePublished is sent after an event was accepted by the broker.
+	TypePublished = "published"
+	// TypePublishFailed is sent when publishing an event failed.
+	TypePublishFailed = "publish_failed"
+	// TypeDropped is sent to a client that lost events because it read too
+	// slowly. Dropped holds the number of lost events.
+	TypeDropped = "dropped"
+)
+
+// Event is one line of the stream.
+type Event struct {
+	Time          time.Time `json:"time"`
+	Type          string    `json:"type"`
+	ResourceType  string    `json:"resource_type,omitempty"`
+	ResourceID    string    `json:"resource_id,omitempty"`
+	Kind          string    `json:"kind,omitempty"`
+	Region        string    `json:"region,omitempty"`
+	Reason        string    `json:"reason,omitempty"`
+	Rule          string    `json:"rule,omitempty"`
+	Condition     string    `json:"condition,omitempty"`
+	Window        string    `json:"window,omitempty"`
+	Topic         string    `json:"topic,omitempty"`
+	EventID       string    `json:"event_id,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	Dropped       uint64    `json:"dropped,omitempty"`
+	ShouldPublish bool      `json:"should_publish"`
+}
+
+// Server accepts clients on a Unix socket and broadcasts events to them.
+// Send is safe for concurrent use.
+type Server struct {
+	listener   net.Listener
+	log        logger.HyperFleetLogger
+	clients    map[*streamClient]struct{}
+	path       string
+	mu         sync.Mutex
+	bufferSize int
+	closed     bool
+}
+
+// streamClient is one connected reader.
+type streamClient struct {
+	conn    net.Conn
+	events  chan Event
+	done    chan struct{}
+	dropped atomic.Uint64
+	once    sync.Once
+}
+
+// Listen creates the Unix socket at path and returns a Server accepting
+// clients on it. A stale socket left at path by a previous process is
+// replaced; any other existing file is an error. The socket is only
+// accessible to the Sentinel's user.
+func Listen(path string, bufferSize int, log logger.HyperFleetLogger) (*Server, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode().Type() != fs.ModeSocket {
+			return nil, fmt.Errorf("decision stream: %s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("decision stream: failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("decision stream: failed to listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		_ = listener.Close() //nolint:errcheck // already returning an error
+		return nil, fmt.Errorf("decision stream: failed to restrict socket permissions: %w", err)
+	}
+
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Server{
+		listener:   listener,
+		log:        log,
+		clients:    make(map[*streamClient]struct{}),
+		path:       path,
+		bufferSize: bufferSize,
+	}, nil
+}
+
+// Path returns the socket path.
+func (s *Server) Path() string {
+	return s.path
+}
+
+// Serve accepts clients until ctx is cancelled or the server is closed, then
+// closes the server.
+func (s *Server) Serve(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		_ = s.Close() //nolint:errcheck // shutdown is best effort
+	}()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("decision stream: accept failed: %w", err)
+		}
+		if !s.add(conn) {
+			_ = conn.Close() //nolint:errcheck // server is closing
+		}
+	}
+}
+
+// Send broadcasts ev to all connected clients without blocking. Clients whose
+// buffer is full lose the event. Send on a nil Server does nothing.
+func (s *Server) Send(ev Event) {
+	if s == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		select {
+		case c.events <- ev:
+		default:
+			c.dropped.Add(1)
+		}
+	}
+}
+
+// Clients returns the number of connected clients.
+func (s *Server) Clients() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.clients)
+}
+
+// Close stops accepting clients, disconnects connected ones, and removes the
+// socket. It is safe to call more than once.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	clients := s.clients
+	s.clients = make(map[*streamClient]struct{})
+	s.mu.Unlock()
+
+	for c := range clients {
+		c.close()
+	}
+	// Closing a Unix listener also removes its socket file.
+	if err := s.listener.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		return fmt.Errorf("decision stream: failed to close listener: %w", err)
+	}
+	return nil
+}
+
+// add registers conn as a client and starts its writer. It returns false if
+// the server is closed.
+func (s *Server) add(conn net.Conn) bool {
+	c := &streamClient{
+		conn:   conn,
+		events: make(chan Event, s.bufferSize),
+		done:   make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return false
+	}
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+
+	s.log.Debugf(context.Background(), "Decision stream client connected clients=%d", s.Clients())
+	go s.write(c)
+	return true
+}
+
+// remove unregisters c and closes its connection.
+func (s *Server) remove(c *streamClient) {
+	s.mu.Lock()
+	delete(s.clients, c)
+	s.mu.Unlock()
+	c.close()
+}
+
+// write sends buffered events to c until it disconnects or the server closes.
+func (s *Server) write(c *streamClient) {
+	defer s.remove(c)
+
+	// The stream is read-only. Reading detects clients that hang up so that
+	// they do not linger until the next event.
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			if _, err := c.conn.Read(buf); err != nil {
+				c.close()
+				return
+			}
+		}
+	}()
+
+	enc := json.NewEncoder(c.conn)
+	for {
+		select {
+		case <-c.done:
+			return
+		case ev := <-c.events:
+			if n := c.dropped.Swap(0); n > 0 {
+				if err := c.encode(enc, Event{Time: time.Now().UTC(), Type: TypeDropped, Dropped: n}); err != nil {
+					return
+				}
+			}
+			if err := c.encode(enc, ev); err != nil {
+				s.log.Debugf(context.Background(), "Decision stream client disconnected error=%v", err)
+				return
+			}
+		}
+	}
+}
+
+func (c *streamClient) encode(enc *json.Encoder, ev Event) error {
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	return enc.Encode(ev)
+}
+
+func (c *streamClient) close() {
+	c.once.Do(func() {
+		close(c.done)
+		_ = c.conn.Close() //nolint:errcheck // connection is being discarded
+	})
+}
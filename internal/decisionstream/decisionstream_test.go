@@ -0,0 +1,198 @@
+package decisionstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+// socketPath returns a path short enough for a Unix socket; t.TempDir paths
+// can exceed the sun_path limit.
+func socketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "ds")
+	if err != nil {
+		t.Fatalf("MkdirTemp failed: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "s.sock")
+}
+
+func startServer(t *testing.T, bufferSize int) *Server {
+	t.Helper()
+	s, err := Listen(socketPath(t), bufferSize, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := s.Serve(ctx); err != nil {
+			t.Errorf("Serve failed: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return s
+}
+
+// connect dials s and waits until the server has registered the client.
+func connect(t *testing.T, s *Server) (net.Conn, *bufio.Scanner) {
+	t.Helper()
+	want := s.Clients() + 1
+	conn, err := net.Dial("unix", s.Path())
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	deadline := time.Now().Add(5 * time.Second)
+	for s.Clients() < want {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for client to be registered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return conn, bufio.NewScanner(conn)
+}
+
+func readEvent(t *testing.T, conn net.Conn, scanner *bufio.Scanner) Event {
+	t.Helper()
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("SetReadDeadline failed: %v", err)
+	}
+	if !scanner.Scan() {
+		t.Fatalf("expected an event, got error: %v", scanner.Err())
+	}
+	var ev Event
+	if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+		t.Fatalf("invalid event %q: %v", scanner.Text(), err)
+	}
+	return ev
+}
+
+func TestServer_BroadcastsToAllClients(t *testing.T) {
+	s := startServer(t, 0)
+	conn1, scanner1 := connect(t, s)
+	conn2, scanner2 := connect(t, s)
+
+	s.Send(Event{Type: TypeDecision, ResourceID: "cluster-1", Reason: "never processed", ShouldPublish: true})
+
+	for _, c := range []struct {
+		conn    net.Conn
+		scanner *bufio.Scanner
+	}{{conn1, scanner1}, {conn2, scanner2}} {
+		ev := readEvent(t, c.conn, c.scanner)
+		if ev.Type != TypeDecision || ev.ResourceID != "cluster-1" || !ev.ShouldPublish {
+			t.Errorf("unexpected event: %+v", ev)
+		}
+		if ev.Time.IsZero() {
+			t.Error("expected Send to set the event time")
+		}
+	}
+}
+
+func TestServer_ReportsDroppedEvents(t *testing.T) {
+	s, err := Listen(socketPath(t), 1, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	// Register a client whose writer is not running yet, so that its
+	// one-event buffer overflows.
+	serverConn, clientConn := net.Pipe()
+	t.Cleanup(func() { _ = clientConn.Close() })
+	c := &streamClient{conn: serverConn, events: make(chan Event, 1), done: make(chan struct{})}
+	s.mu.Lock()
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+
+	s.Send(Event{Type: TypeDecision, ResourceID: "cluster-1"})
+	s.Send(Event{Type: TypeDecision, ResourceID: "cluster-2"})
+	s.Send(Event{Type: TypeDecision, ResourceID: "cluster-3"})
+	go s.write(c)
+
+	scanner := bufio.NewScanner(clientConn)
+	dropped := readEvent(t, clientConn, scanner)
+	if dropped.Type != TypeDropped || dropped.Dropped != 2 {
+		t.Errorf("expected dropped event with count 2, got %+v", dropped)
+	}
+	ev := readEvent(t, clientConn, scanner)
+	if ev.ResourceID != "cluster-1" {
+		t.Errorf("expected the buffered event for cluster-1, got %+v", ev)
+	}
+}
+
+func TestServer_SendOnNilServer(t *testing.T) {
+	var s *Server
+	s.Send(Event{Type: TypeDecision})
+}
+
+func TestServer_RemovesDisconnectedClients(t *testing.T) {
+	s := startServer(t, 0)
+	conn, _ := connect(t, s)
+	_ = conn.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for s.Clients() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for client to be removed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestListen_ReplacesStaleSocket(t *testing.T) {
+	path := socketPath(t)
+	stale, err := Listen(path, 0, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	// Simulate a crashed process: the socket file stays behind.
+	stale.listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := stale.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	s, err := Listen(path, 0, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("Listen over stale socket failed: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket permissions = %o, want 600", perm)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected socket to be removed on Close, got %v", err)
+	}
+}
+
+func TestListen_RejectsRegularFile(t *testing.T) {
+	path := socketPath(t)
+	if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	_, err := Listen(path, 0, logger.NewHyperFleetLogger())
+	if err == nil || !strings.Contains(err.Error(), "is not a socket") {
+		t.Fatalf("expected not-a-socket error, got %v", err)
+	}
+}
@@ -1,7 +1,10 @@
 package engine
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,26 +14,61 @@ import (
 	"github.com/google/cel-go/ext"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
 )
 
 // Decision represents the result of evaluating a resource
 type Decision struct {
-	Reason        string // Human-readable explanation for the decision
-	ShouldPublish bool   // Indicates whether an event should be published for the resource
+	Err           error          // Why the evaluation failed, for reasons that report Failed
+	Reason        reasons.Reason // Why the resource is published or skipped
+	Rule          string         // Name of the message_decision rule that published the resource, if rules are used
+	Condition     string         // Condition type that decided: a condition_actions binding, terminal or stuck phase
+	Window        string         // Name of the maintenance window that skipped the resource, if any
+	ClockSkew     time.Duration  // Largest skew of a condition's last updated time beyond the clock_skew tolerance
+	ShouldPublish bool           // Indicates whether an event should be published for the resource
 }
 
+// Decider decides whether an event should be published for a resource at
+// now. DecisionEngine, driven by message_decision, is the default Decider;
+// the Sentinel accepts any other to replace the triggering policy.
+//
+// Reasons should be taken from pkg/reasons, since they label metrics. With
+// evaluation_cache enabled, decisions not to publish are reused while the
+// resource is unchanged, so a Decider should depend only on the resource and
+// now. Evaluate may be called concurrently: the admin server explains
+// decisions while the poll loop runs. ctx is that of the poll cycle or of the
+// explain request; a Decider that queries a remote service should stop when
+// it is done.
+type Decider interface {
+	Evaluate(ctx context.Context, resource *client.Resource, now time.Time) Decision
+}
+
+var _ Decider = (*DecisionEngine)(nil)
+
 type paramEntry struct {
 	prog cel.Program
 	name string
 }
 
 // DecisionEngine evaluates whether a resource needs an event published
-// using configurable CEL expressions.
+// using configurable CEL expressions. Evaluations are serialized, since the
+// CEL function bindings read per-evaluation lookups.
 type DecisionEngine struct {
-	resultProg       cel.Program
-	conditionsLookup map[string]map[string]interface{}
-	params           []paramEntry
-	mu               sync.Mutex
+	resultProg         cel.Program
+	trace              *explainTrace
+	conditionsLookup   map[string]map[string]interface{}
+	labelsLookup       map[string]string
+	maxAgeOverrides    *config.MaxAgeOverridesConfig
+	stuckDeletion      *config.StuckDeletionConfig
+	clockSkew          *config.ClockSkewConfig
+	windows            *maintenanceWindows
+	maintenanceLabel   string
+	params             []paramEntry
+	rules              []paramEntry
+	conditionActions   []config.ConditionAction
+	terminalConditions []string
+	mu                 sync.Mutex
+	evalMu             sync.Mutex
 }
 
 // NewDecisionEngine creates a new CEL-based decision engine from a MessageDecisionConfig.
@@ -44,11 +82,25 @@ func NewDecisionEngine(cfg *config.MessageDecisionConfig) (*DecisionEngine, erro
 		return nil, fmt.Errorf("invalid message_decision config: %w", err)
 	}
 
-	de := &DecisionEngine{}
+	de := &DecisionEngine{
+		maintenanceLabel:   cfg.MaintenanceLabel,
+		conditionActions:   cfg.ConditionActions,
+		maxAgeOverrides:    cfg.MaxAgeOverrides,
+		terminalConditions: cfg.TerminalConditions,
+		stuckDeletion:      cfg.StuckDeletion,
+		clockSkew:          cfg.ClockSkew,
+	}
 
-	// Build CEL environment with all variables and the condition() function.
-	// The function declaration includes the implementation via FunctionBinding,
-	// which reads from the engine's conditionsLookup (updated per-evaluation).
+	windows, err := newMaintenanceWindows(cfg.MaintenanceWindows)
+	if err != nil {
+		return nil, err
+	}
+	de.windows = windows
+
+	// Build CEL environment with all variables and the condition() and
+	// max_age() functions. The function declarations include the implementation
+	// via FunctionBinding, which reads from the engine's conditionsLookup and
+	// labelsLookup (updated per-evaluation).
 	envOpts := []cel.EnvOption{
 		ext.Strings(),
 		cel.Variable("resource", cel.DynType),
@@ -72,6 +124,27 @@ func NewDecisionEngine(cfg *config.MessageDecisionConfig) (*DecisionEngine, erro
 				}),
 			),
 		),
+		cel.Function("max_age",
+			cel.Overload("max_age_string_duration_to_duration",
+				[]*cel.Type{cel.StringType, cel.DurationType},
+				cel.DurationType,
+				cel.BinaryBinding(func(nameVal, defaultVal ref.Val) ref.Val {
+					name, ok := nameVal.Value().(string)
+					if !ok {
+						return defaultVal
+					}
+					de.mu.Lock()
+					labels := de.labelsLookup
+					de.mu.Unlock()
+					d, overridden := de.maxAge(labels, name)
+					de.trace.maxAge(name, defaultVal, d, overridden)
+					if overridden {
+						return types.Duration{Duration: d}
+					}
+					return defaultVal
+				}),
+			),
+		),
 	}
 
 	// Declare all param names as DynType variables for inter-param references
@@ -98,7 +171,25 @@ func NewDecisionEngine(cfg *config.MessageDecisionConfig) (*DecisionEngine, erro
 		params = append(params, paramEntry{name: p.Name, prog: prg})
 	}
 
-	// Compile result expression
+	de.params = params
+
+	// Compile rules in authored order, or the result expression
+	if len(cfg.Rules) > 0 {
+		de.rules = make([]paramEntry, 0, len(cfg.Rules))
+		for _, r := range cfg.Rules {
+			ast, issues := env.Compile(r.Expr)
+			if issues != nil && issues.Err() != nil {
+				return nil, fmt.Errorf("failed to compile rule %q expression %q: %w", r.Name, r.Expr, issues.Err())
+			}
+			prg, prgErr := env.Program(ast)
+			if prgErr != nil {
+				return nil, fmt.Errorf("failed to create program for rule %q: %w", r.Name, prgErr)
+			}
+			de.rules = append(de.rules, paramEntry{name: r.Name, prog: prg})
+		}
+		return de, nil
+	}
+
 	resultAST, issues := env.Compile(cfg.Result)
 	if issues != nil && issues.Err() != nil {
 		return nil, fmt.Errorf("failed to compile result expression %q: %w", cfg.Result, issues.Err())
@@ -107,29 +198,175 @@ func NewDecisionEngine(cfg *config.MessageDecisionConfig) (*DecisionEngine, erro
 	if err != nil {
 		return nil, fmt.Errorf("failed to create result program: %w", err)
 	}
-
-	de.params = params
 	de.resultProg = resultPrg
 
 	return de, nil
 }
 
+// maxAge returns the max age override for name from the resource labels,
+// clamped to the configured bounds. It reports false when overrides are
+// disabled or the label is missing or not a positive duration, so the caller
+// keeps the expression's default.
+func (e *DecisionEngine) maxAge(labels map[string]string, name string) (time.Duration, bool) {
+	if e.maxAgeOverrides == nil {
+		return 0, false
+	}
+	value, ok := labels[config.MaxAgeLabelPrefix+name]
+	if !ok {
+		return 0, false
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return e.maxAgeOverrides.Clamp(d), true
+}
+
+// terminalCondition returns the first terminal condition that is True on the
+// resource.
+func (e *DecisionEngine) terminalCondition(resource *client.Resource) (string, bool) {
+	for _, terminal := range e.terminalConditions {
+		for _, c := range resource.Status.Conditions {
+			if c.Type == terminal && c.Status == "True" {
+				return terminal, true
+			}
+		}
+	}
+	return "", false
+}
+
+// stuckPhase returns the deletion phase whose condition has been True on
+// the resource for at least the stuck_deletion timeout at now. Conditions
+// without a last transition time are never stuck.
+func (e *DecisionEngine) stuckPhase(resource *client.Resource, now time.Time) (string, bool) {
+	if e.stuckDeletion == nil {
+		return "", false
+	}
+	for _, phase := range e.stuckDeletion.PhaseTypes() {
+		for _, c := range resource.Status.Conditions {
+			if c.Type != phase || c.Status != "True" || c.LastTransitionTime.IsZero() {
+				continue
+			}
+			if now.Sub(c.LastTransitionTime) >= e.stuckDeletion.Timeout {
+				return phase, true
+			}
+		}
+	}
+	return "", false
+}
+
+// IsPaused reports whether the resource carries the well-known pause label.
+// Any value other than "true" (case-insensitive) leaves it active. The
+// Sentinel checks it before running any Decider, so the label also applies
+// with a decision_policy.
+func IsPaused(resource *client.Resource) bool {
+	return strings.EqualFold(resource.Labels[config.PausedLabel], "true")
+}
+
+// inMaintenance reports whether the resource is paused via the maintenance
+// label. Any value other than "true" (case-insensitive) leaves it active.
+func (e *DecisionEngine) inMaintenance(resource *client.Resource) bool {
+	if e.maintenanceLabel == "" {
+		return false
+	}
+	return strings.EqualFold(resource.Labels[e.maintenanceLabel], "true")
+}
+
+// conditionAction returns the decision of the first condition_actions binding
+// that matches a status condition of the resource.
+func (e *DecisionEngine) conditionAction(resource *client.Resource) (Decision, bool) {
+	for _, a := range e.conditionActions {
+		for _, c := range resource.Status.Conditions {
+			if c.Type != a.Type || c.Status != a.MatchStatus() {
+				continue
+			}
+			if a.Action == config.ConditionActionPublish {
+				return Decision{ShouldPublish: true, Reason: reasons.ConditionPublish, Condition: a.Type}, true
+			}
+			return Decision{ShouldPublish: false, Reason: reasons.ConditionSkip, Condition: a.Type}, true
+		}
+	}
+	return Decision{}, false
+}
+
 // Evaluate determines if an event should be published for the resource.
-// Returns a Decision indicating whether to publish and why.
-func (e *DecisionEngine) Evaluate(resource *client.Resource, now time.Time) Decision {
+// Returns a Decision indicating whether to publish and why. The expressions
+// run in process, so ctx is not used.
+func (e *DecisionEngine) Evaluate(_ context.Context, resource *client.Resource, now time.Time) Decision {
+	e.evalMu.Lock()
+	defer e.evalMu.Unlock()
+	return e.evaluate(resource, now)
+}
+
+// evaluate implements Evaluate; the caller holds evalMu.
+func (e *DecisionEngine) evaluate(resource *client.Resource, now time.Time) Decision {
 	if resource == nil {
-		return Decision{ShouldPublish: false, Reason: "resource is nil"}
+		return Decision{ShouldPublish: false, Reason: reasons.NilResource}
 	}
 	if now.IsZero() {
-		return Decision{ShouldPublish: false, Reason: "now time is zero"}
+		return Decision{ShouldPublish: false, Reason: reasons.ZeroTime}
+	}
+	if condition, ok := e.terminalCondition(resource); ok {
+		return Decision{ShouldPublish: false, Reason: reasons.Terminal, Condition: condition}
+	}
+	if e.inMaintenance(resource) {
+		return Decision{ShouldPublish: false, Reason: reasons.Maintenance}
+	}
+	if window, ok := e.windows.match(resource, now); ok {
+		return Decision{ShouldPublish: false, Reason: reasons.MaintenanceWindow, Window: window}
+	}
+	if phase, ok := e.stuckPhase(resource, now); ok {
+		return Decision{ShouldPublish: true, Reason: reasons.StuckDeletion, Condition: phase}
+	}
+	if decision, ok := e.conditionAction(resource); ok {
+		return decision
+	}
+
+	resource, skew := e.deskew(resource, now)
+	decision := e.evaluateExpressions(resource, now)
+	decision.ClockSkew = skew
+	return decision
+}
+
+// deskew returns how far the latest condition last updated time of the
+// resource is ahead of now, when beyond the clock_skew tolerance. With
+// fallback_to_created_time, it returns a copy of the resource in which such
+// times are replaced by the created time.
+func (e *DecisionEngine) deskew(resource *client.Resource, now time.Time) (*client.Resource, time.Duration) {
+	if e.clockSkew == nil {
+		return resource, 0
+	}
+	deskewed := resource
+	var skew time.Duration
+	for i, c := range resource.Status.Conditions {
+		ahead := c.LastUpdatedTime.Sub(now)
+		if ahead <= e.clockSkew.Tolerance {
+			continue
+		}
+		skew = max(skew, ahead)
+		if !e.clockSkew.FallbackToCreatedTime {
+			continue
+		}
+		if deskewed == resource {
+			copied := *resource
+			copied.Status.Conditions = slices.Clone(resource.Status.Conditions)
+			deskewed = &copied
+		}
+		deskewed.Status.Conditions[i].LastUpdatedTime = resource.CreatedTime
 	}
+	return deskewed, skew
+}
 
+// evaluateExpressions evaluates the message_decision params and result or
+// rules for the resource.
+func (e *DecisionEngine) evaluateExpressions(resource *client.Resource, now time.Time) Decision {
 	// Build resource map for CEL evaluation
 	resourceMap := resource.ToMap()
 
-	// Update the conditions lookup for the condition() function binding
+	// Update the lookups for the condition() and max_age() function bindings
 	e.mu.Lock()
 	e.conditionsLookup = buildConditionsLookup(resource.Status.Conditions)
+	e.labelsLookup = resource.Labels
 	e.mu.Unlock()
 
 	// Build base activation with resource and now
@@ -154,10 +391,12 @@ func (e *DecisionEngine) Evaluate(resource *client.Resource, now time.Time) Deci
 		if err != nil {
 			return Decision{
 				ShouldPublish: false,
-				Reason:        fmt.Sprintf("param %q evaluation failed: %v", p.name, err),
+				Reason:        reasons.ParamEvaluationFailed,
+				Err:           fmt.Errorf("param %q: %w", p.name, err),
 			}
 		}
 		paramValues[p.name] = out.Value()
+		e.trace.param(p.name, out)
 	}
 
 	// Evaluate result expression
@@ -169,11 +408,17 @@ func (e *DecisionEngine) Evaluate(resource *client.Resource, now time.Time) Deci
 		resultActivation[k] = v
 	}
 
+	if len(e.rules) > 0 {
+		return e.evaluateRules(resultActivation)
+	}
+
 	out, _, err := e.resultProg.Eval(resultActivation)
+	e.trace.resultValue(out)
 	if err != nil {
 		return Decision{
 			ShouldPublish: false,
-			Reason:        fmt.Sprintf("result evaluation failed: %v", err),
+			Reason:        reasons.ResultEvaluationFailed,
+			Err:           err,
 		}
 	}
 
@@ -181,21 +426,50 @@ func (e *DecisionEngine) Evaluate(resource *client.Resource, now time.Time) Deci
 	if !ok {
 		return Decision{
 			ShouldPublish: false,
-			Reason:        fmt.Sprintf("result expression did not return bool, got %T", out.Value()),
+			Reason:        reasons.ResultNotBool,
+			Err:           fmt.Errorf("got %T", out.Value()),
 		}
 	}
 
 	if shouldPublish {
 		return Decision{
 			ShouldPublish: true,
-			Reason:        "message decision matched",
+			Reason:        reasons.Matched,
 		}
 	}
 
 	return Decision{
 		ShouldPublish: false,
-		Reason:        "message decision result is false",
+		Reason:        reasons.NotMatched,
+	}
+}
+
+// evaluateRules publishes on the first rule that evaluates to true. A rule
+// that fails to evaluate stops evaluation, like a failing result expression.
+func (e *DecisionEngine) evaluateRules(activation map[string]interface{}) Decision {
+	for _, r := range e.rules {
+		out, _, err := r.prog.Eval(activation)
+		e.trace.rule(r.name, out)
+		if err != nil {
+			return Decision{
+				ShouldPublish: false,
+				Reason:        reasons.ResultEvaluationFailed,
+				Err:           fmt.Errorf("rule %q: %w", r.name, err),
+			}
+		}
+		matched, ok := out.Value().(bool)
+		if !ok {
+			return Decision{
+				ShouldPublish: false,
+				Reason:        reasons.ResultNotBool,
+				Err:           fmt.Errorf("rule %q: got %T", r.name, out.Value()),
+			}
+		}
+		if matched {
+			return Decision{ShouldPublish: true, Reason: reasons.Matched, Rule: r.name}
+		}
 	}
+	return Decision{ShouldPublish: false, Reason: reasons.NotMatched}
 }
 
 // buildConditionsLookup creates a map from condition type name to condition data
@@ -1,11 +1,14 @@
 package engine
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
 )
 
 // Test helpers
@@ -151,7 +154,7 @@ func TestDecisionEngine_Evaluate(t *testing.T) {
 		resource          *client.Resource
 		now               time.Time
 		name              string
-		wantReason        string
+		wantReason        reasons.Reason
 		wantShouldPublish bool
 	}{
 		{
@@ -159,104 +162,104 @@ func TestDecisionEngine_Evaluate(t *testing.T) {
 			resource:          newResourceWithCondition("True", now.Add(-31*time.Minute), 2),
 			now:               now,
 			wantShouldPublish: true,
-			wantReason:        "message decision matched",
+			wantReason:        reasons.Matched,
 		},
 		{
 			name:              "reconciled and recent - should not publish",
 			resource:          newResourceWithCondition("True", now.Add(-5*time.Minute), 2),
 			now:               now,
 			wantShouldPublish: false,
-			wantReason:        "message decision result is false",
+			wantReason:        reasons.NotMatched,
 		},
 		{
 			name:              "not reconciled and debounced - should publish",
 			resource:          newResourceWithCondition("False", now.Add(-11*time.Second), 2),
 			now:               now,
 			wantShouldPublish: true,
-			wantReason:        "message decision matched",
+			wantReason:        reasons.Matched,
 		},
 		{
 			name:              "not reconciled and too recent - should not publish",
 			resource:          newResourceWithCondition("False", now.Add(-3*time.Second), 2),
 			now:               now,
 			wantShouldPublish: false,
-			wantReason:        "message decision result is false",
+			wantReason:        reasons.NotMatched,
 		},
 		{
 			name:              "gen 1 with condition (adapter seen) - debounce applies, too recent",
 			resource:          newResourceWithCondition("False", now, 1),
 			now:               now,
 			wantShouldPublish: false,
-			wantReason:        "message decision result is false",
+			wantReason:        reasons.NotMatched,
 		},
 		{
 			name:              "gen 1 no conditions (truly new) - should publish immediately",
 			resource:          newResourceNoConditions(1),
 			now:               now,
 			wantShouldPublish: true,
-			wantReason:        "message decision matched",
+			wantReason:        reasons.Matched,
 		},
 		{
 			name:              "gen 1 with condition debounce exceeded - should publish via not_reconciled_and_debounced",
 			resource:          newResourceWithCondition("False", now.Add(-11*time.Second), 1),
 			now:               now,
 			wantShouldPublish: true,
-			wantReason:        "message decision matched",
+			wantReason:        reasons.Matched,
 		},
 		{
 			name:              "gen 1 reconciled recent - should not publish",
 			resource:          newResourceWithCondition("True", now.Add(-5*time.Minute), 1),
 			now:               now,
 			wantShouldPublish: false,
-			wantReason:        "message decision result is false",
+			wantReason:        reasons.NotMatched,
 		},
 		{
 			name:              "gen 1 reconciled stale - should publish via reconciled_and_stale",
 			resource:          newResourceWithCondition("True", now.Add(-31*time.Minute), 1),
 			now:               now,
 			wantShouldPublish: true,
-			wantReason:        "message decision matched",
+			wantReason:        reasons.Matched,
 		},
 		{
 			name:              "generation mismatch (reconciled, recent) - should publish immediately",
 			resource:          newResourceWithGenerationMismatch("True", now.Add(-1*time.Minute), 3, 2),
 			now:               now,
 			wantShouldPublish: true,
-			wantReason:        "message decision matched",
+			wantReason:        reasons.Matched,
 		},
 		{
 			name:              "generation mismatch (not reconciled, recent) - should publish immediately",
 			resource:          newResourceWithGenerationMismatch("False", now.Add(-1*time.Second), 5, 4),
 			now:               now,
 			wantShouldPublish: true,
-			wantReason:        "message decision matched",
+			wantReason:        reasons.Matched,
 		},
 		{
 			name:              "no generation mismatch (reconciled, recent) - should not publish",
 			resource:          newResourceWithGenerationMismatch("True", now.Add(-1*time.Minute), 2, 2),
 			now:               now,
 			wantShouldPublish: false,
-			wantReason:        "message decision result is false",
+			wantReason:        reasons.NotMatched,
 		},
 		{
 			name:              "nil resource - should not publish",
 			resource:          nil,
 			now:               now,
 			wantShouldPublish: false,
-			wantReason:        "resource is nil",
+			wantReason:        reasons.NilResource,
 		},
 		{
 			name:              "zero now time - should not publish",
 			resource:          newResourceWithCondition("True", now, 2),
 			now:               time.Time{},
 			wantShouldPublish: false,
-			wantReason:        "now time is zero",
+			wantReason:        reasons.ZeroTime,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			decision := engine.Evaluate(tt.resource, tt.now)
+			decision := engine.Evaluate(context.Background(), tt.resource, tt.now)
 
 			if decision.ShouldPublish != tt.wantShouldPublish {
 				t.Errorf("ShouldPublish = %v, want %v", decision.ShouldPublish, tt.wantShouldPublish)
@@ -277,7 +280,7 @@ func TestDecisionEngine_Evaluate_MissingCondition(t *testing.T) {
 	// is_reconciled = false, is_new_resource depends on generation.
 	t.Run("no conditions generation 1 - new resource publishes", func(t *testing.T) {
 		resource := newResourceNoConditions(1)
-		decision := engine.Evaluate(resource, now)
+		decision := engine.Evaluate(context.Background(), resource, now)
 
 		if !decision.ShouldPublish {
 			t.Errorf("expected ShouldPublish=true for new resource with no conditions, got false")
@@ -286,7 +289,7 @@ func TestDecisionEngine_Evaluate_MissingCondition(t *testing.T) {
 
 	t.Run("no conditions generation 2 - generation mismatch publishes", func(t *testing.T) {
 		resource := newResourceNoConditions(2)
-		decision := engine.Evaluate(resource, now)
+		decision := engine.Evaluate(context.Background(), resource, now)
 
 		// No conditions → observed_generation=0, generation=2 → generation_mismatch triggers
 		if !decision.ShouldPublish {
@@ -295,6 +298,428 @@ func TestDecisionEngine_Evaluate_MissingCondition(t *testing.T) {
 	})
 }
 
+func TestDecisionEngine_Evaluate_Maintenance(t *testing.T) {
+	now := time.Now()
+	cfg := newDefaultDecisionConfig()
+	cfg.MaintenanceLabel = "hyperfleet.io/maintenance"
+	engine, err := NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+
+	tests := []struct {
+		labels        map[string]string
+		name          string
+		wantReason    reasons.Reason
+		wantPublished bool
+	}{
+		{name: "no label", wantPublished: true},
+		{name: "label true", labels: map[string]string{"hyperfleet.io/maintenance": "true"}, wantReason: reasons.Maintenance},
+		{name: "label True", labels: map[string]string{"hyperfleet.io/maintenance": "True"}, wantReason: reasons.Maintenance},
+		{name: "label false", labels: map[string]string{"hyperfleet.io/maintenance": "false"}, wantPublished: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A new resource would otherwise always publish.
+			resource := newResourceNoConditions(1)
+			resource.Labels = tt.labels
+
+			decision := engine.Evaluate(context.Background(), resource, now)
+			if decision.ShouldPublish != tt.wantPublished {
+				t.Errorf("ShouldPublish = %v, want %v (reason %q)", decision.ShouldPublish, tt.wantPublished, decision.Reason)
+			}
+			if tt.wantReason != "" && decision.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", decision.Reason, tt.wantReason)
+			}
+		})
+	}
+
+	// Without a configured label the same resource publishes.
+	resource := newResourceNoConditions(1)
+	resource.Labels = map[string]string{"hyperfleet.io/maintenance": "true"}
+	if decision := newTestDecisionEngine(t).Evaluate(context.Background(), resource, now); !decision.ShouldPublish {
+		t.Errorf("expected publish when maintenance_label is not configured, got reason %q", decision.Reason)
+	}
+}
+
+func TestIsPaused(t *testing.T) {
+	tests := []struct {
+		labels map[string]string
+		name   string
+		want   bool
+	}{
+		{name: "no label"},
+		{name: "label true", labels: map[string]string{config.PausedLabel: "true"}, want: true},
+		{name: "label TRUE", labels: map[string]string{config.PausedLabel: "TRUE"}, want: true},
+		{name: "label false", labels: map[string]string{config.PausedLabel: "false"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := newResourceNoConditions(1)
+			resource.Labels = tt.labels
+			if got := IsPaused(resource); got != tt.want {
+				t.Errorf("IsPaused() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecisionEngine_Evaluate_TerminalConditions(t *testing.T) {
+	now := time.Now()
+	cfg := newDefaultDecisionConfig()
+	cfg.TerminalConditions = []string{"Deleted", "Deprovisioned"}
+	cfg.ConditionActions = []config.ConditionAction{
+		{Type: "Degraded", Action: config.ConditionActionPublish},
+	}
+	engine, err := NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+
+	tests := []struct {
+		labels        map[string]string
+		name          string
+		wantCondition string
+		conditions    []client.Condition
+		wantPublished bool
+	}{
+		{name: "no terminal condition", wantPublished: true},
+		{
+			name:          "condition not True",
+			conditions:    []client.Condition{{Type: "Deleted", Status: "False"}},
+			wantPublished: true,
+		},
+		{name: "deleted", conditions: []client.Condition{{Type: "Deleted", Status: "True"}}, wantCondition: "Deleted"},
+		{
+			name:          "deprovisioned",
+			conditions:    []client.Condition{{Type: "Deprovisioned", Status: "True"}},
+			wantCondition: "Deprovisioned",
+		},
+		{
+			name: "before condition actions",
+			conditions: []client.Condition{
+				{Type: "Degraded", Status: "True"},
+				{Type: "Deleted", Status: "True"},
+			},
+			wantCondition: "Deleted",
+		},
+		{
+			name:          "before the paused label",
+			labels:        map[string]string{config.PausedLabel: "true"},
+			conditions:    []client.Condition{{Type: "Deleted", Status: "True"}},
+			wantCondition: "Deleted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A new resource would otherwise always publish.
+			resource := newResourceNoConditions(1)
+			resource.Labels = tt.labels
+			resource.Status.Conditions = tt.conditions
+
+			decision := engine.Evaluate(context.Background(), resource, now)
+			if decision.ShouldPublish != tt.wantPublished {
+				t.Errorf("ShouldPublish = %v, want %v (reason %q)", decision.ShouldPublish, tt.wantPublished, decision.Reason)
+			}
+			if tt.wantCondition == "" {
+				return
+			}
+			if decision.Reason != reasons.Terminal || decision.Condition != tt.wantCondition {
+				t.Errorf("decision = %+v, want a %q skip by condition %q", decision, reasons.Terminal, tt.wantCondition)
+			}
+		})
+	}
+}
+
+func TestDecisionEngine_Evaluate_StuckDeletion(t *testing.T) {
+	now := time.Now()
+	cfg := newDefaultDecisionConfig()
+	cfg.StuckDeletion = &config.StuckDeletionConfig{Timeout: time.Hour}
+	cfg.ConditionActions = []config.ConditionAction{
+		{Type: "Deleting", Action: config.ConditionActionSkip},
+	}
+	engine, err := NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+
+	deleting := func(phase, status string, since time.Duration) client.Condition {
+		return client.Condition{Type: phase, Status: status, LastTransitionTime: now.Add(-since)}
+	}
+	tests := []struct {
+		name       string
+		wantReason reasons.Reason
+		wantPhase  string
+		condition  client.Condition
+	}{
+		{
+			name:       "deleting within the timeout",
+			condition:  deleting("Deleting", "True", 59*time.Minute),
+			wantReason: reasons.ConditionSkip,
+			wantPhase:  "Deleting",
+		},
+		{
+			name:       "deleting for the timeout",
+			condition:  deleting("Deleting", "True", time.Hour),
+			wantReason: reasons.StuckDeletion,
+			wantPhase:  "Deleting",
+		},
+		{
+			name:       "deprovisioning for longer",
+			condition:  deleting("Deprovisioning", "True", 2*time.Hour),
+			wantReason: reasons.StuckDeletion,
+			wantPhase:  "Deprovisioning",
+		},
+		{name: "phase not reached", condition: deleting("Deleting", "False", 2*time.Hour), wantReason: reasons.NotMatched},
+		{
+			name:       "no transition time",
+			condition:  client.Condition{Type: "Deleting", Status: "True"},
+			wantReason: reasons.ConditionSkip,
+			wantPhase:  "Deleting",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Recently reconciled, so the result expression alone would skip.
+			resource := newResourceWithCondition("True", now.Add(-time.Minute), 2)
+			resource.Status.Conditions = append(resource.Status.Conditions, tt.condition)
+
+			decision := engine.Evaluate(context.Background(), resource, now)
+			if decision.Reason != tt.wantReason || decision.Condition != tt.wantPhase {
+				t.Errorf("decision = %+v, want reason %q and condition %q", decision, tt.wantReason, tt.wantPhase)
+			}
+			if decision.ShouldPublish != (tt.wantReason == reasons.StuckDeletion) {
+				t.Errorf("ShouldPublish = %v for reason %q", decision.ShouldPublish, decision.Reason)
+			}
+		})
+	}
+
+	// Configured phases replace the defaults.
+	cfg.StuckDeletion.Phases = []string{"Draining"}
+	engine, err = NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+	resource := newResourceWithCondition("True", now.Add(-time.Minute), 2)
+	resource.Status.Conditions = append(resource.Status.Conditions,
+		deleting("Draining", "True", 2*time.Hour), deleting("Deleting", "True", 2*time.Hour))
+	if decision := engine.Evaluate(context.Background(), resource, now); decision.Condition != "Draining" {
+		t.Errorf("decision = %+v, want a %q stuck in Draining", decision, reasons.StuckDeletion)
+	}
+}
+
+func TestDecisionEngine_Evaluate_ClockSkew(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		clockSkew   *config.ClockSkewConfig
+		name        string
+		ahead       time.Duration
+		wantSkew    time.Duration
+		wantPublish bool
+	}{
+		{name: "no clock_skew", ahead: time.Hour},
+		{name: "within the tolerance", clockSkew: &config.ClockSkewConfig{Tolerance: time.Minute}, ahead: 30 * time.Second},
+		{
+			name:      "beyond the tolerance",
+			clockSkew: &config.ClockSkewConfig{Tolerance: time.Minute},
+			ahead:     time.Hour,
+			wantSkew:  time.Hour,
+		},
+		{
+			name:        "fallback to created time",
+			clockSkew:   &config.ClockSkewConfig{Tolerance: time.Minute, FallbackToCreatedTime: true},
+			ahead:       time.Hour,
+			wantSkew:    time.Hour,
+			wantPublish: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newDefaultDecisionConfig()
+			cfg.ClockSkew = tt.clockSkew
+			engine, err := NewDecisionEngine(cfg)
+			if err != nil {
+				t.Fatalf("NewDecisionEngine failed: %v", err)
+			}
+			// Created an hour ago, so stale when its created time is the reference.
+			lastUpdated := now.Add(tt.ahead)
+			resource := newResourceWithCondition("True", lastUpdated, 2)
+
+			decision := engine.Evaluate(context.Background(), resource, now)
+			if decision.ClockSkew != tt.wantSkew {
+				t.Errorf("ClockSkew = %s, want %s", decision.ClockSkew, tt.wantSkew)
+			}
+			if decision.ShouldPublish != tt.wantPublish {
+				t.Errorf("decision = %+v, want ShouldPublish %v", decision, tt.wantPublish)
+			}
+			if !resource.Status.Conditions[0].LastUpdatedTime.Equal(lastUpdated) {
+				t.Error("Evaluate modified the resource conditions")
+			}
+		})
+	}
+}
+
+func TestDecisionEngine_Evaluate_MaintenanceWindows(t *testing.T) {
+	cfg := newDefaultDecisionConfig()
+	cfg.MaintenanceWindows = []config.MaintenanceWindow{
+		{
+			Name:     "saturday-upgrades",
+			Schedule: "0 2 * * sat",
+			Duration: 4 * time.Hour,
+			Selector: config.LabelSelectorList{{Label: "env", Value: "staging"}},
+		},
+		{
+			Name:     "berlin-nightly",
+			Schedule: "0 1 * * *",
+			Timezone: "Europe/Berlin",
+			Duration: 30 * time.Minute,
+		},
+	}
+	engine, err := NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+
+	// 2026-03-07 is a Saturday; Berlin is UTC+1 in March.
+	saturday := time.Date(2026, time.March, 7, 3, 0, 0, 0, time.UTC)
+	tests := []struct {
+		now        time.Time
+		labels     map[string]string
+		name       string
+		wantWindow string
+	}{
+		{
+			name:       "selected and open",
+			now:        saturday,
+			labels:     map[string]string{"env": "staging"},
+			wantWindow: "saturday-upgrades",
+		},
+		{name: "not selected", now: saturday, labels: map[string]string{"env": "production"}},
+		{name: "closed", now: saturday.Add(4 * time.Hour), labels: map[string]string{"env": "staging"}},
+		{
+			name:       "open in its time zone",
+			now:        time.Date(2026, time.March, 9, 0, 15, 0, 0, time.UTC),
+			wantWindow: "berlin-nightly",
+		},
+		{name: "closed in its time zone", now: time.Date(2026, time.March, 9, 1, 15, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A new resource would otherwise always publish.
+			resource := newResourceNoConditions(1)
+			resource.Labels = tt.labels
+
+			decision := engine.Evaluate(context.Background(), resource, tt.now)
+			if tt.wantWindow == "" {
+				if !decision.ShouldPublish {
+					t.Errorf("ShouldPublish = false, want true (reason %q)", decision.Reason)
+				}
+				return
+			}
+			if decision.ShouldPublish || decision.Reason != reasons.MaintenanceWindow || decision.Window != tt.wantWindow {
+				t.Errorf("decision = %+v, want a %q skip by window %q", decision, reasons.MaintenanceWindow, tt.wantWindow)
+			}
+		})
+	}
+}
+
+func TestDecisionEngine_Evaluate_ConditionActions(t *testing.T) {
+	now := time.Now()
+	cfg := newDefaultDecisionConfig()
+	cfg.MaintenanceLabel = "hyperfleet.io/maintenance"
+	cfg.ConditionActions = []config.ConditionAction{
+		{Type: "Paused", Action: config.ConditionActionSkip},
+		{Type: "Degraded", Action: config.ConditionActionPublish},
+	}
+	engine, err := NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+
+	tests := []struct {
+		labels        map[string]string
+		name          string
+		wantReason    reasons.Reason
+		wantCondition string
+		conditions    []client.Condition
+		wantPublished bool
+	}{
+		{
+			name:       "no binding matches",
+			conditions: []client.Condition{{Type: "Paused", Status: "False"}},
+			wantReason: reasons.NotMatched,
+		},
+		{
+			name:          "skip",
+			conditions:    []client.Condition{{Type: "Paused", Status: "True"}},
+			wantReason:    reasons.ConditionSkip,
+			wantCondition: "Paused",
+		},
+		{
+			name:          "publish",
+			conditions:    []client.Condition{{Type: "Degraded", Status: "True"}},
+			wantReason:    reasons.ConditionPublish,
+			wantCondition: "Degraded",
+			wantPublished: true,
+		},
+		{
+			name: "first binding wins",
+			conditions: []client.Condition{
+				{Type: "Degraded", Status: "True"},
+				{Type: "Paused", Status: "True"},
+			},
+			wantReason:    reasons.ConditionSkip,
+			wantCondition: "Paused",
+		},
+		{
+			name:       "maintenance label first",
+			labels:     map[string]string{"hyperfleet.io/maintenance": "true"},
+			conditions: []client.Condition{{Type: "Degraded", Status: "True"}},
+			wantReason: reasons.Maintenance,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Recently reconciled, so the result expression alone would skip.
+			resource := newResourceWithCondition("True", now.Add(-time.Minute), 2)
+			resource.Labels = tt.labels
+			resource.Status.Conditions = append(resource.Status.Conditions, tt.conditions...)
+
+			decision := engine.Evaluate(context.Background(), resource, now)
+			if decision.ShouldPublish != tt.wantPublished {
+				t.Errorf("ShouldPublish = %v, want %v (reason %q)", decision.ShouldPublish, tt.wantPublished, decision.Reason)
+			}
+			if decision.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", decision.Reason, tt.wantReason)
+			}
+			if decision.Condition != tt.wantCondition {
+				t.Errorf("Condition = %q, want %q", decision.Condition, tt.wantCondition)
+			}
+		})
+	}
+}
+
+func TestDecisionEngine_Evaluate_ConditionActionStatus(t *testing.T) {
+	now := time.Now()
+	cfg := newDefaultDecisionConfig()
+	cfg.ConditionActions = []config.ConditionAction{
+		{Type: "Available", Status: "Unknown", Action: config.ConditionActionPublish},
+	}
+	engine, err := NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+
+	for status, want := range map[string]bool{"Unknown": true, "True": false, "False": false} {
+		resource := newResourceWithCondition("True", now.Add(-time.Minute), 2)
+		resource.Status.Conditions = append(resource.Status.Conditions,
+			client.Condition{Type: "Available", Status: status})
+		if got := engine.Evaluate(context.Background(), resource, now).ShouldPublish; got != want {
+			t.Errorf("Available=%s: ShouldPublish = %v, want %v", status, got, want)
+		}
+	}
+}
+
 func TestDecisionEngine_Evaluate_CustomExpressions(t *testing.T) {
 	now := time.Now()
 
@@ -309,7 +734,7 @@ func TestDecisionEngine_Evaluate_CustomExpressions(t *testing.T) {
 		}
 
 		resource := newResourceWithCondition("True", now, 2)
-		decision := engine.Evaluate(resource, now)
+		decision := engine.Evaluate(context.Background(), resource, now)
 
 		if !decision.ShouldPublish {
 			t.Error("expected ShouldPublish=true for always-true result")
@@ -327,7 +752,7 @@ func TestDecisionEngine_Evaluate_CustomExpressions(t *testing.T) {
 		}
 
 		resource := newResourceWithCondition("True", now, 2)
-		decision := engine.Evaluate(resource, now)
+		decision := engine.Evaluate(context.Background(), resource, now)
 
 		if decision.ShouldPublish {
 			t.Error("expected ShouldPublish=false for always-false result")
@@ -350,14 +775,14 @@ func TestDecisionEngine_Evaluate_CustomExpressions(t *testing.T) {
 
 		// generation 1 → should publish
 		resource := newResourceWithCondition("False", now, 1)
-		decision := engine.Evaluate(resource, now)
+		decision := engine.Evaluate(context.Background(), resource, now)
 		if !decision.ShouldPublish {
 			t.Error("expected ShouldPublish=true for generation 1")
 		}
 
 		// generation 2 → should not publish
 		resource2 := newResourceWithCondition("False", now, 2)
-		decision2 := engine.Evaluate(resource2, now)
+		decision2 := engine.Evaluate(context.Background(), resource2, now)
 		if decision2.ShouldPublish {
 			t.Error("expected ShouldPublish=false for generation 2")
 		}
@@ -386,7 +811,7 @@ func TestDecisionEngine_Evaluate_CustomExpressions(t *testing.T) {
 			},
 		}
 
-		decision := engine.Evaluate(resource, now)
+		decision := engine.Evaluate(context.Background(), resource, now)
 		if !decision.ShouldPublish {
 			t.Error("expected ShouldPublish=true for LastKnownReconciled=True condition")
 		}
@@ -403,7 +828,7 @@ func TestDecisionEngine_Evaluate_CustomExpressions(t *testing.T) {
 			},
 		}
 
-		decision2 := engine.Evaluate(resource2, now)
+		decision2 := engine.Evaluate(context.Background(), resource2, now)
 		if decision2.ShouldPublish {
 			t.Error("expected ShouldPublish=false when LastKnownReconciled condition is missing")
 		}
@@ -415,9 +840,9 @@ func TestDecisionEngine_Evaluate_ConsistentBehavior(t *testing.T) {
 	now := time.Now()
 	resource := newResourceWithCondition("True", now.Add(-31*time.Minute), 2)
 
-	decision1 := engine.Evaluate(resource, now)
-	decision2 := engine.Evaluate(resource, now)
-	decision3 := engine.Evaluate(resource, now)
+	decision1 := engine.Evaluate(context.Background(), resource, now)
+	decision2 := engine.Evaluate(context.Background(), resource, now)
+	decision3 := engine.Evaluate(context.Background(), resource, now)
 
 	if decision1.ShouldPublish != decision2.ShouldPublish || decision1.ShouldPublish != decision3.ShouldPublish {
 		t.Error("Evaluate should return consistent results for same inputs")
@@ -427,6 +852,186 @@ func TestDecisionEngine_Evaluate_ConsistentBehavior(t *testing.T) {
 	}
 }
 
+// TestDecisionEngine_Evaluate_PerStateMaxAge verifies the per-state max age
+// map documented in docs/decision-engine.md: the max age is looked up by the
+// Reconciled status, with a fallback for other states.
+func TestDecisionEngine_Evaluate_PerStateMaxAge(t *testing.T) {
+	cfg := &config.MessageDecisionConfig{
+		Params: []config.Param{
+			{Name: "ref_time", Expr: `condition("Reconciled").last_updated_time`},
+			{Name: "state", Expr: `condition("Reconciled").status`},
+			{Name: "max_ages", Expr: `{"True": duration("30m"), "Unknown": duration("5m"), "False": duration("30s")}`},
+			{Name: "max_age", Expr: `state in max_ages ? max_ages[state] : duration("10s")`},
+			{Name: "is_stale", Expr: `ref_time == "" || now - timestamp(ref_time) > max_age`},
+		},
+		Result: "is_stale",
+	}
+	engine, err := NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+
+	now := time.Now()
+	tests := []struct {
+		name        string
+		status      string
+		age         time.Duration
+		wantPublish bool
+	}{
+		{name: "reconciled within max age", status: "True", age: 29 * time.Minute},
+		{name: "reconciled past max age", status: "True", age: 31 * time.Minute, wantPublish: true},
+		{name: "unknown within max age", status: "Unknown", age: 4 * time.Minute},
+		{name: "unknown past max age", status: "Unknown", age: 6 * time.Minute, wantPublish: true},
+		{name: "not reconciled within max age", status: "False", age: 20 * time.Second},
+		{name: "not reconciled past max age", status: "False", age: 40 * time.Second, wantPublish: true},
+		{name: "other state uses default", status: "Degraded", age: 11 * time.Second, wantPublish: true},
+		{name: "other state within default", status: "Degraded", age: 9 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := engine.Evaluate(context.Background(), newResourceWithCondition(tt.status, now.Add(-tt.age), 2), now)
+			if decision.Err != nil {
+				t.Fatalf("unexpected evaluation error: %v", decision.Err)
+			}
+			if decision.ShouldPublish != tt.wantPublish {
+				t.Errorf("ShouldPublish = %v, want %v (reason %q)", decision.ShouldPublish, tt.wantPublish, decision.Reason)
+			}
+		})
+	}
+}
+
+func TestDecisionEngine_Evaluate_Rules(t *testing.T) {
+	cfg := &config.MessageDecisionConfig{
+		Params: []config.Param{
+			{Name: "is_reconciled", Expr: `condition("Reconciled").status == "True"`},
+		},
+		Rules: []config.Rule{
+			{Name: "new_resource", Expr: `resource.generation == 1`},
+			{Name: "not_reconciled", Expr: `!is_reconciled`},
+			{Name: "big_generation", Expr: `resource.generation > 100`},
+		},
+	}
+	engine, err := NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+
+	now := time.Now()
+	tests := []struct {
+		name       string
+		status     string
+		wantRule   string
+		wantReason reasons.Reason
+		generation int32
+	}{
+		{name: "first rule", status: "False", generation: 1, wantRule: "new_resource", wantReason: reasons.Matched},
+		{name: "second rule", status: "False", generation: 2, wantRule: "not_reconciled", wantReason: reasons.Matched},
+		{name: "last rule", status: "True", generation: 101, wantRule: "big_generation", wantReason: reasons.Matched},
+		{name: "no rule", status: "True", generation: 2, wantReason: reasons.NotMatched},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := engine.Evaluate(context.Background(), newResourceWithCondition(tt.status, now, tt.generation), now)
+			if decision.Reason != tt.wantReason || decision.Rule != tt.wantRule {
+				t.Errorf("Evaluate() = reason %q rule %q, want reason %q rule %q",
+					decision.Reason, decision.Rule, tt.wantReason, tt.wantRule)
+			}
+			if decision.ShouldPublish != tt.wantReason.Publishes() {
+				t.Errorf("ShouldPublish = %v for reason %q", decision.ShouldPublish, decision.Reason)
+			}
+		})
+	}
+}
+
+func TestDecisionEngine_Evaluate_RuleFailures(t *testing.T) {
+	tests := []struct {
+		name       string
+		expr       string
+		wantReason reasons.Reason
+	}{
+		{name: "evaluation fails", expr: `resource.owner == "team-a"`, wantReason: reasons.ResultEvaluationFailed},
+		{name: "not a bool", expr: `resource.id`, wantReason: reasons.ResultNotBool},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := NewDecisionEngine(&config.MessageDecisionConfig{
+				Rules: []config.Rule{{Name: "broken", Expr: tt.expr}, {Name: "always", Expr: "true"}},
+			})
+			if err != nil {
+				t.Fatalf("NewDecisionEngine failed: %v", err)
+			}
+			decision := engine.Evaluate(context.Background(), newResourceWithCondition("True", time.Now(), 1), time.Now())
+			if decision.ShouldPublish || decision.Reason != tt.wantReason {
+				t.Errorf("Evaluate() = %+v, want a skip with reason %q", decision, tt.wantReason)
+			}
+			if decision.Err == nil || !strings.Contains(decision.Err.Error(), `rule "broken"`) {
+				t.Errorf("Err = %v, want it to name the rule", decision.Err)
+			}
+		})
+	}
+}
+
+func TestNewDecisionEngine_InvalidRule(t *testing.T) {
+	_, err := NewDecisionEngine(&config.MessageDecisionConfig{
+		Rules: []config.Rule{{Name: "bad", Expr: "resource.generation =="}},
+	})
+	if err == nil || !strings.Contains(err.Error(), `rule "bad"`) {
+		t.Errorf("expected compile error naming the rule, got %v", err)
+	}
+}
+
+func TestDecisionEngine_Evaluate_Failures(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     string
+		wantReason reasons.Reason
+		wantErr    string
+		params     []config.Param
+	}{
+		{
+			name:       "param evaluation fails",
+			params:     []config.Param{{Name: "owner", Expr: `resource.owner`}},
+			result:     `owner == "team-a"`,
+			wantReason: reasons.ParamEvaluationFailed,
+			wantErr:    `param "owner"`,
+		},
+		{
+			name:       "result evaluation fails",
+			result:     `resource.owner == "team-a"`,
+			wantReason: reasons.ResultEvaluationFailed,
+			wantErr:    "no such key",
+		},
+		{
+			name:       "result is not a bool",
+			result:     `resource.id`,
+			wantReason: reasons.ResultNotBool,
+			wantErr:    "got string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := NewDecisionEngine(&config.MessageDecisionConfig{Params: tt.params, Result: tt.result})
+			if err != nil {
+				t.Fatalf("NewDecisionEngine failed: %v", err)
+			}
+			decision := engine.Evaluate(context.Background(), newResourceWithCondition("True", time.Now(), 1), time.Now())
+			if decision.ShouldPublish {
+				t.Error("expected a failed evaluation not to publish")
+			}
+			if decision.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", decision.Reason, tt.wantReason)
+			}
+			if decision.Err == nil || !strings.Contains(decision.Err.Error(), tt.wantErr) {
+				t.Errorf("Err = %v, want it to contain %q", decision.Err, tt.wantErr)
+			}
+			if !decision.Reason.Failed() {
+				t.Errorf("expected reason %q to report Failed", decision.Reason)
+			}
+		})
+	}
+}
+
 func TestDecisionEngine_Evaluate_ReconciledBoundary(t *testing.T) {
 	now := time.Now()
 	engine := newTestDecisionEngine(t)
@@ -445,7 +1050,7 @@ func TestDecisionEngine_Evaluate_ReconciledBoundary(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			resource := newResourceWithCondition("True", now.Add(tt.lastUpdated), 2)
-			decision := engine.Evaluate(resource, now)
+			decision := engine.Evaluate(context.Background(), resource, now)
 
 			if decision.ShouldPublish != tt.wantShouldPublish {
 				t.Errorf("ShouldPublish = %v, want %v (lastUpdated offset: %v)",
@@ -473,7 +1078,7 @@ func TestDecisionEngine_Evaluate_NotReconciledBoundary(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			resource := newResourceWithCondition("False", now.Add(tt.lastUpdated), 2)
-			decision := engine.Evaluate(resource, now)
+			decision := engine.Evaluate(context.Background(), resource, now)
 
 			if decision.ShouldPublish != tt.wantShouldPublish {
 				t.Errorf("ShouldPublish = %v, want %v (lastUpdated offset: %v)",
@@ -483,6 +1088,118 @@ func TestDecisionEngine_Evaluate_NotReconciledBoundary(t *testing.T) {
 	}
 }
 
+func TestDecisionEngine_Evaluate_MaxAgeOverrides(t *testing.T) {
+	now := time.Now()
+	cfg := newDefaultDecisionConfig()
+	cfg.MaxAgeOverrides = &config.MaxAgeOverridesConfig{Min: time.Minute, Max: time.Hour}
+	engine, err := NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+
+	readyLabel := config.MaxAgeLabelPrefix + "ready"
+	notReadyLabel := config.MaxAgeLabelPrefix + "not-ready"
+	tests := []struct {
+		labels            map[string]string
+		name              string
+		status            string
+		lastUpdated       time.Duration
+		wantShouldPublish bool
+	}{
+		{name: "no label uses 30m default", status: "True", lastUpdated: -20 * time.Minute},
+		{
+			name:              "ready override of 10m",
+			status:            "True",
+			labels:            map[string]string{readyLabel: "10m"},
+			lastUpdated:       -11 * time.Minute,
+			wantShouldPublish: true,
+		},
+		{
+			name:        "ready override of 10m not yet elapsed",
+			status:      "True",
+			labels:      map[string]string{readyLabel: "10m"},
+			lastUpdated: -9 * time.Minute,
+		},
+		{
+			name:              "ready override clamped to min",
+			status:            "True",
+			labels:            map[string]string{readyLabel: "1s"},
+			lastUpdated:       -61 * time.Second,
+			wantShouldPublish: true,
+		},
+		{
+			name:        "ready override below min not yet elapsed",
+			status:      "True",
+			labels:      map[string]string{readyLabel: "1s"},
+			lastUpdated: -59 * time.Second,
+		},
+		{
+			name:        "ready override clamped to max",
+			status:      "True",
+			labels:      map[string]string{readyLabel: "24h"},
+			lastUpdated: -59 * time.Minute,
+		},
+		{
+			name:              "ready override above max elapsed",
+			status:            "True",
+			labels:            map[string]string{readyLabel: "24h"},
+			lastUpdated:       -61 * time.Minute,
+			wantShouldPublish: true,
+		},
+		{
+			name:        "invalid value uses default",
+			status:      "True",
+			labels:      map[string]string{readyLabel: "soon"},
+			lastUpdated: -20 * time.Minute,
+		},
+		{
+			name:        "negative value uses default",
+			status:      "True",
+			labels:      map[string]string{readyLabel: "-5m"},
+			lastUpdated: -20 * time.Minute,
+		},
+		{
+			name:        "not-ready override",
+			status:      "False",
+			labels:      map[string]string{notReadyLabel: "5m"},
+			lastUpdated: -4 * time.Minute,
+		},
+		{
+			name:              "not-ready label does not affect ready resources",
+			status:            "True",
+			labels:            map[string]string{notReadyLabel: "5m"},
+			lastUpdated:       -31 * time.Minute,
+			wantShouldPublish: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := newResourceWithCondition(tt.status, now.Add(tt.lastUpdated), 2)
+			resource.Labels = tt.labels
+			decision := engine.Evaluate(context.Background(), resource, now)
+
+			if decision.ShouldPublish != tt.wantShouldPublish {
+				t.Errorf("ShouldPublish = %v, want %v (reason %q)",
+					decision.ShouldPublish, tt.wantShouldPublish, decision.Reason)
+			}
+		})
+	}
+}
+
+func TestDecisionEngine_Evaluate_MaxAgeOverridesDisabled(t *testing.T) {
+	now := time.Now()
+	engine := newTestDecisionEngine(t)
+
+	// Without max_age_overrides the label is ignored and the 30m default applies.
+	resource := newResourceWithCondition("True", now.Add(-11*time.Minute), 2)
+	resource.Labels = map[string]string{config.MaxAgeLabelPrefix + "ready": "10m"}
+	decision := engine.Evaluate(context.Background(), resource, now)
+	if decision.ShouldPublish {
+		t.Errorf("ShouldPublish = true, want false (reason %q)", decision.Reason)
+	}
+}
+
 func TestNewDecisionEngine_AcceptsStringHelperExpressions(t *testing.T) {
 	// Regression guard: ext.Strings() must be registered so DecisionEngine accepts
 	// string helper expressions. Without it, compilation fails with "undefined field 'split'".
@@ -496,7 +1213,7 @@ func TestNewDecisionEngine_AcceptsStringHelperExpressions(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ext.Strings() not registered — DecisionEngine rejects string helper expressions: %v", err)
 	}
-	decision := engine.Evaluate(newResourceWithCondition("True", time.Now(), 1), time.Now())
+	decision := engine.Evaluate(context.Background(), newResourceWithCondition("True", time.Now(), 1), time.Now())
 	if !decision.ShouldPublish {
 		t.Fatalf("expected evaluation to succeed with string helper expression, got: %+v", decision)
 	}
@@ -0,0 +1,180 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+)
+
+// explainHorizon bounds the search for the next publish time. A resource
+// that would not be published within it has no next publish time.
+const explainHorizon = 7 * 24 * time.Hour
+
+// Explanation breaks a decision down for debugging: the value of every
+// message_decision param, rule and result, the max_age() durations selected
+// and the generations compared. With the default message_decision, ref_time
+// holds the reference time used and generation_mismatch the generation
+// comparison.
+type Explanation struct {
+	Now time.Time `json:"now"`
+	// NextPublish is the earliest time, within a week of Now, at which the
+	// resource would be published if it stayed unchanged. It equals Now when
+	// the resource is published, and is nil when evaluation failed or the
+	// resource is not published within the week.
+	NextPublish         *time.Time       `json:"next_publish_time,omitempty"`
+	Result              *ExplainedValue  `json:"result,omitempty"`
+	ObservedGenerations map[string]int32 `json:"observed_generations,omitempty"`
+	Params              []ExplainedValue `json:"params,omitempty"`
+	Rules               []ExplainedValue `json:"rules,omitempty"`
+	MaxAges             []MaxAgeValue    `json:"max_ages,omitempty"`
+	Decision            Decision         `json:"-"`
+	Generation          int32            `json:"generation"`
+}
+
+// ExplainedValue is the value of a message_decision expression. Timestamps
+// are formatted as RFC 3339 and durations as Go durations.
+type ExplainedValue struct {
+	Value any    `json:"value"`
+	Name  string `json:"name,omitempty"`
+}
+
+// MaxAgeValue is a max_age() call made by the message_decision expressions.
+// Value is the label override when Overridden, else Default.
+type MaxAgeValue struct {
+	Name       string `json:"name"`
+	Default    string `json:"default"`
+	Value      string `json:"value"`
+	Overridden bool   `json:"overridden"`
+}
+
+// explainTrace records the expression values of one evaluation. Its methods
+// do nothing on a nil trace, so evaluate records only while explaining.
+type explainTrace struct {
+	result  *ExplainedValue
+	params  []ExplainedValue
+	rules   []ExplainedValue
+	maxAges []MaxAgeValue
+}
+
+func (t *explainTrace) param(name string, out ref.Val) {
+	if t == nil || out == nil {
+		return
+	}
+	t.params = append(t.params, ExplainedValue{Name: name, Value: explainValue(out)})
+}
+
+func (t *explainTrace) rule(name string, out ref.Val) {
+	if t == nil || out == nil {
+		return
+	}
+	t.rules = append(t.rules, ExplainedValue{Name: name, Value: explainValue(out)})
+}
+
+func (t *explainTrace) resultValue(out ref.Val) {
+	if t == nil || out == nil {
+		return
+	}
+	t.result = &ExplainedValue{Value: explainValue(out)}
+}
+
+func (t *explainTrace) maxAge(name string, defaultVal ref.Val, override time.Duration, overridden bool) {
+	if t == nil {
+		return
+	}
+	v := MaxAgeValue{Name: name, Overridden: overridden}
+	v.Default = fmt.Sprint(explainValue(defaultVal))
+	v.Value = v.Default
+	if overridden {
+		v.Value = override.String()
+	}
+	t.maxAges = append(t.maxAges, v)
+}
+
+// explainValue converts a CEL value to one that encodes readably as JSON.
+func explainValue(val ref.Val) any {
+	switch v := val.Value().(type) {
+	case bool, string, int64, uint64, float64, nil:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Explain evaluates the resource like Evaluate and breaks the decision down.
+// The next publish time is found by evaluating the unchanged resource at
+// later times, assuming that once published it stays published; it is
+// approximate to a second and may be early when maintenance windows apply.
+func (e *DecisionEngine) Explain(resource *client.Resource, now time.Time) *Explanation {
+	e.evalMu.Lock()
+	defer e.evalMu.Unlock()
+
+	e.trace = &explainTrace{}
+	decision := e.evaluate(resource, now)
+	trace := e.trace
+	e.trace = nil
+
+	ex := &Explanation{
+		Now:      now,
+		Decision: decision,
+		Params:   trace.params,
+		Rules:    trace.rules,
+		Result:   trace.result,
+		MaxAges:  trace.maxAges,
+	}
+	if resource == nil || now.IsZero() {
+		return ex
+	}
+
+	ex.Generation = resource.Generation
+	for _, c := range resource.Status.Conditions {
+		if ex.ObservedGenerations == nil {
+			ex.ObservedGenerations = make(map[string]int32, len(resource.Status.Conditions))
+		}
+		ex.ObservedGenerations[c.Type] = c.ObservedGeneration
+	}
+
+	switch {
+	case decision.ShouldPublish:
+		ex.NextPublish = &now
+	case decision.Err == nil:
+		ex.NextPublish = e.nextPublish(resource, now)
+	}
+	return ex
+}
+
+// nextPublish returns the earliest time within explainHorizon after now at
+// which resource would be published, to a second. It doubles the offset from
+// now until the resource is published, then bisects the last interval.
+// The caller holds evalMu.
+func (e *DecisionEngine) nextPublish(resource *client.Resource, now time.Time) *time.Time {
+	skipped := now
+	var published time.Time
+	for step := time.Second; ; step *= 2 {
+		step = min(step, explainHorizon)
+		at := now.Add(step)
+		if e.evaluate(resource, at).ShouldPublish {
+			published = at
+			break
+		}
+		if step == explainHorizon {
+			return nil
+		}
+		skipped = at
+	}
+
+	for published.Sub(skipped) > time.Second {
+		mid := skipped.Add(published.Sub(skipped) / 2)
+		if e.evaluate(resource, mid).ShouldPublish {
+			published = mid
+		} else {
+			skipped = mid
+		}
+	}
+	return &published
+}
@@ -0,0 +1,212 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+)
+
+func explainedValue(t *testing.T, values []ExplainedValue, name string) any {
+	t.Helper()
+	for _, v := range values {
+		if v.Name == name {
+			return v.Value
+		}
+	}
+	t.Fatalf("no value for %q in %+v", name, values)
+	return nil
+}
+
+func TestDecisionEngine_Explain(t *testing.T) {
+	engine := newTestDecisionEngine(t)
+	now := time.Now()
+	lastUpdated := now.Add(-10 * time.Minute)
+	resource := newResourceWithCondition("True", lastUpdated, 2)
+
+	ex := engine.Explain(resource, now)
+
+	if ex.Decision.ShouldPublish || ex.Decision.Reason != reasons.NotMatched {
+		t.Errorf("Decision = %+v, want skip with %q", ex.Decision, reasons.NotMatched)
+	}
+	if !ex.Now.Equal(now) {
+		t.Errorf("Now = %v, want %v", ex.Now, now)
+	}
+	if len(ex.Params) != len(newDefaultDecisionConfig().Params) {
+		t.Errorf("got %d params, want one per configured param", len(ex.Params))
+	}
+	if got, want := explainedValue(t, ex.Params, "ref_time"), lastUpdated.Format(time.RFC3339Nano); got != want {
+		t.Errorf("ref_time = %v, want %v", got, want)
+	}
+	if got := explainedValue(t, ex.Params, "generation_mismatch"); got != false {
+		t.Errorf("generation_mismatch = %v, want false", got)
+	}
+	if ex.Result == nil || ex.Result.Value != false {
+		t.Errorf("Result = %+v, want false", ex.Result)
+	}
+	if len(ex.MaxAges) != 1 {
+		t.Fatalf("MaxAges = %+v, want the ready max age", ex.MaxAges)
+	}
+	if want := (MaxAgeValue{Name: "ready", Default: "30m0s", Value: "30m0s"}); ex.MaxAges[0] != want {
+		t.Errorf("MaxAges[0] = %+v, want %+v", ex.MaxAges[0], want)
+	}
+	if ex.Generation != 2 || ex.ObservedGenerations["Reconciled"] != 2 {
+		t.Errorf("Generation = %d, ObservedGenerations = %v, want 2 and Reconciled: 2",
+			ex.Generation, ex.ObservedGenerations)
+	}
+
+	if ex.NextPublish == nil {
+		t.Fatal("NextPublish is nil, want the time the resource becomes stale")
+	}
+	stale := lastUpdated.Add(30 * time.Minute)
+	if d := ex.NextPublish.Sub(stale); d <= 0 || d > time.Second {
+		t.Errorf("NextPublish = %v, want within a second after %v", ex.NextPublish, stale)
+	}
+}
+
+func TestDecisionEngine_Explain_Published(t *testing.T) {
+	engine := newTestDecisionEngine(t)
+	now := time.Now()
+	resource := newResourceWithCondition("False", now.Add(-time.Minute), 2)
+
+	ex := engine.Explain(resource, now)
+
+	if !ex.Decision.ShouldPublish {
+		t.Fatalf("Decision = %+v, want publish", ex.Decision)
+	}
+	if ex.NextPublish == nil || !ex.NextPublish.Equal(now) {
+		t.Errorf("NextPublish = %v, want now", ex.NextPublish)
+	}
+	want := MaxAgeValue{Name: "not-ready", Default: "10s", Value: "10s"}
+	if len(ex.MaxAges) != 1 || ex.MaxAges[0] != want {
+		t.Errorf("MaxAges = %+v, want [%+v]", ex.MaxAges, want)
+	}
+}
+
+func TestDecisionEngine_Explain_MaxAgeOverride(t *testing.T) {
+	cfg := newDefaultDecisionConfig()
+	cfg.MaxAgeOverrides = &config.MaxAgeOverridesConfig{Min: time.Minute, Max: time.Hour}
+	engine, err := NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+	now := time.Now()
+	lastUpdated := now.Add(-10 * time.Minute)
+	resource := newResourceWithCondition("True", lastUpdated, 2)
+	resource.Labels = map[string]string{config.MaxAgeLabelPrefix + "ready": "45m"}
+
+	ex := engine.Explain(resource, now)
+
+	if want := (MaxAgeValue{Name: "ready", Default: "30m0s", Value: "45m0s", Overridden: true}); len(ex.MaxAges) != 1 ||
+		ex.MaxAges[0] != want {
+		t.Errorf("MaxAges = %+v, want [%+v]", ex.MaxAges, want)
+	}
+	stale := lastUpdated.Add(45 * time.Minute)
+	if ex.NextPublish == nil {
+		t.Fatal("NextPublish is nil")
+	}
+	if d := ex.NextPublish.Sub(stale); d <= 0 || d > time.Second {
+		t.Errorf("NextPublish = %v, want within a second after %v", ex.NextPublish, stale)
+	}
+}
+
+func TestDecisionEngine_Explain_Rules(t *testing.T) {
+	engine, err := NewDecisionEngine(&config.MessageDecisionConfig{
+		Rules: []config.Rule{
+			{Name: "new_resource", Expr: `resource.generation == 1`},
+			{Name: "big_generation", Expr: `resource.generation > 100`},
+			{Name: "never", Expr: `false`},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+
+	ex := engine.Explain(newResourceNoConditions(101), time.Now())
+
+	if ex.Decision.Rule != "big_generation" {
+		t.Errorf("Decision.Rule = %q, want big_generation", ex.Decision.Rule)
+	}
+	// Rules after the matching one are not evaluated
+	want := []ExplainedValue{{Name: "new_resource", Value: false}, {Name: "big_generation", Value: true}}
+	if len(ex.Rules) != len(want) || ex.Rules[0] != want[0] || ex.Rules[1] != want[1] {
+		t.Errorf("Rules = %+v, want %+v", ex.Rules, want)
+	}
+	if ex.Result != nil {
+		t.Errorf("Result = %+v, want nil with rules", ex.Result)
+	}
+}
+
+func TestDecisionEngine_Explain_NeverPublished(t *testing.T) {
+	cfg := newDefaultDecisionConfig()
+	cfg.MaintenanceLabel = "hyperfleet.io/maintenance"
+	engine, err := NewDecisionEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+	resource := newResourceWithCondition("True", time.Now(), 2)
+	resource.Labels = map[string]string{"hyperfleet.io/maintenance": "true"}
+
+	ex := engine.Explain(resource, time.Now())
+
+	if ex.Decision.Reason != reasons.Maintenance {
+		t.Errorf("Decision.Reason = %q, want %q", ex.Decision.Reason, reasons.Maintenance)
+	}
+	if ex.NextPublish != nil {
+		t.Errorf("NextPublish = %v, want nil for a resource in maintenance", ex.NextPublish)
+	}
+	if len(ex.Params) != 0 {
+		t.Errorf("Params = %+v, want none before CEL evaluation", ex.Params)
+	}
+}
+
+func TestDecisionEngine_Explain_Nil(t *testing.T) {
+	ex := newTestDecisionEngine(t).Explain(nil, time.Now())
+	if ex.Decision.Reason != reasons.NilResource || ex.NextPublish != nil {
+		t.Errorf("Explain(nil) = %+v, want %q without a next publish time", ex, reasons.NilResource)
+	}
+}
+
+// TestDecisionEngine_Explain_Concurrent explains while resources are
+// evaluated, as the admin server does during polling; run with -race.
+func TestDecisionEngine_Explain_Concurrent(t *testing.T) {
+	engine := newTestDecisionEngine(t)
+	now := time.Now()
+	stale := newResourceWithCondition("True", now.Add(-time.Hour), 2)
+	fresh := newResourceWithCondition("True", now, 2)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				if !engine.Evaluate(context.Background(), stale, now).ShouldPublish {
+					t.Error("stale resource not published")
+					return
+				}
+			}
+		}()
+	}
+	for range 10 {
+		if ex := engine.Explain(fresh, now); ex.Decision.ShouldPublish {
+			t.Errorf("fresh resource published: %+v", ex.Decision)
+		}
+	}
+	wg.Wait()
+}
+
+func TestDecisionEngine_Explain_NewResource(t *testing.T) {
+	resource := &client.Resource{ID: "r1", Kind: testResourceKind, Generation: 1}
+	ex := newTestDecisionEngine(t).Explain(resource, time.Now())
+	if got := explainedValue(t, ex.Params, "ref_time"); got != "" {
+		t.Errorf("ref_time = %v, want empty without a Reconciled condition", got)
+	}
+	if !ex.Decision.ShouldPublish || ex.Decision.Reason != reasons.Matched {
+		t.Errorf("Decision = %+v, want a new resource published", ex.Decision)
+	}
+}
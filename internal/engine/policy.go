@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+)
+
+// DefaultPolicyTimeout bounds a decision_policy query when
+// decision_policy.timeout is 0.
+const DefaultPolicyTimeout = 2 * time.Second
+
+// maxPolicyResponseSize bounds the body read from the policy server.
+const maxPolicyResponseSize = 1 << 20
+
+// PolicyDecider is a Decider that queries a Rego policy through the Data API
+// of an Open Policy Agent server (POST /v1/data/<path>). OPA loads the policy
+// itself, from local files or a bundle URL, so fleet policy teams can change
+// the triggering logic without a Sentinel release.
+//
+// The policy input is {"resource": ..., "now": ...}, with the resource in the
+// form the CEL expressions see. The policy must return an object with a
+// boolean publish and an optional string reason; the reason is reported as the
+// rule of the decision.
+type PolicyDecider struct {
+	client   *http.Client
+	endpoint string
+	username string
+	password string
+	timeout  time.Duration
+}
+
+// policyDecision is the document the policy returns.
+type policyDecision struct {
+	Publish *bool  `json:"publish"`
+	Reason  string `json:"reason"`
+}
+
+// NewPolicyDecider creates a PolicyDecider from a DecisionPolicyConfig.
+func NewPolicyDecider(cfg *config.DecisionPolicyConfig) (*PolicyDecider, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("decision_policy config is required")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid decision_policy config: %w", err)
+	}
+
+	// Credentials in the URL are sent as basic auth rather than as part of
+	// the URL, so that errors, which quote the URL, do not leak them.
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decision policy URL %s: %w", cfg.RedactedURL(), err)
+	}
+	p := &PolicyDecider{client: &http.Client{}, timeout: cfg.Timeout}
+	if u.User != nil {
+		p.username = u.User.Username()
+		p.password, _ = u.User.Password()
+		u.User = nil
+	}
+	p.endpoint = strings.TrimSuffix(u.String(), "/") + "/v1/data/" + cfg.Path
+	if p.timeout == 0 {
+		p.timeout = DefaultPolicyTimeout
+	}
+	return p, nil
+}
+
+// Evaluate queries the policy for the resource. A policy that cannot be
+// queried, is undefined, or returns no boolean publish skips the resource with
+// reason PolicyEvaluationFailed. The query is bounded by the decision_policy
+// timeout and canceled with ctx.
+func (p *PolicyDecider) Evaluate(ctx context.Context, resource *client.Resource, now time.Time) Decision {
+	if resource == nil {
+		return Decision{ShouldPublish: false, Reason: reasons.NilResource}
+	}
+	if now.IsZero() {
+		return Decision{ShouldPublish: false, Reason: reasons.ZeroTime}
+	}
+
+	decision, err := p.query(ctx, resource, now)
+	if err != nil {
+		return Decision{ShouldPublish: false, Reason: reasons.PolicyEvaluationFailed, Err: err}
+	}
+	if *decision.Publish {
+		return Decision{ShouldPublish: true, Reason: reasons.PolicyPublish, Rule: decision.Reason}
+	}
+	return Decision{ShouldPublish: false, Reason: reasons.PolicySkip, Rule: decision.Reason}
+}
+
+// query posts the policy input and decodes the policy decision.
+func (p *PolicyDecider) query(ctx context.Context, resource *client.Resource, now time.Time) (*policyDecision, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"input": map[string]interface{}{
+			"resource": resource.ToMap(),
+			"now":      now.UTC().Format(time.RFC3339Nano),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding policy input: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating policy request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.username != "" {
+		req.SetBasicAuth(p.username, p.password)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("querying policy: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("querying policy %s: unexpected status %s", p.endpoint, resp.Status)
+	}
+
+	var out struct {
+		Result *policyDecision `json:"result"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxPolicyResponseSize)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decoding policy response: %w", err)
+	}
+	if out.Result == nil {
+		return nil, fmt.Errorf("policy %s is undefined", p.endpoint)
+	}
+	if out.Result.Publish == nil {
+		return nil, fmt.Errorf("policy %s returned no boolean publish", p.endpoint)
+	}
+	return out.Result, nil
+}
@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+)
+
+// newPolicyServer returns an OPA stand-in that answers every query with body
+// and records the last request path and input.
+func newPolicyServer(t *testing.T, status int, body string) (*httptest.Server, *map[string]interface{}, *string) {
+	t.Helper()
+	input := map[string]interface{}{}
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		var req struct {
+			Input map[string]interface{} `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding policy request: %v", err)
+		}
+		input = req.Input
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &input, &path
+}
+
+func newTestPolicyDecider(t *testing.T, url string) *PolicyDecider {
+	t.Helper()
+	p, err := NewPolicyDecider(&config.DecisionPolicyConfig{URL: url, Path: "hyperfleet/sentinel/decision"})
+	if err != nil {
+		t.Fatalf("NewPolicyDecider failed: %v", err)
+	}
+	return p
+}
+
+func TestPolicyDecider_Evaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantReason reasons.Reason
+		wantRule   string
+		status     int
+		wantPub    bool
+	}{
+		{
+			name:       "publish",
+			body:       `{"result": {"publish": true, "reason": "stale"}}`,
+			wantReason: reasons.PolicyPublish,
+			wantRule:   "stale",
+			wantPub:    true,
+		},
+		{name: "skip", body: `{"result": {"publish": false}}`, wantReason: reasons.PolicySkip},
+		{name: "undefined", body: `{}`, wantReason: reasons.PolicyEvaluationFailed},
+		{name: "no publish", body: `{"result": {"reason": "stale"}}`, wantReason: reasons.PolicyEvaluationFailed},
+		{name: "not an object", body: `{"result": true}`, wantReason: reasons.PolicyEvaluationFailed},
+		{
+			name:       "server error",
+			status:     http.StatusInternalServerError,
+			body:       `{"code": "internal_error"}`,
+			wantReason: reasons.PolicyEvaluationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			server, _, _ := newPolicyServer(t, status, tt.body)
+
+			p := newTestPolicyDecider(t, server.URL)
+			decision := p.Evaluate(context.Background(), newResourceNoConditions(1), time.Now())
+			if decision.ShouldPublish != tt.wantPub || decision.Reason != tt.wantReason || decision.Rule != tt.wantRule {
+				t.Errorf("decision = %+v, want publish %v, reason %q, rule %q", decision, tt.wantPub, tt.wantReason, tt.wantRule)
+			}
+			if tt.wantReason.Failed() && decision.Err == nil {
+				t.Error("expected the failure to carry an error")
+			}
+		})
+	}
+}
+
+func TestPolicyDecider_Input(t *testing.T) {
+	server, input, path := newPolicyServer(t, http.StatusOK, `{"result": {"publish": false}}`)
+	now := time.Date(2026, time.March, 7, 2, 0, 0, 0, time.UTC)
+	resource := newResourceWithCondition("True", now.Add(-time.Minute), 3)
+	resource.ID = "cluster-1"
+
+	newTestPolicyDecider(t, server.URL+"/").Evaluate(context.Background(), resource, now)
+
+	if *path != "/v1/data/hyperfleet/sentinel/decision" {
+		t.Errorf("path = %q", *path)
+	}
+	if got := (*input)["now"]; got != "2026-03-07T02:00:00Z" {
+		t.Errorf("input.now = %v", got)
+	}
+	res, ok := (*input)["resource"].(map[string]interface{})
+	if !ok || res["id"] != "cluster-1" || res["generation"] != float64(3) {
+		t.Errorf("input.resource = %v", (*input)["resource"])
+	}
+}
+
+func TestPolicyDecider_BasicAuth(t *testing.T) {
+	var user, password string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ = r.BasicAuth()
+		_, _ = w.Write([]byte(`{"result": {"publish": true}}`))
+	}))
+	defer server.Close()
+
+	p := newTestPolicyDecider(t, strings.Replace(server.URL, "http://", "http://opa:secret@", 1))
+	if decision := p.Evaluate(context.Background(), newResourceNoConditions(1), time.Now()); !decision.ShouldPublish {
+		t.Fatalf("decision = %+v, want publish", decision)
+	}
+	if user != "opa" || password != "secret" {
+		t.Errorf("basic auth = %s:%s, want opa:secret", user, password)
+	}
+	if strings.Contains(p.endpoint, "secret") {
+		t.Errorf("endpoint %q must not carry credentials", p.endpoint)
+	}
+}
+
+func TestPolicyDecider_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	p, err := NewPolicyDecider(&config.DecisionPolicyConfig{
+		URL: server.URL, Path: "sentinel", Timeout: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewPolicyDecider failed: %v", err)
+	}
+	decision := p.Evaluate(context.Background(), newResourceNoConditions(1), time.Now())
+	if decision.Reason != reasons.PolicyEvaluationFailed {
+		t.Errorf("decision = %+v, want %q", decision, reasons.PolicyEvaluationFailed)
+	}
+}
+
+func TestNewPolicyDecider_Invalid(t *testing.T) {
+	if _, err := NewPolicyDecider(nil); err == nil {
+		t.Error("expected an error for a nil config")
+	}
+	if _, err := NewPolicyDecider(&config.DecisionPolicyConfig{URL: "opa:8181", Path: "sentinel"}); err == nil {
+		t.Error("expected an error for a relative URL")
+	}
+}
+
+func TestPolicyDecider_ContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	// The policy timeout is far longer than the test: only the canceled
+	// cycle context can end the query.
+	p, err := NewPolicyDecider(&config.DecisionPolicyConfig{URL: server.URL, Path: "sentinel", Timeout: time.Minute})
+	if err != nil {
+		t.Fatalf("NewPolicyDecider failed: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	decision := p.Evaluate(ctx, newResourceNoConditions(1), time.Now())
+	if decision.Reason != reasons.PolicyEvaluationFailed || !errors.Is(decision.Err, context.DeadlineExceeded) {
+		t.Errorf("decision = %+v, want %q caused by the canceled context", decision, reasons.PolicyEvaluationFailed)
+	}
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/schedule"
+)
+
+// maintenanceWindow is a compiled message_decision maintenance window.
+type maintenanceWindow struct {
+	cron     *schedule.Cron
+	location *time.Location
+	name     string
+	selector config.LabelSelectorList
+	duration time.Duration
+}
+
+// maintenanceWindows tells which maintenance windows are open. Whether a
+// window is open depends only on the time, so it is computed at most once per
+// minute rather than for every resource.
+type maintenanceWindows struct {
+	minute  time.Time
+	windows []maintenanceWindow
+	open    []bool
+	mu      sync.Mutex
+}
+
+func newMaintenanceWindows(cfgs []config.MaintenanceWindow) (*maintenanceWindows, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+	mw := &maintenanceWindows{
+		windows: make([]maintenanceWindow, 0, len(cfgs)),
+		open:    make([]bool, len(cfgs)),
+	}
+	for i := range cfgs {
+		cfg := &cfgs[i]
+		cron, err := schedule.Parse(cfg.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("maintenance window %q: %w", cfg.Name, err)
+		}
+		location, err := cfg.Location()
+		if err != nil {
+			return nil, fmt.Errorf("maintenance window %q: %w", cfg.Name, err)
+		}
+		mw.windows = append(mw.windows, maintenanceWindow{
+			cron:     cron,
+			location: location,
+			name:     cfg.Name,
+			selector: cfg.Selector,
+			duration: cfg.Duration,
+		})
+	}
+	return mw, nil
+}
+
+// match returns the name of the first open window that selects the resource.
+func (mw *maintenanceWindows) match(resource *client.Resource, now time.Time) (string, bool) {
+	if mw == nil {
+		return "", false
+	}
+
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	if minute := now.Truncate(time.Minute); !minute.Equal(mw.minute) {
+		for i, w := range mw.windows {
+			mw.open[i] = w.cron.Active(now.In(w.location), w.duration)
+		}
+		mw.minute = minute
+	}
+	for i, w := range mw.windows {
+		if mw.open[i] && w.selector.Matches(resource.Labels) {
+			return w.name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,69 @@
+// Package fips verifies that the process runs with the Go FIPS 140-3
+// cryptographic module when Sentinel is configured for FIPS mode.
+//
+// With the module enabled, crypto/tls only negotiates FIPS-approved protocol
+// versions, cipher suites, key exchanges, and signature algorithms. This
+// applies process-wide, so it also covers the broker connections that are
+// managed by the hyperfleet-broker library. Connections without TLS and
+// signatures with hashes the module does not approve are outside of it;
+// CheckURL and CheckSignatureAlgorithm reject the settings that select them.
+package fips
+
+import (
+	"crypto/fips140"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrNotEnabled is returned by Check when the Go FIPS 140-3 module is not active.
+var ErrNotEnabled = errors.New(
+	"fips_mode requires the Go FIPS 140-3 module: build with GOFIPS140 (make build-fips) " +
+		"or run with GODEBUG=fips140=on")
+
+// Check returns ErrNotEnabled unless the Go FIPS 140-3 module is active.
+func Check() error {
+	return check(fips140.Enabled)
+}
+
+func check(enabled func() bool) error {
+	if !enabled() {
+		return ErrNotEnabled
+	}
+	return nil
+}
+
+// ModuleVersion returns the version of the active Go FIPS 140-3 module.
+func ModuleVersion() string {
+	return fips140.Version()
+}
+
+// tlsSchemes are the URL schemes of the connections Sentinel makes over TLS.
+var tlsSchemes = map[string]bool{"https": true, "amqps": true, "rediss": true}
+
+// CheckURL returns an error unless rawURL selects a connection over TLS, such
+// as https:// or amqps://.
+func CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if !tlsSchemes[strings.ToLower(u.Scheme)] {
+		return fmt.Errorf("scheme %q does not use TLS, which fips_mode requires", u.Scheme)
+	}
+	return nil
+}
+
+// approvedSignatureAlgorithms are the hashes of HMAC signatures the Go FIPS
+// 140-3 module approves. SHA-1 is not one of them.
+var approvedSignatureAlgorithms = map[string]bool{"sha256": true, "sha384": true, "sha512": true}
+
+// CheckSignatureAlgorithm returns an error unless algorithm, the name of the
+// hash of an HMAC signature such as "sha256", is FIPS-approved.
+func CheckSignatureAlgorithm(algorithm string) error {
+	if !approvedSignatureAlgorithms[algorithm] {
+		return fmt.Errorf("signature algorithm %q is not FIPS-approved", algorithm)
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+// Package leader elects one active Sentinel among several replicas with a
+// Kubernetes Lease (coordination.k8s.io/v1), following the protocol of the
+// Kubernetes controllers: the leader renews the lease every retry period and
+// steps down when it could not renew it for the renew deadline, and another
+// replica takes the lease over once it has not been renewed for the lease
+// duration. Expiry is measured on each replica's own clock from the moment it
+// saw the lease change, so clock skew between replicas does not matter.
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+// releaseTimeout bounds giving up the lease on shutdown.
+const releaseTimeout = 5 * time.Second
+
+// Elector runs a function only while this replica holds the lease.
+type Elector struct {
+	// observedTime is when the lease was last seen to change.
+	observedTime time.Time
+	client       *LeaseClient
+	log          logger.HyperFleetLogger
+	// onChange, when set, is called whenever the replica starts or stops
+	// leading.
+	onChange func(leading bool)
+	// observed is the lease as last read or written.
+	observed      *lease
+	now           func() time.Time
+	namespace     string
+	name          string
+	identity      string
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+	leading       atomic.Bool
+}
+
+// NewElector returns an Elector for the lease configured by cfg. The
+// namespace defaults to the namespace of client's service account and the
+// identity to the host name.
+func NewElector(cfg *config.LeaderElectionConfig, client *LeaseClient, log logger.HyperFleetLogger) (*Elector, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("leader_election config is required")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid leader_election config: %w", err)
+	}
+
+	e := &Elector{
+		client:    client,
+		log:       log,
+		now:       time.Now,
+		namespace: cfg.Namespace,
+		name:      cfg.Name,
+		identity:  cfg.Identity,
+	}
+	e.leaseDuration, e.renewDeadline, e.retryPeriod = cfg.Timing()
+	if e.namespace == "" {
+		e.namespace = client.namespace
+	}
+	if e.namespace == "" {
+		return nil, fmt.Errorf("leader_election.namespace is required outside a Kubernetes pod")
+	}
+	if e.identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("leader_election.identity is required: %w", err)
+		}
+		e.identity = hostname
+	}
+	return e, nil
+}
+
+// Identity returns the identity this replica holds the lease with.
+func (e *Elector) Identity() string {
+	return e.identity
+}
+
+// Lease returns the lease as namespace/name.
+func (e *Elector) Lease() string {
+	return e.namespace + "/" + e.name
+}
+
+// OnChange sets a function called whenever the replica starts or stops
+// leading. It must be set before Run.
+func (e *Elector) OnChange(fn func(leading bool)) {
+	e.onChange = fn
+}
+
+// IsLeader reports whether this replica holds the lease.
+func (e *Elector) IsLeader() bool {
+	return e.leading.Load()
+}
+
+// Run waits for the lease and runs lead while holding it. When the lease
+// cannot be renewed, lead's context is canceled, and once lead returns the
+// replica waits for the lease again. Run returns when ctx is done, giving up
+// the lease so that another replica takes over without waiting for it to
+// expire, or with lead's error if lead returns one while still leading.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context) error) error {
+	defer e.release()
+	for {
+		if !e.acquire(ctx) {
+			return nil
+		}
+		e.setLeading(true)
+		e.log.Infof(ctx, "Acquired leader lease lease=%s identity=%s", e.Lease(), e.identity)
+
+		leadCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		go func() { done <- lead(leadCtx) }()
+
+		stopped := e.renew(leadCtx, done)
+		cancel()
+		err := <-done
+		if stopped {
+			// lead returned on its own.
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		e.setLeading(false)
+		e.log.Warnf(ctx, "Lost leader lease; stopped polling lease=%s identity=%s", e.Lease(), e.identity)
+	}
+}
+
+// acquire tries to take the lease every retry period until it succeeds or
+// ctx is done.
+func (e *Elector) acquire(ctx context.Context) bool {
+	ticker := time.NewTicker(e.retryPeriod)
+	defer ticker.Stop()
+	for {
+		acquired, err := e.tryAcquireOrRenew(ctx)
+		// A conflict means another replica wrote the lease first.
+		if err != nil && !errors.Is(err, errLeaseConflict) && ctx.Err() == nil {
+			e.log.Warnf(ctx, "Failed to acquire leader lease lease=%s error=%v", e.Lease(), err)
+		}
+		if acquired {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// renew renews the lease every retry period until ctx is done, the lease
+// could not be renewed for the renew deadline, or lead returns. It reports
+// whether lead returned; the error lead returned is put back on done.
+func (e *Elector) renew(ctx context.Context, done chan error) bool {
+	ticker := time.NewTicker(e.retryPeriod)
+	defer ticker.Stop()
+	lastRenew := e.now()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case err := <-done:
+			done <- err
+			return true
+		case <-ticker.C:
+		}
+		renewed, err := e.tryAcquireOrRenew(ctx)
+		if renewed {
+			lastRenew = e.now()
+			continue
+		}
+		if err != nil && ctx.Err() == nil {
+			e.log.Warnf(ctx, "Failed to renew leader lease lease=%s error=%v", e.Lease(), err)
+		}
+		// A failed write, including a conflict on a stale resourceVersion, is
+		// retried until the deadline; only a lease read with another holder
+		// ends leadership early.
+		if e.now().Sub(lastRenew) >= e.renewDeadline || (err == nil && ctx.Err() == nil) {
+			// The deadline passed, or another replica holds the lease.
+			return false
+		}
+	}
+}
+
+// tryAcquireOrRenew takes the lease if it is free, expired, or already held
+// by this replica, and reports whether this replica holds it afterwards. It
+// returns false without an error only when another replica holds the lease,
+// and an error wrapping errLeaseConflict when the write lost a race, after
+// which the lease must be read again to know who holds it.
+func (e *Elector) tryAcquireOrRenew(ctx context.Context) (bool, error) {
+	now := e.now()
+	current, err := e.client.get(ctx, e.namespace, e.name)
+	if errors.Is(err, errLeaseNotFound) {
+		l := &lease{
+			APIVersion: "coordination.k8s.io/v1",
+			Kind:       "Lease",
+			Metadata:   leaseMetadata{Name: e.name, Namespace: e.namespace},
+			Spec:       e.spec(now, now, 0),
+		}
+		created, err := e.client.create(ctx, l)
+		if err != nil {
+			return false, err
+		}
+		e.observe(created, now)
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if e.observed == nil || !sameRecord(e.observed.Spec, current.Spec) {
+		e.observe(current, now)
+	}
+	holder := deref(current.Spec.HolderIdentity)
+	if holder != "" && holder != e.identity && now.Before(e.observedTime.Add(e.expiry(current))) {
+		return false, nil
+	}
+
+	acquireTime, transitions := now, deref(current.Spec.LeaseTransitions)
+	if holder == e.identity {
+		if t, err := time.Parse(microTimeFormat, deref(current.Spec.AcquireTime)); err == nil {
+			acquireTime = t
+		}
+	} else {
+		transitions++
+	}
+	current.Spec = e.spec(acquireTime, now, transitions)
+	updated, err := e.client.update(ctx, current)
+	if err != nil {
+		return false, err
+	}
+	e.observe(updated, now)
+	return true, nil
+}
+
+// release gives up the lease if this replica holds it, so that another
+// replica takes over right away.
+func (e *Elector) release() {
+	if !e.IsLeader() {
+		return
+	}
+	e.setLeading(false)
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	current, err := e.client.get(ctx, e.namespace, e.name)
+	if err == nil && deref(current.Spec.HolderIdentity) == e.identity {
+		holder, duration, renewTime := "", int32(1), e.now().UTC().Format(microTimeFormat)
+		current.Spec.HolderIdentity = &holder
+		current.Spec.LeaseDurationSeconds = &duration
+		current.Spec.RenewTime = &renewTime
+		_, err = e.client.update(ctx, current)
+	}
+	if err != nil {
+		e.log.Warnf(ctx, "Failed to release leader lease lease=%s error=%v", e.Lease(), err)
+		return
+	}
+	e.log.Infof(ctx, "Released leader lease lease=%s identity=%s", e.Lease(), e.identity)
+}
+
+// spec returns the lease spec of this replica holding the lease.
+func (e *Elector) spec(acquireTime, renewTime time.Time, transitions int32) leaseSpec {
+	identity := e.identity
+	acquired := acquireTime.UTC().Format(microTimeFormat)
+	renewed := renewTime.UTC().Format(microTimeFormat)
+	duration := int32(e.leaseDuration / time.Second)
+	return leaseSpec{
+		HolderIdentity:       &identity,
+		AcquireTime:          &acquired,
+		RenewTime:            &renewed,
+		LeaseDurationSeconds: &duration,
+		LeaseTransitions:     &transitions,
+	}
+}
+
+// expiry returns how long after its last change l expires: its own lease
+// duration, or this replica's when l has none.
+func (e *Elector) expiry(l *lease) time.Duration {
+	if d := deref(l.Spec.LeaseDurationSeconds); d > 0 {
+		return time.Duration(d) * time.Second
+	}
+	return e.leaseDuration
+}
+
+func (e *Elector) observe(l *lease, now time.Time) {
+	e.observed = l
+	e.observedTime = now
+}
+
+func (e *Elector) setLeading(leading bool) {
+	if e.leading.Swap(leading) != leading && e.onChange != nil {
+		e.onChange(leading)
+	}
+}
+
+// sameRecord reports whether two lease specs name the same holder and renew
+// time, i.e. whether the lease was not renewed in between.
+func sameRecord(a, b leaseSpec) bool {
+	return deref(a.HolderIdentity) == deref(b.HolderIdentity) && deref(a.RenewTime) == deref(b.RenewTime)
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,430 @@
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+const (
+	testNamespace = "hyperfleet"
+	testLeaseName = "sentinel-clusters"
+)
+
+// leaseServer is a Kubernetes API stand-in that stores a single Lease and
+// rejects writes with a stale resourceVersion, as the API server does.
+type leaseServer struct {
+	lease    *lease
+	requests []string
+	mu       sync.Mutex
+	version  int
+	// conflicts is the number of updates still to reject with 409, as if
+	// the lease had changed concurrently.
+	conflicts int
+}
+
+func newLeaseServer(t *testing.T) (*leaseServer, *LeaseClient) {
+	t.Helper()
+	ls := &leaseServer{}
+	server := httptest.NewServer(http.HandlerFunc(ls.serve))
+	t.Cleanup(server.Close)
+	token := func() (string, error) { return "test-token", nil }
+	return ls, NewLeaseClient(server.URL+"/", server.Client(), token)
+}
+
+func (ls *leaseServer) serve(w http.ResponseWriter, r *http.Request) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.requests = append(ls.requests, r.Method+" "+r.URL.Path)
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	leasesPath := "/apis/coordination.k8s.io/v1/namespaces/" + testNamespace + "/leases"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == leasesPath+"/"+testLeaseName:
+		if ls.lease == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	case r.Method == http.MethodPost && r.URL.Path == leasesPath:
+		if ls.lease != nil {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		ls.store(w, r)
+	case r.Method == http.MethodPut && r.URL.Path == leasesPath+"/"+testLeaseName:
+		var l lease
+		if err := json.NewDecoder(r.Body).Decode(&l); err != nil || ls.lease == nil ||
+			l.Metadata.ResourceVersion != ls.lease.Metadata.ResourceVersion || ls.conflicts > 0 {
+			ls.conflicts = max(ls.conflicts-1, 0)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		ls.lease = &l
+		ls.bump()
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(ls.lease)
+}
+
+func (ls *leaseServer) store(w http.ResponseWriter, r *http.Request) {
+	var l lease
+	if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	ls.lease = &l
+	ls.bump()
+}
+
+func (ls *leaseServer) bump() {
+	ls.version++
+	ls.lease.Metadata.ResourceVersion = strconv.Itoa(ls.version)
+}
+
+func (ls *leaseServer) holder() string {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	if ls.lease == nil {
+		return ""
+	}
+	return deref(ls.lease.Spec.HolderIdentity)
+}
+
+func (ls *leaseServer) transitions() int32 {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return deref(ls.lease.Spec.LeaseTransitions)
+}
+
+// fakeClock is a settable clock for the electors of a test.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestElector(t *testing.T, client *LeaseClient, identity string, clock *fakeClock) *Elector {
+	t.Helper()
+	e, err := NewElector(&config.LeaderElectionConfig{
+		Name:      testLeaseName,
+		Namespace: testNamespace,
+		Identity:  identity,
+	}, client, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewElector failed: %v", err)
+	}
+	if clock != nil {
+		e.now = clock.Now
+	}
+	return e
+}
+
+func TestElector_AcquiresFreeLease(t *testing.T) {
+	ls, client := newLeaseServer(t)
+	e := newTestElector(t, client, "sentinel-0", nil)
+
+	acquired, err := e.tryAcquireOrRenew(context.Background())
+	if err != nil {
+		t.Fatalf("tryAcquireOrRenew failed: %v", err)
+	}
+	if !acquired {
+		t.Fatal("expected to acquire a free lease")
+	}
+	if got := ls.holder(); got != "sentinel-0" {
+		t.Errorf("holder = %q, want sentinel-0", got)
+	}
+	if got := deref(ls.lease.Spec.LeaseDurationSeconds); got != int32(config.DefaultLeaseDuration/time.Second) {
+		t.Errorf("leaseDurationSeconds = %d, want %d", got, int32(config.DefaultLeaseDuration/time.Second))
+	}
+}
+
+func TestElector_RenewKeepsAcquireTime(t *testing.T) {
+	ls, client := newLeaseServer(t)
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	e := newTestElector(t, client, "sentinel-0", clock)
+
+	if acquired, err := e.tryAcquireOrRenew(context.Background()); err != nil || !acquired {
+		t.Fatalf("tryAcquireOrRenew = %v, %v; want true, nil", acquired, err)
+	}
+	acquireTime := deref(ls.lease.Spec.AcquireTime)
+
+	clock.now = clock.now.Add(2 * time.Second)
+	if renewed, err := e.tryAcquireOrRenew(context.Background()); err != nil || !renewed {
+		t.Fatalf("tryAcquireOrRenew = %v, %v; want true, nil", renewed, err)
+	}
+	if got := deref(ls.lease.Spec.AcquireTime); got != acquireTime {
+		t.Errorf("acquireTime = %s, want %s", got, acquireTime)
+	}
+	if got, want := deref(ls.lease.Spec.RenewTime), clock.now.Format(microTimeFormat); got != want {
+		t.Errorf("renewTime = %s, want %s", got, want)
+	}
+	if got := ls.transitions(); got != 0 {
+		t.Errorf("leaseTransitions = %d, want 0", got)
+	}
+}
+
+func TestElector_TakesOverExpiredLease(t *testing.T) {
+	ls, client := newLeaseServer(t)
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	leader := newTestElector(t, client, "sentinel-0", clock)
+	follower := newTestElector(t, client, "sentinel-1", clock)
+
+	if acquired, err := leader.tryAcquireOrRenew(context.Background()); err != nil || !acquired {
+		t.Fatalf("leader tryAcquireOrRenew = %v, %v; want true, nil", acquired, err)
+	}
+	if acquired, err := follower.tryAcquireOrRenew(context.Background()); err != nil || acquired {
+		t.Fatalf("follower tryAcquireOrRenew = %v, %v; want false, nil", acquired, err)
+	}
+
+	// Not expired yet: the follower measures from when it first saw the lease.
+	clock.now = clock.now.Add(config.DefaultLeaseDuration - time.Second)
+	if acquired, _ := follower.tryAcquireOrRenew(context.Background()); acquired {
+		t.Fatal("follower acquired a lease that has not expired")
+	}
+
+	clock.now = clock.now.Add(2 * time.Second)
+	if acquired, err := follower.tryAcquireOrRenew(context.Background()); err != nil || !acquired {
+		t.Fatalf("follower tryAcquireOrRenew = %v, %v; want true, nil", acquired, err)
+	}
+	if got := ls.holder(); got != "sentinel-1" {
+		t.Errorf("holder = %q, want sentinel-1", got)
+	}
+	if got := ls.transitions(); got != 1 {
+		t.Errorf("leaseTransitions = %d, want 1", got)
+	}
+
+	// The old leader sees the new holder and does not take the lease back.
+	if renewed, err := leader.tryAcquireOrRenew(context.Background()); err != nil || renewed {
+		t.Fatalf("old leader tryAcquireOrRenew = %v, %v; want false, nil", renewed, err)
+	}
+}
+
+func TestElector_RenewedLeaseDoesNotExpire(t *testing.T) {
+	_, client := newLeaseServer(t)
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	leader := newTestElector(t, client, "sentinel-0", clock)
+	follower := newTestElector(t, client, "sentinel-1", clock)
+
+	if acquired, _ := leader.tryAcquireOrRenew(context.Background()); !acquired {
+		t.Fatal("leader did not acquire the lease")
+	}
+	for range 10 {
+		if acquired, _ := follower.tryAcquireOrRenew(context.Background()); acquired {
+			t.Fatal("follower acquired a lease that is being renewed")
+		}
+		clock.now = clock.now.Add(config.DefaultRetryPeriod)
+		if renewed, _ := leader.tryAcquireOrRenew(context.Background()); !renewed {
+			t.Fatal("leader failed to renew the lease")
+		}
+	}
+}
+
+func TestElector_RunReleasesLeaseOnShutdown(t *testing.T) {
+	ls, client := newLeaseServer(t)
+	e, err := NewElector(&config.LeaderElectionConfig{
+		Name:          testLeaseName,
+		Namespace:     testNamespace,
+		Identity:      "sentinel-0",
+		LeaseDuration: time.Second,
+		RenewDeadline: 500 * time.Millisecond,
+		RetryPeriod:   50 * time.Millisecond,
+	}, client, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewElector failed: %v", err)
+	}
+	var changes []bool
+	e.OnChange(func(leading bool) { changes = append(changes, leading) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leading := make(chan struct{})
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- e.Run(ctx, func(leadCtx context.Context) error {
+			close(leading)
+			<-leadCtx.Done()
+			return leadCtx.Err()
+		})
+	}()
+
+	select {
+	case <-leading:
+	case <-time.After(5 * time.Second):
+		t.Fatal("elector did not start leading")
+	}
+	if !e.IsLeader() {
+		t.Error("IsLeader = false while leading")
+	}
+	cancel()
+	if err := <-runErr; err != nil {
+		t.Fatalf("Run returned %v, want nil", err)
+	}
+
+	if e.IsLeader() {
+		t.Error("IsLeader = true after Run returned")
+	}
+	if got := ls.holder(); got != "" {
+		t.Errorf("holder after release = %q, want empty", got)
+	}
+	if len(changes) != 2 || !changes[0] || changes[1] {
+		t.Errorf("OnChange calls = %v, want [true false]", changes)
+	}
+}
+
+func TestElector_RunStopsLeadingWhenLeaseIsLost(t *testing.T) {
+	ls, client := newLeaseServer(t)
+	e, err := NewElector(&config.LeaderElectionConfig{
+		Name:          testLeaseName,
+		Namespace:     testNamespace,
+		Identity:      "sentinel-0",
+		LeaseDuration: time.Hour,
+		RenewDeadline: 30 * time.Minute,
+		RetryPeriod:   20 * time.Millisecond,
+	}, client, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewElector failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leading := make(chan struct{}, 1)
+	stopped := make(chan struct{}, 1)
+	go func() {
+		_ = e.Run(ctx, func(leadCtx context.Context) error {
+			leading <- struct{}{}
+			<-leadCtx.Done()
+			stopped <- struct{}{}
+			return nil
+		})
+	}()
+	select {
+	case <-leading:
+	case <-time.After(5 * time.Second):
+		t.Fatal("elector did not start leading")
+	}
+
+	// Another replica takes the lease, e.g. after this one was partitioned.
+	ls.mu.Lock()
+	other := "sentinel-1"
+	ls.lease.Spec.HolderIdentity = &other
+	ls.bump()
+	ls.mu.Unlock()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("lead was not canceled after the lease was lost")
+	}
+	if got := ls.holder(); got != "sentinel-1" {
+		t.Errorf("holder = %q, want sentinel-1", got)
+	}
+}
+
+func TestElector_RunKeepsLeadingAfterRenewConflict(t *testing.T) {
+	ls, client := newLeaseServer(t)
+	e, err := NewElector(&config.LeaderElectionConfig{
+		Name:          testLeaseName,
+		Namespace:     testNamespace,
+		Identity:      "sentinel-0",
+		LeaseDuration: time.Hour,
+		RenewDeadline: 30 * time.Minute,
+		RetryPeriod:   20 * time.Millisecond,
+	}, client, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewElector failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leading := make(chan struct{}, 1)
+	stopped := make(chan struct{}, 1)
+	go func() {
+		_ = e.Run(ctx, func(leadCtx context.Context) error {
+			leading <- struct{}{}
+			<-leadCtx.Done()
+			stopped <- struct{}{}
+			return nil
+		})
+	}()
+	select {
+	case <-leading:
+	case <-time.After(5 * time.Second):
+		t.Fatal("elector did not start leading")
+	}
+
+	// The next renew hits a conflict, e.g. on a stale resourceVersion, while
+	// this replica still holds the lease.
+	ls.mu.Lock()
+	ls.conflicts = 1
+	version := ls.version
+	ls.mu.Unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		ls.mu.Lock()
+		renewed := ls.conflicts == 0 && ls.version > version
+		ls.mu.Unlock()
+		if renewed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("lease was not renewed after the conflict")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case <-stopped:
+		t.Fatal("lead was canceled after a single renew conflict")
+	default:
+	}
+	if !e.IsLeader() {
+		t.Error("expected the replica to keep leading")
+	}
+	if got := ls.holder(); got != "sentinel-0" {
+		t.Errorf("holder = %q, want sentinel-0", got)
+	}
+}
+
+func TestNewElector_Defaults(t *testing.T) {
+	client := NewLeaseClient("https://127.0.0.1:6443", http.DefaultClient, nil)
+
+	if _, err := NewElector(&config.LeaderElectionConfig{Name: testLeaseName}, client,
+		logger.NewHyperFleetLogger()); err == nil {
+		t.Error("expected an error without a namespace outside a pod")
+	}
+
+	client.namespace = testNamespace
+	e, err := NewElector(&config.LeaderElectionConfig{Name: testLeaseName}, client, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewElector failed: %v", err)
+	}
+	if got := e.Lease(); got != testNamespace+"/"+testLeaseName {
+		t.Errorf("Lease = %q, want %s/%s", got, testNamespace, testLeaseName)
+	}
+	hostname, _ := os.Hostname()
+	if got := e.Identity(); got != hostname {
+		t.Errorf("Identity = %q, want host name %q", got, hostname)
+	}
+	if e.leaseDuration != config.DefaultLeaseDuration || e.renewDeadline != config.DefaultRenewDeadline ||
+		e.retryPeriod != config.DefaultRetryPeriod {
+		t.Errorf("timing = %s/%s/%s, want defaults", e.leaseDuration, e.renewDeadline, e.retryPeriod)
+	}
+
+	if _, err := NewElector(&config.LeaderElectionConfig{}, client, logger.NewHyperFleetLogger()); err == nil {
+		t.Error("expected an error without a lease name")
+	}
+}
@@ -0,0 +1,188 @@
+package leader
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Files the kubelet mounts into every pod with a service account token.
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	tokenFile         = serviceAccountDir + "/token"
+	caFile            = serviceAccountDir + "/ca.crt"
+	namespaceFile     = serviceAccountDir + "/namespace"
+)
+
+// requestTimeout bounds a single Lease API request.
+const requestTimeout = 10 * time.Second
+
+// maxLeaseSize bounds the body read from the Kubernetes API.
+const maxLeaseSize = 1 << 20
+
+// microTimeFormat is the format of Kubernetes MicroTime fields.
+const microTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+var (
+	errLeaseNotFound = errors.New("lease not found")
+	// errLeaseConflict is returned when the lease was changed since it was
+	// read, typically by another replica.
+	errLeaseConflict = errors.New("lease changed concurrently")
+)
+
+// lease is the part of a coordination.k8s.io/v1 Lease the elector uses.
+type lease struct {
+	Spec       leaseSpec     `json:"spec"`
+	Metadata   leaseMetadata `json:"metadata"`
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+}
+
+type leaseMetadata struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type leaseSpec struct {
+	HolderIdentity       *string `json:"holderIdentity,omitempty"`
+	AcquireTime          *string `json:"acquireTime,omitempty"`
+	RenewTime            *string `json:"renewTime,omitempty"`
+	LeaseDurationSeconds *int32  `json:"leaseDurationSeconds,omitempty"`
+	LeaseTransitions     *int32  `json:"leaseTransitions,omitempty"`
+}
+
+// LeaseClient reads and writes Leases through the Kubernetes API.
+type LeaseClient struct {
+	client *http.Client
+	token  func() (string, error)
+	// baseURL is the API server URL, without a trailing slash.
+	baseURL string
+	// namespace is the namespace of the pod's service account, if known.
+	namespace string
+}
+
+// NewLeaseClient returns a LeaseClient for the API server at baseURL. token,
+// when not nil, returns the bearer token sent with each request.
+func NewLeaseClient(baseURL string, httpClient *http.Client, token func() (string, error)) *LeaseClient {
+	return &LeaseClient{client: httpClient, token: token, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// NewInClusterLeaseClient returns a LeaseClient for the API server of the
+// cluster the pod runs in, authenticated with the pod's service account. The
+// token is read on every request, so projected tokens rotate transparently.
+func NewInClusterLeaseClient() (*LeaseClient, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running in a Kubernetes pod: KUBERNETES_SERVICE_HOST or KUBERNETES_SERVICE_PORT " +
+			"is not set")
+	}
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading service account CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+
+	token := func() (string, error) {
+		token, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("reading service account token: %w", err)
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+	c := NewLeaseClient("https://"+net.JoinHostPort(host, port), &http.Client{Transport: transport}, token)
+	if namespace, err := os.ReadFile(namespaceFile); err == nil {
+		c.namespace = strings.TrimSpace(string(namespace))
+	}
+	return c, nil
+}
+
+// get returns the lease, or errLeaseNotFound.
+func (c *LeaseClient) get(ctx context.Context, namespace, name string) (*lease, error) {
+	return c.do(ctx, http.MethodGet, c.leaseURL(namespace, name), nil)
+}
+
+// create creates l.
+func (c *LeaseClient) create(ctx context.Context, l *lease) (*lease, error) {
+	return c.do(ctx, http.MethodPost, c.leasesURL(l.Metadata.Namespace), l)
+}
+
+// update replaces l, or returns errLeaseConflict if it changed since it was
+// read.
+func (c *LeaseClient) update(ctx context.Context, l *lease) (*lease, error) {
+	return c.do(ctx, http.MethodPut, c.leaseURL(l.Metadata.Namespace, l.Metadata.Name), l)
+}
+
+func (c *LeaseClient) leasesURL(namespace string) string {
+	return c.baseURL + "/apis/coordination.k8s.io/v1/namespaces/" + url.PathEscape(namespace) + "/leases"
+}
+
+func (c *LeaseClient) leaseURL(namespace, name string) string {
+	return c.leasesURL(namespace) + "/" + url.PathEscape(name)
+}
+
+// do sends a Lease request and decodes the Lease in the response.
+func (c *LeaseClient) do(ctx context.Context, method, target string, body *lease) (*lease, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encoding lease: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("creating lease request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != nil {
+		token, err := c.token()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s lease: %w", strings.ToLower(method), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errLeaseNotFound
+	case resp.StatusCode == http.StatusConflict:
+		return nil, errLeaseConflict
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("%s lease %s: unexpected status %s", strings.ToLower(method), target, resp.Status)
+	}
+
+	var out lease
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxLeaseSize)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decoding lease: %w", err)
+	}
+	return &out, nil
+}
@@ -28,6 +28,10 @@ const (
 	metricsStatusLabel           = "status"
 	metricsComponentLabel        = "component"
 	metricsVersionLabel          = "version"
+	metricsResultLabel           = "result"
+	metricsMethodLabel           = "method"
+	metricsOutcomeLabel          = "outcome"
+	metricsTargetLabel           = "target"
 )
 
 // componentName is the value used for the "component" standard label
@@ -53,6 +57,51 @@ var MetricsLabelsWithErrorType = []string{
 	metricsErrorTypeLabel,
 }
 
+// MetricsLabelsWithResult - Array of labels for cache lookup metrics
+var MetricsLabelsWithResult = []string{
+	metricsResourceTypeLabel,
+	metricsResourceSelectorLabel,
+	metricsResultLabel,
+}
+
+// MetricsLabelsWithRequest - Array of labels for HyperFleet API request metrics
+var MetricsLabelsWithRequest = []string{
+	metricsResourceTypeLabel,
+	metricsResourceSelectorLabel,
+	metricsMethodLabel,
+	metricsStatusLabel,
+}
+
+// MetricsLabelsWithStatus - Array of labels for metrics by HTTP status code
+var MetricsLabelsWithStatus = []string{
+	metricsResourceTypeLabel,
+	metricsResourceSelectorLabel,
+	metricsStatusLabel,
+}
+
+// MetricsLabelsWithOutcome - Array of labels for decision metrics by outcome and reason
+var MetricsLabelsWithOutcome = []string{
+	metricsResourceTypeLabel,
+	metricsResourceSelectorLabel,
+	metricsOutcomeLabel,
+	metricsReasonLabel,
+}
+
+// MetricsLabelsWithTarget - Array of labels for fan-out publish metrics by target and result
+var MetricsLabelsWithTarget = []string{
+	metricsResourceTypeLabel,
+	metricsResourceSelectorLabel,
+	metricsTargetLabel,
+	metricsResultLabel,
+}
+
+// Decision outcomes reported by decisions_total
+const (
+	DecisionOutcomePublish = "publish"
+	DecisionOutcomeSkip    = "skip"
+	DecisionOutcomeFailed  = "failed"
+)
+
 // Names of the metrics
 const (
 	pendingResourcesMetric            = "pending_resources"
@@ -62,6 +111,39 @@ const (
 	apiErrorsMetric                   = "api_errors_total"
 	brokerErrorsMetric                = "broker_errors_total"
 	lastSuccessfulPollTimestampMetric = "last_successful_poll_timestamp_seconds"
+	apiCircuitBreakerStateMetric      = "api_circuit_breaker_state"
+	brokerAuthErrorsMetric            = "broker_auth_errors_total"
+	suspendedResourcesMetric          = "suspended_resources"
+	evaluationCacheLookupsMetric      = "evaluation_cache_lookups_total"
+	apiRequestDurationMetric          = "api_request_duration_seconds"
+	apiRequestRetriesMetric           = "api_request_retries_total"
+	apiRetryAfterMetric               = "api_retry_after_seconds"
+	reconcileLatencyMetric            = "reconcile_latency_seconds"
+	fleetSizeTotalMetric              = "fleet_size_total"
+	fleetSizeFetchedMetric            = "fleet_size_fetched"
+	publishesSuppressedMetric         = "publishes_suppressed_total"
+	deferredResourcesMetric           = "deferred_resources"
+	terminalResourcesMetric           = "terminal_resources"
+	clockSkewMetric                   = "clock_skew_total"
+	decisionDurationMetric            = "decision_duration_seconds"
+	decisionsMetric                   = "decisions_total"
+	policyErrorsMetric                = "decision_policy_errors_total"
+	flappingResourcesMetric           = "flapping_resources"
+	cyclesSkippedMetric               = "cycles_skipped_total"
+	pollIntervalMetric                = "poll_interval_seconds"
+	eventsDryRunMetric                = "events_dry_run_total"
+	stateStoreErrorsMetric            = "state_store_errors_total"
+	cycleTimeoutsMetric               = "cycle_timeouts_total"
+	brokerBackpressureActiveMetric    = "broker_backpressure_active"
+	outboxEventsMetric                = "outbox_events_total"
+	outboxSizeMetric                  = "outbox_size"
+	deadLetteredEventsMetric          = "dead_lettered_events_total"
+	fanOutPublishesMetric             = "fanout_publishes_total"
+	schemaViolationsMetric            = "schema_violations_total"
+	publishBatchSizeMetric            = "publish_batch_size"
+	unconfirmedEventsMetric           = "unconfirmed_events"
+	publishConfirmsMetric             = "publish_confirms_total"
+	configReloadsMetric               = "config_reloads_total"
 )
 
 // MetricsNames - Array of names of the metrics
@@ -73,6 +155,39 @@ var MetricsNames = []string{
 	apiErrorsMetric,
 	brokerErrorsMetric,
 	lastSuccessfulPollTimestampMetric,
+	apiCircuitBreakerStateMetric,
+	brokerAuthErrorsMetric,
+	suspendedResourcesMetric,
+	evaluationCacheLookupsMetric,
+	apiRequestDurationMetric,
+	apiRequestRetriesMetric,
+	apiRetryAfterMetric,
+	reconcileLatencyMetric,
+	fleetSizeTotalMetric,
+	fleetSizeFetchedMetric,
+	publishesSuppressedMetric,
+	deferredResourcesMetric,
+	terminalResourcesMetric,
+	clockSkewMetric,
+	decisionDurationMetric,
+	decisionsMetric,
+	policyErrorsMetric,
+	flappingResourcesMetric,
+	cyclesSkippedMetric,
+	pollIntervalMetric,
+	eventsDryRunMetric,
+	stateStoreErrorsMetric,
+	cycleTimeoutsMetric,
+	brokerBackpressureActiveMetric,
+	outboxEventsMetric,
+	outboxSizeMetric,
+	deadLetteredEventsMetric,
+	fanOutPublishesMetric,
+	schemaViolationsMetric,
+	publishBatchSizeMetric,
+	unconfirmedEventsMetric,
+	publishConfirmsMetric,
+	configReloadsMetric,
 }
 
 // Package-level metric collectors, initialized by NewSentinelMetrics with ConstLabels
@@ -84,6 +199,39 @@ var (
 	apiErrorsCounter                 *prometheus.CounterVec
 	brokerErrorsCounter              *prometheus.CounterVec
 	lastSuccessfulPollTimestampGauge prometheus.Gauge
+	apiCircuitBreakerStateGauge      *prometheus.GaugeVec
+	brokerAuthErrorsCounter          *prometheus.CounterVec
+	suspendedResourcesGauge          *prometheus.GaugeVec
+	evaluationCacheLookupsCounter    *prometheus.CounterVec
+	apiRequestDurationHistogram      *prometheus.HistogramVec
+	apiRequestRetriesCounter         *prometheus.CounterVec
+	apiRetryAfterHistogram           *prometheus.HistogramVec
+	reconcileLatencyHistogram        *prometheus.HistogramVec
+	fleetSizeTotalGauge              *prometheus.GaugeVec
+	fleetSizeFetchedGauge            *prometheus.GaugeVec
+	publishesSuppressedCounter       *prometheus.CounterVec
+	deferredResourcesGauge           *prometheus.GaugeVec
+	terminalResourcesGauge           *prometheus.GaugeVec
+	clockSkewCounter                 *prometheus.CounterVec
+	decisionDurationHistogram        *prometheus.HistogramVec
+	decisionsCounter                 *prometheus.CounterVec
+	policyErrorsCounter              *prometheus.CounterVec
+	flappingResourcesGauge           *prometheus.GaugeVec
+	cyclesSkippedCounter             *prometheus.CounterVec
+	pollIntervalGauge                *prometheus.GaugeVec
+	eventsDryRunCounter              *prometheus.CounterVec
+	stateStoreErrorsCounter          *prometheus.CounterVec
+	cycleTimeoutsCounter             *prometheus.CounterVec
+	brokerBackpressureActiveGauge    *prometheus.GaugeVec
+	outboxEventsCounter              *prometheus.CounterVec
+	outboxSizeGauge                  *prometheus.GaugeVec
+	deadLetteredEventsCounter        *prometheus.CounterVec
+	fanOutPublishesCounter           *prometheus.CounterVec
+	schemaViolationsCounter          *prometheus.CounterVec
+	publishBatchSizeHistogram        *prometheus.HistogramVec
+	unconfirmedEventsGauge           *prometheus.GaugeVec
+	publishConfirmsCounter           *prometheus.CounterVec
+	configReloadsCounter             *prometheus.CounterVec
 )
 
 // SentinelMetrics holds all Prometheus metrics for the Sentinel service
@@ -108,6 +256,108 @@ type SentinelMetrics struct {
 
 	// Act as a dead man's switch for alerting on a fully stuck Sentinel.
 	LastSuccessfulPollTimestamp prometheus.Gauge
+
+	// APICircuitBreakerState tracks the HyperFleet API circuit breaker state
+	APICircuitBreakerState *prometheus.GaugeVec
+
+	// BrokerAuthErrors tracks publishes rejected by the broker for lack of authorization
+	BrokerAuthErrors *prometheus.CounterVec
+
+	// SuspendedResources tracks resources paused by the pause or maintenance
+	// label or a maintenance window
+	SuspendedResources *prometheus.GaugeVec
+
+	// EvaluationCacheLookups tracks hits and misses of the per-resource evaluation cache
+	EvaluationCacheLookups *prometheus.CounterVec
+
+	// APIRequestDuration tracks the latency of individual HyperFleet API HTTP requests
+	APIRequestDuration *prometheus.HistogramVec
+
+	// APIRequestRetries tracks HyperFleet API HTTP requests that were retries of a failed attempt
+	APIRequestRetries *prometheus.CounterVec
+
+	// APIRetryAfter tracks the delays requested by the HyperFleet API in Retry-After headers
+	APIRetryAfter *prometheus.HistogramVec
+
+	// ReconcileLatency tracks the time from publishing a generation-mismatch event until the
+	// resource's observedGeneration catches up
+	ReconcileLatency *prometheus.HistogramVec
+
+	// FleetSizeTotal tracks the number of resources matching the resource selector as
+	// reported by the HyperFleet API
+	FleetSizeTotal *prometheus.GaugeVec
+
+	// FleetSizeFetched tracks the number of resources fetched by the last full list
+	FleetSizeFetched *prometheus.GaugeVec
+
+	// PublishesSuppressed tracks publishes held back by the per-resource republish backoff
+	PublishesSuppressed *prometheus.CounterVec
+
+	// DeferredResources tracks resources deferred to the next cycle by the publish rate limit
+	DeferredResources *prometheus.GaugeVec
+
+	// TerminalResources tracks resources skipped because they are in a terminal phase
+	TerminalResources *prometheus.GaugeVec
+
+	// ClockSkew tracks evaluations that found a condition updated beyond the clock skew tolerance
+	ClockSkew *prometheus.CounterVec
+
+	// DecisionDuration tracks how long the decider takes to evaluate a resource
+	DecisionDuration *prometheus.HistogramVec
+
+	// Decisions tracks decider evaluations by outcome and reason
+	Decisions *prometheus.CounterVec
+
+	// PolicyErrors tracks decision_policy queries that failed
+	PolicyErrors *prometheus.CounterVec
+
+	// FlappingResources tracks resources whose condition flaps
+	FlappingResources *prometheus.GaugeVec
+
+	// CyclesSkipped tracks poll cycles skipped because the previous cycle was still running
+	CyclesSkipped *prometheus.CounterVec
+
+	// PollInterval tracks the current poll interval
+	PollInterval *prometheus.GaugeVec
+
+	// EventsDryRun tracks events that dry-run mode would have published
+	EventsDryRun *prometheus.CounterVec
+
+	// StateStoreErrors tracks failed reads and writes of the state store
+	StateStoreErrors *prometheus.CounterVec
+
+	// CycleTimeouts tracks poll cycles canceled at their deadline
+	CycleTimeouts *prometheus.CounterVec
+
+	// BrokerBackpressureActive tracks whether publishing is paused by broker backpressure
+	BrokerBackpressureActive *prometheus.GaugeVec
+
+	// OutboxEvents tracks events spooled to, replayed from, and dropped by the outbox
+	OutboxEvents *prometheus.CounterVec
+
+	// OutboxSize tracks the number of events waiting in the outbox
+	OutboxSize *prometheus.GaugeVec
+
+	// DeadLetteredEvents tracks events routed to the dead letter sink
+	DeadLetteredEvents *prometheus.CounterVec
+
+	// FanOutPublishes tracks publishes to each fan-out target by result
+	FanOutPublishes *prometheus.CounterVec
+
+	// SchemaViolations tracks events not published because their data does not match message_schema
+	SchemaViolations *prometheus.CounterVec
+
+	// PublishBatchSize tracks the number of events per batch flushed by clients.broker.batch
+	PublishBatchSize *prometheus.HistogramVec
+
+	// UnconfirmedEvents tracks events handed to the async publish pipeline and not yet confirmed
+	UnconfirmedEvents *prometheus.GaugeVec
+
+	// PublishConfirms tracks confirmed, re-queued, and dropped events of the async publish pipeline
+	PublishConfirms *prometheus.CounterVec
+
+	// ConfigReloads tracks configuration reloads by result
+	ConfigReloads *prometheus.CounterVec
 }
 
 var (
@@ -205,187 +455,1533 @@ func NewSentinelMetrics(registry prometheus.Registerer, version string) *Sentine
 			},
 		)
 
-		// Register all metrics
-		registry.MustRegister(pendingResourcesGauge)
-		registry.MustRegister(eventsPublishedCounter)
-		registry.MustRegister(resourcesSkippedCounter)
-		registry.MustRegister(pollDurationHistogram)
-		registry.MustRegister(apiErrorsCounter)
-		registry.MustRegister(brokerErrorsCounter)
-		registry.MustRegister(lastSuccessfulPollTimestampGauge)
+		apiCircuitBreakerStateGauge = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        apiCircuitBreakerStateMetric,
+				Help:        "State of the HyperFleet API circuit breaker (0=closed, 1=open, 2=half-open)",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
 
-		metricsInstance = &SentinelMetrics{
-			PendingResources:            pendingResourcesGauge,
-			EventsPublished:             eventsPublishedCounter,
-			ResourcesSkipped:            resourcesSkippedCounter,
-			PollDuration:                pollDurationHistogram,
-			APIErrors:                   apiErrorsCounter,
-			BrokerErrors:                brokerErrorsCounter,
-			LastSuccessfulPollTimestamp: lastSuccessfulPollTimestampGauge,
-		}
-	})
+		brokerAuthErrorsCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        brokerAuthErrorsMetric,
+				Help:        "Total number of publishes rejected by the message broker due to missing authorization",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
 
-	return metricsInstance
-}
+		suspendedResourcesGauge = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        suspendedResourcesMetric,
+				Help:        "Number of resources skipped because of the pause or maintenance label or a maintenance window",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
 
-// ResetSentinelMetrics resets all metric collectors to their initial state.
-//
-// This function is intended for testing purposes only. It resets the sync.Once
-// so that NewSentinelMetrics can be called again to reinitialize collectors,
-// and clears all metric values if collectors have been initialized.
-//
-// WARNING: Do not use in production code. This will clear operational metrics.
-//
-// Thread-safe: Safe to call concurrently, but should only be called from test code.
-func ResetSentinelMetrics() {
-	if pendingResourcesGauge != nil {
-		pendingResourcesGauge.Reset()
-	}
-	if eventsPublishedCounter != nil {
-		eventsPublishedCounter.Reset()
-	}
-	if resourcesSkippedCounter != nil {
-		resourcesSkippedCounter.Reset()
-	}
-	if pollDurationHistogram != nil {
-		pollDurationHistogram.Reset()
-	}
-	if apiErrorsCounter != nil {
-		apiErrorsCounter.Reset()
-	}
-	if brokerErrorsCounter != nil {
-		brokerErrorsCounter.Reset()
-	}
-	if lastSuccessfulPollTimestampGauge != nil {
-		lastSuccessfulPollTimestampGauge.Set(0)
-	}
-	registerOnce = sync.Once{}
-	metricsInstance = nil
-}
+		evaluationCacheLookupsCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        evaluationCacheLookupsMetric,
+				Help:        "Total number of evaluation cache lookups by result (hit skips the decision evaluation)",
+				ConstLabels: constLabels,
+			},
+			MetricsLabelsWithResult,
+		)
 
-// UpdatePendingResourcesMetric sets the current number of resources pending reconciliation.
-//
-// This gauge metric tracks resources that need reconciliation based on max age intervals
-// or generation mismatches. The count is set (not incremented) and represents the current
-// snapshot of pending resources.
-//
-// Parameters:
-//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
-//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
-//   - count: Number of pending resources (negative values are clamped to 0)
-//
-// Thread-safe: Can be called concurrently from multiple goroutines.
-//
-// Validation: Empty resourceType or resourceSelector trigger a warning and are ignored to prevent
-// cardinality issues. This should never happen in normal operation and indicates a bug.
-func UpdatePendingResourcesMetric(resourceType, resourceSelector string, count int) {
-	// Validate inputs
-	if resourceType == "" || resourceSelector == "" {
-		getLogger().Warnf(context.Background(),
-			"Attempted to update pending_resources metric with empty parameters: resourceType=%q resourceSelector=%q",
-			resourceType, resourceSelector)
-		return
-	}
-	if count < 0 {
-		count = 0
-	}
+		apiRequestDurationHistogram = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        apiRequestDurationMetric,
+				Help:        "Duration of HyperFleet API HTTP requests in seconds, by method and status code",
+				Buckets:     prometheus.DefBuckets,
+				ConstLabels: constLabels,
+			},
+			MetricsLabelsWithRequest,
+		)
 
-	labels := prometheus.Labels{
-		metricsResourceTypeLabel:     resourceType,
-		metricsResourceSelectorLabel: resourceSelector,
-	}
-	pendingResourcesGauge.With(labels).Set(float64(count))
-}
+		apiRequestRetriesCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        apiRequestRetriesMetric,
+				Help:        "Total number of HyperFleet API HTTP requests sent as a retry of a failed attempt",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
 
-// UpdateEventsPublishedMetric increments the counter of reconciliation events published to the broker.
-//
-// This counter tracks successful event publications, labeled by resource type, selector, and reason.
-// Common reasons include "max_age_exceeded" and "generation_mismatch".
-//
-// Parameters:
-//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
-//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
-//   - reason: Reason for publishing (e.g., "max_age_exceeded", "generation_mismatch")
-//
-// Thread-safe: Can be called concurrently from multiple goroutines.
-//
-// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
-// This should never happen in normal operation and indicates a bug.
-func UpdateEventsPublishedMetric(resourceType, resourceSelector, reason string) {
-	// Validate inputs
-	if resourceType == "" || resourceSelector == "" || reason == "" {
-		getLogger().Warnf(context.Background(),
-			"Attempted to update events_published metric with empty parameters: resourceType=%q resourceSelector=%q reason=%q",
-			resourceType, resourceSelector, reason)
-		return
-	}
+		apiRetryAfterHistogram = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        apiRetryAfterMetric,
+				Help:        "Delay in seconds requested by the Retry-After header of throttled HyperFleet API responses",
+				Buckets:     []float64{1, 2, 5, 10, 30, 60, 120, 300},
+				ConstLabels: constLabels,
+			},
+			MetricsLabelsWithStatus,
+		)
 
-	labels := prometheus.Labels{
-		metricsResourceTypeLabel:     resourceType,
-		metricsResourceSelectorLabel: resourceSelector,
-		metricsReasonLabel:           reason,
-	}
-	eventsPublishedCounter.With(labels).Inc()
-}
+		reconcileLatencyHistogram = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Subsystem: metricsSubsystem,
+				Name:      reconcileLatencyMetric,
+				Help: "Time in seconds from publishing an event for a new generation until the resource's " +
+					"observed generation caught up",
+				Buckets:     []float64{5, 15, 30, 60, 120, 300, 600, 1800, 3600, 7200, 21600, 86400},
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
 
-// UpdateResourcesSkippedMetric increments the counter of resources that were skipped during evaluation.
-//
-// Resources are skipped when they don't meet the criteria for publishing events, such as
-// being recently updated (within max age) or having matching observed generation.
-// Common reasons include "within_max_age" and "generation_match".
-//
-// Parameters:
-//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
-//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
-//   - reason: Reason for skipping (e.g., "within_max_age", "generation_match")
-//
-// Thread-safe: Can be called concurrently from multiple goroutines.
-//
-// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
-// This should never happen in normal operation and indicates a bug.
-func UpdateResourcesSkippedMetric(resourceType, resourceSelector, reason string) {
-	// Validate inputs
-	if resourceType == "" || resourceSelector == "" || reason == "" {
-		getLogger().Warnf(context.Background(),
-			"Attempted to update resources_skipped metric with empty parameters: resourceType=%q resourceSelector=%q reason=%q",
-			resourceType, resourceSelector, reason)
-		return
-	}
+		fleetSizeTotalGauge = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        fleetSizeTotalMetric,
+				Help:        "Number of resources matching the resource selector, as reported by the HyperFleet API",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
 
-	labels := prometheus.Labels{
-		metricsResourceTypeLabel:     resourceType,
-		metricsResourceSelectorLabel: resourceSelector,
-		metricsReasonLabel:           reason,
-	}
-	resourcesSkippedCounter.With(labels).Inc()
-}
+		fleetSizeFetchedGauge = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        fleetSizeFetchedMetric,
+				Help:        "Number of resources fetched by the last full list of the HyperFleet API",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
 
-// UpdatePollDurationMetric records the duration of a polling cycle in seconds.
+		publishesSuppressedCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        publishesSuppressedMetric,
+				Help:        "Total number of publishes held back by the per-resource republish backoff",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		deferredResourcesGauge = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        deferredResourcesMetric,
+				Help:        "Number of resources deferred to the next poll cycle by the publish rate limit",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		terminalResourcesGauge = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        terminalResourcesMetric,
+				Help:        "Number of resources skipped because they are in a terminal phase",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		clockSkewCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        clockSkewMetric,
+				Help:        "Total number of evaluations that found a condition last updated beyond the clock skew tolerance",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		decisionDurationHistogram = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        decisionDurationMetric,
+				Help:        "Duration in seconds of evaluating a resource with the decider (message_decision or decision_policy)",
+				Buckets:     []float64{0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 2.5},
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		decisionsCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        decisionsMetric,
+				Help:        "Total number of resources evaluated by the decider, by outcome and reason",
+				ConstLabels: constLabels,
+			},
+			MetricsLabelsWithOutcome,
+		)
+
+		policyErrorsCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        policyErrorsMetric,
+				Help:        "Total number of resources whose decision_policy query failed",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		flappingResourcesGauge = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        flappingResourcesMetric,
+				Help:        "Number of resources whose condition flapped within the flap detection window",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		cyclesSkippedCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        cyclesSkippedMetric,
+				Help:        "Total number of poll cycles skipped because the previous cycle was still running",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		pollIntervalGauge = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        pollIntervalMetric,
+				Help:        "Current interval between poll cycles in seconds",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		eventsDryRunCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        eventsDryRunMetric,
+				Help:        "Total number of reconciliation events that would have been published in dry-run mode",
+				ConstLabels: constLabels,
+			},
+			MetricsLabelsWithReason,
+		)
+
+		stateStoreErrorsCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        stateStoreErrorsMetric,
+				Help:        "Total number of failed reads and writes of the publish history state store",
+				ConstLabels: constLabels,
+			},
+			MetricsLabelsWithErrorType,
+		)
+
+		cycleTimeoutsCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        cycleTimeoutsMetric,
+				Help:        "Total number of poll cycles canceled because they reached their deadline",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		brokerBackpressureActiveGauge = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        brokerBackpressureActiveMetric,
+				Help:        "Whether publishing is paused because of repeated broker errors (1=paused, 0=publishing)",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		outboxEventsCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        outboxEventsMetric,
+				Help:        "Total number of events spooled to, replayed from, or dropped by the outbox by result",
+				ConstLabels: constLabels,
+			},
+			MetricsLabelsWithResult,
+		)
+
+		outboxSizeGauge = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        outboxSizeMetric,
+				Help:        "Number of events whose publish failed waiting in the outbox to be replayed",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		deadLetteredEventsCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        deadLetteredEventsMetric,
+				Help:        "Total number of events routed to the dead letter sink after repeated failed publishes",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		fanOutPublishesCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        fanOutPublishesMetric,
+				Help:        "Total number of publishes to each fan-out target by result (success or error)",
+				ConstLabels: constLabels,
+			},
+			MetricsLabelsWithTarget,
+		)
+
+		schemaViolationsCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        schemaViolationsMetric,
+				Help:        "Total number of events not published because their data does not match message_schema",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		publishBatchSizeHistogram = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        publishBatchSizeMetric,
+				Help:        "Number of events per publish batch",
+				Buckets:     []float64{1, 2, 5, 10, 25, 50, 100, 250},
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		unconfirmedEventsGauge = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        unconfirmedEventsMetric,
+				Help:        "Number of events handed to the async publish pipeline that the broker has not confirmed yet",
+				ConstLabels: constLabels,
+			},
+			MetricsLabels,
+		)
+
+		publishConfirmsCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        publishConfirmsMetric,
+				Help:        "Total number of events confirmed, re-queued, or dropped by the async publish pipeline by result",
+				ConstLabels: constLabels,
+			},
+			MetricsLabelsWithResult,
+		)
+
+		configReloadsCounter = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem:   metricsSubsystem,
+				Name:        configReloadsMetric,
+				Help:        "Total number of configuration reloads by result",
+				ConstLabels: constLabels,
+			},
+			MetricsLabelsWithResult,
+		)
+
+		// Register all metrics
+		registry.MustRegister(pendingResourcesGauge)
+		registry.MustRegister(eventsPublishedCounter)
+		registry.MustRegister(resourcesSkippedCounter)
+		registry.MustRegister(pollDurationHistogram)
+		registry.MustRegister(apiErrorsCounter)
+		registry.MustRegister(brokerErrorsCounter)
+		registry.MustRegister(lastSuccessfulPollTimestampGauge)
+		registry.MustRegister(apiCircuitBreakerStateGauge)
+		registry.MustRegister(brokerAuthErrorsCounter)
+		registry.MustRegister(suspendedResourcesGauge)
+		registry.MustRegister(evaluationCacheLookupsCounter)
+		registry.MustRegister(apiRequestDurationHistogram)
+		registry.MustRegister(apiRequestRetriesCounter)
+		registry.MustRegister(apiRetryAfterHistogram)
+		registry.MustRegister(reconcileLatencyHistogram)
+		registry.MustRegister(fleetSizeTotalGauge)
+		registry.MustRegister(fleetSizeFetchedGauge)
+		registry.MustRegister(publishesSuppressedCounter)
+		registry.MustRegister(deferredResourcesGauge)
+		registry.MustRegister(terminalResourcesGauge)
+		registry.MustRegister(clockSkewCounter)
+		registry.MustRegister(decisionDurationHistogram)
+		registry.MustRegister(decisionsCounter)
+		registry.MustRegister(policyErrorsCounter)
+		registry.MustRegister(flappingResourcesGauge)
+		registry.MustRegister(cyclesSkippedCounter)
+		registry.MustRegister(pollIntervalGauge)
+		registry.MustRegister(eventsDryRunCounter)
+		registry.MustRegister(stateStoreErrorsCounter)
+		registry.MustRegister(cycleTimeoutsCounter)
+		registry.MustRegister(brokerBackpressureActiveGauge)
+		registry.MustRegister(outboxEventsCounter)
+		registry.MustRegister(outboxSizeGauge)
+		registry.MustRegister(deadLetteredEventsCounter)
+		registry.MustRegister(fanOutPublishesCounter)
+		registry.MustRegister(schemaViolationsCounter)
+		registry.MustRegister(publishBatchSizeHistogram)
+		registry.MustRegister(unconfirmedEventsGauge)
+		registry.MustRegister(publishConfirmsCounter)
+		registry.MustRegister(configReloadsCounter)
+
+		metricsInstance = &SentinelMetrics{
+			PendingResources:            pendingResourcesGauge,
+			EventsPublished:             eventsPublishedCounter,
+			ResourcesSkipped:            resourcesSkippedCounter,
+			PollDuration:                pollDurationHistogram,
+			APIErrors:                   apiErrorsCounter,
+			BrokerErrors:                brokerErrorsCounter,
+			LastSuccessfulPollTimestamp: lastSuccessfulPollTimestampGauge,
+			APICircuitBreakerState:      apiCircuitBreakerStateGauge,
+			BrokerAuthErrors:            brokerAuthErrorsCounter,
+			SuspendedResources:          suspendedResourcesGauge,
+			EvaluationCacheLookups:      evaluationCacheLookupsCounter,
+			APIRequestDuration:          apiRequestDurationHistogram,
+			APIRequestRetries:           apiRequestRetriesCounter,
+			APIRetryAfter:               apiRetryAfterHistogram,
+			ReconcileLatency:            reconcileLatencyHistogram,
+			FleetSizeTotal:              fleetSizeTotalGauge,
+			FleetSizeFetched:            fleetSizeFetchedGauge,
+			PublishesSuppressed:         publishesSuppressedCounter,
+			DeferredResources:           deferredResourcesGauge,
+			TerminalResources:           terminalResourcesGauge,
+			ClockSkew:                   clockSkewCounter,
+			DecisionDuration:            decisionDurationHistogram,
+			Decisions:                   decisionsCounter,
+			PolicyErrors:                policyErrorsCounter,
+			FlappingResources:           flappingResourcesGauge,
+			CyclesSkipped:               cyclesSkippedCounter,
+			PollInterval:                pollIntervalGauge,
+			EventsDryRun:                eventsDryRunCounter,
+			StateStoreErrors:            stateStoreErrorsCounter,
+			CycleTimeouts:               cycleTimeoutsCounter,
+			BrokerBackpressureActive:    brokerBackpressureActiveGauge,
+			OutboxEvents:                outboxEventsCounter,
+			OutboxSize:                  outboxSizeGauge,
+			DeadLetteredEvents:          deadLetteredEventsCounter,
+			FanOutPublishes:             fanOutPublishesCounter,
+			SchemaViolations:            schemaViolationsCounter,
+			PublishBatchSize:            publishBatchSizeHistogram,
+			UnconfirmedEvents:           unconfirmedEventsGauge,
+			PublishConfirms:             publishConfirmsCounter,
+			ConfigReloads:               configReloadsCounter,
+		}
+	})
+
+	return metricsInstance
+}
+
+// ResetSentinelMetrics resets all metric collectors to their initial state.
+//
+// This function is intended for testing purposes only. It resets the sync.Once
+// so that NewSentinelMetrics can be called again to reinitialize collectors,
+// and clears all metric values if collectors have been initialized.
+//
+// WARNING: Do not use in production code. This will clear operational metrics.
+//
+// Thread-safe: Safe to call concurrently, but should only be called from test code.
+func ResetSentinelMetrics() {
+	if pendingResourcesGauge != nil {
+		pendingResourcesGauge.Reset()
+	}
+	if eventsPublishedCounter != nil {
+		eventsPublishedCounter.Reset()
+	}
+	if resourcesSkippedCounter != nil {
+		resourcesSkippedCounter.Reset()
+	}
+	if pollDurationHistogram != nil {
+		pollDurationHistogram.Reset()
+	}
+	if apiErrorsCounter != nil {
+		apiErrorsCounter.Reset()
+	}
+	if brokerErrorsCounter != nil {
+		brokerErrorsCounter.Reset()
+	}
+	if lastSuccessfulPollTimestampGauge != nil {
+		lastSuccessfulPollTimestampGauge.Set(0)
+	}
+	if apiCircuitBreakerStateGauge != nil {
+		apiCircuitBreakerStateGauge.Reset()
+	}
+	if brokerAuthErrorsCounter != nil {
+		brokerAuthErrorsCounter.Reset()
+	}
+	if suspendedResourcesGauge != nil {
+		suspendedResourcesGauge.Reset()
+	}
+	if evaluationCacheLookupsCounter != nil {
+		evaluationCacheLookupsCounter.Reset()
+	}
+	if apiRequestDurationHistogram != nil {
+		apiRequestDurationHistogram.Reset()
+	}
+	if apiRequestRetriesCounter != nil {
+		apiRequestRetriesCounter.Reset()
+	}
+	if apiRetryAfterHistogram != nil {
+		apiRetryAfterHistogram.Reset()
+	}
+	if reconcileLatencyHistogram != nil {
+		reconcileLatencyHistogram.Reset()
+	}
+	if fleetSizeTotalGauge != nil {
+		fleetSizeTotalGauge.Reset()
+	}
+	if fleetSizeFetchedGauge != nil {
+		fleetSizeFetchedGauge.Reset()
+	}
+	if publishesSuppressedCounter != nil {
+		publishesSuppressedCounter.Reset()
+	}
+	if deferredResourcesGauge != nil {
+		deferredResourcesGauge.Reset()
+	}
+	if terminalResourcesGauge != nil {
+		terminalResourcesGauge.Reset()
+	}
+	if clockSkewCounter != nil {
+		clockSkewCounter.Reset()
+	}
+	if decisionDurationHistogram != nil {
+		decisionDurationHistogram.Reset()
+	}
+	if decisionsCounter != nil {
+		decisionsCounter.Reset()
+	}
+	if policyErrorsCounter != nil {
+		policyErrorsCounter.Reset()
+	}
+	if flappingResourcesGauge != nil {
+		flappingResourcesGauge.Reset()
+	}
+	if cyclesSkippedCounter != nil {
+		cyclesSkippedCounter.Reset()
+	}
+	if pollIntervalGauge != nil {
+		pollIntervalGauge.Reset()
+	}
+	if eventsDryRunCounter != nil {
+		eventsDryRunCounter.Reset()
+	}
+	if stateStoreErrorsCounter != nil {
+		stateStoreErrorsCounter.Reset()
+	}
+	if cycleTimeoutsCounter != nil {
+		cycleTimeoutsCounter.Reset()
+	}
+	if brokerBackpressureActiveGauge != nil {
+		brokerBackpressureActiveGauge.Reset()
+	}
+	if outboxEventsCounter != nil {
+		outboxEventsCounter.Reset()
+	}
+	if outboxSizeGauge != nil {
+		outboxSizeGauge.Reset()
+	}
+	if deadLetteredEventsCounter != nil {
+		deadLetteredEventsCounter.Reset()
+	}
+	if fanOutPublishesCounter != nil {
+		fanOutPublishesCounter.Reset()
+	}
+	if schemaViolationsCounter != nil {
+		schemaViolationsCounter.Reset()
+	}
+	if publishBatchSizeHistogram != nil {
+		publishBatchSizeHistogram.Reset()
+	}
+	if unconfirmedEventsGauge != nil {
+		unconfirmedEventsGauge.Reset()
+	}
+	if publishConfirmsCounter != nil {
+		publishConfirmsCounter.Reset()
+	}
+	if configReloadsCounter != nil {
+		configReloadsCounter.Reset()
+	}
+	registerOnce = sync.Once{}
+	metricsInstance = nil
+}
+
+// UpdatePendingResourcesMetric sets the current number of resources pending reconciliation.
+//
+// This gauge metric tracks resources that need reconciliation based on max age intervals
+// or generation mismatches. The count is set (not incremented) and represents the current
+// snapshot of pending resources.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - count: Number of pending resources (negative values are clamped to 0)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty resourceType or resourceSelector trigger a warning and are ignored to prevent
+// cardinality issues. This should never happen in normal operation and indicates a bug.
+func UpdatePendingResourcesMetric(resourceType, resourceSelector string, count int) {
+	// Validate inputs
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update pending_resources metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+	if count < 0 {
+		count = 0
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	pendingResourcesGauge.With(labels).Set(float64(count))
+}
+
+// UpdateEventsPublishedMetric increments the counter of reconciliation events published to the broker.
+//
+// This counter tracks successful event publications, labeled by resource type, selector, and reason.
+// Reasons are the pkg/reasons values that publish, i.e. "message decision matched".
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - reason: Reason for publishing (see pkg/reasons)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateEventsPublishedMetric(resourceType, resourceSelector, reason string) {
+	// Validate inputs
+	if resourceType == "" || resourceSelector == "" || reason == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update events_published metric with empty parameters: resourceType=%q resourceSelector=%q reason=%q",
+			resourceType, resourceSelector, reason)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsReasonLabel:           reason,
+	}
+	eventsPublishedCounter.With(labels).Inc()
+}
+
+// UpdateResourcesSkippedMetric increments the counter of resources that were skipped during evaluation.
+//
+// Resources are skipped when they don't meet the criteria for publishing events, such as
+// being recently updated (within max age) or having matching observed generation.
+// Reasons are the pkg/reasons values that skip, e.g. "message decision result is false" and "paused".
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - reason: Reason for skipping (see pkg/reasons)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateResourcesSkippedMetric(resourceType, resourceSelector, reason string) {
+	// Validate inputs
+	if resourceType == "" || resourceSelector == "" || reason == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update resources_skipped metric with empty parameters: resourceType=%q resourceSelector=%q reason=%q",
+			resourceType, resourceSelector, reason)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsReasonLabel:           reason,
+	}
+	resourcesSkippedCounter.With(labels).Inc()
+}
+
+// UpdatePollDurationMetric records the duration of a polling cycle in seconds.
+//
+// This histogram metric tracks how long each polling cycle takes, including API calls,
+// decision evaluation, and event publishing. Useful for identifying performance issues
+// and API latency.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - durationSeconds: Duration in seconds (negative values trigger a warning and are ignored)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty resourceType/resourceSelector or negative duration trigger a warning and are
+// ignored to prevent invalid metrics. This should never happen in normal operation and indicates a bug.
+func UpdatePollDurationMetric(resourceType, resourceSelector string, durationSeconds float64) {
+	// Validate inputs
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update poll_duration metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+	if durationSeconds < 0 {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update poll_duration metric with negative duration: %f", durationSeconds)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	pollDurationHistogram.With(labels).Observe(durationSeconds)
+}
+
+// UpdateAPIErrorsMetric increments the counter of errors when calling the HyperFleet API.
+//
+// Tracks API errors by type to help diagnose connectivity and availability issues.
+// Common error types include "fetch_error", "auth_error", "rate_limited", "not_found".
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - errorType: Type of error (e.g., "fetch_error", "auth_error", "rate_limited")
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateAPIErrorsMetric(resourceType, resourceSelector, errorType string) {
+	// Validate inputs
+	if resourceType == "" || resourceSelector == "" || errorType == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update api_errors metric with empty parameters: resourceType=%q resourceSelector=%q errorType=%q",
+			resourceType, resourceSelector, errorType)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsErrorTypeLabel:        errorType,
+	}
+	apiErrorsCounter.With(labels).Inc()
+}
+
+// UpdateBrokerErrorsMetric increments the counter of errors when publishing events to the message broker.
+//
+// Tracks broker errors by type to help diagnose message delivery and broker connectivity issues.
+// Common error types include "publish_error", "connection_error", "timeout".
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - errorType: Type of error (e.g., "publish_error", "connection_error")
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateBrokerErrorsMetric(resourceType, resourceSelector, errorType string) {
+	// Validate inputs
+	if resourceType == "" || resourceSelector == "" || errorType == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update broker_errors metric with empty parameters: resourceType=%q resourceSelector=%q errorType=%q",
+			resourceType, resourceSelector, errorType)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsErrorTypeLabel:        errorType,
+	}
+	brokerErrorsCounter.With(labels).Inc()
+}
+
+// UpdateBrokerAuthErrorsMetric increments the counter of publishes rejected by the broker due to authorization.
+//
+// Authorization failures (RabbitMQ ACCESS_REFUSED, Pub/Sub PermissionDenied/Unauthenticated) indicate
+// missing topic permissions rather than a broker outage, and are counted here in addition to
+// broker_errors_total with error_type "auth_error" so they can be alerted on separately.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateBrokerAuthErrorsMetric(resourceType, resourceSelector string) {
+	// Validate inputs
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update broker_auth_errors metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	brokerAuthErrorsCounter.With(labels).Inc()
+}
+
+// UpdateLastSuccessfulPollTimestampMetric sets the gauge to the current Unix timestamp.
+//
+// This gauge acts as a dead man's switch: if the value becomes stale relative to
+// the current time, alerting knows the Sentinel has stopped polling.
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+func UpdateLastSuccessfulPollTimestampMetric() {
+	if lastSuccessfulPollTimestampGauge == nil {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update last_successful_poll_timestamp metric before initialization")
+		return
+	}
+
+	lastSuccessfulPollTimestampGauge.SetToCurrentTime()
+}
+
+// UpdateAPICircuitBreakerStateMetric sets the current state of the HyperFleet API circuit breaker.
+//
+// The gauge is 0 while the circuit is closed, 1 while it is open (fetches are skipped until the
+// cooldown elapses) and 2 while a single trial fetch is allowed through (half-open). Alerting on
+// a value of 1 catches a HyperFleet API outage without waiting for api_errors_total to grow.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - state: Circuit breaker state (0=closed, 1=open, 2=half-open)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty resourceType/resourceSelector or an unknown state trigger a warning and are
+// ignored. This should never happen in normal operation and indicates a bug.
+func UpdateAPICircuitBreakerStateMetric(resourceType, resourceSelector string, state int) {
+	// Validate inputs
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update api_circuit_breaker_state metric with empty parameters: "+
+				"resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+	if state < 0 || state > 2 {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update api_circuit_breaker_state metric with unknown state: %d", state)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	apiCircuitBreakerStateGauge.With(labels).Set(float64(state))
+}
+
+// GetResourceSelectorLabel converts resource selector to a single label value.
+// Empty selector returns "all", otherwise returns comma-separated label:value pairs.
+// Uses strings.Builder for efficient string concatenation.
+func GetResourceSelectorLabel(selectors config.LabelSelectorList) string {
+	if len(selectors) == 0 {
+		return "all"
+	}
+
+	var builder strings.Builder
+	for i, selector := range selectors {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(selector.Label)
+		builder.WriteString(":")
+		builder.WriteString(selector.Value)
+	}
+	return builder.String()
+}
+
+// UpdateSuspendedResourcesMetric sets the current number of resources paused for maintenance.
+//
+// This gauge metric tracks resources that matched the resource selector but were skipped
+// because they carry the sentinel.hyperfleet.io/paused label or the configured maintenance
+// label. The count is set (not incremented)
+// and represents the snapshot of the last poll cycle.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - count: Number of suspended resources (negative values are clamped to 0)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty resourceType or resourceSelector trigger a warning and are ignored to prevent
+// cardinality issues. This should never happen in normal operation and indicates a bug.
+func UpdateSuspendedResourcesMetric(resourceType, resourceSelector string, count int) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update suspended_resources metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+	if count < 0 {
+		count = 0
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	suspendedResourcesGauge.With(labels).Set(float64(count))
+}
+
+// UpdateEvaluationCacheLookupsMetric increments the counter of evaluation cache lookups.
+//
+// A "hit" means the resource was unchanged since its last evaluation and the cached
+// decision was reused; a "miss" means the decision engine evaluated the resource.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - result: Lookup result ("hit" or "miss")
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateEvaluationCacheLookupsMetric(resourceType, resourceSelector, result string) {
+	if resourceType == "" || resourceSelector == "" || result == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update evaluation_cache_lookups metric with empty parameters: "+
+				"resourceType=%q resourceSelector=%q result=%q",
+			resourceType, resourceSelector, result)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsResultLabel:           result,
+	}
+	evaluationCacheLookupsCounter.With(labels).Inc()
+}
+
+// UpdateAPIRequestDurationMetric records the duration of one HyperFleet API HTTP request.
+//
+// Unlike poll_duration_seconds, this histogram covers a single HTTP request: each page of
+// a list and each retry is observed separately. Requests that failed without a response
+// (network errors, timeouts) use the status "error".
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - method: HTTP method (e.g., "GET")
+//   - status: HTTP status code (e.g., "200", "503") or "error"
+//   - durationSeconds: Duration in seconds (negative values trigger a warning and are ignored)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters or a negative duration trigger a warning and are ignored.
+// This should never happen in normal operation and indicates a bug.
+func UpdateAPIRequestDurationMetric(resourceType, resourceSelector, method, status string, durationSeconds float64) {
+	if resourceType == "" || resourceSelector == "" || method == "" || status == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update api_request_duration metric with empty parameters: "+
+				"resourceType=%q resourceSelector=%q method=%q status=%q",
+			resourceType, resourceSelector, method, status)
+		return
+	}
+	if durationSeconds < 0 {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update api_request_duration metric with negative duration: %f", durationSeconds)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsMethodLabel:           method,
+		metricsStatusLabel:           status,
+	}
+	apiRequestDurationHistogram.With(labels).Observe(durationSeconds)
+}
+
+// UpdateAPIRequestRetriesMetric increments the counter of HyperFleet API HTTP requests that
+// were sent as a retry of a failed attempt.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateAPIRequestRetriesMetric(resourceType, resourceSelector string) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update api_request_retries metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	apiRequestRetriesCounter.With(labels).Inc()
+}
+
+// UpdateAPIRetryAfterMetric records the delay requested by the Retry-After header of a
+// throttled (429) or unavailable (503) HyperFleet API response.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - status: HTTP status code of the response (e.g., "429")
+//   - delaySeconds: Requested delay in seconds (negative values trigger a warning and are ignored)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters or a negative delay trigger a warning and are ignored.
+// This should never happen in normal operation and indicates a bug.
+func UpdateAPIRetryAfterMetric(resourceType, resourceSelector, status string, delaySeconds float64) {
+	if resourceType == "" || resourceSelector == "" || status == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update api_retry_after metric with empty parameters: "+
+				"resourceType=%q resourceSelector=%q status=%q",
+			resourceType, resourceSelector, status)
+		return
+	}
+	if delaySeconds < 0 {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update api_retry_after metric with negative delay: %f", delaySeconds)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsStatusLabel:           status,
+	}
+	apiRetryAfterHistogram.With(labels).Observe(delaySeconds)
+}
+
+// UpdateReconcileLatencyMetric records the time from publishing an event for a new
+// generation of a resource until the API reported its observed generation caught up.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - latencySeconds: Time to reconcile in seconds (negative values trigger a warning and are ignored)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters or a negative latency trigger a warning and are ignored.
+// This should never happen in normal operation and indicates a bug.
+func UpdateReconcileLatencyMetric(resourceType, resourceSelector string, latencySeconds float64) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update reconcile_latency metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+	if latencySeconds < 0 {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update reconcile_latency metric with negative latency: %f", latencySeconds)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	reconcileLatencyHistogram.With(labels).Observe(latencySeconds)
+}
+
+// UpdateFleetSizeTotalMetric sets the number of resources matching the resource selector,
+// as reported in the total of the HyperFleet API list response.
+//
+// Together with fleet_size_fetched it shows whether the Sentinel sees the whole fleet: a
+// total above the fetched count means pagination ended before every resource was listed,
+// for example because of a server-side limit. The count is set from full lists only.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - total: Total reported by the API (negative values are clamped to 0)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty resourceType or resourceSelector trigger a warning and are ignored to prevent
+// cardinality issues. This should never happen in normal operation and indicates a bug.
+func UpdateFleetSizeTotalMetric(resourceType, resourceSelector string, total int64) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update fleet_size_total metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	fleetSizeTotalGauge.With(labels).Set(float64(max(total, 0)))
+}
+
+// UpdateFleetSizeFetchedMetric sets the number of resources returned by the last full list
+// of the HyperFleet API.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - fetched: Number of resources fetched (negative values are clamped to 0)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty resourceType or resourceSelector trigger a warning and are ignored to prevent
+// cardinality issues. This should never happen in normal operation and indicates a bug.
+func UpdateFleetSizeFetchedMetric(resourceType, resourceSelector string, fetched int64) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update fleet_size_fetched metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	fleetSizeFetchedGauge.With(labels).Set(float64(max(fetched, 0)))
+}
+
+// UpdatePublishesSuppressedMetric increments the counter of publishes held back
+// by the per-resource republish backoff (republish_backoff).
+//
+// A resource that is decided to publish again before its backoff has elapsed is
+// skipped with reason "republish backoff" and counted here as well as in
+// resources_skipped_total.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdatePublishesSuppressedMetric(resourceType, resourceSelector string) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update publishes_suppressed metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	publishesSuppressedCounter.With(labels).Inc()
+}
+
+// UpdateDeferredResourcesMetric sets the number of resources deferred to the
+// next poll cycle by the publish rate limit (publish_rate_limit).
+//
+// A resource that is decided to publish after the cycle reached
+// max_events_per_cycle, or that max_events_per_second would not let publish
+// before the next cycle, is skipped with reason "deferred" and counted here as
+// well as in resources_skipped_total. The count is set (not incremented) and
+// represents the snapshot of the last poll cycle.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - count: Number of deferred resources (negative values are clamped to 0)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateDeferredResourcesMetric(resourceType, resourceSelector string, count int) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update deferred_resources metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	deferredResourcesGauge.With(labels).Set(float64(max(count, 0)))
+}
+
+// UpdateTerminalResourcesMetric sets the number of resources in a terminal
+// phase (message_decision.terminal_conditions).
+//
+// A resource with a terminal condition, such as Deleted, set to True is
+// skipped with reason "terminal phase" and never published again. Unlike
+// suspended_resources, these resources are not expected to come back; a
+// growing count means dead resources that the API has not yet removed. The
+// count is set (not incremented) and represents the snapshot of the last poll
+// cycle.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - count: Number of terminal resources (negative values are clamped to 0)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateTerminalResourcesMetric(resourceType, resourceSelector string, count int) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update terminal_resources metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	terminalResourcesGauge.With(labels).Set(float64(max(count, 0)))
+}
+
+// UpdateClockSkewMetric increments the counter of evaluations that found a
+// condition last updated further in the future than the clock skew tolerance
+// (message_decision.clock_skew).
+//
+// A future last updated time usually means the clock of the API or an adapter
+// is ahead of the Sentinel's. Such a resource does not look stale until the
+// Sentinel's clock catches up, unless fallback_to_created_time is set.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateClockSkewMetric(resourceType, resourceSelector string) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update clock_skew metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	clockSkewCounter.With(labels).Inc()
+}
+
+// UpdateDecisionMetrics records one evaluation of a resource by the decider:
+// its duration in decision_duration_seconds and its outcome and reason in
+// decisions_total.
+//
+// Decisions reused by the evaluation cache are not evaluations and are not
+// recorded. Use the duration to spot slower message_decision expressions or
+// decision_policy queries after a change.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - outcome: DecisionOutcomePublish, DecisionOutcomeSkip or DecisionOutcomeFailed
+//   - reason: Reason of the decision (e.g., "message decision matched")
+//   - durationSeconds: Duration in seconds (negative values trigger a warning and are ignored)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters or a negative duration trigger a warning and are ignored.
+// This should never happen in normal operation and indicates a bug.
+func UpdateDecisionMetrics(resourceType, resourceSelector, outcome, reason string, durationSeconds float64) {
+	if resourceType == "" || resourceSelector == "" || outcome == "" || reason == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update decision metrics with empty parameters: "+
+				"resourceType=%q resourceSelector=%q outcome=%q reason=%q",
+			resourceType, resourceSelector, outcome, reason)
+		return
+	}
+	if durationSeconds < 0 {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update decision metrics with negative duration: %f", durationSeconds)
+		return
+	}
+
+	decisionDurationHistogram.With(prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}).Observe(durationSeconds)
+	decisionsCounter.With(prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsOutcomeLabel:          outcome,
+		metricsReasonLabel:           reason,
+	}).Inc()
+}
+
+// UpdatePolicyErrorsMetric increments the counter of resources whose
+// decision_policy query failed: OPA could not be reached or answered with an
+// error, the policy was undefined, or it returned no boolean publish.
+//
+// Such resources are skipped with reason "policy evaluation failed" and the
+// error is logged at warn level. A rising rate usually means the OPA sidecar
+// is down or the policy bundle failed to load.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdatePolicyErrorsMetric(resourceType, resourceSelector string) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update decision_policy_errors metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	policyErrorsCounter.With(labels).Inc()
+}
+
+// UpdateFlappingResourcesMetric sets the number of flapping resources
+// (flap_detection).
+//
+// A resource flaps when its condition changed status at least flips times
+// within the window; it is then published at most once per publish_interval,
+// and held-back publishes are skipped with reason "flapping". The count is set
+// (not incremented) and represents the snapshot of the last poll cycle.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - count: Number of flapping resources (negative values are clamped to 0)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateFlappingResourcesMetric(resourceType, resourceSelector string, count int) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update flapping_resources metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	flappingResourcesGauge.With(labels).Set(float64(max(count, 0)))
+}
+
+// UpdateCyclesSkippedMetric adds count to the counter of skipped poll cycles.
+//
+// A poll cycle that takes longer than poll_interval does not overlap with the
+// next one: the cycles that were due while it ran are skipped and counted here.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - count: Number of skipped cycles (values below 1 are ignored)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateCyclesSkippedMetric(resourceType, resourceSelector string, count int) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update cycles_skipped metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+	if count < 1 {
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	cyclesSkippedCounter.With(labels).Add(float64(count))
+}
+
+// UpdatePollIntervalMetric sets the current interval between poll cycles.
+//
+// The interval is poll_interval, or with adaptive_interval the interval
+// adapted to the backlog of the last cycle.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - seconds: Poll interval in seconds
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdatePollIntervalMetric(resourceType, resourceSelector string, seconds float64) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update poll_interval metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	pollIntervalGauge.With(labels).Set(seconds)
+}
+
+// UpdateEventsDryRunMetric increments the counter of reconciliation events that
+// dry-run mode would have published.
+//
+// In dry-run mode the Sentinel evaluates resources as usual but never publishes,
+// so this counter takes the place of events_published_total.
 //
-// This histogram metric tracks how long each polling cycle takes, including API calls,
-// decision evaluation, and event publishing. Useful for identifying performance issues
-// and API latency.
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - reason: Reason the event would have been published (see pkg/reasons)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateEventsDryRunMetric(resourceType, resourceSelector, reason string) {
+	if resourceType == "" || resourceSelector == "" || reason == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update events_dry_run metric with empty parameters: resourceType=%q resourceSelector=%q reason=%q",
+			resourceType, resourceSelector, reason)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsReasonLabel:           reason,
+	}
+	eventsDryRunCounter.With(labels).Inc()
+}
+
+// UpdateStateStoreErrorsMetric increments the counter of failed reads and
+// writes of the publish history state store.
+//
+// A failed read leaves the resource's republish backoff and dedup window as
+// they are in memory; a failed write loses the publish for the next restart or
+// replica. Neither stops the publish itself.
 //
 // Parameters:
 //   - resourceType: Type of resource (e.g., "clusters", "nodepools")
 //   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
-//   - durationSeconds: Duration in seconds (negative values trigger a warning and are ignored)
+//   - errorType: Type of error ("read" or "write")
 //
 // Thread-safe: Can be called concurrently from multiple goroutines.
 //
-// Validation: Empty resourceType/resourceSelector or negative duration trigger a warning and are
-// ignored to prevent invalid metrics. This should never happen in normal operation and indicates a bug.
-func UpdatePollDurationMetric(resourceType, resourceSelector string, durationSeconds float64) {
-	// Validate inputs
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateStateStoreErrorsMetric(resourceType, resourceSelector, errorType string) {
+	if resourceType == "" || resourceSelector == "" || errorType == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update state_store_errors metric with empty parameters: resourceType=%q resourceSelector=%q errorType=%q",
+			resourceType, resourceSelector, errorType)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsErrorTypeLabel:        errorType,
+	}
+	stateStoreErrorsCounter.With(labels).Inc()
+}
+
+// UpdateCycleTimeoutsMetric increments the counter of poll cycles canceled at
+// their deadline.
+//
+// Each poll cycle runs with a deadline of cycle_timeout, or 90% of the poll
+// interval, so that a hung API or broker call cannot stall the poll loop. The
+// resources a canceled cycle did not publish are published by the next one.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateCycleTimeoutsMetric(resourceType, resourceSelector string) {
 	if resourceType == "" || resourceSelector == "" {
 		getLogger().Warnf(context.Background(),
-			"Attempted to update poll_duration metric with empty parameters: resourceType=%q resourceSelector=%q",
+			"Attempted to update cycle_timeouts metric with empty parameters: resourceType=%q resourceSelector=%q",
 			resourceType, resourceSelector)
 		return
 	}
-	if durationSeconds < 0 {
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	cycleTimeoutsCounter.With(labels).Inc()
+}
+
+// UpdateBrokerBackpressureActiveMetric sets whether publishing is paused by
+// broker backpressure.
+//
+// With clients.broker.backpressure configured, publishing is paused for the
+// cooldown once the broker has rejected error_threshold publishes within the
+// window. The gauge is 1 during the cooldown and 0 otherwise; resources skipped
+// meanwhile are counted in resources_skipped_total with reason
+// "broker backpressure".
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - active: Whether publishing is paused
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateBrokerBackpressureActiveMetric(resourceType, resourceSelector string, active bool) {
+	if resourceType == "" || resourceSelector == "" {
 		getLogger().Warnf(context.Background(),
-			"Attempted to update poll_duration metric with negative duration: %f", durationSeconds)
+			"Attempted to update broker_backpressure_active metric with empty parameters: "+
+				"resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
 		return
 	}
 
@@ -393,103 +1989,278 @@ func UpdatePollDurationMetric(resourceType, resourceSelector string, durationSec
 		metricsResourceTypeLabel:     resourceType,
 		metricsResourceSelectorLabel: resourceSelector,
 	}
-	pollDurationHistogram.With(labels).Observe(durationSeconds)
+	value := 0.0
+	if active {
+		value = 1
+	}
+	brokerBackpressureActiveGauge.With(labels).Set(value)
 }
 
-// UpdateAPIErrorsMetric increments the counter of errors when calling the HyperFleet API.
+// UpdateOutboxEventsMetric adds count to the counter of outbox events with
+// the given result.
 //
-// Tracks API errors by type to help diagnose connectivity and availability issues.
-// Common error types include "fetch_error", "timeout", "auth_error".
+// With clients.broker.outbox configured, an event whose publish failed is
+// spooled to disk ("spooled") and replayed at the start of a later poll cycle
+// ("replayed"). Events evicted from a full outbox, or that cannot be spooled
+// or read back, are counted as "dropped".
 //
 // Parameters:
 //   - resourceType: Type of resource (e.g., "clusters", "nodepools")
 //   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
-//   - errorType: Type of error (e.g., "fetch_error", "timeout", "auth_error")
+//   - result: "spooled", "replayed", or "dropped"
+//   - count: Number of events
 //
 // Thread-safe: Can be called concurrently from multiple goroutines.
 //
 // Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
 // This should never happen in normal operation and indicates a bug.
-func UpdateAPIErrorsMetric(resourceType, resourceSelector, errorType string) {
-	// Validate inputs
-	if resourceType == "" || resourceSelector == "" || errorType == "" {
+func UpdateOutboxEventsMetric(resourceType, resourceSelector, result string, count int) {
+	if resourceType == "" || resourceSelector == "" || result == "" {
 		getLogger().Warnf(context.Background(),
-			"Attempted to update api_errors metric with empty parameters: resourceType=%q resourceSelector=%q errorType=%q",
-			resourceType, resourceSelector, errorType)
+			"Attempted to update outbox_events metric with empty parameters: resourceType=%q resourceSelector=%q result=%q",
+			resourceType, resourceSelector, result)
 		return
 	}
 
 	labels := prometheus.Labels{
 		metricsResourceTypeLabel:     resourceType,
 		metricsResourceSelectorLabel: resourceSelector,
-		metricsErrorTypeLabel:        errorType,
+		metricsResultLabel:           result,
 	}
-	apiErrorsCounter.With(labels).Inc()
+	outboxEventsCounter.With(labels).Add(float64(count))
 }
 
-// UpdateBrokerErrorsMetric increments the counter of errors when publishing events to the message broker.
+// UpdateOutboxSizeMetric sets the number of events waiting in the outbox.
 //
-// Tracks broker errors by type to help diagnose message delivery and broker connectivity issues.
-// Common error types include "publish_error", "connection_error", "timeout".
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - size: Number of spooled events
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateOutboxSizeMetric(resourceType, resourceSelector string, size int) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update outbox_size metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	outboxSizeGauge.With(labels).Set(float64(size))
+}
+
+// UpdateDeadLetteredEventsMetric increments the counter of events routed to the dead letter sink.
+//
+// An event is dead-lettered once the publishes for one generation of a resource have failed
+// clients.broker.dead_letter.max_attempts times in a row. The resource itself keeps being retried.
 //
 // Parameters:
 //   - resourceType: Type of resource (e.g., "clusters", "nodepools")
 //   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
-//   - errorType: Type of error (e.g., "publish_error", "connection_error")
 //
 // Thread-safe: Can be called concurrently from multiple goroutines.
 //
 // Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
 // This should never happen in normal operation and indicates a bug.
-func UpdateBrokerErrorsMetric(resourceType, resourceSelector, errorType string) {
-	// Validate inputs
-	if resourceType == "" || resourceSelector == "" || errorType == "" {
+func UpdateDeadLetteredEventsMetric(resourceType, resourceSelector string) {
+	if resourceType == "" || resourceSelector == "" {
 		getLogger().Warnf(context.Background(),
-			"Attempted to update broker_errors metric with empty parameters: resourceType=%q resourceSelector=%q errorType=%q",
-			resourceType, resourceSelector, errorType)
+			"Attempted to update dead_lettered_events metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
 		return
 	}
 
 	labels := prometheus.Labels{
 		metricsResourceTypeLabel:     resourceType,
 		metricsResourceSelectorLabel: resourceSelector,
-		metricsErrorTypeLabel:        errorType,
 	}
-	brokerErrorsCounter.With(labels).Inc()
+	deadLetteredEventsCounter.With(labels).Inc()
 }
 
-// UpdateLastSuccessfulPollTimestampMetric sets the gauge to the current Unix timestamp.
+// UpdateFanOutPublishesMetric increments the counter of publishes to a fan-out target.
 //
-// This gauge acts as a dead man's switch: if the value becomes stale relative to
-// the current time, alerting knows the Sentinel has stopped polling.
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - target: Name of the target from clients.broker.fan_out, or "primary"
+//   - result: "success" or "error"
 //
 // Thread-safe: Can be called concurrently from multiple goroutines.
-func UpdateLastSuccessfulPollTimestampMetric() {
-	if lastSuccessfulPollTimestampGauge == nil {
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateFanOutPublishesMetric(resourceType, resourceSelector, target, result string) {
+	if resourceType == "" || resourceSelector == "" || target == "" || result == "" {
 		getLogger().Warnf(context.Background(),
-			"Attempted to update last_successful_poll_timestamp metric before initialization")
+			"Attempted to update fanout_publishes metric with empty parameters: "+
+				"resourceType=%q resourceSelector=%q target=%q result=%q",
+			resourceType, resourceSelector, target, result)
 		return
 	}
 
-	lastSuccessfulPollTimestampGauge.SetToCurrentTime()
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsTargetLabel:           target,
+		metricsResultLabel:           result,
+	}
+	fanOutPublishesCounter.With(labels).Inc()
 }
 
-// GetResourceSelectorLabel converts resource selector to a single label value.
-// Empty selector returns "all", otherwise returns comma-separated label:value pairs.
-// Uses strings.Builder for efficient string concatenation.
-func GetResourceSelectorLabel(selectors config.LabelSelectorList) string {
-	if len(selectors) == 0 {
-		return "all"
+// UpdateSchemaViolationsMetric increments the counter of events whose data does not match message_schema.
+//
+// Such events are not published; the resource stays pending and is evaluated again on the next
+// poll cycle. The violations are logged with the offending fields.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateSchemaViolationsMetric(resourceType, resourceSelector string) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update schema_violations metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
 	}
 
-	var builder strings.Builder
-	for i, selector := range selectors {
-		if i > 0 {
-			builder.WriteString(",")
-		}
-		builder.WriteString(selector.Label)
-		builder.WriteString(":")
-		builder.WriteString(selector.Value)
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
 	}
-	return builder.String()
+	schemaViolationsCounter.With(labels).Inc()
+}
+
+// UpdatePublishBatchSizeMetric records the number of events in a flushed publish batch.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - size: Number of events in the batch (values below 1 trigger a warning and are ignored)
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters or an empty batch trigger a warning and are ignored.
+// This should never happen in normal operation and indicates a bug.
+func UpdatePublishBatchSizeMetric(resourceType, resourceSelector string, size int) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update publish_batch_size metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+	if size < 1 {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update publish_batch_size metric with empty batch: %d", size)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	publishBatchSizeHistogram.With(labels).Observe(float64(size))
+}
+
+// UpdateUnconfirmedEventsMetric sets the number of events awaiting a broker confirm.
+//
+// With clients.broker.async_publish configured, an event is unconfirmed from the moment it is
+// queued until its publish succeeds or it is dropped, including while it waits to be re-queued.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - count: Number of unconfirmed events
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateUnconfirmedEventsMetric(resourceType, resourceSelector string, count int) {
+	if resourceType == "" || resourceSelector == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update unconfirmed_events metric with empty parameters: resourceType=%q resourceSelector=%q",
+			resourceType, resourceSelector)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+	}
+	unconfirmedEventsGauge.With(labels).Set(float64(count))
+}
+
+// UpdatePublishConfirmsMetric increments the counter of async publish outcomes.
+//
+// An event queued by the async publish pipeline is "confirmed" once its publish succeeds. A failed
+// publish is "requeued" for another attempt, or "dropped" once its attempts are exhausted or the
+// queue is full.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - result: "confirmed", "requeued", or "dropped"
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdatePublishConfirmsMetric(resourceType, resourceSelector, result string) {
+	if resourceType == "" || resourceSelector == "" || result == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update publish_confirms metric with empty parameters: "+
+				"resourceType=%q resourceSelector=%q result=%q",
+			resourceType, resourceSelector, result)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsResultLabel:           result,
+	}
+	publishConfirmsCounter.With(labels).Inc()
+}
+
+// UpdateConfigReloadsMetric increments the counter of configuration reloads.
+//
+// A reload is a "success" once every watcher accepted the new configuration, and a "failure" when
+// the configuration could not be loaded or was rejected, in which case the old one stays in use.
+//
+// Parameters:
+//   - resourceType: Type of resource (e.g., "clusters", "nodepools")
+//   - resourceSelector: Label selector string (e.g., "shard:1" or "all")
+//   - result: "success" or "failure"
+//
+// Thread-safe: Can be called concurrently from multiple goroutines.
+//
+// Validation: Empty parameters trigger a warning and are ignored to prevent cardinality issues.
+// This should never happen in normal operation and indicates a bug.
+func UpdateConfigReloadsMetric(resourceType, resourceSelector, result string) {
+	if resourceType == "" || resourceSelector == "" || result == "" {
+		getLogger().Warnf(context.Background(),
+			"Attempted to update config_reloads metric with empty parameters: "+
+				"resourceType=%q resourceSelector=%q result=%q",
+			resourceType, resourceSelector, result)
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     resourceType,
+		metricsResourceSelectorLabel: resourceSelector,
+		metricsResultLabel:           result,
+	}
+	configReloadsCounter.With(labels).Inc()
 }
@@ -253,6 +253,396 @@ func TestUpdateBrokerErrorsMetric(t *testing.T) {
 	}
 }
 
+func TestUpdateBrokerAuthErrorsMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateBrokerAuthErrorsMetric("clusters", "all")
+
+	count := testutil.CollectAndCount(brokerAuthErrorsCounter)
+	if count == 0 {
+		t.Error("Expected BrokerAuthErrors metric to be collected")
+	}
+}
+
+func TestUpdateDeferredResourcesMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateDeferredResourcesMetric("clusters", "all", 3)
+
+	got := testutil.ToFloat64(deferredResourcesGauge.With(prometheus.Labels{
+		metricsResourceTypeLabel:     "clusters",
+		metricsResourceSelectorLabel: "all",
+	}))
+	if got != 3 {
+		t.Errorf("Expected deferred_resources to be 3, got %f", got)
+	}
+}
+
+func TestUpdateTerminalResourcesMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateTerminalResourcesMetric("clusters", "all", 4)
+
+	got := testutil.ToFloat64(terminalResourcesGauge.With(prometheus.Labels{
+		metricsResourceTypeLabel:     "clusters",
+		metricsResourceSelectorLabel: "all",
+	}))
+	if got != 4 {
+		t.Errorf("Expected terminal_resources to be 4, got %f", got)
+	}
+}
+
+func TestUpdateFlappingResourcesMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateFlappingResourcesMetric("clusters", "all", 2)
+	UpdateFlappingResourcesMetric("", "all", 5) // ignored
+
+	got := testutil.ToFloat64(flappingResourcesGauge.With(prometheus.Labels{
+		metricsResourceTypeLabel:     "clusters",
+		metricsResourceSelectorLabel: "all",
+	}))
+	if got != 2 {
+		t.Errorf("Expected flapping_resources to be 2, got %f", got)
+	}
+}
+
+func TestUpdateCyclesSkippedMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateCyclesSkippedMetric("clusters", "all", 2)
+	UpdateCyclesSkippedMetric("clusters", "all", 0) // ignored
+	UpdateCyclesSkippedMetric("", "all", 5)         // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(cyclesSkippedCounter.With(labels)); got != 2 {
+		t.Errorf("Expected cycles_skipped_total 2, got %v", got)
+	}
+}
+
+func TestUpdatePollIntervalMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdatePollIntervalMetric("clusters", "all", 2.5)
+	UpdatePollIntervalMetric("", "all", 5) // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(pollIntervalGauge.With(labels)); got != 2.5 {
+		t.Errorf("Expected poll_interval_seconds 2.5, got %v", got)
+	}
+}
+
+func TestUpdateEventsDryRunMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateEventsDryRunMetric("clusters", "all", "message decision matched")
+	UpdateEventsDryRunMetric("clusters", "all", "message decision matched")
+	UpdateEventsDryRunMetric("clusters", "all", "") // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all", "reason": "message decision matched"}
+	if got := testutil.ToFloat64(eventsDryRunCounter.With(labels)); got != 2 {
+		t.Errorf("Expected events_dry_run_total 2, got %v", got)
+	}
+}
+
+func TestUpdateStateStoreErrorsMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateStateStoreErrorsMetric("clusters", "all", "read")
+	UpdateStateStoreErrorsMetric("clusters", "all", "write")
+	UpdateStateStoreErrorsMetric("clusters", "all", "write")
+	UpdateStateStoreErrorsMetric("clusters", "", "read") // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all", "error_type": "write"}
+	if got := testutil.ToFloat64(stateStoreErrorsCounter.With(labels)); got != 2 {
+		t.Errorf("Expected state_store_errors_total 2, got %v", got)
+	}
+}
+
+func TestUpdateCycleTimeoutsMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateCycleTimeoutsMetric("clusters", "all")
+	UpdateCycleTimeoutsMetric("", "all") // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(cycleTimeoutsCounter.With(labels)); got != 1 {
+		t.Errorf("Expected cycle_timeouts_total 1, got %v", got)
+	}
+}
+
+func TestUpdateBrokerBackpressureActiveMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	UpdateBrokerBackpressureActiveMetric("clusters", "all", true)
+	if got := testutil.ToFloat64(brokerBackpressureActiveGauge.With(labels)); got != 1 {
+		t.Errorf("Expected broker_backpressure_active 1, got %v", got)
+	}
+	UpdateBrokerBackpressureActiveMetric("clusters", "all", false)
+	UpdateBrokerBackpressureActiveMetric("", "all", true) // ignored
+	if got := testutil.ToFloat64(brokerBackpressureActiveGauge.With(labels)); got != 0 {
+		t.Errorf("Expected broker_backpressure_active 0, got %v", got)
+	}
+}
+
+func TestUpdateOutboxMetrics(t *testing.T) {
+	initTestMetrics(t)
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all", "result": "spooled"}
+	UpdateOutboxEventsMetric("clusters", "all", "spooled", 3)
+	UpdateOutboxEventsMetric("clusters", "all", "", 1) // ignored
+	if got := testutil.ToFloat64(outboxEventsCounter.With(labels)); got != 3 {
+		t.Errorf("Expected outbox_events_total{result=\"spooled\"} 3, got %v", got)
+	}
+
+	UpdateOutboxSizeMetric("clusters", "all", 2)
+	sizeLabels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(outboxSizeGauge.With(sizeLabels)); got != 2 {
+		t.Errorf("Expected outbox_size 2, got %v", got)
+	}
+}
+
+func TestUpdateDeadLetteredEventsMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateDeadLetteredEventsMetric("clusters", "all")
+	UpdateDeadLetteredEventsMetric("", "all") // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(deadLetteredEventsCounter.With(labels)); got != 1 {
+		t.Errorf("Expected dead_lettered_events_total 1, got %v", got)
+	}
+}
+
+func TestUpdateFanOutPublishesMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateFanOutPublishesMetric("clusters", "all", "pubsub", "error")
+	UpdateFanOutPublishesMetric("clusters", "all", "", "error") // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all", "target": "pubsub", "result": "error"}
+	if got := testutil.ToFloat64(fanOutPublishesCounter.With(labels)); got != 1 {
+		t.Errorf("Expected fanout_publishes_total 1, got %v", got)
+	}
+}
+
+func TestUpdateSchemaViolationsMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateSchemaViolationsMetric("clusters", "all")
+	UpdateSchemaViolationsMetric("clusters", "") // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(schemaViolationsCounter.With(labels)); got != 1 {
+		t.Errorf("Expected schema_violations_total 1, got %v", got)
+	}
+}
+
+func TestUpdatePublishBatchSizeMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdatePublishBatchSizeMetric("clusters", "all", 10)
+	UpdatePublishBatchSizeMetric("clusters", "all", 0) // ignored
+	UpdatePublishBatchSizeMetric("", "all", 10)        // ignored
+
+	count := testutil.CollectAndCount(publishBatchSizeHistogram)
+	if count != 1 {
+		t.Errorf("Expected 1 publish_batch_size series, got %d", count)
+	}
+}
+
+func TestUpdateAsyncPublishMetrics(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateUnconfirmedEventsMetric("clusters", "all", 3)
+	UpdatePublishConfirmsMetric("clusters", "all", "confirmed")
+	UpdatePublishConfirmsMetric("clusters", "all", "confirmed")
+	UpdatePublishConfirmsMetric("clusters", "all", "") // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(unconfirmedEventsGauge.With(labels)); got != 3 {
+		t.Errorf("Expected unconfirmed_events 3, got %v", got)
+	}
+	labels["result"] = "confirmed"
+	if got := testutil.ToFloat64(publishConfirmsCounter.With(labels)); got != 2 {
+		t.Errorf("Expected publish_confirms_total{result=\"confirmed\"} 2, got %v", got)
+	}
+}
+
+func TestUpdateConfigReloadsMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateConfigReloadsMetric("clusters", "all", "success")
+	UpdateConfigReloadsMetric("clusters", "all", "failure")
+	UpdateConfigReloadsMetric("clusters", "all", "") // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all", "result": "success"}
+	if got := testutil.ToFloat64(configReloadsCounter.With(labels)); got != 1 {
+		t.Errorf("Expected config_reloads_total{result=\"success\"} 1, got %v", got)
+	}
+}
+
+func TestUpdateSuspendedResourcesMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateSuspendedResourcesMetric("clusters", "all", 2)
+
+	got := testutil.ToFloat64(suspendedResourcesGauge.With(prometheus.Labels{
+		metricsResourceTypeLabel:     "clusters",
+		metricsResourceSelectorLabel: "all",
+	}))
+	if got != 2 {
+		t.Errorf("Expected suspended_resources to be 2, got %f", got)
+	}
+}
+
+func TestUpdateEvaluationCacheLookupsMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateEvaluationCacheLookupsMetric("clusters", "all", "hit")
+	UpdateEvaluationCacheLookupsMetric("clusters", "all", "hit")
+	UpdateEvaluationCacheLookupsMetric("clusters", "all", "miss")
+
+	got := testutil.ToFloat64(evaluationCacheLookupsCounter.With(prometheus.Labels{
+		metricsResourceTypeLabel:     "clusters",
+		metricsResourceSelectorLabel: "all",
+		metricsResultLabel:           "hit",
+	}))
+	if got != 2 {
+		t.Errorf("Expected 2 cache hits, got %f", got)
+	}
+}
+
+func TestUpdateAPIRequestMetrics(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateAPIRequestDurationMetric("clusters", "all", "GET", "200", 0.05)
+	UpdateAPIRequestDurationMetric("clusters", "all", "GET", "503", 0.2)
+	UpdateAPIRequestDurationMetric("clusters", "all", "GET", "200", -1) // ignored
+	UpdateAPIRequestRetriesMetric("clusters", "all")
+
+	if count := testutil.CollectAndCount(apiRequestDurationHistogram); count != 2 {
+		t.Errorf("Expected 2 api_request_duration series, got %d", count)
+	}
+	got := testutil.ToFloat64(apiRequestRetriesCounter.With(prometheus.Labels{
+		metricsResourceTypeLabel:     "clusters",
+		metricsResourceSelectorLabel: "all",
+	}))
+	if got != 1 {
+		t.Errorf("Expected 1 retry, got %f", got)
+	}
+}
+
+func TestUpdateAPIRetryAfterMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateAPIRetryAfterMetric("clusters", "all", "429", 5)
+	UpdateAPIRetryAfterMetric("clusters", "all", "429", -1) // ignored
+	UpdateAPIRetryAfterMetric("clusters", "", "429", 5)     // ignored
+
+	if count := testutil.CollectAndCount(apiRetryAfterHistogram); count != 1 {
+		t.Errorf("Expected 1 api_retry_after series, got %d", count)
+	}
+}
+
+func TestUpdateReconcileLatencyMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateReconcileLatencyMetric("clusters", "all", 90)
+	UpdateReconcileLatencyMetric("clusters", "all", -1) // ignored
+	UpdateReconcileLatencyMetric("", "all", 90)         // ignored
+
+	if count := testutil.CollectAndCount(reconcileLatencyHistogram); count != 1 {
+		t.Errorf("Expected 1 reconcile_latency series, got %d", count)
+	}
+}
+
+func TestUpdatePublishesSuppressedMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdatePublishesSuppressedMetric("clusters", "all")
+	UpdatePublishesSuppressedMetric("clusters", "all")
+	UpdatePublishesSuppressedMetric("", "all") // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(publishesSuppressedCounter.With(labels)); got != 2 {
+		t.Errorf("Expected publishes_suppressed_total 2, got %v", got)
+	}
+}
+
+func TestUpdateClockSkewMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateClockSkewMetric("clusters", "all")
+	UpdateClockSkewMetric("", "all") // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(clockSkewCounter.With(labels)); got != 1 {
+		t.Errorf("Expected clock_skew_total 1, got %v", got)
+	}
+}
+
+func TestUpdatePolicyErrorsMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdatePolicyErrorsMetric("clusters", "all")
+	UpdatePolicyErrorsMetric("clusters", "all")
+	UpdatePolicyErrorsMetric("", "all") // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(policyErrorsCounter.With(labels)); got != 2 {
+		t.Errorf("Expected decision_policy_errors_total 2, got %v", got)
+	}
+}
+
+func TestUpdateDecisionMetrics(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateDecisionMetrics("clusters", "all", DecisionOutcomePublish, "message decision matched", 0.0002)
+	UpdateDecisionMetrics("clusters", "all", DecisionOutcomePublish, "message decision matched", 0.0004)
+	UpdateDecisionMetrics("clusters", "all", DecisionOutcomeSkip, "", 0.0001)                             // ignored
+	UpdateDecisionMetrics("clusters", "all", DecisionOutcomeSkip, "message decision result is false", -1) // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.CollectAndCount(decisionDurationHistogram); got != 1 {
+		t.Errorf("Expected 1 decision_duration_seconds series, got %d", got)
+	}
+	labels["outcome"] = DecisionOutcomePublish
+	labels["reason"] = "message decision matched"
+	if got := testutil.ToFloat64(decisionsCounter.With(labels)); got != 2 {
+		t.Errorf("Expected decisions_total 2, got %v", got)
+	}
+	if got := testutil.CollectAndCount(decisionsCounter); got != 1 {
+		t.Errorf("Expected 1 decisions_total series, got %d", got)
+	}
+}
+
+func TestUpdateFleetSizeMetrics(t *testing.T) {
+	initTestMetrics(t)
+
+	UpdateFleetSizeTotalMetric("clusters", "all", 1200)
+	UpdateFleetSizeFetchedMetric("clusters", "all", 1000)
+	UpdateFleetSizeTotalMetric("", "all", 10)        // ignored
+	UpdateFleetSizeFetchedMetric("clusters", "", 10) // ignored
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(fleetSizeTotalGauge.With(labels)); got != 1200 {
+		t.Errorf("Expected fleet_size_total 1200, got %v", got)
+	}
+	if got := testutil.ToFloat64(fleetSizeFetchedGauge.With(labels)); got != 1000 {
+		t.Errorf("Expected fleet_size_fetched 1000, got %v", got)
+	}
+
+	UpdateFleetSizeTotalMetric("clusters", "all", -1)
+	if got := testutil.ToFloat64(fleetSizeTotalGauge.With(labels)); got != 0 {
+		t.Errorf("Expected negative total to be clamped to 0, got %v", got)
+	}
+	if count := testutil.CollectAndCount(fleetSizeTotalGauge); count != 1 {
+		t.Errorf("Expected 1 fleet_size_total series, got %d", count)
+	}
+}
+
 func TestUpdateLastSuccessfulPollTimestampMetric(t *testing.T) {
 	initTestMetrics(t)
 
@@ -264,6 +654,26 @@ func TestUpdateLastSuccessfulPollTimestampMetric(t *testing.T) {
 	}
 }
 
+func TestUpdateAPICircuitBreakerStateMetric(t *testing.T) {
+	initTestMetrics(t)
+
+	labels := prometheus.Labels{
+		metricsResourceTypeLabel:     "clusters",
+		metricsResourceSelectorLabel: "all",
+	}
+
+	UpdateAPICircuitBreakerStateMetric("clusters", "all", 1)
+	if v := testutil.ToFloat64(apiCircuitBreakerStateGauge.With(labels)); v != 1 {
+		t.Errorf("Expected circuit breaker state 1, got %f", v)
+	}
+
+	// Unknown states are ignored
+	UpdateAPICircuitBreakerStateMetric("clusters", "all", 7)
+	if v := testutil.ToFloat64(apiCircuitBreakerStateGauge.With(labels)); v != 1 {
+		t.Errorf("Expected circuit breaker state to stay 1, got %f", v)
+	}
+}
+
 func TestGetResourceSelectorLabel(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -367,7 +777,7 @@ func TestMetricsLabelsConstants(t *testing.T) {
 
 func TestMetricsNamesConstants(t *testing.T) {
 	// Verify all metric names are in the MetricsNames array
-	expectedCount := 7
+	expectedCount := 40
 	if len(MetricsNames) != expectedCount {
 		t.Errorf("Expected %d metric names, got %d", expectedCount, len(MetricsNames))
 	}
@@ -430,6 +840,23 @@ func TestAllMetricsHaveStandardLabels(t *testing.T) {
 		"api_errors_total":                       apiErrorsCounter,
 		"broker_errors_total":                    brokerErrorsCounter,
 		"last_successful_poll_timestamp_seconds": lastSuccessfulPollTimestampGauge,
+		"api_circuit_breaker_state":              apiCircuitBreakerStateGauge,
+		"broker_auth_errors_total":               brokerAuthErrorsCounter,
+		"suspended_resources":                    suspendedResourcesGauge,
+		"evaluation_cache_lookups_total":         evaluationCacheLookupsCounter,
+		"api_request_duration_seconds":           apiRequestDurationHistogram,
+		"api_request_retries_total":              apiRequestRetriesCounter,
+		"api_retry_after_seconds":                apiRetryAfterHistogram,
+		"reconcile_latency_seconds":              reconcileLatencyHistogram,
+		"fleet_size_total":                       fleetSizeTotalGauge,
+		"fleet_size_fetched":                     fleetSizeFetchedGauge,
+		"deferred_resources":                     deferredResourcesGauge,
+		"terminal_resources":                     terminalResourcesGauge,
+		"clock_skew_total":                       clockSkewCounter,
+		"decision_duration_seconds":              decisionDurationHistogram,
+		"decisions_total":                        decisionsCounter,
+		"decision_policy_errors_total":           policyErrorsCounter,
+		"flapping_resources":                     flappingResourcesGauge,
 	}
 
 	for name, collector := range collectors {
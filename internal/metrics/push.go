@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+const (
+	// DefaultPushJob is the Pushgateway job used when metrics_push.job is empty.
+	DefaultPushJob = "hyperfleet-sentinel"
+	// DefaultPushTimeout bounds a push when metrics_push.timeout is 0.
+	DefaultPushTimeout = 5 * time.Second
+)
+
+// Push sends the current values of the metrics in gatherer to the
+// Pushgateway configured by cfg, replacing the metrics previously pushed for
+// the same job and instance. It is used on shutdown, after the last poll
+// cycle, so that the final values outlive the pod.
+func Push(ctx context.Context, cfg *config.MetricsPushConfig, gatherer prometheus.Gatherer, instance string) error {
+	job := cfg.Job
+	if job == "" {
+		job = DefaultPushJob
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultPushTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Credentials in the URL are sent as basic auth rather than as part of
+	// the URL, so that push errors, which quote the URL, do not leak them.
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return fmt.Errorf("invalid metrics push URL %s: %w", cfg.RedactedURL(), err)
+	}
+	userinfo := u.User
+	u.User = nil
+	pusher := push.New(u.String(), job).Gatherer(gatherer).Grouping("instance", instance)
+	if userinfo != nil {
+		password, _ := userinfo.Password()
+		pusher = pusher.BasicAuth(userinfo.Username(), password)
+	}
+	if err := pusher.PushContext(ctx); err != nil {
+		return fmt.Errorf("failed to push metrics to %s: %w", u, err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPush(t *testing.T) {
+	var gotMethod, gotPath, gotUser, gotPassword, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		gotUser, gotPassword, _ = r.BasicAuth()
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ResetSentinelMetrics()
+	registry := prometheus.NewRegistry()
+	NewSentinelMetrics(registry, testVersion)
+	UpdateEventsPublishedMetric("clusters", "all", "message decision matched")
+
+	cfg := &config.MetricsPushConfig{URL: strings.Replace(server.URL, "http://", "http://user:secret@", 1)}
+	if err := Push(context.Background(), cfg, registry, "sentinel-a"); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+
+	if gotMethod != http.MethodPut {
+		t.Errorf("Expected PUT, got %s", gotMethod)
+	}
+	if gotPath != "/metrics/job/"+DefaultPushJob+"/instance/sentinel-a" {
+		t.Errorf("Unexpected push path %q", gotPath)
+	}
+	if gotUser != "user" || gotPassword != "secret" {
+		t.Errorf("Expected basic auth user:secret, got %s:%s", gotUser, gotPassword)
+	}
+	if !strings.Contains(gotBody, "hyperfleet_sentinel_events_published_total") {
+		t.Error("Expected pushed metrics to include events_published_total")
+	}
+}
+
+func TestPush_ErrorRedactsPassword(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := &config.MetricsPushConfig{
+		URL: strings.Replace(server.URL, "http://", "http://user:secret@", 1),
+		Job: "sentinel",
+	}
+	err := Push(context.Background(), cfg, prometheus.NewRegistry(), "sentinel-a")
+	if err == nil {
+		t.Fatal("Expected error from a failing Pushgateway")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("Expected the password to be redacted, got %v", err)
+	}
+}
@@ -0,0 +1,271 @@
+// Package outbox spools events whose publish failed to a BoltDB file, so that
+// they can be replayed once the broker recovers instead of waiting for the
+// resource's next max-age republish.
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// DefaultMaxEvents bounds each queue when clients.broker.outbox.max_events
+	// is 0.
+	DefaultMaxEvents = 10000
+
+	// lockTimeout bounds the wait for the lock of the outbox file.
+	lockTimeout = 2 * time.Second
+)
+
+var (
+	// eventsBucket holds a queue's entries by sequence number, oldest first.
+	eventsBucket = []byte("events")
+	// keysBucket maps a queue's entry keys to their sequence numbers.
+	keysBucket = []byte("keys")
+)
+
+// entry is one spooled event.
+type entry struct {
+	Event cloudevents.Event `json:"event"`
+	Key   string            `json:"key"`
+	Topic string            `json:"topic"`
+}
+
+// Outbox is a bounded, disk-backed store of events awaiting publication. It
+// holds one queue per name, so that several watchers can share the file; each
+// queue keeps at most one event per key, the latest, and at most max events,
+// dropping the oldest when full. The file is locked while open, so replicas
+// cannot share it. An Outbox is safe for concurrent use.
+type Outbox struct {
+	db  *bolt.DB
+	max int
+}
+
+// New opens the outbox configured by cfg.
+func New(cfg *config.BrokerOutboxConfig) (*Outbox, error) {
+	maxEvents := cfg.MaxEvents
+	if maxEvents == 0 {
+		maxEvents = DefaultMaxEvents
+	}
+	return Open(cfg.Path, maxEvents)
+}
+
+// Open opens or creates the outbox file at path. Each queue holds at most
+// maxEvents events, which must be positive.
+func Open(path string, maxEvents int) (*Outbox, error) {
+	if maxEvents < 1 {
+		return nil, fmt.Errorf("outbox must hold at least 1 event, got %d", maxEvents)
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: lockTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox file %s: %w", path, err)
+	}
+	return &Outbox{db: db, max: maxEvents}, nil
+}
+
+// Add spools event for topic in queue under key, replacing the event spooled
+// under the same key, and returns the number of events dropped to make room.
+func (o *Outbox) Add(queue, key, topic string, event *cloudevents.Event) (int, error) {
+	data, err := json.Marshal(entry{Event: *event, Key: key, Topic: topic})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode event %s: %w", event.ID(), err)
+	}
+	dropped := 0
+	err = o.db.Update(func(tx *bolt.Tx) error {
+		q, err := createQueue(tx, queue)
+		if err != nil {
+			return err
+		}
+		if seq := q.keys.Get([]byte(key)); seq != nil {
+			if err := q.delete(seq); err != nil {
+				return err
+			}
+		}
+		for q.len() >= o.max {
+			seq, _ := q.events.Cursor().First()
+			if seq == nil {
+				break
+			}
+			if err := q.delete(seq); err != nil {
+				return err
+			}
+			dropped++
+		}
+		return q.put(key, data)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to spool event %s: %w", event.ID(), err)
+	}
+	return dropped, nil
+}
+
+// Remove deletes the event spooled in queue under key, if any.
+func (o *Outbox) Remove(queue, key string) error {
+	return o.db.Update(func(tx *bolt.Tx) error {
+		q := getQueue(tx, queue)
+		if q == nil {
+			return nil
+		}
+		if seq := q.keys.Get([]byte(key)); seq != nil {
+			return q.delete(seq)
+		}
+		return nil
+	})
+}
+
+// Replay publishes the events of queue oldest first, removing each once
+// publish succeeds. It stops at the first failed publish, whose error it
+// returns, or when ctx is done. Entries that cannot be decoded are removed and
+// counted as dropped.
+func (o *Outbox) Replay(
+	ctx context.Context, queue string, publish func(topic string, event *cloudevents.Event) error,
+) (replayed, dropped int, err error) {
+	for ctx.Err() == nil {
+		var seq, data []byte
+		err := o.db.View(func(tx *bolt.Tx) error {
+			if q := getQueue(tx, queue); q != nil {
+				k, v := q.events.Cursor().First()
+				// Copy the entry, which is only valid inside the transaction.
+				seq, data = append(seq, k...), append(data, v...)
+			}
+			return nil
+		})
+		if err != nil {
+			return replayed, dropped, fmt.Errorf("failed to read outbox: %w", err)
+		}
+		if seq == nil {
+			return replayed, dropped, nil
+		}
+
+		var e entry
+		if json.Unmarshal(data, &e) != nil {
+			dropped++
+		} else if err := publish(e.Topic, &e.Event); err != nil {
+			return replayed, dropped, err
+		} else {
+			replayed++
+		}
+
+		// The entry may have been replaced by a newer event for its key
+		// while it was published; the newer one stays.
+		err = o.db.Update(func(tx *bolt.Tx) error {
+			if q := getQueue(tx, queue); q != nil && q.events.Get(seq) != nil {
+				return q.delete(seq)
+			}
+			return nil
+		})
+		if err != nil {
+			return replayed, dropped, fmt.Errorf("failed to remove replayed event: %w", err)
+		}
+	}
+	return replayed, dropped, ctx.Err()
+}
+
+// Len returns the number of events spooled in queue.
+func (o *Outbox) Len(queue string) (int, error) {
+	n := 0
+	err := o.db.View(func(tx *bolt.Tx) error {
+		if q := getQueue(tx, queue); q != nil {
+			n = q.len()
+		}
+		return nil
+	})
+	return n, err
+}
+
+// Close closes the file.
+func (o *Outbox) Close() error {
+	return o.db.Close()
+}
+
+// countKey holds the number of events of a queue in its bucket.
+var countKey = []byte("count")
+
+// boltQueue is the bucket of one queue, valid for one transaction. Its events
+// bucket holds the entries by sequence number, its keys bucket maps entry
+// keys to sequence numbers, and countKey holds the number of entries.
+type boltQueue struct {
+	root   *bolt.Bucket
+	events *bolt.Bucket
+	keys   *bolt.Bucket
+}
+
+// createQueue returns queue, creating its buckets if needed.
+func createQueue(tx *bolt.Tx, queue string) (*boltQueue, error) {
+	root, err := tx.CreateBucketIfNotExists([]byte(queue))
+	if err != nil {
+		return nil, err
+	}
+	q := &boltQueue{root: root}
+	if q.events, err = root.CreateBucketIfNotExists(eventsBucket); err != nil {
+		return nil, err
+	}
+	if q.keys, err = root.CreateBucketIfNotExists(keysBucket); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// getQueue returns queue, or nil if nothing was ever spooled in it.
+func getQueue(tx *bolt.Tx, queue string) *boltQueue {
+	root := tx.Bucket([]byte(queue))
+	if root == nil {
+		return nil
+	}
+	return &boltQueue{root: root, events: root.Bucket(eventsBucket), keys: root.Bucket(keysBucket)}
+}
+
+func (q *boltQueue) len() int {
+	v := q.root.Get(countKey)
+	if len(v) != 8 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(v))
+}
+
+func (q *boltQueue) setLen(n int) error {
+	return q.root.Put(countKey, binary.BigEndian.AppendUint64(nil, uint64(n)))
+}
+
+// put appends the entry data under key.
+func (q *boltQueue) put(key string, data []byte) error {
+	n, err := q.events.NextSequence()
+	if err != nil {
+		return err
+	}
+	seq := binary.BigEndian.AppendUint64(nil, n)
+	if err := q.events.Put(seq, data); err != nil {
+		return err
+	}
+	if err := q.keys.Put([]byte(key), seq); err != nil {
+		return err
+	}
+	return q.setLen(q.len() + 1)
+}
+
+// delete deletes the entry at seq, and its key unless the key has since been
+// spooled again under a newer sequence number.
+func (q *boltQueue) delete(seq []byte) error {
+	data := q.events.Get(seq)
+	if data == nil {
+		return nil
+	}
+	var e entry
+	if json.Unmarshal(data, &e) == nil && bytes.Equal(q.keys.Get([]byte(e.Key)), seq) {
+		if err := q.keys.Delete([]byte(e.Key)); err != nil {
+			return err
+		}
+	}
+	if err := q.events.Delete(seq); err != nil {
+		return err
+	}
+	return q.setLen(max(q.len()-1, 0))
+}
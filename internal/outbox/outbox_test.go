@@ -0,0 +1,164 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+func newTestEvent(id string) *cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(id)
+	event.SetType("com.redhat.hyperfleet.cluster.reconcile")
+	event.SetSource("hyperfleet-sentinel")
+	return &event
+}
+
+func openTestOutbox(t *testing.T, path string, maxEvents int) *Outbox {
+	t.Helper()
+	ob, err := Open(path, maxEvents)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	t.Cleanup(func() { _ = ob.Close() })
+	return ob
+}
+
+// replayAll replays queue and returns the "topic/id" of each event published.
+func replayAll(t *testing.T, ob *Outbox, queue string) []string {
+	t.Helper()
+	var got []string
+	_, _, err := ob.Replay(context.Background(), queue, func(topic string, event *cloudevents.Event) error {
+		got = append(got, topic+"/"+event.ID())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	return got
+}
+
+func TestOutbox_ReplayInOrder(t *testing.T) {
+	ob := openTestOutbox(t, filepath.Join(t.TempDir(), "outbox.db"), 10)
+
+	for _, e := range []struct{ key, topic, id string }{
+		{"cluster-1", "clusters", "a"},
+		{"cluster-2", "clusters", "b"},
+		{"cluster-1", "clusters", "c"}, // replaces a
+		{"nodepool-1", "nodepools", "d"},
+	} {
+		if _, err := ob.Add("clusters/all", e.key, e.topic, newTestEvent(e.id)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if _, err := ob.Add("clusters/shard:1", "cluster-3", "clusters", newTestEvent("e")); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if n, _ := ob.Len("clusters/all"); n != 3 {
+		t.Errorf("Expected 3 spooled events, got %d", n)
+	}
+
+	want := []string{"clusters/b", "clusters/c", "nodepools/d"}
+	if got := replayAll(t, ob, "clusters/all"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected replay %v, got %v", want, got)
+	}
+	if n, _ := ob.Len("clusters/all"); n != 0 {
+		t.Errorf("Expected the replayed events to be removed, got %d left", n)
+	}
+	if n, _ := ob.Len("clusters/shard:1"); n != 1 {
+		t.Errorf("Expected the other queue to be left alone, got %d events", n)
+	}
+}
+
+func TestOutbox_DropsOldestWhenFull(t *testing.T) {
+	ob := openTestOutbox(t, filepath.Join(t.TempDir(), "outbox.db"), 2)
+
+	dropped := 0
+	for _, id := range []string{"a", "b", "c"} {
+		n, err := ob.Add("q", "key-"+id, "clusters", newTestEvent(id))
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		dropped += n
+	}
+	if dropped != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", dropped)
+	}
+	if got, want := replayAll(t, ob, "q"), []string{"clusters/b", "clusters/c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected replay %v, got %v", want, got)
+	}
+}
+
+func TestOutbox_ReplayStopsAtFailure(t *testing.T) {
+	ob := openTestOutbox(t, filepath.Join(t.TempDir(), "outbox.db"), 10)
+	for _, id := range []string{"a", "b", "c"} {
+		if _, err := ob.Add("q", id, "clusters", newTestEvent(id)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	brokerDown := errors.New("broker down")
+	replayed, _, err := ob.Replay(context.Background(), "q", func(_ string, event *cloudevents.Event) error {
+		if event.ID() == "b" {
+			return brokerDown
+		}
+		return nil
+	})
+	if !errors.Is(err, brokerDown) {
+		t.Errorf("Expected the publish error, got %v", err)
+	}
+	if replayed != 1 {
+		t.Errorf("Expected 1 replayed event, got %d", replayed)
+	}
+	if got, want := replayAll(t, ob, "q"), []string{"clusters/b", "clusters/c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the failed event to stay first, got %v", got)
+	}
+}
+
+func TestOutbox_Remove(t *testing.T) {
+	ob := openTestOutbox(t, filepath.Join(t.TempDir(), "outbox.db"), 10)
+	for _, id := range []string{"a", "b"} {
+		if _, err := ob.Add("q", id, "clusters", newTestEvent(id)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	if err := ob.Remove("q", "a"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := ob.Remove("other", "a"); err != nil {
+		t.Fatalf("Remove from an empty queue failed: %v", err)
+	}
+	if got, want := replayAll(t, ob, "q"), []string{"clusters/b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected replay %v, got %v", want, got)
+	}
+}
+
+func TestOutbox_SurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.db")
+	ob, err := Open(path, 10)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if _, err := ob.Add("q", "cluster-1", "clusters", newTestEvent("a")); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := ob.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	ob = openTestOutbox(t, path, 10)
+	if got, want := replayAll(t, ob, "q"), []string{"clusters/a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the spooled event to survive a restart, got %v", got)
+	}
+}
+
+func TestOpen_RejectsEmptyBound(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "outbox.db"), 0); err == nil {
+		t.Error("Expected an outbox that holds no events to be rejected")
+	}
+}
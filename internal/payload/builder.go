@@ -9,6 +9,19 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
 )
 
+// Limits on build definitions. Build definitions come from configuration that
+// may include tenant-provided fragments, so compilation and evaluation are
+// bounded regardless of what the definition contains.
+const (
+	// maxDepth is the deepest nesting of objects a build definition may have.
+	maxDepth = 16
+	// maxExpressionLength is the longest CEL expression that is compiled.
+	maxExpressionLength = 4096
+	// evalCostLimit caps the CEL runtime cost of evaluating one expression.
+	// Evaluation that exceeds it fails like any other evaluation error.
+	evalCostLimit = 1_000_000
+)
+
 // ValueDef is the result of parsing a raw YAML node into a typed definition.
 type ValueDef struct {
 	Literal       interface{}            // constant value (int, bool, nil)
@@ -72,7 +85,7 @@ func NewBuilder(buildDef interface{}, log logger.HyperFleetLogger) (*Builder, er
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
 	}
-	compiled, err := compileMap(defMap, env)
+	compiled, err := compileMap(defMap, env, 1)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile build definition: %w", err)
 	}
@@ -80,10 +93,14 @@ func NewBuilder(buildDef interface{}, log logger.HyperFleetLogger) (*Builder, er
 }
 
 // compileMap recursively compiles a definition map into compiled nodes.
-func compileMap(def map[string]interface{}, env *cel.Env) (map[string]*compiledNode, error) {
+// depth is the nesting level of def, starting at 1.
+func compileMap(def map[string]interface{}, env *cel.Env, depth int) (map[string]*compiledNode, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("build definition is nested more than %d levels deep", maxDepth)
+	}
 	result := make(map[string]*compiledNode, len(def))
 	for key, rawVal := range def {
-		node, err := compileNode(rawVal, env)
+		node, err := compileNode(rawVal, env, depth)
 		if err != nil {
 			return nil, fmt.Errorf("key %q: %w", key, err)
 		}
@@ -93,14 +110,14 @@ func compileMap(def map[string]interface{}, env *cel.Env) (map[string]*compiledN
 }
 
 // compileNode compiles a single raw value into a compiledNode.
-func compileNode(raw interface{}, env *cel.Env) (*compiledNode, error) {
+func compileNode(raw interface{}, env *cel.Env, depth int) (*compiledNode, error) {
 	vd, err := ParseValueDef(raw)
 	if err != nil {
 		return nil, err
 	}
 	switch {
 	case vd.Children != nil:
-		children, err := compileMap(vd.Children, env)
+		children, err := compileMap(vd.Children, env, depth+1)
 		if err != nil {
 			return nil, err
 		}
@@ -111,12 +128,14 @@ func compileNode(raw interface{}, env *cel.Env) (*compiledNode, error) {
 				"a compiledNode cannot be created from an empty CEL expression",
 			vd.Expression,
 		)
+	case len(vd.Expression) > maxExpressionLength:
+		return nil, fmt.Errorf("CEL expression is %d bytes long, limit is %d", len(vd.Expression), maxExpressionLength)
 	case vd.Expression != "":
 		ast, issues := env.Compile(vd.Expression)
 		if issues != nil && issues.Err() != nil {
 			return nil, fmt.Errorf("CEL expression %q: %w", vd.Expression, issues.Err())
 		}
-		prg, err := env.Program(ast)
+		prg, err := env.Program(ast, cel.CostLimit(evalCostLimit))
 		if err != nil {
 			return nil, fmt.Errorf("CEL program for %q: %w", vd.Expression, err)
 		}
@@ -128,21 +147,40 @@ func compileNode(raw interface{}, env *cel.Env) (*compiledNode, error) {
 
 // BuildPayload builds an event payload map from the given resource and decision reason.
 // The reason is available to CEL expressions as the "reason" variable.
-// ctx is used for correlated warning logs if CEL evaluation fails.
+// A field whose expression fails is omitted and logged with its path and the
+// resource ID; the other fields are still built. ctx is used for correlated
+// warning logs.
 func (b *Builder) BuildPayload(ctx context.Context, resource *client.Resource, reason string) map[string]interface{} {
-	return b.evalCompiledMap(ctx, b.compiled, resource.ToMap(), reason)
+	ev := evaluation{resourceID: resource.ID, resourceMap: resource.ToMap(), reason: reason}
+	return b.evalCompiledMap(ctx, b.compiled, ev, "")
+}
+
+// evaluation is the input of one BuildPayload call.
+type evaluation struct {
+	resourceID  string
+	resourceMap map[string]interface{}
+	reason      string
+}
+
+// fieldPath joins a parent field path and a key with a dot.
+func fieldPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
 }
 
-// evalCompiledMap evaluates a compiled map against the resource and reason.
+// evalCompiledMap evaluates a compiled map, found at path in the payload,
+// against the resource and reason.
 func (b *Builder) evalCompiledMap(
 	ctx context.Context,
 	nodes map[string]*compiledNode,
-	resourceMap map[string]interface{},
-	reason string,
+	ev evaluation,
+	path string,
 ) map[string]interface{} {
 	result := make(map[string]interface{})
 	for key, node := range nodes {
-		val := b.evalCompiledNode(ctx, node, resourceMap, reason)
+		val := b.evalCompiledNode(ctx, node, ev, fieldPath(path, key))
 		if val != nil {
 			result[key] = val
 		}
@@ -150,16 +188,17 @@ func (b *Builder) evalCompiledMap(
 	return result
 }
 
-// evalCompiledNode evaluates a single compiled node.
-// Returns nil for missing or null values (fail-safe: misconfigured fields are omitted).
+// evalCompiledNode evaluates a single compiled node found at path in the
+// payload. Returns nil for missing or null values (fail-safe: misconfigured
+// fields are omitted).
 func (b *Builder) evalCompiledNode(
 	ctx context.Context,
 	node *compiledNode,
-	resourceMap map[string]interface{},
-	reason string,
+	ev evaluation,
+	path string,
 ) interface{} {
 	if node.children != nil {
-		nested := b.evalCompiledMap(ctx, node.children, resourceMap, reason)
+		nested := b.evalCompiledMap(ctx, node.children, ev, path)
 		if len(nested) == 0 {
 			return nil
 		}
@@ -167,15 +206,17 @@ func (b *Builder) evalCompiledNode(
 	}
 	if node.program != nil {
 		out, _, err := node.program.Eval(map[string]interface{}{
-			"resource": resourceMap,
-			"reason":   reason,
+			"resource": ev.resourceMap,
+			"reason":   ev.reason,
 		})
 		if err != nil {
-			b.log.Warnf(ctx, "CEL expression evaluation failed: %v", err)
+			b.log.Warnf(ctx, "message_data field omitted: CEL expression evaluation failed field=%s resource_id=%s error=%v",
+				path, ev.resourceID, err)
 			return nil
 		}
 		if out == nil {
-			b.log.Warn(ctx, "CEL expression evaluated to nil")
+			b.log.Warnf(ctx, "message_data field omitted: CEL expression evaluated to nil field=%s resource_id=%s",
+				path, ev.resourceID)
 			return nil
 		}
 		return out.Value()
@@ -2,6 +2,7 @@ package payload
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -107,6 +108,47 @@ func TestNewBuilder_NilValueReturnsError(t *testing.T) {
 	}
 }
 
+func TestNewBuilder_TooDeepReturnsError(t *testing.T) {
+	buildDef := map[string]interface{}{"id": "resource.id"}
+	for i := 0; i < maxDepth; i++ {
+		buildDef = map[string]interface{}{"nested": buildDef}
+	}
+	_, err := NewBuilder(buildDef, logger.NewHyperFleetLogger())
+	if err == nil || !strings.Contains(err.Error(), "levels deep") {
+		t.Fatalf("expected nesting depth error, got %v", err)
+	}
+}
+
+func TestNewBuilder_LongExpressionReturnsError(t *testing.T) {
+	buildDef := map[string]interface{}{
+		"id": "resource.id + '" + strings.Repeat("x", maxExpressionLength) + "'",
+	}
+	_, err := NewBuilder(buildDef, logger.NewHyperFleetLogger())
+	if err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Fatalf("expected expression length error, got %v", err)
+	}
+}
+
+func TestBuildPayload_CostLimitOmitsField(t *testing.T) {
+	buildDef := map[string]interface{}{
+		"id": "resource.id",
+		"slow": "[1,2,3,4,5,6,7,8,9,10].map(a, [1,2,3,4,5,6,7,8,9,10].map(b, [1,2,3,4,5,6,7,8,9,10]" +
+			".map(c, [1,2,3,4,5,6,7,8,9,10].map(d, [1,2,3,4,5,6,7,8,9,10].map(e, [1,2,3,4,5,6,7,8,9,10]" +
+			".map(f, a+b+c+d+e+f))))))",
+	}
+	b, err := NewBuilder(buildDef, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewBuilder failed: %v", err)
+	}
+	payload := b.BuildPayload(context.Background(), makeTestResource(), "")
+	if payload["id"] != testClusterID {
+		t.Errorf("expected id %q, got %v", testClusterID, payload["id"])
+	}
+	if _, ok := payload["slow"]; ok {
+		t.Error("expected expression exceeding the cost limit to be omitted")
+	}
+}
+
 // ============================================================================
 // BuildPayload Tests
 // ============================================================================
@@ -237,6 +279,44 @@ func TestBuildPayload_MissingFieldOmitted(t *testing.T) {
 	}
 }
 
+func TestBuildPayload_FailedFieldLoggedWithPath(t *testing.T) {
+	buildDef := map[string]interface{}{
+		"id": "resource.id",
+		"placement": map[string]interface{}{
+			"region": "resource.spec.region",
+			"zone":   "resource.nonexistent_field",
+		},
+	}
+	log := logger.NewMockLogger()
+	b, err := NewBuilder(buildDef, log)
+	if err != nil {
+		t.Fatalf("NewBuilder failed: %v", err)
+	}
+
+	resource := makeTestResource()
+	resource.Spec = map[string]interface{}{"region": "us-east-1"}
+	payload := b.BuildPayload(context.Background(), resource, "")
+
+	placement, ok := payload["placement"].(map[string]interface{})
+	if !ok || placement["region"] != "us-east-1" {
+		t.Fatalf("expected placement.region to be built, got %v", payload["placement"])
+	}
+	if _, exists := placement["zone"]; exists {
+		t.Errorf("expected placement.zone to be omitted, got %v", placement["zone"])
+	}
+	if payload["id"] != testClusterID {
+		t.Errorf("expected id %q, got %v", testClusterID, payload["id"])
+	}
+
+	if len(*log.CapturedLogs) != 1 {
+		t.Fatalf("expected one warning, got %v", *log.CapturedLogs)
+	}
+	msg := (*log.CapturedLogs)[0]
+	if !strings.Contains(msg, "field=placement.zone") || !strings.Contains(msg, "resource_id="+testClusterID) {
+		t.Errorf("expected warning to name the field and resource, got %q", msg)
+	}
+}
+
 func TestBuildPayload_CELStringLiteral(t *testing.T) {
 	buildDef := map[string]interface{}{
 		"origin": `"hyperfleet-sentinel"`,
@@ -387,3 +467,60 @@ func TestBuildPayload_WithNameSpecReferences(t *testing.T) {
 		t.Errorf("expected name 'my-cluster', got %v", payload["name"])
 	}
 }
+
+func TestBuildPayload_SpecAndLabels(t *testing.T) {
+	buildDef := map[string]interface{}{
+		"region":   "resource.spec.region",
+		"platform": "resource.spec.platform.type",
+		"nodes":    "resource.spec.nodes.size()",
+		"shard":    "resource.labels['shard']",
+		"missing":  "resource.spec.missing",
+	}
+	b, err := NewBuilder(buildDef, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewBuilder failed: %v", err)
+	}
+
+	resource := makeTestResource()
+	resource.Labels = map[string]string{"shard": "3"}
+	resource.Spec = map[string]interface{}{
+		"region":   "us-east-1",
+		"platform": map[string]interface{}{"type": "gcp"},
+		"nodes":    []interface{}{"a", "b"},
+	}
+
+	payload := b.BuildPayload(context.Background(), resource, "test-reason")
+
+	want := map[string]interface{}{"region": "us-east-1", "platform": "gcp", "nodes": int64(2), "shard": "3"}
+	for key, v := range want {
+		if payload[key] != v {
+			t.Errorf("expected %s %v (%T), got %v (%T)", key, v, v, payload[key], payload[key])
+		}
+	}
+	if _, ok := payload["missing"]; ok {
+		t.Errorf("expected missing spec field to be omitted, got %v", payload["missing"])
+	}
+}
+
+// ============================================================================
+// Fuzz Tests
+// ============================================================================
+
+func FuzzBuildPayload(f *testing.F) {
+	f.Add("resource.id", "")
+	f.Add("resource.labels['region']", "message_decision")
+	f.Add("reason == '' ? 'none' : reason", "x")
+	f.Add("resource.status.conditions.filter(c, c.type == 'Reconciled').size()", "")
+	f.Add("'\x00' + resource.kind", "\n")
+	f.Add("[1,2,3].map(a, [1,2,3].map(b, a*b))", "")
+
+	log := logger.NewHyperFleetLogger()
+	resource := makeTestResource()
+	f.Fuzz(func(t *testing.T, expr, reason string) {
+		b, err := NewBuilder(map[string]interface{}{"value": expr, "nested": map[string]interface{}{"value": expr}}, log)
+		if err != nil {
+			return
+		}
+		b.BuildPayload(context.Background(), resource, reason)
+	})
+}
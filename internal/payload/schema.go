@@ -0,0 +1,445 @@
+package payload
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultSchema is the schema used when message_schema names no file. It
+// checks the types of the conventional fields decoded by pkg/events and
+// requires id.
+//
+//go:embed schema.json
+var defaultSchema []byte
+
+// Schema is a compiled JSON Schema for event data. It supports the subset of
+// JSON Schema (draft 2020-12) that describes event payloads: type, enum,
+// const, properties, required, additionalProperties, items, minLength,
+// maxLength, pattern, minimum, maximum, minItems, and maxItems. Annotations
+// such as title and description are ignored. Other keywords, such as $ref
+// and allOf, are rejected when the schema is compiled rather than silently
+// skipped. Patterns use Go regular expression syntax.
+type Schema struct {
+	root *schemaNode
+}
+
+// schemaNode is one compiled (sub)schema. A nil *schemaNode accepts any
+// value.
+type schemaNode struct {
+	properties           map[string]*schemaNode
+	additionalProperties *schemaNode
+	items                *schemaNode
+	pattern              *regexp.Regexp
+	minLength            *int
+	maxLength            *int
+	minItems             *int
+	maxItems             *int
+	minimum              *float64
+	maximum              *float64
+	types                []string
+	enum                 []any
+	required             []string
+	// reject is set for the false schema, which accepts no value.
+	reject bool
+	// noAdditional is set when additionalProperties is false.
+	noAdditional bool
+}
+
+// annotations are keywords that do not affect validation.
+var annotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true,
+	"default": true, "examples": true, "deprecated": true, "readOnly": true, "writeOnly": true,
+	"format": true,
+}
+
+// jsonTypes are the values of the type keyword.
+var jsonTypes = []string{"null", "boolean", "object", "array", "number", "string", "integer"}
+
+// Violation is a part of event data that does not match the schema.
+type Violation struct {
+	// Field is the path of the offending field, e.g. "owner_references.id"
+	// or "conditions[0].type", or "" for the data itself.
+	Field   string
+	Message string
+}
+
+// String returns the violation as "field: message".
+func (v Violation) String() string {
+	if v.Field == "" {
+		return v.Message
+	}
+	return v.Field + ": " + v.Message
+}
+
+// LoadSchema reads and compiles the JSON Schema at path, or the default
+// schema shipped with the Sentinel when path is empty.
+func LoadSchema(path string) (*Schema, error) {
+	if path == "" {
+		return CompileSchema(defaultSchema)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema file: %w", err)
+	}
+	return CompileSchema(raw)
+}
+
+// CompileSchema compiles a JSON Schema document.
+func CompileSchema(raw []byte) (*Schema, error) {
+	doc, err := decodeJSON(raw)
+	if err != nil {
+		return nil, fmt.Errorf("decoding schema: %w", err)
+	}
+	root, err := compileSchemaNode(doc, "")
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{root: root}, nil
+}
+
+// decodeJSON decodes raw with numbers kept as json.Number, so that integers
+// are told apart from other numbers.
+func decodeJSON(raw []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return v, nil
+}
+
+// compileSchemaNode compiles the schema raw found at path in the schema
+// document.
+func compileSchemaNode(raw any, path string) (*schemaNode, error) {
+	switch v := raw.(type) {
+	case bool:
+		if v {
+			return nil, nil
+		}
+		return &schemaNode{reject: true}, nil
+	case map[string]any:
+		return compileSchemaObject(v, path)
+	default:
+		return nil, fmt.Errorf("schema %s: must be an object or a boolean", schemaPath(path))
+	}
+}
+
+func compileSchemaObject(m map[string]any, path string) (*schemaNode, error) {
+	node := &schemaNode{}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		value := m[key]
+		keyPath := fieldPath(path, key)
+		var err error
+		switch key {
+		case "type":
+			node.types, err = compileTypes(value)
+		case "enum":
+			list, ok := value.([]any)
+			if !ok {
+				err = fmt.Errorf("must be an array")
+			}
+			node.enum = list
+		case "const":
+			node.enum = []any{value}
+		case "properties":
+			// Errors of nested schemas carry their own path.
+			if node.properties, err = compileProperties(value, keyPath); err != nil {
+				return nil, err
+			}
+		case "required":
+			node.required, err = compileStrings(value)
+		case "additionalProperties":
+			if value == false {
+				node.noAdditional = true
+			} else if node.additionalProperties, err = compileSchemaNode(value, keyPath); err != nil {
+				return nil, err
+			}
+		case "items":
+			if node.items, err = compileSchemaNode(value, keyPath); err != nil {
+				return nil, err
+			}
+		case "pattern":
+			s, ok := value.(string)
+			if !ok {
+				err = fmt.Errorf("must be a string")
+			} else {
+				node.pattern, err = regexp.Compile(s)
+			}
+		case "minLength":
+			node.minLength, err = compileCount(value)
+		case "maxLength":
+			node.maxLength, err = compileCount(value)
+		case "minItems":
+			node.minItems, err = compileCount(value)
+		case "maxItems":
+			node.maxItems, err = compileCount(value)
+		case "minimum":
+			node.minimum, err = compileNumber(value)
+		case "maximum":
+			node.maximum, err = compileNumber(value)
+		default:
+			if !annotations[key] {
+				err = fmt.Errorf("unsupported keyword")
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", schemaPath(keyPath), err)
+		}
+	}
+	return node, nil
+}
+
+func compileTypes(value any) ([]string, error) {
+	var types []string
+	switch v := value.(type) {
+	case string:
+		types = []string{v}
+	case []any:
+		var err error
+		if types, err = compileStrings(v); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("must be a string or an array of strings")
+	}
+	for _, t := range types {
+		if !slices.Contains(jsonTypes, t) {
+			return nil, fmt.Errorf("unknown type %q", t)
+		}
+	}
+	return types, nil
+}
+
+func compileProperties(value any, path string) (map[string]*schemaNode, error) {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("must be an object")
+	}
+	properties := make(map[string]*schemaNode, len(m))
+	for name, raw := range m {
+		node, err := compileSchemaNode(raw, fieldPath(path, name))
+		if err != nil {
+			return nil, err
+		}
+		properties[name] = node
+	}
+	return properties, nil
+}
+
+func compileStrings(value any) ([]string, error) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("must be an array of strings")
+	}
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be an array of strings")
+		}
+		strs = append(strs, s)
+	}
+	return strs, nil
+}
+
+func compileCount(value any) (*int, error) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("must be a non-negative integer")
+	}
+	i, err := n.Int64()
+	if err != nil || i < 0 {
+		return nil, fmt.Errorf("must be a non-negative integer")
+	}
+	count := int(i)
+	return &count, nil
+}
+
+func compileNumber(value any) (*float64, error) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("must be a number")
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("must be a number")
+	}
+	return &f, nil
+}
+
+// schemaPath names a location in the schema document for errors.
+func schemaPath(path string) string {
+	if path == "" {
+		return "root"
+	}
+	return path
+}
+
+// Validate checks JSON event data against the schema and returns every
+// violation found, or nil if the data matches.
+func (s *Schema) Validate(data []byte) []Violation {
+	value, err := decodeJSON(data)
+	if err != nil {
+		return []Violation{{Message: fmt.Sprintf("data is not valid JSON: %v", err)}}
+	}
+	var violations []Violation
+	s.root.validate(value, "", &violations)
+	return violations
+}
+
+func (n *schemaNode) validate(value any, path string, violations *[]Violation) {
+	if n == nil {
+		return
+	}
+	report := func(format string, args ...any) {
+		*violations = append(*violations, Violation{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+	if n.reject {
+		report("no value is allowed")
+		return
+	}
+	if len(n.types) > 0 && !slices.ContainsFunc(n.types, func(t string) bool { return hasType(value, t) }) {
+		report("expected %s, got %s", strings.Join(n.types, " or "), typeOf(value))
+		return
+	}
+	if n.enum != nil && !slices.ContainsFunc(n.enum, func(e any) bool { return jsonEqual(e, value) }) {
+		report("value is not one of the allowed values")
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+		if n.minLength != nil && length < *n.minLength {
+			report("must be at least %d characters long", *n.minLength)
+		}
+		if n.maxLength != nil && length > *n.maxLength {
+			report("must be at most %d characters long", *n.maxLength)
+		}
+		if n.pattern != nil && !n.pattern.MatchString(v) {
+			report("must match pattern %q", n.pattern)
+		}
+	case json.Number:
+		f, _ := v.Float64()
+		if n.minimum != nil && f < *n.minimum {
+			report("must be at least %v", *n.minimum)
+		}
+		if n.maximum != nil && f > *n.maximum {
+			report("must be at most %v", *n.maximum)
+		}
+	case []any:
+		if n.minItems != nil && len(v) < *n.minItems {
+			report("must have at least %d items", *n.minItems)
+		}
+		if n.maxItems != nil && len(v) > *n.maxItems {
+			report("must have at most %d items", *n.maxItems)
+		}
+		for i, item := range v {
+			n.items.validate(item, fmt.Sprintf("%s[%d]", path, i), violations)
+		}
+	case map[string]any:
+		n.validateObject(v, path, violations)
+	}
+}
+
+func (n *schemaNode) validateObject(m map[string]any, path string, violations *[]Violation) {
+	for _, name := range n.required {
+		if _, ok := m[name]; !ok {
+			*violations = append(*violations, Violation{Field: fieldPath(path, name), Message: "required field is missing"})
+		}
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if prop, ok := n.properties[key]; ok {
+			prop.validate(m[key], fieldPath(path, key), violations)
+			continue
+		}
+		if n.noAdditional {
+			*violations = append(*violations, Violation{Field: fieldPath(path, key), Message: "field is not allowed"})
+			continue
+		}
+		n.additionalProperties.validate(m[key], fieldPath(path, key), violations)
+	}
+}
+
+// hasType reports whether value is of the JSON Schema type t.
+func hasType(value any, t string) bool {
+	if t == "integer" {
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	}
+	return typeOf(value) == t
+}
+
+// typeOf returns the JSON Schema type of a decoded JSON value; numbers are
+// "number".
+func typeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// jsonEqual reports whether two decoded JSON values are equal, comparing
+// numbers by value.
+func jsonEqual(a, b any) bool {
+	return reflect.DeepEqual(normalizeNumbers(a), normalizeNumbers(b))
+}
+
+func normalizeNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = normalizeNumbers(item)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = normalizeNumbers(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,162 @@
+package payload
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDefaultSchema(t *testing.T) {
+	schema, err := LoadSchema("")
+	if err != nil {
+		t.Fatalf("LoadSchema failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "conventional fields",
+			data: `{"id":"cls-abc","kind":"Cluster","href":"/api/v1/clusters/cls-abc","generation":3,` +
+				`"owner_references":{"id":"org-1"},"custom":{"any":true}}`,
+		},
+		{name: "only id", data: `{"id":"cls-abc"}`},
+		{name: "missing id", data: `{"kind":"Cluster"}`, want: []string{"id: required field is missing"}},
+		{
+			name: "wrong types",
+			data: `{"id":"","generation":"3","owner_references":{"id":7}}`,
+			want: []string{
+				"generation: expected integer, got string",
+				"id: must be at least 1 characters long",
+				"owner_references.id: expected string, got number",
+			},
+		},
+		{
+			name: "fractional generation",
+			data: `{"id":"a","generation":1.5}`,
+			want: []string{"generation: expected integer, got number"},
+		},
+		{name: "not an object", data: `["cls-abc"]`, want: []string{"expected object, got array"}},
+		{name: "invalid JSON", data: `{"id":`, want: []string{"data is not valid JSON: unexpected EOF"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range schema.Validate([]byte(tt.data)) {
+				got = append(got, v.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema_Keywords(t *testing.T) {
+	schema, err := CompileSchema([]byte(`{
+		"type": "object",
+		"properties": {
+			"phase": {"enum": ["Ready", "Deleting"]},
+			"region": {"type": "string", "pattern": "^[a-z]+-[a-z]+$", "maxLength": 12},
+			"replicas": {"type": "integer", "minimum": 1, "maximum": 3},
+			"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 2},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"version": {"const": 2},
+			"parent": {"type": ["string", "null"]}
+		},
+		"additionalProperties": false
+	}`))
+	if err != nil {
+		t.Fatalf("CompileSchema failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "valid",
+			data: `{"phase":"Ready","region":"us-east","replicas":3,"tags":["a"],"labels":{"team":"x"},` +
+				`"version":2.0,"parent":null}`,
+		},
+		{name: "enum", data: `{"phase":"Failed"}`, want: []string{"phase: value is not one of the allowed values"}},
+		{name: "const", data: `{"version":3}`, want: []string{"version: value is not one of the allowed values"}},
+		{name: "pattern", data: `{"region":"US"}`, want: []string{`region: must match pattern "^[a-z]+-[a-z]+$"`}},
+		{name: "maxLength", data: `{"region":"us-northeastx"}`, want: []string{"region: must be at most 12 characters long"}},
+		{name: "minimum", data: `{"replicas":0}`, want: []string{"replicas: must be at least 1"}},
+		{name: "maximum", data: `{"replicas":4}`, want: []string{"replicas: must be at most 3"}},
+		{
+			name: "items",
+			data: `{"tags":["a",1,"c"]}`,
+			want: []string{"tags: must have at most 2 items", "tags[1]: expected string, got number"},
+		},
+		{name: "minItems", data: `{"tags":[]}`, want: []string{"tags: must have at least 1 items"}},
+		{
+			name: "additionalProperties schema",
+			data: `{"labels":{"team":1}}`,
+			want: []string{"labels.team: expected string, got number"},
+		},
+		{name: "additionalProperties false", data: `{"owner":"x"}`, want: []string{"owner: field is not allowed"}},
+		{name: "type list", data: `{"parent":1}`, want: []string{"parent: expected string or null, got number"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range schema.Validate([]byte(tt.data)) {
+				got = append(got, v.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{name: "invalid JSON", schema: `{`, wantErr: "decoding schema"},
+		{name: "not a schema", schema: `"object"`, wantErr: "schema root: must be an object or a boolean"},
+		{name: "unsupported keyword", schema: `{"allOf":[]}`, wantErr: "schema allOf: unsupported keyword"},
+		{
+			name:    "nested unsupported keyword",
+			schema:  `{"properties":{"id":{"$ref":"#/defs/id"}}}`,
+			wantErr: "schema properties.id.$ref: unsupported keyword",
+		},
+		{name: "unknown type", schema: `{"type":"int"}`, wantErr: `schema type: unknown type "int"`},
+		{name: "negative count", schema: `{"minLength":-1}`, wantErr: "schema minLength: must be a non-negative integer"},
+		{name: "invalid pattern", schema: `{"pattern":"("}`, wantErr: "schema pattern:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileSchema([]byte(tt.schema))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CompileSchema() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadSchema_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(path, []byte(`{"required":["href"]}`), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	schema, err := LoadSchema(path)
+	if err != nil {
+		t.Fatalf("LoadSchema failed: %v", err)
+	}
+	if got := schema.Validate([]byte(`{"id":"cls-abc"}`)); len(got) != 1 || got[0].Field != "href" {
+		t.Errorf("Expected a missing href, got %v", got)
+	}
+
+	if _, err := LoadSchema(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected an error for a missing schema file")
+	}
+}
@@ -0,0 +1,184 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill-amqp/v3/pkg/amqp"
+	"github.com/ThreeDotsLabs/watermill/message"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/openshift-hyperfleet/hyperfleet-broker/broker"
+	brokerlogger "github.com/openshift-hyperfleet/hyperfleet-broker/pkg/logger"
+	amqp091 "github.com/rabbitmq/amqp091-go"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+)
+
+// AMQPBrokerType is the broker type reported by the AMQP publisher, the same
+// as the broker library's.
+const AMQPBrokerType = "rabbitmq"
+
+// AMQPConfig holds the broker library settings the AMQP publisher uses.
+type AMQPConfig struct {
+	URL              string
+	Exchange         string
+	ExchangeType     string
+	RoutingKey       string
+	PublisherConfirm bool
+}
+
+// RabbitMQConfig returns the RabbitMQ settings of a broker library
+// configuration if it selects RabbitMQ, and nil otherwise. The configuration
+// is read like that of PubSubBrokerConfig.
+func RabbitMQConfig(configMap map[string]string) (*AMQPConfig, error) {
+	settings, err := loadBrokerSettings(configMap)
+	if err != nil || settings.get("broker.type") != AMQPBrokerType {
+		return nil, err
+	}
+	confirm, err := settings.getBool("broker.rabbitmq.publisher_confirm")
+	if err != nil {
+		return nil, err
+	}
+	return &AMQPConfig{
+		URL:              settings.get("broker.rabbitmq.url"),
+		Exchange:         settings.get("broker.rabbitmq.exchange"),
+		ExchangeType:     settings.get("broker.rabbitmq.exchange_type"),
+		RoutingKey:       settings.get("broker.rabbitmq.routing_key"),
+		PublisherConfirm: confirm,
+	}, nil
+}
+
+// AMQPMarshaler marshals messages as the broker library does, a structured
+// CloudEvent with persistent delivery, and maps the message options of the
+// event to AMQP: events.ExpiryTimeExtension to the per-message TTL
+// (expiration), events.PriorityExtension to the priority, and the
+// clients.broker.message_options headers to AMQP headers.
+type AMQPMarshaler struct {
+	amqp.DefaultMarshaler
+}
+
+// Marshal marshals msg, whose payload is a structured CloudEvent.
+func (m AMQPMarshaler) Marshal(msg *message.Message) (amqp091.Publishing, error) {
+	publishing, err := m.DefaultMarshaler.Marshal(msg)
+	if err != nil {
+		return publishing, err
+	}
+	var event cloudevents.Event
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		return publishing, fmt.Errorf("decoding event %s: %w", msg.UUID, err)
+	}
+	for name, value := range event.Extensions() {
+		switch {
+		case name == events.ExpiryTimeExtension:
+			expiry, err := types.ToTime(value)
+			if err != nil {
+				return publishing, fmt.Errorf("event %s: %s: %w", msg.UUID, name, err)
+			}
+			// RabbitMQ drops a message whose TTL has passed when it reaches
+			// the head of its queue.
+			publishing.Expiration = strconv.FormatInt(max(time.Until(expiry).Milliseconds(), 0), 10)
+		case name == events.PriorityExtension:
+			priority, err := types.ToInteger(value)
+			if err != nil || priority < 0 || priority > math.MaxUint8 {
+				return publishing, fmt.Errorf("event %s: %s must be between 0 and 255, got %v", msg.UUID, name, value)
+			}
+			publishing.Priority = uint8(priority)
+		case !events.IsReservedAttribute(name):
+			// The Sentinel sets no other extensions than reserved ones and
+			// the headers of message_options.
+			publishing.Headers[name] = fmt.Sprint(value)
+		}
+	}
+	return publishing, nil
+}
+
+// AMQPPublisher publishes events to RabbitMQ like the broker library's
+// publisher, with the broker library's exchange, routing key, and confirm
+// settings, and also maps their message options to AMQP properties and
+// headers with AMQPMarshaler, which the broker library does not, so that
+// RabbitMQ expires and prioritizes messages as configured by
+// clients.broker.message_options. It records the broker library's publish
+// metrics.
+type AMQPPublisher struct {
+	pub     *amqp.Publisher
+	metrics *broker.MetricsRecorder
+}
+
+var _ Publisher = (*AMQPPublisher)(nil)
+
+// NewAMQPPublisher creates an AMQPPublisher connected to the broker of cfg.
+// log receives the AMQP client's logs; metrics may be nil.
+func NewAMQPPublisher(
+	cfg *AMQPConfig, log brokerlogger.Logger, metrics *broker.MetricsRecorder,
+) (*AMQPPublisher, error) {
+	if cfg == nil || cfg.URL == "" {
+		return nil, fmt.Errorf("rabbitmq.url is required")
+	}
+	amqpConfig := amqp.NewDurablePubSubConfig(cfg.URL, amqp.GenerateQueueNameTopicNameWithSuffix(""))
+	if cfg.ExchangeType != "" {
+		amqpConfig.Exchange.Type = cfg.ExchangeType
+	}
+	if exchange := cfg.Exchange; exchange != "" {
+		amqpConfig.Exchange.GenerateName = func(string) string { return exchange }
+	}
+	if routingKey := cfg.RoutingKey; routingKey != "" {
+		amqpConfig.Publish.GenerateRoutingKey = func(string) string { return routingKey }
+	}
+	amqpConfig.Publish.ConfirmDelivery = cfg.PublisherConfirm
+	amqpConfig.Marshaler = AMQPMarshaler{}
+
+	pub, err := amqp.NewPublisher(amqpConfig, brokerlogger.NewWatermillLoggerAdapter(log, context.Background()))
+	if err != nil {
+		return nil, fmt.Errorf("creating AMQP publisher: %w", err)
+	}
+	return &AMQPPublisher{pub: pub, metrics: metrics}, nil
+}
+
+// Publish publishes event to topic.
+func (p *AMQPPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	start := time.Now()
+	payload, err := json.Marshal(event)
+	if err != nil {
+		p.recordError(topic, "conversion")
+		return fmt.Errorf("encoding event %s: %w", event.ID(), err)
+	}
+	msg := message.NewMessage(event.ID(), payload)
+	msg.SetContext(ctx)
+	if err := p.pub.Publish(topic, msg); err != nil {
+		p.recordError(topic, "publish")
+		return err
+	}
+	if p.metrics != nil {
+		p.metrics.RecordPublished(topic)
+		p.metrics.RecordDuration(topic, time.Since(start))
+	}
+	return nil
+}
+
+func (p *AMQPPublisher) recordError(topic, errorType string) {
+	if p.metrics != nil {
+		p.metrics.RecordError(topic, errorType)
+	}
+}
+
+// Health reports whether the connection to RabbitMQ is established.
+func (p *AMQPPublisher) Health(ctx context.Context) error {
+	if p.pub.Closed() {
+		return fmt.Errorf("RabbitMQ connection is closed")
+	}
+	if !p.pub.IsConnected() {
+		return fmt.Errorf("RabbitMQ connection is not established")
+	}
+	return nil
+}
+
+// Close closes the connection to RabbitMQ.
+func (p *AMQPPublisher) Close() error { return p.pub.Close() }
+
+// BrokerType returns AMQPBrokerType.
+func (p *AMQPPublisher) BrokerType() string { return AMQPBrokerType }
@@ -0,0 +1,209 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+// asyncRequeueDelay is the wait before an event whose publish failed is
+// queued again, so that a failing broker is not retried in a busy loop.
+const asyncRequeueDelay = time.Second
+
+// ErrAsyncQueueFull is returned by AsyncPublisher.Publish when the queue
+// holds queue_size unconfirmed events.
+var ErrAsyncQueueFull = errors.New("async publish queue is full")
+
+// ErrAsyncPublisherClosed is returned by AsyncPublisher.Publish once Close
+// has been called.
+var ErrAsyncPublisherClosed = errors.New("async publisher is closed")
+
+// AsyncPublisher decouples sending events from waiting for the broker to
+// confirm them. Publish only queues the event and returns; up to maxInFlight
+// goroutines publish queued events through the wrapped publisher, whose
+// successful return is the confirm. An event whose publish fails is queued
+// again after a delay until it has been attempted maxAttempts times, then
+// dropped and logged. Events are counted in unconfirmed_events until they
+// are confirmed or dropped, and each outcome in publish_confirms_total.
+//
+// A publish whose context was marked by WithConfirm bypasses the queue and
+// waits for the confirm.
+type AsyncPublisher struct {
+	Publisher
+	log              logger.HyperFleetLogger
+	queue            chan *asyncEvent
+	stop             chan struct{}
+	unconfirmed      map[metricsLabels]int
+	resourceType     string
+	resourceSelector string
+	workers          sync.WaitGroup
+	pending          sync.WaitGroup
+	requeueDelay     time.Duration
+	maxAttempts      int
+	mu               sync.Mutex
+	closed           bool
+}
+
+var _ Publisher = (*AsyncPublisher)(nil)
+
+// asyncEvent is a queued event and the publish attempts made for it.
+type asyncEvent struct {
+	ctx      context.Context
+	event    *cloudevents.Event
+	topic    string
+	labels   metricsLabels
+	attempts int
+}
+
+// NewAsyncPublisher wraps pub so that events are published in the
+// background as configured by cfg, and starts the goroutines that publish
+// them. Events are counted with resourceType and resourceSelector unless
+// their context carries other labels; see WithMetricsLabels.
+// metrics.NewSentinelMetrics must have been called.
+func NewAsyncPublisher(
+	pub Publisher, cfg *config.BrokerAsyncPublishConfig, resourceType, resourceSelector string,
+	log logger.HyperFleetLogger,
+) *AsyncPublisher {
+	p := &AsyncPublisher{
+		Publisher:        pub,
+		log:              log,
+		queue:            make(chan *asyncEvent, cfg.QueueSize),
+		stop:             make(chan struct{}),
+		unconfirmed:      make(map[metricsLabels]int),
+		resourceType:     resourceType,
+		resourceSelector: resourceSelector,
+		requeueDelay:     asyncRequeueDelay,
+		maxAttempts:      cfg.MaxAttempts,
+	}
+	for range cfg.MaxInFlight {
+		p.workers.Go(p.run)
+	}
+	return p
+}
+
+// confirmKey is the context key under which WithConfirm marks a publish.
+type confirmKey struct{}
+
+// WithConfirm returns a copy of ctx whose publishes through an
+// AsyncPublisher wait for the broker to confirm them, e.g. for topic probes
+// whose result matters to the caller.
+func WithConfirm(ctx context.Context) context.Context {
+	return context.WithValue(ctx, confirmKey{}, true)
+}
+
+// Publish queues event for publishing to topic and returns without waiting
+// for the broker, or fails at once with ErrAsyncQueueFull. With a context
+// marked by WithConfirm, it publishes event and waits instead.
+func (p *AsyncPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	if confirm, _ := ctx.Value(confirmKey{}).(bool); confirm {
+		return p.Publisher.Publish(ctx, topic, event)
+	}
+
+	labels := metricsLabels{resourceType: p.resourceType, resourceSelector: p.resourceSelector}
+	if l, ok := ctx.Value(metricsLabelsKey{}).(metricsLabels); ok {
+		labels = l
+	}
+	// The event is published after Publish returned, while the caller
+	// reuses it, and outlives the context of the publish.
+	e := event.Clone()
+	ae := &asyncEvent{ctx: context.WithoutCancel(ctx), event: &e, topic: topic, labels: labels}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrAsyncPublisherClosed
+	}
+	p.pending.Add(1)
+	select {
+	case p.queue <- ae:
+	default:
+		p.pending.Done()
+		return fmt.Errorf("publishing event %s to topic %s: %w", event.ID(), topic, ErrAsyncQueueFull)
+	}
+	p.addUnconfirmed(labels, 1)
+	return nil
+}
+
+// run publishes queued events until stop is closed.
+func (p *AsyncPublisher) run() {
+	for {
+		select {
+		case <-p.stop:
+			return
+		case ae := <-p.queue:
+			p.attempt(ae)
+		}
+	}
+}
+
+// attempt publishes ae once and records the outcome: confirmed, re-queued,
+// or dropped.
+func (p *AsyncPublisher) attempt(ae *asyncEvent) {
+	ae.attempts++
+	err := p.Publisher.Publish(ae.ctx, ae.topic, ae.event)
+	if err == nil {
+		p.done(ae, "confirmed")
+		return
+	}
+
+	if ae.attempts >= p.maxAttempts {
+		p.log.Errorf(ae.ctx, "Dropped unconfirmed event topic=%s event_id=%s attempts=%d error=%v",
+			ae.topic, ae.event.ID(), ae.attempts, err)
+		p.done(ae, "dropped")
+		return
+	}
+
+	metrics.UpdatePublishConfirmsMetric(ae.labels.resourceType, ae.labels.resourceSelector, "requeued")
+	p.log.Warnf(ae.ctx, "Re-queuing unconfirmed event topic=%s event_id=%s attempts=%d error=%v",
+		ae.topic, ae.event.ID(), ae.attempts, err)
+	time.AfterFunc(p.requeueDelay, func() {
+		select {
+		case p.queue <- ae:
+		default:
+			p.log.Errorf(ae.ctx, "Dropped unconfirmed event: queue is full topic=%s event_id=%s",
+				ae.topic, ae.event.ID())
+			p.done(ae, "dropped")
+		}
+	})
+}
+
+// done records the final outcome of ae.
+func (p *AsyncPublisher) done(ae *asyncEvent, result string) {
+	metrics.UpdatePublishConfirmsMetric(ae.labels.resourceType, ae.labels.resourceSelector, result)
+	p.mu.Lock()
+	p.addUnconfirmed(ae.labels, -1)
+	p.mu.Unlock()
+	p.pending.Done()
+}
+
+// addUnconfirmed adjusts the unconfirmed count of labels by delta. p.mu must
+// be held.
+func (p *AsyncPublisher) addUnconfirmed(labels metricsLabels, delta int) {
+	p.unconfirmed[labels] += delta
+	metrics.UpdateUnconfirmedEventsMetric(labels.resourceType, labels.resourceSelector, p.unconfirmed[labels])
+}
+
+// Close stops accepting events, waits until every queued event is confirmed
+// or dropped, and closes the wrapped publisher. Failed publishes are still
+// re-queued until their attempts are exhausted, which bounds the wait.
+func (p *AsyncPublisher) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	p.pending.Wait()
+	close(p.stop)
+	p.workers.Wait()
+	return p.Publisher.Close()
+}
@@ -0,0 +1,154 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// confirmRecorder returns errs in turn, then nil. While gate is set, each
+// publish signals started and waits for gate to be closed.
+type confirmRecorder struct {
+	MockPublisher
+	started chan struct{}
+	gate    chan struct{}
+	errs    []error
+	calls   int
+	mu      sync.Mutex
+}
+
+func (p *confirmRecorder) Publish(_ context.Context, _ string, _ *cloudevents.Event) error {
+	if p.gate != nil {
+		p.started <- struct{}{}
+		<-p.gate
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.calls <= len(p.errs) {
+		return p.errs[p.calls-1]
+	}
+	return nil
+}
+
+func newTestAsyncPublisher(
+	t *testing.T, pub Publisher, cfg *config.BrokerAsyncPublishConfig,
+) (*AsyncPublisher, *metrics.SentinelMetrics) {
+	t.Helper()
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+	p := NewAsyncPublisher(pub, cfg, "clusters", "all", logger.NewHyperFleetLogger())
+	p.requeueDelay = time.Millisecond
+	return p, m
+}
+
+func confirmCount(m *metrics.SentinelMetrics, result string) float64 {
+	return testutil.ToFloat64(m.PublishConfirms.With(prometheus.Labels{
+		"resource_type": "clusters", "resource_selector": "all", "result": result,
+	}))
+}
+
+func TestAsyncPublisher_Confirms(t *testing.T) {
+	rec := &confirmRecorder{errs: []error{errors.New("broker down")}}
+	p, m := newTestAsyncPublisher(t, rec, &config.BrokerAsyncPublishConfig{MaxInFlight: 2, QueueSize: 10, MaxAttempts: 3})
+
+	for range 3 {
+		if err := p.Publish(context.Background(), "clusters", newTestEvent()); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	// Close waits for every event to be confirmed.
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if rec.calls != 4 {
+		t.Errorf("Expected the failed publish to be re-queued, got %d publishes", rec.calls)
+	}
+	if got := confirmCount(m, "confirmed"); got != 3 {
+		t.Errorf("Expected 3 confirmed events, got %v", got)
+	}
+	if got := confirmCount(m, "requeued"); got != 1 {
+		t.Errorf("Expected 1 re-queued event, got %v", got)
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.UnconfirmedEvents.With(labels)); got != 0 {
+		t.Errorf("Expected no unconfirmed events, got %v", got)
+	}
+	if err := p.Publish(context.Background(), "clusters", newTestEvent()); !errors.Is(err, ErrAsyncPublisherClosed) {
+		t.Errorf("Expected publishes after Close to fail, got %v", err)
+	}
+}
+
+func TestAsyncPublisher_DropsAfterMaxAttempts(t *testing.T) {
+	brokerDown := errors.New("broker down")
+	rec := &confirmRecorder{errs: []error{brokerDown, brokerDown, brokerDown}}
+	p, m := newTestAsyncPublisher(t, rec, &config.BrokerAsyncPublishConfig{MaxInFlight: 1, QueueSize: 10, MaxAttempts: 2})
+
+	if err := p.Publish(context.Background(), "clusters", newTestEvent()); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if rec.calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", rec.calls)
+	}
+	if got := confirmCount(m, "dropped"); got != 1 {
+		t.Errorf("Expected the event to be dropped, got %v", got)
+	}
+}
+
+func TestAsyncPublisher_QueueFull(t *testing.T) {
+	rec := &confirmRecorder{started: make(chan struct{}), gate: make(chan struct{})}
+	p, m := newTestAsyncPublisher(t, rec, &config.BrokerAsyncPublishConfig{MaxInFlight: 1, QueueSize: 1, MaxAttempts: 1})
+
+	// The first event is in flight and the second fills the queue.
+	if err := p.Publish(context.Background(), "clusters", newTestEvent()); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	<-rec.started
+	if err := p.Publish(context.Background(), "clusters", newTestEvent()); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.UnconfirmedEvents.With(labels)); got != 2 {
+		t.Errorf("Expected 2 unconfirmed events, got %v", got)
+	}
+	if err := p.Publish(context.Background(), "clusters", newTestEvent()); !errors.Is(err, ErrAsyncQueueFull) {
+		t.Errorf("Expected ErrAsyncQueueFull, got %v", err)
+	}
+
+	close(rec.gate)
+	go func() {
+		for range rec.started {
+		}
+	}()
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	close(rec.started)
+	if got := confirmCount(m, "confirmed"); got != 2 {
+		t.Errorf("Expected the 2 queued events to be confirmed, got %v", got)
+	}
+}
+
+func TestAsyncPublisher_WithConfirm(t *testing.T) {
+	brokerDown := errors.New("broker down")
+	rec := &confirmRecorder{errs: []error{brokerDown}}
+	p, _ := newTestAsyncPublisher(t, rec, &config.BrokerAsyncPublishConfig{MaxInFlight: 1, QueueSize: 1, MaxAttempts: 1})
+	defer func() { _ = p.Close() }()
+
+	if err := p.Publish(WithConfirm(context.Background()), "clusters", newTestEvent()); !errors.Is(err, brokerDown) {
+		t.Errorf("Expected the publish to wait for the broker, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+)
+
+// BatchSender is implemented by publishers that can publish several events
+// to a topic in one request.
+type BatchSender interface {
+	// SupportsBatch reports whether PublishBatch may be used, which can
+	// depend on the publisher's configuration.
+	SupportsBatch() bool
+	// PublishBatch publishes events to topic in one request. The batch
+	// succeeds or fails as a whole.
+	PublishBatch(ctx context.Context, topic string, events []*cloudevents.Event) error
+}
+
+// BatchPublisher collects the events published to a topic into batches and
+// flushes a batch once it holds maxSize events or flushInterval after its
+// first event. A batch is sent in one request when the wrapped publisher is a
+// BatchSender that supports it, such as the webhook publisher in structured
+// mode; otherwise its events are
+// published concurrently, since the broker library has no multi-message
+// publish. Each flushed batch is counted in publish_batch_size.
+//
+// Publish blocks until the batch of its event is flushed, so batches only
+// fill when several publishes are in flight at once.
+type BatchPublisher struct {
+	Publisher
+	sender           BatchSender
+	batches          map[string]*batch
+	resourceType     string
+	resourceSelector string
+	flushInterval    time.Duration
+	maxSize          int
+	mu               sync.Mutex
+}
+
+var _ Publisher = (*BatchPublisher)(nil)
+
+// batch is the pending batch of a topic. errs holds the result of the
+// publish of each event once done is closed. Whoever removes a batch from
+// BatchPublisher.batches flushes it.
+type batch struct {
+	ctx    context.Context
+	timer  *time.Timer
+	done   chan struct{}
+	events []*cloudevents.Event
+	errs   []error
+}
+
+// NewBatchPublisher wraps pub so that events are published in batches as
+// configured by cfg. Batches are counted with resourceType and
+// resourceSelector unless the context of their first event carries other
+// labels; see WithMetricsLabels. metrics.NewSentinelMetrics must have been
+// called.
+func NewBatchPublisher(
+	pub Publisher, cfg *config.BrokerBatchConfig, resourceType, resourceSelector string,
+) *BatchPublisher {
+	p := &BatchPublisher{
+		Publisher:        pub,
+		batches:          make(map[string]*batch),
+		resourceType:     resourceType,
+		resourceSelector: resourceSelector,
+		flushInterval:    cfg.FlushInterval,
+		maxSize:          cfg.MaxSize,
+	}
+	if sender, ok := pub.(BatchSender); ok && sender.SupportsBatch() {
+		p.sender = sender
+	}
+	return p
+}
+
+// Publish adds event to the pending batch of topic and waits until the batch
+// is flushed or ctx is done. An event whose caller gave up is still published
+// with its batch.
+func (p *BatchPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	// The batch is published after Publish returned when ctx is done first,
+	// while the caller reuses the event.
+	e := event.Clone()
+
+	p.mu.Lock()
+	b := p.batches[topic]
+	if b == nil {
+		// The batch outlives the publish that opened it.
+		b = &batch{ctx: context.WithoutCancel(ctx), done: make(chan struct{})}
+		p.batches[topic] = b
+		b.timer = time.AfterFunc(p.flushInterval, func() { p.flushTopic(topic, b) })
+	}
+	i := len(b.events)
+	b.events = append(b.events, &e)
+	full := len(b.events) >= p.maxSize
+	if full {
+		delete(p.batches, topic)
+	}
+	p.mu.Unlock()
+
+	if full {
+		b.timer.Stop()
+		go p.flush(topic, b)
+	}
+
+	select {
+	case <-b.done:
+		return b.errs[i]
+	case <-ctx.Done():
+		return fmt.Errorf("publishing event %s to topic %s: %w", event.ID(), topic, ctx.Err())
+	}
+}
+
+// flushTopic flushes b when its flush interval elapses, unless it filled up
+// first.
+func (p *BatchPublisher) flushTopic(topic string, b *batch) {
+	p.mu.Lock()
+	if p.batches[topic] != b {
+		p.mu.Unlock()
+		return
+	}
+	delete(p.batches, topic)
+	p.mu.Unlock()
+	p.flush(topic, b)
+}
+
+// flush publishes the events of b, which is no longer pending, and releases
+// the publishes waiting for it.
+func (p *BatchPublisher) flush(topic string, b *batch) {
+	defer close(b.done)
+
+	resourceType, resourceSelector := p.resourceType, p.resourceSelector
+	if labels, ok := b.ctx.Value(metricsLabelsKey{}).(metricsLabels); ok {
+		resourceType, resourceSelector = labels.resourceType, labels.resourceSelector
+	}
+	metrics.UpdatePublishBatchSizeMetric(resourceType, resourceSelector, len(b.events))
+
+	b.errs = make([]error, len(b.events))
+	if p.sender != nil && len(b.events) > 1 {
+		err := p.sender.PublishBatch(b.ctx, topic, b.events)
+		for i := range b.errs {
+			b.errs[i] = err
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for i, event := range b.events {
+		wg.Go(func() {
+			b.errs[i] = p.Publisher.Publish(b.ctx, topic, event)
+		})
+	}
+	wg.Wait()
+}
+
+// Close flushes the pending batches and closes the wrapped publisher.
+func (p *BatchPublisher) Close() error {
+	p.mu.Lock()
+	pending := p.batches
+	p.batches = make(map[string]*batch)
+	p.mu.Unlock()
+
+	for topic, b := range pending {
+		b.timer.Stop()
+		p.flush(topic, b)
+	}
+	return p.Publisher.Close()
+}
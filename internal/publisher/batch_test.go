@@ -0,0 +1,142 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// batchRecorder records the events published to it, one by one or, when
+// batches is set, in batches.
+type batchRecorder struct {
+	MockPublisher
+	err       error
+	published []string
+	sizes     []int
+	mu        sync.Mutex
+	batches   bool
+	closed    bool
+}
+
+func (p *batchRecorder) Publish(_ context.Context, _ string, event *cloudevents.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published = append(p.published, event.ID())
+	return p.err
+}
+
+func (p *batchRecorder) SupportsBatch() bool { return p.batches }
+
+func (p *batchRecorder) PublishBatch(_ context.Context, _ string, events []*cloudevents.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, event := range events {
+		p.published = append(p.published, event.ID())
+	}
+	p.sizes = append(p.sizes, len(events))
+	return p.err
+}
+
+func (p *batchRecorder) Close() error {
+	p.closed = true
+	return nil
+}
+
+func newTestBatchPublisher(
+	t *testing.T, pub Publisher, maxSize int, flushInterval time.Duration,
+) (*BatchPublisher, *metrics.SentinelMetrics) {
+	t.Helper()
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+	cfg := &config.BrokerBatchConfig{MaxSize: maxSize, FlushInterval: flushInterval}
+	return NewBatchPublisher(pub, cfg, "clusters", "all"), m
+}
+
+// publishConcurrently publishes n events to topic at once and returns their
+// errors.
+func publishConcurrently(ctx context.Context, p Publisher, topic string, n int) []error {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Go(func() {
+			event := newTestEvent()
+			event.SetID(fmt.Sprintf("event-%d", i))
+			errs[i] = p.Publish(ctx, topic, event)
+		})
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestBatchPublisher_FlushesFullBatch(t *testing.T) {
+	rec := &batchRecorder{batches: true}
+	p, m := newTestBatchPublisher(t, rec, 3, time.Hour)
+
+	for _, err := range publishConcurrently(context.Background(), p, "clusters", 3) {
+		if err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	if len(rec.sizes) != 1 || rec.sizes[0] != 3 {
+		t.Errorf("Expected one batch of 3 events, got %v", rec.sizes)
+	}
+	if got := testutil.CollectAndCount(m.PublishBatchSize); got != 1 {
+		t.Errorf("Expected publish_batch_size to be recorded, got %d series", got)
+	}
+}
+
+func TestBatchPublisher_FlushesAfterInterval(t *testing.T) {
+	rec := &batchRecorder{}
+	p, _ := newTestBatchPublisher(t, rec, 10, 10*time.Millisecond)
+
+	errs := publishConcurrently(context.Background(), p, "clusters", 2)
+	errs = append(errs, publishConcurrently(context.Background(), p, "nodepools", 1)...)
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	// Without batch support, the events of a batch are published one by one.
+	if len(rec.published) != 3 || len(rec.sizes) != 0 {
+		t.Errorf("Expected 3 events published one by one, got %v in batches %v", rec.published, rec.sizes)
+	}
+}
+
+func TestBatchPublisher_BatchErrorFailsEveryEvent(t *testing.T) {
+	brokerDown := errors.New("broker down")
+	p, _ := newTestBatchPublisher(t, &batchRecorder{batches: true, err: brokerDown}, 2, time.Hour)
+
+	for _, err := range publishConcurrently(context.Background(), p, "clusters", 2) {
+		if !errors.Is(err, brokerDown) {
+			t.Errorf("Expected every publish of the batch to fail, got %v", err)
+		}
+	}
+}
+
+func TestBatchPublisher_ContextDone(t *testing.T) {
+	rec := &batchRecorder{batches: true}
+	p, _ := newTestBatchPublisher(t, rec, 10, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Publish(ctx, "clusters", newTestEvent()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the publish to give up with its context, got %v", err)
+	}
+
+	// The pending batch is still flushed on close.
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if len(rec.published) != 1 || !rec.closed {
+		t.Errorf("Expected the pending event to be published and the publisher closed, got %v", rec.published)
+	}
+}
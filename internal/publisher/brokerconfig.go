@@ -0,0 +1,119 @@
+package publisher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/fips"
+)
+
+// LoadBrokerConfigFile reads a broker library configuration file, such as
+// broker.yaml, into the flat map accepted by broker.NewPublisher, whose keys
+// are dotted paths like "broker.rabbitmq.url".
+func LoadBrokerConfigFile(path string) (map[string]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading broker config file %s: %w", path, err)
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("parsing broker config file %s: %w", path, err)
+	}
+	flat := make(map[string]string)
+	flatten("", doc, flat)
+	return flat, nil
+}
+
+// flatten adds the leaves of m to flat, keyed by their dotted path below
+// prefix.
+func flatten(prefix string, m map[string]any, flat map[string]string) {
+	for key, value := range m {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			flatten(key, nested, flat)
+		} else {
+			flat[key] = fmt.Sprint(value)
+		}
+	}
+}
+
+// brokerSettings are the settings of a broker library configuration, keyed
+// by dotted path like "broker.rabbitmq.url".
+type brokerSettings map[string]string
+
+// loadBrokerSettings returns the settings of configMap or, when configMap is
+// nil, of the broker.yaml the broker library reads without a config map:
+// BROKER_CONFIG_FILE, or broker.yaml next to the executable. A missing file
+// has no settings.
+func loadBrokerSettings(configMap map[string]string) (brokerSettings, error) {
+	if configMap != nil {
+		return configMap, nil
+	}
+	path := os.Getenv("BROKER_CONFIG_FILE")
+	if path == "" {
+		path = "broker.yaml"
+		if exe, err := os.Executable(); err == nil {
+			if exe, err = filepath.EvalSymlinks(exe); err == nil {
+				path = filepath.Join(filepath.Dir(exe), "broker.yaml")
+			}
+		}
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return brokerSettings{}, nil
+	}
+	return LoadBrokerConfigFile(path)
+}
+
+// get returns the setting at key. As in the broker library, an environment
+// variable named after the key, e.g. BROKER_RABBITMQ_URL, overrides it.
+func (s brokerSettings) get(key string) string {
+	if v, ok := os.LookupEnv(strings.ToUpper(strings.ReplaceAll(key, ".", "_"))); ok {
+		return v
+	}
+	return s[key]
+}
+
+// getBool returns the boolean setting at key, false if it is not set.
+func (s brokerSettings) getBool(key string) (bool, error) {
+	v := s.get(key)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("broker config %s: %w", key, err)
+	}
+	return b, nil
+}
+
+// CheckFIPSBrokerConfig returns an error if a broker library configuration,
+// read like that of PubSubBrokerConfig, connects to its broker without TLS,
+// which fips_mode does not allow: RabbitMQ must use an amqps:// URL, and
+// Google Pub/Sub must not be redirected to the plaintext emulator by
+// PUBSUB_EMULATOR_HOST. The same settings configure the broker library's
+// publishers and the Sentinel's AMQPPublisher and PubSubPublisher.
+func CheckFIPSBrokerConfig(configMap map[string]string) error {
+	settings, err := loadBrokerSettings(configMap)
+	if err != nil {
+		return err
+	}
+	switch settings.get("broker.type") {
+	case AMQPBrokerType:
+		if err := fips.CheckURL(settings.get("broker.rabbitmq.url")); err != nil {
+			return fmt.Errorf("broker config rabbitmq.url: %w", err)
+		}
+	case PubSubBrokerType:
+		if host := os.Getenv("PUBSUB_EMULATOR_HOST"); host != "" {
+			return fmt.Errorf("PUBSUB_EMULATOR_HOST %s: the Pub/Sub emulator does not use TLS, which fips_mode requires",
+				host)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package publisher
+
+import "testing"
+
+func TestCheckFIPSBrokerConfig(t *testing.T) {
+	tests := []struct {
+		configMap map[string]string
+		env       map[string]string
+		name      string
+		wantErr   bool
+	}{
+		{
+			name:      "rabbitmq over TLS",
+			configMap: map[string]string{"broker.type": "rabbitmq", "broker.rabbitmq.url": "amqps://rabbitmq:5671/"},
+		},
+		{
+			name:      "rabbitmq without TLS",
+			configMap: map[string]string{"broker.type": "rabbitmq", "broker.rabbitmq.url": "amqp://rabbitmq:5672/"},
+			wantErr:   true,
+		},
+		{
+			name:      "rabbitmq without TLS by env",
+			configMap: map[string]string{"broker.type": "rabbitmq", "broker.rabbitmq.url": "amqps://rabbitmq:5671/"},
+			env:       map[string]string{"BROKER_RABBITMQ_URL": "amqp://rabbitmq:5672/"},
+			wantErr:   true,
+		},
+		{
+			name:      "pubsub",
+			configMap: map[string]string{"broker.type": "googlepubsub", "broker.googlepubsub.project_id": "p"},
+		},
+		{
+			name:      "pubsub emulator",
+			configMap: map[string]string{"broker.type": "googlepubsub", "broker.googlepubsub.project_id": "p"},
+			env:       map[string]string{"PUBSUB_EMULATOR_HOST": "localhost:8085"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PUBSUB_EMULATOR_HOST", "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if err := CheckFIPSBrokerConfig(tt.configMap); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+)
+
+// EncodingPublisher encodes the payload of events as configured for their
+// topic by clients.broker.encodings before publishing them through the
+// wrapped publisher. Events for topics without an entry, or with the json
+// encoding, are published unchanged. Batches are passed on to the wrapped
+// publisher if it supports them. All other methods are those of the wrapped
+// publisher.
+type EncodingPublisher struct {
+	Publisher
+	broker *config.BrokerConfig
+}
+
+var (
+	_ Publisher   = (*EncodingPublisher)(nil)
+	_ BatchSender = (*EncodingPublisher)(nil)
+)
+
+// NewEncodingPublisher wraps pub so that events are encoded as configured by
+// b.Encodings.
+func NewEncodingPublisher(pub Publisher, b *config.BrokerConfig) *EncodingPublisher {
+	return &EncodingPublisher{Publisher: pub, broker: b}
+}
+
+// Publish encodes event for topic and publishes it. The caller's event is
+// left unchanged, so that it can be spooled or published again as JSON.
+func (p *EncodingPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	if p.broker.TopicEncoding(topic) != config.EncodingProtobuf {
+		return p.Publisher.Publish(ctx, topic, event)
+	}
+	e := event.Clone()
+	if err := events.EncodeProtobuf(&e); err != nil {
+		return fmt.Errorf("publishing event %s to topic %s: %w", event.ID(), topic, err)
+	}
+	return p.Publisher.Publish(ctx, topic, &e)
+}
+
+// SupportsBatch reports whether the wrapped publisher supports batches.
+func (p *EncodingPublisher) SupportsBatch() bool {
+	sender, ok := p.Publisher.(BatchSender)
+	return ok && sender.SupportsBatch()
+}
+
+// PublishBatch encodes events for topic and publishes them as one batch
+// through the wrapped publisher. It must only be called if SupportsBatch
+// returns true.
+func (p *EncodingPublisher) PublishBatch(ctx context.Context, topic string, evts []*cloudevents.Event) error {
+	sender := p.Publisher.(BatchSender)
+	if p.broker.TopicEncoding(topic) != config.EncodingProtobuf {
+		return sender.PublishBatch(ctx, topic, evts)
+	}
+	encoded := make([]*cloudevents.Event, len(evts))
+	for i, event := range evts {
+		e := event.Clone()
+		if err := events.EncodeProtobuf(&e); err != nil {
+			return fmt.Errorf("publishing batch to topic %s: %w", topic, err)
+		}
+		encoded[i] = &e
+	}
+	return sender.PublishBatch(ctx, topic, encoded)
+}
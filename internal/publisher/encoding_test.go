@@ -0,0 +1,64 @@
+package publisher
+
+import (
+	"context"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+)
+
+// encodingRecorder records the datacontenttype of the events published to
+// it, one by one or in batches.
+type encodingRecorder struct {
+	MockPublisher
+	contentTypes []string
+}
+
+func (p *encodingRecorder) Publish(_ context.Context, _ string, event *cloudevents.Event) error {
+	p.contentTypes = append(p.contentTypes, event.DataContentType())
+	return nil
+}
+
+func (p *encodingRecorder) SupportsBatch() bool { return true }
+
+func (p *encodingRecorder) PublishBatch(_ context.Context, _ string, evts []*cloudevents.Event) error {
+	for _, event := range evts {
+		p.contentTypes = append(p.contentTypes, event.DataContentType())
+	}
+	return nil
+}
+
+func TestEncodingPublisher(t *testing.T) {
+	rec := &encodingRecorder{}
+	p := NewEncodingPublisher(rec, &config.BrokerConfig{
+		TopicPrefix: "staging-",
+		Encodings:   map[string]string{"clusters": config.EncodingProtobuf, "nodepools": config.EncodingJSON},
+	})
+
+	event := newWebhookTestEvent(t)
+	for _, topic := range []string{"staging-clusters", "staging-nodepools", "other"} {
+		if err := p.Publish(context.Background(), topic, event); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	if err := p.PublishBatch(context.Background(), "staging-clusters", []*cloudevents.Event{event}); err != nil {
+		t.Fatalf("PublishBatch failed: %v", err)
+	}
+
+	want := []string{
+		events.ProtobufContentType, cloudevents.ApplicationJSON, cloudevents.ApplicationJSON, events.ProtobufContentType,
+	}
+	for i, ct := range want {
+		if i >= len(rec.contentTypes) || rec.contentTypes[i] != ct {
+			t.Fatalf("Expected content types %v, got %v", want, rec.contentTypes)
+		}
+	}
+	if event.DataContentType() != cloudevents.ApplicationJSON {
+		t.Errorf("Expected the caller's event to stay JSON, got %q", event.DataContentType())
+	}
+	if !p.SupportsBatch() || NewEncodingPublisher(NewMockPublisher(), nil).SupportsBatch() {
+		t.Error("Expected batch support to follow the wrapped publisher")
+	}
+}
@@ -0,0 +1,69 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// amqpAuthMarkers identify RabbitMQ authorization failures. The broker library
+// surfaces AMQP errors as text, so the AMQP ACCESS_REFUSED reply (code 403) is
+// matched on the message rather than by type.
+var amqpAuthMarkers = []string{"ACCESS_REFUSED", "Exception (403)"}
+
+// IsAuthError reports whether err is a broker authorization failure, i.e. the
+// broker was reachable but rejected the publish for lack of permissions on the
+// topic, as opposed to a connectivity or transient failure.
+//
+// Google Pub/Sub errors are classified by their gRPC status code
+// (PermissionDenied, Unauthenticated); RabbitMQ errors by the ACCESS_REFUSED reply.
+func IsAuthError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		switch grpcErr.GRPCStatus().Code() {
+		case codes.PermissionDenied, codes.Unauthenticated:
+			return true
+		default:
+			return false
+		}
+	}
+
+	msg := err.Error()
+	for _, marker := range amqpAuthMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTransientError reports whether err is a broker failure that may succeed
+// when retried, such as a lost connection or an overloaded broker.
+// Authorization failures, canceled publishes, and Pub/Sub errors about the
+// request itself (e.g. InvalidArgument, NotFound) are not transient.
+// RabbitMQ errors other than ACCESS_REFUSED are all treated as transient, as
+// the broker library does not classify them.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || IsAuthError(err) {
+		return false
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		switch grpcErr.GRPCStatus().Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted,
+			codes.Internal, codes.Unknown:
+			return true
+		default:
+			return false
+		}
+	}
+	return true
+}
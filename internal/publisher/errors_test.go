@@ -0,0 +1,77 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsAuthError(t *testing.T) {
+	tests := []struct {
+		err  error
+		name string
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{
+			name: "rabbitmq access refused",
+			err:  errors.New(`Exception (403) Reason: "ACCESS_REFUSED - access to exchange 'clusters' refused"`),
+			want: true,
+		},
+		{
+			name: "pubsub permission denied",
+			err:  fmt.Errorf("publish failed: %w", status.Error(codes.PermissionDenied, "User not authorized")),
+			want: true,
+		},
+		{
+			name: "pubsub unauthenticated",
+			err:  status.Error(codes.Unauthenticated, "invalid credentials"),
+			want: true,
+		},
+		{
+			name: "pubsub unavailable",
+			err:  status.Error(codes.Unavailable, "connection refused"),
+			want: false,
+		},
+		{
+			name: "connection failure",
+			err:  errors.New("dial tcp 127.0.0.1:5672: connect: connection refused"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAuthError(tt.err); got != tt.want {
+				t.Errorf("IsAuthError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		err  error
+		name string
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "connection failure", err: errors.New("dial tcp 127.0.0.1:5672: connect: connection refused"), want: true},
+		{name: "pubsub unavailable", err: status.Error(codes.Unavailable, "connection refused"), want: true},
+		{name: "pubsub quota", err: status.Error(codes.ResourceExhausted, "quota exceeded"), want: true},
+		{name: "pubsub invalid argument", err: status.Error(codes.InvalidArgument, "message too large"), want: false},
+		{name: "pubsub topic not found", err: status.Error(codes.NotFound, "topic not found"), want: false},
+		{name: "rabbitmq access refused", err: errors.New(`Exception (403) Reason: "ACCESS_REFUSED"`), want: false},
+		{name: "canceled", err: fmt.Errorf("publish failed: %w", context.Canceled), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransientError(tt.err); got != tt.want {
+				t.Errorf("IsTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
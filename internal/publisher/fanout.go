@@ -0,0 +1,140 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+// FanOutTarget is one publisher of a FanOutPublisher.
+type FanOutTarget struct {
+	Publisher Publisher
+	Name      string
+	// Optional targets do not fail the publish; their failures are only
+	// logged and counted.
+	Optional bool
+}
+
+// FanOutPublisher publishes every event to all of its targets concurrently,
+// e.g. to the old and the new broker while migrating between them. A publish
+// fails if a target that is not optional fails; the event is then published
+// again to every target by a later cycle, so targets that succeeded see it
+// twice. Each publish to a target is counted in fanout_publishes_total.
+type FanOutPublisher struct {
+	log              logger.HyperFleetLogger
+	resourceType     string
+	resourceSelector string
+	targets          []FanOutTarget
+}
+
+var _ Publisher = (*FanOutPublisher)(nil)
+
+// NewFanOutPublisher creates a FanOutPublisher. The first target is the
+// primary one, whose broker type the publisher reports. Publishes are counted
+// with resourceType and resourceSelector unless their context carries other
+// labels; see WithMetricsLabels. metrics.NewSentinelMetrics must have been
+// called.
+func NewFanOutPublisher(
+	targets []FanOutTarget, resourceType, resourceSelector string, log logger.HyperFleetLogger,
+) (*FanOutPublisher, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("at least one fan-out target is required")
+	}
+	return &FanOutPublisher{
+		log:              log,
+		resourceType:     resourceType,
+		resourceSelector: resourceSelector,
+		targets:          targets,
+	}, nil
+}
+
+// Publish publishes event to topic on every target and waits for all of them.
+// The errors of the targets that are not optional are joined, each prefixed
+// with its target name.
+func (p *FanOutPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	resourceType, resourceSelector := p.resourceType, p.resourceSelector
+	if labels, ok := ctx.Value(metricsLabelsKey{}).(metricsLabels); ok {
+		resourceType, resourceSelector = labels.resourceType, labels.resourceSelector
+	}
+
+	errs := make([]error, len(p.targets))
+	var wg sync.WaitGroup
+	for i, t := range p.targets {
+		wg.Go(func() {
+			errs[i] = t.Publisher.Publish(ctx, topic, event)
+		})
+	}
+	wg.Wait()
+
+	var failed []error
+	for i, t := range p.targets {
+		if errs[i] == nil {
+			metrics.UpdateFanOutPublishesMetric(resourceType, resourceSelector, t.Name, "success")
+			continue
+		}
+		metrics.UpdateFanOutPublishesMetric(resourceType, resourceSelector, t.Name, "error")
+		if t.Optional {
+			p.log.Warnf(ctx, "Failed to publish event to optional fan-out target target=%s event_id=%s error=%v",
+				t.Name, event.ID(), errs[i])
+			continue
+		}
+		failed = append(failed, fmt.Errorf("target %s: %w", t.Name, errs[i]))
+	}
+	return errors.Join(failed...)
+}
+
+// Health reports the health of the targets that are not optional.
+func (p *FanOutPublisher) Health(ctx context.Context) error {
+	var errs []error
+	for _, t := range p.targets {
+		if t.Optional {
+			continue
+		}
+		if err := t.Publisher.Health(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("target %s: %w", t.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes every target.
+func (p *FanOutPublisher) Close() error {
+	var errs []error
+	for _, t := range p.targets {
+		if err := t.Publisher.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("target %s: %w", t.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BrokerType returns the broker type of the primary target.
+func (p *FanOutPublisher) BrokerType() string {
+	return p.targets[0].Publisher.BrokerType()
+}
+
+// metricsLabelsKey is the context key under which WithMetricsLabels stores
+// publish metric labels.
+type metricsLabelsKey struct{}
+
+// metricsLabels are the resource type and selector labels of publish metrics.
+type metricsLabels struct {
+	resourceType     string
+	resourceSelector string
+}
+
+// WithMetricsLabels returns a copy of ctx whose publishes are recorded with
+// resourceType and resourceSelector instead of the labels given to
+// NewFanOutPublisher or NewBatchPublisher, so that poll loops sharing a
+// publisher keep their own labels.
+func WithMetricsLabels(ctx context.Context, resourceType, resourceSelector string) context.Context {
+	return context.WithValue(ctx, metricsLabelsKey{}, metricsLabels{
+		resourceType:     resourceType,
+		resourceSelector: resourceSelector,
+	})
+}
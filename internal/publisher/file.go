@@ -0,0 +1,100 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-broker/broker"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+)
+
+// FileBrokerType is the broker type reported by the file publisher.
+const FileBrokerType = "file"
+
+// FilePublisher writes every event as a line of JSON, a structured
+// CloudEvent, to a file or stdout, so that developers can inspect what the
+// Sentinel would publish without running a broker. The topic is not written;
+// it is recorded with the broker library's publish metrics like other
+// publishers.
+type FilePublisher struct {
+	w       io.Writer
+	file    *os.File
+	metrics *broker.MetricsRecorder
+	mu      sync.Mutex
+}
+
+var _ Publisher = (*FilePublisher)(nil)
+
+// NewFilePublisher creates a FilePublisher from cfg, opening the file for
+// appending and creating it if needed. metrics may be nil.
+func NewFilePublisher(cfg *config.BrokerFileConfig, metrics *broker.MetricsRecorder) (*FilePublisher, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("file config is required")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid file config: %w", err)
+	}
+	if cfg.Path == config.FileStdout {
+		return &FilePublisher{w: os.Stdout, metrics: metrics}, nil
+	}
+	f, err := os.OpenFile(cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening event file %s: %w", cfg.Path, err)
+	}
+	return &FilePublisher{w: f, file: f, metrics: metrics}, nil
+}
+
+// Publish appends event to the file as one line.
+func (p *FilePublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("writing event %s: %w", event.ID(), err)
+	}
+	start := time.Now()
+	line, err := json.Marshal(event)
+	if err != nil {
+		p.recordError(topic, "conversion")
+		return fmt.Errorf("encoding event %s: %w", event.ID(), err)
+	}
+
+	// One write per line keeps lines whole when events are published
+	// concurrently.
+	p.mu.Lock()
+	_, err = p.w.Write(append(line, '\n'))
+	p.mu.Unlock()
+	if err != nil {
+		p.recordError(topic, "publish")
+		return fmt.Errorf("writing event %s: %w", event.ID(), err)
+	}
+	if p.metrics != nil {
+		p.metrics.RecordPublished(topic)
+		p.metrics.RecordDuration(topic, time.Since(start))
+	}
+	return nil
+}
+
+func (p *FilePublisher) recordError(topic, errorType string) {
+	if p.metrics != nil {
+		p.metrics.RecordError(topic, errorType)
+	}
+}
+
+// Health always succeeds: write errors surface through the publish error
+// metrics instead.
+func (p *FilePublisher) Health(ctx context.Context) error { return nil }
+
+// Close closes the file; stdout is left open.
+func (p *FilePublisher) Close() error {
+	if p.file == nil {
+		return nil
+	}
+	return p.file.Close()
+}
+
+// BrokerType returns FileBrokerType.
+func (p *FilePublisher) BrokerType() string { return FileBrokerType }
@@ -0,0 +1,85 @@
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+)
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	if err := os.WriteFile(path, []byte(`{"id":"earlier"}`+"\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	p, err := NewFilePublisher(&config.BrokerFileConfig{Path: path}, nil)
+	if err != nil {
+		t.Fatalf("NewFilePublisher failed: %v", err)
+	}
+	if p.BrokerType() != FileBrokerType {
+		t.Errorf("Expected broker type %q, got %q", FileBrokerType, p.BrokerType())
+	}
+
+	first, second := newWebhookTestEvent(t), newWebhookTestEvent(t)
+	second.SetID("event-2")
+	for _, event := range []*cloudevents.Event{first, second} {
+		if err := p.Publish(context.Background(), "clusters", event); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != 3 {
+		t.Fatalf("Expected the events to be appended to the existing line, got %d lines", len(lines))
+	}
+	for i, want := range []string{"event-1", "event-2"} {
+		var event cloudevents.Event
+		if err := json.Unmarshal([]byte(lines[i+1]), &event); err != nil {
+			t.Fatalf("Expected a CloudEvent per line: %v", err)
+		}
+		if event.ID() != want {
+			t.Errorf("Expected event %q on line %d, got %q", want, i+2, event.ID())
+		}
+	}
+}
+
+func TestFilePublisher_ContextDone(t *testing.T) {
+	p, err := NewFilePublisher(&config.BrokerFileConfig{Path: filepath.Join(t.TempDir(), "events.jsonl")}, nil)
+	if err != nil {
+		t.Fatalf("NewFilePublisher failed: %v", err)
+	}
+	defer func() { _ = p.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Publish(ctx, "clusters", newTestEvent()); err == nil {
+		t.Error("Expected a publish with a done context to fail")
+	}
+}
+
+func TestNewFilePublisher_InvalidPath(t *testing.T) {
+	if _, err := NewFilePublisher(&config.BrokerFileConfig{Path: "events.jsonl"}, nil); err == nil {
+		t.Error("Expected a relative path to be rejected")
+	}
+	missing := filepath.Join(t.TempDir(), "missing", "events.jsonl")
+	if _, err := NewFilePublisher(&config.BrokerFileConfig{Path: missing}, nil); err == nil {
+		t.Error("Expected a path in a missing directory to fail")
+	}
+}
@@ -6,8 +6,18 @@ import (
 	"fmt"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-broker/broker"
 )
 
+// Publisher publishes the Sentinel's events. It has the methods of
+// broker.Publisher, with a stronger contract: Publish returns once ctx is
+// done, so that a hung broker cannot hold up a poll cycle past its deadline
+// or delay shutdown. Publishers of the broker library do not honor ctx; wrap
+// them with NewTimeoutPublisher.
+type Publisher interface {
+	broker.Publisher
+}
+
 // MockPublisher is a mock publisher for testing/development
 // Implements broker.Publisher interface
 type MockPublisher struct {
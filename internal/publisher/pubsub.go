@@ -0,0 +1,189 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2"
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/ThreeDotsLabs/watermill-googlecloud/v2/pkg/googlecloud"
+	"github.com/ThreeDotsLabs/watermill/message"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-broker/broker"
+	brokerlogger "github.com/openshift-hyperfleet/hyperfleet-broker/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+)
+
+const (
+	// PubSubBrokerType is the broker type reported by the Pub/Sub publisher,
+	// the same as the broker library's.
+	PubSubBrokerType = "googlepubsub"
+
+	// pubSubHealthTopic is a topic that does not exist, probed by Health: a
+	// NotFound reply proves the round trip to Pub/Sub succeeded.
+	pubSubHealthTopic = "hyperfleet-health-probe"
+
+	// pubSubHealthTimeout bounds one health probe.
+	pubSubHealthTimeout = 3 * time.Second
+)
+
+// PubSubConfig holds the broker library settings the Pub/Sub publisher uses.
+type PubSubConfig struct {
+	ProjectID             string
+	CreateTopicIfMissing  bool
+	EnableMessageOrdering bool
+}
+
+// PubSubBrokerConfig returns the Google Pub/Sub settings of a broker library
+// configuration if it selects Google Pub/Sub, and nil otherwise. The
+// configuration is configMap or, when configMap is nil, the broker.yaml the
+// broker library would read; BROKER_* environment variables override both,
+// as they do in the broker library.
+func PubSubBrokerConfig(configMap map[string]string) (*PubSubConfig, error) {
+	settings, err := loadBrokerSettings(configMap)
+	if err != nil || settings.get("broker.type") != PubSubBrokerType {
+		return nil, err
+	}
+	ordering, err := settings.getBool("broker.googlepubsub.enable_message_ordering")
+	if err != nil {
+		return nil, err
+	}
+	createTopic, err := settings.getBool("broker.googlepubsub.create_topic_if_missing")
+	if err != nil {
+		return nil, err
+	}
+	return &PubSubConfig{
+		ProjectID:             settings.get("broker.googlepubsub.project_id"),
+		CreateTopicIfMissing:  createTopic,
+		EnableMessageOrdering: ordering,
+	}, nil
+}
+
+// NewPubSubMarshaler returns the marshaler of the Pub/Sub publisher, which
+// marshals messages as the broker library does. With ordering, it also sets
+// the ordering key to the events.PartitionKeyExtension of the structured
+// CloudEvent in the payload, and fails for an event without one, which
+// could not be delivered in order.
+func NewPubSubMarshaler(ordering bool) googlecloud.Marshaler {
+	if !ordering {
+		return googlecloud.DefaultMarshalerUnmarshaler{}
+	}
+	return googlecloud.NewOrderingMarshaler(func(topic string, msg *message.Message) (string, error) {
+		var envelope map[string]json.RawMessage
+		if err := json.Unmarshal(msg.Payload, &envelope); err != nil {
+			return "", fmt.Errorf("decoding event %s: %w", msg.UUID, err)
+		}
+		var key string
+		if raw, ok := envelope[events.PartitionKeyExtension]; ok {
+			if err := json.Unmarshal(raw, &key); err != nil {
+				return "", fmt.Errorf("decoding %s of event %s: %w", events.PartitionKeyExtension, msg.UUID, err)
+			}
+		}
+		if key == "" {
+			return "", fmt.Errorf("event %s has no %s extension to order by", msg.UUID, events.PartitionKeyExtension)
+		}
+		return key, nil
+	})
+}
+
+// PubSubPublisher publishes events to Google Pub/Sub like the broker
+// library's publisher, as structured CloudEvents. With message ordering, it
+// also sets each message's ordering key, which the broker library does not
+// expose, so that subscriptions with message ordering receive the events of
+// one resource in order. It records the broker library's publish metrics.
+type PubSubPublisher struct {
+	pub     *googlecloud.Publisher
+	health  *pubsub.Client
+	metrics *broker.MetricsRecorder
+	project string
+}
+
+var _ Publisher = (*PubSubPublisher)(nil)
+
+// NewPubSubPublisher creates a PubSubPublisher for the project of cfg. It
+// authenticates with Application Default Credentials, such as Workload
+// Identity on GKE or a credentials file named by
+// GOOGLE_APPLICATION_CREDENTIALS, e.g. for service account impersonation.
+// log receives the Pub/Sub client's logs; metrics may be nil.
+func NewPubSubPublisher(
+	cfg *PubSubConfig, log brokerlogger.Logger, metrics *broker.MetricsRecorder,
+) (*PubSubPublisher, error) {
+	if cfg == nil || cfg.ProjectID == "" {
+		return nil, fmt.Errorf("googlepubsub.project_id is required")
+	}
+	pub, err := googlecloud.NewPublisher(googlecloud.PublisherConfig{
+		ProjectID:                 cfg.ProjectID,
+		DoNotCreateTopicIfMissing: !cfg.CreateTopicIfMissing,
+		EnableMessageOrdering:     cfg.EnableMessageOrdering,
+		// A failed publish pauses its ordering key until publishing resumes.
+		EnableMessageOrderingAutoResumePublishOnError: cfg.EnableMessageOrdering,
+		Marshaler: NewPubSubMarshaler(cfg.EnableMessageOrdering),
+	}, brokerlogger.NewWatermillLoggerAdapter(log, context.Background()))
+	if err != nil {
+		return nil, fmt.Errorf("creating Pub/Sub publisher: %w", err)
+	}
+	health, err := pubsub.NewClient(context.Background(), cfg.ProjectID)
+	if err != nil {
+		_ = pub.Close()
+		return nil, fmt.Errorf("creating Pub/Sub health check client: %w", err)
+	}
+	return &PubSubPublisher{pub: pub, health: health, metrics: metrics, project: cfg.ProjectID}, nil
+}
+
+// Publish publishes event to topic and waits for Pub/Sub to confirm it.
+func (p *PubSubPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	start := time.Now()
+	payload, err := json.Marshal(event)
+	if err != nil {
+		p.recordError(topic, "conversion")
+		return fmt.Errorf("encoding event %s: %w", event.ID(), err)
+	}
+	msg := message.NewMessage(event.ID(), payload)
+	msg.SetContext(ctx)
+	if err := p.pub.Publish(topic, msg); err != nil {
+		p.recordError(topic, "publish")
+		return err
+	}
+	if p.metrics != nil {
+		p.metrics.RecordPublished(topic)
+		p.metrics.RecordDuration(topic, time.Since(start))
+	}
+	return nil
+}
+
+func (p *PubSubPublisher) recordError(topic, errorType string) {
+	if p.metrics != nil {
+		p.metrics.RecordError(topic, errorType)
+	}
+}
+
+// Health looks up a topic that does not exist, as the broker library does:
+// a NotFound reply means Pub/Sub is reachable.
+func (p *PubSubPublisher) Health(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, pubSubHealthTimeout)
+	defer cancel()
+	_, err := p.health.TopicAdminClient.GetTopic(ctx, &pubsubpb.GetTopicRequest{
+		Topic: fmt.Sprintf("projects/%s/topics/%s", p.project, pubSubHealthTopic),
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return fmt.Errorf("google pub/sub health check failed: %w", err)
+	}
+	return nil
+}
+
+// Close flushes pending publishes and closes the Pub/Sub clients.
+func (p *PubSubPublisher) Close() error {
+	err := p.pub.Close()
+	if closeErr := p.health.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// BrokerType returns PubSubBrokerType.
+func (p *PubSubPublisher) BrokerType() string { return PubSubBrokerType }
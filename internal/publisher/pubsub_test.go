@@ -0,0 +1,201 @@
+package publisher
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloud.google.com/go/pubsub/v2"
+	"github.com/ThreeDotsLabs/watermill-googlecloud/v2/pkg/googlecloud"
+	"github.com/ThreeDotsLabs/watermill/message"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+// marshalPubSub marshals a reconcile event with partitionKey, none if empty,
+// as the Pub/Sub publisher does.
+func marshalPubSub(t *testing.T, ordering bool, partitionKey string) (*pubsub.Message, []byte, error) {
+	t.Helper()
+	event := cloudevents.NewEvent()
+	event.SetID("event-1")
+	event.SetSource("hyperfleet-sentinel")
+	event.SetType("com.redhat.hyperfleet.cluster.reconcile")
+	if partitionKey != "" {
+		event.SetExtension(events.PartitionKeyExtension, partitionKey)
+	}
+	payload, err := json.Marshal(&event)
+	if err != nil {
+		t.Fatalf("Failed to marshal event: %v", err)
+	}
+	out, err := NewPubSubMarshaler(ordering).Marshal("clusters", message.NewMessage(event.ID(), payload))
+	return out, payload, err
+}
+
+func TestPubSubMarshaler_OrderingKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     string
+		ordering bool
+	}{
+		{name: "with ordering", ordering: true, want: "cluster-1"},
+		{name: "without ordering", ordering: false, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, payload, err := marshalPubSub(t, tt.ordering, "cluster-1")
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if out.OrderingKey != tt.want {
+				t.Errorf("Expected ordering key %q, got %q", tt.want, out.OrderingKey)
+			}
+			if string(out.Data) != string(payload) {
+				t.Errorf("Expected the structured event as data, got %s", out.Data)
+			}
+			if out.Attributes[googlecloud.UUIDHeaderKey] != "event-1" {
+				t.Errorf("Expected the event ID as message UUID, got %v", out.Attributes)
+			}
+		})
+	}
+}
+
+func TestPubSubMarshaler_MissingPartitionKey(t *testing.T) {
+	if _, _, err := marshalPubSub(t, true, ""); err == nil {
+		t.Error("Expected an error for an event without a partition key when ordering")
+	}
+	out, _, err := marshalPubSub(t, false, "")
+	if err != nil {
+		t.Fatalf("Expected no error without ordering, got %v", err)
+	}
+	if out.OrderingKey != "" {
+		t.Errorf("Expected no ordering key, got %q", out.OrderingKey)
+	}
+}
+
+func TestPubSubMarshaler_InvalidPayload(t *testing.T) {
+	for _, payload := range []string{`not json`, `{"partitionkey":1}`} {
+		if _, err := NewPubSubMarshaler(true).Marshal("clusters", message.NewMessage("event-1", []byte(payload))); err == nil {
+			t.Errorf("Expected an error for payload %s", payload)
+		}
+	}
+}
+
+func TestNewPubSubPublisher_Credentials(t *testing.T) {
+	// Impersonation of the Sentinel's service account, the credentials a
+	// workload outside GKE uses instead of Workload Identity.
+	impersonated := `{
+		"type": "impersonated_service_account",
+		"service_account_impersonation_url": "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/sentinel@p.iam.gserviceaccount.com:generateAccessToken",
+		"source_credentials": {
+			"type": "authorized_user", "client_id": "id", "client_secret": "secret", "refresh_token": "token"
+		}
+	}`
+	tests := []struct {
+		name        string
+		credentials string
+		wantErr     bool
+	}{
+		{name: "impersonated service account", credentials: impersonated},
+		{name: "unsupported credentials", credentials: `{"type": "bogus"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "credentials.json")
+			if err := os.WriteFile(path, []byte(tt.credentials), 0o600); err != nil {
+				t.Fatalf("Failed to write credentials: %v", err)
+			}
+			t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+			// The emulator would bypass Application Default Credentials.
+			t.Setenv("PUBSUB_EMULATOR_HOST", "")
+
+			pub, err := NewPubSubPublisher(&PubSubConfig{ProjectID: "p"}, logger.NewHyperFleetLogger(), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %t, got %v", tt.wantErr, err)
+			}
+			if pub != nil {
+				_ = pub.Close()
+			}
+		})
+	}
+}
+
+func TestPubSubBrokerConfig(t *testing.T) {
+	tests := []struct {
+		configMap map[string]string
+		env       map[string]string
+		want      *PubSubConfig
+		name      string
+		wantErr   bool
+	}{
+		{
+			name:      "rabbitmq",
+			configMap: map[string]string{"broker.type": "rabbitmq"},
+		},
+		{
+			name:      "pubsub without ordering",
+			configMap: map[string]string{"broker.type": "googlepubsub", "broker.googlepubsub.project_id": "p"},
+			want:      &PubSubConfig{ProjectID: "p"},
+		},
+		{
+			name: "pubsub with ordering",
+			configMap: map[string]string{
+				"broker.type":                                 "googlepubsub",
+				"broker.googlepubsub.project_id":              "p",
+				"broker.googlepubsub.enable_message_ordering": "true",
+				"broker.googlepubsub.create_topic_if_missing": "true",
+			},
+			want: &PubSubConfig{ProjectID: "p", CreateTopicIfMissing: true, EnableMessageOrdering: true},
+		},
+		{
+			name:      "ordering enabled by env",
+			configMap: map[string]string{"broker.type": "googlepubsub"},
+			env: map[string]string{
+				"BROKER_GOOGLEPUBSUB_ENABLE_MESSAGE_ORDERING": "true",
+				"BROKER_GOOGLEPUBSUB_PROJECT_ID":              "from-env",
+			},
+			want: &PubSubConfig{ProjectID: "from-env", EnableMessageOrdering: true},
+		},
+		{
+			name: "invalid bool",
+			configMap: map[string]string{
+				"broker.type": "googlepubsub",
+				"broker.googlepubsub.enable_message_ordering": "sometimes",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			got, err := PubSubBrokerConfig(tt.configMap)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %t, got %v", tt.wantErr, err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPubSubBrokerConfig_BrokerConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broker.yaml")
+	yaml := "broker:\n  type: googlepubsub\n  googlepubsub:\n    project_id: p\n    enable_message_ordering: true\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatalf("Failed to write broker config: %v", err)
+	}
+	t.Setenv("BROKER_CONFIG_FILE", path)
+
+	got, err := PubSubBrokerConfig(nil)
+	if err != nil {
+		t.Fatalf("PubSubBrokerConfig failed: %v", err)
+	}
+	if got == nil || got.ProjectID != "p" || !got.EnableMessageOrdering {
+		t.Errorf("Expected the settings of the broker config file, got %+v", got)
+	}
+}
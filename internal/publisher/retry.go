@@ -0,0 +1,77 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-broker/broker"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+)
+
+const (
+	// DefaultRetryInitialInterval is the wait before the first retry when
+	// clients.broker.retry.initial_interval is 0.
+	DefaultRetryInitialInterval = 100 * time.Millisecond
+
+	// DefaultRetryMaxInterval caps the wait between retries when
+	// clients.broker.retry.max_interval is 0.
+	DefaultRetryMaxInterval = 2 * time.Second
+)
+
+// RetryPublisher retries publishes that fail with a transient error, as
+// classified by IsTransientError, with exponential backoff. Other failures
+// are returned at once. All other methods are those of the wrapped
+// publisher.
+type RetryPublisher struct {
+	broker.Publisher
+	maxAttempts     int
+	initialInterval time.Duration
+	maxInterval     time.Duration
+}
+
+var _ Publisher = (*RetryPublisher)(nil)
+
+// NewRetryPublisher wraps pub so that its publishes are retried as
+// configured by cfg.
+func NewRetryPublisher(pub broker.Publisher, cfg *config.BrokerRetryConfig) *RetryPublisher {
+	p := &RetryPublisher{
+		Publisher:       pub,
+		maxAttempts:     max(cfg.MaxAttempts, 1),
+		initialInterval: cfg.InitialInterval,
+		maxInterval:     cfg.MaxInterval,
+	}
+	if p.initialInterval == 0 {
+		p.initialInterval = DefaultRetryInitialInterval
+	}
+	if p.maxInterval == 0 {
+		p.maxInterval = max(DefaultRetryMaxInterval, p.initialInterval)
+	}
+	return p
+}
+
+// Publish publishes event to topic, retrying transient failures until the
+// attempts are exhausted or ctx is done. The error of the last attempt is
+// wrapped, so IsAuthError and IsTransientError still classify it.
+func (p *RetryPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	delay := p.initialInterval
+	for attempt := 1; ; attempt++ {
+		err := p.Publisher.Publish(ctx, topic, event)
+		if err == nil {
+			return nil
+		}
+		if attempt >= p.maxAttempts || !IsTransientError(err) {
+			if attempt == 1 {
+				return err
+			}
+			return fmt.Errorf("publishing event %s after %d attempts: %w", event.ID(), attempt, err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("publishing event %s: %w", event.ID(), err)
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, p.maxInterval)
+	}
+}
@@ -0,0 +1,109 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingPublisher fails its first publishes with the given errors in turn.
+type failingPublisher struct {
+	MockPublisher
+	errs  []error
+	calls int
+}
+
+func (p *failingPublisher) Publish(_ context.Context, _ string, _ *cloudevents.Event) error {
+	p.calls++
+	if p.calls <= len(p.errs) {
+		return p.errs[p.calls-1]
+	}
+	return nil
+}
+
+func TestRetryPublisher(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "success", wantCalls: 1},
+		{name: "transient failures are retried", errs: []error{unavailable, unavailable}, wantCalls: 3},
+		{
+			name:      "attempts are bounded",
+			errs:      []error{unavailable, unavailable, unavailable, unavailable},
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "auth errors are not retried",
+			errs:      []error{status.Error(codes.PermissionDenied, "not authorized")},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid requests are not retried",
+			errs:      []error{status.Error(codes.InvalidArgument, "message too large")},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pub := &failingPublisher{errs: tt.errs}
+			p := NewRetryPublisher(pub, &config.BrokerRetryConfig{MaxAttempts: 3, InitialInterval: time.Millisecond})
+
+			err := p.Publish(context.Background(), "clusters", newTestEvent())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if pub.calls != tt.wantCalls {
+				t.Errorf("Expected %d attempts, got %d", tt.wantCalls, pub.calls)
+			}
+		})
+	}
+}
+
+func TestRetryPublisher_KeepsErrorClass(t *testing.T) {
+	pub := &failingPublisher{errs: []error{
+		errors.New("connection reset"),
+		errors.New(`Exception (403) Reason: "ACCESS_REFUSED"`),
+	}}
+	p := NewRetryPublisher(pub, &config.BrokerRetryConfig{MaxAttempts: 3, InitialInterval: time.Millisecond})
+
+	err := p.Publish(context.Background(), "clusters", newTestEvent())
+	if !IsAuthError(err) {
+		t.Errorf("Expected the last attempt's auth error to be kept, got %v", err)
+	}
+}
+
+func TestRetryPublisher_StopsWhenCanceled(t *testing.T) {
+	pub := &failingPublisher{errs: []error{errors.New("connection reset")}}
+	p := NewRetryPublisher(pub, &config.BrokerRetryConfig{MaxAttempts: 3, InitialInterval: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := p.Publish(ctx, "clusters", newTestEvent()); err == nil {
+		t.Error("Expected a canceled publish to fail")
+	}
+	if pub.calls != 1 {
+		t.Errorf("Expected no retry once canceled, got %d attempts", pub.calls)
+	}
+}
+
+func newTestEvent() *cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID("event-1")
+	event.SetType("com.redhat.hyperfleet.cluster.reconcile")
+	event.SetSource("hyperfleet-sentinel")
+	return &event
+}
@@ -0,0 +1,55 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-broker/broker"
+)
+
+// DefaultPublishTimeout bounds one publish when clients.broker.publish_timeout
+// is 0.
+const DefaultPublishTimeout = 10 * time.Second
+
+// TimeoutPublisher makes a publisher that ignores the context, such as those
+// of the broker library, honor it: Publish returns when ctx is done or the
+// timeout elapses, whichever comes first. The abandoned publish keeps running
+// in the background on a copy of the event until the wrapped publisher
+// returns. All other methods are those of the wrapped publisher.
+type TimeoutPublisher struct {
+	broker.Publisher
+	timeout time.Duration
+}
+
+var _ Publisher = (*TimeoutPublisher)(nil)
+
+// NewTimeoutPublisher wraps pub so that each publish is bounded by ctx and
+// timeout; a timeout of 0 uses DefaultPublishTimeout.
+func NewTimeoutPublisher(pub broker.Publisher, timeout time.Duration) *TimeoutPublisher {
+	if timeout <= 0 {
+		timeout = DefaultPublishTimeout
+	}
+	return &TimeoutPublisher{Publisher: pub, timeout: timeout}
+}
+
+// Publish publishes event to topic, giving up once ctx is done or the timeout
+// elapses. The error then wraps ctx.Err(), e.g. context.DeadlineExceeded.
+func (p *TimeoutPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	// The wrapped publisher may still read the event after Publish returned,
+	// while the caller reuses it.
+	e := event.Clone()
+	done := make(chan error, 1)
+	go func() { done <- p.Publisher.Publish(ctx, topic, &e) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("publishing event %s to topic %s: %w", event.ID(), topic, ctx.Err())
+	}
+}
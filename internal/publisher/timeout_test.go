@@ -0,0 +1,62 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// hungPublisher blocks every publish until release is closed, ignoring the
+// context like the broker library does.
+type hungPublisher struct {
+	MockPublisher
+	release chan struct{}
+}
+
+func (p *hungPublisher) Publish(_ context.Context, _ string, _ *cloudevents.Event) error {
+	<-p.release
+	return nil
+}
+
+func newHungPublisher(t *testing.T) *hungPublisher {
+	t.Helper()
+	p := &hungPublisher{release: make(chan struct{})}
+	t.Cleanup(func() { close(p.release) })
+	return p
+}
+
+func TestTimeoutPublisher_Timeout(t *testing.T) {
+	p := NewTimeoutPublisher(newHungPublisher(t), 10*time.Millisecond)
+
+	err := p.Publish(context.Background(), "clusters", newTestEvent())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a hung publish to time out, got %v", err)
+	}
+}
+
+func TestTimeoutPublisher_Canceled(t *testing.T) {
+	p := NewTimeoutPublisher(newHungPublisher(t), time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := p.Publish(ctx, "clusters", newTestEvent())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a hung publish to return once canceled, got %v", err)
+	}
+}
+
+func TestTimeoutPublisher_Result(t *testing.T) {
+	brokerDown := errors.New("broker down")
+	pub := &failingPublisher{errs: []error{brokerDown}}
+	p := NewTimeoutPublisher(pub, time.Second)
+
+	if err := p.Publish(context.Background(), "clusters", newTestEvent()); !errors.Is(err, brokerDown) {
+		t.Errorf("Expected the publish error, got %v", err)
+	}
+	if err := p.Publish(context.Background(), "clusters", newTestEvent()); err != nil {
+		t.Errorf("Expected the publish to succeed, got %v", err)
+	}
+}
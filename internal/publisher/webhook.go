@@ -0,0 +1,298 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // HMAC-SHA1 only when clients.broker.webhook.signature_algorithm selects it.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/openshift-hyperfleet/hyperfleet-broker/broker"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+)
+
+const (
+	// WebhookBrokerType is the broker type reported by the webhook publisher.
+	WebhookBrokerType = "webhook"
+
+	// DefaultWebhookTimeout bounds one delivery attempt when
+	// clients.broker.webhook.timeout is 0.
+	DefaultWebhookTimeout = 5 * time.Second
+
+	// DefaultWebhookMaxAttempts is the number of delivery attempts when
+	// clients.broker.webhook.max_attempts is 0.
+	DefaultWebhookMaxAttempts = 3
+
+	// SignatureHeader carries the HMAC of the request body, as
+	// "<algorithm>=<hex>", e.g. "sha256=<hex>", when a webhook secret is
+	// configured.
+	SignatureHeader = "X-HyperFleet-Signature"
+
+	// TopicHeader carries the topic the event would have been published to,
+	// so that one endpoint can serve several resource kinds or regions.
+	TopicHeader = "X-HyperFleet-Topic"
+
+	// webhookRetryInterval is the delay before the first retry; it doubles
+	// with each further attempt.
+	webhookRetryInterval = 500 * time.Millisecond
+
+	// maxWebhookErrorBody bounds the response body quoted in errors.
+	maxWebhookErrorBody = 512
+
+	// batchContentType is the media type of the CloudEvents JSON batch format.
+	batchContentType = "application/cloudevents-batch+json"
+)
+
+// WebhookPublisher POSTs CloudEvents to an HTTP endpoint, for consumers
+// without a message broker. Network errors, 429, and 5xx responses are
+// retried with exponential backoff; other non-2xx responses fail the publish
+// at once. It implements broker.Publisher and records the broker library's
+// publish metrics under the event's topic. In structured mode it also sends
+// batches of events in the CloudEvents JSON batch format.
+type WebhookPublisher struct {
+	client      *http.Client
+	metrics     *broker.MetricsRecorder
+	endpoint    string
+	username    string
+	password    string
+	signature   string
+	secret      []byte
+	timeout     time.Duration
+	retryDelay  time.Duration
+	maxAttempts int
+	binary      bool
+}
+
+var (
+	_ Publisher   = (*WebhookPublisher)(nil)
+	_ BatchSender = (*WebhookPublisher)(nil)
+)
+
+// NewWebhookPublisher creates a WebhookPublisher from cfg. It reads the
+// signing secret, if any, once; the publisher must be recreated to rotate it.
+// metrics may be nil.
+func NewWebhookPublisher(
+	cfg *config.BrokerWebhookConfig, metrics *broker.MetricsRecorder,
+) (*WebhookPublisher, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("webhook config is required")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid webhook config: %w", err)
+	}
+
+	// Credentials in the URL are sent as basic auth rather than as part of
+	// the URL, so that errors, which quote the URL, do not leak them.
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook URL %s: %w", cfg.RedactedURL(), err)
+	}
+	p := &WebhookPublisher{
+		client:      &http.Client{},
+		metrics:     metrics,
+		timeout:     cfg.Timeout,
+		retryDelay:  webhookRetryInterval,
+		maxAttempts: cfg.MaxAttempts,
+		binary:      cfg.Mode == config.WebhookModeBinary,
+		signature:   cfg.Signature(),
+	}
+	if u.User != nil {
+		p.username = u.User.Username()
+		p.password, _ = u.User.Password()
+		u.User = nil
+	}
+	p.endpoint = u.String()
+	if p.timeout == 0 {
+		p.timeout = DefaultWebhookTimeout
+	}
+	if p.maxAttempts == 0 {
+		p.maxAttempts = DefaultWebhookMaxAttempts
+	}
+
+	if cfg.SecretPath != "" {
+		raw, err := os.ReadFile(cfg.SecretPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading webhook secret file %s: %w", cfg.SecretPath, err)
+		}
+		secret := strings.TrimSpace(string(raw))
+		if secret == "" {
+			return nil, fmt.Errorf("webhook secret file %s is empty", cfg.SecretPath)
+		}
+		p.secret = []byte(secret)
+	}
+	return p, nil
+}
+
+// Publish delivers event to the webhook endpoint, retrying transient
+// failures until the attempts are exhausted or ctx is done.
+func (p *WebhookPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	start := time.Now()
+	header, body, err := p.encode(ctx, event)
+	if err != nil {
+		p.recordError(topic, "conversion")
+		return fmt.Errorf("encoding event %s: %w", event.ID(), err)
+	}
+	return p.send(ctx, topic, "event "+event.ID(), 1, start, header, body)
+}
+
+// SupportsBatch reports whether the publisher is in structured mode: the
+// CloudEvents binary mode has no batch format.
+func (p *WebhookPublisher) SupportsBatch() bool { return !p.binary }
+
+// PublishBatch delivers events to the webhook endpoint in one request in the
+// CloudEvents JSON batch format, retrying as Publish does.
+func (p *WebhookPublisher) PublishBatch(ctx context.Context, topic string, events []*cloudevents.Event) error {
+	start := time.Now()
+	body, err := json.Marshal(events)
+	if err != nil {
+		p.recordError(topic, "conversion")
+		return fmt.Errorf("encoding batch of %d events: %w", len(events), err)
+	}
+	header := http.Header{}
+	header.Set("Content-Type", batchContentType)
+	return p.send(ctx, topic, fmt.Sprintf("batch of %d events", len(events)), len(events), start, header, body)
+}
+
+// send delivers body, which holds count events described by what, retrying
+// transient failures until the attempts are exhausted or ctx is done.
+func (p *WebhookPublisher) send(
+	ctx context.Context, topic, what string, count int, start time.Time, header http.Header, body []byte,
+) error {
+	header.Set(TopicHeader, topic)
+	if p.secret != nil {
+		header.Set(SignatureHeader, SignWith(p.signature, p.secret, body))
+	}
+
+	delay := p.retryDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := p.deliver(ctx, header, body)
+		if err == nil {
+			if p.metrics != nil {
+				for range count {
+					p.metrics.RecordPublished(topic)
+				}
+				p.metrics.RecordDuration(topic, time.Since(start))
+			}
+			return nil
+		}
+		if !retry || attempt >= p.maxAttempts {
+			p.recordError(topic, "publish")
+			return fmt.Errorf("delivering %s to webhook %s after %d attempts: %w", what, p.endpoint, attempt, err)
+		}
+		select {
+		case <-ctx.Done():
+			p.recordError(topic, "publish")
+			return fmt.Errorf("delivering %s to webhook %s: %w", what, p.endpoint, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// encode renders event in the configured CloudEvents HTTP binding mode and
+// returns the request headers and body.
+func (p *WebhookPublisher) encode(ctx context.Context, event *cloudevents.Event) (http.Header, []byte, error) {
+	if p.binary {
+		ctx = binding.WithForceBinary(ctx)
+	} else {
+		ctx = binding.WithForceStructured(ctx)
+	}
+	req := &http.Request{Header: make(http.Header)}
+	if err := cehttp.WriteRequest(ctx, binding.ToMessage(event), req); err != nil {
+		return nil, nil, err
+	}
+	if req.Body == nil {
+		return req.Header, nil, nil
+	}
+	defer func() { _ = req.Body.Close() }()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return req.Header, body, nil
+}
+
+// deliver makes one delivery attempt and reports whether a failure is worth
+// retrying.
+func (p *WebhookPublisher) deliver(ctx context.Context, header http.Header, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header = header.Clone()
+	if p.username != "" {
+		req.SetBasicAuth(p.username, p.password)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorBody))
+	err = fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
+
+func (p *WebhookPublisher) recordError(topic, errorType string) {
+	if p.metrics != nil {
+		p.metrics.RecordError(topic, errorType)
+	}
+}
+
+// Health always succeeds: the endpoint has no health check of its own, and
+// failed deliveries surface through the publish error metrics and broker
+// backpressure instead.
+func (p *WebhookPublisher) Health(ctx context.Context) error { return nil }
+
+// Close is a no-op; the publisher holds no connection.
+func (p *WebhookPublisher) Close() error { return nil }
+
+// BrokerType returns WebhookBrokerType.
+func (p *WebhookPublisher) BrokerType() string { return WebhookBrokerType }
+
+// Sign returns the SignatureHeader value for body: "sha256=" followed by the
+// hex HMAC-SHA256 of body keyed with secret. Receivers should recompute it
+// over the raw request body and compare with hmac.Equal.
+func Sign(secret, body []byte) string {
+	return SignWith(config.WebhookSignatureSHA256, secret, body)
+}
+
+// SignWith returns the SignatureHeader value for body signed with the HMAC
+// using the hash of algorithm, such as config.WebhookSignatureSHA512:
+// algorithm, "=", and the hex HMAC of body keyed with secret. Unknown
+// algorithms sign with SHA-256.
+func SignWith(algorithm string, secret, body []byte) string {
+	var h func() hash.Hash
+	switch algorithm {
+	case config.WebhookSignatureSHA512:
+		h = sha512.New
+	case config.WebhookSignatureSHA1:
+		h = sha1.New
+	default:
+		algorithm, h = config.WebhookSignatureSHA256, sha256.New
+	}
+	mac := hmac.New(h, secret)
+	mac.Write(body)
+	return algorithm + "=" + hex.EncodeToString(mac.Sum(nil))
+}
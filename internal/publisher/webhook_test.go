@@ -0,0 +1,288 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+)
+
+// webhookRequest is a request received by webhookServer.
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookServer records the requests it receives and answers them with the
+// given statuses in turn, then with 200.
+func webhookServer(t *testing.T, statuses ...int) (*httptest.Server, func() []webhookRequest) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		requests []webhookRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, webhookRequest{header: r.Header.Clone(), body: body})
+		n := len(requests)
+		mu.Unlock()
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []webhookRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]webhookRequest(nil), requests...)
+	}
+}
+
+func newWebhookTestEvent(t *testing.T) *cloudevents.Event {
+	t.Helper()
+	event := cloudevents.NewEvent()
+	event.SetID("event-1")
+	event.SetType("com.redhat.hyperfleet.cluster.reconcile")
+	event.SetSource("hyperfleet-sentinel")
+	if err := event.SetData(cloudevents.ApplicationJSON, map[string]string{"id": "cluster-1"}); err != nil {
+		t.Fatalf("SetData failed: %v", err)
+	}
+	return &event
+}
+
+func newTestWebhookPublisher(t *testing.T, cfg *config.BrokerWebhookConfig) *WebhookPublisher {
+	t.Helper()
+	p, err := NewWebhookPublisher(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewWebhookPublisher failed: %v", err)
+	}
+	p.retryDelay = time.Millisecond
+	return p
+}
+
+func TestWebhookPublisher_Structured(t *testing.T) {
+	server, requests := webhookServer(t)
+	secretPath := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretPath, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	p := newTestWebhookPublisher(t, &config.BrokerWebhookConfig{URL: server.URL, SecretPath: secretPath})
+
+	if err := p.Publish(context.Background(), "clusters", newWebhookTestEvent(t)); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(got))
+	}
+	req := got[0]
+	if ct := req.header.Get("Content-Type"); ct != "application/cloudevents+json" {
+		t.Errorf("Expected a structured CloudEvent, got Content-Type %q", ct)
+	}
+	if topic := req.header.Get(TopicHeader); topic != "clusters" {
+		t.Errorf("Expected %s %q, got %q", TopicHeader, "clusters", topic)
+	}
+	if sig := req.header.Get(SignatureHeader); sig != Sign([]byte("s3cret"), req.body) {
+		t.Errorf("Expected the body to be signed with the trimmed secret, got %s %q", SignatureHeader, sig)
+	}
+	var event cloudevents.Event
+	if err := json.Unmarshal(req.body, &event); err != nil {
+		t.Fatalf("Expected the body to be a CloudEvent: %v", err)
+	}
+	if event.ID() != "event-1" {
+		t.Errorf("Expected event ID %q, got %q", "event-1", event.ID())
+	}
+}
+
+func TestWebhookPublisher_SignatureAlgorithm(t *testing.T) {
+	secretPath := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretPath, []byte("s3cret"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	for _, alg := range []string{config.WebhookSignatureSHA512, config.WebhookSignatureSHA1} {
+		t.Run(alg, func(t *testing.T) {
+			server, requests := webhookServer(t)
+			p := newTestWebhookPublisher(t, &config.BrokerWebhookConfig{
+				URL: server.URL, SecretPath: secretPath, SignatureAlgorithm: alg,
+			})
+
+			if err := p.Publish(context.Background(), "clusters", newWebhookTestEvent(t)); err != nil {
+				t.Fatalf("Publish failed: %v", err)
+			}
+			req := requests()[0]
+			sig := req.header.Get(SignatureHeader)
+			if !strings.HasPrefix(sig, alg+"=") || sig != SignWith(alg, []byte("s3cret"), req.body) {
+				t.Errorf("Expected the body to be signed with HMAC-%s, got %s %q", alg, SignatureHeader, sig)
+			}
+		})
+	}
+}
+
+func TestWebhookPublisher_Binary(t *testing.T) {
+	server, requests := webhookServer(t)
+	p := newTestWebhookPublisher(t, &config.BrokerWebhookConfig{URL: server.URL, Mode: config.WebhookModeBinary})
+
+	if err := p.Publish(context.Background(), "clusters", newWebhookTestEvent(t)); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(got))
+	}
+	req := got[0]
+	if id := req.header.Get("Ce-Id"); id != "event-1" {
+		t.Errorf("Expected ce-id %q, got %q", "event-1", id)
+	}
+	if ct := req.header.Get("Content-Type"); ct != cloudevents.ApplicationJSON {
+		t.Errorf("Expected the data content type, got Content-Type %q", ct)
+	}
+	if body := strings.TrimSpace(string(req.body)); body != `{"id":"cluster-1"}` {
+		t.Errorf("Expected the event data as body, got %s", body)
+	}
+	if sig := req.header.Get(SignatureHeader); sig != "" {
+		t.Errorf("Expected no signature without a secret, got %q", sig)
+	}
+}
+
+func TestWebhookPublisher_PublishBatch(t *testing.T) {
+	server, requests := webhookServer(t)
+	p := newTestWebhookPublisher(t, &config.BrokerWebhookConfig{URL: server.URL})
+	if !p.SupportsBatch() {
+		t.Fatal("Expected structured mode to support batches")
+	}
+
+	first, second := newWebhookTestEvent(t), newWebhookTestEvent(t)
+	second.SetID("event-2")
+	if err := p.PublishBatch(context.Background(), "clusters", []*cloudevents.Event{first, second}); err != nil {
+		t.Fatalf("PublishBatch failed: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(got))
+	}
+	if ct := got[0].header.Get("Content-Type"); ct != "application/cloudevents-batch+json" {
+		t.Errorf("Expected a CloudEvents batch, got Content-Type %q", ct)
+	}
+	var batch []cloudevents.Event
+	if err := json.Unmarshal(got[0].body, &batch); err != nil {
+		t.Fatalf("Expected the body to be a JSON array of CloudEvents: %v", err)
+	}
+	if len(batch) != 2 || batch[0].ID() != "event-1" || batch[1].ID() != "event-2" {
+		t.Errorf("Expected events event-1 and event-2 in order, got %v", batch)
+	}
+
+	binary := newTestWebhookPublisher(t, &config.BrokerWebhookConfig{URL: server.URL, Mode: config.WebhookModeBinary})
+	if binary.SupportsBatch() {
+		t.Error("Expected binary mode not to support batches")
+	}
+}
+
+func TestWebhookPublisher_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		maxAttempts  int
+		wantRequests int
+		wantErr      bool
+	}{
+		{
+			name:         "transient failures are retried",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			wantRequests: 3,
+		},
+		{
+			name:         "attempts are bounded",
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway},
+			maxAttempts:  2,
+			wantRequests: 2,
+			wantErr:      true,
+		},
+		{
+			name:         "client errors are not retried",
+			statuses:     []int{http.StatusBadRequest},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := webhookServer(t, tt.statuses...)
+			p := newTestWebhookPublisher(t, &config.BrokerWebhookConfig{URL: server.URL, MaxAttempts: tt.maxAttempts})
+
+			err := p.Publish(context.Background(), "clusters", newWebhookTestEvent(t))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(requests()); got != tt.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tt.wantRequests, got)
+			}
+		})
+	}
+}
+
+func TestWebhookPublisher_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+	p := newTestWebhookPublisher(t, &config.BrokerWebhookConfig{
+		URL: server.URL, Timeout: 10 * time.Millisecond, MaxAttempts: 1,
+	})
+
+	if err := p.Publish(context.Background(), "clusters", newWebhookTestEvent(t)); err == nil {
+		t.Error("Expected a publish to a hung endpoint to time out")
+	}
+}
+
+func TestWebhookPublisher_BasicAuth(t *testing.T) {
+	server, requests := webhookServer(t, http.StatusForbidden)
+	p := newTestWebhookPublisher(t, &config.BrokerWebhookConfig{
+		URL: strings.Replace(server.URL, "http://", "http://sentinel:hunter2@", 1),
+	})
+
+	err := p.Publish(context.Background(), "clusters", newWebhookTestEvent(t))
+	if err == nil {
+		t.Fatal("Expected a rejected publish to fail")
+	}
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("Expected the error not to leak the password, got %v", err)
+	}
+	auth := requests()[0].header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Basic ") {
+		t.Errorf("Expected URL credentials to be sent as basic auth, got Authorization %q", auth)
+	}
+}
+
+func TestNewWebhookPublisher_Secret(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	for _, path := range []string{filepath.Join(dir, "missing"), empty} {
+		_, err := NewWebhookPublisher(&config.BrokerWebhookConfig{URL: "http://localhost", SecretPath: path}, nil)
+		if err == nil {
+			t.Errorf("Expected an error for secret file %s", path)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, spec string) *Cron {
+	t.Helper()
+	c, err := Parse(spec)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", spec, err)
+	}
+	return c
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{spec: "", wantErr: "must have 5 fields"},
+		{spec: "0 2 * *", wantErr: "must have 5 fields"},
+		{spec: "60 * * * *", wantErr: "minute value 60 is outside 0-59"},
+		{spec: "0 24 * * *", wantErr: "hour value 24"},
+		{spec: "0 0 0 * *", wantErr: "day of month value 0"},
+		{spec: "0 0 * 13 *", wantErr: "month value 13"},
+		{spec: "0 0 * * 8", wantErr: "day of week value 8"},
+		{spec: "0 0 * * mon-foo", wantErr: `invalid day of week value "foo"`},
+		{spec: "*/0 * * * *", wantErr: "step"},
+		{spec: "30-10 * * * *", wantErr: "reversed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := Parse(tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse(%q) error = %v, want it to contain %q", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCron_Matches(t *testing.T) {
+	// 2026-03-02 is a Monday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.March, day, hour, minute, 30, 0, time.UTC)
+	}
+	tests := []struct {
+		time time.Time
+		spec string
+		want bool
+	}{
+		{spec: "0 2 * * *", time: at(2, 2, 0), want: true},
+		{spec: "0 2 * * *", time: at(2, 2, 1)},
+		{spec: "*/15 * * * *", time: at(2, 5, 45), want: true},
+		{spec: "*/15 * * * *", time: at(2, 5, 44)},
+		{spec: "5/20 * * * *", time: at(2, 5, 25), want: true},
+		{spec: "0-30/10 * * * *", time: at(2, 5, 30), want: true},
+		{spec: "0-30/10 * * * *", time: at(2, 5, 40)},
+		{spec: "0 2 * * mon-fri", time: at(2, 2, 0), want: true},
+		{spec: "0 2 * * sat,sun", time: at(2, 2, 0)},
+		{spec: "0 2 * * 7", time: at(1, 2, 0), want: true},
+		{spec: "0 2 * MAR *", time: at(2, 2, 0), want: true},
+		{spec: "0 2 * 4 *", time: at(2, 2, 0)},
+		// Day of month and day of week restricted: either matches.
+		{spec: "0 2 15 * mon", time: at(2, 2, 0), want: true},
+		{spec: "0 2 15 * mon", time: at(15, 2, 0), want: true},
+		{spec: "0 2 15 * mon", time: at(3, 2, 0)},
+		{spec: "@daily", time: at(3, 0, 0), want: true},
+		{spec: "@weekly", time: at(1, 0, 0), want: true},
+		{spec: "@weekly", time: at(2, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec+" "+tt.time.Format(time.RFC3339), func(t *testing.T) {
+			if got := mustParse(t, tt.spec).Matches(tt.time); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCron_Active(t *testing.T) {
+	c := mustParse(t, "0 2 * * sat")
+	// 2026-03-07 is a Saturday.
+	start := time.Date(2026, time.March, 7, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		time time.Time
+		name string
+		want bool
+	}{
+		{name: "before the window", time: start.Add(-time.Second)},
+		{name: "at the start", time: start, want: true},
+		{name: "inside the window", time: start.Add(3*time.Hour + 59*time.Minute), want: true},
+		{name: "at the end", time: start.Add(4 * time.Hour)},
+		{name: "next day", time: start.Add(24 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Active(tt.time, 4*time.Hour); got != tt.want {
+				t.Errorf("Active(%s) = %v, want %v", tt.time, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCron_ActiveInLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	c := mustParse(t, "0 2 * * *")
+	// 02:30 local is 00:30 UTC.
+	at := time.Date(2026, time.March, 7, 0, 30, 0, 0, time.UTC)
+	if !c.Active(at.In(loc), time.Hour) {
+		t.Error("expected window to be active at 02:30 local time")
+	}
+	if c.Active(at, time.Hour) {
+		t.Error("expected window to be inactive at 00:30 UTC")
+	}
+}
@@ -0,0 +1,29 @@
+package sentinel
+
+import "time"
+
+// adaptiveInterval adapts the poll interval to the backlog (adaptive_interval):
+// it halves after a cycle that found pending resources, down to floor, and
+// doubles after a cycle that found none, up to ceiling. Short intervals pick
+// up the next changes of a busy fleet quickly; long ones spare the API while
+// the fleet is quiet.
+type adaptiveInterval struct {
+	current time.Duration
+	floor   time.Duration
+	ceiling time.Duration
+}
+
+func newAdaptiveInterval(initial, floor, ceiling time.Duration) *adaptiveInterval {
+	return &adaptiveInterval{current: initial, floor: floor, ceiling: ceiling}
+}
+
+// next returns the interval until the cycle after one that found pending
+// resources.
+func (a *adaptiveInterval) next(pending int) time.Duration {
+	if pending > 0 {
+		a.current = max(a.current/2, a.floor)
+	} else {
+		a.current = min(a.current*2, a.ceiling)
+	}
+	return a.current
+}
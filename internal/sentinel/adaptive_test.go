@@ -0,0 +1,75 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestAdaptiveInterval_Next(t *testing.T) {
+	a := newAdaptiveInterval(4*time.Second, time.Second, 10*time.Second)
+
+	for _, want := range []time.Duration{2 * time.Second, time.Second, time.Second} {
+		if got := a.next(3); got != want {
+			t.Errorf("next(3) = %s, want %s", got, want)
+		}
+	}
+	for _, want := range []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second} {
+		if got := a.next(0); got != want {
+			t.Errorf("next(0) = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestRunCycle_AdaptivePollInterval(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{reconciledResource("cluster-1", "True", 2)}}
+	cfg := newTestSentinelConfig()
+	cfg.PollInterval = 4 * time.Second
+	cfg.AdaptiveInterval = &config.AdaptiveIntervalConfig{Min: time.Second, Max: time.Minute}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), &MockPublisher{}, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+
+	// The stale resource is pending: the interval halves.
+	if err := s.runCycle(context.Background(), ticker); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := testutil.ToFloat64(m.PollInterval.With(labels)); got != 2 {
+		t.Errorf("Expected poll_interval_seconds == 2 after a pending cycle, got %v", got)
+	}
+
+	// A failed cycle leaves the interval unchanged.
+	fetcher.Err = errors.New("api unavailable")
+	if err := s.runCycle(context.Background(), ticker); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if got := s.interval.current; got != 2*time.Second {
+		t.Errorf("Expected a failed cycle to keep the interval at 2s, got %s", got)
+	}
+
+	// An empty cycle doubles it.
+	fetcher.Err = nil
+	fetcher.Resources = nil
+	if err := s.runCycle(context.Background(), ticker); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := testutil.ToFloat64(m.PollInterval.With(labels)); got != 4 {
+		t.Errorf("Expected poll_interval_seconds == 4 after an empty cycle, got %v", got)
+	}
+}
@@ -0,0 +1,106 @@
+package sentinel
+
+import (
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+)
+
+// backoffEntry is the republish backoff of one resource.
+type backoffEntry struct {
+	nextPublish time.Time
+	delay       time.Duration
+	cycle       uint64
+	generation  int32
+}
+
+// republishBackoff holds back repeated publishes for the same generation of a
+// resource. The first publish is never held back. After each publish, the next
+// one for the same generation waits for the current delay, which starts at
+// initial and doubles up to maxDelay. A newer generation starts over, so spec
+// changes are published right away.
+//
+// Like the evaluation cache, the backoff lives in memory and is used only by
+// the poll loop. Without a state store, a restarted Sentinel publishes stuck
+// resources once more before backing off again.
+type republishBackoff struct {
+	entries  map[string]backoffEntry
+	initial  time.Duration
+	maxDelay time.Duration
+	cycle    uint64
+}
+
+func newRepublishBackoff(initial, maxDelay time.Duration) *republishBackoff {
+	return &republishBackoff{
+		entries:  make(map[string]backoffEntry),
+		initial:  initial,
+		maxDelay: maxDelay,
+	}
+}
+
+// beginCycle marks the start of a poll cycle. Entries seen during the cycle
+// survive the next prune.
+func (b *republishBackoff) beginCycle() {
+	b.cycle++
+}
+
+// suppress reports whether a publish for the resource at now must be held
+// back.
+func (b *republishBackoff) suppress(key string, resource *client.Resource, now time.Time) bool {
+	entry, ok := b.entries[key]
+	if !ok {
+		return false
+	}
+	entry.cycle = b.cycle
+	b.entries[key] = entry
+	return entry.generation == resource.Generation && now.Before(entry.nextPublish)
+}
+
+// published records a publish for the resource at the given time and doubles
+// its delay.
+func (b *republishBackoff) published(key string, resource *client.Resource, at time.Time) {
+	delay := b.initial
+	if entry, ok := b.entries[key]; ok && entry.generation == resource.Generation {
+		delay = min(entry.delay*2, b.maxDelay)
+	}
+	b.entries[key] = backoffEntry{
+		nextPublish: at.Add(delay),
+		delay:       delay,
+		cycle:       b.cycle,
+		generation:  resource.Generation,
+	}
+}
+
+// restore seeds the backoff of a resource from a publish recorded by an
+// earlier run or another replica, unless the resource already has an entry.
+func (b *republishBackoff) restore(key string, generation int32, at time.Time, delay time.Duration) {
+	if _, ok := b.entries[key]; ok || delay <= 0 {
+		return
+	}
+	b.entries[key] = backoffEntry{
+		nextPublish: at.Add(delay),
+		delay:       min(delay, b.maxDelay),
+		cycle:       b.cycle,
+		generation:  generation,
+	}
+}
+
+// delay returns the current delay of a resource, or 0 without an entry.
+func (b *republishBackoff) delay(key string) time.Duration {
+	return b.entries[key].delay
+}
+
+// reset forgets the backoff of a resource that no longer needs publishing.
+func (b *republishBackoff) reset(key string) {
+	delete(b.entries, key)
+}
+
+// prune drops entries of resources not seen in the current cycle. Call it
+// only after a cycle that listed every resource.
+func (b *republishBackoff) prune() {
+	for key, entry := range b.entries {
+		if entry.cycle != b.cycle {
+			delete(b.entries, key)
+		}
+	}
+}
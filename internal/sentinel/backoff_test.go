@@ -0,0 +1,113 @@
+package sentinel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRepublishBackoff(t *testing.T) {
+	start := time.Now()
+	b := newRepublishBackoff(time.Minute, 3*time.Minute)
+	b.beginCycle()
+	gen1 := &client.Resource{ID: "cluster-1", Generation: 1}
+
+	if b.suppress("a", gen1, start) {
+		t.Fatal("expected the first publish not to be held back")
+	}
+	b.published("a", gen1, start)
+
+	// The delay doubles after each publish, up to the maximum.
+	at := start
+	for i, delay := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+		if !b.suppress("a", gen1, at.Add(delay-time.Second)) {
+			t.Fatalf("publish %d: expected a publish before %s to be held back", i, delay)
+		}
+		at = at.Add(delay)
+		if b.suppress("a", gen1, at) {
+			t.Fatalf("publish %d: expected a publish after %s to be allowed", i, delay)
+		}
+		b.published("a", gen1, at)
+	}
+
+	// A new generation is published right away and starts over.
+	gen2 := &client.Resource{ID: "cluster-1", Generation: 2}
+	if b.suppress("a", gen2, at.Add(time.Second)) {
+		t.Error("expected a new generation not to be held back")
+	}
+	b.published("a", gen2, at.Add(time.Second))
+	if got := b.entries["a"].delay; got != time.Minute {
+		t.Errorf("expected the delay to start over for a new generation, got %s", got)
+	}
+
+	b.reset("a")
+	if b.suppress("a", gen2, at.Add(2*time.Second)) {
+		t.Error("expected a reset resource not to be held back")
+	}
+}
+
+func TestRepublishBackoff_Prune(t *testing.T) {
+	start := time.Now()
+	b := newRepublishBackoff(time.Minute, time.Hour)
+	b.beginCycle()
+	b.published("a", &client.Resource{ID: "a", Generation: 1}, start)
+	b.published("b", &client.Resource{ID: "b", Generation: 1}, start)
+
+	b.beginCycle()
+	b.suppress("a", &client.Resource{ID: "a", Generation: 1}, start)
+	b.prune()
+
+	if _, ok := b.entries["a"]; !ok {
+		t.Error("expected a resource seen in the cycle to be kept")
+	}
+	if _, ok := b.entries["b"]; ok {
+		t.Error("expected a resource not seen in the cycle to be pruned")
+	}
+}
+
+func TestTrigger_RepublishBackoff(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	// A new resource is published on every cycle without the backoff.
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{{ID: "cluster-1", Kind: testResourceKind, Generation: 1}},
+	}
+	cfg := newTestSentinelConfig()
+	cfg.RepublishBackoff = &config.RepublishBackoffConfig{InitialInterval: time.Hour, MaxInterval: 4 * time.Hour}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	for range 3 {
+		if err := s.trigger(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if len(pub.publishedEvents) != 1 {
+		t.Errorf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.PublishesSuppressed.With(labels)); got != 2 {
+		t.Errorf("Expected publishes_suppressed_total == 2, got %v", got)
+	}
+	labels["reason"] = reasons.Backoff.String()
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 2 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 2, got %v", reasons.Backoff, got)
+	}
+	if st := s.Status(); st.PendingTotal != 1 {
+		t.Errorf("Expected the held back resource to stay pending, got %d", st.PendingTotal)
+	}
+}
@@ -0,0 +1,73 @@
+package sentinel
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// brokerBackpressure pauses publishing for a cooldown once the broker has
+// failed threshold publishes within a window, so that a failing broker is not
+// sent every pending resource again on every cycle. Publishes left in flight
+// when it trips are not counted towards the next pause.
+//
+// Failures are recorded by the poll loop; the readiness check reads the pause
+// concurrently, so the state is guarded by mu.
+type brokerBackpressure struct {
+	mu        sync.Mutex
+	failures  []time.Time
+	until     time.Time
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+}
+
+func newBrokerBackpressure(threshold int, window, cooldown time.Duration) *brokerBackpressure {
+	return &brokerBackpressure{threshold: threshold, window: window, cooldown: cooldown}
+}
+
+// failed records a publish that failed at, and reports whether it started a
+// pause.
+func (b *brokerBackpressure) failed(at time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if at.Before(b.until) {
+		return false
+	}
+	cutoff := at.Add(-b.window)
+	kept := b.failures[:0]
+	for _, f := range b.failures {
+		if f.After(cutoff) {
+			kept = append(kept, f)
+		}
+	}
+	b.failures = append(kept, at)
+	if len(b.failures) < b.threshold {
+		return false
+	}
+	b.failures = b.failures[:0]
+	b.until = at.Add(b.cooldown)
+	return true
+}
+
+// active reports whether publishing is paused at now.
+func (b *brokerBackpressure) active(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return now.Before(b.until)
+}
+
+// BrokerBackpressureError returns an error while publishing is paused by
+// clients.broker.backpressure, or nil.
+func (s *Sentinel) BrokerBackpressureError() error {
+	if s.backpressure == nil {
+		return nil
+	}
+	s.backpressure.mu.Lock()
+	defer s.backpressure.mu.Unlock()
+	if !time.Now().Before(s.backpressure.until) {
+		return nil
+	}
+	return fmt.Errorf("publishing paused until %s after %d broker errors within %s",
+		s.backpressure.until.Format(time.RFC3339), s.backpressure.threshold, s.backpressure.window)
+}
@@ -0,0 +1,98 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestBrokerBackpressure(t *testing.T) {
+	start := time.Now()
+	b := newBrokerBackpressure(3, time.Minute, 5*time.Minute)
+
+	if b.failed(start) || b.failed(start.Add(10*time.Second)) {
+		t.Fatal("expected no pause below the threshold")
+	}
+	// The first failure has left the window.
+	if b.failed(start.Add(61 * time.Second)) {
+		t.Fatal("expected failures outside the window not to count")
+	}
+	if !b.failed(start.Add(62 * time.Second)) {
+		t.Fatal("expected a pause at the threshold within the window")
+	}
+	tripped := start.Add(62 * time.Second)
+	if !b.active(tripped.Add(4 * time.Minute)) {
+		t.Error("expected publishing to be paused during the cooldown")
+	}
+	if b.failed(tripped.Add(time.Second)) || len(b.failures) != 0 {
+		t.Error("expected failures during the cooldown not to count")
+	}
+	if b.active(tripped.Add(5 * time.Minute)) {
+		t.Error("expected publishing to resume after the cooldown")
+	}
+	if b.failed(tripped.Add(5 * time.Minute)) {
+		t.Error("expected the failures before the pause not to count towards the next one")
+	}
+}
+
+func TestTrigger_BrokerBackpressure(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+		{ID: "cluster-2", Kind: testResourceKind, Generation: 1},
+		{ID: "cluster-3", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.Clients.Broker.Backpressure = &config.BrokerBackpressureConfig{
+		ErrorThreshold: 2, Window: time.Minute, Cooldown: time.Hour,
+	}
+	pub := &MockPublisher{publishError: errors.New("broker unavailable")}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.BrokerBackpressureError(); err != nil {
+		t.Fatalf("Expected no backpressure before any failure, got %v", err)
+	}
+
+	_ = s.trigger(context.Background())
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.BrokerBackpressureActive.With(labels)); got != 1 {
+		t.Errorf("Expected broker_backpressure_active == 1, got %v", got)
+	}
+	if err := s.BrokerBackpressureError(); err == nil {
+		t.Error("Expected BrokerBackpressureError to report the pause")
+	}
+	labels["error_type"] = "publish_error"
+	if got := testutil.ToFloat64(m.BrokerErrors.With(labels)); got != 2 {
+		t.Errorf("Expected 2 publish attempts before the pause, got broker_errors_total == %v", got)
+	}
+	delete(labels, "error_type")
+	labels["reason"] = reasons.BrokerBackpressure.String()
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 1, got %v", reasons.BrokerBackpressure, got)
+	}
+
+	// The broker recovers, but publishing stays paused for the cooldown.
+	pub.publishError = nil
+	_ = s.trigger(context.Background())
+	if len(pub.publishedEvents) != 0 {
+		t.Errorf("Expected no publish during the cooldown, got %d", len(pub.publishedEvents))
+	}
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 4 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 4, got %v", reasons.BrokerBackpressure, got)
+	}
+}
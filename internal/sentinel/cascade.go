@@ -0,0 +1,62 @@
+package sentinel
+
+import (
+	"context"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+)
+
+// cascade publishes a reconcile event with reason cascade for each child of
+// parent: the cascade.resource_type resources whose owner_references point at
+// it. Like resource tagging, cascading is best effort: a failed list is
+// counted as an API error with type cascade_error and a failed publish as a
+// broker error, but neither fails the parent's publish. The children are not
+// evaluated, so their own backoff, dedup, and pause settings do not apply.
+func (s *Sentinel) cascade(ctx context.Context, region Region, parent *client.Resource) {
+	if s.cascadeType == "" {
+		return
+	}
+	resourceType := s.config.ResourceType
+	resourceSelector := metrics.GetResourceSelectorLabel(s.config.ResourceSelector)
+
+	children, _, err := region.Client.FetchResources(ctx, s.cascadeType, nil, client.OwnerFilter(parent.ID))
+	if err != nil {
+		metrics.UpdateAPIErrorsMetric(resourceType, resourceSelector, "cascade_error")
+		s.logger.Warnf(ctx, "Failed to list resources to cascade to resource_id=%s resource_type=%s error=%v",
+			parent.ID, s.cascadeType, err)
+		return
+	}
+
+	decision := engine.Decision{ShouldPublish: true, Reason: reasons.Cascade}
+	for i := range children {
+		child := &children[i]
+		if child.OwnerReferences == nil || child.OwnerReferences.ID != parent.ID {
+			continue
+		}
+		child.Region = region.Name
+		topic := s.topicFor(region, child.Kind)
+		childCtx := logger.WithTopic(ctx, topic)
+
+		event, err := s.newEvent(childCtx, region, child, decision)
+		if err == nil {
+			err = s.setMessageOptions(&event, topic, child)
+		}
+		if err != nil {
+			s.logger.Errorf(childCtx, "Failed to build cascade event resource_id=%s parent_id=%s error=%v",
+				child.ID, parent.ID, err)
+			continue
+		}
+		if err := s.publisher.Publish(childCtx, topic, &event); err != nil {
+			metrics.UpdateBrokerErrorsMetric(resourceType, resourceSelector, "publish_error")
+			s.logger.Errorf(childCtx, "Failed to publish cascade event resource_id=%s parent_id=%s error=%v",
+				child.ID, parent.ID, err)
+			continue
+		}
+		metrics.UpdateEventsPublishedMetric(resourceType, resourceSelector, reasons.Cascade.String())
+		s.logger.Infof(childCtx, "Published cascade event resource_id=%s parent_id=%s", child.ID, parent.ID)
+	}
+}
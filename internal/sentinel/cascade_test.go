@@ -0,0 +1,147 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// cascadeFetcher lists children for the nodepools resource type and
+// otherwise behaves like its Fetcher.
+type cascadeFetcher struct {
+	*clienttest.Fetcher
+	childErr error
+	children []client.Resource
+	filters  []string
+}
+
+func (f *cascadeFetcher) FetchResources(
+	ctx context.Context, resourceType string, labelSelector map[string]string, additionalFilters ...string,
+) ([]client.Resource, client.ListMeta, error) {
+	if resourceType != "nodepools" {
+		return f.Fetcher.FetchResources(ctx, resourceType, labelSelector, additionalFilters...)
+	}
+	f.filters = append(f.filters, additionalFilters...)
+	if f.childErr != nil {
+		return nil, client.ListMeta{}, f.childErr
+	}
+	return append([]client.Resource(nil), f.children...), client.ListMeta{}, nil
+}
+
+func nodePool(id, ownerID string) client.Resource {
+	return client.Resource{
+		ID: id, Kind: "NodePool", Generation: 1,
+		OwnerReferences: &client.ObjectReference{ID: ownerID, Kind: testResourceKind},
+	}
+}
+
+func TestTrigger_Cascade(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &cascadeFetcher{
+		Fetcher: &clienttest.Fetcher{Resources: []client.Resource{
+			{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+		}},
+		children: []client.Resource{
+			nodePool("nodepool-1", "cluster-1"),
+			nodePool("nodepool-2", "cluster-1"),
+			nodePool("nodepool-3", "cluster-2"),
+		},
+	}
+	cfg := newTestSentinelConfig()
+	cfg.Cascade = &config.CascadeConfig{ResourceType: "nodepools"}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("trigger failed: %v", err)
+	}
+
+	if len(fetcher.filters) != 1 || fetcher.filters[0] != client.OwnerFilter("cluster-1") {
+		t.Errorf("Expected the nodepools of cluster-1 to be listed, got filters %v", fetcher.filters)
+	}
+	// The cluster, then its two nodepools; nodepool-3 belongs to another cluster.
+	if len(pub.publishedEvents) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(pub.publishedEvents))
+	}
+	for i, wantID := range []string{"nodepool-1", "nodepool-2"} {
+		event := pub.publishedEvents[i+1]
+		if event.Type() != events.EventType("NodePool") {
+			t.Errorf("Expected event type %q, got %q", events.EventType("NodePool"), event.Type())
+		}
+		data, err := events.Parse(event)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		if data.ID != wantID {
+			t.Errorf("Expected cascade event for %s, got %s", wantID, data.ID)
+		}
+	}
+
+	labels := prometheus.Labels{
+		"resource_type": "clusters", "resource_selector": "all", "reason": reasons.Cascade.String(),
+	}
+	if got := testutil.ToFloat64(m.EventsPublished.With(labels)); got != 2 {
+		t.Errorf("Expected events_published_total{reason=%q} == 2, got %v", reasons.Cascade, got)
+	}
+}
+
+func TestTrigger_CascadeListFailure(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &cascadeFetcher{
+		Fetcher: &clienttest.Fetcher{Resources: []client.Resource{
+			{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+		}},
+		childErr: errors.New("connection refused"),
+	}
+	cfg := newTestSentinelConfig()
+	cfg.Cascade = &config.CascadeConfig{ResourceType: "nodepools"}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected a failed cascade not to fail the cycle, got %v", err)
+	}
+	if len(pub.publishedEvents) != 1 {
+		t.Errorf("Expected the cluster event to be published, got %d events", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{
+		"resource_type": "clusters", "resource_selector": "all", "error_type": "cascade_error",
+	}
+	if got := testutil.ToFloat64(m.APIErrors.With(labels)); got != 1 {
+		t.Errorf("Expected api_errors_total{error_type=\"cascade_error\"} == 1, got %v", got)
+	}
+}
+
+func TestNewSentinel_CascadeSkipsOwnResourceType(t *testing.T) {
+	cfg := newTestSentinelConfig()
+	cfg.ResourceType = "nodepools"
+	cfg.Cascade = &config.CascadeConfig{ResourceType: "nodepools"}
+	s, err := NewSentinel(cfg, &clienttest.Fetcher{}, newTestDecisionEngine(t), &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if s.cascadeType != "" {
+		t.Errorf("Expected the nodepools watcher not to cascade, got %q", s.cascadeType)
+	}
+}
@@ -0,0 +1,38 @@
+package sentinel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/payload"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+// CheckConfig reports the errors NewMultiRegionSentinel would return for
+// cfg, a validated watcher configuration, without creating a Sentinel: the
+// clients.broker source and message_options templates, the message_data
+// expressions, and the message_schema. The message_decision is checked by
+// creating its decider.
+func CheckConfig(cfg *config.SentinelConfig, log logger.HyperFleetLogger) error {
+	var errs []error
+	attrs, err := newEventAttributes(cfg)
+	if err == nil {
+		_, err = attrs.source()
+	}
+	errs = append(errs, err)
+	if _, err := newMessageOptions(cfg.Clients.Broker); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.MessageData != nil {
+		if _, err := payload.NewBuilder(cfg.MessageData, log); err != nil {
+			errs = append(errs, fmt.Errorf("failed to create payload builder: %w", err))
+		}
+	}
+	if ms := cfg.MessageSchema; ms != nil && ms.Enabled {
+		if _, err := payload.LoadSchema(ms.Path); err != nil {
+			errs = append(errs, fmt.Errorf("message_schema: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,59 @@
+package sentinel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+func TestCheckConfig(t *testing.T) {
+	tests := []struct {
+		modify  func(cfg *config.SentinelConfig)
+		name    string
+		wantErr []string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *config.SentinelConfig) {},
+		},
+		{
+			name: "invalid message_data",
+			modify: func(cfg *config.SentinelConfig) {
+				cfg.MessageData = map[string]interface{}{"id": "resource.("}
+			},
+			wantErr: []string{"payload builder"},
+		},
+		{
+			name: "invalid message_data and message_schema",
+			modify: func(cfg *config.SentinelConfig) {
+				cfg.MessageData = map[string]interface{}{"id": "resource.("}
+				cfg.MessageSchema = &config.MessageSchemaConfig{Enabled: true, Path: "/nonexistent/schema.json"}
+			},
+			wantErr: []string{"payload builder", "message_schema"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestSentinelConfig()
+			tt.modify(cfg)
+			err := CheckConfig(cfg, logger.NewHyperFleetLogger())
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error to contain %q, got %v", want, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package sentinel
+
+import (
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+)
+
+// cooldownEntry is the last publish of one resource and the status it was
+// published with.
+type cooldownEntry struct {
+	publishedAt time.Time
+	lastUpdated time.Time
+	generation  int32
+}
+
+// updateCooldown holds back publishes for a resource until its status is
+// updated after a publish. The adapters that process an event bump the
+// last_updated_time of the resource's conditions; until they do, another
+// event would only repeat the last one. A newer generation, a later
+// last_updated_time, or the timeout ends the cooldown, so a violated adapter
+// contract delays events but never stops them.
+//
+// Like the dedup window, it lives in memory and is used only by the poll
+// loop.
+type updateCooldown struct {
+	entries map[string]cooldownEntry
+	timeout time.Duration
+}
+
+func newUpdateCooldown(timeout time.Duration) *updateCooldown {
+	return &updateCooldown{
+		entries: make(map[string]cooldownEntry),
+		timeout: timeout,
+	}
+}
+
+// suppress reports whether a publish for the resource at now must wait for
+// its status to be updated.
+func (c *updateCooldown) suppress(key string, resource *client.Resource, now time.Time) bool {
+	entry, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	if entry.generation != resource.Generation || referenceTime(resource).After(entry.lastUpdated) ||
+		!now.Before(entry.publishedAt.Add(c.timeout)) {
+		delete(c.entries, key)
+		return false
+	}
+	return true
+}
+
+// published records a publish for the resource at the given time.
+func (c *updateCooldown) published(key string, resource *client.Resource, at time.Time) {
+	c.entries[key] = cooldownEntry{
+		publishedAt: at,
+		lastUpdated: referenceTime(resource),
+		generation:  resource.Generation,
+	}
+}
+
+// prune drops the cooldowns whose timeout elapsed before now. Expired entries
+// suppress nothing, so it may run after any cycle.
+func (c *updateCooldown) prune(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.publishedAt.Add(c.timeout)) {
+			delete(c.entries, key)
+		}
+	}
+}
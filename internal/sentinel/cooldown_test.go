@@ -0,0 +1,111 @@
+package sentinel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestUpdateCooldown(t *testing.T) {
+	start := time.Now()
+	c := newUpdateCooldown(time.Hour)
+	updated := start.Add(-time.Minute)
+	resource := &client.Resource{ID: "cluster-1", Generation: 1, Status: client.ResourceStatus{
+		Conditions: []client.Condition{{Type: "Adapter1Successful", LastUpdatedTime: updated}},
+	}}
+
+	if c.suppress("a", resource, start) {
+		t.Fatal("expected the first publish not to be suppressed")
+	}
+	c.published("a", resource, start)
+
+	if !c.suppress("a", resource, start.Add(time.Minute)) {
+		t.Error("expected a publish before the status is updated to be suppressed")
+	}
+	if c.suppress("a", &client.Resource{ID: "cluster-1", Generation: 2}, start.Add(time.Minute)) {
+		t.Error("expected a publish for a new generation not to be suppressed")
+	}
+
+	c.published("a", resource, start)
+	if c.suppress("a", resource, start.Add(time.Hour)) {
+		t.Error("expected a publish after the timeout not to be suppressed")
+	}
+
+	c.published("a", resource, start)
+	resource.Status.Conditions[0].LastUpdatedTime = start.Add(time.Second)
+	if c.suppress("a", resource, start.Add(time.Minute)) {
+		t.Error("expected a publish after the status was updated not to be suppressed")
+	}
+	if c.suppress("b", resource, start) {
+		t.Error("expected a publish for another resource not to be suppressed")
+	}
+}
+
+func TestUpdateCooldown_Prune(t *testing.T) {
+	start := time.Now()
+	c := newUpdateCooldown(time.Minute)
+	c.published("old", &client.Resource{ID: "old"}, start)
+	c.published("new", &client.Resource{ID: "new"}, start.Add(30*time.Second))
+
+	c.prune(start.Add(time.Minute))
+	if _, ok := c.entries["old"]; ok {
+		t.Error("expected the cooldown whose timeout elapsed to be pruned")
+	}
+	if _, ok := c.entries["new"]; !ok {
+		t.Error("expected the cooldown within its timeout to be kept")
+	}
+}
+
+func TestTrigger_UpdateCooldown(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.UpdateCooldown = &config.UpdateCooldownConfig{Timeout: time.Hour}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	// No adapter has updated the resource's status since the first publish.
+	for range 2 {
+		if err := s.trigger(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.PendingResources.With(labels)); got != 1 {
+		t.Errorf("Expected the held back resource to count as pending, got pending_resources == %v", got)
+	}
+	labels["reason"] = reasons.AwaitingUpdate.String()
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 1, got %v", reasons.AwaitingUpdate, got)
+	}
+
+	// An adapter updates the status, so the next publish goes out.
+	fetcher.Resources[0].Status.Conditions = []client.Condition{
+		{Type: "Adapter1Successful", Status: "False", LastUpdatedTime: time.Now()},
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 2 {
+		t.Errorf("Expected a publish after the status update, got %d events", len(pub.publishedEvents))
+	}
+}
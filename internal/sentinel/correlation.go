@@ -0,0 +1,38 @@
+package sentinel
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/telemetry"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// shardLabel returns the value of the shard extension attribute: the
+// resource_selector metric label, followed by /index/total when resources are
+// split between replicas with shard.
+func shardLabel(cfg *config.SentinelConfig) string {
+	label := metrics.GetResourceSelectorLabel(cfg.ResourceSelector)
+	if sh := cfg.Shard; sh != nil && sh.Total > 1 {
+		return label + "/" + sh.String()
+	}
+	return label
+}
+
+// setCorrelation sets the extension attributes that let consumers correlate
+// event with the logs and traces of the Sentinel: traceparent from the span
+// in ctx, opid from the operation ID of the poll cycle, shard, and
+// sentinelversion from the logger configuration. traceparent and opid are
+// left unset when ctx has no valid span or operation ID.
+func (s *Sentinel) setCorrelation(ctx context.Context, event *cloudevents.Event) {
+	telemetry.SetTraceContext(event, oteltrace.SpanFromContext(ctx))
+	if opID := logger.GetOpID(ctx); opID != "" {
+		event.SetExtension(events.OpIDExtension, opID)
+	}
+	event.SetExtension(events.ShardExtension, s.shard)
+	event.SetExtension(events.SentinelVersionExtension, logger.GetGlobalConfig().Version)
+}
@@ -0,0 +1,70 @@
+package sentinel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+)
+
+// cycleSummaryTimeout bounds publishing the summary of a poll cycle.
+const cycleSummaryTimeout = 10 * time.Second
+
+// publishCycleSummary publishes a CycleSummaryEventType event for cycle to
+// clients.broker.cycle_summary_topic, if it is set. The summary is sent also
+// when the cycle failed or reached its deadline; a failed publish is only
+// logged. Nothing is published in dry-run mode.
+func (s *Sentinel) publishCycleSummary(ctx context.Context, cycle CycleStatus, counts *pollCounts) {
+	b := s.config.Clients.Broker
+	if b == nil || b.CycleSummaryTopic == "" || s.config.DryRun {
+		return
+	}
+	topic := b.ResolveTopic("", b.CycleSummaryTopic)
+
+	if err := s.sendCycleSummary(ctx, topic, cycle, counts); err != nil {
+		s.logger.Warnf(ctx, "Failed to publish cycle summary topic=%s error=%v", topic, err)
+	}
+}
+
+func (s *Sentinel) sendCycleSummary(ctx context.Context, topic string, cycle CycleStatus, counts *pollCounts) error {
+	eventID, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("failed to generate cycle summary event ID: %w", err)
+	}
+
+	event := cloudevents.NewEvent()
+	event.SetSpecVersion(cloudevents.VersionV1)
+	event.SetType(events.CycleSummaryEventType)
+	event.SetSource(s.source)
+	event.SetExtension(events.SchemaVersionExtension, events.SchemaVersion)
+	event.SetExtension(events.PartitionKeyExtension, s.source)
+	s.setCorrelation(ctx, &event)
+	event.SetID(eventID.String())
+	summary := events.CycleSummary{
+		Started:          cycle.Started,
+		ResourceSelector: s.config.ResourceSelector.ToMap(),
+		Sentinel:         s.config.Sentinel.Name,
+		ResourceType:     s.config.ResourceType,
+		OpID:             cycle.OpID,
+		Error:            cycle.Error,
+		DurationSeconds:  cycle.Duration.Seconds(),
+		Fetched:          cycle.Total,
+		Published:        cycle.Published,
+		Skipped:          cycle.Skipped,
+		Failed:           counts.failed,
+		Pending:          cycle.Pending,
+		Incremental:      cycle.Incremental,
+	}
+	if err := event.SetData(cloudevents.ApplicationJSON, summary); err != nil {
+		return fmt.Errorf("failed to set cycle summary event data: %w", err)
+	}
+
+	// The cycle may have ended because its context was canceled; the summary
+	// is still worth sending.
+	publishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cycleSummaryTimeout)
+	defer cancel()
+	return s.publisher.Publish(publishCtx, topic, &event)
+}
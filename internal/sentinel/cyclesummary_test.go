@@ -0,0 +1,104 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestTrigger_CycleSummary(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	// cluster-1 is new and published; cluster-2 was reconciled a minute ago
+	// and skipped.
+	reconciled := reconciledResource("cluster-2", "True", 2)
+	reconciled.Status.Conditions[0].LastUpdatedTime = time.Now().Add(-time.Minute)
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+		reconciled,
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.Sentinel.Name = "sentinel-clusters"
+	cfg.Clients.Broker.TopicPrefix = "prod-"
+	cfg.Clients.Broker.CycleSummaryTopic = "sentinel-cycles"
+	pub := &topicPublisher{failTopics: map[string]error{}}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	summary := lastCycleSummary(t, &pub.MockPublisher, "prod-sentinel-cycles")
+	if summary.Sentinel != "sentinel-clusters" || summary.ResourceType != "clusters" {
+		t.Errorf("Expected the summary to name the Sentinel and resource type, got %+v", summary)
+	}
+	if summary.Fetched != 2 || summary.Published != 1 || summary.Skipped != 1 || summary.Failed != 0 {
+		t.Errorf("Expected fetched=2 published=1 skipped=1 failed=0, got %+v", summary)
+	}
+	if summary.OpID == "" || summary.Error != "" {
+		t.Errorf("Expected an op ID and no error, got %+v", summary)
+	}
+
+	// Failed publishes are counted, and the summary still goes out.
+	pub.failTopics["prod-"+testTopic] = errors.New("broker unavailable")
+	_ = s.trigger(context.Background())
+	summary = lastCycleSummary(t, &pub.MockPublisher, "prod-sentinel-cycles")
+	if summary.Published != 0 || summary.Failed != 1 {
+		t.Errorf("Expected published=0 failed=1, got %+v", summary)
+	}
+}
+
+func TestTrigger_NoCycleSummaryByDefault(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, e := range pub.publishedEvents {
+		if e.Type() == events.CycleSummaryEventType {
+			t.Fatal("Expected no cycle summary without clients.broker.cycle_summary_topic")
+		}
+	}
+}
+
+// lastCycleSummary returns the last event published, which must be a cycle
+// summary on topic.
+func lastCycleSummary(t *testing.T, pub *MockPublisher, topic string) *events.CycleSummary {
+	t.Helper()
+	n := len(pub.publishedEvents)
+	if n == 0 {
+		t.Fatal("Expected a cycle summary, got no events")
+	}
+	if got := pub.publishedTopics[n-1]; got != topic {
+		t.Fatalf("Expected the cycle summary on %q, got the last event on %q", topic, got)
+	}
+	event := pub.publishedEvents[n-1]
+	if v := event.Extensions()[events.PartitionKeyExtension]; v != event.Source() {
+		t.Errorf("Expected the source %q as partition key, got %v", event.Source(), v)
+	}
+	summary, err := events.ParseCycleSummary(event)
+	if err != nil {
+		t.Fatalf("ParseCycleSummary failed: %v", err)
+	}
+	return summary
+}
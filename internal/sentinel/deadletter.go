@@ -0,0 +1,144 @@
+package sentinel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+)
+
+// deadLetterEntry counts the failed publishes of one generation of a
+// resource.
+type deadLetterEntry struct {
+	cycle      uint64
+	attempts   int
+	generation int32
+	sent       bool
+}
+
+// deadLetters counts consecutive failed publishes per resource and decides
+// when the event of a resource is dead-lettered: once maxAttempts publishes
+// for the same generation have failed. A successful publish or a newer
+// generation starts over, and a generation is dead-lettered only once.
+//
+// Like the republish backoff, the counts live in memory and are used only by
+// the poll loop.
+type deadLetters struct {
+	entries     map[string]deadLetterEntry
+	maxAttempts int
+	cycle       uint64
+}
+
+func newDeadLetters(maxAttempts int) *deadLetters {
+	return &deadLetters{entries: make(map[string]deadLetterEntry), maxAttempts: maxAttempts}
+}
+
+// beginCycle marks the start of a poll cycle. Entries seen during the cycle
+// survive the next prune.
+func (d *deadLetters) beginCycle() {
+	d.cycle++
+}
+
+// failed records a failed publish for the resource, and returns the number of
+// failed publishes for its generation and whether its event is due for the
+// dead letter sink.
+func (d *deadLetters) failed(key string, resource *client.Resource) (int, bool) {
+	entry := d.entries[key]
+	if entry.generation != resource.Generation {
+		entry = deadLetterEntry{generation: resource.Generation}
+	}
+	entry.attempts++
+	entry.cycle = d.cycle
+	d.entries[key] = entry
+	return entry.attempts, !entry.sent && entry.attempts >= d.maxAttempts
+}
+
+// seen keeps the entry of a resource listed in the current cycle.
+func (d *deadLetters) seen(key string) {
+	if entry, ok := d.entries[key]; ok {
+		entry.cycle = d.cycle
+		d.entries[key] = entry
+	}
+}
+
+// sent records that the event of the resource was dead-lettered.
+func (d *deadLetters) sent(key string) {
+	entry := d.entries[key]
+	entry.sent = true
+	d.entries[key] = entry
+}
+
+// published forgets the failed publishes of a resource.
+func (d *deadLetters) published(key string) {
+	delete(d.entries, key)
+}
+
+// prune drops entries of resources not seen in the current cycle. Call it
+// only after a cycle that listed every resource.
+func (d *deadLetters) prune() {
+	for key, entry := range d.entries {
+		if entry.cycle != d.cycle {
+			delete(d.entries, key)
+		}
+	}
+}
+
+// deadLetter records a failed publish of event to topic, and routes the event
+// to clients.broker.dead_letter once it is due. A dead-lettered event is
+// removed from the outbox, so that it does not hold up the replay of others.
+// Failing to dead-letter is logged, and retried on the next failed publish.
+func (s *Sentinel) deadLetter(
+	ctx context.Context, key, topic string, resource *client.Resource, event *cloudevents.Event, publishErr error,
+) {
+	if s.deadLetters == nil {
+		return
+	}
+	attempts, due := s.deadLetters.failed(key, resource)
+	if !due {
+		return
+	}
+
+	dl := event.Clone()
+	dl.SetExtension(events.DeadLetterReasonExtension, publishErr.Error())
+	dl.SetExtension(events.DeadLetterAttemptsExtension, attempts)
+	dl.SetExtension(events.DeadLetterTopicExtension, topic)
+	if err := s.sendDeadLetter(ctx, s.config.Clients.Broker, &dl); err != nil {
+		s.logger.Errorf(ctx, "Failed to dead-letter event resource_id=%s event_id=%s error=%v",
+			resource.ID, event.ID(), err)
+		return
+	}
+	s.deadLetters.sent(key)
+	s.unspool(ctx, key)
+	metrics.UpdateDeadLetteredEventsMetric(s.config.ResourceType,
+		metrics.GetResourceSelectorLabel(s.config.ResourceSelector))
+	s.logger.Warnf(ctx, "Dead-lettered event after repeated failed publishes resource_id=%s event_id=%s attempts=%d",
+		resource.ID, event.ID(), attempts)
+}
+
+// sendDeadLetter publishes event to the dead letter topic, with the topic
+// prefix applied, or appends it to the dead letter file.
+func (s *Sentinel) sendDeadLetter(ctx context.Context, b *config.BrokerConfig, event *cloudevents.Event) error {
+	if b.DeadLetter.Topic != "" {
+		return s.publisher.Publish(ctx, b.ResolveTopic("", b.DeadLetter.Topic), event)
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	f, err := os.OpenFile(b.DeadLetter.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open dead letter file: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write dead letter file: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,171 @@
+package sentinel
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-broker/broker"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// topicFailingPublisher fails every publish to one topic.
+type topicFailingPublisher struct {
+	MockPublisher
+	failTopic string
+}
+
+func (p *topicFailingPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	if topic == p.failTopic {
+		return errors.New("message too large")
+	}
+	return p.MockPublisher.Publish(ctx, topic, event)
+}
+
+func newDeadLetterTestSentinel(
+	t *testing.T, dl *config.BrokerDeadLetterConfig, fetcher *clienttest.Fetcher, pub broker.Publisher,
+) *Sentinel {
+	t.Helper()
+	cfg := newTestSentinelConfig()
+	cfg.Clients.Broker.DeadLetter = dl
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	return s
+}
+
+// readDeadLetters returns the events in the dead letter file at path.
+func readDeadLetters(t *testing.T, path string) []cloudevents.Event {
+	t.Helper()
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var got []cloudevents.Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event cloudevents.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("Expected a CloudEvent per line: %v", err)
+		}
+		got = append(got, event)
+	}
+	return got
+}
+
+func TestTrigger_DeadLetterFile(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	path := filepath.Join(t.TempDir(), "dead-letter.jsonl")
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	pub := &MockPublisher{publishError: errors.New("message too large")}
+	s := newDeadLetterTestSentinel(t, &config.BrokerDeadLetterConfig{MaxAttempts: 2, Path: path}, fetcher, pub)
+
+	for i := range 3 {
+		if err := s.trigger(context.Background()); err != nil {
+			t.Fatalf("trigger failed: %v", err)
+		}
+		if i == 0 && len(readDeadLetters(t, path)) != 0 {
+			t.Fatal("Expected no dead letter before max_attempts failed publishes")
+		}
+	}
+
+	got := readDeadLetters(t, path)
+	if len(got) != 1 {
+		t.Fatalf("Expected the generation to be dead-lettered once, got %d events", len(got))
+	}
+	ext := got[0].Extensions()
+	if ext[events.DeadLetterReasonExtension] != "message too large" {
+		t.Errorf("Expected the publish error as reason, got %v", ext[events.DeadLetterReasonExtension])
+	}
+	if attempts, _ := ext[events.DeadLetterAttemptsExtension].(int32); attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %v", ext[events.DeadLetterAttemptsExtension])
+	}
+	if ext[events.DeadLetterTopicExtension] != testTopic {
+		t.Errorf("Expected topic %q, got %v", testTopic, ext[events.DeadLetterTopicExtension])
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if v := testutil.ToFloat64(m.DeadLetteredEvents.With(labels)); v != 1 {
+		t.Errorf("Expected dead_lettered_events_total 1, got %v", v)
+	}
+
+	// A new generation is dead-lettered again.
+	fetcher.Resources[0].Generation = 2
+	for range 2 {
+		if err := s.trigger(context.Background()); err != nil {
+			t.Fatalf("trigger failed: %v", err)
+		}
+	}
+	if got := readDeadLetters(t, path); len(got) != 2 {
+		t.Errorf("Expected the new generation to be dead-lettered, got %d events", len(got))
+	}
+}
+
+func TestTrigger_DeadLetterTopic(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	pub := &topicFailingPublisher{failTopic: "staging-" + testTopic}
+	s := newDeadLetterTestSentinel(t, &config.BrokerDeadLetterConfig{MaxAttempts: 1, Topic: "dead-letter"}, fetcher, pub)
+	s.config.Clients.Broker.TopicPrefix = "staging-"
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("trigger failed: %v", err)
+	}
+	if len(pub.publishedTopics) != 1 || pub.publishedTopics[0] != "staging-dead-letter" {
+		t.Fatalf("Expected the event on the prefixed dead letter topic, got %v", pub.publishedTopics)
+	}
+	data, err := events.Parse(pub.publishedEvents[0])
+	if err != nil {
+		t.Fatalf("Expected the dead letter to be the reconcile event: %v", err)
+	}
+	if data.ID != "cluster-1" {
+		t.Errorf("Expected the event of cluster-1, got %s", data.ID)
+	}
+}
+
+func TestTrigger_DeadLetterResetByPublish(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	path := filepath.Join(t.TempDir(), "dead-letter.jsonl")
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	pub := &MockPublisher{}
+	s := newDeadLetterTestSentinel(t, &config.BrokerDeadLetterConfig{MaxAttempts: 2, Path: path}, fetcher, pub)
+
+	for _, publishErr := range []error{errors.New("connection refused"), nil, errors.New("connection refused")} {
+		pub.publishError = publishErr
+		if err := s.trigger(context.Background()); err != nil {
+			t.Fatalf("trigger failed: %v", err)
+		}
+	}
+	if got := readDeadLetters(t, path); len(got) != 0 {
+		t.Errorf("Expected failures separated by a publish not to be dead-lettered, got %d events", len(got))
+	}
+}
@@ -0,0 +1,73 @@
+package sentinel
+
+import (
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+)
+
+// dedupEntry is the last publish of one resource.
+type dedupEntry struct {
+	publishedAt time.Time
+	reason      reasons.Reason
+	generation  int32
+}
+
+// publishDedup suppresses publishes identical to a recent one: same resource,
+// generation, and reason within window. Adapters that are slow to update the
+// resource's conditions would otherwise get the same event again every cycle.
+//
+// Like the republish backoff, it lives in memory, is used only by the poll
+// loop, and is seeded from the state store when one is set.
+type publishDedup struct {
+	entries map[string]dedupEntry
+	window  time.Duration
+}
+
+func newPublishDedup(window time.Duration) *publishDedup {
+	return &publishDedup{
+		entries: make(map[string]dedupEntry),
+		window:  window,
+	}
+}
+
+// suppress reports whether a publish for the resource with reason at now
+// duplicates one within the window.
+func (d *publishDedup) suppress(key string, resource *client.Resource, reason reasons.Reason, now time.Time) bool {
+	entry, ok := d.entries[key]
+	return ok && entry.generation == resource.Generation && entry.reason == reason &&
+		now.Before(entry.publishedAt.Add(d.window))
+}
+
+// published records a publish for the resource with reason at the given time.
+func (d *publishDedup) published(key string, resource *client.Resource, reason reasons.Reason, at time.Time) {
+	d.entries[key] = dedupEntry{
+		publishedAt: at,
+		reason:      reason,
+		generation:  resource.Generation,
+	}
+}
+
+// restore seeds the last publish of a resource from one recorded by an
+// earlier run or another replica, unless a later one is already known.
+func (d *publishDedup) restore(key string, generation int32, reason reasons.Reason, at time.Time) {
+	if entry, ok := d.entries[key]; ok && !entry.publishedAt.Before(at) {
+		return
+	}
+	d.entries[key] = dedupEntry{
+		publishedAt: at,
+		reason:      reason,
+		generation:  generation,
+	}
+}
+
+// prune drops the publishes that left the window before now. Expired entries
+// suppress nothing, so it may run after any cycle.
+func (d *publishDedup) prune(now time.Time) {
+	for key, entry := range d.entries {
+		if !now.Before(entry.publishedAt.Add(d.window)) {
+			delete(d.entries, key)
+		}
+	}
+}
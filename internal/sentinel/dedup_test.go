@@ -0,0 +1,103 @@
+package sentinel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPublishDedup(t *testing.T) {
+	start := time.Now()
+	d := newPublishDedup(time.Minute)
+	gen1 := &client.Resource{ID: "cluster-1", Generation: 1}
+
+	if d.suppress("a", gen1, reasons.Matched, start) {
+		t.Fatal("expected the first publish not to be suppressed")
+	}
+	d.published("a", gen1, reasons.Matched, start)
+
+	if !d.suppress("a", gen1, reasons.Matched, start.Add(59*time.Second)) {
+		t.Error("expected an identical publish within the window to be suppressed")
+	}
+	if d.suppress("a", gen1, reasons.Matched, start.Add(time.Minute)) {
+		t.Error("expected an identical publish after the window not to be suppressed")
+	}
+	if d.suppress("a", gen1, reasons.StuckDeletion, start.Add(time.Second)) {
+		t.Error("expected a publish with another reason not to be suppressed")
+	}
+	gen2 := &client.Resource{ID: "cluster-1", Generation: 2}
+	if d.suppress("a", gen2, reasons.Matched, start.Add(time.Second)) {
+		t.Error("expected a publish for a new generation not to be suppressed")
+	}
+	if d.suppress("b", gen1, reasons.Matched, start.Add(time.Second)) {
+		t.Error("expected a publish for another resource not to be suppressed")
+	}
+}
+
+func TestPublishDedup_Prune(t *testing.T) {
+	start := time.Now()
+	d := newPublishDedup(time.Minute)
+	d.published("old", &client.Resource{ID: "old"}, reasons.Matched, start)
+	d.published("new", &client.Resource{ID: "new"}, reasons.Matched, start.Add(30*time.Second))
+
+	d.prune(start.Add(time.Minute))
+	if _, ok := d.entries["old"]; ok {
+		t.Error("expected the publish that left the window to be pruned")
+	}
+	if _, ok := d.entries["new"]; !ok {
+		t.Error("expected the publish within the window to be kept")
+	}
+}
+
+func TestTrigger_Dedup(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.Dedup = &config.DedupConfig{Window: time.Hour}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	// The resource still looks new on the second cycle, but the same event
+	// was published within the window.
+	for range 2 {
+		if err := s.trigger(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.PendingResources.With(labels)); got != 1 {
+		t.Errorf("Expected the duplicate to count as pending, got pending_resources == %v", got)
+	}
+	labels["reason"] = reasons.Duplicate.String()
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 1, got %v", reasons.Duplicate, got)
+	}
+
+	// A new generation is published right away.
+	fetcher.Resources[0].Generation = 2
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 2 {
+		t.Errorf("Expected the new generation to be published, got %d events", len(pub.publishedEvents))
+	}
+}
@@ -0,0 +1,93 @@
+package sentinel
+
+import (
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+)
+
+// driftEntry is the generation drift of one resource.
+type driftEntry struct {
+	since      time.Time
+	cycles     int
+	cycle      uint64
+	generation int32
+}
+
+// generationDrift tracks, per resource, how long its generation has been ahead
+// of the observed generation of a status condition, and holds back publishes
+// until the drift has persisted for minCycles consecutive poll cycles and for
+// minAge. A drift that clears, or a newer generation, starts over. Resources
+// without the condition have no drift, so new resources are not held back.
+//
+// Like the republish backoff, it lives only in memory and is used only by the
+// poll loop.
+type generationDrift struct {
+	entries   map[string]driftEntry
+	condition string
+	minAge    time.Duration
+	minCycles int
+	cycle     uint64
+}
+
+func newGenerationDrift(condition string, minCycles int, minAge time.Duration) *generationDrift {
+	return &generationDrift{
+		entries:   make(map[string]driftEntry),
+		condition: condition,
+		minAge:    minAge,
+		minCycles: minCycles,
+	}
+}
+
+// beginCycle marks the start of a poll cycle. Entries seen during the cycle
+// survive the next prune.
+func (g *generationDrift) beginCycle() {
+	g.cycle++
+}
+
+// hold records the resource's drift for the current cycle and reports whether
+// a publish for it at now must be held back. Call it once per cycle for every
+// resource, whether or not it is published, so that the cycles are counted.
+func (g *generationDrift) hold(key string, resource *client.Resource, now time.Time) bool {
+	if !g.drifted(resource) {
+		delete(g.entries, key)
+		return false
+	}
+
+	entry, ok := g.entries[key]
+	switch {
+	case !ok || entry.generation != resource.Generation:
+		entry = driftEntry{since: now, cycles: 1, generation: resource.Generation}
+	case entry.cycle+1 < g.cycle:
+		// A cycle did not see the resource, so the cycles are no longer
+		// consecutive; the drift itself has not been seen to clear.
+		entry.cycles = 1
+	case entry.cycle < g.cycle:
+		entry.cycles++
+	}
+	entry.cycle = g.cycle
+	g.entries[key] = entry
+
+	return entry.cycles < g.minCycles || now.Sub(entry.since) < g.minAge
+}
+
+// drifted reports whether the resource generation is ahead of the observed
+// generation of the tracked condition.
+func (g *generationDrift) drifted(resource *client.Resource) bool {
+	for _, c := range resource.Status.Conditions {
+		if c.Type == g.condition {
+			return resource.Generation > c.ObservedGeneration
+		}
+	}
+	return false
+}
+
+// prune drops entries of resources not seen in the current cycle. Call it
+// only after a cycle that listed every resource.
+func (g *generationDrift) prune() {
+	for key, entry := range g.entries {
+		if entry.cycle != g.cycle {
+			delete(g.entries, key)
+		}
+	}
+}
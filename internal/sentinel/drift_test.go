@@ -0,0 +1,153 @@
+package sentinel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// driftedResource returns a resource whose Reconciled condition observed
+// generation lags its generation when observed < generation.
+func driftedResource(generation, observed int32) *client.Resource {
+	return &client.Resource{ID: "cluster-1", Generation: generation, Status: client.ResourceStatus{
+		Conditions: []client.Condition{{
+			Type: "Reconciled", Status: "False", ObservedGeneration: observed, LastUpdatedTime: time.Now(),
+		}},
+	}}
+}
+
+func TestGenerationDrift_Cycles(t *testing.T) {
+	now := time.Now()
+	g := newGenerationDrift("Reconciled", 3, 0)
+	drifted := driftedResource(2, 1)
+
+	for cycle := 1; cycle <= 3; cycle++ {
+		g.beginCycle()
+		held := g.hold("a", drifted, now)
+		if want := cycle < 3; held != want {
+			t.Fatalf("cycle %d: hold = %v, want %v", cycle, held, want)
+		}
+	}
+
+	// A newer generation starts over.
+	g.beginCycle()
+	if !g.hold("a", driftedResource(3, 1), now) {
+		t.Error("expected a new generation to be held back")
+	}
+
+	// A cycle that did not see the resource breaks the consecutive cycles.
+	g.beginCycle()
+	g.beginCycle()
+	if !g.hold("a", driftedResource(3, 1), now) {
+		t.Error("expected a gap to restart the cycle count")
+	}
+
+	// Once the drift clears, the next drift starts over.
+	g.beginCycle()
+	if g.hold("a", driftedResource(3, 3), now) {
+		t.Error("expected a resource without drift not to be held back")
+	}
+	if _, ok := g.entries["a"]; ok {
+		t.Error("expected the entry to be dropped once the drift clears")
+	}
+}
+
+func TestGenerationDrift_Duration(t *testing.T) {
+	start := time.Now()
+	g := newGenerationDrift("Reconciled", 0, 30*time.Second)
+	drifted := driftedResource(2, 1)
+
+	g.beginCycle()
+	if !g.hold("a", drifted, start) {
+		t.Fatal("expected a new drift to be held back")
+	}
+	g.beginCycle()
+	if !g.hold("a", drifted, start.Add(29*time.Second)) {
+		t.Error("expected a drift younger than the duration to be held back")
+	}
+	g.beginCycle()
+	if g.hold("a", drifted, start.Add(30*time.Second)) {
+		t.Error("expected a drift that persisted for the duration not to be held back")
+	}
+}
+
+func TestGenerationDrift_NoCondition(t *testing.T) {
+	g := newGenerationDrift("Reconciled", 3, 0)
+	g.beginCycle()
+	if g.hold("a", &client.Resource{ID: "cluster-1", Generation: 1}, time.Now()) {
+		t.Error("expected a resource without the condition not to be held back")
+	}
+	if !g.hold("a", driftedResource(2, 1), time.Now()) {
+		t.Error("expected a drifted resource to be held back")
+	}
+	other := newGenerationDrift("Available", 3, 0)
+	other.beginCycle()
+	if other.hold("a", driftedResource(2, 1), time.Now()) {
+		t.Error("expected only the configured condition to be checked")
+	}
+}
+
+func TestGenerationDrift_Prune(t *testing.T) {
+	g := newGenerationDrift("Reconciled", 3, 0)
+	g.beginCycle()
+	g.hold("a", driftedResource(2, 1), time.Now())
+	g.hold("b", driftedResource(2, 1), time.Now())
+
+	g.beginCycle()
+	g.hold("b", driftedResource(2, 1), time.Now())
+	g.prune()
+	if _, ok := g.entries["a"]; ok {
+		t.Error("expected prune to drop entries not seen in the current cycle")
+	}
+	if _, ok := g.entries["b"]; !ok {
+		t.Error("expected prune to keep entries seen in the current cycle")
+	}
+}
+
+func TestTrigger_GenerationDrift(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	// The spec changed (generation 3) since the last reconciliation
+	// (generation 2), so the default generation_mismatch param publishes.
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{*driftedResource(3, 2)}}
+	fetcher.Resources[0].Kind = testResourceKind
+	cfg := newTestSentinelConfig()
+	cfg.GenerationDrift = &config.GenerationDriftConfig{Cycles: 2}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 0 {
+		t.Fatalf("Expected the first cycle to hold the publish back, got %d events", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{
+		"resource_type":     "clusters",
+		"resource_selector": "all",
+		"reason":            reasons.GenerationDrift.String(),
+	}
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 1, got %v", reasons.GenerationDrift, got)
+	}
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 1 {
+		t.Errorf("Expected the second cycle to publish, got %d events", len(pub.publishedEvents))
+	}
+}
@@ -0,0 +1,107 @@
+package sentinel
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+)
+
+// evalCacheEntry is the outcome of the last evaluation of one resource.
+type evalCacheEntry struct {
+	evaluatedAt time.Time
+	err         error
+	reason      reasons.Reason
+	condition   string
+	version     uint64
+	cycle       uint64
+}
+
+// evaluationCache remembers, per resource, a content version and the last
+// decision not to publish, so that unchanged resources can skip the decision
+// engine until revalidateAfter has elapsed. The HyperFleet API does not expose
+// a per-resource version, so the version is a hash of the resource as
+// returned by the API.
+//
+// Only the poll loop uses the cache, so it is not synchronized. Like the rest
+// of the Sentinel's state, it lives only in memory.
+type evaluationCache struct {
+	entries         map[string]evalCacheEntry
+	revalidateAfter time.Duration
+	cycle           uint64
+}
+
+func newEvaluationCache(revalidateAfter time.Duration) *evaluationCache {
+	return &evaluationCache{
+		entries:         make(map[string]evalCacheEntry),
+		revalidateAfter: revalidateAfter,
+	}
+}
+
+// beginCycle marks the start of a poll cycle. Entries looked up or stored
+// during the cycle survive the next prune.
+func (c *evaluationCache) beginCycle() {
+	c.cycle++
+}
+
+// lookup returns the cached skip decision for the resource if its version is
+// unchanged and it was evaluated less than revalidateAfter ago.
+func (c *evaluationCache) lookup(key string, version uint64, now time.Time) (engine.Decision, bool) {
+	entry, ok := c.entries[key]
+	if !ok || entry.version != version || now.Sub(entry.evaluatedAt) >= c.revalidateAfter {
+		return engine.Decision{}, false
+	}
+	entry.cycle = c.cycle
+	c.entries[key] = entry
+	return engine.Decision{ShouldPublish: false, Reason: entry.reason, Err: entry.err, Condition: entry.condition}, true
+}
+
+// store records the decision for the resource. Decisions to publish are not
+// cached, since the resource must be evaluated again on the next cycle, and
+// neither are maintenance window skips, so that publishing resumes as soon as
+// the window closes.
+func (c *evaluationCache) store(key string, version uint64, now time.Time, decision engine.Decision) {
+	if decision.ShouldPublish || decision.Reason == reasons.MaintenanceWindow {
+		delete(c.entries, key)
+		return
+	}
+	c.entries[key] = evalCacheEntry{
+		evaluatedAt: now,
+		err:         decision.Err,
+		reason:      decision.Reason,
+		condition:   decision.Condition,
+		version:     version,
+		cycle:       c.cycle,
+	}
+}
+
+// prune drops entries of resources not seen in the current cycle. Call it
+// only after a cycle that listed every resource.
+func (c *evaluationCache) prune() {
+	for key, entry := range c.entries {
+		if entry.cycle != c.cycle {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// evalCacheKey identifies a resource across regions.
+func evalCacheKey(region, id string) string {
+	return region + "/" + id
+}
+
+// resourceVersion hashes the resource content seen by the decision engine.
+// ok is false if the resource cannot be encoded, in which case it must not be
+// cached.
+func resourceVersion(resource *client.Resource) (version uint64, ok bool) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return 0, false
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(data) //nolint:errcheck // hash.Hash writes never fail
+	return h.Sum64(), true
+}
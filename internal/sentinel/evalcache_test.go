@@ -0,0 +1,64 @@
+package sentinel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+)
+
+func TestEvaluationCache(t *testing.T) {
+	now := time.Now()
+	skip := engine.Decision{ShouldPublish: false, Reason: reasons.NotMatched}
+
+	c := newEvaluationCache(time.Minute)
+	c.beginCycle()
+	c.store("a", 1, now, skip)
+	c.store("b", 1, now, engine.Decision{ShouldPublish: true, Reason: reasons.Matched})
+
+	if d, hit := c.lookup("a", 1, now.Add(30*time.Second)); !hit || d.Reason != skip.Reason || d.ShouldPublish {
+		t.Errorf("expected cached skip decision, got %+v hit=%v", d, hit)
+	}
+	if _, hit := c.lookup("a", 2, now); hit {
+		t.Error("expected a miss for a changed version")
+	}
+	if _, hit := c.lookup("a", 1, now.Add(time.Minute)); hit {
+		t.Error("expected a miss once revalidate_after has elapsed")
+	}
+	if _, hit := c.lookup("b", 1, now); hit {
+		t.Error("expected decisions to publish not to be cached")
+	}
+	c.store("w", 1, now, engine.Decision{ShouldPublish: false, Reason: reasons.MaintenanceWindow, Window: "nightly"})
+	if _, hit := c.lookup("w", 1, now); hit {
+		t.Error("expected maintenance window skips not to be cached")
+	}
+
+	// "a" was not touched in the new cycle, "c" was.
+	c.beginCycle()
+	c.store("c", 1, now, skip)
+	c.prune()
+	if _, ok := c.entries["a"]; ok {
+		t.Error("expected prune to drop entries not seen in the current cycle")
+	}
+	if _, ok := c.entries["c"]; !ok {
+		t.Error("expected prune to keep entries seen in the current cycle")
+	}
+}
+
+func TestResourceVersion(t *testing.T) {
+	r := &client.Resource{ID: "cluster-1", Generation: 1, Labels: map[string]string{"shard": "1"}}
+	v1, ok := resourceVersion(r)
+	if !ok {
+		t.Fatal("expected resource to be hashable")
+	}
+	if v, _ := resourceVersion(r); v != v1 {
+		t.Error("expected a stable version for unchanged content")
+	}
+
+	r.Labels["maintenance"] = "true"
+	if v, _ := resourceVersion(r); v == v1 {
+		t.Error("expected the version to change with the labels")
+	}
+}
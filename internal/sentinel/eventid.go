@@ -0,0 +1,58 @@
+package sentinel
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+)
+
+// eventIDNamespace is the UUID namespace of deterministic event IDs. Changing
+// it changes every ID, so it is fixed.
+var eventIDNamespace = uuid.MustParse("d9e607d6-575b-4d71-b77a-5a74b9214565")
+
+// newEventID returns the ID of a reconcile event for resource published for
+// reason: a random UUID v7, or with clients.broker.event_id_mode deterministic
+// the ID from deterministicEventID.
+func (s *Sentinel) newEventID(resource *client.Resource, reason reasons.Reason) (string, error) {
+	if s.config.Clients.Broker.DeterministicEventIDs() {
+		return deterministicEventID(resource, reason), nil
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// deterministicEventID derives a UUID v5 from the resource kind, region, ID,
+// and generation, its reference time, and reason. Publishing the same
+// resource again for the same reason before its status changes yields the
+// same ID, so consumers can drop the repeat; any new generation or condition
+// update yields a new one.
+func deterministicEventID(resource *client.Resource, reason reasons.Reason) string {
+	name := strings.Join([]string{
+		resource.Kind,
+		resource.Region,
+		resource.ID,
+		strconv.FormatInt(int64(resource.Generation), 10),
+		referenceTime(resource).UTC().Format(time.RFC3339Nano),
+		reason.String(),
+	}, "\x00")
+	return uuid.NewSHA1(eventIDNamespace, []byte(name)).String()
+}
+
+// referenceTime returns the latest last updated time of the resource's
+// conditions, or its created time when it has none.
+func referenceTime(resource *client.Resource) time.Time {
+	ref := resource.CreatedTime
+	for _, c := range resource.Status.Conditions {
+		if c.LastUpdatedTime.After(ref) {
+			ref = c.LastUpdatedTime
+		}
+	}
+	return ref
+}
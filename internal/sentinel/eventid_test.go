@@ -0,0 +1,101 @@
+package sentinel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestDeterministicEventID(t *testing.T) {
+	updated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	base := func() *client.Resource {
+		return &client.Resource{
+			ID:         "cluster-1",
+			Kind:       testResourceKind,
+			Generation: 2,
+			Status: client.ResourceStatus{Conditions: []client.Condition{
+				{Type: "Reconciled", Status: "True", LastUpdatedTime: updated},
+			}},
+		}
+	}
+
+	id := deterministicEventID(base(), reasons.Matched)
+	if _, err := uuid.Parse(id); err != nil {
+		t.Fatalf("Expected a UUID, got %q: %v", id, err)
+	}
+	if again := deterministicEventID(base(), reasons.Matched); again != id {
+		t.Errorf("Expected the same ID for the same resource and reason, got %q then %q", id, again)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*client.Resource)
+		reason reasons.Reason
+	}{
+		{name: "generation", modify: func(r *client.Resource) { r.Generation++ }},
+		{name: "condition update", modify: func(r *client.Resource) {
+			r.Status.Conditions[0].LastUpdatedTime = updated.Add(time.Second)
+		}},
+		{name: "region", modify: func(r *client.Resource) { r.Region = "us-east" }},
+		{name: "kind", modify: func(r *client.Resource) { r.Kind = "NodePool" }},
+		{name: "reason", reason: reasons.StuckDeletion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := base()
+			if tt.modify != nil {
+				tt.modify(r)
+			}
+			reason := reasons.Matched
+			if tt.reason != "" {
+				reason = tt.reason
+			}
+			if got := deterministicEventID(r, reason); got == id {
+				t.Errorf("Expected a different ID after changing the %s, got the same %q", tt.name, got)
+			}
+		})
+	}
+}
+
+func TestTrigger_DeterministicEventIDs(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	for _, mode := range []string{config.EventIDModeRandom, config.EventIDModeDeterministic} {
+		t.Run(mode, func(t *testing.T) {
+			cfg := newTestSentinelConfig()
+			cfg.Clients.Broker.EventIDMode = mode
+			pub := &MockPublisher{}
+			s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+			if err != nil {
+				t.Fatalf("NewSentinel failed: %v", err)
+			}
+
+			for range 2 {
+				if err := s.trigger(context.Background()); err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+			}
+			if len(pub.publishedEvents) != 2 {
+				t.Fatalf("Expected 2 published events, got %d", len(pub.publishedEvents))
+			}
+			same := pub.publishedEvents[0].ID() == pub.publishedEvents[1].ID()
+			if want := mode == config.EventIDModeDeterministic; same != want {
+				t.Errorf("Expected repeated publishes to share an ID: %v, got IDs %q and %q",
+					want, pub.publishedEvents[0].ID(), pub.publishedEvents[1].ID())
+			}
+		})
+	}
+}
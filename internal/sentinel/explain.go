@@ -0,0 +1,73 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+)
+
+// ErrUnknownRegion is returned by Explain when the region is not polled by
+// the Sentinel, or is empty while several regions are.
+var ErrUnknownRegion = errors.New("unknown region")
+
+// ExplainResource explains the decision for resource at now. Deciders other
+// than engine.DecisionEngine are not broken down: the explanation carries
+// only their decision, and neither is a resource with the pause label, which
+// no decider evaluates. Pause, backoff and the other Sentinel-level checks
+// made after the decision are not included. ctx bounds the decider's query,
+// if it makes one.
+func (s *Sentinel) ExplainResource(ctx context.Context, resource *client.Resource, now time.Time) *engine.Explanation {
+	s.mu.RLock()
+	decider := s.decider
+	s.mu.RUnlock()
+	if resource != nil && engine.IsPaused(resource) {
+		return &engine.Explanation{
+			Now:        now,
+			Decision:   engine.Decision{ShouldPublish: false, Reason: reasons.ResourcePaused},
+			Generation: resource.Generation,
+		}
+	}
+	if de, ok := decider.(*engine.DecisionEngine); ok {
+		return de.Explain(resource, now)
+	}
+	return &engine.Explanation{Now: now, Decision: decider.Evaluate(ctx, resource, now)}
+}
+
+// Explain fetches the resource with the given ID from the named region and
+// explains its decision at now. The region may be empty for a Sentinel that
+// polls a single region.
+func (s *Sentinel) Explain(
+	ctx context.Context, regionName, id string, now time.Time,
+) (*client.Resource, *engine.Explanation, error) {
+	region, err := s.region(regionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	resourceType := s.currentConfig().ResourceType
+	resource, err := region.Client.GetResource(ctx, resourceType, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch %s %s: %w", resourceType, id, err)
+	}
+	resource.Region = region.Name
+	return resource, s.ExplainResource(ctx, resource, now), nil
+}
+
+func (s *Sentinel) region(name string) (Region, error) {
+	if name == "" && len(s.regions) == 1 {
+		return s.regions[0], nil
+	}
+	for _, r := range s.regions {
+		if name != "" && r.Name == name {
+			return r, nil
+		}
+	}
+	if name == "" {
+		return Region{}, fmt.Errorf("%w: a region is required when polling %d regions", ErrUnknownRegion, len(s.regions))
+	}
+	return Region{}, fmt.Errorf("%w %q", ErrUnknownRegion, name)
+}
@@ -0,0 +1,109 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+)
+
+func TestExplain_FetchesByID(t *testing.T) {
+	now := time.Now()
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "stale", Kind: testResourceKind, Generation: 2, Status: client.ResourceStatus{
+			Conditions: []client.Condition{{
+				Type: "Reconciled", Status: "True", ObservedGeneration: 2, LastUpdatedTime: now.Add(-time.Hour),
+			}},
+		}},
+	}}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, nil, &MockPublisher{}, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	resource, ex, err := s.Explain(context.Background(), "", "stale", now)
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if resource.ID != "stale" {
+		t.Errorf("resource.ID = %q, want stale", resource.ID)
+	}
+	if !ex.Decision.ShouldPublish || len(ex.Params) == 0 {
+		t.Errorf("explanation = %+v, want a published resource broken down by param", ex)
+	}
+
+	_, _, err = s.Explain(context.Background(), "", "missing", now)
+	if !client.IsNotFound(err) {
+		t.Errorf("Explain(missing) error = %v, want not found", err)
+	}
+}
+
+func TestExplain_Regions(t *testing.T) {
+	regions := []Region{
+		{Name: "us-east", Client: &clienttest.Fetcher{Resources: []client.Resource{{ID: "east-1", Generation: 1}}}},
+		{Name: "eu-west", Client: &clienttest.Fetcher{Resources: []client.Resource{{ID: "west-1", Generation: 1}}}},
+	}
+	s, err := NewMultiRegionSentinel(newTestSentinelConfig(), regions, nil, &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewMultiRegionSentinel failed: %v", err)
+	}
+
+	resource, _, err := s.Explain(context.Background(), "eu-west", "west-1", time.Now())
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if resource.Region != "eu-west" {
+		t.Errorf("resource.Region = %q, want eu-west", resource.Region)
+	}
+
+	for _, region := range []string{"", "ap-south"} {
+		if _, _, err := s.Explain(context.Background(), region, "west-1", time.Now()); !errors.Is(err, ErrUnknownRegion) {
+			t.Errorf("Explain(region %q) error = %v, want ErrUnknownRegion", region, err)
+		}
+	}
+}
+
+func TestExplainResource_CustomDecider(t *testing.T) {
+	decider := deciderFunc(func(context.Context, *client.Resource, time.Time) engine.Decision {
+		return engine.Decision{Reason: reasons.PolicySkip, Rule: "frozen"}
+	})
+	s, err := NewSentinel(newTestSentinelConfig(), &clienttest.Fetcher{}, decider, &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	now := time.Now()
+	ex := s.ExplainResource(context.Background(), &client.Resource{ID: "r1"}, now)
+	if ex.Decision.Reason != reasons.PolicySkip || ex.Decision.Rule != "frozen" || !ex.Now.Equal(now) {
+		t.Errorf("explanation = %+v, want the custom decision", ex)
+	}
+	if ex.Params != nil || ex.NextPublish != nil {
+		t.Errorf("explanation = %+v, want no breakdown for a custom decider", ex)
+	}
+}
+
+func TestExplainResource_PausedLabel(t *testing.T) {
+	s, err := NewSentinel(newTestSentinelConfig(), &clienttest.Fetcher{}, newTestDecisionEngine(t), &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	resource := &client.Resource{ID: "r1", Generation: 2, Labels: map[string]string{config.PausedLabel: "true"}}
+	ex := s.ExplainResource(context.Background(), resource, time.Now())
+	if ex.Decision.ShouldPublish || ex.Decision.Reason != reasons.ResourcePaused || ex.Generation != 2 {
+		t.Errorf("explanation = %+v, want %q", ex, reasons.ResourcePaused)
+	}
+	if ex.NextPublish != nil {
+		t.Errorf("NextPublish = %v, want nil for a paused resource", ex.NextPublish)
+	}
+}
@@ -0,0 +1,84 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrFailureBudgetExhausted is returned by Start once max_consecutive_failures
+// poll cycles in a row have failed.
+var ErrFailureBudgetExhausted = errors.New("max_consecutive_failures reached")
+
+// cycleOutcome classifies a poll cycle for max_consecutive_failures.
+type cycleOutcome int
+
+const (
+	cycleSucceeded cycleOutcome = iota
+	cycleFailed
+	// cycleHeld is a cycle that neither reached the API nor published
+	// because the API circuit breaker or broker backpressure held it back.
+	// It leaves the count unchanged, so that a pause does not hide the
+	// failures around it.
+	cycleHeld
+)
+
+// failureBudget counts consecutive failed poll cycles. It is only used by the
+// poll loop.
+type failureBudget struct {
+	lastErr     string
+	max         int
+	consecutive int
+}
+
+func newFailureBudget(maxFailures int) *failureBudget {
+	return &failureBudget{max: maxFailures}
+}
+
+// record counts the outcome of a poll cycle; cause describes a failed one.
+func (b *failureBudget) record(outcome cycleOutcome, cause string) {
+	switch outcome {
+	case cycleSucceeded:
+		b.consecutive = 0
+		b.lastErr = ""
+	case cycleFailed:
+		b.consecutive++
+		b.lastErr = cause
+	}
+}
+
+// exhausted reports whether max consecutive poll cycles have failed.
+func (b *failureBudget) exhausted() bool {
+	return b.consecutive >= b.max
+}
+
+// classifyCycle returns the outcome of a poll cycle that returned err, and
+// the cause of a failed one. A cycle fails when it could not fetch resources
+// or every publish it attempted failed. A cycle canceled by shutdown is held.
+func (s *Sentinel) classifyCycle(err error, counts *pollCounts, polled int, circuitOpen bool) (cycleOutcome, string) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return cycleHeld, ""
+	case err != nil:
+		return cycleFailed, err.Error()
+	case polled == 0 && circuitOpen:
+		return cycleHeld, ""
+	case counts.failed > 0 && counts.published == 0:
+		return cycleFailed, fmt.Sprintf("all %d publishes failed", counts.failed)
+	case counts.published == 0 && s.backpressure != nil && s.backpressure.active(time.Now()):
+		return cycleHeld, ""
+	default:
+		return cycleSucceeded, ""
+	}
+}
+
+// failureBudgetError returns ErrFailureBudgetExhausted, with the cause of the
+// last failed cycle, once max_consecutive_failures is reached.
+func (s *Sentinel) failureBudgetError() error {
+	if s.failures == nil || !s.failures.exhausted() {
+		return nil
+	}
+	return fmt.Errorf("%w: %d consecutive poll cycles failed, last: %s",
+		ErrFailureBudgetExhausted, s.failures.consecutive, s.failures.lastErr)
+}
@@ -0,0 +1,91 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestStart_FailureBudgetExhausted(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Err: errors.New("connection refused")}
+	cfg := newTestSentinelConfig()
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.MaxConsecutiveFailures = 3
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), &MockPublisher{}, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- s.Start(context.Background()) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrFailureBudgetExhausted) {
+			t.Fatalf("Expected ErrFailureBudgetExhausted, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Start to return after 3 failed cycles")
+	}
+	if len(fetcher.Calls) != 3 {
+		t.Errorf("Expected 3 poll cycles before exiting, got %d", len(fetcher.Calls))
+	}
+}
+
+func TestTrigger_FailureBudget(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+	ctx := context.Background()
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.MaxConsecutiveFailures = 2
+	pub := &MockPublisher{publishError: errors.New("broker unavailable")}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	// A cycle whose every publish fails counts as failed.
+	_ = s.trigger(ctx)
+	if s.failures.consecutive != 1 {
+		t.Fatalf("Expected 1 consecutive failure, got %d", s.failures.consecutive)
+	}
+
+	// A cycle skipped by the open circuit leaves the count unchanged.
+	fetcher.Err = client.ErrCircuitOpen
+	_ = s.trigger(ctx)
+	if s.failures.consecutive != 1 {
+		t.Fatalf("Expected the open circuit to leave 1 consecutive failure, got %d", s.failures.consecutive)
+	}
+	if err := s.failureBudgetError(); err != nil {
+		t.Fatalf("Expected the budget not to be exhausted, got %v", err)
+	}
+
+	// A successful cycle resets the count.
+	fetcher.Err = nil
+	pub.publishError = nil
+	_ = s.trigger(ctx)
+	if s.failures.consecutive != 0 {
+		t.Fatalf("Expected a successful cycle to reset the count, got %d", s.failures.consecutive)
+	}
+
+	fetcher.Err = errors.New("connection refused")
+	_ = s.trigger(ctx)
+	_ = s.trigger(ctx)
+	if err := s.failureBudgetError(); !errors.Is(err, ErrFailureBudgetExhausted) {
+		t.Errorf("Expected ErrFailureBudgetExhausted after 2 failed cycles, got %v", err)
+	}
+}
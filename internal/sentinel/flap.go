@@ -0,0 +1,119 @@
+package sentinel
+
+import (
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+)
+
+// flapEntry is the flap state of one resource.
+type flapEntry struct {
+	transition  time.Time   // last transition time of the condition when last seen
+	lastPublish time.Time   // when an event was last published for the resource
+	status      string      // status of the condition when last seen
+	flips       []time.Time // when status changes were seen within the window, oldest first
+	cycle       uint64
+	seen        bool // whether the condition has been seen
+	flapping    bool
+}
+
+// flapDetector tracks, per resource, the status changes of a condition seen by
+// the poll loop. A resource whose condition changed at least flips times
+// within window is flapping, and is published at most once per
+// publishInterval until fewer changes fall within the window.
+//
+// A change of status counts as one flip; a newer last transition time with
+// the same status means the condition went and came back between two polls,
+// and counts as two. Like the republish backoff, it lives only in memory and
+// is used only by the poll loop.
+type flapDetector struct {
+	entries         map[string]*flapEntry
+	condition       string
+	window          time.Duration
+	publishInterval time.Duration
+	flips           int
+	cycle           uint64
+}
+
+func newFlapDetector(condition string, window time.Duration, flips int, publishInterval time.Duration) *flapDetector {
+	return &flapDetector{
+		entries:         make(map[string]*flapEntry),
+		condition:       condition,
+		window:          window,
+		flips:           flips,
+		publishInterval: publishInterval,
+	}
+}
+
+// beginCycle marks the start of a poll cycle. Entries seen during the cycle
+// survive the next prune.
+func (f *flapDetector) beginCycle() {
+	f.cycle++
+}
+
+// observe records the resource's condition for the current cycle. It reports
+// whether the resource is flapping and whether that changed with this
+// observation. Call it once per cycle for every resource.
+func (f *flapDetector) observe(key string, resource *client.Resource, now time.Time) (flapping, changed bool) {
+	entry, ok := f.entries[key]
+	if !ok {
+		entry = &flapEntry{}
+		f.entries[key] = entry
+	}
+	entry.cycle = f.cycle
+
+	for _, c := range resource.Status.Conditions {
+		if c.Type != f.condition {
+			continue
+		}
+		switch {
+		case !entry.seen:
+		case c.Status != entry.status:
+			entry.flips = append(entry.flips, now)
+		case c.LastTransitionTime.After(entry.transition):
+			entry.flips = append(entry.flips, now, now)
+		}
+		entry.seen = true
+		entry.status = c.Status
+		entry.transition = c.LastTransitionTime
+		break
+	}
+
+	cutoff := now.Add(-f.window)
+	expired := 0
+	for expired < len(entry.flips) && !entry.flips[expired].After(cutoff) {
+		expired++
+	}
+	entry.flips = entry.flips[expired:]
+
+	was := entry.flapping
+	entry.flapping = len(entry.flips) >= f.flips
+	return entry.flapping, entry.flapping != was
+}
+
+// hold reports whether a publish for the resource at now must be held back
+// because it is flapping and was published less than publishInterval ago.
+func (f *flapDetector) hold(key string, now time.Time) bool {
+	entry, ok := f.entries[key]
+	if !ok || !entry.flapping || entry.lastPublish.IsZero() {
+		return false
+	}
+	return now.Sub(entry.lastPublish) < f.publishInterval
+}
+
+// published records that an event for the resource was published at.
+func (f *flapDetector) published(key string, at time.Time) {
+	if entry, ok := f.entries[key]; ok {
+		entry.lastPublish = at
+	}
+}
+
+// prune drops entries of resources not seen in the current cycle. Call it
+// only after a cycle that listed every resource.
+func (f *flapDetector) prune() {
+	for key, entry := range f.entries {
+		if entry.cycle != f.cycle {
+			delete(f.entries, key)
+		}
+	}
+}
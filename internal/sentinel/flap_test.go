@@ -0,0 +1,163 @@
+package sentinel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// flappingResource returns a resource whose Reconciled condition has status,
+// last updated an hour ago so that the default rules publish it.
+func flappingResource(status string, transition time.Time) *client.Resource {
+	return &client.Resource{ID: "cluster-1", Kind: testResourceKind, Generation: 1, Status: client.ResourceStatus{
+		Conditions: []client.Condition{{
+			Type: "Reconciled", Status: status, ObservedGeneration: 1,
+			LastUpdatedTime: time.Now().Add(-time.Hour), LastTransitionTime: transition,
+		}},
+	}}
+}
+
+func TestFlapDetector_Flips(t *testing.T) {
+	start := time.Now()
+	f := newFlapDetector("Reconciled", 10*time.Minute, 3, time.Hour)
+
+	observe := func(at time.Duration, status string) (bool, bool) {
+		f.beginCycle()
+		return f.observe("a", flappingResource(status, start), start.Add(at))
+	}
+	for i, status := range []string{"True", "False", "True"} {
+		if flapping, _ := observe(time.Duration(i)*time.Minute, status); flapping {
+			t.Fatalf("observation %d: expected fewer than 3 flips not to flap", i)
+		}
+	}
+	if flapping, changed := observe(3*time.Minute, "False"); !flapping || !changed {
+		t.Fatalf("observe = %v, %v; want the third flip to start flapping", flapping, changed)
+	}
+	if flapping, changed := observe(4*time.Minute, "False"); !flapping || changed {
+		t.Errorf("observe = %v, %v; want the resource to keep flapping", flapping, changed)
+	}
+
+	// The first flip, at one minute, leaves the window.
+	if flapping, changed := observe(11*time.Minute, "False"); flapping || !changed {
+		t.Errorf("observe = %v, %v; want the resource to stop flapping", flapping, changed)
+	}
+}
+
+func TestFlapDetector_TransitionBetweenPolls(t *testing.T) {
+	now := time.Now()
+	f := newFlapDetector("Reconciled", 10*time.Minute, 2, time.Hour)
+
+	f.beginCycle()
+	f.observe("a", flappingResource("True", now.Add(-time.Hour)), now)
+
+	// Same status, newer transition: the condition went and came back.
+	f.beginCycle()
+	if flapping, _ := f.observe("a", flappingResource("True", now), now); !flapping {
+		t.Error("expected a newer transition time to count as two flips")
+	}
+
+	other := newFlapDetector("Available", 10*time.Minute, 2, time.Hour)
+	for _, status := range []string{"True", "False", "True"} {
+		other.beginCycle()
+		if flapping, _ := other.observe("a", flappingResource(status, now), now); flapping {
+			t.Error("expected only the configured condition to be tracked")
+		}
+	}
+}
+
+func TestFlapDetector_Hold(t *testing.T) {
+	now := time.Now()
+	f := newFlapDetector("Reconciled", 10*time.Minute, 2, 5*time.Minute)
+
+	f.beginCycle()
+	f.observe("a", flappingResource("True", now), now)
+	f.published("a", now)
+	if f.hold("a", now.Add(time.Minute)) {
+		t.Error("expected a resource that is not flapping not to be held back")
+	}
+
+	f.beginCycle()
+	f.observe("a", flappingResource("False", now), now)
+	f.beginCycle()
+	f.observe("a", flappingResource("True", now), now)
+	if !f.hold("a", now.Add(4*time.Minute)) {
+		t.Error("expected a flapping resource to be held back within the publish interval")
+	}
+	if f.hold("a", now.Add(5*time.Minute)) {
+		t.Error("expected a flapping resource to be published once the publish interval elapsed")
+	}
+	if f.hold("b", now) {
+		t.Error("expected an unknown resource not to be held back")
+	}
+}
+
+func TestFlapDetector_Prune(t *testing.T) {
+	f := newFlapDetector("Reconciled", 10*time.Minute, 2, time.Hour)
+	f.beginCycle()
+	f.observe("a", flappingResource("True", time.Now()), time.Now())
+	f.observe("b", flappingResource("True", time.Now()), time.Now())
+
+	f.beginCycle()
+	f.observe("b", flappingResource("True", time.Now()), time.Now())
+	f.prune()
+	if _, ok := f.entries["a"]; ok {
+		t.Error("expected prune to drop entries not seen in the current cycle")
+	}
+	if _, ok := f.entries["b"]; !ok {
+		t.Error("expected prune to keep entries seen in the current cycle")
+	}
+}
+
+func TestTrigger_FlapDetection(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{*flappingResource("False", time.Now())}}
+	cfg := newTestSentinelConfig()
+	cfg.FlapDetection = &config.FlapDetectionConfig{Window: time.Hour, Flips: 2, PublishInterval: time.Hour}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	// Not ready, then ready and stale: both are published.
+	for _, status := range []string{"False", "True"} {
+		fetcher.Resources[0].Status.Conditions[0].Status = status
+		if err := s.trigger(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if len(pub.publishedEvents) != 2 {
+		t.Fatalf("Expected 2 published events before flapping, got %d", len(pub.publishedEvents))
+	}
+
+	// The second flip makes it flap, within an hour of the last publish.
+	fetcher.Resources[0].Status.Conditions[0].Status = "False"
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 2 {
+		t.Errorf("Expected the flapping resource to be held back, got %d events", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.FlappingResources.With(labels)); got != 1 {
+		t.Errorf("Expected flapping_resources == 1, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.PendingResources.With(labels)); got != 1 {
+		t.Errorf("Expected pending_resources == 1, got %v", got)
+	}
+	labels["reason"] = reasons.Flapping.String()
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 1, got %v", reasons.Flapping, got)
+	}
+}
@@ -0,0 +1,128 @@
+package sentinel
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/state"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+)
+
+// publishHistory persists the last publish of each resource in a state store,
+// so that the republish backoff and dedup window survive restarts and carry
+// over to the replica that takes over. A resource's record is read once, the
+// first time it is to be published; from then on the in-memory state is
+// current and every publish is written through.
+//
+// It is used only by the poll loop.
+type publishHistory struct {
+	store        state.Store
+	resourceType string
+	loaded       map[string]uint64
+	cycle        uint64
+}
+
+func newPublishHistory(store state.Store, resourceType string) *publishHistory {
+	return &publishHistory{
+		store:        store,
+		resourceType: resourceType,
+		loaded:       make(map[string]uint64),
+	}
+}
+
+// beginCycle marks the start of a poll cycle. Resources seen during the cycle
+// are not read again after the next prune.
+func (h *publishHistory) beginCycle() {
+	h.cycle++
+}
+
+// storeKey returns the state store key of a resource: the resource type, the
+// region if any, and the resource ID, separated by slashes. It is shared by
+// every Sentinel watching the same resource type.
+func (h *publishHistory) storeKey(key string) string {
+	return h.resourceType + "/" + strings.TrimPrefix(key, "/")
+}
+
+// needsLoad reports whether the resource's record has not been read yet, and
+// marks the resource as seen in the current cycle.
+func (h *publishHistory) needsLoad(key string) bool {
+	_, ok := h.loaded[key]
+	if ok {
+		h.loaded[key] = h.cycle
+	}
+	return !ok
+}
+
+// markLoaded records that the resource's in-memory state is current.
+func (h *publishHistory) markLoaded(key string) {
+	h.loaded[key] = h.cycle
+}
+
+// prune forgets resources not seen in the current cycle, so they are read
+// again if they come back. Call it only after a cycle that listed every
+// resource.
+func (h *publishHistory) prune() {
+	for key, cycle := range h.loaded {
+		if cycle != h.cycle {
+			delete(h.loaded, key)
+		}
+	}
+}
+
+// SetStateStore makes the Sentinel record its publishes in store and seed the
+// republish backoff and dedup window from the records of earlier runs. Call it
+// before Start.
+func (s *Sentinel) SetStateStore(store state.Store) {
+	s.history = newPublishHistory(store, s.config.ResourceType)
+}
+
+// loadHistory seeds the republish backoff and dedup window of a resource from
+// its stored record, the first time the resource is to be published. A failed
+// read is logged and retried the next time.
+func (s *Sentinel) loadHistory(ctx context.Context, key string, resource *client.Resource) {
+	if !s.history.needsLoad(key) {
+		return
+	}
+	rec, ok, err := s.history.store.Get(ctx, s.history.storeKey(key))
+	if err != nil {
+		s.logger.Warnf(ctx, "Failed to read publish history resource_id=%s error=%v", resource.ID, err)
+		metrics.UpdateStateStoreErrorsMetric(s.config.ResourceType,
+			metrics.GetResourceSelectorLabel(s.config.ResourceSelector), "read")
+		return
+	}
+	s.history.markLoaded(key)
+	if !ok {
+		return
+	}
+	if s.backoff != nil {
+		s.backoff.restore(key, rec.Generation, rec.PublishedAt, rec.BackoffDelay)
+	}
+	if s.dedup != nil {
+		s.dedup.restore(key, rec.Generation, reasons.Reason(rec.Reason), rec.PublishedAt)
+	}
+}
+
+// saveHistory writes a publish of the resource to the state store. A failed
+// write is logged; the publish itself stands.
+func (s *Sentinel) saveHistory(
+	ctx context.Context, key string, resource *client.Resource, decision engine.Decision, at time.Time,
+) {
+	rec := state.Record{
+		PublishedAt: at,
+		Reason:      decision.Reason.String(),
+		Generation:  resource.Generation,
+	}
+	if s.backoff != nil {
+		rec.BackoffDelay = s.backoff.delay(key)
+	}
+	s.history.markLoaded(key)
+	if err := s.history.store.Put(ctx, s.history.storeKey(key), rec); err != nil {
+		s.logger.Warnf(ctx, "Failed to write publish history resource_id=%s error=%v", resource.ID, err)
+		metrics.UpdateStateStoreErrorsMetric(s.config.ResourceType,
+			metrics.GetResourceSelectorLabel(s.config.ResourceSelector), "write")
+	}
+}
@@ -0,0 +1,143 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/state"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// failingStore is a state store whose reads and writes fail.
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) (state.Record, bool, error) {
+	return state.Record{}, false, errors.New("connection refused")
+}
+
+func (failingStore) Put(context.Context, string, state.Record) error {
+	return errors.New("connection refused")
+}
+
+func (failingStore) Close() error { return nil }
+
+func TestTrigger_StateStoreSurvivesRestart(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.Dedup = &config.DedupConfig{Window: time.Hour}
+	store := state.NewMemoryStore(state.DefaultTTL)
+
+	newStoreSentinel := func(pub *MockPublisher) *Sentinel {
+		s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+		if err != nil {
+			t.Fatalf("NewSentinel failed: %v", err)
+		}
+		s.SetStateStore(store)
+		return s
+	}
+
+	first := &MockPublisher{}
+	if err := newStoreSentinel(first).trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(first.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(first.publishedEvents))
+	}
+
+	// A restarted Sentinel reads the publish back and keeps the dedup window.
+	second := &MockPublisher{}
+	if err := newStoreSentinel(second).trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(second.publishedEvents) != 0 {
+		t.Errorf("Expected the restarted Sentinel to suppress the duplicate, got %d events", len(second.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all", "reason": reasons.Duplicate.String()}
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 1, got %v", reasons.Duplicate, got)
+	}
+}
+
+func TestTrigger_StateStoreRestoresBackoff(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.RepublishBackoff = &config.RepublishBackoffConfig{InitialInterval: time.Hour, MaxInterval: 4 * time.Hour}
+	store := state.NewMemoryStore(state.DefaultTTL)
+
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), &MockPublisher{}, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	s.SetStateStore(store)
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	rec, ok, _ := store.Get(context.Background(), "clusters/cluster-1")
+	if !ok || rec.BackoffDelay != time.Hour || rec.Generation != 1 {
+		t.Fatalf("Expected the publish to be recorded with its backoff delay, got %+v ok=%v", rec, ok)
+	}
+
+	pub := &MockPublisher{}
+	s, err = NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	s.SetStateStore(store)
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 0 {
+		t.Errorf("Expected the restored backoff to hold back the publish, got %d events", len(pub.publishedEvents))
+	}
+}
+
+func TestTrigger_StateStoreErrors(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.Dedup = &config.DedupConfig{Window: time.Hour}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	s.SetStateStore(failingStore{})
+
+	// A failing store never stops a publish.
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	for _, errorType := range []string{"read", "write"} {
+		labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all", "error_type": errorType}
+		if got := testutil.ToFloat64(m.StateStoreErrors.With(labels)); got != 1 {
+			t.Errorf("Expected state_store_errors_total{error_type=%q} == 1, got %v", errorType, got)
+		}
+	}
+}
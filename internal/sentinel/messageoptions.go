@@ -0,0 +1,73 @@
+package sentinel
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+)
+
+// messageOptions are the clients.broker.message_options of a topic, with
+// the header templates parsed.
+type messageOptions struct {
+	headers  map[string]*template.Template
+	ttl      time.Duration
+	priority int
+}
+
+// newMessageOptions parses the message options of b, keyed by the lower-cased
+// topic they apply to, TopicPrefix included. It returns nil if none are
+// configured.
+func newMessageOptions(b *config.BrokerConfig) (map[string]*messageOptions, error) {
+	if b == nil || len(b.MessageOptions) == 0 {
+		return nil, nil
+	}
+	byTopic := make(map[string]*messageOptions, len(b.MessageOptions))
+	for topic, cfg := range b.MessageOptions {
+		opts := &messageOptions{
+			headers:  make(map[string]*template.Template, len(cfg.Headers)),
+			ttl:      cfg.TTL,
+			priority: cfg.Priority,
+		}
+		for name, value := range cfg.Headers {
+			tmpl, err := config.ParseMessageHeader(name, value)
+			if err != nil {
+				return nil, fmt.Errorf("clients.broker.message_options: %s: headers: %s: %w", topic, name, err)
+			}
+			opts.headers[name] = tmpl
+		}
+		byTopic[strings.ToLower(b.TopicPrefix+topic)] = opts
+	}
+	return byTopic, nil
+}
+
+// setMessageOptions sets the message options of topic on event, the
+// reconcile or stuck event of resource, as extension attributes.
+func (s *Sentinel) setMessageOptions(event *cloudevents.Event, topic string, resource *client.Resource) error {
+	opts := s.messageOptions[strings.ToLower(topic)]
+	if opts == nil {
+		return nil
+	}
+	if opts.ttl > 0 {
+		event.SetExtension(events.ExpiryTimeExtension, time.Now().Add(opts.ttl))
+	}
+	if opts.priority > 0 {
+		event.SetExtension(events.PriorityExtension, opts.priority)
+	}
+	data := config.MessageHeaderData{Labels: resource.Labels, ID: resource.ID, Kind: resource.Kind}
+	for name, tmpl := range opts.headers {
+		var value strings.Builder
+		if err := tmpl.Execute(&value, data); err != nil {
+			return fmt.Errorf("render header %s: %w", name, err)
+		}
+		if value.Len() > 0 {
+			event.SetExtension(name, value.String())
+		}
+	}
+	return nil
+}
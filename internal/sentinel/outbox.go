@@ -0,0 +1,91 @@
+package sentinel
+
+import (
+	"context"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/outbox"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+)
+
+// SetOutbox makes the Sentinel spool events whose publish failed to ob and
+// replay them at the end of every poll cycle. Its events are kept in a
+// queue of their own, named after the resource type and selector, so that
+// watchers can share ob. Call it before Start.
+func (s *Sentinel) SetOutbox(ob *outbox.Outbox) {
+	s.outbox = ob
+	s.outboxQueue = s.config.ResourceType + "/" + metrics.GetResourceSelectorLabel(s.config.ResourceSelector)
+}
+
+// spool stores an event whose publish failed, replacing any event spooled
+// earlier for the same resource. Spooling is best effort: a failure is logged
+// and counts the event as dropped.
+func (s *Sentinel) spool(ctx context.Context, key, topic string, event *cloudevents.Event) {
+	if s.outbox == nil {
+		return
+	}
+	resourceType := s.config.ResourceType
+	resourceSelector := metrics.GetResourceSelectorLabel(s.config.ResourceSelector)
+
+	dropped, err := s.outbox.Add(s.outboxQueue, key, topic, event)
+	if err != nil {
+		s.logger.Warnf(ctx, "Failed to spool event to the outbox event_id=%s error=%v", event.ID(), err)
+		metrics.UpdateOutboxEventsMetric(resourceType, resourceSelector, "dropped", 1)
+		return
+	}
+	metrics.UpdateOutboxEventsMetric(resourceType, resourceSelector, "spooled", 1)
+	if dropped > 0 {
+		s.logger.Warnf(ctx, "Outbox is full; dropped the oldest spooled events count=%d", dropped)
+		metrics.UpdateOutboxEventsMetric(resourceType, resourceSelector, "dropped", dropped)
+	}
+	s.updateOutboxSize(ctx)
+}
+
+// unspool removes the event spooled for a resource that has since been
+// published, so that it is not replayed after the newer event.
+func (s *Sentinel) unspool(ctx context.Context, key string) {
+	if s.outbox == nil {
+		return
+	}
+	if err := s.outbox.Remove(s.outboxQueue, key); err != nil {
+		s.logger.Warnf(ctx, "Failed to remove a superseded event from the outbox error=%v", err)
+	}
+}
+
+// replayOutbox publishes the spooled events, oldest first, until one fails.
+// Nothing is replayed while publishing is paused or broker backpressure is
+// active.
+func (s *Sentinel) replayOutbox(ctx context.Context) {
+	if s.outbox == nil || s.Paused() || (s.backpressure != nil && s.backpressure.active(time.Now())) {
+		return
+	}
+	resourceType := s.config.ResourceType
+	resourceSelector := metrics.GetResourceSelectorLabel(s.config.ResourceSelector)
+
+	replayed, dropped, err := s.outbox.Replay(ctx, s.outboxQueue, func(topic string, event *cloudevents.Event) error {
+		return s.publisher.Publish(logger.WithTopic(ctx, topic), topic, event)
+	})
+	if replayed > 0 {
+		s.logger.Infof(ctx, "Replayed events from the outbox count=%d", replayed)
+		metrics.UpdateOutboxEventsMetric(resourceType, resourceSelector, "replayed", replayed)
+	}
+	if dropped > 0 {
+		s.logger.Warnf(ctx, "Dropped unreadable events from the outbox count=%d", dropped)
+		metrics.UpdateOutboxEventsMetric(resourceType, resourceSelector, "dropped", dropped)
+	}
+	if err != nil && ctx.Err() == nil {
+		s.logger.Warnf(ctx, "Stopped replaying the outbox error=%v", err)
+	}
+	s.updateOutboxSize(ctx)
+}
+
+func (s *Sentinel) updateOutboxSize(ctx context.Context) {
+	n, err := s.outbox.Len(s.outboxQueue)
+	if err != nil {
+		s.logger.Warnf(ctx, "Failed to read the outbox size error=%v", err)
+		return
+	}
+	metrics.UpdateOutboxSizeMetric(s.config.ResourceType, metrics.GetResourceSelectorLabel(s.config.ResourceSelector), n)
+}
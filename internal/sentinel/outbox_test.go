@@ -0,0 +1,135 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/outbox"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newOutboxTestSentinel(
+	t *testing.T, fetcher *clienttest.Fetcher, pub *MockPublisher,
+) (*Sentinel, *outbox.Outbox) {
+	t.Helper()
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	ob, err := outbox.Open(filepath.Join(t.TempDir(), "outbox.db"), 10)
+	if err != nil {
+		t.Fatalf("outbox.Open failed: %v", err)
+	}
+	t.Cleanup(func() { _ = ob.Close() })
+	s.SetOutbox(ob)
+	return s, ob
+}
+
+func TestTrigger_OutboxReplay(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	pub := &MockPublisher{publishError: errors.New("connection refused")}
+	s, ob := newOutboxTestSentinel(t, fetcher, pub)
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("trigger failed: %v", err)
+	}
+	if n, _ := ob.Len(s.outboxQueue); n != 1 {
+		t.Fatalf("Expected the failed event to be spooled, got %d events", n)
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.OutboxSize.With(labels)); got != 1 {
+		t.Errorf("Expected outbox_size 1, got %v", got)
+	}
+
+	// The broker recovers; the resource no longer needs an event of its own.
+	pub.publishError = nil
+	fetcher.Resources = nil
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("trigger failed: %v", err)
+	}
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected the spooled event to be replayed, got %d events", len(pub.publishedEvents))
+	}
+	data, err := events.Parse(pub.publishedEvents[0])
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if data.ID != "cluster-1" {
+		t.Errorf("Expected the event of cluster-1 to be replayed, got %s", data.ID)
+	}
+	if n, _ := ob.Len(s.outboxQueue); n != 0 {
+		t.Errorf("Expected the replayed event to be removed, got %d events", n)
+	}
+	labels["result"] = "replayed"
+	if got := testutil.ToFloat64(m.OutboxEvents.With(labels)); got != 1 {
+		t.Errorf("Expected outbox_events_total{result=\"replayed\"} == 1, got %v", got)
+	}
+}
+
+func TestTrigger_OutboxSupersededByPublish(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	pub := &MockPublisher{publishError: errors.New("connection refused")}
+	s, ob := newOutboxTestSentinel(t, fetcher, pub)
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("trigger failed: %v", err)
+	}
+
+	// The broker recovers and the resource is published again, so the
+	// spooled event is stale and must not follow the new one.
+	pub.publishError = nil
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("trigger failed: %v", err)
+	}
+	if len(pub.publishedEvents) != 1 {
+		t.Errorf("Expected only the new event to be published, got %d events", len(pub.publishedEvents))
+	}
+	if n, _ := ob.Len(s.outboxQueue); n != 0 {
+		t.Errorf("Expected the superseded event to be removed, got %d events", n)
+	}
+}
+
+func TestTrigger_OutboxHeldWhilePaused(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+	}}
+	pub := &MockPublisher{publishError: errors.New("connection refused")}
+	s, ob := newOutboxTestSentinel(t, fetcher, pub)
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("trigger failed: %v", err)
+	}
+	pub.publishError = nil
+	fetcher.Resources = nil
+	s.SetPaused(context.Background(), true)
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("trigger failed: %v", err)
+	}
+	if len(pub.publishedEvents) != 0 {
+		t.Errorf("Expected nothing to be replayed while paused, got %d events", len(pub.publishedEvents))
+	}
+	if n, _ := ob.Len(s.outboxQueue); n != 1 {
+		t.Errorf("Expected the spooled event to be kept, got %d events", n)
+	}
+}
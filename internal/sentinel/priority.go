@@ -0,0 +1,43 @@
+package sentinel
+
+import (
+	"slices"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+)
+
+// Publish priorities, most urgent first.
+const (
+	// priorityReconcile is a resource the adapters still have to reconcile:
+	// its Reconciled condition is missing or not True, or has not observed
+	// the current generation.
+	priorityReconcile = iota
+	// priorityRefresh is a reconciled resource, published only to refresh
+	// it once its max age has elapsed.
+	priorityRefresh
+)
+
+// publishPriority ranks a resource for publishing within a poll cycle.
+func publishPriority(resource *client.Resource) int {
+	for _, c := range resource.Status.Conditions {
+		if c.Type != reconciledConditionType {
+			continue
+		}
+		if c.Status == "True" && c.ObservedGeneration >= resource.Generation {
+			return priorityRefresh
+		}
+		break
+	}
+	return priorityReconcile
+}
+
+// sortByPublishPriority orders resources so that those awaiting
+// reconciliation are evaluated, and published, before reconciled resources
+// due for a refresh. When the publish rate limit or a slow broker cuts a
+// cycle short, the most urgent events have gone out. The sort is stable, so
+// resources of the same priority keep the order the API listed them in.
+func sortByPublishPriority(resources []client.Resource) {
+	slices.SortStableFunc(resources, func(a, b client.Resource) int {
+		return publishPriority(&a) - publishPriority(&b)
+	})
+}
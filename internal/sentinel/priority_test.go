@@ -0,0 +1,93 @@
+package sentinel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// reconciledResource returns a resource at generation 2 whose Reconciled
+// condition has status and observedGeneration, last updated an hour ago.
+func reconciledResource(id, status string, observedGeneration int32) client.Resource {
+	return client.Resource{ID: id, Kind: testResourceKind, Generation: 2, Status: client.ResourceStatus{
+		Conditions: []client.Condition{{
+			Type: "Reconciled", Status: status, ObservedGeneration: observedGeneration,
+			LastUpdatedTime: time.Now().Add(-time.Hour),
+		}},
+	}}
+}
+
+func TestPublishPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource client.Resource
+		want     int
+	}{
+		{name: "reconciled", resource: reconciledResource("r", "True", 2), want: priorityRefresh},
+		{name: "not reconciled", resource: reconciledResource("r", "False", 2), want: priorityReconcile},
+		{name: "generation mismatch", resource: reconciledResource("r", "True", 1), want: priorityReconcile},
+		{name: "no condition", resource: client.Resource{ID: "r", Generation: 1}, want: priorityReconcile},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := publishPriority(&tt.resource); got != tt.want {
+				t.Errorf("publishPriority() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortByPublishPriority(t *testing.T) {
+	resources := []client.Resource{
+		reconciledResource("refresh-1", "True", 2),
+		reconciledResource("not-ready", "False", 2),
+		reconciledResource("refresh-2", "True", 2),
+		reconciledResource("mismatch", "True", 1),
+	}
+	sortByPublishPriority(resources)
+
+	want := []string{"not-ready", "mismatch", "refresh-1", "refresh-2"}
+	for i, id := range want {
+		if resources[i].ID != id {
+			t.Errorf("resources[%d].ID = %q, want %q", i, resources[i].ID, id)
+		}
+	}
+}
+
+func TestTrigger_PublishPriority(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		reconciledResource("cluster-stale", "True", 2),
+		reconciledResource("cluster-mismatch", "True", 1),
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.PublishRateLimit = &config.PublishRateLimitConfig{MaxEventsPerCycle: 1}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	data := map[string]interface{}{}
+	if err := pub.publishedEvents[0].DataAs(&data); err != nil {
+		t.Fatalf("Failed to decode event data: %v", err)
+	}
+	if data["id"] != "cluster-mismatch" {
+		t.Errorf("Expected the generation mismatch to be published first, got %v", data["id"])
+	}
+}
@@ -0,0 +1,68 @@
+package sentinel
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// publishLimit caps the publishes of the Sentinel: at most perCycle per poll
+// cycle, and at most perSecond per second across cycles. A publish over
+// either limit is deferred to the next poll cycle rather than delayed past
+// it. It is used only by the poll loop.
+type publishLimit struct {
+	limiter      *rate.Limiter
+	now          func() time.Time
+	sleep        func(ctx context.Context, d time.Duration) error
+	pollInterval time.Duration
+	perCycle     int
+	admitted     int
+}
+
+// newPublishLimit returns a publishLimit. Zero leaves a limit off. The
+// per-second limit has a burst of one, so publishes are spread evenly.
+func newPublishLimit(perCycle int, perSecond float64, pollInterval time.Duration) *publishLimit {
+	p := &publishLimit{
+		now:          time.Now,
+		sleep:        sleepContext,
+		pollInterval: pollInterval,
+		perCycle:     perCycle,
+	}
+	if perSecond > 0 {
+		p.limiter = rate.NewLimiter(rate.Limit(perSecond), 1)
+	}
+	return p
+}
+
+// beginCycle resets the per-cycle count at the start of a poll cycle.
+func (p *publishLimit) beginCycle() {
+	p.admitted = 0
+}
+
+// admit reports whether a resource may be published in the cycle that started
+// at cycleStart, waiting for the per-second limit if needed. It returns false
+// when the cycle has reached its cap or the wait would last past the start of
+// the next cycle, and the context's error if ctx is done while waiting.
+func (p *publishLimit) admit(ctx context.Context, cycleStart time.Time) (bool, error) {
+	if p.perCycle > 0 && p.admitted >= p.perCycle {
+		return false, nil
+	}
+	if p.limiter != nil {
+		now := p.now()
+		r := p.limiter.ReserveN(now, 1)
+		delay := r.DelayFrom(now)
+		if now.Add(delay).After(cycleStart.Add(p.pollInterval)) {
+			r.CancelAt(now)
+			return false, nil
+		}
+		if delay > 0 {
+			if err := p.sleep(ctx, delay); err != nil {
+				r.CancelAt(p.now())
+				return false, err
+			}
+		}
+	}
+	p.admitted++
+	return true, nil
+}
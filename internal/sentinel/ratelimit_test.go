@@ -0,0 +1,139 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// fakeClock replaces the limit's clock and sleep with a clock that sleeping
+// advances, and returns the recorded sleeps.
+func fakeClock(p *publishLimit, start time.Time) *[]time.Duration {
+	now := start
+	var sleeps []time.Duration
+	p.now = func() time.Time { return now }
+	p.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		now = now.Add(d)
+		return nil
+	}
+	return &sleeps
+}
+
+func admitN(t *testing.T, p *publishLimit, cycleStart time.Time, n int) int {
+	t.Helper()
+	admitted := 0
+	for range n {
+		ok, err := p.admit(context.Background(), cycleStart)
+		if err != nil {
+			t.Fatalf("admit failed: %v", err)
+		}
+		if ok {
+			admitted++
+		}
+	}
+	return admitted
+}
+
+func TestPublishLimit_PerCycle(t *testing.T) {
+	p := newPublishLimit(2, 0, time.Minute)
+	start := time.Now()
+
+	p.beginCycle()
+	if got := admitN(t, p, start, 5); got != 2 {
+		t.Errorf("Expected 2 admitted publishes, got %d", got)
+	}
+
+	// Each cycle starts with a new allowance.
+	p.beginCycle()
+	if got := admitN(t, p, start.Add(time.Minute), 1); got != 1 {
+		t.Errorf("Expected the first publish of a new cycle to be admitted, got %d", got)
+	}
+}
+
+func TestPublishLimit_PerSecond(t *testing.T) {
+	// 2 events per second within a 5s cycle: the first right away, then one
+	// every 500ms until the wait would pass the start of the next cycle.
+	p := newPublishLimit(0, 2, 5*time.Second)
+	start := time.Now()
+	sleeps := fakeClock(p, start)
+
+	p.beginCycle()
+	if got := admitN(t, p, start, 20); got != 11 {
+		t.Errorf("Expected 11 admitted publishes, got %d", got)
+	}
+	for _, d := range *sleeps {
+		if d != 500*time.Millisecond {
+			t.Fatalf("Expected waits of 500ms, got %v", *sleeps)
+		}
+	}
+}
+
+func TestPublishLimit_Canceled(t *testing.T) {
+	p := newPublishLimit(0, 0.001, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	if ok, err := p.admit(ctx, start); !ok || err != nil {
+		t.Fatalf("Expected the first publish to be admitted, got %v, %v", ok, err)
+	}
+	// The next token is 1000s away, within the hour-long cycle.
+	if _, err := p.admit(ctx, start); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestTrigger_PublishRateLimit(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+		{ID: "cluster-2", Kind: testResourceKind, Generation: 1},
+		{ID: "cluster-3", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.PublishRateLimit = &config.PublishRateLimitConfig{MaxEventsPerCycle: 1}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 1 {
+		t.Errorf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.DeferredResources.With(labels)); got != 2 {
+		t.Errorf("Expected deferred_resources == 2, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.PendingResources.With(labels)); got != 3 {
+		t.Errorf("Expected pending_resources == 3, got %v", got)
+	}
+	labels["reason"] = reasons.Deferred.String()
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 2 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 2, got %v", reasons.Deferred, got)
+	}
+
+	// The next cycle has a new allowance.
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 2 {
+		t.Errorf("Expected 2 published events after the second cycle, got %d", len(pub.publishedEvents))
+	}
+}
@@ -0,0 +1,103 @@
+package sentinel
+
+import (
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+)
+
+// reconciledConditionType is the condition whose observedGeneration reports
+// the latest generation of a resource that the adapters have reconciled.
+const reconciledConditionType = "Reconciled"
+
+// reconcileEntry is a published generation of one resource that has not been
+// reconciled yet.
+type reconcileEntry struct {
+	publishedAt time.Time
+	cycle       uint64
+	generation  int32
+}
+
+// reconcileTracker measures time-to-reconcile: the time from publishing an
+// event for a generation that the Reconciled condition has not observed yet
+// until a later poll sees observedGeneration catch up. Re-publishing the same
+// generation keeps the first publish time. A newer generation replaces the
+// one being tracked, so only the latest generation of a resource is measured.
+//
+// The latency is seen at poll time, so it is at most one poll interval late.
+// Like the evaluation cache, the tracker lives only in memory and is used only
+// by the poll loop; generations in flight when the Sentinel restarts are not
+// measured.
+type reconcileTracker struct {
+	entries map[string]reconcileEntry
+	cycle   uint64
+}
+
+func newReconcileTracker() *reconcileTracker {
+	return &reconcileTracker{entries: make(map[string]reconcileEntry)}
+}
+
+// beginCycle marks the start of a poll cycle. Entries seen during the cycle
+// survive the next prune.
+func (t *reconcileTracker) beginCycle() {
+	t.cycle++
+}
+
+// observe checks a fetched resource against its tracked generation. It
+// returns the time since the generation was published once the resource's
+// observedGeneration has caught up, and stops tracking it.
+func (t *reconcileTracker) observe(key string, resource *client.Resource, now time.Time) (time.Duration, bool) {
+	entry, ok := t.entries[key]
+	if !ok {
+		return 0, false
+	}
+	if resource.Generation < entry.generation {
+		// The resource was recreated; its old generation will never be seen.
+		delete(t.entries, key)
+		return 0, false
+	}
+	if observedGeneration(resource) < entry.generation {
+		entry.cycle = t.cycle
+		t.entries[key] = entry
+		return 0, false
+	}
+	delete(t.entries, key)
+	return max(now.Sub(entry.publishedAt), 0), true
+}
+
+// published records that an event was published for the resource at the
+// given time. Resources whose generation is already reconciled are ignored.
+func (t *reconcileTracker) published(key string, resource *client.Resource, at time.Time) {
+	if resource.Generation <= observedGeneration(resource) {
+		return
+	}
+	if entry, ok := t.entries[key]; ok && entry.generation == resource.Generation {
+		return
+	}
+	t.entries[key] = reconcileEntry{
+		publishedAt: at,
+		cycle:       t.cycle,
+		generation:  resource.Generation,
+	}
+}
+
+// prune drops entries of resources not seen in the current cycle. Call it
+// only after a cycle that listed every resource.
+func (t *reconcileTracker) prune() {
+	for key, entry := range t.entries {
+		if entry.cycle != t.cycle {
+			delete(t.entries, key)
+		}
+	}
+}
+
+// observedGeneration returns the observedGeneration of the resource's
+// Reconciled condition, or 0 if it has none.
+func observedGeneration(resource *client.Resource) int32 {
+	for _, c := range resource.Status.Conditions {
+		if c.Type == reconciledConditionType {
+			return c.ObservedGeneration
+		}
+	}
+	return 0
+}
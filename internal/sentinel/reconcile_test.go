@@ -0,0 +1,86 @@
+package sentinel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+)
+
+func reconcileResource(generation, observed int32) *client.Resource {
+	return &client.Resource{
+		ID:         "cluster-1",
+		Generation: generation,
+		Status: client.ResourceStatus{Conditions: []client.Condition{
+			{Type: reconciledConditionType, Status: "False", ObservedGeneration: observed},
+		}},
+	}
+}
+
+func TestReconcileTracker(t *testing.T) {
+	start := time.Now()
+	tr := newReconcileTracker()
+	tr.beginCycle()
+
+	// Already reconciled generations are not tracked.
+	tr.published("a", reconcileResource(1, 1), start)
+	if _, ok := tr.entries["a"]; ok {
+		t.Fatal("expected a reconciled generation not to be tracked")
+	}
+
+	tr.published("a", reconcileResource(2, 1), start)
+	// Re-publishing the same generation keeps the first publish time.
+	tr.published("a", reconcileResource(2, 1), start.Add(time.Minute))
+
+	if _, ok := tr.observe("a", reconcileResource(2, 1), start.Add(2*time.Minute)); ok {
+		t.Fatal("expected no latency before observedGeneration catches up")
+	}
+	latency, ok := tr.observe("a", reconcileResource(2, 2), start.Add(3*time.Minute))
+	if !ok || latency != 3*time.Minute {
+		t.Fatalf("expected latency 3m, got %v ok=%v", latency, ok)
+	}
+	if _, ok := tr.observe("a", reconcileResource(2, 2), start.Add(4*time.Minute)); ok {
+		t.Error("expected a reconciled generation to be reported once")
+	}
+}
+
+func TestReconcileTracker_NewerGenerationReplaces(t *testing.T) {
+	start := time.Now()
+	tr := newReconcileTracker()
+	tr.beginCycle()
+
+	tr.published("a", reconcileResource(2, 1), start)
+	tr.published("a", reconcileResource(3, 1), start.Add(time.Minute))
+
+	// Generation 2 caught up, but generation 3 is the one being tracked.
+	if _, ok := tr.observe("a", reconcileResource(3, 2), start.Add(2*time.Minute)); ok {
+		t.Fatal("expected no latency until the latest generation catches up")
+	}
+	latency, ok := tr.observe("a", reconcileResource(3, 3), start.Add(3*time.Minute))
+	if !ok || latency != 2*time.Minute {
+		t.Fatalf("expected latency 2m from the latest publish, got %v ok=%v", latency, ok)
+	}
+}
+
+func TestReconcileTracker_RecreatedAndPruned(t *testing.T) {
+	start := time.Now()
+	tr := newReconcileTracker()
+	tr.beginCycle()
+	tr.published("a", reconcileResource(5, 4), start)
+	tr.published("b", reconcileResource(2, 1), start)
+
+	// "a" came back with a lower generation, i.e. it was recreated.
+	if _, ok := tr.observe("a", reconcileResource(1, 1), start); ok {
+		t.Error("expected no latency for a recreated resource")
+	}
+	if _, ok := tr.entries["a"]; ok {
+		t.Error("expected a recreated resource to be forgotten")
+	}
+
+	// "b" was not seen in the new cycle.
+	tr.beginCycle()
+	tr.prune()
+	if _, ok := tr.entries["b"]; ok {
+		t.Error("expected prune to drop resources not seen in the current cycle")
+	}
+}
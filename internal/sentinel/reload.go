@@ -0,0 +1,110 @@
+package sentinel
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/payload"
+)
+
+// Reload is a configuration change prepared by PrepareReload.
+type Reload struct {
+	cfg            *config.SentinelConfig
+	decider        engine.Decider
+	payloadBuilder *payload.Builder
+}
+
+// PrepareReload checks that the reloadable settings of cfg can replace those
+// of the Sentinel: poll_interval, resource_selector, and message_data, and
+// message_decision when decider is not nil. Other settings of cfg are
+// ignored. Nothing changes until the Reload is passed to Reload, so that
+// several Sentinels can be reloaded all or nothing.
+func (s *Sentinel) PrepareReload(cfg *config.SentinelConfig, decider engine.Decider) (*Reload, error) {
+	if cfg.PollInterval <= 0 {
+		return nil, fmt.Errorf("poll_interval must be positive, got %s", cfg.PollInterval)
+	}
+	r := &Reload{cfg: cfg, decider: decider}
+	if cfg.MessageData != nil {
+		builder, err := payload.NewBuilder(cfg.MessageData, s.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create payload builder: %w", err)
+		}
+		r.payloadBuilder = builder
+	}
+	return r, nil
+}
+
+// Reload hands r to the poll loop, which applies it between poll cycles; a
+// loop that is not running applies it when it starts. A later Reload
+// replaces one not applied yet.
+func (s *Sentinel) Reload(r *Reload) {
+	s.mu.Lock()
+	s.pendingReload = r
+	s.mu.Unlock()
+	select {
+	case s.reloaded <- struct{}{}:
+	default:
+	}
+}
+
+// applyReload applies the pending Reload, if any, and resets ticker to the
+// reloaded poll interval. It must only be called by the poll loop, which
+// reads the configuration without locking.
+func (s *Sentinel) applyReload(ctx context.Context, ticker *time.Ticker) {
+	s.mu.Lock()
+	r := s.pendingReload
+	s.pendingReload = nil
+	if r == nil {
+		s.mu.Unlock()
+		return
+	}
+
+	cfg := *s.config
+	cfg.PollInterval = r.cfg.PollInterval
+	cfg.ResourceSelector = r.cfg.ResourceSelector
+	cfg.MessageData = r.cfg.MessageData
+	if r.decider != nil {
+		cfg.MessageDecision = r.cfg.MessageDecision
+		s.decider = r.decider
+		// Cached decisions were made with the old decider.
+		if s.evalCache != nil {
+			s.evalCache = newEvaluationCache(cfg.EvaluationCache.RevalidateAfter)
+		}
+	}
+	selectorChanged := !slices.Equal(cfg.ResourceSelector, s.config.ResourceSelector)
+	if selectorChanged {
+		// Resources that newly match were not necessarily updated since the
+		// last fetch, so the next fetch lists them all.
+		s.fetchCursors = make([]fetchCursor, len(s.regions))
+	}
+	s.config = &cfg
+	s.payloadBuilder = r.payloadBuilder
+	s.shard = shardLabel(&cfg)
+	s.mu.Unlock()
+
+	ticker.Reset(cfg.PollInterval)
+	if a := cfg.AdaptiveInterval; a != nil {
+		s.interval = newAdaptiveInterval(cfg.PollInterval, a.Min, a.Max)
+	}
+	if s.publishLimit != nil {
+		s.publishLimit.pollInterval = cfg.PollInterval
+	}
+	metrics.UpdatePollIntervalMetric(cfg.ResourceType,
+		metrics.GetResourceSelectorLabel(cfg.ResourceSelector), cfg.PollInterval.Seconds())
+	s.logger.Infof(ctx, "Reloaded configuration resource_type=%s poll_interval=%s resource_selector=%s "+
+		"message_decision=%t selector_changed=%t", cfg.ResourceType, cfg.PollInterval,
+		metrics.GetResourceSelectorLabel(cfg.ResourceSelector), r.decider != nil, selectorChanged)
+}
+
+// currentConfig returns the configuration for callers outside the poll loop,
+// which may replace it on reload.
+func (s *Sentinel) currentConfig() *config.SentinelConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config
+}
@@ -0,0 +1,89 @@
+package sentinel
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestReload(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	resource := reconciledResource("cluster-1", "True", 2)
+	resource.Labels = map[string]string{"shard": "2"}
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{resource}}
+	pub := &MockPublisher{}
+	cfg := newTestSentinelConfig()
+	cfg.PollInterval = 5 * time.Second
+	cfg.ResourceSelector = config.LabelSelectorList{{Label: "shard", Value: "1"}}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+
+	if err := s.runCycle(context.Background(), ticker); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 0 {
+		t.Fatalf("Expected no event for a resource outside the selector, got %d", len(pub.publishedEvents))
+	}
+
+	next := newTestSentinelConfig()
+	next.PollInterval = 10 * time.Second
+	next.ResourceSelector = config.LabelSelectorList{{Label: "shard", Value: "2"}}
+	next.MessageData = map[string]interface{}{"id": "resource.id", "origin": `"reloaded"`}
+	r, err := s.PrepareReload(next, nil)
+	if err != nil {
+		t.Fatalf("PrepareReload failed: %v", err)
+	}
+	s.Reload(r)
+	s.applyReload(context.Background(), ticker)
+
+	if s.config.PollInterval != 10*time.Second {
+		t.Errorf("Expected poll_interval 10s after reload, got %s", s.config.PollInterval)
+	}
+	if err := s.runCycle(context.Background(), ticker); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 event after the selector was reloaded, got %d", len(pub.publishedEvents))
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(pub.publishedEvents[0].Data(), &data); err != nil {
+		t.Fatalf("Failed to unmarshal event data: %v", err)
+	}
+	if data["origin"] != "reloaded" {
+		t.Errorf("Expected origin from the reloaded message_data, got %v", data["origin"])
+	}
+}
+
+func TestPrepareReload_Invalid(t *testing.T) {
+	s, err := NewSentinel(newTestSentinelConfig(), &clienttest.Fetcher{}, newTestDecisionEngine(t), &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	next := newTestSentinelConfig()
+	next.PollInterval = time.Second
+	next.MessageData = map[string]interface{}{"id": "resource.("}
+	if _, err := s.PrepareReload(next, nil); err == nil {
+		t.Error("Expected an error for invalid message_data")
+	}
+
+	next = newTestSentinelConfig()
+	if _, err := s.PrepareReload(next, nil); err == nil {
+		t.Error("Expected an error for a zero poll_interval")
+	}
+}
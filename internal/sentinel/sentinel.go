@@ -2,23 +2,32 @@ package sentinel
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/google/uuid"
-	"github.com/openshift-hyperfleet/hyperfleet-broker/broker"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/decisionstream"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/outbox"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/payload"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/publisher"
+	apierrors "github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 // otelMessagingSystem maps broker type identifiers to OTel semantic convention values
@@ -33,32 +42,205 @@ func brokerTypeToOTel(brokerType string) string {
 	return brokerType
 }
 
+// incrementalFetchOverlap widens each incremental fetch window backwards to
+// tolerate clock skew between the Sentinel and the API and updates committed
+// with a timestamp shortly before the previous fetch started. Resources seen
+// twice are simply evaluated again.
+const incrementalFetchOverlap = 30 * time.Second
+
+// probeTimeout bounds publishing the startup probe to one topic.
+const probeTimeout = 10 * time.Second
+
+// Region is a HyperFleet API endpoint polled by the Sentinel. Resources
+// fetched through Client are tagged with Name and their events are published
+// to Topic, unless clients.broker.topics routes their kind elsewhere; either
+// way clients.broker.topic_prefix is prepended. A single-endpoint Sentinel
+// has one Region with an empty Name.
+type Region struct {
+	Client client.ResourceFetcher
+	Name   string
+	Topic  string
+}
+
 // Sentinel polls the HyperFleet API and triggers reconciliation events
 type Sentinel struct {
 	lastSuccessfulPoll time.Time
-	publisher          broker.Publisher
+	started            time.Time
+	eventAttrs         *eventAttributes
+	source             string
+	shard              string
+	brokerAuthErr      error
+	topicErrs          map[string]error
+	messageOptions     map[string]*messageOptions
+	publisher          publisher.Publisher
 	logger             logger.HyperFleetLogger
 	config             *config.SentinelConfig
-	client             *client.HyperFleetClient
-	decisionEngine     *engine.DecisionEngine
+	decider            engine.Decider
 	payloadBuilder     *payload.Builder
+	messageSchema      *payload.Schema
+	evalCache          *evaluationCache
+	reconciles         *reconcileTracker
+	backoff            *republishBackoff
+	dedup              *publishDedup
+	cooldown           *updateCooldown
+	history            *publishHistory
+	outbox             *outbox.Outbox
+	outboxQueue        string
+	deadLetters        *deadLetters
+	drift              *generationDrift
+	flaps              *flapDetector
+	stagger            *publishStagger
+	publishLimit       *publishLimit
+	backpressure       *brokerBackpressure
+	failures           *failureBudget
+	cascadeType        string
+	interval           *adaptiveInterval
+	stream             *decisionstream.Server
+	lastCycle          *CycleStatus
+	recentEvents       *eventRing
+	regions            []Region
+	fetchCursors       []fetchCursor
+	pendingResources   []PendingResource
+	pendingTotal       int
+	totals             cycleTotals
+	pendingReload      *Reload
+	stop               chan struct{}
+	reloaded           chan struct{}
+	stopOnce           sync.Once
 	mu                 sync.RWMutex
+	paused             bool
 }
 
-// NewSentinel creates a new sentinel
+// fetchCursor tracks incremental fetching for one region. It lives only in
+// memory, so a restarted Sentinel begins with a full list.
+type fetchCursor struct {
+	lastFetch    time.Time // start of the last successful fetch
+	lastFullList time.Time // start of the last successful full list
+}
+
+// NewSentinel creates a new sentinel that polls a single HyperFleet API
+// endpoint and publishes to clients.broker.topic. decider decides which
+// resources are published; when nil, an engine.DecisionEngine is built from
+// cfg.MessageDecision. pub must return once the context of a publish is done,
+// or a hung broker holds up poll cycles and shutdown.
 func NewSentinel(
 	cfg *config.SentinelConfig,
-	client *client.HyperFleetClient,
-	decisionEngine *engine.DecisionEngine,
-	pub broker.Publisher,
+	fetcher client.ResourceFetcher,
+	decider engine.Decider,
+	pub publisher.Publisher,
+	log logger.HyperFleetLogger,
+) (*Sentinel, error) {
+	topic := ""
+	if cfg.Clients.Broker != nil {
+		topic = cfg.Clients.Broker.Topic
+	}
+	return NewMultiRegionSentinel(cfg, []Region{{Client: fetcher, Topic: topic}}, decider, pub, log)
+}
+
+// NewMultiRegionSentinel creates a sentinel that polls several HyperFleet API
+// endpoints in one poll loop and publishes each region's events to its topic.
+// decider is used as by NewSentinel.
+func NewMultiRegionSentinel(
+	cfg *config.SentinelConfig,
+	regions []Region,
+	decider engine.Decider,
+	pub publisher.Publisher,
 	log logger.HyperFleetLogger,
 ) (*Sentinel, error) {
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("at least one region is required")
+	}
+	if decider == nil {
+		de, err := engine.NewDecisionEngine(cfg.MessageDecision)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create decision engine: %w", err)
+		}
+		decider = de
+	}
+
 	s := &Sentinel{
-		config:         cfg,
-		client:         client,
-		decisionEngine: decisionEngine,
-		publisher:      pub,
-		logger:         log,
+		config:       cfg,
+		regions:      regions,
+		fetchCursors: make([]fetchCursor, len(regions)),
+		reconciles:   newReconcileTracker(),
+		recentEvents: newEventRing(recentEventsCapacity),
+		decider:      decider,
+		publisher:    pub,
+		logger:       log,
+		paused:       cfg.Paused,
+		started:      time.Now(),
+		stop:         make(chan struct{}),
+		reloaded:     make(chan struct{}, 1),
+	}
+
+	attrs, err := newEventAttributes(cfg)
+	if err != nil {
+		return nil, err
+	}
+	source, err := attrs.source()
+	if err != nil {
+		return nil, err
+	}
+	s.eventAttrs = attrs
+	s.source = source
+	if s.messageOptions, err = newMessageOptions(cfg.Clients.Broker); err != nil {
+		return nil, err
+	}
+	s.shard = shardLabel(cfg)
+
+	if cfg.EvaluationCache != nil {
+		s.evalCache = newEvaluationCache(cfg.EvaluationCache.RevalidateAfter)
+	}
+
+	if rb := cfg.RepublishBackoff; rb != nil {
+		s.backoff = newRepublishBackoff(rb.InitialInterval, rb.MaxInterval)
+	}
+
+	if dd := cfg.Dedup; dd != nil {
+		s.dedup = newPublishDedup(dd.Window)
+	}
+
+	if uc := cfg.UpdateCooldown; uc != nil {
+		s.cooldown = newUpdateCooldown(uc.Timeout)
+	}
+
+	if gd := cfg.GenerationDrift; gd != nil {
+		s.drift = newGenerationDrift(gd.ConditionType(), gd.Cycles, gd.Duration)
+	}
+
+	if fd := cfg.FlapDetection; fd != nil {
+		s.flaps = newFlapDetector(fd.ConditionType(), fd.Window, fd.Flips, fd.PublishInterval)
+	}
+
+	if ps := cfg.PublishStagger; ps != nil {
+		s.stagger = newPublishStagger(ps.Burst, ps.Interval, ps.Jitter)
+	}
+
+	if bp := cfg.Clients.Broker; bp != nil && bp.Backpressure != nil {
+		s.backpressure = newBrokerBackpressure(bp.Backpressure.ErrorThreshold, bp.Backpressure.Window,
+			bp.Backpressure.Cooldown)
+	}
+
+	if bp := cfg.Clients.Broker; bp != nil && bp.DeadLetter != nil {
+		s.deadLetters = newDeadLetters(bp.DeadLetter.MaxAttempts)
+	}
+
+	// With watchers, the watcher of the cascaded resource type itself does
+	// not cascade.
+	if c := cfg.Cascade; c != nil && c.ResourceType != cfg.ResourceType {
+		s.cascadeType = c.ResourceType
+	}
+
+	if cfg.MaxConsecutiveFailures > 0 {
+		s.failures = newFailureBudget(cfg.MaxConsecutiveFailures)
+	}
+
+	if rl := cfg.PublishRateLimit; rl != nil {
+		s.publishLimit = newPublishLimit(rl.MaxEventsPerCycle, rl.MaxEventsPerSecond, cfg.PollInterval)
+	}
+
+	if a := cfg.AdaptiveInterval; a != nil {
+		s.interval = newAdaptiveInterval(cfg.PollInterval, a.Min, a.Max)
 	}
 
 	if cfg.MessageData != nil {
@@ -69,43 +251,421 @@ func NewSentinel(
 		s.payloadBuilder = builder
 	}
 
+	if ms := cfg.MessageSchema; ms != nil && ms.Enabled {
+		schema, err := payload.LoadSchema(ms.Path)
+		if err != nil {
+			return nil, fmt.Errorf("message_schema: %w", err)
+		}
+		s.messageSchema = schema
+	}
+
 	return s, nil
 }
 
+// SetDecisionStream makes the Sentinel send every decision and publish result
+// to ds. Call it before Start.
+func (s *Sentinel) SetDecisionStream(ds *decisionstream.Server) {
+	s.stream = ds
+}
+
 func (s *Sentinel) LastSuccessfulPoll() time.Time {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	return s.lastSuccessfulPoll
 }
 
-// Start starts the polling loop
-func (s *Sentinel) Start(ctx context.Context) error {
-	s.logger.Infof(ctx, "Starting sentinel resource_type=%s poll_interval=%s",
-		s.config.ResourceType, s.config.PollInterval)
+// BrokerAuthError returns the last authorization error reported by the broker,
+// or nil if the most recent publish succeeded or no publish has been rejected.
+func (s *Sentinel) BrokerAuthError() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.brokerAuthErr
+}
+
+// CircuitState reports the most severe API circuit breaker state across
+// regions: open if any region's circuit is open, then half-open, else closed.
+func (s *Sentinel) CircuitState() client.CircuitState {
+	state := client.CircuitClosed
+	for _, region := range s.regions {
+		if region.Client == nil {
+			continue
+		}
+		switch region.Client.CircuitState() {
+		case client.CircuitOpen:
+			return client.CircuitOpen
+		case client.CircuitHalfOpen:
+			state = client.CircuitHalfOpen
+		default:
+		}
+	}
+	return state
+}
+
+// Paused reports whether publishing is paused for the resource type.
+func (s *Sentinel) Paused() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.paused
+}
+
+// SetPaused pauses or resumes publishing for the resource type. While paused,
+// the Sentinel keeps polling and evaluating resources, but resources that
+// would be published are skipped with reason "paused". Resumed resources are
+// published on the next poll cycle if they still need reconciliation.
+func (s *Sentinel) SetPaused(ctx context.Context, paused bool) {
+	s.mu.Lock()
+	changed := s.paused != paused
+	s.paused = paused
+	s.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	resourceType := s.currentConfig().ResourceType
+	if paused {
+		s.logger.Warnf(ctx, "Publishing paused resource_type=%s", resourceType)
+	} else {
+		s.logger.Infof(ctx, "Publishing resumed resource_type=%s", resourceType)
+	}
+}
+
+func (s *Sentinel) setBrokerAuthError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.brokerAuthErr = err
+}
+
+// TopicError returns an error naming every topic whose startup probe failed
+// and that has not accepted an event since, or nil if all topics are reachable.
+func (s *Sentinel) TopicError() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	errs := make([]error, 0, len(s.topicErrs))
+	for _, rt := range s.topics() {
+		if err, ok := s.topicErrs[rt.topic]; ok {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// regionTopic is a topic published to and the region whose events go there.
+type regionTopic struct {
+	region Region
+	topic  string
+}
+
+// topicFor returns the topic that events for resources of kind fetched from
+// region are published to.
+func (s *Sentinel) topicFor(region Region, kind string) string {
+	return s.config.Clients.Broker.ResolveTopic(kind, region.Topic)
+}
+
+// topics returns every distinct topic the Sentinel publishes to: each
+// region's topic and each kind's routed topic, in a stable order.
+func (s *Sentinel) topics() []regionTopic {
+	kinds := []string{""}
+	if s.config.Clients.Broker != nil {
+		kinds = append(kinds, slices.Sorted(maps.Keys(s.config.Clients.Broker.Topics))...)
+	}
+	seen := make(map[string]bool)
+	var topics []regionTopic
+	for _, region := range s.regions {
+		for _, kind := range kinds {
+			topic := s.topicFor(region, kind)
+			if seen[topic] {
+				continue
+			}
+			seen[topic] = true
+			topics = append(topics, regionTopic{region: region, topic: topic})
+		}
+	}
+	return topics
+}
+
+// setTopicReachable records that topic accepted an event.
+func (s *Sentinel) setTopicReachable(topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.topicErrs, topic)
+}
+
+// ProbeTopics publishes a ProbeEventType event to every topic, so
+// that an unknown topic or a missing publish permission is reported at startup
+// instead of at the first real publish. It returns the failures joined, one
+// per topic. TopicError keeps reporting a failed topic, and each poll cycle
+// probes it again, until it accepts an event. In dry-run mode nothing is
+// published, so no topic is probed.
+func (s *Sentinel) ProbeTopics(ctx context.Context) error {
+	if s.config.DryRun {
+		return nil
+	}
+	s.probeTopics(ctx, func(string) bool { return true })
+	return s.TopicError()
+}
 
+// reprobeFailedTopics probes the topics whose last probe failed.
+func (s *Sentinel) reprobeFailedTopics(ctx context.Context) {
+	s.mu.RLock()
+	failed := len(s.topicErrs)
+	s.mu.RUnlock()
+	if failed == 0 {
+		return
+	}
+	s.probeTopics(ctx, func(topic string) bool {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		_, ok := s.topicErrs[topic]
+		return ok
+	})
+}
+
+// probeTopics probes each distinct topic selected by include and records the
+// outcome.
+func (s *Sentinel) probeTopics(ctx context.Context, include func(topic string) bool) {
+	for _, rt := range s.topics() {
+		if !include(rt.topic) {
+			continue
+		}
+
+		err := s.probeTopic(ctx, rt.region, rt.topic)
+		s.mu.Lock()
+		if err != nil {
+			if s.topicErrs == nil {
+				s.topicErrs = make(map[string]error)
+			}
+			s.topicErrs[rt.topic] = fmt.Errorf("topic %q unreachable: %w", rt.topic, err)
+		} else {
+			delete(s.topicErrs, rt.topic)
+		}
+		s.mu.Unlock()
+
+		if err != nil {
+			s.logger.Errorf(ctx, "Broker topic probe failed topic=%s error=%v", rt.topic, err)
+			continue
+		}
+		s.logger.Infof(ctx, "Broker topic probe succeeded topic=%s", rt.topic)
+	}
+}
+
+func (s *Sentinel) probeTopic(ctx context.Context, region Region, topic string) error {
+	eventID, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("failed to generate probe event ID: %w", err)
+	}
+
+	event := cloudevents.NewEvent()
+	event.SetSpecVersion(cloudevents.VersionV1)
+	event.SetType(events.ProbeEventType)
+	event.SetSource(s.source)
+	event.SetExtension(events.SchemaVersionExtension, events.SchemaVersion)
+	event.SetExtension(events.PartitionKeyExtension, s.source)
+	if region.Name != "" {
+		event.SetExtension(events.RegionExtension, region.Name)
+	}
+	s.setCorrelation(ctx, &event)
+	event.SetID(eventID.String())
+	probe := events.Probe{Sentinel: s.config.Sentinel.Name, Topic: topic}
+	if err := event.SetData(cloudevents.ApplicationJSON, probe); err != nil {
+		return fmt.Errorf("failed to set probe event data: %w", err)
+	}
+
+	// The probe must reach the broker, not only an async publish queue.
+	probeCtx, cancel := context.WithTimeout(publisher.WithConfirm(ctx), probeTimeout)
+	defer cancel()
+	return s.publisher.Publish(probeCtx, topic, &event)
+}
+
+// Start starts the polling loop. It returns ctx.Err() when ctx is done, nil
+// once Stop is called and the cycle in progress has finished, or an error
+// wrapping ErrFailureBudgetExhausted after max_consecutive_failures failed
+// poll cycles in a row.
+func (s *Sentinel) Start(ctx context.Context) error {
 	ticker := time.NewTicker(s.config.PollInterval)
 	defer ticker.Stop()
+	// A reload received while the loop was not running, e.g. on a leader
+	// election follower, applies now.
+	s.applyReload(ctx, ticker)
+
+	s.logger.Infof(ctx, "Starting sentinel resource_type=%s poll_interval=%s",
+		s.config.ResourceType, s.config.PollInterval)
+	metrics.UpdatePollIntervalMetric(s.config.ResourceType,
+		metrics.GetResourceSelectorLabel(s.config.ResourceSelector), s.config.PollInterval.Seconds())
 
 	// Run immediately on start
-	if err := s.trigger(ctx); err != nil {
+	if err := s.runCycle(ctx, ticker); err != nil {
 		s.logger.Errorf(ctx, "Initial trigger failed: %v", err)
 	}
+	if err := s.failureBudgetError(); err != nil {
+		s.logger.Errorf(ctx, "Stopping sentinel after consecutive failed poll cycles: %v", err)
+		return err
+	}
 
 	for {
 		select {
 		case <-ctx.Done():
 			s.logger.Info(ctx, "Stopping sentinel due to context cancellation")
 			return ctx.Err()
+		case <-s.stop:
+			s.logger.Info(ctx, "Stopping sentinel after the last poll cycle")
+			return nil
+		case <-s.reloaded:
+			s.applyReload(ctx, ticker)
 		case <-ticker.C:
-			if err := s.trigger(ctx); err != nil {
+			if s.stopped() {
+				// The tick raced Stop; the stop wins.
+				continue
+			}
+			if err := s.runCycle(ctx, ticker); err != nil {
 				s.logger.Errorf(ctx, "Trigger failed: %v", err)
 			}
+			if err := s.failureBudgetError(); err != nil {
+				s.logger.Errorf(ctx, "Stopping sentinel after consecutive failed poll cycles: %v", err)
+				return err
+			}
+		}
+	}
+}
+
+// cycleTimeout returns the deadline of a poll cycle run at interval:
+// cycle_timeout, or else 90% of the interval so that the cycle ends before the
+// next one is due.
+func (s *Sentinel) cycleTimeout(interval time.Duration) time.Duration {
+	if s.config.CycleTimeout > 0 {
+		return s.config.CycleTimeout
+	}
+	return interval / 10 * 9
+}
+
+// Stop asks the polling loop to return without starting another cycle. A
+// cycle in progress is not interrupted, so its publishes complete; cancel the
+// context passed to Start to interrupt it. Stop may be called more than once.
+func (s *Sentinel) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// stopped reports whether Stop has been called.
+func (s *Sentinel) stopped() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// runCycle runs one poll cycle with the deadline of cycleTimeout. Cycles
+// never overlap: the ticks of ticker that fire while a cycle runs longer than
+// the poll interval are skipped, so the next cycle starts at the first tick
+// after it ends instead of right away. With adaptive_interval, ticker is then
+// reset to the interval adapted to the cycle's backlog.
+func (s *Sentinel) runCycle(ctx context.Context, ticker *time.Ticker) error {
+	resourceType := s.config.ResourceType
+	resourceSelector := metrics.GetResourceSelectorLabel(s.config.ResourceSelector)
+	interval := s.config.PollInterval
+	if s.interval != nil {
+		interval = s.interval.current
+	}
+
+	start := time.Now()
+	timeout := s.cycleTimeout(interval)
+	cycleCtx, cancel := context.WithTimeout(ctx, timeout)
+	err := s.trigger(cycleCtx)
+	timedOut := errors.Is(cycleCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	cancel()
+	duration := time.Since(start)
+
+	if timedOut {
+		// A hung API or broker call must not stall the loop; the resources
+		// left unpublished are published by the next cycle.
+		s.logger.Warnf(ctx, "Poll cycle reached its deadline and was canceled cycle_timeout=%s", timeout)
+		metrics.UpdateCycleTimeoutsMetric(resourceType, resourceSelector)
+	}
+
+	if skipped := int(duration / interval); skipped > 0 {
+		// The ticker holds at most one tick; drop it.
+		select {
+		case <-ticker.C:
+		default:
+		}
+		s.logger.Warnf(ctx, "Poll cycle took longer than poll_interval; skipped cycles=%d duration=%s poll_interval=%s",
+			skipped, duration.Round(time.Millisecond), interval)
+		metrics.UpdateCyclesSkippedMetric(resourceType, resourceSelector, skipped)
+	}
+
+	// A failed cycle says nothing about the backlog.
+	if s.interval == nil || err != nil {
+		return err
+	}
+	s.mu.RLock()
+	pending := s.lastCycle.Pending
+	s.mu.RUnlock()
+	if next := s.interval.next(pending); next != interval {
+		ticker.Reset(next)
+		if s.publishLimit != nil {
+			s.publishLimit.pollInterval = next
 		}
+		s.logger.Infof(ctx, "Adapted poll interval poll_interval=%s pending=%d", next, pending)
+		metrics.UpdatePollIntervalMetric(resourceType, resourceSelector, next.Seconds())
+	}
+	return err
+}
+
+// apiErrorType classifies a fetch error for the api_errors_total metric.
+func apiErrorType(err error) string {
+	switch {
+	case client.IsTokenError(err):
+		return "auth_error"
+	case apierrors.IsRateLimited(err):
+		return "rate_limited"
+	case apierrors.IsNotFound(err):
+		return "not_found"
+	case apierrors.IsResponseTooLarge(err):
+		return "response_too_large"
+	default:
+		return "fetch_error"
+	}
+}
+
+// pollCounts accumulates the outcome of one poll cycle across regions.
+type pollCounts struct {
+	// pendingResources lists the first pendingResourcesLimit pending
+	// resources for Status.
+	pendingResources []PendingResource
+	total            int
+	published        int
+	skipped          int
+	failed           int
+	pending          int
+	suspended        int
+	terminal         int
+	deferred         int
+	dryRun           int
+	flapping         int
+	// fleetTotal and fleetFetched sum the API-reported totals and the
+	// fetched counts of the regions' lists.
+	fleetTotal   int64
+	fleetFetched int64
+	// incremental is set when any region fetched only updated resources, in
+	// which case the counts do not cover the whole fleet.
+	incremental bool
+}
+
+// addPending counts a resource awaiting reconciliation.
+func (c *pollCounts) addPending(resource *client.Resource, reason string) {
+	c.pending++
+	if len(c.pendingResources) < pendingResourcesLimit {
+		c.pendingResources = append(c.pendingResources, PendingResource{
+			ID:     resource.ID,
+			Kind:   resource.Kind,
+			Region: resource.Region,
+			Reason: reason,
+		})
 	}
 }
 
 // trigger checks resources and publishes events to trigger reconciliation
-func (s *Sentinel) trigger(ctx context.Context) error {
+func (s *Sentinel) trigger(ctx context.Context) (err error) {
 	startTime := time.Now()
 
 	// span: sentinel.poll
@@ -116,53 +676,304 @@ func (s *Sentinel) trigger(ctx context.Context) error {
 	// Get metric labels
 	resourceType := s.config.ResourceType
 	resourceSelector := metrics.GetResourceSelectorLabel(s.config.ResourceSelector)
-	topic := ""
-	if s.config.Clients.Broker != nil {
-		topic = s.config.Clients.Broker.Topic
-	}
 
 	// Add subset to context for structured logging
 	ctx = logger.WithSubset(ctx, resourceType)
-	ctx = logger.WithTopic(ctx, topic)
+	// Label API requests and fan-out publishes with this loop's resource type
+	// and selector, also when the client and publisher are shared with other
+	// watchers.
+	ctx = client.WithMetricsLabels(ctx, resourceType, resourceSelector)
+	ctx = publisher.WithMetricsLabels(ctx, resourceType, resourceSelector)
+
+	// Every log line and API request of the cycle carries the same operation
+	// ID, sent to the API as X-Request-ID.
+	if opID, err := uuid.NewV7(); err == nil {
+		ctx = logger.WithOpID(ctx, opID.String())
+	}
 
 	s.logger.Debug(ctx, "Starting trigger cycle")
 
 	// Convert label selectors to map for filtering
 	labelSelector := s.config.ResourceSelector.ToMap()
 
+	s.reprobeFailedTopics(ctx)
+
+	if s.Paused() {
+		s.logger.Warn(ctx, "Publishing is paused; resources are evaluated but no events are published")
+	}
+
+	now := time.Now()
+	if s.evalCache != nil {
+		s.evalCache.beginCycle()
+	}
+	s.reconciles.beginCycle()
+	if s.backoff != nil {
+		s.backoff.beginCycle()
+	}
+	if s.deadLetters != nil {
+		s.deadLetters.beginCycle()
+	}
+	if s.history != nil {
+		s.history.beginCycle()
+	}
+	if s.drift != nil {
+		s.drift.beginCycle()
+	}
+	if s.flaps != nil {
+		s.flaps.beginCycle()
+	}
+	if s.stagger != nil {
+		s.stagger.beginCycle()
+	}
+	if s.publishLimit != nil {
+		s.publishLimit.beginCycle()
+	}
+	var counts pollCounts
+	var fetchErrs []error
+	polled := 0
+	circuitOpen := false
+	defer func() {
+		cycle := CycleStatus{
+			Started:     startTime,
+			OpID:        logger.GetOpID(ctx),
+			Duration:    time.Since(startTime),
+			Total:       counts.total,
+			Published:   counts.published,
+			Skipped:     counts.skipped,
+			Pending:     counts.pending,
+			Incremental: counts.incremental,
+		}
+		if err != nil {
+			cycle.Error = err.Error()
+		}
+		s.recordCycle(cycle, &counts, polled > 0)
+		s.publishCycleSummary(ctx, cycle, &counts)
+		if s.failures != nil {
+			s.failures.record(s.classifyCycle(err, &counts, polled, circuitOpen))
+		}
+	}()
+
+	// Regions are polled one after another within the same cycle, so a
+	// multi-region Sentinel still runs a single poll loop.
+	for i, region := range s.regions {
+		err := s.pollRegion(ctx, region, &s.fetchCursors[i], labelSelector, now, &counts)
+		if errors.Is(err, client.ErrCircuitOpen) {
+			// The API was not contacted, so this is not an API error; the
+			// readiness check reports the open circuit.
+			s.logger.Warnf(ctx, "Skipping poll cycle%s: %v", regionLogSuffix(region), err)
+			circuitOpen = true
+			continue
+		}
+		if err != nil {
+			// Record API error
+			pollSpan.RecordError(err)
+			pollSpan.SetStatus(codes.Error, "fetch resources failed")
+			metrics.UpdateAPIErrorsMetric(resourceType, resourceSelector, apiErrorType(err))
+			fetchErrs = append(fetchErrs, err)
+			continue
+		}
+		polled++
+	}
+	metrics.UpdateAPICircuitBreakerStateMetric(resourceType, resourceSelector, int(s.CircuitState()))
+	if s.backpressure != nil {
+		metrics.UpdateBrokerBackpressureActiveMetric(resourceType, resourceSelector, s.backpressure.active(time.Now()))
+	}
+	// Spooled events are replayed after the cycle's own publishes, which
+	// remove the events they supersede, and also when the API is unreachable.
+	s.replayOutbox(ctx)
+
+	if polled == 0 {
+		if len(fetchErrs) == 0 {
+			return nil
+		}
+		return fmt.Errorf("failed to fetch resources: %w", errors.Join(fetchErrs...))
+	}
+
+	// Record pending resources count. Incremental cycles only see changed
+	// resources, so the gauges keep the values of the last full list.
+	if !counts.incremental {
+		metrics.UpdatePendingResourcesMetric(resourceType, resourceSelector, counts.pending)
+		metrics.UpdateSuspendedResourcesMetric(resourceType, resourceSelector, counts.suspended)
+		metrics.UpdateTerminalResourcesMetric(resourceType, resourceSelector, counts.terminal)
+		metrics.UpdateFleetSizeTotalMetric(resourceType, resourceSelector, counts.fleetTotal)
+		metrics.UpdateFleetSizeFetchedMetric(resourceType, resourceSelector, counts.fleetFetched)
+		if s.flaps != nil {
+			metrics.UpdateFlappingResourcesMetric(resourceType, resourceSelector, counts.flapping)
+		}
+	}
+
+	if s.publishLimit != nil {
+		metrics.UpdateDeferredResourcesMetric(resourceType, resourceSelector, counts.deferred)
+	}
+
+	// Record poll duration
+	duration := time.Since(startTime).Seconds()
+	metrics.UpdatePollDurationMetric(resourceType, resourceSelector, duration)
+
+	if s.config.DryRun {
+		s.logger.Infof(ctx, "Trigger cycle completed total=%d published=%d skipped=%d dry_run=%d duration=%.3fs",
+			counts.total, counts.published, counts.skipped, counts.dryRun, duration)
+	} else {
+		s.logger.Infof(ctx, "Trigger cycle completed total=%d published=%d skipped=%d duration=%.3fs",
+			counts.total, counts.published, counts.skipped, duration)
+	}
+
+	if len(fetchErrs) > 0 {
+		// Resources of the reachable regions were processed, but the cycle
+		// does not count as successful while a region is unreachable.
+		return fmt.Errorf("failed to fetch resources: %w", errors.Join(fetchErrs...))
+	}
+
+	if err := ctx.Err(); err != nil {
+		// Canceled before every resource was evaluated: the resources not
+		// reached must not be pruned from the in-memory state.
+		return fmt.Errorf("poll cycle interrupted: %w", err)
+	}
+
+	if s.dedup != nil {
+		s.dedup.prune(now)
+	}
+	if s.cooldown != nil {
+		s.cooldown.prune(now)
+	}
+
+	// Only a cycle that listed every resource shows which ones are gone.
+	if !counts.incremental {
+		if s.evalCache != nil {
+			s.evalCache.prune()
+		}
+		s.reconciles.prune()
+		if s.backoff != nil {
+			s.backoff.prune()
+		}
+		if s.deadLetters != nil {
+			s.deadLetters.prune()
+		}
+		if s.history != nil {
+			s.history.prune()
+		}
+		if s.drift != nil {
+			s.drift.prune()
+		}
+		if s.flaps != nil {
+			s.flaps.prune()
+		}
+	}
+
+	s.mu.Lock()
+	s.lastSuccessfulPoll = time.Now()
+	s.mu.Unlock()
+	metrics.UpdateLastSuccessfulPollTimestampMetric()
+
+	return nil
+}
+
+// fetchRegion fetches the resources of one region. With incremental_fetch
+// enabled it lists only resources updated since the region's last successful
+// fetch, and falls back to a full list on the first poll and whenever
+// full_list_interval has elapsed. full reports which kind of fetch ran.
+func (s *Sentinel) fetchRegion(
+	ctx context.Context,
+	region Region,
+	cursor *fetchCursor,
+	labelSelector map[string]string,
+) (resources []client.Resource, meta client.ListMeta, full bool, err error) {
+	resourceType := s.config.ResourceType
+	fetchStart := time.Now()
+
+	inc := s.config.IncrementalFetch
+	full = inc == nil || cursor.lastFullList.IsZero() || fetchStart.Sub(cursor.lastFullList) >= inc.FullListInterval
+	if full {
+		resources, meta, err = region.Client.FetchResources(ctx, resourceType, labelSelector)
+	} else {
+		updatedAfter := cursor.lastFetch.Add(-incrementalFetchOverlap)
+		resources, meta, err = region.Client.FetchResourcesUpdatedAfter(ctx, resourceType, labelSelector, updatedAfter)
+	}
+	if err != nil {
+		return nil, client.ListMeta{}, false, err
+	}
+
+	cursor.lastFetch = fetchStart
+	if full {
+		cursor.lastFullList = fetchStart
+	}
+	return resources, meta, full, nil
+}
+
+// pollRegion fetches the resources of one region, evaluates them, and
+// publishes events to the region's topic, adding the outcome to counts.
+func (s *Sentinel) pollRegion(
+	ctx context.Context,
+	region Region,
+	cursor *fetchCursor,
+	labelSelector map[string]string,
+	now time.Time,
+	counts *pollCounts,
+) error {
+	resourceType := s.config.ResourceType
+	resourceSelector := metrics.GetResourceSelectorLabel(s.config.ResourceSelector)
+	ctx = logger.WithTopic(ctx, s.topicFor(region, ""))
+	paused := s.Paused()
+
 	// Fetch all resources matching label selectors.
 	// TODO(HYPERFLEET-805): Add optional server_filters config for server-side pre-filtering
 	// to reduce the result set before CEL evaluation. Currently fetches the full result set
 	// and evaluates each resource in-memory. At large scale, use resource_selector labels
 	// to shard across multiple Sentinel instances.
-	resources, err := s.client.FetchResources(ctx, s.config.ResourceType, labelSelector)
+	resources, meta, full, err := s.fetchRegion(ctx, region, cursor, labelSelector)
 	if err != nil {
-		// Record API error
-		pollSpan.RecordError(err)
-		pollSpan.SetStatus(codes.Error, "fetch resources failed")
-		errorType := "fetch_error"
-		if client.IsTokenError(err) {
-			errorType = "auth_error"
+		if region.Name != "" {
+			return fmt.Errorf("region %s: %w", region.Name, err)
 		}
-		metrics.UpdateAPIErrorsMetric(resourceType, resourceSelector, errorType)
-		return fmt.Errorf("failed to fetch resources: %w", err)
+		return err
 	}
 
-	s.logger.Infof(ctx, "Fetched resources count=%d label_selectors=%d", len(resources), len(s.config.ResourceSelector))
+	fetchMode := "full"
+	if !full {
+		fetchMode = "incremental"
+		counts.incremental = true
+	}
+	s.logger.Infof(ctx, "Fetched resources count=%d total=%d pages=%d label_selectors=%d fetch_mode=%s%s",
+		len(resources), meta.Total, meta.Pages, len(s.config.ResourceSelector), fetchMode, regionLogSuffix(region))
+	if meta.Truncated() {
+		s.logger.Warnf(ctx, "Fetched fewer resources than the API reported count=%d total=%d pages=%d page_size=%d%s",
+			meta.Fetched, meta.Total, meta.Pages, meta.PageSize, regionLogSuffix(region))
+	}
+	// Resources of other shards are left to their replicas; the fleet size
+	// gauges still report the whole list.
+	if sh := s.config.Shard; sh != nil && sh.Total > 1 {
+		fetched := len(resources)
+		resources = filterShard(resources, sh.Index, sh.Total)
+		s.logger.Debugf(ctx, "Kept resources of shard shard=%s count=%d fetched=%d%s",
+			sh, len(resources), fetched, regionLogSuffix(region))
+	}
+	counts.total += len(resources)
+	counts.fleetTotal += meta.Total
+	counts.fleetFetched += meta.Fetched
 
-	now := time.Now()
-	published := 0
-	skipped := 0
-	pending := 0
+	// Resources awaiting reconciliation go first, ahead of refreshes.
+	sortByPublishPriority(resources)
+
+	// Outcomes are recorded on this goroutine, in resource order, while the
+	// pool evaluates and publishes.
+	pool := newWorkerPool(s.concurrency())
+	defer pool.flush(true)
+
+	decisions := s.evaluateAll(ctx, resources, region, now, pool)
 
 	// Evaluate each resource
 	for i := range resources {
 		resource := &resources[i]
+
 		// span: sentinel.evaluate
-		evalCtx, evalSpan := telemetry.StartSpan(ctx, "sentinel.evaluate",
-			attribute.String("hyperfleet.resource_type", s.config.ResourceType),
+		evalAttrs := []attribute.KeyValue{
+			attribute.String("hyperfleet.resource_type", resourceType),
 			attribute.String("hyperfleet.resource_id", resource.ID),
-		)
+		}
+		if region.Name != "" {
+			evalAttrs = append(evalAttrs, attribute.String("hyperfleet.region", region.Name))
+		}
+		evalCtx, evalSpan := telemetry.StartSpan(ctx, "sentinel.evaluate", evalAttrs...)
 
 		if resource.ID == "" {
 			s.logger.Warnf(ctx, "Skipping resource with empty ID kind=%s", resource.Kind)
@@ -170,107 +981,577 @@ func (s *Sentinel) trigger(ctx context.Context) error {
 			continue
 		}
 
-		decision := s.decisionEngine.Evaluate(resource, now)
-		evalSpan.SetAttributes(attribute.String("hyperfleet.decision_reason", decision.Reason))
+		key := evalCacheKey(region.Name, resource.ID)
+		if latency, ok := s.reconciles.observe(key, resource, now); ok {
+			metrics.UpdateReconcileLatencyMetric(resourceType, resourceSelector, latency.Seconds())
+		}
+		if s.deadLetters != nil {
+			s.deadLetters.seen(key)
+		}
 
-		if decision.ShouldPublish {
-			pending++
+		decision := decisions[i]
+		if decision.ClockSkew > 0 {
+			s.logger.Warnf(evalCtx, "Condition last updated beyond the clock skew tolerance resource_id=%s skew=%s%s",
+				resource.ID, decision.ClockSkew, regionLogSuffix(region))
+			metrics.UpdateClockSkewMetric(resourceType, resourceSelector)
+		}
+		if decision.ShouldPublish && s.history != nil {
+			s.loadHistory(evalCtx, key, resource)
+		}
+		if decision.ShouldPublish && paused {
+			// Applied after evaluate so that the evaluation cache never holds
+			// the pause and resumed resources are published right away.
+			decision = engine.Decision{ShouldPublish: false, Reason: reasons.Paused}
+		} else if s.backoff != nil {
+			decision = s.applyBackoff(key, resource, now, decision)
+		}
+		if decision.ShouldPublish && s.dedup != nil && s.dedup.suppress(key, resource, decision.Reason, now) {
+			// The adapters have not caught up with the previous event yet.
+			decision = engine.Decision{ShouldPublish: false, Reason: reasons.Duplicate}
+		}
+		if decision.ShouldPublish && s.taggedRecently(resource, now) {
+			// Published recently, possibly by another replica.
+			decision = engine.Decision{ShouldPublish: false, Reason: reasons.Duplicate}
+		}
+		if decision.ShouldPublish && s.cooldown != nil && s.cooldown.suppress(key, resource, now) {
+			// The status has not been updated since the last publish.
+			decision = engine.Decision{ShouldPublish: false, Reason: reasons.AwaitingUpdate}
+		}
+		if s.drift != nil && s.drift.hold(key, resource, now) && decision.ShouldPublish {
+			// Adapters may still be reconciling the new generation.
+			decision = engine.Decision{ShouldPublish: false, Reason: reasons.GenerationDrift}
+		}
+		if s.flaps != nil {
+			decision = s.applyFlapDamping(evalCtx, key, resource, now, decision, counts)
+		}
+		if decision.ShouldPublish && s.backpressure != nil && s.backpressure.active(time.Now()) {
+			// The broker keeps failing; publishing resumes after the cooldown.
+			decision = engine.Decision{ShouldPublish: false, Reason: reasons.BrokerBackpressure}
+		}
+		if decision.ShouldPublish && s.publishLimit != nil {
+			admitted, err := s.publishLimit.admit(evalCtx, now)
+			if err != nil {
+				// Shutting down: the remaining resources are published by the
+				// next cycle or the next instance.
+				s.logger.Warnf(evalCtx, "Stopped publishing for this cycle resource_id=%s error=%v",
+					resource.ID, err)
+				evalSpan.End()
+				return nil
+			}
+			if !admitted {
+				decision = engine.Decision{ShouldPublish: false, Reason: reasons.Deferred}
+				counts.deferred++
+			}
+		}
+		evalSpan.SetAttributes(attribute.String("hyperfleet.decision_reason", decision.Reason.String()))
+		if decision.Rule != "" {
+			evalSpan.SetAttributes(attribute.String("hyperfleet.decision_rule", decision.Rule))
+		}
+		if decision.Condition != "" {
+			evalSpan.SetAttributes(attribute.String("hyperfleet.decision_condition", decision.Condition))
+		}
+		if decision.Window != "" {
+			evalSpan.SetAttributes(attribute.String("hyperfleet.decision_window", decision.Window))
+		}
+		streamEvent := decisionstream.Event{
+			Type:          decisionstream.TypeDecision,
+			ResourceType:  resourceType,
+			ResourceID:    resource.ID,
+			Kind:          resource.Kind,
+			Region:        region.Name,
+			Reason:        decision.Reason.String(),
+			Rule:          decision.Rule,
+			Condition:     decision.Condition,
+			Window:        decision.Window,
+			ShouldPublish: decision.ShouldPublish,
+		}
+		s.sendEvent(streamEvent)
 
+		if decision.ShouldPublish && s.config.DryRun {
+			pool.record(func() {
+				s.recordDryRun(logger.WithDecisionReason(evalCtx, decision.Reason.String()), resource, decision, counts)
+			})
+			evalSpan.End()
+			continue
+		}
+
+		if decision.ShouldPublish {
 			// Add decision reason to context for structured logging
-			eventCtx := logger.WithDecisionReason(evalCtx, decision.Reason)
+			eventCtx := logger.WithDecisionReason(evalCtx, decision.Reason.String())
 
-			eventData := s.buildEventData(eventCtx, resource, decision)
+			if s.stagger != nil {
+				if err := s.stagger.wait(eventCtx); err != nil {
+					// Shutting down: the remaining resources are published
+					// by the next cycle or the next instance.
+					s.logger.Warnf(eventCtx, "Stopped publishing for this cycle resource_id=%s error=%v",
+						resource.ID, err)
+					evalSpan.End()
+					return nil
+				}
+			}
 
-			// Create CloudEvent
-			event := cloudevents.NewEvent()
-			event.SetSpecVersion(cloudevents.VersionV1)
-			event.SetType(fmt.Sprintf("com.redhat.hyperfleet.%s.reconcile", strings.ToLower(resource.Kind)))
-			event.SetSource("hyperfleet-sentinel")
+			pool.submit(func() func() {
+				return s.publish(eventCtx, evalSpan, region, key, resource, decision, streamEvent, counts)
+			})
+			continue
+		}
 
-			// Generate UUID v7 for event ID
-			eventID, err := uuid.NewV7()
-			if err != nil {
-				s.logger.Errorf(eventCtx, "Failed to generate UUID v7 for event ID resource_id=%s error=%v", resource.ID, err)
-				evalSpan.RecordError(err)
-				evalSpan.SetStatus(codes.Error, "generate event ID failed")
-				evalSpan.End()
-				continue
-			}
-			event.SetID(eventID.String())
+		pool.record(func() {
+			// Add decision reason to context for structured logging
+			skipCtx := logger.WithDecisionReason(evalCtx, decision.Reason.String())
 
-			if err := event.SetData(cloudevents.ApplicationJSON, eventData); err != nil {
-				s.logger.Errorf(eventCtx, "Failed to set event data resource_id=%s error=%v", resource.ID, err)
-				evalSpan.RecordError(err)
-				evalSpan.SetStatus(codes.Error, "set event data failed")
-				evalSpan.End()
-				continue
+			// Record skipped resource
+			metrics.UpdateResourcesSkippedMetric(resourceType, resourceSelector, decision.Reason.String())
+
+			switch {
+			case decision.Err != nil:
+				s.logger.Debugf(skipCtx, "Skipped resource resource_id=%s error=%v",
+					resource.ID, decision.Err)
+			case decision.Condition != "":
+				s.logger.Debugf(skipCtx, "Skipped resource resource_id=%s condition=%s",
+					resource.ID, decision.Condition)
+			case decision.Window != "":
+				s.logger.Debugf(skipCtx, "Skipped resource resource_id=%s window=%s",
+					resource.ID, decision.Window)
+			default:
+				s.logger.Debugf(skipCtx, "Skipped resource resource_id=%s",
+					resource.ID)
+			}
+			counts.skipped++
+			switch decision.Reason {
+			case reasons.Maintenance, reasons.MaintenanceWindow, reasons.ResourcePaused:
+				counts.suspended++
+			case reasons.Terminal:
+				counts.terminal++
+			case reasons.Paused, reasons.Backoff, reasons.Deferred, reasons.GenerationDrift, reasons.Flapping,
+				reasons.Duplicate, reasons.AwaitingUpdate, reasons.BrokerBackpressure:
+				// Still awaiting reconciliation once publishing resumes, the
+				// backoff, flap publish interval, dedup window, update
+				// cooldown, or broker cooldown elapses, the drift persists,
+				// or the next cycle runs.
+				counts.addPending(resource, decision.Reason.String())
+			default:
 			}
+		})
+		evalSpan.End()
+	}
 
-			// span: publish (child of sentinel.evaluate)
-			publishCtx, publishSpan := telemetry.StartSpan(eventCtx, fmt.Sprintf("%s publish", topic),
-				attribute.String("messaging.system", brokerTypeToOTel(s.publisher.BrokerType())),
-				attribute.String("messaging.operation.type", "publish"),
-				attribute.String("messaging.destination.name", topic),
-				attribute.String("messaging.message.id", event.ID()),
-			)
+	return nil
+}
 
-			if publishSpan.SpanContext().IsValid() {
-				telemetry.SetTraceContext(&event, publishSpan)
-			}
+// publish builds and publishes the event for a resource whose decision is to
+// publish, tags the resource, and ends evalSpan. It may run on a worker; it
+// returns the func that records the outcome, which the poll loop runs in
+// resource order.
+func (s *Sentinel) publish(
+	ctx context.Context,
+	evalSpan oteltrace.Span,
+	region Region,
+	key string,
+	resource *client.Resource,
+	decision engine.Decision,
+	streamEvent decisionstream.Event,
+	counts *pollCounts,
+) func() {
+	defer evalSpan.End()
+
+	resourceType := s.config.ResourceType
+	resourceSelector := metrics.GetResourceSelectorLabel(s.config.ResourceSelector)
+	topic := s.topicFor(region, resource.Kind)
+	ctx = logger.WithTopic(ctx, topic)
+	pending := func() { counts.addPending(resource, decision.Reason.String()) }
+
+	event, err := s.newEvent(ctx, region, resource, decision)
+	if err == nil {
+		err = s.setMessageOptions(&event, topic, resource)
+	}
+	if err != nil {
+		s.logger.Errorf(ctx, "Failed to build event resource_id=%s error=%v", resource.ID, err)
+		evalSpan.RecordError(err)
+		evalSpan.SetStatus(codes.Error, "build event failed")
+		return pending
+	}
+	streamEvent.Topic = topic
+	streamEvent.EventID = event.ID()
 
-			// Publish to broker using configured topic
-			if err := s.publisher.Publish(publishCtx, topic, &event); err != nil {
-				publishSpan.RecordError(err)
-				publishSpan.SetStatus(codes.Error, "publish failed")
-				// Record broker error
+	// span: publish (child of sentinel.evaluate)
+	publishCtx, publishSpan := telemetry.StartSpan(ctx, fmt.Sprintf("%s publish", topic),
+		attribute.String("messaging.system", brokerTypeToOTel(s.publisher.BrokerType())),
+		attribute.String("messaging.operation.type", "publish"),
+		attribute.String("messaging.destination.name", topic),
+		attribute.String("messaging.message.id", event.ID()),
+	)
+
+	if publishSpan.SpanContext().IsValid() {
+		telemetry.SetTraceContext(&event, publishSpan)
+	}
+
+	// Publish to broker using the topic routed for the resource
+	if err := s.publisher.Publish(publishCtx, topic, &event); err != nil {
+		publishSpan.RecordError(err)
+		publishSpan.SetStatus(codes.Error, "publish failed")
+		publishSpan.End()
+		s.spool(ctx, key, topic, &event)
+		return func() {
+			pending()
+			// Record broker error, separating authorization failures from connectivity ones
+			if publisher.IsAuthError(err) {
+				metrics.UpdateBrokerErrorsMetric(resourceType, resourceSelector, "auth_error")
+				metrics.UpdateBrokerAuthErrorsMetric(resourceType, resourceSelector)
+				s.setBrokerAuthError(fmt.Errorf("publish to topic %q not authorized: %w", topic, err))
+				s.logger.Errorf(publishCtx, "Broker rejected publish: not authorized for topic topic=%s resource_id=%s error=%v",
+					topic, resource.ID, err)
+			} else {
 				metrics.UpdateBrokerErrorsMetric(resourceType, resourceSelector, "publish_error")
 				s.logger.Errorf(publishCtx, "Failed to publish event resource_id=%s error=%v", resource.ID, err)
-				publishSpan.End()
-				evalSpan.End()
-				continue
 			}
+			if s.backpressure != nil && s.backpressure.failed(time.Now()) {
+				s.logger.Warnf(ctx, "Pausing publishing after repeated broker errors error_threshold=%d window=%s cooldown=%s",
+					s.backpressure.threshold, s.backpressure.window, s.backpressure.cooldown)
+				metrics.UpdateBrokerBackpressureActiveMetric(resourceType, resourceSelector, true)
+			}
+			streamEvent.Type = decisionstream.TypePublishFailed
+			streamEvent.Error = err.Error()
+			s.sendEvent(streamEvent)
+			counts.failed++
+			s.deadLetter(ctx, key, topic, resource, &event, err)
+		}
+	}
 
-			publishSpan.End()
+	publishSpan.End()
+	publishedAt := time.Now()
+	s.unspool(ctx, key)
+	s.tagResource(ctx, region, resource, publishedAt)
+	s.cascade(ctx, region, resource)
 
-			// Record successful event publication
-			metrics.UpdateEventsPublishedMetric(resourceType, resourceSelector, decision.Reason)
+	return func() {
+		pending()
+		s.setBrokerAuthError(nil)
+		s.setTopicReachable(topic)
+		s.reconciles.published(key, resource, publishedAt)
+		if s.backoff != nil {
+			s.backoff.published(key, resource, publishedAt)
+		}
+		if s.deadLetters != nil {
+			s.deadLetters.published(key)
+		}
+		if s.flaps != nil {
+			s.flaps.published(key, publishedAt)
+		}
+		if s.dedup != nil {
+			s.dedup.published(key, resource, decision.Reason, publishedAt)
+		}
+		if s.cooldown != nil {
+			s.cooldown.published(key, resource, publishedAt)
+		}
+		if s.history != nil {
+			s.saveHistory(ctx, key, resource, decision, publishedAt)
+		}
 
-			s.logger.Infof(eventCtx, "Published event resource_id=%s",
+		// Record successful event publication
+		metrics.UpdateEventsPublishedMetric(resourceType, resourceSelector, decision.Reason.String())
+		streamEvent.Type = decisionstream.TypePublished
+		s.sendEvent(streamEvent)
+
+		switch {
+		case decision.Rule != "":
+			s.logger.Infof(ctx, "Published event resource_id=%s rule=%s", resource.ID, decision.Rule)
+		case decision.Condition != "":
+			s.logger.Infof(ctx, "Published event resource_id=%s condition=%s", resource.ID, decision.Condition)
+		default:
+			s.logger.Infof(ctx, "Published event resource_id=%s",
 				resource.ID)
-			published++
-		} else {
-			// Add decision reason to context for structured logging
-			skipCtx := logger.WithDecisionReason(evalCtx, decision.Reason)
+		}
+		counts.published++
+	}
+}
 
-			// Record skipped resource
-			metrics.UpdateResourcesSkippedMetric(resourceType, resourceSelector, decision.Reason)
+// recordDryRun records a resource that dry-run mode would have published. No
+// event is built or sent, and the resource is not tagged, so it stays pending
+// and is reported again by the next cycle.
+func (s *Sentinel) recordDryRun(
+	ctx context.Context, resource *client.Resource, decision engine.Decision, counts *pollCounts,
+) {
+	metrics.UpdateEventsDryRunMetric(s.config.ResourceType,
+		metrics.GetResourceSelectorLabel(s.config.ResourceSelector), decision.Reason.String())
 
-			s.logger.Debugf(skipCtx, "Skipped resource resource_id=%s",
-				resource.ID)
-			skipped++
+	switch {
+	case decision.Rule != "":
+		s.logger.Infof(ctx, "Dry run: would publish event resource_id=%s rule=%s", resource.ID, decision.Rule)
+	case decision.Condition != "":
+		s.logger.Infof(ctx, "Dry run: would publish event resource_id=%s condition=%s", resource.ID, decision.Condition)
+	default:
+		s.logger.Infof(ctx, "Dry run: would publish event resource_id=%s", resource.ID)
+	}
+	counts.addPending(resource, decision.Reason.String())
+	counts.dryRun++
+}
+
+// applyBackoff holds back a decision to publish while the resource's republish
+// backoff has not elapsed, and resets the backoff of a resource that no longer
+// needs publishing. Like the pause, it is applied after evaluate, so the
+// evaluation cache never holds it.
+func (s *Sentinel) applyBackoff(
+	key string, resource *client.Resource, now time.Time, decision engine.Decision,
+) engine.Decision {
+	if !decision.ShouldPublish {
+		s.backoff.reset(key)
+		return decision
+	}
+	if !s.backoff.suppress(key, resource, now) {
+		return decision
+	}
+	metrics.UpdatePublishesSuppressedMetric(s.config.ResourceType,
+		metrics.GetResourceSelectorLabel(s.config.ResourceSelector))
+	return engine.Decision{ShouldPublish: false, Reason: reasons.Backoff}
+}
+
+// applyFlapDamping records the resource's condition with the flap detector,
+// logs when it starts or stops flapping, and holds back a decision to publish
+// a flapping resource within publish_interval of its last publish.
+func (s *Sentinel) applyFlapDamping(
+	ctx context.Context, key string, resource *client.Resource, now time.Time, decision engine.Decision,
+	counts *pollCounts,
+) engine.Decision {
+	flapping, changed := s.flaps.observe(key, resource, now)
+	if flapping {
+		counts.flapping++
+	}
+	switch {
+	case changed && flapping:
+		s.logger.Warnf(ctx, "Resource is flapping; publishing at most once per %s resource_id=%s condition=%s",
+			s.flaps.publishInterval, resource.ID, s.flaps.condition)
+	case changed:
+		s.logger.Infof(ctx, "Resource stopped flapping resource_id=%s condition=%s",
+			resource.ID, s.flaps.condition)
+	}
+	if decision.ShouldPublish && s.flaps.hold(key, now) {
+		return engine.Decision{ShouldPublish: false, Reason: reasons.Flapping}
+	}
+	return decision
+}
+
+// evaluateAll runs the decision engine for every resource of a region and
+// returns the decisions by index. With evaluation_cache enabled it reuses the
+// last decision not to publish while a resource is unchanged and was evaluated
+// less than revalidate_after ago. The other resources are evaluated on pool: a
+// decision_policy is queried in parallel, while the engine still runs
+// message_decision expressions one at a time. Resources without an ID are not
+// evaluated.
+func (s *Sentinel) evaluateAll(
+	ctx context.Context, resources []client.Resource, region Region, now time.Time, pool *workerPool,
+) []engine.Decision {
+	decisions := make([]engine.Decision, len(resources))
+	for i := range resources {
+		resource := &resources[i]
+		resource.Region = region.Name
+		if resource.ID == "" {
+			continue
 		}
+		if decision, hit := s.cachedDecision(resource, now); hit {
+			decisions[i] = decision
+			continue
+		}
+		pool.submit(func() func() {
+			decision := s.decide(ctx, resource, now)
+			return func() {
+				decisions[i] = decision
+				s.cacheDecision(resource, now, decision)
+			}
+		})
+	}
+	pool.flush(true)
+	return decisions
+}
 
-		evalSpan.End()
+// cachedDecision looks resource up in the evaluation cache, if enabled.
+func (s *Sentinel) cachedDecision(resource *client.Resource, now time.Time) (engine.Decision, bool) {
+	if s.evalCache == nil {
+		return engine.Decision{}, false
 	}
 
-	// Record pending resources count
-	metrics.UpdatePendingResourcesMetric(resourceType, resourceSelector, pending)
+	resourceType := s.config.ResourceType
+	resourceSelector := metrics.GetResourceSelectorLabel(s.config.ResourceSelector)
+	if version, ok := resourceVersion(resource); ok {
+		if decision, hit := s.evalCache.lookup(evalCacheKey(resource.Region, resource.ID), version, now); hit {
+			metrics.UpdateEvaluationCacheLookupsMetric(resourceType, resourceSelector, "hit")
+			return decision, true
+		}
+	}
+	metrics.UpdateEvaluationCacheLookupsMetric(resourceType, resourceSelector, "miss")
+	return engine.Decision{}, false
+}
 
-	// Record poll duration
-	duration := time.Since(startTime).Seconds()
-	metrics.UpdatePollDurationMetric(resourceType, resourceSelector, duration)
+// cacheDecision stores a decision in the evaluation cache, if enabled.
+func (s *Sentinel) cacheDecision(resource *client.Resource, now time.Time, decision engine.Decision) {
+	if s.evalCache == nil {
+		return
+	}
+	if version, ok := resourceVersion(resource); ok {
+		s.evalCache.store(evalCacheKey(resource.Region, resource.ID), version, now, decision)
+	}
+}
 
-	s.logger.Infof(ctx, "Trigger cycle completed total=%d published=%d skipped=%d duration=%.3fs",
-		len(resources), published, skipped, duration)
+// decide runs the decider for resource and records how long it took and
+// what it decided. A resource with the pause label is skipped without
+// running the decider, whichever decider is configured. A failed
+// decision_policy query is logged at warn level and counted, since it skips
+// the resource until OPA answers again, unless the cycle was canceled.
+func (s *Sentinel) decide(ctx context.Context, resource *client.Resource, now time.Time) engine.Decision {
+	start := time.Now()
+	var decision engine.Decision
+	if engine.IsPaused(resource) {
+		decision = engine.Decision{ShouldPublish: false, Reason: reasons.ResourcePaused}
+	} else {
+		decision = s.decider.Evaluate(ctx, resource, now)
+	}
+	elapsed := time.Since(start)
 
-	s.mu.Lock()
-	s.lastSuccessfulPoll = time.Now()
-	s.mu.Unlock()
-	metrics.UpdateLastSuccessfulPollTimestampMetric()
+	resourceSelector := metrics.GetResourceSelectorLabel(s.config.ResourceSelector)
+	if decision.Reason == reasons.PolicyEvaluationFailed && ctx.Err() == nil {
+		s.logger.Warnf(ctx, "Decision policy evaluation failed resource_id=%s error=%v", resource.ID, decision.Err)
+		metrics.UpdatePolicyErrorsMetric(s.config.ResourceType, resourceSelector)
+	}
+
+	outcome := metrics.DecisionOutcomeSkip
+	switch {
+	case decision.Reason.Failed():
+		outcome = metrics.DecisionOutcomeFailed
+	case decision.ShouldPublish:
+		outcome = metrics.DecisionOutcomePublish
+	}
+	metrics.UpdateDecisionMetrics(s.config.ResourceType, resourceSelector,
+		outcome, decision.Reason.String(), elapsed.Seconds())
+	return decision
+}
+
+// regionLogSuffix returns " region=<name>" for multi-region Sentinels so that
+// log lines stay unchanged for single-endpoint deployments.
+func regionLogSuffix(region Region) string {
+	if region.Name == "" {
+		return ""
+	}
+	return " region=" + region.Name
+}
+
+// PublishHandoff publishes a final heartbeat announcing that this instance has
+// stopped publishing for its resource type and selector. It is called once the
+// polling loop has stopped, when the instance is drained to rebalance shards.
+// The Sentinel keeps no state, so nothing else needs to be handed over. In
+// dry-run mode the handoff is only logged.
+func (s *Sentinel) PublishHandoff(ctx context.Context) error {
+	if s.config.DryRun {
+		s.logger.Infof(ctx, "Dry run: would publish handoff event resource_type=%s resource_selector=%s",
+			s.config.ResourceType, metrics.GetResourceSelectorLabel(s.config.ResourceSelector))
+		return nil
+	}
+	handoff := events.Handoff{
+		Sentinel:           s.config.Sentinel.Name,
+		ResourceType:       s.config.ResourceType,
+		ResourceSelector:   s.config.ResourceSelector.ToMap(),
+		LastSuccessfulPoll: s.LastSuccessfulPoll(),
+	}
+
+	// Consumers listen on their own topic, so each topic gets a copy.
+	for _, rt := range s.topics() {
+		region := rt.region
+		eventID, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("failed to generate handoff event ID: %w", err)
+		}
+
+		event := cloudevents.NewEvent()
+		event.SetSpecVersion(cloudevents.VersionV1)
+		event.SetType(events.HandoffEventType)
+		event.SetSource(s.source)
+		event.SetExtension(events.SchemaVersionExtension, events.SchemaVersion)
+		event.SetExtension(events.PartitionKeyExtension, s.source)
+		if region.Name != "" {
+			event.SetExtension(events.RegionExtension, region.Name)
+		}
+		s.setCorrelation(ctx, &event)
+		event.SetID(eventID.String())
+
+		if err := event.SetData(cloudevents.ApplicationJSON, handoff); err != nil {
+			return fmt.Errorf("failed to set handoff event data: %w", err)
+		}
 
+		if err := s.publisher.Publish(ctx, rt.topic, &event); err != nil {
+			return fmt.Errorf("failed to publish handoff event to topic %q: %w", rt.topic, err)
+		}
+	}
+
+	s.logger.Infof(ctx, "Published handoff event resource_type=%s resource_selector=%s",
+		s.config.ResourceType, metrics.GetResourceSelectorLabel(s.config.ResourceSelector))
 	return nil
 }
 
+// newEvent builds the CloudEvent published for resource with decision.
+func (s *Sentinel) newEvent(
+	ctx context.Context,
+	region Region,
+	resource *client.Resource,
+	decision engine.Decision,
+) (cloudevents.Event, error) {
+	eventData := s.buildEventData(ctx, resource, decision)
+
+	attrs, err := s.eventAttrs.forKind(resource.Kind)
+	if err != nil {
+		return cloudevents.Event{}, err
+	}
+
+	event := cloudevents.NewEvent()
+	event.SetSpecVersion(cloudevents.VersionV1)
+	if decision.Reason == reasons.StuckDeletion {
+		event.SetType(attrs.eventType + events.StuckSuffix)
+	} else {
+		event.SetType(attrs.eventType)
+	}
+	event.SetSource(attrs.source)
+	if attrs.dataSchema != "" {
+		event.SetDataSchema(attrs.dataSchema)
+	}
+	event.SetExtension(events.SchemaVersionExtension, events.SchemaVersion)
+	event.SetExtension(events.PartitionKeyExtension, resource.ID)
+	if region.Name != "" {
+		event.SetExtension(events.RegionExtension, region.Name)
+	}
+	s.setCorrelation(ctx, &event)
+
+	eventID, err := s.newEventID(resource, decision.Reason)
+	if err != nil {
+		return event, fmt.Errorf("generate event ID: %w", err)
+	}
+	event.SetID(eventID)
+
+	if err := event.SetData(cloudevents.ApplicationJSON, eventData); err != nil {
+		return event, fmt.Errorf("set event data: %w", err)
+	}
+	if err := s.validateEventData(&event); err != nil {
+		return event, err
+	}
+	return event, nil
+}
+
+// validateEventData checks the data of event against message_schema, and
+// counts events that do not match it.
+func (s *Sentinel) validateEventData(event *cloudevents.Event) error {
+	if s.messageSchema == nil {
+		return nil
+	}
+	violations := s.messageSchema.Validate(event.Data())
+	if len(violations) == 0 {
+		return nil
+	}
+	metrics.UpdateSchemaViolationsMetric(s.config.ResourceType,
+		metrics.GetResourceSelectorLabel(s.config.ResourceSelector))
+	msgs := make([]string, len(violations))
+	for i, v := range violations {
+		msgs[i] = v.String()
+	}
+	return fmt.Errorf("event data does not match message_schema: %s", strings.Join(msgs, "; "))
+}
+
 // buildEventData builds the CloudEvent data payload for a resource using the
 // configured payload builder.
 func (s *Sentinel) buildEventData(
@@ -282,5 +1563,5 @@ func (s *Sentinel) buildEventData(
 		s.logger.Errorf(ctx, "payload builder not initialized for resource_id=%s", resource.ID)
 		return map[string]interface{}{}
 	}
-	return s.payloadBuilder.BuildPayload(ctx, resource, decision.Reason)
+	return s.payloadBuilder.BuildPayload(ctx, resource, decision.Reason.String())
 }
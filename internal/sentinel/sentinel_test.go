@@ -5,17 +5,29 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/decisionstream"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/engine"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/publisher"
+	apierrors "github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.opentelemetry.io/otel"
@@ -205,6 +217,21 @@ func TestTrigger_Success(t *testing.T) {
 	if event.SpecVersion() != cloudevents.VersionV1 {
 		t.Errorf("Expected CloudEvents v1, got '%s'", event.SpecVersion())
 	}
+	if v := event.Extensions()[events.SchemaVersionExtension]; v != events.SchemaVersion {
+		t.Errorf("Expected schema version extension %q, got %v", events.SchemaVersion, v)
+	}
+	if v := event.Extensions()[events.PartitionKeyExtension]; v != "cluster-1" {
+		t.Errorf("Expected partition key extension %q, got %v", "cluster-1", v)
+	}
+
+	// The published event must round-trip through the consumer SDK.
+	re, err := events.Parse(event)
+	if err != nil {
+		t.Fatalf("events.Parse failed: %v", err)
+	}
+	if re.ResourceType != "cluster" {
+		t.Errorf("Expected resource type 'cluster', got '%s'", re.ResourceType)
+	}
 }
 
 // TestTrigger_NoEventsPublished tests when no events should be published
@@ -371,6 +398,186 @@ func TestTrigger_PublishError(t *testing.T) {
 	if err != nil {
 		t.Errorf("Expected no error (graceful degradation), got %v", err)
 	}
+	if authErr := s.BrokerAuthError(); authErr != nil {
+		t.Errorf("Expected connectivity failure not to be reported as auth error, got %v", authErr)
+	}
+}
+
+// TestTrigger_PublishAuthError tests that broker authorization failures are
+// counted separately and reported through BrokerAuthError until a publish succeeds.
+func TestTrigger_PublishAuthError(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	server := mockServerForResources(t, []map[string]interface{}{
+		createMockCluster("cluster-1", 2, 2, true, now.Add(-31*time.Minute)),
+	})
+	defer server.Close()
+
+	hyperfleetClient, err := client.NewHyperFleetClient(
+		server.URL, 10*time.Second, "test-sentinel", "test", client.DefaultPageSize, "", 0)
+	if err != nil {
+		t.Fatalf("failed to create HyperFleet client: %v", err)
+	}
+	decisionEngine := newTestDecisionEngine(t)
+	mockPublisher := &MockPublisher{
+		publishError: errors.New(`Exception (403) Reason: "ACCESS_REFUSED - access to exchange 'test-topic' refused"`),
+	}
+	log := logger.NewHyperFleetLogger()
+
+	metrics.ResetSentinelMetrics()
+	registry := prometheus.NewRegistry()
+	m := metrics.NewSentinelMetrics(registry, "test")
+
+	cfg := newTestSentinelConfig()
+
+	s, err := NewSentinel(cfg, hyperfleetClient, decisionEngine, mockPublisher, log)
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	if err := s.trigger(ctx); err != nil {
+		t.Fatalf("Expected no error (graceful degradation), got %v", err)
+	}
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.BrokerAuthErrors.With(labels)); got != 1 {
+		t.Errorf("Expected broker_auth_errors_total == 1, got %v", got)
+	}
+	labels["error_type"] = "auth_error"
+	if got := testutil.ToFloat64(m.BrokerErrors.With(labels)); got != 1 {
+		t.Errorf("Expected broker_errors_total{error_type=auth_error} == 1, got %v", got)
+	}
+
+	authErr := s.BrokerAuthError()
+	if authErr == nil || !strings.Contains(authErr.Error(), testTopic) {
+		t.Fatalf("Expected BrokerAuthError to name topic %q, got %v", testTopic, authErr)
+	}
+
+	// A successful publish clears the readiness failure.
+	mockPublisher.publishError = nil
+	if err := s.trigger(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if authErr := s.BrokerAuthError(); authErr != nil {
+		t.Errorf("Expected BrokerAuthError to clear after a successful publish, got %v", authErr)
+	}
+}
+
+// TestTrigger_MaintenanceLabel tests that resources with the maintenance label are skipped and counted
+func TestTrigger_MaintenanceLabel(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	paused := createMockCluster("cluster-paused", 2, 1, true, now)
+	paused["labels"] = map[string]string{"hyperfleet.io/maintenance": "true"}
+	server := mockServerForResources(t, []map[string]interface{}{
+		paused,
+		createMockCluster("cluster-active", 2, 1, true, now),
+	})
+	defer server.Close()
+
+	hyperfleetClient, err := client.NewHyperFleetClient(
+		server.URL, 10*time.Second, "test-sentinel", "test", client.DefaultPageSize, "", 0)
+	if err != nil {
+		t.Fatalf("failed to create HyperFleet client: %v", err)
+	}
+	cfg := newTestSentinelConfig()
+	cfg.MessageDecision.MaintenanceLabel = "hyperfleet.io/maintenance"
+	decisionEngine, err := engine.NewDecisionEngine(cfg.MessageDecision)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+	mockPublisher := &MockPublisher{}
+
+	metrics.ResetSentinelMetrics()
+	registry := prometheus.NewRegistry()
+	m := metrics.NewSentinelMetrics(registry, "test")
+
+	s, err := NewSentinel(cfg, hyperfleetClient, decisionEngine, mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(mockPublisher.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(mockPublisher.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.SuspendedResources.With(labels)); got != 1 {
+		t.Errorf("Expected suspended_resources == 1, got %v", got)
+	}
+	labels["reason"] = reasons.Maintenance.String()
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=maintenance} == 1, got %v", got)
+	}
+}
+
+// TestTrigger_Paused tests that a paused Sentinel evaluates but does not publish, and publishes again once resumed
+func TestTrigger_Paused(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	server := mockServerForResources(t, []map[string]interface{}{
+		createMockCluster("cluster-1", 2, 1, true, now),
+	})
+	defer server.Close()
+
+	hyperfleetClient, err := client.NewHyperFleetClient(
+		server.URL, 10*time.Second, "test-sentinel", "test", client.DefaultPageSize, "", 0)
+	if err != nil {
+		t.Fatalf("failed to create HyperFleet client: %v", err)
+	}
+	cfg := newTestSentinelConfig()
+	cfg.Paused = true
+	cfg.EvaluationCache = &config.EvaluationCacheConfig{RevalidateAfter: time.Hour}
+	decisionEngine, err := engine.NewDecisionEngine(cfg.MessageDecision)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+	mockPublisher := &MockPublisher{}
+
+	metrics.ResetSentinelMetrics()
+	registry := prometheus.NewRegistry()
+	m := metrics.NewSentinelMetrics(registry, "test")
+
+	s, err := NewSentinel(cfg, hyperfleetClient, decisionEngine, mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if !s.Paused() {
+		t.Fatal("Expected Sentinel to start paused")
+	}
+	if err := s.trigger(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(mockPublisher.publishedEvents) != 0 {
+		t.Fatalf("Expected no published events while paused, got %d", len(mockPublisher.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.PendingResources.With(labels)); got != 1 {
+		t.Errorf("Expected pending_resources == 1 while paused, got %v", got)
+	}
+	if s.LastSuccessfulPoll().IsZero() {
+		t.Error("Expected a paused poll cycle to count as successful")
+	}
+	skipped := prometheus.Labels{
+		"resource_type": "clusters", "resource_selector": "all", "reason": reasons.Paused.String(),
+	}
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(skipped)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=paused} == 1, got %v", got)
+	}
+
+	s.SetPaused(ctx, false)
+	if err := s.trigger(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(mockPublisher.publishedEvents) != 1 {
+		t.Errorf("Expected 1 published event after resume, got %d", len(mockPublisher.publishedEvents))
+	}
 }
 
 // TestTrigger_MixedResources tests handling of multiple resources with different outcomes
@@ -564,8 +771,8 @@ func TestBuildEventData_WithBuilder(t *testing.T) {
 		Href:       "/api/v1/clusters/cls-direct",
 		Generation: 1,
 	}
-	decision := engine.Decision{ShouldPublish: true, Reason: "message decision matched"}
-	ctx := logger.WithDecisionReason(context.Background(), decision.Reason)
+	decision := engine.Decision{ShouldPublish: true, Reason: reasons.Matched}
+	ctx := logger.WithDecisionReason(context.Background(), decision.Reason.String())
 
 	data := s.buildEventData(ctx, resource, decision)
 	if data["id"] != "cls-direct" {
@@ -768,3 +975,1580 @@ func getSpanNames(spans []tracetest.SpanStub) []string {
 	}
 	return names
 }
+
+// TestPublishHandoff tests the final heartbeat published when a shard is drained
+func TestPublishHandoff(t *testing.T) {
+	mockPublisher := &MockPublisher{}
+	cfg := newTestSentinelConfig()
+	cfg.Sentinel.Name = "sentinel-shard-1"
+	cfg.ResourceSelector = config.LabelSelectorList{{Label: "shard", Value: "1"}}
+
+	s, err := NewSentinel(cfg, nil, newTestDecisionEngine(t), mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	if err := s.PublishHandoff(context.Background()); err != nil {
+		t.Fatalf("PublishHandoff failed: %v", err)
+	}
+
+	if len(mockPublisher.publishedEvents) != 1 || mockPublisher.publishedTopics[0] != testTopic {
+		t.Fatalf("Expected 1 event on topic %q, got %d on %v",
+			testTopic, len(mockPublisher.publishedEvents), mockPublisher.publishedTopics)
+	}
+	handoff, err := events.ParseHandoff(mockPublisher.publishedEvents[0])
+	if err != nil {
+		t.Fatalf("ParseHandoff failed: %v", err)
+	}
+	if handoff.Sentinel != "sentinel-shard-1" || handoff.ResourceType != "clusters" {
+		t.Errorf("Unexpected handoff identity: %+v", handoff)
+	}
+	if handoff.ResourceSelector["shard"] != "1" {
+		t.Errorf("Expected resource selector shard=1, got %v", handoff.ResourceSelector)
+	}
+}
+
+// TestTrigger_MultiRegion tests that each region's resources are tagged and published to the region topic
+func TestTrigger_MultiRegion(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	east := mockServerForResources(t, []map[string]interface{}{
+		createMockCluster("cluster-east", 2, 1, true, now),
+	})
+	defer east.Close()
+	west := mockServerForResources(t, []map[string]interface{}{
+		createMockCluster("cluster-west", 2, 1, true, now),
+	})
+	defer west.Close()
+
+	newClient := func(url string) *client.HyperFleetClient {
+		c, err := client.NewHyperFleetClient(url, 10*time.Second, "test-sentinel", "test", client.DefaultPageSize, "", 0)
+		if err != nil {
+			t.Fatalf("failed to create HyperFleet client: %v", err)
+		}
+		return c
+	}
+	regions := []Region{
+		{Client: newClient(east.URL), Name: "us-east", Topic: "clusters-us-east"},
+		{Client: newClient(west.URL), Name: "us-west", Topic: "clusters-us-west"},
+	}
+
+	cfg := newTestSentinelConfig()
+	cfg.MessageData["region"] = "resource.region"
+	mockPublisher := &MockPublisher{}
+
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	s, err := NewMultiRegionSentinel(cfg, regions, newTestDecisionEngine(t), mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewMultiRegionSentinel failed: %v", err)
+	}
+	if err := s.trigger(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(mockPublisher.publishedEvents) != 2 {
+		t.Fatalf("Expected 2 published events, got %d", len(mockPublisher.publishedEvents))
+	}
+	for i, want := range []struct{ region, topic, id string }{
+		{region: "us-east", topic: "clusters-us-east", id: "cluster-east"},
+		{region: "us-west", topic: "clusters-us-west", id: "cluster-west"},
+	} {
+		if mockPublisher.publishedTopics[i] != want.topic {
+			t.Errorf("event %d: topic = %q, want %q", i, mockPublisher.publishedTopics[i], want.topic)
+		}
+		re, err := events.Parse(mockPublisher.publishedEvents[i])
+		if err != nil {
+			t.Fatalf("event %d: Parse: %v", i, err)
+		}
+		if re.ID != want.id || re.Region != want.region || re.Data["region"] != want.region {
+			t.Errorf("event %d: got id=%q region=%q data.region=%v, want %s/%s",
+				i, re.ID, re.Region, re.Data["region"], want.id, want.region)
+		}
+	}
+	if s.LastSuccessfulPoll().IsZero() {
+		t.Error("Expected LastSuccessfulPoll to be set after all regions were polled")
+	}
+}
+
+// TestTrigger_MultiRegionPartialFailure tests that one unreachable region does not block the others
+func TestTrigger_MultiRegionPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	healthy := mockServerForResources(t, []map[string]interface{}{
+		createMockCluster("cluster-east", 2, 1, true, now),
+	})
+	defer healthy.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer broken.Close()
+
+	newClient := func(url string) *client.HyperFleetClient {
+		c, err := client.NewHyperFleetClient(url, 10*time.Second, "test-sentinel", "test", client.DefaultPageSize, "", 0)
+		if err != nil {
+			t.Fatalf("failed to create HyperFleet client: %v", err)
+		}
+		return c
+	}
+	regions := []Region{
+		{Client: newClient(broken.URL), Name: "eu-central", Topic: "clusters-eu-central"},
+		{Client: newClient(healthy.URL), Name: "us-east", Topic: "clusters-us-east"},
+	}
+	mockPublisher := &MockPublisher{}
+
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	s, err := NewMultiRegionSentinel(newTestSentinelConfig(), regions, newTestDecisionEngine(t), mockPublisher,
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewMultiRegionSentinel failed: %v", err)
+	}
+
+	err = s.trigger(ctx)
+	if err == nil || !strings.Contains(err.Error(), "eu-central") {
+		t.Fatalf("Expected error naming the failed region, got %v", err)
+	}
+	if len(mockPublisher.publishedTopics) != 1 || mockPublisher.publishedTopics[0] != "clusters-us-east" {
+		t.Errorf("Expected the healthy region to publish, got topics %v", mockPublisher.publishedTopics)
+	}
+	if !s.LastSuccessfulPoll().IsZero() {
+		t.Error("Expected LastSuccessfulPoll to stay unset while a region fails")
+	}
+}
+
+// TestTrigger_IncrementalFetch verifies that incremental_fetch lists only
+// updated resources between full lists and falls back to a full list once
+// full_list_interval has elapsed.
+func TestTrigger_IncrementalFetch(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	var searches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		searches = append(searches, r.URL.Query().Get("search"))
+		response := createMockClusterList([]map[string]interface{}{
+			createMockCluster("cluster-1", 2, 2, true, now.Add(-31*time.Minute)),
+		})
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Logf("Error encoding response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	hyperfleetClient, err := client.NewHyperFleetClient(
+		server.URL, 10*time.Second, "test-sentinel", "test", client.DefaultPageSize, "", 0)
+	if err != nil {
+		t.Fatalf("failed to create HyperFleet client: %v", err)
+	}
+
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	cfg := newTestSentinelConfig()
+	cfg.IncrementalFetch = &config.IncrementalFetchConfig{FullListInterval: time.Hour}
+
+	s, err := NewSentinel(cfg, hyperfleetClient, newTestDecisionEngine(t), &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	for range 2 {
+		if err := s.trigger(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// Pretend the last full list is older than full_list_interval.
+	s.fetchCursors[0].lastFullList = now.Add(-2 * time.Hour)
+	if err := s.trigger(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(searches) != 3 {
+		t.Fatalf("Expected 3 list requests, got %d", len(searches))
+	}
+	if searches[0] != "" {
+		t.Errorf("Expected first poll to be a full list, got search %q", searches[0])
+	}
+	if !strings.HasPrefix(searches[1], "updated_time>'") {
+		t.Errorf("Expected second poll to filter on updated_time, got search %q", searches[1])
+	}
+	if searches[2] != "" {
+		t.Errorf("Expected a full list after full_list_interval, got search %q", searches[2])
+	}
+}
+
+// TestTrigger_ReconcileLatency tests that the time from publishing a new
+// generation until its observedGeneration catches up is recorded.
+func TestTrigger_ReconcileLatency(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	resource := func(observed int32) client.Resource {
+		return client.Resource{
+			ID:         "cluster-1",
+			Kind:       testResourceKind,
+			Generation: 2,
+			Status: client.ResourceStatus{Conditions: []client.Condition{
+				{Type: "Reconciled", Status: "True", ObservedGeneration: observed, LastUpdatedTime: time.Now()},
+			}},
+		}
+	}
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{resource(1)}}
+	mockPublisher := &MockPublisher{}
+
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, newTestDecisionEngine(t), mockPublisher,
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(mockPublisher.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(mockPublisher.publishedEvents))
+	}
+	if count := testutil.CollectAndCount(m.ReconcileLatency); count != 0 {
+		t.Fatalf("Expected no reconcile_latency observations yet, got %d series", count)
+	}
+
+	fetcher.Resources = []client.Resource{resource(2)}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count := testutil.CollectAndCount(m.ReconcileLatency); count != 1 {
+		t.Errorf("Expected 1 reconcile_latency series after catch-up, got %d", count)
+	}
+}
+
+// TestTrigger_FleetSize tests that a full list records the API-reported total
+// and the fetched count, so that a truncated list shows as a gap between them.
+func TestTrigger_FleetSize(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{
+			{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+			{ID: "cluster-2", Kind: testResourceKind, Generation: 1},
+		},
+		Total: 5,
+	}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, newTestDecisionEngine(t), &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.FleetSizeTotal.With(labels)); got != 5 {
+		t.Errorf("Expected fleet_size_total == 5, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.FleetSizeFetched.With(labels)); got != 2 {
+		t.Errorf("Expected fleet_size_fetched == 2, got %v", got)
+	}
+}
+
+// TestTrigger_RequestIDPerCycle tests that each poll cycle sends its own
+// operation ID as X-Request-ID on its API requests.
+func TestTrigger_RequestIDPerCycle(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	var requestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get("X-Request-ID"))
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(createMockClusterList(nil)); err != nil {
+			t.Logf("Error encoding response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	hyperfleetClient, err := client.NewHyperFleetClient(
+		server.URL, 10*time.Second, "test-sentinel", "test", client.DefaultPageSize, "", 0)
+	if err != nil {
+		t.Fatalf("failed to create HyperFleet client: %v", err)
+	}
+	s, err := NewSentinel(newTestSentinelConfig(), hyperfleetClient, newTestDecisionEngine(t), &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	for range 2 {
+		if err := s.trigger(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if len(requestIDs) != 2 {
+		t.Fatalf("Expected 2 API requests, got %d", len(requestIDs))
+	}
+	if requestIDs[0] == "" || requestIDs[0] == requestIDs[1] {
+		t.Errorf("Expected a distinct X-Request-ID per cycle, got %q", requestIDs)
+	}
+}
+
+// TestTrigger_WithResourceFetcher drives the Sentinel with an in-memory
+// fetcher instead of an httptest server.
+func TestTrigger_WithResourceFetcher(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{
+			{ID: "cluster-1", Kind: testResourceKind, Generation: 1, Labels: map[string]string{"shard": "1"}},
+			{ID: "cluster-2", Kind: testResourceKind, Generation: 1, Labels: map[string]string{"shard": "2"}},
+		},
+	}
+	mockPublisher := &MockPublisher{}
+
+	cfg := newTestSentinelConfig()
+	cfg.ResourceSelector = config.LabelSelectorList{{Label: "shard", Value: "1"}}
+
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(fetcher.Calls) != 1 || fetcher.Calls[0].LabelSelector["shard"] != "1" {
+		t.Fatalf("Expected one fetch with selector shard=1, got %+v", fetcher.Calls)
+	}
+	if len(mockPublisher.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(mockPublisher.publishedEvents))
+	}
+	re, err := events.Parse(mockPublisher.publishedEvents[0])
+	if err != nil {
+		t.Fatalf("events.Parse failed: %v", err)
+	}
+	if re.ID != "cluster-1" {
+		t.Errorf("Expected event for cluster-1, got %q", re.ID)
+	}
+}
+
+// TestTrigger_OwnerReferences verifies that child resources carry their
+// owner reference into the event, and that resources without an owner omit it.
+func TestTrigger_OwnerReferences(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	owner := &client.ObjectReference{
+		ID: "cluster-1", Kind: testResourceKind, Href: "/api/hyperfleet/v1/clusters/cluster-1",
+	}
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{
+			{ID: "nodepool-1", Kind: "NodePool", Generation: 1, OwnerReferences: owner},
+			{ID: "nodepool-2", Kind: "NodePool", Generation: 1},
+		},
+	}
+	mockPublisher := &MockPublisher{}
+
+	cfg := newTestSentinelConfig()
+	cfg.ResourceType = "nodepools"
+	cfg.MessageData = map[string]interface{}{
+		"id":   "resource.id",
+		"kind": "resource.kind",
+		"owner_references": map[string]interface{}{
+			"id":   "resource.owner_references.id",
+			"href": "resource.owner_references.href",
+			"kind": "resource.owner_references.kind",
+		},
+	}
+
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(mockPublisher.publishedEvents) != 2 {
+		t.Fatalf("Expected 2 published events, got %d", len(mockPublisher.publishedEvents))
+	}
+
+	byID := make(map[string]*events.ReconcileEvent)
+	for _, event := range mockPublisher.publishedEvents {
+		re, err := events.Parse(event)
+		if err != nil {
+			t.Fatalf("events.Parse failed: %v", err)
+		}
+		byID[re.ID] = re
+	}
+	got := byID["nodepool-1"].OwnerReferences
+	if got == nil || got.ID != owner.ID || got.Kind != owner.Kind || got.Href != owner.Href {
+		t.Errorf("Expected owner reference %+v for nodepool-1, got %+v", owner, got)
+	}
+	if got := byID["nodepool-2"].OwnerReferences; got != nil {
+		t.Errorf("Expected no owner reference for nodepool-2, got %+v", got)
+	}
+}
+
+func TestTrigger_EventSource(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{
+			{ID: "cluster-1", Kind: testResourceKind, Generation: 1, Labels: map[string]string{"shard": "1"}},
+		},
+	}
+	mockPublisher := &MockPublisher{}
+
+	cfg := newTestSentinelConfig()
+	cfg.ResourceSelector = config.LabelSelectorList{{Label: "shard", Value: "1"}}
+	cfg.Clients.Broker.Source = "hyperfleet-sentinel/{{.Instance}}/{{.Shard}}"
+
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(mockPublisher.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(mockPublisher.publishedEvents))
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatalf("os.Hostname failed: %v", err)
+	}
+	re, err := events.Parse(mockPublisher.publishedEvents[0])
+	if err != nil {
+		t.Fatalf("events.Parse failed: %v", err)
+	}
+	if want := "hyperfleet-sentinel/" + hostname + "/shard:1"; re.Source != want {
+		t.Errorf("Expected source %q, got %q", want, re.Source)
+	}
+}
+
+func TestTrigger_EventTypeAndDataSchema(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{{ID: "cluster-1", Kind: testResourceKind, Generation: 1}},
+	}
+	mockPublisher := &MockPublisher{}
+
+	cfg := newTestSentinelConfig()
+	cfg.Clients.Broker.Source = "hyperfleet-sentinel/tenant-a/{{.Kind}}"
+	cfg.Clients.Broker.EventType = "com.example.tenant-a.{{.Kind}}.reconcile"
+	cfg.Clients.Broker.DataSchema = "https://schemas.example.com/{{.Kind}}/v1.json"
+
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(mockPublisher.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(mockPublisher.publishedEvents))
+	}
+
+	event := mockPublisher.publishedEvents[0]
+	if want := "com.example.tenant-a.cluster.reconcile"; event.Type() != want {
+		t.Errorf("Expected type %q, got %q", want, event.Type())
+	}
+	if want := "hyperfleet-sentinel/tenant-a/cluster"; event.Source() != want {
+		t.Errorf("Expected source %q, got %q", want, event.Source())
+	}
+	if want := "https://schemas.example.com/cluster/v1.json"; event.DataSchema() != want {
+		t.Errorf("Expected dataschema %q, got %q", want, event.DataSchema())
+	}
+	if s.source != "hyperfleet-sentinel/tenant-a/" {
+		t.Errorf("Expected the source of Sentinel events to render without a kind, got %q", s.source)
+	}
+}
+
+func TestTrigger_MessageOptions(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{{
+			ID: "cluster-1", Kind: testResourceKind, Generation: 1, Labels: map[string]string{"team": "core"},
+		}},
+	}
+	mockPublisher := &MockPublisher{}
+	cfg := newTestSentinelConfig()
+	cfg.Clients.Broker.TopicPrefix = "staging-"
+	cfg.Clients.Broker.MessageOptions = map[string]config.BrokerMessageOptionsConfig{
+		testTopic: {
+			TTL:      10 * time.Minute,
+			Priority: 5,
+			Headers:  map[string]string{"team": "{{.Labels.team}}", "owner": "{{.Labels.owner}}", "env": "prod"},
+		},
+	}
+
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(mockPublisher.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(mockPublisher.publishedEvents))
+	}
+
+	event := mockPublisher.publishedEvents[0]
+	ext := event.Extensions()
+	if ext["team"] != "core" || ext["env"] != "prod" || ext[events.PriorityExtension] != int32(5) {
+		t.Errorf("Expected the configured headers and priority, got %v", ext)
+	}
+	if _, ok := ext["owner"]; ok {
+		t.Error("Expected a header that renders empty to be omitted")
+	}
+	if events.Expired(event, time.Now()) || !events.Expired(event, time.Now().Add(11*time.Minute)) {
+		t.Errorf("Expected the event to expire after the TTL, got expirytime %v", ext[events.ExpiryTimeExtension])
+	}
+}
+
+func TestTrigger_MessageSchemaViolation(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	path := filepath.Join(t.TempDir(), "message-schema.json")
+	if err := os.WriteFile(path, []byte(`{"required":["id","href"]}`), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{{ID: "cluster-1", Kind: testResourceKind, Generation: 1}},
+	}
+	mockPublisher := &MockPublisher{}
+	cfg := newTestSentinelConfig()
+	cfg.MessageSchema = &config.MessageSchemaConfig{Enabled: true, Path: path}
+
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(mockPublisher.publishedEvents) != 0 {
+		t.Errorf("Expected the event without href not to be published, got %d events", len(mockPublisher.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.SchemaViolations.With(labels)); got != 1 {
+		t.Errorf("Expected schema_violations_total 1, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.PendingResources.With(labels)); got != 1 {
+		t.Errorf("Expected the resource to stay pending, got %v", got)
+	}
+
+	// The shipped schema accepts the id and kind of the test message_data.
+	cfg.MessageSchema = &config.MessageSchemaConfig{Enabled: true}
+	s, err = NewSentinel(cfg, fetcher, newTestDecisionEngine(t), mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(mockPublisher.publishedEvents) != 1 {
+		t.Errorf("Expected the event to match the shipped schema, got %d events", len(mockPublisher.publishedEvents))
+	}
+}
+
+func TestNewSentinel_InvalidMessageSchema(t *testing.T) {
+	cfg := newTestSentinelConfig()
+	cfg.MessageSchema = &config.MessageSchemaConfig{Enabled: true, Path: filepath.Join(t.TempDir(), "missing.json")}
+
+	_, err := NewSentinel(cfg, &clienttest.Fetcher{}, newTestDecisionEngine(t), &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err == nil || !strings.Contains(err.Error(), "message_schema") {
+		t.Errorf("Expected message_schema error, got %v", err)
+	}
+}
+
+func TestTrigger_DecisionRule(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{{ID: "cluster-1", Kind: testResourceKind, Generation: 1}},
+	}
+	de, err := engine.NewDecisionEngine(&config.MessageDecisionConfig{
+		Rules: []config.Rule{{Name: "new_resource", Expr: "resource.generation == 1"}},
+	})
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, de, &MockPublisher{}, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	recent := s.Status().RecentEvents
+	if len(recent) != 1 || recent[0].Type != decisionstream.TypePublished || recent[0].Rule != "new_resource" {
+		t.Errorf("Expected a published event for rule new_resource, got %+v", recent)
+	}
+}
+
+func TestTrigger_PausedLabel(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-paused", Kind: testResourceKind, Generation: 1, Labels: map[string]string{config.PausedLabel: "true"}},
+		{ID: "cluster-active", Kind: testResourceKind, Generation: 1},
+	}}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.SuspendedResources.With(labels)); got != 1 {
+		t.Errorf("Expected suspended_resources == 1, got %v", got)
+	}
+	labels["reason"] = reasons.ResourcePaused.String()
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 1, got %v", reasons.ResourcePaused, got)
+	}
+}
+
+func TestTrigger_PausedLabel_DecisionPolicy(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	var mu sync.Mutex
+	var queried []string
+	opa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Input struct {
+				Resource map[string]interface{} `json:"resource"`
+			} `json:"input"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		queried = append(queried, fmt.Sprint(body.Input.Resource["id"]))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"result": {"publish": true, "reason": "always"}}`))
+	}))
+	defer opa.Close()
+	decider, err := engine.NewPolicyDecider(&config.DecisionPolicyConfig{URL: opa.URL, Path: "hyperfleet/decision"})
+	if err != nil {
+		t.Fatalf("NewPolicyDecider failed: %v", err)
+	}
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-paused", Kind: testResourceKind, Generation: 1, Labels: map[string]string{config.PausedLabel: "true"}},
+		{ID: "cluster-active", Kind: testResourceKind, Generation: 1},
+	}}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, decider, pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	if len(queried) != 1 || queried[0] != "cluster-active" {
+		t.Errorf("Expected the policy to be queried for cluster-active only, got %q", queried)
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.SuspendedResources.With(labels)); got != 1 {
+		t.Errorf("Expected suspended_resources == 1, got %v", got)
+	}
+	labels["reason"] = reasons.ResourcePaused.String()
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 1, got %v", reasons.ResourcePaused, got)
+	}
+}
+
+func TestTrigger_DecisionPolicyErrors(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	opa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer opa.Close()
+	decider, err := engine.NewPolicyDecider(&config.DecisionPolicyConfig{URL: opa.URL, Path: "hyperfleet/decision"})
+	if err != nil {
+		t.Fatalf("NewPolicyDecider failed: %v", err)
+	}
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+		{ID: "cluster-2", Kind: testResourceKind, Generation: 1},
+	}}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, decider, pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pub.publishedEvents) != 0 {
+		t.Errorf("Expected no published events, got %d", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.PolicyErrors.With(labels)); got != 2 {
+		t.Errorf("Expected decision_policy_errors_total == 2, got %v", got)
+	}
+}
+
+func TestTrigger_MaintenanceWindow(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-a", Kind: testResourceKind, Generation: 1, Labels: map[string]string{"shard": "a"}},
+		{ID: "cluster-b", Kind: testResourceKind, Generation: 1, Labels: map[string]string{"shard": "b"}},
+	}}
+	cfg := newTestSentinelConfig()
+	// A window that opens every minute is always open.
+	cfg.MessageDecision.MaintenanceWindows = []config.MaintenanceWindow{{
+		Name:     "always",
+		Schedule: "* * * * *",
+		Duration: time.Minute,
+		Selector: config.LabelSelectorList{{Label: "shard", Value: "a"}},
+	}}
+	de, err := engine.NewDecisionEngine(cfg.MessageDecision)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, de, pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.SuspendedResources.With(labels)); got != 1 {
+		t.Errorf("Expected suspended_resources == 1, got %v", got)
+	}
+	labels["reason"] = reasons.MaintenanceWindow.String()
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 1, got %v", reasons.MaintenanceWindow, got)
+	}
+}
+
+func TestTrigger_TerminalConditions(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-deleted", Kind: testResourceKind, Generation: 1, Status: client.ResourceStatus{
+			Conditions: []client.Condition{{Type: "Deleted", Status: "True"}},
+		}},
+		{ID: "cluster-active", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.MessageDecision.TerminalConditions = []string{"Deleted"}
+	de, err := engine.NewDecisionEngine(cfg.MessageDecision)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, de, pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.TerminalResources.With(labels)); got != 1 {
+		t.Errorf("Expected terminal_resources == 1, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.SuspendedResources.With(labels)); got != 0 {
+		t.Errorf("Expected suspended_resources == 0, got %v", got)
+	}
+	labels["reason"] = reasons.Terminal.String()
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 1, got %v", reasons.Terminal, got)
+	}
+}
+
+func TestTrigger_DryRun(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+		{ID: "cluster-2", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.DryRun = true
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pub.publishedEvents) != 0 {
+		t.Fatalf("Expected no published events in dry-run mode, got %d", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.PendingResources.With(labels)); got != 2 {
+		t.Errorf("Expected pending_resources == 2, got %v", got)
+	}
+	labels["reason"] = reasons.Matched.String()
+	if got := testutil.ToFloat64(m.EventsDryRun.With(labels)); got != 2 {
+		t.Errorf("Expected events_dry_run_total{reason=%q} == 2, got %v", reasons.Matched, got)
+	}
+	if got := testutil.ToFloat64(m.EventsPublished.With(labels)); got != 0 {
+		t.Errorf("Expected events_published_total == 0, got %v", got)
+	}
+	if status := s.Status(); status.LastCycle == nil || status.LastCycle.Published != 0 || status.PendingTotal != 2 {
+		t.Errorf("Expected a cycle with 2 pending and none published, got %+v", status)
+	}
+
+	if err := s.PublishHandoff(context.Background()); err != nil {
+		t.Fatalf("PublishHandoff failed: %v", err)
+	}
+	if err := s.ProbeTopics(context.Background()); err != nil {
+		t.Fatalf("ProbeTopics failed: %v", err)
+	}
+	if len(pub.publishedEvents) != 0 {
+		t.Errorf("Expected no handoff or probe event in dry-run mode, got %d events", len(pub.publishedEvents))
+	}
+}
+
+func TestTrigger_DecisionMetrics(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-new", Kind: testResourceKind, Generation: 1},
+		{ID: "cluster-fresh", Kind: testResourceKind, Generation: 2, Status: client.ResourceStatus{
+			Conditions: []client.Condition{
+				{Type: "Reconciled", Status: "True", LastUpdatedTime: time.Now(), ObservedGeneration: 2},
+			},
+		}},
+	}}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, nil, &MockPublisher{}, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, tt := range []struct {
+		outcome string
+		reason  reasons.Reason
+	}{
+		{outcome: metrics.DecisionOutcomePublish, reason: reasons.Matched},
+		{outcome: metrics.DecisionOutcomeSkip, reason: reasons.NotMatched},
+	} {
+		labels := prometheus.Labels{
+			"resource_type": "clusters", "resource_selector": "all", "outcome": tt.outcome, "reason": tt.reason.String(),
+		}
+		if got := testutil.ToFloat64(m.Decisions.With(labels)); got != 1 {
+			t.Errorf("Expected decisions_total{outcome=%q} == 1, got %v", tt.outcome, got)
+		}
+	}
+	if got := testutil.CollectAndCount(m.DecisionDuration); got != 1 {
+		t.Errorf("Expected 1 decision_duration_seconds series, got %d", got)
+	}
+}
+
+func TestTrigger_ClockSkew(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	reconciled := func(lastUpdated time.Time) client.ResourceStatus {
+		return client.ResourceStatus{Conditions: []client.Condition{
+			{Type: "Reconciled", Status: "True", LastUpdatedTime: lastUpdated, ObservedGeneration: 2},
+		}}
+	}
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{
+			ID: "cluster-ahead", Kind: testResourceKind, Generation: 2,
+			CreatedTime: time.Now().Add(-time.Hour), Status: reconciled(time.Now().Add(time.Hour)),
+		},
+		{
+			ID: "cluster-current", Kind: testResourceKind, Generation: 2,
+			CreatedTime: time.Now().Add(-time.Hour), Status: reconciled(time.Now()),
+		},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.MessageDecision.ClockSkew = &config.ClockSkewConfig{Tolerance: time.Minute, FallbackToCreatedTime: true}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, nil, pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The skewed resource falls back to its created time and is stale.
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.ClockSkew.With(labels)); got != 1 {
+		t.Errorf("Expected clock_skew_total == 1, got %v", got)
+	}
+}
+
+// slowPublisher is a MockPublisher that takes delay to publish.
+type slowPublisher struct {
+	MockPublisher
+	delay time.Duration
+}
+
+func (p *slowPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	time.Sleep(p.delay)
+	return p.MockPublisher.Publish(ctx, topic, event)
+}
+
+func TestRunCycle_SkipsOverlappingCycles(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{reconciledResource("cluster-1", "True", 2)}}
+	cfg := newTestSentinelConfig()
+	cfg.PollInterval = 50 * time.Millisecond
+	cfg.CycleTimeout = time.Second // longer than poll_interval
+	pub := &slowPublisher{delay: 110 * time.Millisecond}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+	if err := s.runCycle(context.Background(), ticker); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Ticks fired at 50ms and 100ms; the next one is due at 150ms.
+	select {
+	case <-ticker.C:
+		t.Error("Expected the tick that fired during the cycle to be dropped")
+	default:
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.CyclesSkipped.With(labels)); got < 2 {
+		t.Errorf("Expected cycles_skipped_total >= 2, got %v", got)
+	}
+}
+
+// hungPublisher is a MockPublisher whose publishes block until ctx is done.
+type hungPublisher struct {
+	MockPublisher
+}
+
+func (p *hungPublisher) Publish(ctx context.Context, _ string, _ *cloudevents.Event) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestRunCycle_Deadline(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{reconciledResource("cluster-1", "True", 2)}}
+	cfg := newTestSentinelConfig()
+	cfg.PollInterval = 100 * time.Millisecond
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), &hungPublisher{}, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	start := time.Now()
+	err = s.runCycle(context.Background(), ticker)
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the cycle to reach its deadline, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the hung publish to be canceled at 90%% of poll_interval, took %s", elapsed)
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.CycleTimeouts.With(labels)); got != 1 {
+		t.Errorf("Expected cycle_timeouts_total == 1, got %v", got)
+	}
+	if !s.LastSuccessfulPoll().IsZero() {
+		t.Error("Expected a canceled cycle not to count as a successful poll")
+	}
+}
+
+func TestCycleTimeout(t *testing.T) {
+	cfg := newTestSentinelConfig()
+	s, err := NewSentinel(cfg, &clienttest.Fetcher{}, newTestDecisionEngine(t), &MockPublisher{}, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if got := s.cycleTimeout(10 * time.Second); got != 9*time.Second {
+		t.Errorf("Expected 90%% of the poll interval, got %s", got)
+	}
+	cfg.CycleTimeout = time.Minute
+	if got := s.cycleTimeout(10 * time.Second); got != time.Minute {
+		t.Errorf("Expected cycle_timeout, got %s", got)
+	}
+}
+
+func TestTrigger_StuckDeletion(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	// Recently reconciled, so the result expression alone would skip.
+	reconciled := client.Condition{Type: "Reconciled", Status: "True", LastUpdatedTime: time.Now(), ObservedGeneration: 2}
+	deleting := func(since time.Duration) client.Condition {
+		return client.Condition{Type: "Deleting", Status: "True", LastTransitionTime: time.Now().Add(-since)}
+	}
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-stuck", Kind: testResourceKind, Generation: 2, Status: client.ResourceStatus{
+			Conditions: []client.Condition{reconciled, deleting(2 * time.Hour)},
+		}},
+		{ID: "cluster-deleting", Kind: testResourceKind, Generation: 2, Status: client.ResourceStatus{
+			Conditions: []client.Condition{reconciled, deleting(time.Minute)},
+		}},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.MessageDecision.StuckDeletion = &config.StuckDeletionConfig{Timeout: time.Hour}
+	de, err := engine.NewDecisionEngine(cfg.MessageDecision)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, de, pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	if got, want := pub.publishedEvents[0].Type(), events.StuckEventType(testResourceKind); got != want {
+		t.Errorf("Expected event type %q, got %q", want, got)
+	}
+	labels := prometheus.Labels{
+		"resource_type":     "clusters",
+		"resource_selector": "all",
+		"reason":            reasons.StuckDeletion.String(),
+	}
+	if got := testutil.ToFloat64(m.EventsPublished.With(labels)); got != 1 {
+		t.Errorf("Expected events_published_total{reason=%q} == 1, got %v", reasons.StuckDeletion, got)
+	}
+}
+
+func TestTrigger_ConditionActions(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	// Recently reconciled, so the result expression alone would skip.
+	reconciled := client.Condition{Type: "Reconciled", Status: "True", LastUpdatedTime: time.Now(), ObservedGeneration: 2}
+	withConditions := func(id string, conditions ...client.Condition) client.Resource {
+		return client.Resource{ID: id, Kind: testResourceKind, Generation: 2, Status: client.ResourceStatus{
+			Conditions: append([]client.Condition{reconciled}, conditions...),
+		}}
+	}
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		withConditions("cluster-degraded", client.Condition{Type: "Degraded", Status: "True"}),
+		withConditions("cluster-paused", client.Condition{Type: "Paused", Status: "True"}),
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.MessageDecision.ConditionActions = []config.ConditionAction{
+		{Type: "Paused", Action: config.ConditionActionSkip},
+		{Type: "Degraded", Action: config.ConditionActionPublish},
+	}
+	de, err := engine.NewDecisionEngine(cfg.MessageDecision)
+	if err != nil {
+		t.Fatalf("NewDecisionEngine failed: %v", err)
+	}
+	s, err := NewSentinel(cfg, fetcher, de, &MockPublisher{}, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	recent := s.Status().RecentEvents
+	if len(recent) != 1 || recent[0].ResourceID != "cluster-degraded" || recent[0].Condition != "Degraded" {
+		t.Errorf("Expected a published event for condition Degraded, got %+v", recent)
+	}
+	labels := prometheus.Labels{
+		"resource_type": "clusters", "resource_selector": "all", "reason": reasons.ConditionSkip.String(),
+	}
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 1 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 1, got %v", reasons.ConditionSkip, got)
+	}
+}
+
+func TestNewSentinel_InvalidEventSource(t *testing.T) {
+	cfg := newTestSentinelConfig()
+	cfg.Clients.Broker.Source = "other/{{.Instance}}"
+
+	_, err := NewSentinel(cfg, &clienttest.Fetcher{}, newTestDecisionEngine(t), &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err == nil || !strings.Contains(err.Error(), "clients.broker.source") {
+		t.Errorf("Expected clients.broker.source error, got %v", err)
+	}
+}
+
+// deciderFunc adapts a function to the engine.Decider interface.
+type deciderFunc func(ctx context.Context, resource *client.Resource, now time.Time) engine.Decision
+
+func (f deciderFunc) Evaluate(ctx context.Context, resource *client.Resource, now time.Time) engine.Decision {
+	return f(ctx, resource, now)
+}
+
+func TestTrigger_CustomDecider(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-odd", Kind: testResourceKind, Generation: 1},
+		{ID: "cluster-even", Kind: testResourceKind, Generation: 2},
+	}}
+	decider := deciderFunc(func(_ context.Context, resource *client.Resource, _ time.Time) engine.Decision {
+		if resource.Generation%2 == 0 {
+			return engine.Decision{ShouldPublish: true, Reason: reasons.Matched}
+		}
+		return engine.Decision{Reason: reasons.NotMatched}
+	})
+	pub := &MockPublisher{}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, decider, pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pub.publishedEvents) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+	data := map[string]interface{}{}
+	if err := pub.publishedEvents[0].DataAs(&data); err != nil {
+		t.Fatalf("Failed to decode event data: %v", err)
+	}
+	if data["id"] != "cluster-even" {
+		t.Errorf("Expected the event for cluster-even, got %v", data["id"])
+	}
+}
+
+func TestNewSentinel_DefaultDecider(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	// A new resource is published by the default message_decision.
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{{ID: "cluster-1", Kind: testResourceKind, Generation: 1}}}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, nil, pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 1 {
+		t.Errorf("Expected 1 published event, got %d", len(pub.publishedEvents))
+	}
+
+	cfg := newTestSentinelConfig()
+	cfg.MessageDecision = &config.MessageDecisionConfig{}
+	if _, err := NewSentinel(cfg, fetcher, nil, pub, logger.NewHyperFleetLogger()); err == nil {
+		t.Error("Expected an invalid message_decision to be rejected")
+	}
+}
+
+func TestCircuitState_MostSevereRegion(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []client.CircuitState
+		want   client.CircuitState
+	}{
+		{name: "all closed", states: []client.CircuitState{client.CircuitClosed, client.CircuitClosed},
+			want: client.CircuitClosed},
+		{name: "one half-open", states: []client.CircuitState{client.CircuitClosed, client.CircuitHalfOpen},
+			want: client.CircuitHalfOpen},
+		{name: "one open", states: []client.CircuitState{client.CircuitHalfOpen, client.CircuitOpen},
+			want: client.CircuitOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regions := make([]Region, 0, len(tt.states))
+			for i, state := range tt.states {
+				regions = append(regions, Region{
+					Client: &clienttest.Fetcher{State: state},
+					Name:   fmt.Sprintf("region-%d", i),
+					Topic:  fmt.Sprintf("topic-%d", i),
+				})
+			}
+			s, err := NewMultiRegionSentinel(newTestSentinelConfig(), regions, newTestDecisionEngine(t),
+				&MockPublisher{}, logger.NewHyperFleetLogger())
+			if err != nil {
+				t.Fatalf("NewMultiRegionSentinel failed: %v", err)
+			}
+			if got := s.CircuitState(); got != tt.want {
+				t.Errorf("CircuitState() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIErrorType(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: errors.New("boom"), want: "fetch_error"},
+		{err: &client.APIError{StatusCode: http.StatusServiceUnavailable, Retriable: true}, want: "fetch_error"},
+		{err: fmt.Errorf("wrapped: %w", &client.APIError{StatusCode: http.StatusTooManyRequests}), want: "rate_limited"},
+		{err: &client.APIError{StatusCode: http.StatusNotFound}, want: "not_found"},
+		{err: &client.APIError{Cause: &client.TokenError{}}, want: "auth_error"},
+		{err: &client.APIError{Cause: apierrors.ErrResponseTooLarge}, want: "response_too_large"},
+	}
+	for _, tt := range tests {
+		if got := apiErrorType(tt.err); got != tt.want {
+			t.Errorf("apiErrorType(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+// TestTrigger_EvaluationCache verifies that unchanged resources reuse their
+// cached skip decision and are evaluated again once they change.
+func TestTrigger_EvaluationCache(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	// Reconciled recently: the default decision skips it.
+	resource := client.Resource{
+		ID: "cluster-1", Kind: testResourceKind, Generation: 2,
+		Status: client.ResourceStatus{Conditions: []client.Condition{{
+			Type: "Reconciled", Status: "True", ObservedGeneration: 2, LastUpdatedTime: time.Now(),
+		}}},
+	}
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{resource}}
+	mockPublisher := &MockPublisher{}
+
+	cfg := newTestSentinelConfig()
+	cfg.EvaluationCache = &config.EvaluationCacheConfig{RevalidateAfter: time.Hour}
+
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), mockPublisher, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for range 2 {
+		if err := s.trigger(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	hits := m.EvaluationCacheLookups.WithLabelValues("clusters", "all", "hit")
+	misses := m.EvaluationCacheLookups.WithLabelValues("clusters", "all", "miss")
+	if got := testutil.ToFloat64(misses); got != 1 {
+		t.Errorf("Expected 1 cache miss, got %f", got)
+	}
+	if got := testutil.ToFloat64(hits); got != 1 {
+		t.Errorf("Expected 1 cache hit, got %f", got)
+	}
+
+	// A spec change bumps the generation; the cached decision must not be reused.
+	fetcher.Resources[0].Generation = 3
+	if err := s.trigger(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := testutil.ToFloat64(misses); got != 2 {
+		t.Errorf("Expected 2 cache misses after the change, got %f", got)
+	}
+	if len(mockPublisher.publishedEvents) != 1 {
+		t.Errorf("Expected 1 published event after the generation change, got %d",
+			len(mockPublisher.publishedEvents))
+	}
+}
+
+func TestTrigger_DecisionStream(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	dir, err := os.MkdirTemp("", "ds")
+	if err != nil {
+		t.Fatalf("MkdirTemp failed: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	ds, err := decisionstream.Listen(filepath.Join(dir, "s.sock"), 0, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = ds.Serve(ctx) }()
+
+	conn, err := net.Dial("unix", ds.Path())
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+	for deadline := time.Now().Add(5 * time.Second); ds.Clients() == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for stream client")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{{ID: "cluster-1", Kind: testResourceKind, Generation: 1}},
+	}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, newTestDecisionEngine(t), &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	s.SetDecisionStream(ds)
+
+	if err := s.trigger(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("SetReadDeadline failed: %v", err)
+	}
+	dec := json.NewDecoder(conn)
+	var decision, published decisionstream.Event
+	if err := dec.Decode(&decision); err != nil {
+		t.Fatalf("failed to read decision event: %v", err)
+	}
+	if err := dec.Decode(&published); err != nil {
+		t.Fatalf("failed to read published event: %v", err)
+	}
+
+	if decision.Type != decisionstream.TypeDecision || decision.ResourceID != "cluster-1" || !decision.ShouldPublish {
+		t.Errorf("unexpected decision event: %+v", decision)
+	}
+	if published.Type != decisionstream.TypePublished || published.Topic != testTopic || published.EventID == "" {
+		t.Errorf("unexpected published event: %+v", published)
+	}
+}
+
+// topicPublisher fails publishes to the topics in failTopics.
+type topicPublisher struct {
+	failTopics map[string]error
+	MockPublisher
+}
+
+func (p *topicPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	if err, ok := p.failTopics[topic]; ok {
+		return err
+	}
+	return p.MockPublisher.Publish(ctx, topic, event)
+}
+
+func TestProbeTopics(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	regions := []Region{
+		{Client: &clienttest.Fetcher{}, Name: "us-east", Topic: "clusters-us-east"},
+		{Client: &clienttest.Fetcher{}, Name: "us-west", Topic: "clusters-us-west"},
+	}
+	pub := &topicPublisher{failTopics: map[string]error{"clusters-us-west": errors.New("topic not found")}}
+
+	s, err := NewMultiRegionSentinel(newTestSentinelConfig(), regions, newTestDecisionEngine(t), pub,
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewMultiRegionSentinel failed: %v", err)
+	}
+
+	err = s.ProbeTopics(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `topic "clusters-us-west" unreachable: topic not found`) {
+		t.Fatalf("Expected per-topic probe error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "clusters-us-east") {
+		t.Errorf("Expected reachable topic to be omitted from the error, got %v", err)
+	}
+	if s.TopicError() == nil {
+		t.Error("Expected TopicError to report the unreachable topic")
+	}
+
+	if len(pub.publishedEvents) != 1 || pub.publishedTopics[0] != "clusters-us-east" {
+		t.Fatalf("Expected one probe on clusters-us-east, got topics %v", pub.publishedTopics)
+	}
+	probe := pub.publishedEvents[0]
+	if !events.IsProbe(probe) {
+		t.Errorf("Expected a probe event, got type %q", probe.Type())
+	}
+	if _, err := events.Parse(probe); !errors.Is(err, events.ErrNotReconcileEvent) {
+		t.Errorf("Expected probe to be rejected by events.Parse, got %v", err)
+	}
+
+	// Once the topic exists, the next poll cycle probes it again and clears the error.
+	delete(pub.failTopics, "clusters-us-west")
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := s.TopicError(); err != nil {
+		t.Errorf("Expected no topic error after a successful probe, got %v", err)
+	}
+}
+
+func TestProbeTopics_AsyncPublish(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	pub := &topicPublisher{failTopics: map[string]error{testTopic: errors.New("topic not found")}}
+	asyncCfg := &config.BrokerAsyncPublishConfig{MaxInFlight: 1, QueueSize: 1, MaxAttempts: 1}
+	async := publisher.NewAsyncPublisher(pub, asyncCfg, "clusters", "all", logger.NewHyperFleetLogger())
+	defer func() { _ = async.Close() }()
+	s, err := NewSentinel(newTestSentinelConfig(), &clienttest.Fetcher{}, newTestDecisionEngine(t), async,
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	// Probes wait for the broker instead of only being queued.
+	if err := s.ProbeTopics(context.Background()); err == nil {
+		t.Fatal("Expected the probe to report the unreachable topic")
+	}
+}
+
+func TestTrigger_TopicRouting(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: "Cluster", Generation: 1},
+		{ID: "nodepool-1", Kind: "NodePool", Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.Clients.Broker.Topic = "clusters"
+	cfg.Clients.Broker.Topics = map[string]string{"nodepool": "nodepools"}
+	cfg.Clients.Broker.TopicPrefix = "staging-"
+	pub := &MockPublisher{}
+
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	if err := s.ProbeTopics(context.Background()); err != nil {
+		t.Fatalf("ProbeTopics failed: %v", err)
+	}
+	if want := []string{"staging-clusters", "staging-nodepools"}; !slices.Equal(pub.publishedTopics, want) {
+		t.Fatalf("Expected probes on %v, got %v", want, pub.publishedTopics)
+	}
+
+	pub.publishedEvents, pub.publishedTopics = nil, nil
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := map[string]string{"cluster-1": "staging-clusters", "nodepool-1": "staging-nodepools"}
+	if len(pub.publishedEvents) != len(want) {
+		t.Fatalf("Expected %d published events, got %d", len(want), len(pub.publishedEvents))
+	}
+	for i, event := range pub.publishedEvents {
+		re, err := events.Parse(event)
+		if err != nil {
+			t.Fatalf("events.Parse failed: %v", err)
+		}
+		if got := pub.publishedTopics[i]; got != want[re.ID] {
+			t.Errorf("Expected %s on topic %q, got %q", re.ID, want[re.ID], got)
+		}
+	}
+}
+
+// blockingPublisher holds every publish until release is closed, signalling
+// started when the first one begins.
+type blockingPublisher struct {
+	MockPublisher
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (b *blockingPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	b.once.Do(func() { close(b.started) })
+	<-b.release
+	return b.MockPublisher.Publish(ctx, topic, event)
+}
+
+func TestStart_StopFinishesCycleInProgress(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+		{ID: "cluster-2", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.PollInterval = time.Hour
+	pub := &blockingPublisher{started: make(chan struct{}), release: make(chan struct{})}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- s.Start(context.Background()) }()
+
+	// Stop while the first cycle is publishing; the cycle still completes.
+	<-pub.started
+	s.Stop()
+	s.Stop()
+	close(pub.release)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Expected Start to return nil after Stop, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Start to return after Stop")
+	}
+	if len(pub.publishedEvents) != 2 {
+		t.Errorf("Expected the cycle in progress to publish both events, got %d", len(pub.publishedEvents))
+	}
+}
@@ -0,0 +1,28 @@
+package sentinel
+
+import (
+	"hash/fnv"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+)
+
+// resourceShard returns the shard, 0 to total-1, that a resource ID belongs
+// to. The FNV-1a hash of the ID is stable across replicas and restarts, so
+// replicas with the same total agree on the owner of every resource.
+func resourceShard(id string, total int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	return int(h.Sum32() % uint32(total))
+}
+
+// filterShard keeps the resources that belong to shard index of total,
+// reusing the backing array of resources.
+func filterShard(resources []client.Resource, index, total int) []client.Resource {
+	kept := resources[:0]
+	for i := range resources {
+		if resourceShard(resources[i].ID, total) == index {
+			kept = append(kept, resources[i])
+		}
+	}
+	return kept
+}
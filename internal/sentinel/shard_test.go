@@ -0,0 +1,133 @@
+package sentinel
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestResourceShard(t *testing.T) {
+	const total = 4
+	perShard := make([]int, total)
+	for i := range 1000 {
+		id := fmt.Sprintf("cluster-%d", i)
+		shard := resourceShard(id, total)
+		if shard < 0 || shard >= total {
+			t.Fatalf("Expected shard of %s between 0 and %d, got %d", id, total-1, shard)
+		}
+		if again := resourceShard(id, total); again != shard {
+			t.Fatalf("Expected a stable shard for %s, got %d then %d", id, shard, again)
+		}
+		perShard[shard]++
+	}
+	for shard, n := range perShard {
+		if n < 150 {
+			t.Errorf("Expected resources spread over the shards, shard %d has %d of 1000", shard, n)
+		}
+	}
+	if shard := resourceShard("cluster-1", 1); shard != 0 {
+		t.Errorf("Expected every resource in shard 0 of 1, got %d", shard)
+	}
+}
+
+func TestTrigger_Shard(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	var resources []client.Resource
+	for i := range 30 {
+		resources = append(resources, client.Resource{ID: fmt.Sprintf("cluster-%d", i), Kind: testResourceKind, Generation: 1})
+	}
+
+	// Every resource is published by exactly one of the replicas.
+	const total = 3
+	publishedBy := map[string]int{}
+	for index := range total {
+		cfg := newTestSentinelConfig()
+		cfg.Shard = &config.ShardConfig{Index: index, Total: total}
+		pub := &MockPublisher{}
+		fetcher := &clienttest.Fetcher{Resources: resources}
+		s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+		if err != nil {
+			t.Fatalf("NewSentinel failed: %v", err)
+		}
+		if err := s.trigger(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(pub.publishedEvents) == 0 || len(pub.publishedEvents) == len(resources) {
+			t.Errorf("Expected shard %d to publish a part of the resources, got %d", index, len(pub.publishedEvents))
+		}
+		for _, event := range pub.publishedEvents {
+			data := map[string]interface{}{}
+			if err := event.DataAs(&data); err != nil {
+				t.Fatalf("Failed to decode event data: %v", err)
+			}
+			id, _ := data["id"].(string)
+			if prev, ok := publishedBy[id]; ok {
+				t.Errorf("Expected %s to be published once, got shards %d and %d", id, prev, index)
+			}
+			publishedBy[id] = index
+		}
+	}
+	if len(publishedBy) != len(resources) {
+		t.Errorf("Expected all %d resources to be published, got %d", len(resources), len(publishedBy))
+	}
+}
+
+func TestTrigger_CorrelationExtensions(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	cfg := newTestSentinelConfig()
+	cfg.ResourceSelector = config.LabelSelectorList{{Label: "shard", Value: "1"}}
+	cfg.Shard = &config.ShardConfig{Index: 0, Total: 1}
+	pub := &MockPublisher{}
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1, Labels: map[string]string{"shard": "1"}},
+	}}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	ctx := logger.WithOpID(context.Background(), "ignored")
+	for range 2 {
+		if err := s.trigger(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if len(pub.publishedEvents) != 2 {
+		t.Fatalf("Expected 2 published events, got %d", len(pub.publishedEvents))
+	}
+
+	var opIDs []string
+	for _, event := range pub.publishedEvents {
+		re, err := events.Parse(event)
+		if err != nil {
+			t.Fatalf("events.Parse failed: %v", err)
+		}
+		if re.Shard != "shard:1" {
+			t.Errorf("Expected shard %q without splitting between replicas, got %q", "shard:1", re.Shard)
+		}
+		if re.SentinelVersion != logger.GetGlobalConfig().Version {
+			t.Errorf("Expected the logger version, got %q", re.SentinelVersion)
+		}
+		opIDs = append(opIDs, re.OpID)
+	}
+	if opIDs[0] == "" || opIDs[0] == "ignored" || opIDs[0] == opIDs[1] {
+		t.Errorf("Expected the operation ID of each poll cycle, got %v", opIDs)
+	}
+
+	cfg.Shard = &config.ShardConfig{Index: 1, Total: 4}
+	if got := shardLabel(cfg); got != "shard:1/1/4" {
+		t.Errorf("Expected shard %q, got %q", "shard:1/1/4", got)
+	}
+}
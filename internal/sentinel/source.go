@@ -0,0 +1,85 @@
+package sentinel
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+)
+
+// kindAttributes are the CloudEvent attributes of the events published for
+// one resource kind.
+type kindAttributes struct {
+	source     string
+	eventType  string
+	dataSchema string
+}
+
+// eventAttributes renders the clients.broker source, event_type, and
+// data_schema templates, once per resource kind.
+type eventAttributes struct {
+	broker *config.BrokerConfig
+	kinds  map[string]kindAttributes
+	data   config.EventSourceData
+	mu     sync.Mutex
+}
+
+// newEventAttributes creates the eventAttributes of cfg. The instance is the
+// hostname, which Kubernetes sets to the pod name.
+func newEventAttributes(cfg *config.SentinelConfig) (*eventAttributes, error) {
+	instance, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine instance for event source: %w", err)
+	}
+	return &eventAttributes{
+		broker: cfg.Clients.Broker,
+		kinds:  make(map[string]kindAttributes),
+		data: config.EventSourceData{
+			Name:         cfg.Sentinel.Name,
+			Instance:     instance,
+			Shard:        metrics.GetResourceSelectorLabel(cfg.ResourceSelector),
+			ResourceType: cfg.ResourceType,
+		},
+	}, nil
+}
+
+// source renders the source of events about the Sentinel itself, which are
+// not published for a resource kind.
+func (a *eventAttributes) source() (string, error) {
+	source, err := a.broker.EventSource(a.data)
+	if err != nil {
+		return "", fmt.Errorf("clients.broker.source: %w", err)
+	}
+	return source, nil
+}
+
+// forKind returns the attributes of the events published for resources of
+// kind.
+func (a *eventAttributes) forKind(kind string) (kindAttributes, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if attrs, ok := a.kinds[kind]; ok {
+		return attrs, nil
+	}
+
+	data := a.data
+	data.Kind = strings.ToLower(kind)
+	var (
+		attrs kindAttributes
+		err   error
+	)
+	if attrs.source, err = a.broker.EventSource(data); err != nil {
+		return attrs, fmt.Errorf("clients.broker.source: %w", err)
+	}
+	if attrs.eventType, err = a.broker.ReconcileEventType(data); err != nil {
+		return attrs, fmt.Errorf("clients.broker.event_type: %w", err)
+	}
+	if attrs.dataSchema, err = a.broker.EventDataSchema(data); err != nil {
+		return attrs, fmt.Errorf("clients.broker.data_schema: %w", err)
+	}
+	a.kinds[kind] = attrs
+	return attrs, nil
+}
@@ -0,0 +1,64 @@
+package sentinel
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// publishStagger spreads the publishes of a poll cycle: the first burst
+// publishes of a cycle are sent right away, and each further publish waits
+// interval, randomized by up to ±jitter. It is used only by the poll loop.
+type publishStagger struct {
+	sleep     func(ctx context.Context, d time.Duration) error
+	interval  time.Duration
+	jitter    float64
+	burst     int
+	published int
+}
+
+func newPublishStagger(burst int, interval time.Duration, jitter float64) *publishStagger {
+	return &publishStagger{
+		sleep:    sleepContext,
+		interval: interval,
+		jitter:   jitter,
+		burst:    burst,
+	}
+}
+
+// beginCycle resets the burst at the start of a poll cycle.
+func (p *publishStagger) beginCycle() {
+	p.published = 0
+}
+
+// wait blocks until the next publish of the cycle may be sent. It returns the
+// context's error if ctx is done first.
+func (p *publishStagger) wait(ctx context.Context) error {
+	p.published++
+	if p.published <= p.burst {
+		return nil
+	}
+	return p.sleep(ctx, p.delay())
+}
+
+// delay returns interval randomized by up to ±jitter.
+func (p *publishStagger) delay() time.Duration {
+	if p.jitter == 0 {
+		return p.interval
+	}
+	// Jitter only spreads publishes; it does not need a cryptographic source.
+	factor := 1 + p.jitter*(2*rand.Float64()-1) //nolint:gosec // see above
+	return time.Duration(float64(p.interval) * factor)
+}
+
+// sleepContext sleeps for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
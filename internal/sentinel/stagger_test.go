@@ -0,0 +1,93 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// recordSleeps replaces the stagger's sleep and returns the recorded delays.
+func recordSleeps(p *publishStagger) *[]time.Duration {
+	var sleeps []time.Duration
+	p.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	return &sleeps
+}
+
+func TestPublishStagger(t *testing.T) {
+	p := newPublishStagger(2, time.Second, 0)
+	sleeps := recordSleeps(p)
+
+	p.beginCycle()
+	for range 4 {
+		if err := p.wait(context.Background()); err != nil {
+			t.Fatalf("wait failed: %v", err)
+		}
+	}
+	if len(*sleeps) != 2 || (*sleeps)[0] != time.Second || (*sleeps)[1] != time.Second {
+		t.Errorf("Expected two waits of 1s after the burst, got %v", *sleeps)
+	}
+
+	// Each cycle starts with a new burst.
+	p.beginCycle()
+	if err := p.wait(context.Background()); err != nil || len(*sleeps) != 2 {
+		t.Errorf("Expected the first publish of a new cycle not to wait, got %v", *sleeps)
+	}
+}
+
+func TestPublishStagger_Jitter(t *testing.T) {
+	p := newPublishStagger(0, time.Second, 0.5)
+	for range 100 {
+		if d := p.delay(); d < 500*time.Millisecond || d > 1500*time.Millisecond {
+			t.Fatalf("Expected delay within ±50%% of 1s, got %s", d)
+		}
+	}
+}
+
+func TestPublishStagger_Canceled(t *testing.T) {
+	p := newPublishStagger(0, time.Hour, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestTrigger_PublishStagger(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{Resources: []client.Resource{
+		{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+		{ID: "cluster-2", Kind: testResourceKind, Generation: 1},
+		{ID: "cluster-3", Kind: testResourceKind, Generation: 1},
+	}}
+	cfg := newTestSentinelConfig()
+	cfg.PublishStagger = &config.PublishStaggerConfig{Interval: time.Minute, Burst: 1}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	sleeps := recordSleeps(s.stagger)
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pub.publishedEvents) != 3 {
+		t.Errorf("Expected 3 published events, got %d", len(pub.publishedEvents))
+	}
+	if len(*sleeps) != 2 {
+		t.Errorf("Expected a wait before the 2 publishes after the burst, got %v", *sleeps)
+	}
+}
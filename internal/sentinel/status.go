@@ -0,0 +1,201 @@
+package sentinel
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/decisionstream"
+)
+
+// recentEventsCapacity is the number of publish results kept for Status.
+const recentEventsCapacity = 50
+
+// pendingResourcesLimit bounds the pending resources kept for Status, so that
+// a large backlog does not grow the Sentinel's memory.
+const pendingResourcesLimit = 200
+
+// CycleStatus summarizes one poll cycle.
+type CycleStatus struct {
+	Started   time.Time
+	OpID      string
+	Error     string
+	Duration  time.Duration
+	Total     int
+	Published int
+	Skipped   int
+	Pending   int
+	// Incremental is set when the cycle fetched only updated resources.
+	Incremental bool
+}
+
+// Summary totals the poll cycles since the Sentinel was created. It is logged
+// when the Sentinel shuts down.
+type Summary struct {
+	Started time.Time
+	// LastCycle is nil if no poll cycle completed.
+	LastCycle    *CycleStatus
+	Uptime       time.Duration
+	Cycles       int
+	FailedCycles int
+	Published    int
+	Skipped      int
+}
+
+// cycleTotals accumulates poll cycle outcomes for Summary.
+type cycleTotals struct {
+	cycles       int
+	failedCycles int
+	published    int
+	skipped      int
+}
+
+// PendingResource is a resource that awaited reconciliation in the last full
+// poll cycle: it was published, failed to publish, or was held back by a
+// pause.
+type PendingResource struct {
+	ID     string
+	Kind   string
+	Region string
+	Reason string
+}
+
+// Status is a point-in-time view of the Sentinel for status pages.
+type Status struct {
+	LastSuccessfulPoll time.Time
+	// LastCycle is nil until the first poll cycle completes.
+	LastCycle *CycleStatus
+	// Pending holds at most 200 of the PendingTotal pending resources of the
+	// last full poll cycle.
+	Pending []PendingResource
+	// RecentEvents holds the latest publish results, newest first.
+	RecentEvents []decisionstream.Event
+	PendingTotal int
+	CircuitState client.CircuitState
+	Paused       bool
+}
+
+// Status returns a snapshot of the last poll cycle, its pending resources,
+// and the latest publish results.
+func (s *Sentinel) Status() Status {
+	s.mu.RLock()
+	st := Status{
+		LastSuccessfulPoll: s.lastSuccessfulPoll,
+		Pending:            append([]PendingResource(nil), s.pendingResources...),
+		PendingTotal:       s.pendingTotal,
+		RecentEvents:       s.recentEvents.newestFirst(),
+		Paused:             s.paused,
+	}
+	if s.lastCycle != nil {
+		cycle := *s.lastCycle
+		st.LastCycle = &cycle
+	}
+	s.mu.RUnlock()
+
+	st.CircuitState = s.CircuitState()
+	return st
+}
+
+// Summary returns the totals of all poll cycles so far and the last cycle.
+func (s *Sentinel) Summary() Summary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sum := Summary{
+		Started:      s.started,
+		Uptime:       time.Since(s.started),
+		Cycles:       s.totals.cycles,
+		FailedCycles: s.totals.failedCycles,
+		Published:    s.totals.published,
+		Skipped:      s.totals.skipped,
+	}
+	if s.lastCycle != nil {
+		cycle := *s.lastCycle
+		sum.LastCycle = &cycle
+	}
+	return sum
+}
+
+// LogSummary logs the Summary as one structured line. It is called once the
+// polling loop has stopped.
+func (s *Sentinel) LogSummary(ctx context.Context) {
+	sum := s.Summary()
+	log := s.logger.Extra("uptime", sum.Uptime.Round(time.Second).String()).
+		Extra("cycles", sum.Cycles).
+		Extra("failed_cycles", sum.FailedCycles).
+		Extra("published", sum.Published).
+		Extra("skipped", sum.Skipped)
+	if c := sum.LastCycle; c != nil {
+		log = log.Extra("last_cycle_op_id", c.OpID).
+			Extra("last_cycle_total", c.Total).
+			Extra("last_cycle_published", c.Published).
+			Extra("last_cycle_skipped", c.Skipped).
+			Extra("last_cycle_duration", c.Duration.String())
+		if c.Error != "" {
+			log = log.Extra("last_cycle_error", c.Error)
+		}
+	}
+	log.Info(ctx, "Sentinel final summary")
+}
+
+// recordCycle stores the outcome of a poll cycle for Status. The pending
+// resources are replaced only by a cycle that listed every resource, like the
+// pending_resources gauge.
+func (s *Sentinel) recordCycle(cycle CycleStatus, counts *pollCounts, listed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastCycle = &cycle
+	s.totals.cycles++
+	if cycle.Error != "" {
+		s.totals.failedCycles++
+	}
+	s.totals.published += cycle.Published
+	s.totals.skipped += cycle.Skipped
+	if listed && !counts.incremental {
+		s.pendingResources = counts.pendingResources
+		s.pendingTotal = counts.pending
+	}
+}
+
+// sendEvent sends ev to the decision stream and keeps publish results for
+// Status.
+func (s *Sentinel) sendEvent(ev decisionstream.Event) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	s.stream.Send(ev)
+	if ev.Type == decisionstream.TypeDecision {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recentEvents.add(ev)
+}
+
+// eventRing keeps the latest events up to its capacity.
+type eventRing struct {
+	events []decisionstream.Event
+	next   int
+}
+
+func newEventRing(capacity int) *eventRing {
+	return &eventRing{events: make([]decisionstream.Event, 0, capacity)}
+}
+
+func (r *eventRing) add(ev decisionstream.Event) {
+	if len(r.events) < cap(r.events) {
+		r.events = append(r.events, ev)
+		return
+	}
+	r.events[r.next] = ev
+	r.next = (r.next + 1) % len(r.events)
+}
+
+// newestFirst returns a copy of the events, newest first.
+func (r *eventRing) newestFirst() []decisionstream.Event {
+	out := make([]decisionstream.Event, 0, len(r.events))
+	for i := range len(r.events) {
+		idx := (r.next - 1 - i + 2*len(r.events)) % len(r.events)
+		out = append(out, r.events[idx])
+	}
+	return out
+}
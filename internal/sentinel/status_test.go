@@ -0,0 +1,125 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/decisionstream"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestStatus(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{
+			{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+			{ID: "cluster-2", Kind: testResourceKind, Generation: 1},
+		},
+	}
+	pub := &MockPublisher{}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, newTestDecisionEngine(t), pub, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	if st := s.Status(); st.LastCycle != nil || len(st.RecentEvents) != 0 {
+		t.Fatalf("Expected an empty status before the first cycle, got %+v", st)
+	}
+
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	st := s.Status()
+	if st.LastCycle == nil || st.LastCycle.Total != 2 || st.LastCycle.Published != 2 || st.LastCycle.Error != "" {
+		t.Fatalf("Unexpected last cycle %+v", st.LastCycle)
+	}
+	if st.LastCycle.OpID == "" {
+		t.Error("Expected the last cycle to record its op_id")
+	}
+	if st.PendingTotal != 2 || len(st.Pending) != 2 || st.Pending[0].ID != "cluster-1" {
+		t.Errorf("Expected both clusters pending, got %d: %+v", st.PendingTotal, st.Pending)
+	}
+	if len(st.RecentEvents) != 2 || st.RecentEvents[0].ResourceID != "cluster-2" ||
+		st.RecentEvents[0].Type != decisionstream.TypePublished {
+		t.Errorf("Expected both publishes, newest first, got %+v", st.RecentEvents)
+	}
+
+	// A failed cycle is recorded, but keeps the pending resources of the last
+	// full list.
+	fetcher.Err = errors.New("api down")
+	if err := s.trigger(context.Background()); err == nil {
+		t.Fatal("Expected the cycle to fail")
+	}
+	st = s.Status()
+	if st.LastCycle == nil || st.LastCycle.Error == "" {
+		t.Errorf("Expected the failed cycle to be recorded, got %+v", st.LastCycle)
+	}
+	if st.PendingTotal != 2 {
+		t.Errorf("Expected pending resources to survive a failed cycle, got %d", st.PendingTotal)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{{ID: "cluster-1", Kind: testResourceKind, Generation: 1}},
+	}
+	s, err := NewSentinel(newTestSentinelConfig(), fetcher, newTestDecisionEngine(t), &MockPublisher{},
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	if sum := s.Summary(); sum.Cycles != 0 || sum.LastCycle != nil || sum.Started.IsZero() {
+		t.Fatalf("Expected an empty summary before the first cycle, got %+v", sum)
+	}
+
+	for range 2 {
+		if err := s.trigger(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	fetcher.Err = errors.New("api down")
+	if err := s.trigger(context.Background()); err == nil {
+		t.Fatal("Expected the cycle to fail")
+	}
+
+	sum := s.Summary()
+	if sum.Cycles != 3 || sum.FailedCycles != 1 || sum.Published != 2 {
+		t.Errorf("Expected 3 cycles, 1 failed, 2 published, got %+v", sum)
+	}
+	if sum.LastCycle == nil || sum.LastCycle.Error == "" {
+		t.Errorf("Expected the failed cycle as the last cycle, got %+v", sum.LastCycle)
+	}
+	if sum.Uptime <= 0 {
+		t.Errorf("Expected a positive uptime, got %s", sum.Uptime)
+	}
+	s.LogSummary(context.Background())
+}
+
+func TestEventRing(t *testing.T) {
+	r := newEventRing(3)
+	for i := 1; i <= 5; i++ {
+		r.add(decisionstream.Event{ResourceID: fmt.Sprintf("r%d", i)})
+		got := r.newestFirst()
+		if len(got) != min(i, 3) || got[0].ResourceID != fmt.Sprintf("r%d", i) {
+			t.Fatalf("after %d events: got %+v", i, got)
+		}
+	}
+	got := r.newestFirst()
+	for i, want := range []string{"r5", "r4", "r3"} {
+		if got[i].ResourceID != want {
+			t.Errorf("event %d = %s, want %s", i, got[i].ResourceID, want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package sentinel
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+)
+
+// tagTimeFormat is the format of the resource tagging label value: a compact
+// UTC ISO 8601 time, since label values cannot hold colons.
+const tagTimeFormat = "20060102T150405Z"
+
+// tagResource writes the publish time into the resource tagging label of
+// resource, keeping its other labels. Tagging is best effort: a failure is
+// logged and counted as an API error with type tag_error, but does not undo
+// or fail the publish. Regions whose client cannot write labels are not
+// tagged.
+func (s *Sentinel) tagResource(ctx context.Context, region Region, resource *client.Resource, at time.Time) {
+	if s.config.ResourceTagging == nil {
+		return
+	}
+	labeler, ok := region.Client.(client.ResourceLabeler)
+	if !ok {
+		return
+	}
+
+	key := s.config.ResourceTagging.Label
+	labels := maps.Clone(resource.Labels)
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	labels[key] = at.UTC().Format(tagTimeFormat)
+
+	if err := labeler.PatchResourceLabels(ctx, s.config.ResourceType, resource.ID, labels); err != nil {
+		metrics.UpdateAPIErrorsMetric(s.config.ResourceType,
+			metrics.GetResourceSelectorLabel(s.config.ResourceSelector), "tag_error")
+		s.logger.Warnf(ctx, "Failed to tag resource resource_id=%s label=%s error=%v", resource.ID, key, err)
+		return
+	}
+	resource.Labels = labels
+}
+
+// taggedRecently reports whether the resource tagging label of resource shows
+// a publish within resource_tagging.min_interval before now, by this or any
+// other replica. A missing or unparsable label shows no publish.
+func (s *Sentinel) taggedRecently(resource *client.Resource, now time.Time) bool {
+	rt := s.config.ResourceTagging
+	if rt == nil || rt.MinInterval <= 0 {
+		return false
+	}
+	value, ok := resource.Labels[rt.Label]
+	if !ok {
+		return false
+	}
+	tagged, err := time.Parse(tagTimeFormat, value)
+	if err != nil {
+		return false
+	}
+	return now.Before(tagged.Add(rt.MinInterval))
+}
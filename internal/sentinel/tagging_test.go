@@ -0,0 +1,153 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/reasons"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const testTagLabel = "sentinel.hyperfleet.io/last-triggered"
+
+func newTaggingTestSentinel(
+	t *testing.T, fetcher *clienttest.Fetcher, tagging *config.ResourceTaggingConfig,
+) *Sentinel {
+	t.Helper()
+	cfg := newTestSentinelConfig()
+	cfg.ResourceTagging = tagging
+	s, err := NewSentinel(cfg, fetcher, newTestDecisionEngine(t), &MockPublisher{}, logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	return s
+}
+
+func patchCalls(f *clienttest.Fetcher) []clienttest.Call {
+	var calls []clienttest.Call
+	for _, c := range f.Calls {
+		if c.Method == "PatchResourceLabels" {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+func TestTrigger_ResourceTagging(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{
+			{ID: "cluster-1", Kind: testResourceKind, Generation: 1, Labels: map[string]string{"shard": "1"}},
+		},
+	}
+	s := newTaggingTestSentinel(t, fetcher, &config.ResourceTaggingConfig{Label: testTagLabel})
+	before := time.Now().UTC().Truncate(time.Second)
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	calls := patchCalls(fetcher)
+	if len(calls) != 1 || calls[0].ID != "cluster-1" || calls[0].ResourceType != "clusters" {
+		t.Fatalf("Expected one patch of clusters/cluster-1, got %+v", calls)
+	}
+	labels := calls[0].Labels
+	if labels["shard"] != "1" {
+		t.Errorf("Expected existing labels to be kept, got %v", labels)
+	}
+	tagged, err := time.Parse(tagTimeFormat, labels[testTagLabel])
+	if err != nil || tagged.Before(before) {
+		t.Errorf("Expected %s to hold the publish time, got %q (%v)", testTagLabel, labels[testTagLabel], err)
+	}
+}
+
+func TestTrigger_ResourceTaggingDisabled(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{{ID: "cluster-1", Kind: testResourceKind, Generation: 1}},
+	}
+	s := newTaggingTestSentinel(t, fetcher, nil)
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls := patchCalls(fetcher); len(calls) != 0 {
+		t.Errorf("Expected no patches without resource_tagging, got %+v", calls)
+	}
+}
+
+func TestTrigger_ResourceTaggingFailure(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{{ID: "cluster-1", Kind: testResourceKind, Generation: 1}},
+		PatchErr:  errors.New("forbidden"),
+	}
+	s := newTaggingTestSentinel(t, fetcher, &config.ResourceTaggingConfig{Label: "example.com/nudged"})
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected a failed tag not to fail the cycle, got %v", err)
+	}
+	if st := s.Status(); st.LastCycle == nil || st.LastCycle.Published != 1 {
+		t.Errorf("Expected the event to count as published, got %+v", st.LastCycle)
+	}
+
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all", "error_type": "tag_error"}
+	if got := testutil.ToFloat64(m.APIErrors.With(labels)); got != 1 {
+		t.Errorf("Expected api_errors_total{error_type=\"tag_error\"} == 1, got %v", got)
+	}
+	if calls := patchCalls(fetcher); len(calls) != 1 || calls[0].Labels["example.com/nudged"] == "" {
+		t.Errorf("Expected a patch of the configured label, got %+v", calls)
+	}
+}
+
+func TestTrigger_ResourceTaggingMinInterval(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	// cluster-2 was tagged by another replica a minute ago, cluster-3 two
+	// hours ago.
+	now := time.Now().UTC()
+	fetcher := &clienttest.Fetcher{
+		Resources: []client.Resource{
+			{ID: "cluster-1", Kind: testResourceKind, Generation: 1},
+			{ID: "cluster-2", Kind: testResourceKind, Generation: 1, Labels: map[string]string{
+				testTagLabel: now.Add(-time.Minute).Format(tagTimeFormat),
+			}},
+			{ID: "cluster-3", Kind: testResourceKind, Generation: 1, Labels: map[string]string{
+				testTagLabel: now.Add(-2 * time.Hour).Format(tagTimeFormat),
+			}},
+		},
+	}
+	s := newTaggingTestSentinel(t, fetcher, &config.ResourceTaggingConfig{Label: testTagLabel, MinInterval: time.Hour})
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls := patchCalls(fetcher); len(calls) != 2 || calls[0].ID != "cluster-1" || calls[1].ID != "cluster-3" {
+		t.Fatalf("Expected cluster-1 and cluster-3 to be published and tagged, got %+v", calls)
+	}
+
+	// The tags written by the first cycle hold back the next one.
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls := patchCalls(fetcher); len(calls) != 2 {
+		t.Errorf("Expected no further publish within min_interval, got %+v", calls)
+	}
+	labels := prometheus.Labels{
+		"resource_type": "clusters", "resource_selector": "all", "reason": reasons.Duplicate.String(),
+	}
+	if got := testutil.ToFloat64(m.ResourcesSkipped.With(labels)); got != 4 {
+		t.Errorf("Expected resources_skipped_total{reason=%q} == 4, got %v", reasons.Duplicate, got)
+	}
+}
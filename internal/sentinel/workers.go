@@ -0,0 +1,93 @@
+package sentinel
+
+// workerPool runs the work of a poll cycle on up to size goroutines. Each
+// piece of work returns a func that records its outcome, and the poll loop
+// runs these funcs in submission order: trackers, counts, and metrics are
+// only updated from the poll loop, in resource order, whatever order the work
+// finishes in. A nil pool runs work inline and records it right away, as a
+// poll loop without workers does.
+type workerPool struct {
+	sem     chan struct{}
+	pending []chan func()
+}
+
+// newWorkerPool returns a pool of size goroutines, or nil for a size of one
+// or less.
+func newWorkerPool(size int) *workerPool {
+	if size <= 1 {
+		return nil
+	}
+	return &workerPool{sem: make(chan struct{}, size)}
+}
+
+// submit runs work on the pool, waiting while size works are running. The
+// func work returns, if not nil, runs on a later submit, record, or flush,
+// once the work submitted before has been recorded.
+func (p *workerPool) submit(work func() func()) {
+	if p == nil {
+		if record := work(); record != nil {
+			record()
+		}
+		return
+	}
+	p.sem <- struct{}{}
+	done := make(chan func(), 1)
+	p.pending = append(p.pending, done)
+	go func() {
+		defer func() { <-p.sem }()
+		done <- work()
+	}()
+	p.flush(false)
+}
+
+// record queues an outcome without work, to be recorded after the work
+// submitted before it.
+func (p *workerPool) record(record func()) {
+	if p == nil {
+		record()
+		return
+	}
+	done := make(chan func(), 1)
+	done <- record
+	p.pending = append(p.pending, done)
+	p.flush(false)
+}
+
+// flush records the outcomes of finished work in submission order, stopping
+// at the first unfinished one. With wait, it waits for all submitted work and
+// records every outcome.
+func (p *workerPool) flush(wait bool) {
+	if p == nil {
+		return
+	}
+	for len(p.pending) > 0 {
+		var record func()
+		if wait {
+			record = <-p.pending[0]
+		} else {
+			select {
+			case record = <-p.pending[0]:
+			default:
+				return
+			}
+		}
+		p.pending = p.pending[1:]
+		if record != nil {
+			record()
+		}
+	}
+}
+
+// concurrency returns the number of goroutines a poll cycle evaluates and
+// publishes on: workers.concurrency, raised to clients.broker.batch.max_size
+// so that a publish batch can fill up while its publishes wait for it.
+func (s *Sentinel) concurrency() int {
+	n := 1
+	if w := s.config.Workers; w != nil {
+		n = w.Concurrency
+	}
+	if b := s.config.Clients.Broker; b != nil && b.Batch != nil {
+		n = max(n, b.Batch.MaxSize)
+	}
+	return n
+}
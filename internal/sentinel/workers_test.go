@@ -0,0 +1,161 @@
+package sentinel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/client/clienttest"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/publisher"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// lockedPublisher is a MockPublisher safe for publishing from workers.
+type lockedPublisher struct {
+	MockPublisher
+	mu sync.Mutex
+}
+
+func (p *lockedPublisher) Publish(ctx context.Context, topic string, event *cloudevents.Event) error {
+	// Let later resources finish first.
+	time.Sleep(time.Millisecond)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.MockPublisher.Publish(ctx, topic, event)
+}
+
+func TestWorkerPool_RecordsInOrder(t *testing.T) {
+	pool := newWorkerPool(4)
+	var recorded []int
+	for i := range 10 {
+		if i%3 == 0 {
+			pool.record(func() { recorded = append(recorded, i) })
+			continue
+		}
+		pool.submit(func() func() {
+			// Later work finishes first.
+			time.Sleep(time.Duration(10-i) * time.Millisecond)
+			return func() { recorded = append(recorded, i) }
+		})
+	}
+	pool.flush(true)
+
+	if len(recorded) != 10 {
+		t.Fatalf("Expected 10 recorded outcomes, got %v", recorded)
+	}
+	for i, got := range recorded {
+		if got != i {
+			t.Fatalf("Expected outcomes in submission order, got %v", recorded)
+		}
+	}
+}
+
+func TestWorkerPool_Nil(t *testing.T) {
+	if pool := newWorkerPool(1); pool != nil {
+		t.Fatal("Expected no pool for a concurrency of 1")
+	}
+
+	var pool *workerPool
+	recorded := 0
+	pool.submit(func() func() { return func() { recorded++ } })
+	pool.submit(func() func() { return nil })
+	pool.record(func() { recorded++ })
+	if recorded != 2 {
+		t.Errorf("Expected a nil pool to record right away, got %d outcomes", recorded)
+	}
+	pool.flush(true)
+}
+
+func TestTrigger_Workers(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	m := metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	var resources []client.Resource
+	for i := range 20 {
+		resource := reconciledResource(fmt.Sprintf("cluster-%02d", i), "True", 2)
+		if i%4 == 0 {
+			// Updated just now: skipped.
+			resource.Status.Conditions[0].LastUpdatedTime = time.Now()
+		}
+		resources = append(resources, resource)
+	}
+	cfg := newTestSentinelConfig()
+	cfg.Workers = &config.WorkersConfig{Concurrency: 4}
+	pub := &lockedPublisher{}
+	s, err := NewSentinel(cfg, &clienttest.Fetcher{Resources: resources}, newTestDecisionEngine(t), pub,
+		logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pub.publishedEvents) != 15 {
+		t.Fatalf("Expected 15 published events, got %d", len(pub.publishedEvents))
+	}
+	status := s.Status()
+	if status.LastCycle == nil || status.LastCycle.Published != 15 || status.LastCycle.Skipped != 5 {
+		t.Errorf("Expected 15 published and 5 skipped, got %+v", status.LastCycle)
+	}
+	labels := prometheus.Labels{"resource_type": "clusters", "resource_selector": "all"}
+	if got := testutil.ToFloat64(m.PendingResources.With(labels)); got != 15 {
+		t.Errorf("Expected pending_resources == 15, got %v", got)
+	}
+
+	// Pending resources are listed in resource order, whatever order the
+	// workers finished in.
+	if len(status.Pending) != 15 {
+		t.Fatalf("Expected 15 pending resources, got %d", len(status.Pending))
+	}
+	want := 1
+	for _, pending := range status.Pending {
+		if want%4 == 0 {
+			want++
+		}
+		if id := fmt.Sprintf("cluster-%02d", want); pending.ID != id {
+			t.Fatalf("Expected pending resource %s, got %s", id, pending.ID)
+		}
+		want++
+	}
+}
+
+func TestTrigger_BatchFillsWithoutWorkers(t *testing.T) {
+	metrics.ResetSentinelMetrics()
+	metrics.NewSentinelMetrics(prometheus.NewRegistry(), "test")
+
+	var resources []client.Resource
+	for i := range 10 {
+		resources = append(resources, reconciledResource(fmt.Sprintf("cluster-%02d", i), "True", 2))
+	}
+	cfg := newTestSentinelConfig()
+	batch := &config.BrokerBatchConfig{MaxSize: 5, FlushInterval: 10 * time.Second}
+	cfg.Clients.Broker.Batch = batch
+	pub := &lockedPublisher{}
+	s, err := NewSentinel(cfg, &clienttest.Fetcher{Resources: resources}, newTestDecisionEngine(t),
+		publisher.NewBatchPublisher(pub, batch, "clusters", "all"), logger.NewHyperFleetLogger())
+	if err != nil {
+		t.Fatalf("NewSentinel failed: %v", err)
+	}
+
+	// Batches only flush before their interval when max_size publishes are
+	// in flight at once.
+	start := time.Now()
+	if err := s.trigger(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= batch.FlushInterval {
+		t.Errorf("Expected full batches to flush right away, cycle took %s", elapsed)
+	}
+	if len(pub.publishedEvents) != 10 {
+		t.Errorf("Expected 10 published events, got %d", len(pub.publishedEvents))
+	}
+}
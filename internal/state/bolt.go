@@ -0,0 +1,111 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltBucket is the bucket holding the records.
+var boltBucket = []byte("publishes")
+
+// BoltStore keeps records in a BoltDB file, so they survive restarts of a
+// single replica. The file is locked while open, so replicas cannot share it.
+type BoltStore struct {
+	db  *bolt.DB
+	now func() time.Time
+	ttl time.Duration
+}
+
+var _ Store = (*BoltStore)(nil)
+
+// NewBoltStore opens or creates the BoltDB file at path, waiting up to timeout
+// for its lock, and drops records that expired while it was closed.
+func NewBoltStore(path string, ttl, timeout time.Duration) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: timeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state file %s: %w", path, err)
+	}
+	b := &BoltStore{db: db, now: time.Now, ttl: ttl}
+	if err := b.prune(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// prune creates the bucket if needed and deletes expired or unreadable
+// records.
+func (b *BoltStore) prune() error {
+	now := b.now()
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(boltBucket)
+		if err != nil {
+			return err
+		}
+		var stale [][]byte
+		err = bucket.ForEach(func(k, v []byte) error {
+			var rec Record
+			if json.Unmarshal(v, &rec) != nil || rec.expired(b.ttl, now) {
+				stale = append(stale, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range stale {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prune state file: %w", err)
+	}
+	return nil
+}
+
+// Get returns the record stored under key.
+func (b *BoltStore) Get(_ context.Context, key string) (Record, bool, error) {
+	var data []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		// Copy the value, which is only valid inside the transaction.
+		data = append(data, tx.Bucket(boltBucket).Get([]byte(key))...)
+		return nil
+	})
+	if err != nil {
+		return Record{}, false, err
+	}
+	if data == nil {
+		return Record{}, false, nil
+	}
+	var rec Record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return Record{}, false, fmt.Errorf("failed to decode record %q: %w", key, err)
+	}
+	if rec.expired(b.ttl, b.now()) {
+		return Record{}, false, nil
+	}
+	return rec, true, nil
+}
+
+// Put stores rec under key.
+func (b *BoltStore) Put(_ context.Context, key string, rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode record %q: %w", key, err)
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Put([]byte(key), data)
+	})
+}
+
+// Close closes the file.
+func (b *BoltStore) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,69 @@
+package state
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memorySweepInterval is the number of puts between sweeps of expired records.
+const memorySweepInterval = 1024
+
+// MemoryStore keeps records in memory. It survives neither restarts nor
+// replica changes, and is the default when no other store is configured.
+type MemoryStore struct {
+	records map[string]Record
+	now     func() time.Time
+	ttl     time.Duration
+	puts    int
+	mu      sync.Mutex
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty in-memory store whose records expire after
+// ttl.
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]Record),
+		now:     time.Now,
+		ttl:     ttl,
+	}
+}
+
+// Get returns the record stored under key.
+func (m *MemoryStore) Get(_ context.Context, key string) (Record, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec, ok := m.records[key]
+	if !ok {
+		return Record{}, false, nil
+	}
+	if rec.expired(m.ttl, m.now()) {
+		delete(m.records, key)
+		return Record{}, false, nil
+	}
+	return rec, true, nil
+}
+
+// Put stores rec under key.
+func (m *MemoryStore) Put(_ context.Context, key string, rec Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[key] = rec
+	m.puts++
+	if m.puts%memorySweepInterval == 0 {
+		now := m.now()
+		for k, r := range m.records {
+			if r.expired(m.ttl, now) {
+				delete(m.records, k)
+			}
+		}
+	}
+	return nil
+}
+
+// Close is a no-op.
+func (m *MemoryStore) Close() error {
+	return nil
+}
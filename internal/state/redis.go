@@ -0,0 +1,323 @@
+package state
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultRedisPort is used when the Redis URL has no port.
+const defaultRedisPort = "6379"
+
+// maxRedisReplySize bounds a bulk reply read from Redis.
+const maxRedisReplySize = 1 << 20
+
+// errRedisNil is returned by do for a nil bulk reply, i.e. a missing key.
+var errRedisNil = errors.New("redis: nil reply")
+
+// redisError is an error reply from Redis.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// redisPoolSize bounds the connections a RedisStore opens at once, and so the
+// store operations in flight; further operations wait for a connection.
+const redisPoolSize = 8
+
+// RedisStore keeps records in Redis as JSON strings that expire with the TTL,
+// so that replicas sharing the Redis server share the records. It speaks the
+// Redis protocol over a pool of up to redisPoolSize connections, so that the
+// workers of a cycle do not wait for each other. A connection that fails is
+// discarded, and a command that fails on an idle connection, which the server
+// or the network may have closed since its last use, is retried once on a new
+// one.
+type RedisStore struct {
+	dial      func(ctx context.Context) (net.Conn, error)
+	now       func() time.Time
+	conns     chan struct{}
+	username  string
+	password  string
+	prefix    string
+	idle      []*redisConn
+	db        int
+	ttl       time.Duration
+	timeout   time.Duration
+	mu        sync.Mutex
+	hasSelect bool
+	closed    bool
+}
+
+// redisConn is one connection to Redis.
+type redisConn struct {
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// NewRedisStore creates a store for the Redis server at rawURL, of the form
+// redis://[user[:password]@]host[:port][/db], or rediss:// for TLS. Keys are
+// prefixed with prefix and a colon when prefix is set. Connections are made
+// on first use.
+func NewRedisStore(rawURL, prefix string, ttl, timeout time.Duration) (*RedisStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Host == "" {
+		return nil, fmt.Errorf("invalid redis URL %q", redactedURL(rawURL))
+	}
+
+	r := &RedisStore{
+		now:     time.Now,
+		conns:   make(chan struct{}, redisPoolSize),
+		ttl:     ttl,
+		timeout: timeout,
+	}
+	if prefix != "" {
+		r.prefix = prefix + ":"
+	}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			r.username, r.password = u.User.Username(), password
+		} else {
+			// redis://password@host is the common short form.
+			r.password = u.User.Username()
+		}
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		r.db, err = strconv.Atoi(db)
+		if err != nil || r.db < 0 {
+			return nil, fmt.Errorf("invalid redis database %q", db)
+		}
+		r.hasSelect = r.db != 0
+	}
+
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), defaultRedisPort)
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	if u.Scheme == "rediss" {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{
+			ServerName: u.Hostname(),
+			MinVersion: tls.VersionTLS12,
+		}}
+		r.dial = func(ctx context.Context) (net.Conn, error) {
+			return tlsDialer.DialContext(ctx, "tcp", addr)
+		}
+	} else {
+		r.dial = func(ctx context.Context) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp", addr)
+		}
+	}
+	return r, nil
+}
+
+// Get returns the record stored under key.
+func (r *RedisStore) Get(ctx context.Context, key string) (Record, bool, error) {
+	data, err := r.do(ctx, "GET", r.prefix+key)
+	if errors.Is(err, errRedisNil) {
+		return Record{}, false, nil
+	}
+	if err != nil {
+		return Record{}, false, err
+	}
+	var rec Record
+	if err := json.Unmarshal([]byte(data), &rec); err != nil {
+		return Record{}, false, fmt.Errorf("failed to decode record %q: %w", key, err)
+	}
+	if rec.expired(r.ttl, r.now()) {
+		return Record{}, false, nil
+	}
+	return rec, true, nil
+}
+
+// Put stores rec under key until the TTL has passed since its publish.
+func (r *RedisStore) Put(ctx context.Context, key string, rec Record) error {
+	remaining := rec.PublishedAt.Add(r.ttl).Sub(r.now())
+	if remaining < time.Millisecond {
+		return nil
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode record %q: %w", key, err)
+	}
+	_, err = r.do(ctx, "SET", r.prefix+key, string(data), "PX", strconv.FormatInt(remaining.Milliseconds(), 10))
+	return err
+}
+
+// Close closes the idle connections. Connections in use are closed when
+// their command completes.
+func (r *RedisStore) Close() error {
+	r.mu.Lock()
+	idle := r.idle
+	r.idle, r.closed = nil, true
+	r.mu.Unlock()
+
+	var errs []error
+	for _, c := range idle {
+		errs = append(errs, c.conn.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// do sends one command and returns its reply on a connection of the pool. A
+// connection is discarded after any error other than an error reply; when
+// it was idle before the command, the command is retried on a new
+// connection, as GET and SET can safely be sent twice.
+func (r *RedisStore) do(ctx context.Context, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	select {
+	case r.conns <- struct{}{}:
+		defer func() { <-r.conns }()
+	case <-ctx.Done():
+		return "", fmt.Errorf("waiting for a redis connection: %w", ctx.Err())
+	}
+
+	for {
+		c, reused, err := r.get(ctx)
+		if err != nil {
+			return "", err
+		}
+		reply, err := c.roundTrip(ctx, args)
+		var replyErr redisError
+		if err == nil || errors.Is(err, errRedisNil) || errors.As(err, &replyErr) {
+			r.put(c)
+			return reply, err
+		}
+		_ = c.conn.Close()
+		if !reused || ctx.Err() != nil || errors.Is(err, os.ErrDeadlineExceeded) {
+			return "", err
+		}
+	}
+}
+
+// get returns an idle connection, with reused set, or a new one.
+func (r *RedisStore) get(ctx context.Context) (c *redisConn, reused bool, err error) {
+	r.mu.Lock()
+	if n := len(r.idle); n > 0 {
+		c = r.idle[n-1]
+		r.idle = r.idle[:n-1]
+	}
+	r.mu.Unlock()
+	if c != nil {
+		return c, true, nil
+	}
+	c, err = r.connect(ctx)
+	return c, false, err
+}
+
+// put returns c to the idle connections, or closes it once the store is
+// closed.
+func (r *RedisStore) put(c *redisConn) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		_ = c.conn.Close()
+		return
+	}
+	r.idle = append(r.idle, c)
+}
+
+// connect dials the server, authenticates, and selects the database.
+func (r *RedisStore) connect(ctx context.Context) (*redisConn, error) {
+	conn, err := r.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	c := &redisConn{conn: conn, rd: bufio.NewReader(conn)}
+
+	var setup [][]string
+	switch {
+	case r.username != "":
+		setup = append(setup, []string{"AUTH", r.username, r.password})
+	case r.password != "":
+		setup = append(setup, []string{"AUTH", r.password})
+	}
+	if r.hasSelect {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(r.db)})
+	}
+	for _, args := range setup {
+		if _, err := c.roundTrip(ctx, args); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to %s on redis: %w", strings.ToLower(args[0]), err)
+		}
+	}
+	return c, nil
+}
+
+// roundTrip writes a command as an array of bulk strings and reads the reply.
+func (c *redisConn) roundTrip(ctx context.Context, args []string) (string, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := c.conn.SetDeadline(deadline); err != nil {
+			return "", err
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return "", err
+	}
+	return c.readReply()
+}
+
+// readReply reads a simple string, error, integer, or bulk string reply.
+func (c *redisConn) readReply() (string, error) {
+	line, err := c.rd.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return "", fmt.Errorf("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", redisError(line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n > maxRedisReplySize {
+			return "", fmt.Errorf("redis: invalid bulk length %q", line[1:])
+		}
+		if n < 0 {
+			return "", errRedisNil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(c.rd, buf); err != nil {
+			return "", err
+		}
+		return string(buf[:n]), nil
+	default:
+		return "", fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
+
+// redactedURL returns raw with its password replaced, or a placeholder when it
+// does not parse.
+func redactedURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "xxxxx"
+	}
+	return u.Redacted()
+}
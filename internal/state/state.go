@@ -0,0 +1,74 @@
+// Package state persists the last publish of each resource, so that
+// publish-history features such as the republish backoff and the dedup window
+// survive restarts and can be shared by replicas.
+package state
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+)
+
+// Defaults used when state_store leaves ttl, timeout, or key_prefix unset.
+const (
+	DefaultTTL       = 24 * time.Hour
+	DefaultTimeout   = 2 * time.Second
+	DefaultKeyPrefix = "hyperfleet-sentinel"
+)
+
+// Record is the last publish of one resource.
+type Record struct {
+	PublishedAt time.Time `json:"published_at"`
+	Reason      string    `json:"reason"`
+	// BackoffDelay is the republish backoff delay after the publish, or 0
+	// without republish_backoff.
+	BackoffDelay time.Duration `json:"backoff_delay,omitempty"`
+	Generation   int32         `json:"generation"`
+}
+
+// expired reports whether the record is older than ttl at now.
+func (r Record) expired(ttl time.Duration, now time.Time) bool {
+	return !now.Before(r.PublishedAt.Add(ttl))
+}
+
+// Store reads and writes publish records by key. Implementations are safe for
+// concurrent use. Records expire once the store's TTL has passed since their
+// publish.
+type Store interface {
+	// Get returns the record stored under key; ok is false if there is none
+	// or it expired.
+	Get(ctx context.Context, key string) (rec Record, ok bool, err error)
+	// Put stores rec under key, replacing any previous record.
+	Put(ctx context.Context, key string, rec Record) error
+	// Close releases the store's connections or files.
+	Close() error
+}
+
+// New creates the store configured by cfg.
+func New(cfg *config.StateStoreConfig) (Store, error) {
+	ttl := cfg.TTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	switch cfg.StoreType() {
+	case config.StateStoreMemory:
+		return NewMemoryStore(ttl), nil
+	case config.StateStoreRedis:
+		prefix := cfg.KeyPrefix
+		if prefix == "" {
+			prefix = DefaultKeyPrefix
+		}
+		return NewRedisStore(cfg.URL, prefix, ttl, timeout)
+	case config.StateStoreBolt:
+		return NewBoltStore(cfg.Path, ttl, timeout)
+	default:
+		return nil, fmt.Errorf("unknown state store type %q", cfg.Type)
+	}
+}
@@ -0,0 +1,413 @@
+package state
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *config.StateStoreConfig
+		want    string
+		wantErr bool
+	}{
+		{name: "default", cfg: &config.StateStoreConfig{}, want: "*state.MemoryStore"},
+		{name: "memory", cfg: &config.StateStoreConfig{Type: config.StateStoreMemory}, want: "*state.MemoryStore"},
+		{
+			name: "redis",
+			cfg:  &config.StateStoreConfig{Type: config.StateStoreRedis, URL: "redis://localhost:6379/0"},
+			want: "*state.RedisStore",
+		},
+		{
+			name: "bolt",
+			cfg:  &config.StateStoreConfig{Type: config.StateStoreBolt, Path: filepath.Join(t.TempDir(), "state.db")},
+			want: "*state.BoltStore",
+		},
+		{name: "unknown", cfg: &config.StateStoreConfig{Type: "etcd"}, wantErr: true},
+		{
+			name:    "invalid redis URL",
+			cfg:     &config.StateStoreConfig{Type: config.StateStoreRedis, URL: "http://localhost"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := New(tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer store.Close() //nolint:errcheck // test cleanup
+			if got := fmt.Sprintf("%T", store); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// testStore runs the checks every Store must pass. setNow sets the store's clock.
+func testStore(t *testing.T, store Store, setNow func(time.Time)) {
+	t.Helper()
+	ctx := context.Background()
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	setNow(start)
+
+	if _, ok, err := store.Get(ctx, "clusters/c1"); err != nil || ok {
+		t.Fatalf("expected no record, got ok=%v err=%v", ok, err)
+	}
+
+	want := Record{PublishedAt: start, Reason: "message decision matched", BackoffDelay: time.Minute, Generation: 3}
+	if err := store.Put(ctx, "clusters/c1", want); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	got, ok, err := store.Get(ctx, "clusters/c1")
+	if err != nil || !ok {
+		t.Fatalf("expected the record, got ok=%v err=%v", ok, err)
+	}
+	if !got.PublishedAt.Equal(want.PublishedAt) || got.Reason != want.Reason ||
+		got.BackoffDelay != want.BackoffDelay || got.Generation != want.Generation {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if _, ok, _ := store.Get(ctx, "clusters/c2"); ok {
+		t.Error("expected no record for another key")
+	}
+
+	setNow(start.Add(time.Hour))
+	if _, ok, err := store.Get(ctx, "clusters/c1"); err != nil || ok {
+		t.Errorf("expected the record to expire after the TTL, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore(time.Hour)
+	testStore(t, store, func(now time.Time) { store.now = func() time.Time { return now } })
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now()
+	store := NewMemoryStore(time.Minute)
+	store.now = func() time.Time { return start }
+
+	for i := range memorySweepInterval - 1 {
+		_ = store.Put(ctx, strconv.Itoa(i), Record{PublishedAt: start.Add(-time.Hour)})
+	}
+	_ = store.Put(ctx, "fresh", Record{PublishedAt: start})
+
+	if len(store.records) != 1 {
+		t.Errorf("expected expired records to be swept, got %d records", len(store.records))
+	}
+}
+
+func TestBoltStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	store, err := NewBoltStore(path, time.Hour, time.Second)
+	if err != nil {
+		t.Fatalf("NewBoltStore failed: %v", err)
+	}
+	defer store.Close() //nolint:errcheck // test cleanup
+	testStore(t, store, func(now time.Time) { store.now = func() time.Time { return now } })
+}
+
+func TestBoltStore_Reopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state.db")
+	now := time.Now()
+
+	store, err := NewBoltStore(path, time.Hour, time.Second)
+	if err != nil {
+		t.Fatalf("NewBoltStore failed: %v", err)
+	}
+	_ = store.Put(ctx, "fresh", Record{PublishedAt: now, Generation: 2})
+	_ = store.Put(ctx, "stale", Record{PublishedAt: now.Add(-2 * time.Hour)})
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	store, err = NewBoltStore(path, time.Hour, time.Second)
+	if err != nil {
+		t.Fatalf("NewBoltStore failed on reopen: %v", err)
+	}
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	if rec, ok, err := store.Get(ctx, "fresh"); err != nil || !ok || rec.Generation != 2 {
+		t.Errorf("expected the record to survive a reopen, got %+v ok=%v err=%v", rec, ok, err)
+	}
+	if _, ok, _ := store.Get(ctx, "stale"); ok {
+		t.Error("expected the expired record to be pruned on reopen")
+	}
+}
+
+func TestBoltStore_Locked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	store, err := NewBoltStore(path, time.Hour, time.Second)
+	if err != nil {
+		t.Fatalf("NewBoltStore failed: %v", err)
+	}
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	if _, err := NewBoltStore(path, time.Hour, 50*time.Millisecond); err == nil {
+		t.Error("expected opening a locked file to time out")
+	}
+}
+
+// fakeRedis is a Redis server that understands AUTH, SELECT, GET, and SET
+// with PX, enough for RedisStore.
+type fakeRedis struct {
+	ln       net.Listener
+	data     map[string]string
+	ttls     map[string]string
+	conns    map[net.Conn]bool
+	commands []string
+	password string
+	mu       sync.Mutex
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	f := &fakeRedis{
+		ln:       ln,
+		data:     make(map[string]string),
+		ttls:     make(map[string]string),
+		conns:    make(map[net.Conn]bool),
+		password: password,
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.conns[conn] = true
+			f.mu.Unlock()
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+// dropConns closes the connections of the clients, as a Redis restart or a
+// failover does, and returns how many there were.
+func (f *fakeRedis) dropConns() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := len(f.conns)
+	for conn := range f.conns {
+		_ = conn.Close()
+		delete(f.conns, conn)
+	}
+	return n
+}
+
+func (f *fakeRedis) addr() string {
+	return f.ln.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer func() {
+		f.mu.Lock()
+		delete(f.conns, conn)
+		f.mu.Unlock()
+		_ = conn.Close()
+	}()
+	rd := bufio.NewReader(conn)
+	authed := f.password == ""
+	for {
+		args, err := readCommand(rd)
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.commands = append(f.commands, args[0])
+		var reply string
+		switch {
+		case args[0] == "AUTH":
+			if args[len(args)-1] == f.password {
+				authed = true
+				reply = "+OK\r\n"
+			} else {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authed:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case args[0] == "SELECT":
+			reply = "+OK\r\n"
+		case args[0] == "GET":
+			if v, ok := f.data[args[1]]; ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case args[0] == "SET" && len(args) == 5 && args[3] == "PX":
+			f.data[args[1]] = args[2]
+			f.ttls[args[1]] = args[4]
+			reply = "+OK\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		f.mu.Unlock()
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+// readCommand reads one command sent as an array of bulk strings.
+func readCommand(rd *bufio.Reader) ([]string, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if _, err := rd.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := rd.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+func TestRedisStore(t *testing.T) {
+	f := newFakeRedis(t, "")
+	store, err := NewRedisStore("redis://"+f.addr(), "", time.Hour, time.Second)
+	if err != nil {
+		t.Fatalf("NewRedisStore failed: %v", err)
+	}
+	defer store.Close() //nolint:errcheck // test cleanup
+	testStore(t, store, func(now time.Time) { store.now = func() time.Time { return now } })
+}
+
+func TestRedisStore_PrefixAndTTL(t *testing.T) {
+	f := newFakeRedis(t, "secret")
+	store, err := NewRedisStore("redis://:secret@"+f.addr()+"/2", "sentinel", time.Hour, time.Second)
+	if err != nil {
+		t.Fatalf("NewRedisStore failed: %v", err)
+	}
+	defer store.Close() //nolint:errcheck // test cleanup
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	if err := store.Put(context.Background(), "clusters/c1", Record{PublishedAt: now.Add(-30 * time.Minute)}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	// Expired records are not written at all.
+	if err := store.Put(context.Background(), "clusters/c2", Record{PublishedAt: now.Add(-2 * time.Hour)}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if got := strings.Join(f.commands, ","); got != "AUTH,SELECT,SET" {
+		t.Errorf("expected AUTH,SELECT,SET, got %s", got)
+	}
+	if _, ok := f.data["sentinel:clusters/c1"]; !ok {
+		t.Errorf("expected the key to be prefixed, got %v", f.data)
+	}
+	if got := f.ttls["sentinel:clusters/c1"]; got != strconv.FormatInt((30*time.Minute).Milliseconds(), 10) {
+		t.Errorf("expected the key to expire with the record, got PX %s", got)
+	}
+}
+
+func TestRedisStore_ConnectionDropped(t *testing.T) {
+	f := newFakeRedis(t, "secret")
+	store, err := NewRedisStore("redis://:secret@"+f.addr(), "", time.Hour, time.Second)
+	if err != nil {
+		t.Fatalf("NewRedisStore failed: %v", err)
+	}
+	defer store.Close() //nolint:errcheck // test cleanup
+	ctx := context.Background()
+	now := time.Now()
+
+	// The workers of a cycle share the pool.
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*redisPoolSize)
+	for i := range 2 * redisPoolSize {
+		wg.Go(func() {
+			errs <- store.Put(ctx, fmt.Sprintf("clusters/c%d", i), Record{PublishedAt: now, Reason: "max age"})
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	// The server drops every connection in the middle of the cycle.
+	if n := f.dropConns(); n == 0 || n > redisPoolSize {
+		t.Fatalf("expected between 1 and %d connections, got %d", redisPoolSize, n)
+	}
+
+	rec, ok, err := store.Get(ctx, "clusters/c0")
+	if err != nil || !ok || rec.Reason != "max age" {
+		t.Fatalf("expected the record after reconnecting, got %+v, %t, %v", rec, ok, err)
+	}
+	if err := store.Put(ctx, "clusters/c1", Record{PublishedAt: now, Reason: "generation changed"}); err != nil {
+		t.Fatalf("Put after reconnecting failed: %v", err)
+	}
+	if rec, _, _ := store.Get(ctx, "clusters/c1"); rec.Reason != "generation changed" {
+		t.Errorf("expected the record written after reconnecting, got %+v", rec)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.conns) != 1 {
+		t.Errorf("expected the store to reconnect once, got %d connections", len(f.conns))
+	}
+}
+
+func TestRedisStore_Errors(t *testing.T) {
+	f := newFakeRedis(t, "secret")
+	store, err := NewRedisStore("redis://:wrong@"+f.addr(), "", time.Hour, time.Second)
+	if err != nil {
+		t.Fatalf("NewRedisStore failed: %v", err)
+	}
+	defer store.Close() //nolint:errcheck // test cleanup
+	if _, _, err := store.Get(context.Background(), "clusters/c1"); err == nil {
+		t.Error("expected a failed AUTH to be returned")
+	}
+
+	addr := f.addr()
+	_ = f.ln.Close()
+	store, err = NewRedisStore("redis://"+addr, "", time.Hour, time.Second)
+	if err != nil {
+		t.Fatalf("NewRedisStore failed: %v", err)
+	}
+	defer store.Close() //nolint:errcheck // test cleanup
+	if err := store.Put(context.Background(), "clusters/c1", Record{PublishedAt: time.Now()}); err == nil {
+		t.Error("expected an unreachable server to be returned")
+	}
+}
@@ -0,0 +1,130 @@
+// Package statusui serves a read-only HTML status page for the Sentinel, so
+// that engineers without access to Grafana can check a Sentinel's health
+// through a port-forward to its admin server.
+//
+// The page is a single server-rendered document without scripts or external
+// assets. It shows the last poll cycle, the resources pending reconciliation,
+// a summary of the configuration, and the latest publish results, and reloads
+// itself every refreshSeconds seconds.
+package statusui
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/metrics"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/sentinel"
+)
+
+// Path is where the admin server serves the status page.
+const Path = "/ui"
+
+// refreshSeconds is the reload interval of the page.
+const refreshSeconds = 10
+
+//go:embed status.html
+var statusHTML string
+
+var statusTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"since": func(now, t time.Time) string {
+		if t.IsZero() {
+			return "never"
+		}
+		return now.Sub(t).Truncate(time.Second).String() + " ago"
+	},
+	"timestamp": func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339)
+	},
+	"seconds": func(d time.Duration) string {
+		return fmt.Sprintf("%.3fs", d.Seconds())
+	},
+}).Parse(statusHTML))
+
+// setting is one row of the configuration summary.
+type setting struct {
+	Name  string
+	Value string
+}
+
+// page is the data rendered by status.html.
+type page struct {
+	Now            time.Time
+	Title          string
+	Config         []setting
+	Status         sentinel.Status
+	RefreshSeconds int
+}
+
+// Handler returns the handler for the status page. status is called on every
+// request for a fresh snapshot.
+func Handler(cfg *config.SentinelConfig, status func() sentinel.Status) http.Handler {
+	settings := summarize(cfg)
+	title := fmt.Sprintf("Sentinel %s (%s)", cfg.Sentinel.Name, cfg.ResourceType)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		err := statusTemplate.Execute(&buf, page{
+			Now:            time.Now(),
+			Title:          title,
+			Config:         settings,
+			Status:         status(),
+			RefreshSeconds: refreshSeconds,
+		})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to render status page: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+		_, _ = w.Write(buf.Bytes()) //nolint:errcheck // client may have gone away; nothing left to do
+	})
+}
+
+// summarize lists the settings that describe what the Sentinel watches and
+// where it publishes. Credentials and file paths are left out.
+func summarize(cfg *config.SentinelConfig) []setting {
+	settings := []setting{
+		{Name: "Sentinel", Value: cfg.Sentinel.Name},
+		{Name: "Resource type", Value: cfg.ResourceType},
+		{Name: "Resource selector", Value: metrics.GetResourceSelectorLabel(cfg.ResourceSelector)},
+		{Name: "Poll interval", Value: cfg.PollInterval.String()},
+	}
+	if a := cfg.AdaptiveInterval; a != nil {
+		settings[len(settings)-1].Value += fmt.Sprintf(" (adaptive, %s to %s)", a.Min, a.Max)
+	}
+
+	if api := cfg.Clients.HyperFleetAPI; api != nil {
+		if len(api.Regions) > 0 {
+			regions := make([]string, 0, len(api.Regions))
+			for _, region := range api.Regions {
+				regions = append(regions, fmt.Sprintf("%s: %s → %s", region.Name, region.BaseURL, region.Topic))
+			}
+			settings = append(settings, setting{Name: "Regions", Value: strings.Join(regions, ", ")})
+		} else {
+			settings = append(settings, setting{Name: "HyperFleet API", Value: api.BaseURL})
+		}
+		settings = append(settings, setting{Name: "API version", Value: api.Version})
+	}
+	if broker := cfg.Clients.Broker; broker != nil && broker.Topic != "" {
+		settings = append(settings, setting{Name: "Topic", Value: broker.Topic})
+	}
+
+	incremental := "disabled"
+	if cfg.IncrementalFetch != nil {
+		incremental = "full list every " + cfg.IncrementalFetch.FullListInterval.String()
+	}
+	settings = append(settings, setting{Name: "Incremental fetch", Value: incremental})
+
+	evalCache := "disabled"
+	if cfg.EvaluationCache != nil {
+		evalCache = "revalidate after " + cfg.EvaluationCache.RevalidateAfter.String()
+	}
+	return append(settings, setting{Name: "Evaluation cache", Value: evalCache})
+}
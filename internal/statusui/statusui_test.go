@@ -0,0 +1,114 @@
+package statusui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/config"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/decisionstream"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/internal/sentinel"
+)
+
+func newTestConfig() *config.SentinelConfig {
+	cfg := config.NewSentinelConfig()
+	cfg.Sentinel.Name = "clusters-sentinel"
+	cfg.ResourceType = "clusters"
+	cfg.ResourceSelector = config.LabelSelectorList{{Label: "shard", Value: "1"}}
+	cfg.Clients.HyperFleetAPI.BaseURL = "http://hyperfleet-api:8000"
+	cfg.Clients.Broker.Topic = "clusters-topic"
+	return cfg
+}
+
+func get(t *testing.T, h http.Handler) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	started := time.Now().Add(-time.Minute)
+	status := sentinel.Status{
+		LastSuccessfulPoll: started,
+		LastCycle: &sentinel.CycleStatus{
+			Started:   started,
+			OpID:      "op-123",
+			Duration:  1500 * time.Millisecond,
+			Total:     3,
+			Published: 1,
+			Skipped:   2,
+			Pending:   1,
+		},
+		Pending:      []sentinel.PendingResource{{ID: "cluster-1", Kind: "Cluster", Reason: "generation changed"}},
+		PendingTotal: 1,
+		RecentEvents: []decisionstream.Event{{
+			Time:       started,
+			Type:       decisionstream.TypePublishFailed,
+			ResourceID: "cluster-2",
+			Kind:       "Cluster",
+			Topic:      "clusters-topic",
+			Error:      "broker <unavailable>",
+		}},
+		Paused: true,
+	}
+
+	rec := get(t, Handler(newTestConfig(), func() sentinel.Status { return status }))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Expected an HTML content type, got %q", ct)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"Sentinel clusters-sentinel (clusters)",
+		"op-123",
+		"1.500s",
+		"cluster-1",
+		"generation changed",
+		"publish_failed",
+		"broker &lt;unavailable&gt;",
+		"shard:1",
+		"http://hyperfleet-api:8000",
+		"clusters-topic",
+		"paused",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected status page to contain %q", want)
+		}
+	}
+}
+
+func TestHandler_NoCycleYet(t *testing.T) {
+	rec := get(t, Handler(newTestConfig(), func() sentinel.Status { return sentinel.Status{} }))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"never", "No poll cycle has completed yet", "No events published since startup"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected status page to contain %q", want)
+		}
+	}
+}
+
+func TestSummarize_Regions(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Clients.HyperFleetAPI.BaseURL = ""
+	cfg.Clients.HyperFleetAPI.Regions = []config.HyperFleetAPIRegionConfig{
+		{Name: "us-east", BaseURL: "http://east:8000", Topic: "clusters-us-east"},
+	}
+
+	for _, s := range summarize(cfg) {
+		if s.Name == "Regions" {
+			if want := "us-east: http://east:8000 → clusters-us-east"; s.Value != want {
+				t.Errorf("Regions = %q, want %q", s.Value, want)
+			}
+			return
+		}
+	}
+	t.Error("Expected a Regions setting")
+}
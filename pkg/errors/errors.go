@@ -0,0 +1,124 @@
+// Package errors defines the error returned for failed HyperFleet API requests
+// and helpers to classify it.
+//
+// An APIError carries the request method and path, the number of attempts made
+// including retries, and the start of the response body, so its Error string is
+// enough to diagnose a failed fetch from a single log line. The helpers look
+// through wrapped errors, so callers can pass the error returned by the client
+// unchanged:
+//
+//	if errors.IsRateLimited(err) { ... }
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrResponseTooLarge is the cause of an APIError for a response that exceeded
+// the client's response size limits.
+var ErrResponseTooLarge = stderrors.New("response too large")
+
+// MaxResponseSnippet is the maximum number of response body bytes kept in
+// APIError.ResponseSnippet.
+const MaxResponseSnippet = 256
+
+// APIError describes a failed HyperFleet API request.
+type APIError struct {
+	// Cause is the underlying error, if any. It is returned by Unwrap.
+	Cause error
+	// Method is the HTTP method of the request, e.g. "GET".
+	Method string
+	// Path is the request path and query, without scheme and host.
+	Path    string
+	Message string
+	// ResponseSnippet holds up to MaxResponseSnippet bytes of the response
+	// body of an error status.
+	ResponseSnippet string
+	// RetryAfter is the delay requested by the Retry-After header of a 429 or
+	// 503 response, or 0 if the header was absent or invalid.
+	RetryAfter time.Duration
+	// StatusCode is the HTTP status code, or 0 if no response was received.
+	StatusCode int
+	// Attempts is the number of attempts made, including retries. It is 0 for
+	// errors that were not returned through the retry loop.
+	Attempts  int
+	Retriable bool
+}
+
+// Error formats the error as
+// "GET /api/hyperfleet/v1/clusters?page=1: API error (status 503): <message>: <snippet> (3 attempts)",
+// omitting the parts that are not set.
+func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.Method != "" || e.Path != "" {
+		fmt.Fprintf(&b, "%s %s: ", e.Method, e.Path)
+	}
+	if e.StatusCode > 0 {
+		fmt.Fprintf(&b, "API error (status %d): ", e.StatusCode)
+	}
+	b.WriteString(e.Message)
+	if e.ResponseSnippet != "" {
+		fmt.Fprintf(&b, ": %q", e.ResponseSnippet)
+	}
+	if e.RetryAfter > 0 {
+		fmt.Fprintf(&b, " (retry after %s)", e.RetryAfter)
+	}
+	if e.Attempts > 1 {
+		fmt.Fprintf(&b, " (%d attempts)", e.Attempts)
+	}
+	return b.String()
+}
+
+// Unwrap returns Cause.
+func (e *APIError) Unwrap() error { return e.Cause }
+
+// AsAPIError returns the first *APIError in err's chain.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if stderrors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// IsRetriable reports whether err's chain holds an APIError that may succeed
+// when retried, such as a network error or a 5xx, 408, or 429 response.
+func IsRetriable(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.Retriable
+}
+
+// IsNotFound reports whether err's chain holds an APIError for a 404 response.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsRateLimited reports whether err's chain holds an APIError for a 429 response.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// RetryAfter returns the delay requested by the server for the APIError in
+// err's chain. ok is false if no delay was requested.
+func RetryAfter(err error) (delay time.Duration, ok bool) {
+	apiErr, found := AsAPIError(err)
+	if !found || apiErr.RetryAfter <= 0 {
+		return 0, false
+	}
+	return apiErr.RetryAfter, true
+}
+
+// IsResponseTooLarge reports whether err's chain holds ErrResponseTooLarge,
+// i.e. a response exceeded the client's body size or item count limit.
+func IsResponseTooLarge(err error) bool {
+	return stderrors.Is(err, ErrResponseTooLarge)
+}
+
+func hasStatus(err error, statusCode int) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == statusCode
+}
@@ -0,0 +1,118 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		err  APIError
+	}{
+		{
+			name: "message only",
+			err:  APIError{Message: "request timeout"},
+			want: "request timeout",
+		},
+		{
+			name: "full metadata",
+			err: APIError{
+				Method:          http.MethodGet,
+				Path:            "/api/hyperfleet/v1/clusters?page=1&size=100",
+				StatusCode:      http.StatusServiceUnavailable,
+				Message:         "API request failed with status 503",
+				ResponseSnippet: "upstream unavailable",
+				Attempts:        4,
+			},
+			want: `GET /api/hyperfleet/v1/clusters?page=1&size=100: API error (status 503): ` +
+				`API request failed with status 503: "upstream unavailable" (4 attempts)`,
+		},
+		{
+			name: "retry after",
+			err: APIError{
+				StatusCode: http.StatusTooManyRequests,
+				Message:    "API request failed with status 429",
+				RetryAfter: 5 * time.Second,
+				Attempts:   2,
+			},
+			want: "API error (status 429): API request failed with status 429 (retry after 5s) (2 attempts)",
+		},
+		{
+			name: "single attempt is not reported",
+			err:  APIError{StatusCode: http.StatusNotFound, Message: "not found", Attempts: 1},
+			want: "API error (status 404): not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	cause := stderrors.New("token file missing")
+	notFound := fmt.Errorf("failed to get clusters/x: %w", &APIError{StatusCode: http.StatusNotFound})
+	rateLimited := fmt.Errorf("failed to fetch clusters: %w",
+		&APIError{StatusCode: http.StatusTooManyRequests, Retriable: true})
+	wrapped := &APIError{Message: "auth", Cause: cause}
+	tooLarge := fmt.Errorf("failed to fetch clusters: %w", &APIError{Message: "too big", Cause: ErrResponseTooLarge})
+
+	tests := []struct {
+		err                                        error
+		name                                       string
+		retriable, notFound, rateLimited, tooLarge bool
+	}{
+		{name: "nil", err: nil},
+		{name: "plain error", err: cause},
+		{name: "not found", err: notFound, notFound: true},
+		{name: "rate limited", err: rateLimited, retriable: true, rateLimited: true},
+		{name: "with cause", err: wrapped},
+		{name: "response too large", err: tooLarge, tooLarge: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetriable(tt.err); got != tt.retriable {
+				t.Errorf("IsRetriable() = %v, want %v", got, tt.retriable)
+			}
+			if got := IsNotFound(tt.err); got != tt.notFound {
+				t.Errorf("IsNotFound() = %v, want %v", got, tt.notFound)
+			}
+			if got := IsRateLimited(tt.err); got != tt.rateLimited {
+				t.Errorf("IsRateLimited() = %v, want %v", got, tt.rateLimited)
+			}
+			if got := IsResponseTooLarge(tt.err); got != tt.tooLarge {
+				t.Errorf("IsResponseTooLarge() = %v, want %v", got, tt.tooLarge)
+			}
+		})
+	}
+
+	if !stderrors.Is(wrapped, cause) {
+		t.Error("expected errors.Is to find the cause through Unwrap")
+	}
+	if apiErr, ok := AsAPIError(notFound); !ok || !strings.Contains(apiErr.Error(), "404") {
+		t.Errorf("AsAPIError() = %v, %v", apiErr, ok)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	throttled := fmt.Errorf("failed to fetch clusters: %w",
+		&APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 3 * time.Second})
+	if delay, ok := RetryAfter(throttled); !ok || delay != 3*time.Second {
+		t.Errorf("RetryAfter() = %s, %v, want 3s, true", delay, ok)
+	}
+	if _, ok := RetryAfter(&APIError{StatusCode: http.StatusTooManyRequests}); ok {
+		t.Error("expected no delay when Retry-After was not sent")
+	}
+	if _, ok := RetryAfter(stderrors.New("plain")); ok {
+		t.Error("expected no delay for a plain error")
+	}
+}
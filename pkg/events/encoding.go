@@ -0,0 +1,396 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ProtobufContentType is the datacontenttype of events whose payload Sentinel
+// encoded in the protobuf binary format, for topics configured with the
+// protobuf encoding. The payload is a message of the hyperfleet.sentinel.v1
+// package defined in events.proto, chosen by the event type. The Parse
+// functions and JSONData decode such payloads transparently.
+const ProtobufContentType = "application/protobuf"
+
+// Field numbers of the messages of events.proto.
+const (
+	reconcileID protowire.Number = iota + 1
+	reconcileKind
+	reconcileHref
+	reconcileGeneration
+	reconcileOwnerReferences
+	reconcileFields
+)
+
+const (
+	referenceID protowire.Number = iota + 1
+	referenceHref
+	referenceKind
+)
+
+const (
+	handoffSentinel protowire.Number = iota + 1
+	handoffResourceType
+	handoffResourceSelector
+	handoffLastSuccessfulPoll
+)
+
+const (
+	summarySentinel protowire.Number = iota + 1
+	summaryResourceType
+	summaryResourceSelector
+	summaryOpID
+	summaryError
+	summaryStarted
+	summaryDurationSeconds
+	summaryFetched
+	summaryPublished
+	summarySkipped
+	summaryFailed
+	summaryPending
+	summaryIncremental
+)
+
+const (
+	probeSentinel protowire.Number = iota + 1
+	probeTopic
+)
+
+// Wire types of the fields of the messages of events.proto.
+var (
+	reconcileSchema = map[protowire.Number]protowire.Type{
+		reconcileID:              protowire.BytesType,
+		reconcileKind:            protowire.BytesType,
+		reconcileHref:            protowire.BytesType,
+		reconcileGeneration:      protowire.VarintType,
+		reconcileOwnerReferences: protowire.BytesType,
+		reconcileFields:          protowire.BytesType,
+	}
+	referenceSchema = map[protowire.Number]protowire.Type{
+		referenceID:   protowire.BytesType,
+		referenceHref: protowire.BytesType,
+		referenceKind: protowire.BytesType,
+	}
+	handoffSchema = map[protowire.Number]protowire.Type{
+		handoffSentinel:           protowire.BytesType,
+		handoffResourceType:       protowire.BytesType,
+		handoffResourceSelector:   protowire.BytesType,
+		handoffLastSuccessfulPoll: protowire.BytesType,
+	}
+	summarySchema = map[protowire.Number]protowire.Type{
+		summarySentinel:         protowire.BytesType,
+		summaryResourceType:     protowire.BytesType,
+		summaryResourceSelector: protowire.BytesType,
+		summaryOpID:             protowire.BytesType,
+		summaryError:            protowire.BytesType,
+		summaryStarted:          protowire.BytesType,
+		summaryDurationSeconds:  protowire.Fixed64Type,
+		summaryFetched:          protowire.VarintType,
+		summaryPublished:        protowire.VarintType,
+		summarySkipped:          protowire.VarintType,
+		summaryFailed:           protowire.VarintType,
+		summaryPending:          protowire.VarintType,
+		summaryIncremental:      protowire.VarintType,
+	}
+	probeSchema = map[protowire.Number]protowire.Type{
+		probeSentinel: protowire.BytesType,
+		probeTopic:    protowire.BytesType,
+	}
+)
+
+// EncodeProtobuf replaces the JSON payload of e with its protobuf encoding
+// and sets the datacontenttype to ProtobufContentType. The payload of a
+// handoff, cycle summary, or probe event becomes a Handoff, CycleSummary, or
+// Probe message of events.proto, and that of any other event, i.e. a
+// reconcile or stuck event, a ReconcilePayload. Events without a payload or
+// already encoded are left unchanged.
+func EncodeProtobuf(e *cloudevents.Event) error {
+	data := e.Data()
+	if len(data) == 0 || e.DataContentType() == ProtobufContentType {
+		return nil
+	}
+	if ct := e.DataContentType(); ct != "" && ct != cloudevents.ApplicationJSON {
+		return fmt.Errorf("event %s: cannot encode %s payload as protobuf", e.ID(), ct)
+	}
+
+	var encoded []byte
+	var err error
+	switch e.Type() {
+	case HandoffEventType:
+		encoded, err = encodeHandoff(data)
+	case CycleSummaryEventType:
+		encoded, err = encodeCycleSummary(data)
+	case ProbeEventType:
+		encoded, err = encodeProbe(data)
+	default:
+		encoded, err = encodeReconcilePayload(data)
+	}
+	if err != nil {
+		return fmt.Errorf("event %s: encoding payload as protobuf: %w", e.ID(), err)
+	}
+	return e.SetData(ProtobufContentType, encoded)
+}
+
+// JSONData returns the payload of e as JSON, decoding a protobuf payload
+// first. Use it with ParseData for events Parse does not recognise, such as
+// those of a custom event type.
+func JSONData(e *cloudevents.Event) ([]byte, error) {
+	if e.DataContentType() != ProtobufContentType {
+		return e.Data(), nil
+	}
+	var data []byte
+	var err error
+	switch e.Type() {
+	case HandoffEventType:
+		data, err = decodeHandoff(e.Data())
+	case CycleSummaryEventType:
+		data, err = decodeCycleSummary(e.Data())
+	case ProbeEventType:
+		data, err = decodeProbe(e.Data())
+	default:
+		data, err = decodeReconcilePayload(e.Data())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding protobuf payload: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalStrict decodes the JSON payload data into v, failing for fields v
+// does not have, which the protobuf encoding would drop.
+func unmarshalStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// encodeReconcilePayload encodes a JSON reconcile payload as a
+// ReconcilePayload. The conventional fields are typed when they have the
+// type of ReconcilePayload; the others go to its fields Struct, where
+// numbers become doubles.
+func encodeReconcilePayload(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var payload map[string]any
+	if err := dec.Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	var p protoBuilder
+	for _, field := range []struct {
+		name string
+		num  protowire.Number
+	}{{"id", reconcileID}, {"kind", reconcileKind}, {"href", reconcileHref}} {
+		if v, ok := payload[field.name].(string); ok {
+			p.optionalString(field.num, v)
+			delete(payload, field.name)
+		}
+	}
+	if n, ok := payload["generation"].(json.Number); ok {
+		if generation, err := n.Int64(); err == nil {
+			p.optionalInt64(reconcileGeneration, generation)
+			delete(payload, "generation")
+		}
+	}
+	if ref, ok := encodeObjectReference(payload["owner_references"]); ok {
+		p.bytes(reconcileOwnerReferences, ref)
+		delete(payload, "owner_references")
+	}
+	if len(payload) > 0 {
+		rest, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		var fields structpb.Struct
+		if err := fields.UnmarshalJSON(rest); err != nil {
+			return nil, err
+		}
+		p.message(reconcileFields, &fields)
+	}
+	return p.b, p.err
+}
+
+// encodeObjectReference encodes v as an ObjectReference if it is an object
+// with only non-empty id, href, and kind strings, as ObjectReference
+// marshals to JSON.
+func encodeObjectReference(v any) ([]byte, bool) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	var ref ObjectReference
+	for key, value := range m {
+		s, ok := value.(string)
+		if !ok || s == "" {
+			return nil, false
+		}
+		switch key {
+		case "id":
+			ref.ID = s
+		case "href":
+			ref.Href = s
+		case "kind":
+			ref.Kind = s
+		default:
+			return nil, false
+		}
+	}
+	var p protoBuilder
+	p.string(referenceID, ref.ID)
+	p.string(referenceHref, ref.Href)
+	p.string(referenceKind, ref.Kind)
+	return p.b, true
+}
+
+// decodeReconcilePayload decodes a ReconcilePayload into its JSON payload.
+func decodeReconcilePayload(b []byte) ([]byte, error) {
+	f, err := parseProto(b, reconcileSchema)
+	if err != nil {
+		return nil, err
+	}
+	var fields structpb.Struct
+	if _, err := f.message(reconcileFields, &fields); err != nil {
+		return nil, err
+	}
+	payload := fields.AsMap()
+	for _, field := range []struct {
+		name string
+		num  protowire.Number
+	}{{"id", reconcileID}, {"kind", reconcileKind}, {"href", reconcileHref}} {
+		if v, ok := f.string(field.num); ok {
+			payload[field.name] = v
+		}
+	}
+	if generation, ok := f.int64(reconcileGeneration); ok {
+		payload["generation"] = generation
+	}
+	if v, ok := f.last(reconcileOwnerReferences); ok {
+		ref, err := parseProto(v.bytes, referenceSchema)
+		if err != nil {
+			return nil, fmt.Errorf("owner_references: %w", err)
+		}
+		var owner ObjectReference
+		owner.ID, _ = ref.string(referenceID)
+		owner.Href, _ = ref.string(referenceHref)
+		owner.Kind, _ = ref.string(referenceKind)
+		payload["owner_references"] = owner
+	}
+	return json.Marshal(payload)
+}
+
+// encodeHandoff encodes a JSON Handoff payload as a Handoff message.
+func encodeHandoff(data []byte) ([]byte, error) {
+	var h Handoff
+	if err := unmarshalStrict(data, &h); err != nil {
+		return nil, err
+	}
+	var p protoBuilder
+	p.string(handoffSentinel, h.Sentinel)
+	p.string(handoffResourceType, h.ResourceType)
+	p.stringMap(handoffResourceSelector, h.ResourceSelector)
+	p.timestamp(handoffLastSuccessfulPoll, h.LastSuccessfulPoll)
+	return p.b, p.err
+}
+
+// decodeHandoff decodes a Handoff message into its JSON payload.
+func decodeHandoff(b []byte) ([]byte, error) {
+	f, err := parseProto(b, handoffSchema)
+	if err != nil {
+		return nil, err
+	}
+	var h Handoff
+	h.Sentinel, _ = f.string(handoffSentinel)
+	h.ResourceType, _ = f.string(handoffResourceType)
+	if h.ResourceSelector, err = f.stringMap(handoffResourceSelector); err != nil {
+		return nil, err
+	}
+	if h.LastSuccessfulPoll, err = f.timestamp(handoffLastSuccessfulPoll); err != nil {
+		return nil, err
+	}
+	return json.Marshal(&h)
+}
+
+// encodeCycleSummary encodes a JSON CycleSummary payload as a CycleSummary
+// message.
+func encodeCycleSummary(data []byte) ([]byte, error) {
+	var c CycleSummary
+	if err := unmarshalStrict(data, &c); err != nil {
+		return nil, err
+	}
+	var p protoBuilder
+	p.string(summarySentinel, c.Sentinel)
+	p.string(summaryResourceType, c.ResourceType)
+	p.stringMap(summaryResourceSelector, c.ResourceSelector)
+	p.string(summaryOpID, c.OpID)
+	p.string(summaryError, c.Error)
+	p.timestamp(summaryStarted, c.Started)
+	p.double(summaryDurationSeconds, c.DurationSeconds)
+	p.int64(summaryFetched, int64(c.Fetched))
+	p.int64(summaryPublished, int64(c.Published))
+	p.int64(summarySkipped, int64(c.Skipped))
+	p.int64(summaryFailed, int64(c.Failed))
+	p.int64(summaryPending, int64(c.Pending))
+	p.bool(summaryIncremental, c.Incremental)
+	return p.b, p.err
+}
+
+// decodeCycleSummary decodes a CycleSummary message into its JSON payload.
+func decodeCycleSummary(b []byte) ([]byte, error) {
+	f, err := parseProto(b, summarySchema)
+	if err != nil {
+		return nil, err
+	}
+	var c CycleSummary
+	c.Sentinel, _ = f.string(summarySentinel)
+	c.ResourceType, _ = f.string(summaryResourceType)
+	if c.ResourceSelector, err = f.stringMap(summaryResourceSelector); err != nil {
+		return nil, err
+	}
+	c.OpID, _ = f.string(summaryOpID)
+	c.Error, _ = f.string(summaryError)
+	if c.Started, err = f.timestamp(summaryStarted); err != nil {
+		return nil, err
+	}
+	c.DurationSeconds = f.double(summaryDurationSeconds)
+	for num, count := range map[protowire.Number]*int{
+		summaryFetched:   &c.Fetched,
+		summaryPublished: &c.Published,
+		summarySkipped:   &c.Skipped,
+		summaryFailed:    &c.Failed,
+		summaryPending:   &c.Pending,
+	} {
+		n, _ := f.int64(num)
+		*count = int(n)
+	}
+	c.Incremental = f.bool(summaryIncremental)
+	return json.Marshal(&c)
+}
+
+// encodeProbe encodes a JSON Probe payload as a Probe message.
+func encodeProbe(data []byte) ([]byte, error) {
+	var probe Probe
+	if err := unmarshalStrict(data, &probe); err != nil {
+		return nil, err
+	}
+	var p protoBuilder
+	p.string(probeSentinel, probe.Sentinel)
+	p.string(probeTopic, probe.Topic)
+	return p.b, p.err
+}
+
+// decodeProbe decodes a Probe message into its JSON payload.
+func decodeProbe(b []byte) ([]byte, error) {
+	f, err := parseProto(b, probeSchema)
+	if err != nil {
+		return nil, err
+	}
+	var probe Probe
+	probe.Sentinel, _ = f.string(probeSentinel)
+	probe.Topic, _ = f.string(probeTopic)
+	return json.Marshal(&probe)
+}
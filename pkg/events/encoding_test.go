@@ -0,0 +1,346 @@
+package events
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	_ "google.golang.org/protobuf/types/known/structpb"    // registers struct.proto
+	_ "google.golang.org/protobuf/types/known/timestamppb" // registers timestamp.proto
+)
+
+// eventsProto is the descriptor of events.proto, which the encoded payloads
+// are checked against with a generic protobuf decoder.
+const eventsProto = `
+name: "events.proto"
+package: "hyperfleet.sentinel.v1"
+dependency: ["google/protobuf/struct.proto", "google/protobuf/timestamp.proto"]
+syntax: "proto3"
+message_type {
+  name: "ReconcilePayload"
+  field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 0 proto3_optional: true }
+  field { name: "kind" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 1 proto3_optional: true }
+  field { name: "href" number: 3 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 2 proto3_optional: true }
+  field { name: "generation" number: 4 label: LABEL_OPTIONAL type: TYPE_INT64 oneof_index: 3 proto3_optional: true }
+  field {
+    name: "owner_references" number: 5 label: LABEL_OPTIONAL type: TYPE_MESSAGE
+    type_name: ".hyperfleet.sentinel.v1.ObjectReference"
+  }
+  field { name: "fields" number: 6 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".google.protobuf.Struct" }
+  oneof_decl { name: "_id" }
+  oneof_decl { name: "_kind" }
+  oneof_decl { name: "_href" }
+  oneof_decl { name: "_generation" }
+}
+message_type {
+  name: "ObjectReference"
+  field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "href" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "kind" number: 3 label: LABEL_OPTIONAL type: TYPE_STRING }
+}
+message_type {
+  name: "Handoff"
+  field { name: "sentinel" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "resource_type" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field {
+    name: "resource_selector" number: 3 label: LABEL_REPEATED type: TYPE_MESSAGE
+    type_name: ".hyperfleet.sentinel.v1.Handoff.ResourceSelectorEntry"
+  }
+  field {
+    name: "last_successful_poll" number: 4 label: LABEL_OPTIONAL type: TYPE_MESSAGE
+    type_name: ".google.protobuf.Timestamp"
+  }
+  nested_type {
+    name: "ResourceSelectorEntry"
+    field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+    field { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING }
+    options { map_entry: true }
+  }
+}
+message_type {
+  name: "CycleSummary"
+  field { name: "sentinel" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "resource_type" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field {
+    name: "resource_selector" number: 3 label: LABEL_REPEATED type: TYPE_MESSAGE
+    type_name: ".hyperfleet.sentinel.v1.CycleSummary.ResourceSelectorEntry"
+  }
+  field { name: "op_id" number: 4 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "error" number: 5 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "started" number: 6 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".google.protobuf.Timestamp" }
+  field { name: "duration_seconds" number: 7 label: LABEL_OPTIONAL type: TYPE_DOUBLE }
+  field { name: "fetched" number: 8 label: LABEL_OPTIONAL type: TYPE_INT64 }
+  field { name: "published" number: 9 label: LABEL_OPTIONAL type: TYPE_INT64 }
+  field { name: "skipped" number: 10 label: LABEL_OPTIONAL type: TYPE_INT64 }
+  field { name: "failed" number: 11 label: LABEL_OPTIONAL type: TYPE_INT64 }
+  field { name: "pending" number: 12 label: LABEL_OPTIONAL type: TYPE_INT64 }
+  field { name: "incremental" number: 13 label: LABEL_OPTIONAL type: TYPE_BOOL }
+  nested_type {
+    name: "ResourceSelectorEntry"
+    field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+    field { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING }
+    options { map_entry: true }
+  }
+}
+message_type {
+  name: "Probe"
+  field { name: "sentinel" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "topic" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING }
+}
+`
+
+// decodeWithSchema decodes the protobuf payload of e as the named message of
+// events.proto and returns it in the protobuf JSON mapping with the field
+// names of the schema.
+func decodeWithSchema(t *testing.T, e *cloudevents.Event, message string) map[string]any {
+	t.Helper()
+	var fdp descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(eventsProto), &fdp); err != nil {
+		t.Fatalf("parsing the descriptor: %v", err)
+	}
+	fd, err := protodesc.NewFile(&fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("building the descriptor: %v", err)
+	}
+	msg := dynamicpb.NewMessage(fd.Messages().ByName(protoreflect.Name(message)))
+	if err := proto.Unmarshal(e.Data(), msg); err != nil {
+		t.Fatalf("expected a %s payload: %v", message, err)
+	}
+	out, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		t.Fatalf("protojson.Marshal: %v", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(out, &m); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	return m
+}
+
+// assertSameJSON fails unless got and want hold the same JSON value.
+func assertSameJSON(t *testing.T, got, want []byte) {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if err := json.Unmarshal(want, &w); err != nil {
+		t.Fatalf("invalid JSON %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("expected JSON %s, got %s", want, got)
+	}
+}
+
+func TestEncodeProtobuf(t *testing.T) {
+	e := newTestEvent(t, "NodePool", map[string]interface{}{
+		"id":               "nodepool-1",
+		"kind":             "NodePool",
+		"generation":       7,
+		"owner_references": map[string]interface{}{"id": "cluster-1", "kind": "Cluster"},
+		"labels":           map[string]interface{}{"env": "prod"},
+		"replicas":         3,
+	})
+	original := e.Data()
+	if err := EncodeProtobuf(e); err != nil {
+		t.Fatalf("EncodeProtobuf: %v", err)
+	}
+	if e.DataContentType() != ProtobufContentType {
+		t.Errorf("expected datacontenttype %q, got %q", ProtobufContentType, e.DataContentType())
+	}
+
+	got := decodeWithSchema(t, e, "ReconcilePayload")
+	want := map[string]any{
+		"id":               "nodepool-1",
+		"kind":             "NodePool",
+		"generation":       "7",
+		"owner_references": map[string]any{"id": "cluster-1", "kind": "Cluster"},
+		"fields":           map[string]any{"labels": map[string]any{"env": "prod"}, "replicas": float64(3)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected ReconcilePayload %v, got %v", want, got)
+	}
+
+	// Encoding twice is a no-op, and the payload decodes to the JSON one.
+	if err := EncodeProtobuf(e); err != nil {
+		t.Fatalf("EncodeProtobuf: %v", err)
+	}
+	data, err := JSONData(e)
+	if err != nil {
+		t.Fatalf("JSONData: %v", err)
+	}
+	assertSameJSON(t, data, original)
+
+	re, err := Parse(e)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if re.ID != "nodepool-1" || re.Generation != 7 || re.OwnerReferences == nil || re.OwnerReferences.ID != "cluster-1" {
+		t.Errorf("unexpected typed fields: %+v", re)
+	}
+	if labels, _ := re.Data["labels"].(map[string]interface{}); labels["env"] != "prod" {
+		t.Errorf("expected nested fields in Data, got %v", re.Data["labels"])
+	}
+}
+
+func TestEncodeProtobuf_UntypedConventionalFields(t *testing.T) {
+	// message_data may emit the conventional fields with other types; they
+	// are kept in fields.
+	e := newTestEvent(t, "Cluster", map[string]interface{}{
+		"id":               42,
+		"generation":       "7",
+		"owner_references": map[string]interface{}{"id": "cluster-1", "extra": "x"},
+		"href":             "",
+	})
+	original := e.Data()
+	if err := EncodeProtobuf(e); err != nil {
+		t.Fatalf("EncodeProtobuf: %v", err)
+	}
+
+	got := decodeWithSchema(t, e, "ReconcilePayload")
+	if got["href"] != "" {
+		t.Errorf("expected an empty href to be kept, got %v", got)
+	}
+	fields, _ := got["fields"].(map[string]any)
+	for _, name := range []string{"id", "generation", "owner_references"} {
+		if _, ok := fields[name]; !ok {
+			t.Errorf("expected %s in fields, got %v", name, got)
+		}
+	}
+
+	data, err := JSONData(e)
+	if err != nil {
+		t.Fatalf("JSONData: %v", err)
+	}
+	assertSameJSON(t, data, original)
+}
+
+func TestEncodeProtobuf_SentinelEvents(t *testing.T) {
+	started := time.Date(2026, 10, 15, 12, 0, 0, 500, time.UTC)
+	tests := []struct {
+		payload   any
+		want      map[string]any
+		eventType string
+		message   string
+	}{
+		{
+			eventType: HandoffEventType,
+			message:   "Handoff",
+			payload: Handoff{
+				Sentinel:           "sentinel-0",
+				ResourceType:       "clusters",
+				ResourceSelector:   map[string]string{"shard": "1", "env": "prod"},
+				LastSuccessfulPoll: started,
+			},
+			want: map[string]any{
+				"sentinel":             "sentinel-0",
+				"resource_type":        "clusters",
+				"resource_selector":    map[string]any{"shard": "1", "env": "prod"},
+				"last_successful_poll": "2026-10-15T12:00:00.000000500Z",
+			},
+		},
+		{
+			eventType: CycleSummaryEventType,
+			message:   "CycleSummary",
+			payload: CycleSummary{
+				Started:         started,
+				Sentinel:        "sentinel-0",
+				ResourceType:    "clusters",
+				OpID:            "op-1",
+				DurationSeconds: 1.5,
+				Fetched:         10,
+				Published:       3,
+				Skipped:         7,
+				Incremental:     true,
+			},
+			want: map[string]any{
+				"sentinel":         "sentinel-0",
+				"resource_type":    "clusters",
+				"op_id":            "op-1",
+				"started":          "2026-10-15T12:00:00.000000500Z",
+				"duration_seconds": 1.5,
+				"fetched":          "10",
+				"published":        "3",
+				"skipped":          "7",
+				"incremental":      true,
+			},
+		},
+		{
+			eventType: ProbeEventType,
+			message:   "Probe",
+			payload:   Probe{Sentinel: "sentinel-0", Topic: "clusters"},
+			want:      map[string]any{"sentinel": "sentinel-0", "topic": "clusters"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			e := cloudevents.NewEvent()
+			e.SetID("evt-1")
+			e.SetType(tt.eventType)
+			e.SetSource(Source)
+			if err := e.SetData(cloudevents.ApplicationJSON, tt.payload); err != nil {
+				t.Fatalf("SetData: %v", err)
+			}
+			original := e.Data()
+			if err := EncodeProtobuf(&e); err != nil {
+				t.Fatalf("EncodeProtobuf: %v", err)
+			}
+			if got := decodeWithSchema(t, &e, tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %s %v, got %v", tt.message, tt.want, got)
+			}
+			data, err := JSONData(&e)
+			if err != nil {
+				t.Fatalf("JSONData: %v", err)
+			}
+			assertSameJSON(t, data, original)
+		})
+	}
+}
+
+func TestEncodeProtobuf_UnknownField(t *testing.T) {
+	e := cloudevents.NewEvent()
+	e.SetID("evt-1")
+	e.SetType(ProbeEventType)
+	e.SetSource(Source)
+	if err := e.SetData(cloudevents.ApplicationJSON, map[string]string{"sentinel": "s", "bogus": "x"}); err != nil {
+		t.Fatalf("SetData: %v", err)
+	}
+	if err := EncodeProtobuf(&e); err == nil {
+		t.Error("expected a field missing from the Probe message to be rejected")
+	}
+}
+
+func TestEncodeProtobuf_NotJSON(t *testing.T) {
+	e := newTestEvent(t, "Cluster", nil)
+	if err := e.SetData(cloudevents.TextPlain, "cluster-1"); err != nil {
+		t.Fatalf("SetData: %v", err)
+	}
+	if err := EncodeProtobuf(e); err == nil {
+		t.Error("expected a text/plain payload to be rejected")
+	}
+}
+
+func TestParse_InvalidProtobuf(t *testing.T) {
+	for _, data := range [][]byte{
+		{0xff},
+		{0x08, 0x01}, // id as a varint
+	} {
+		e := newTestEvent(t, "Cluster", nil)
+		if err := e.SetData(ProtobufContentType, data); err != nil {
+			t.Fatalf("SetData: %v", err)
+		}
+		if _, err := Parse(e); err == nil {
+			t.Errorf("expected the protobuf payload %x to fail", data)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+// Package events provides typed helpers for consuming the CloudEvents published
+// by HyperFleet Sentinel: reconcile events, stuck events, shard handoff
+// events, topic probe events, and poll cycle summary events.
+//
+// Adapters should parse incoming events with Parse rather than decoding the
+// JSON payload by hand. Parse validates the event type and schema version,
+// decodes the conventional payload fields (id, kind, href, generation,
+// owner_references) into typed fields, and keeps the full payload in Data so
+// that custom message_data fields remain accessible.
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+const (
+	// Source is the CloudEvent source attribute set on every Sentinel event.
+	// Sentinels configured with clients.broker.source append an identity to
+	// it, e.g. "hyperfleet-sentinel/sentinel-0/shard:1"; use IsSource to
+	// recognize both forms.
+	Source = "hyperfleet-sentinel"
+
+	// SchemaVersionExtension is the CloudEvent extension attribute that carries
+	// the reconcile payload schema version.
+	SchemaVersionExtension = "schemaversion"
+
+	// SchemaVersion is the payload schema version emitted by this Sentinel
+	// release. Events without the extension predate versioning and are
+	// treated as version "1".
+	SchemaVersion = "1"
+
+	// RegionExtension is the CloudEvent extension attribute naming the
+	// HyperFleet API region a resource was fetched from. It is set only by
+	// Sentinels running in multi-region mode.
+	RegionExtension = "region"
+
+	// PartitionKeyExtension is the CloudEvents partitioning extension
+	// attribute. Reconcile events carry the resource ID in it, and the
+	// Sentinel's own events, such as probes and cycle summaries, the Sentinel's
+	// source. The Pub/Sub publisher uses it as ordering key, so that ordered
+	// subscriptions receive every event for one resource in order.
+	PartitionKeyExtension = "partitionkey"
+
+	// TraceParentExtension, OpIDExtension, ShardExtension, and
+	// SentinelVersionExtension correlate an event with the Sentinel that
+	// published it: the W3C trace context of the publish when tracing is
+	// enabled, the operation ID of the poll cycle, which also appears in every
+	// log line of the cycle, the resource_selector label of the Sentinel
+	// followed by /index/total when it runs with shard, and the Sentinel
+	// release.
+	TraceParentExtension     = "traceparent"
+	OpIDExtension            = "opid"
+	ShardExtension           = "shard"
+	SentinelVersionExtension = "sentinelversion"
+
+	// DeadLetterReasonExtension, DeadLetterAttemptsExtension, and
+	// DeadLetterTopicExtension are set on a reconcile event routed to the
+	// dead letter sink: the error of its last failed publish, the number of
+	// failed publishes, and the topic it was meant for.
+	DeadLetterReasonExtension   = "deadletterreason"
+	DeadLetterAttemptsExtension = "deadletterattempts"
+	DeadLetterTopicExtension    = "deadlettertopic"
+
+	// ExpiryTimeExtension and PriorityExtension are set on the reconcile
+	// events of topics with clients.broker.message_options: the time after
+	// which the event is stale, publish time plus the configured TTL, and the
+	// configured priority. Use Expired to drop stale events.
+	ExpiryTimeExtension = "expirytime"
+	PriorityExtension   = "priority"
+
+	// HandoffEventType is the CloudEvent type of the final heartbeat a
+	// Sentinel publishes when it is drained and releases its shard.
+	HandoffEventType = "com.redhat.hyperfleet.sentinel.handoff"
+
+	// ProbeEventType is the CloudEvent type of the probe a Sentinel publishes
+	// to each of its topics at startup when clients.broker.probe_topics is
+	// enabled. Consumers should acknowledge and ignore it.
+	ProbeEventType = "com.redhat.hyperfleet.sentinel.probe"
+
+	// CycleSummaryEventType is the CloudEvent type of the summary a Sentinel
+	// publishes after each poll cycle to clients.broker.cycle_summary_topic,
+	// when it is set.
+	CycleSummaryEventType = "com.redhat.hyperfleet.sentinel.cycle_summary"
+
+	// StuckSuffix is appended to the reconcile event type of a resource to
+	// form its stuck event type, also when clients.broker.event_type sets the
+	// reconcile event type.
+	StuckSuffix = ".stuck"
+
+	typePrefix = "com.redhat.hyperfleet."
+	typeSuffix = ".reconcile"
+)
+
+var (
+	// ErrNotReconcileEvent is returned when an event is not a Sentinel reconcile event.
+	ErrNotReconcileEvent = errors.New("not a sentinel reconcile event")
+
+	// ErrUnsupportedSchemaVersion is returned when an event carries a schema
+	// version whose major component this package does not understand.
+	ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+
+	// ErrNotHandoffEvent is returned when an event is not a Sentinel handoff event.
+	ErrNotHandoffEvent = errors.New("not a sentinel handoff event")
+
+	// ErrNotCycleSummaryEvent is returned when an event is not a Sentinel
+	// cycle summary event.
+	ErrNotCycleSummaryEvent = errors.New("not a sentinel cycle summary event")
+)
+
+// ObjectReference identifies a related HyperFleet resource, such as the owner
+// of a nodepool.
+type ObjectReference struct {
+	ID   string `json:"id,omitempty"`
+	Href string `json:"href,omitempty"`
+	Kind string `json:"kind,omitempty"`
+}
+
+// ReconcileEvent is the typed form of a Sentinel reconcile event.
+//
+// The typed payload fields are populated only when the Sentinel's message_data
+// configuration emits them under their conventional names; Data always holds
+// the complete decoded payload.
+type ReconcileEvent struct {
+	Data            map[string]interface{} `json:"-"`
+	OwnerReferences *ObjectReference       `json:"owner_references,omitempty"`
+	EventID         string                 `json:"-"`
+	Source          string                 `json:"-"`
+	ResourceType    string                 `json:"-"`
+	SchemaVersion   string                 `json:"-"`
+	Region          string                 `json:"-"`
+	TraceParent     string                 `json:"-"`
+	OpID            string                 `json:"-"`
+	Shard           string                 `json:"-"`
+	SentinelVersion string                 `json:"-"`
+	ID              string                 `json:"id,omitempty"`
+	Kind            string                 `json:"kind,omitempty"`
+	Href            string                 `json:"href,omitempty"`
+	Generation      int64                  `json:"generation,omitempty"`
+}
+
+// Handoff is the payload of a HandoffEventType event. It announces that the
+// named Sentinel instance has stopped publishing for its resource type and
+// selector, so another instance can take over the shard.
+type Handoff struct {
+	LastSuccessfulPoll time.Time         `json:"last_successful_poll,omitzero"`
+	ResourceSelector   map[string]string `json:"resource_selector,omitempty"`
+	Sentinel           string            `json:"sentinel"`
+	ResourceType       string            `json:"resource_type"`
+}
+
+// CycleSummary is the payload of a CycleSummaryEventType event. It reports the
+// outcome of one poll cycle of the named Sentinel instance. Failed counts the
+// events the broker did not accept; Error is set when the cycle itself failed,
+// for example because the HyperFleet API could not be listed.
+type CycleSummary struct {
+	Started          time.Time         `json:"started"`
+	ResourceSelector map[string]string `json:"resource_selector,omitempty"`
+	Sentinel         string            `json:"sentinel"`
+	ResourceType     string            `json:"resource_type"`
+	OpID             string            `json:"op_id,omitempty"`
+	Error            string            `json:"error,omitempty"`
+	DurationSeconds  float64           `json:"duration_seconds"`
+	Fetched          int               `json:"fetched"`
+	Published        int               `json:"published"`
+	Skipped          int               `json:"skipped"`
+	Failed           int               `json:"failed"`
+	Pending          int               `json:"pending"`
+	Incremental      bool              `json:"incremental,omitempty"`
+}
+
+// Probe is the payload of a ProbeEventType event.
+type Probe struct {
+	Sentinel string `json:"sentinel"`
+	Topic    string `json:"topic"`
+}
+
+// IsSource reports whether source is the CloudEvent source of a Sentinel:
+// Source itself, or Source followed by "/" and an instance identity.
+func IsSource(source string) bool {
+	return source == Source || strings.HasPrefix(source, Source+"/")
+}
+
+// IsProbe reports whether e is a Sentinel topic probe event.
+func IsProbe(e *cloudevents.Event) bool {
+	return e != nil && IsSource(e.Source()) && e.Type() == ProbeEventType
+}
+
+// ParseCycleSummary decodes a cycle summary event. It returns
+// ErrNotCycleSummaryEvent for events of another type or source.
+func ParseCycleSummary(e *cloudevents.Event) (*CycleSummary, error) {
+	if e == nil || !IsSource(e.Source()) || e.Type() != CycleSummaryEventType {
+		return nil, ErrNotCycleSummaryEvent
+	}
+	data, err := JSONData(e)
+	if err != nil {
+		return nil, fmt.Errorf("event %s: %w", e.ID(), err)
+	}
+	var c CycleSummary
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("event %s: decoding cycle summary payload: %w", e.ID(), err)
+	}
+	return &c, nil
+}
+
+// ParseHandoff decodes a handoff event. It returns ErrNotHandoffEvent for
+// events of another type or source.
+func ParseHandoff(e *cloudevents.Event) (*Handoff, error) {
+	if e == nil || !IsSource(e.Source()) || e.Type() != HandoffEventType {
+		return nil, ErrNotHandoffEvent
+	}
+	data, err := JSONData(e)
+	if err != nil {
+		return nil, fmt.Errorf("event %s: %w", e.ID(), err)
+	}
+	var h Handoff
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("event %s: decoding handoff payload: %w", e.ID(), err)
+	}
+	return &h, nil
+}
+
+// EventType returns the CloudEvent type Sentinel uses for reconcile events of
+// the given resource kind, e.g. "Cluster" -> "com.redhat.hyperfleet.cluster.reconcile".
+func EventType(kind string) string {
+	return typePrefix + strings.ToLower(kind) + typeSuffix
+}
+
+// StuckEventType returns the CloudEvent type Sentinel uses for resources of
+// the given kind that are stuck in a deletion phase, e.g. "Cluster" ->
+// "com.redhat.hyperfleet.cluster.reconcile.stuck". Stuck events carry the
+// same payload as reconcile events, but are not reconcile events: adapters
+// that only handle reconcile events can ignore them.
+func StuckEventType(kind string) string {
+	return EventType(kind) + StuckSuffix
+}
+
+// IsStuckEvent reports whether e was published by Sentinel as a stuck event.
+// Use ParseData to decode its payload.
+func IsStuckEvent(e *cloudevents.Event) bool {
+	if e == nil || !IsSource(e.Source()) {
+		return false
+	}
+	eventType, ok := strings.CutSuffix(e.Type(), StuckSuffix)
+	if !ok {
+		return false
+	}
+	_, ok = ResourceTypeFromEventType(eventType)
+	return ok
+}
+
+// ResourceTypeFromEventType extracts the lower-cased resource kind from a
+// reconcile event type. ok is false if eventType is not a reconcile event type.
+func ResourceTypeFromEventType(eventType string) (resourceType string, ok bool) {
+	if !strings.HasPrefix(eventType, typePrefix) || !strings.HasSuffix(eventType, typeSuffix) {
+		return "", false
+	}
+	resourceType = strings.TrimSuffix(strings.TrimPrefix(eventType, typePrefix), typeSuffix)
+	if resourceType == "" || strings.Contains(resourceType, ".") {
+		return "", false
+	}
+	return resourceType, true
+}
+
+// IsReconcileEvent reports whether e was published by Sentinel as a reconcile event.
+func IsReconcileEvent(e *cloudevents.Event) bool {
+	if e == nil || !IsSource(e.Source()) {
+		return false
+	}
+	_, ok := ResourceTypeFromEventType(e.Type())
+	return ok
+}
+
+// Parse converts a CloudEvent into a ReconcileEvent, decoding JSON and
+// protobuf payloads alike. It returns ErrNotReconcileEvent for events of
+// another type or source and ErrUnsupportedSchemaVersion for payloads from an
+// incompatible schema major version.
+func Parse(e *cloudevents.Event) (*ReconcileEvent, error) {
+	if !IsReconcileEvent(e) {
+		return nil, ErrNotReconcileEvent
+	}
+
+	version, err := eventSchemaVersion(e)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := JSONData(e)
+	if err != nil {
+		return nil, fmt.Errorf("event %s: %w", e.ID(), err)
+	}
+	re, err := ParseData(data)
+	if err != nil {
+		return nil, fmt.Errorf("event %s: %w", e.ID(), err)
+	}
+	re.EventID = e.ID()
+	re.Source = e.Source()
+	re.ResourceType, _ = ResourceTypeFromEventType(e.Type())
+	re.SchemaVersion = version
+	re.Region = extension(e, RegionExtension)
+	re.TraceParent = extension(e, TraceParentExtension)
+	re.OpID = extension(e, OpIDExtension)
+	re.Shard = extension(e, ShardExtension)
+	re.SentinelVersion = extension(e, SentinelVersionExtension)
+	return re, nil
+}
+
+// Expired reports whether e carries an ExpiryTimeExtension that is before
+// now.
+func Expired(e *cloudevents.Event, now time.Time) bool {
+	v, ok := e.Extensions()[ExpiryTimeExtension]
+	if !ok {
+		return false
+	}
+	expiry, err := types.ToTime(v)
+	return err == nil && expiry.Before(now)
+}
+
+// reservedAttributes are the CloudEvent context attributes and the extension
+// attributes Sentinel sets itself.
+var reservedAttributes = []string{
+	"id", "source", "specversion", "type", "datacontenttype", "dataschema", "subject", "time", "data",
+	SchemaVersionExtension, RegionExtension, PartitionKeyExtension, TraceParentExtension,
+	OpIDExtension, ShardExtension, SentinelVersionExtension, DeadLetterReasonExtension,
+	DeadLetterAttemptsExtension, DeadLetterTopicExtension, ExpiryTimeExtension, PriorityExtension,
+}
+
+// IsReservedAttribute reports whether name is a CloudEvent context attribute
+// or an extension attribute set by Sentinel, which configured headers must
+// not override.
+func IsReservedAttribute(name string) bool {
+	return slices.Contains(reservedAttributes, name)
+}
+
+// extension returns the value of the named extension attribute of e, or ""
+// if it is not set.
+func extension(e *cloudevents.Event, name string) string {
+	if v, ok := e.Extensions()[name]; ok {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// ParseData decodes a raw reconcile event JSON payload. Envelope fields
+// (EventID, Source, ResourceType, SchemaVersion, and the extension fields such
+// as Region) are left empty; use Parse when the full CloudEvent is available.
+func ParseData(data []byte) (*ReconcileEvent, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty event payload")
+	}
+
+	var re ReconcileEvent
+	if err := json.Unmarshal(data, &re); err != nil {
+		return nil, fmt.Errorf("decoding event payload: %w", err)
+	}
+	if err := json.Unmarshal(data, &re.Data); err != nil {
+		return nil, fmt.Errorf("decoding event payload: %w", err)
+	}
+	return &re, nil
+}
+
+// eventSchemaVersion returns the schema version carried by e, defaulting to
+// "1" for events published before versioning was introduced. Minor versions
+// only add fields, so any version with a supported major is accepted.
+func eventSchemaVersion(e *cloudevents.Event) (string, error) {
+	v, ok := e.Extensions()[SchemaVersionExtension]
+	if !ok {
+		return SchemaVersion, nil
+	}
+	version := fmt.Sprint(v)
+	major, _, _ := strings.Cut(version, ".")
+	if major != SchemaVersion {
+		return "", fmt.Errorf("%w %q (supported: %s.x)", ErrUnsupportedSchemaVersion, version, SchemaVersion)
+	}
+	return version, nil
+}
@@ -0,0 +1,337 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// newTestEvent builds a reconcile event the way Sentinel publishes it.
+func newTestEvent(t *testing.T, kind string, data interface{}) *cloudevents.Event {
+	t.Helper()
+	e := cloudevents.NewEvent()
+	e.SetID("evt-1")
+	e.SetType(EventType(kind))
+	e.SetSource(Source)
+	e.SetExtension(SchemaVersionExtension, SchemaVersion)
+	if err := e.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		t.Fatalf("SetData: %v", err)
+	}
+	return &e
+}
+
+func TestEventType(t *testing.T) {
+	if got := EventType("NodePool"); got != "com.redhat.hyperfleet.nodepool.reconcile" {
+		t.Errorf("EventType(NodePool) = %q", got)
+	}
+}
+
+func TestResourceTypeFromEventType(t *testing.T) {
+	tests := []struct {
+		eventType string
+		want      string
+		wantOK    bool
+	}{
+		{eventType: "com.redhat.hyperfleet.cluster.reconcile", want: "cluster", wantOK: true},
+		{eventType: "com.redhat.hyperfleet.nodepool.reconcile", want: "nodepool", wantOK: true},
+		{eventType: "com.redhat.hyperfleet..reconcile"},
+		{eventType: "com.redhat.hyperfleet.sentinel.handoff"},
+		{eventType: "com.redhat.hyperfleet.cluster.reconcile.stuck"},
+		{eventType: "com.example.cluster.reconcile"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			got, ok := ResourceTypeFromEventType(tt.eventType)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("got (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	e := newTestEvent(t, "NodePool", map[string]interface{}{
+		"id":         "np-1",
+		"kind":       "NodePool",
+		"href":       "/api/hyperfleet/v1/nodepools/np-1",
+		"generation": 3,
+		"owner_references": map[string]interface{}{
+			"id":   "cluster-1",
+			"kind": "Cluster",
+		},
+		"region": "us-east-1",
+	})
+
+	re, err := Parse(e)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if re.ID != "np-1" || re.Kind != "NodePool" || re.Generation != 3 {
+		t.Errorf("unexpected typed fields: %+v", re)
+	}
+	if re.OwnerReferences == nil || re.OwnerReferences.ID != "cluster-1" {
+		t.Errorf("expected owner reference cluster-1, got %+v", re.OwnerReferences)
+	}
+	if re.EventID != "evt-1" || re.ResourceType != "nodepool" || re.SchemaVersion != SchemaVersion {
+		t.Errorf("unexpected envelope fields: %+v", re)
+	}
+	if re.Data["region"] != "us-east-1" {
+		t.Errorf("expected custom field in Data, got %v", re.Data["region"])
+	}
+}
+
+func TestParse_Region(t *testing.T) {
+	e := newTestEvent(t, "Cluster", map[string]interface{}{"id": "c-1"})
+	re, err := Parse(e)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if re.Region != "" {
+		t.Errorf("expected empty Region without extension, got %q", re.Region)
+	}
+
+	e.SetExtension(RegionExtension, "us-east")
+	if re, err = Parse(e); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if re.Region != "us-east" {
+		t.Errorf("Region = %q, want us-east", re.Region)
+	}
+}
+
+func TestParse_CorrelationExtensions(t *testing.T) {
+	e := newTestEvent(t, "Cluster", map[string]interface{}{"id": "c-1"})
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	e.SetExtension(TraceParentExtension, traceParent)
+	e.SetExtension(OpIDExtension, "op-1")
+	e.SetExtension(ShardExtension, "shard:1/0/2")
+	e.SetExtension(SentinelVersionExtension, "1.2.0")
+
+	re, err := Parse(e)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if re.TraceParent != traceParent || re.OpID != "op-1" || re.Shard != "shard:1/0/2" || re.SentinelVersion != "1.2.0" {
+		t.Errorf("unexpected correlation fields: %+v", re)
+	}
+}
+
+func TestParse_InstanceSource(t *testing.T) {
+	e := newTestEvent(t, "Cluster", map[string]interface{}{"id": "c-1"})
+	e.SetSource(Source + "/sentinel-0/shard:1")
+	re, err := Parse(e)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if re.Source != Source+"/sentinel-0/shard:1" {
+		t.Errorf("Source = %q, want the instance source", re.Source)
+	}
+}
+
+func TestIsSource(t *testing.T) {
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		{source: Source, want: true},
+		{source: Source + "/sentinel-0", want: true},
+		{source: Source + "/sentinel-0/shard:1", want: true},
+		{source: Source + "-other"},
+		{source: "other/" + Source},
+		{source: ""},
+	}
+	for _, tt := range tests {
+		if got := IsSource(tt.source); got != tt.want {
+			t.Errorf("IsSource(%q) = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestParse_SchemaVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		wantErr bool
+		setExt  bool
+	}{
+		{version: SchemaVersion, setExt: false},
+		{version: "1", setExt: true},
+		{version: "1.4", setExt: true},
+		{version: "2", setExt: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			e := newTestEvent(t, "Cluster", map[string]interface{}{"id": "c-1"})
+			if tt.setExt {
+				e.SetExtension(SchemaVersionExtension, tt.version)
+			} else {
+				delete(e.Extensions(), SchemaVersionExtension)
+			}
+
+			re, err := Parse(e)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedSchemaVersion) {
+					t.Fatalf("expected ErrUnsupportedSchemaVersion, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if re.SchemaVersion != tt.version {
+				t.Errorf("SchemaVersion = %q, want %q", re.SchemaVersion, tt.version)
+			}
+		})
+	}
+}
+
+func TestParse_NotReconcileEvent(t *testing.T) {
+	e := newTestEvent(t, "Cluster", map[string]interface{}{"id": "c-1"})
+	e.SetSource("someone-else")
+	if _, err := Parse(e); !errors.Is(err, ErrNotReconcileEvent) {
+		t.Errorf("expected ErrNotReconcileEvent, got %v", err)
+	}
+	if _, err := Parse(nil); !errors.Is(err, ErrNotReconcileEvent) {
+		t.Errorf("expected ErrNotReconcileEvent for nil event, got %v", err)
+	}
+}
+
+func TestParseData_Errors(t *testing.T) {
+	if _, err := ParseData(nil); err == nil {
+		t.Error("expected error for empty payload")
+	}
+	if _, err := ParseData([]byte("{not json")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestParseHandoff(t *testing.T) {
+	e := cloudevents.NewEvent()
+	e.SetID("evt-2")
+	e.SetType(HandoffEventType)
+	e.SetSource(Source)
+	if err := e.SetData(cloudevents.ApplicationJSON, Handoff{
+		Sentinel:         "sentinel-shard-1",
+		ResourceType:     "clusters",
+		ResourceSelector: map[string]string{"shard": "1"},
+	}); err != nil {
+		t.Fatalf("SetData: %v", err)
+	}
+
+	h, err := ParseHandoff(&e)
+	if err != nil {
+		t.Fatalf("ParseHandoff: %v", err)
+	}
+	if h.Sentinel != "sentinel-shard-1" || h.ResourceType != "clusters" || h.ResourceSelector["shard"] != "1" {
+		t.Errorf("unexpected handoff: %+v", h)
+	}
+
+	// Handoff events are not reconcile events and vice versa.
+	if _, err := Parse(&e); !errors.Is(err, ErrNotReconcileEvent) {
+		t.Errorf("expected ErrNotReconcileEvent, got %v", err)
+	}
+	reconcile := newTestEvent(t, "Cluster", map[string]interface{}{"id": "c-1"})
+	if _, err := ParseHandoff(reconcile); !errors.Is(err, ErrNotHandoffEvent) {
+		t.Errorf("expected ErrNotHandoffEvent, got %v", err)
+	}
+}
+
+func TestParseCycleSummary(t *testing.T) {
+	e := cloudevents.NewEvent()
+	e.SetID("evt-3")
+	e.SetType(CycleSummaryEventType)
+	e.SetSource(Source + "/sentinel-0/all")
+	if err := e.SetData(cloudevents.ApplicationJSON, CycleSummary{
+		Sentinel:        "sentinel-clusters",
+		ResourceType:    "clusters",
+		DurationSeconds: 1.5,
+		Fetched:         10,
+		Published:       3,
+		Skipped:         6,
+		Failed:          1,
+	}); err != nil {
+		t.Fatalf("SetData: %v", err)
+	}
+
+	c, err := ParseCycleSummary(&e)
+	if err != nil {
+		t.Fatalf("ParseCycleSummary: %v", err)
+	}
+	if c.Sentinel != "sentinel-clusters" || c.Fetched != 10 || c.Published != 3 || c.Skipped != 6 || c.Failed != 1 ||
+		c.DurationSeconds != 1.5 {
+		t.Errorf("unexpected cycle summary: %+v", c)
+	}
+
+	if _, err := Parse(&e); !errors.Is(err, ErrNotReconcileEvent) {
+		t.Errorf("expected ErrNotReconcileEvent, got %v", err)
+	}
+	reconcile := newTestEvent(t, "Cluster", map[string]interface{}{"id": "c-1"})
+	if _, err := ParseCycleSummary(reconcile); !errors.Is(err, ErrNotCycleSummaryEvent) {
+		t.Errorf("expected ErrNotCycleSummaryEvent, got %v", err)
+	}
+}
+
+func TestIsStuckEvent(t *testing.T) {
+	if got := StuckEventType("Cluster"); got != "com.redhat.hyperfleet.cluster.reconcile.stuck" {
+		t.Errorf("StuckEventType(Cluster) = %q", got)
+	}
+
+	e := newTestEvent(t, "Cluster", map[string]interface{}{"id": "c-1"})
+	e.SetType(StuckEventType("Cluster"))
+	if !IsStuckEvent(e) {
+		t.Error("expected stuck event to be recognised")
+	}
+	if IsReconcileEvent(e) {
+		t.Error("stuck event must not be a reconcile event")
+	}
+	if IsStuckEvent(newTestEvent(t, "Cluster", map[string]interface{}{"id": "c-1"})) {
+		t.Error("reconcile event must not be a stuck event")
+	}
+	if IsStuckEvent(nil) {
+		t.Error("nil event must not be a stuck event")
+	}
+}
+
+func TestIsProbe(t *testing.T) {
+	e := cloudevents.NewEvent()
+	e.SetID("evt-3")
+	e.SetType(ProbeEventType)
+	e.SetSource(Source)
+
+	if !IsProbe(&e) {
+		t.Error("expected probe event to be recognised")
+	}
+	if IsReconcileEvent(&e) {
+		t.Error("probe event must not be a reconcile event")
+	}
+	if IsProbe(newTestEvent(t, "Cluster", map[string]interface{}{"id": "c-1"})) {
+		t.Error("reconcile event must not be a probe")
+	}
+	if IsProbe(nil) {
+		t.Error("nil event must not be a probe")
+	}
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Now()
+	e := newTestEvent(t, "Cluster", map[string]interface{}{"id": "cluster-1"})
+	if Expired(e, now) {
+		t.Error("expected an event without expirytime never to expire")
+	}
+	e.SetExtension(ExpiryTimeExtension, now.Add(time.Minute))
+	if Expired(e, now) {
+		t.Error("expected the event not to be expired before its expirytime")
+	}
+	if !Expired(e, now.Add(2*time.Minute)) {
+		t.Error("expected the event to be expired after its expirytime")
+	}
+}
+
+func TestIsReservedAttribute(t *testing.T) {
+	for name, want := range map[string]bool{"id": true, PartitionKeyExtension: true, PriorityExtension: true, "team": false} {
+		if got := IsReservedAttribute(name); got != want {
+			t.Errorf("IsReservedAttribute(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
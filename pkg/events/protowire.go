@@ -0,0 +1,216 @@
+package events
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// protoBuilder appends the fields of a message of events.proto in the
+// protobuf binary format. As in proto3, fields without presence are omitted
+// when they hold their zero value. The first error of a nested message is
+// kept in err.
+type protoBuilder struct {
+	err error
+	b   []byte
+}
+
+func (p *protoBuilder) string(num protowire.Number, v string) {
+	if v != "" {
+		p.optionalString(num, v)
+	}
+}
+
+func (p *protoBuilder) optionalString(num protowire.Number, v string) {
+	p.b = protowire.AppendTag(p.b, num, protowire.BytesType)
+	p.b = protowire.AppendString(p.b, v)
+}
+
+func (p *protoBuilder) int64(num protowire.Number, v int64) {
+	if v != 0 {
+		p.optionalInt64(num, v)
+	}
+}
+
+func (p *protoBuilder) optionalInt64(num protowire.Number, v int64) {
+	p.b = protowire.AppendTag(p.b, num, protowire.VarintType)
+	p.b = protowire.AppendVarint(p.b, uint64(v))
+}
+
+func (p *protoBuilder) double(num protowire.Number, v float64) {
+	if v != 0 {
+		p.b = protowire.AppendTag(p.b, num, protowire.Fixed64Type)
+		p.b = protowire.AppendFixed64(p.b, math.Float64bits(v))
+	}
+}
+
+func (p *protoBuilder) bool(num protowire.Number, v bool) {
+	if v {
+		p.b = protowire.AppendTag(p.b, num, protowire.VarintType)
+		p.b = protowire.AppendVarint(p.b, protowire.EncodeBool(v))
+	}
+}
+
+// bytes appends an encoded message.
+func (p *protoBuilder) bytes(num protowire.Number, v []byte) {
+	p.b = protowire.AppendTag(p.b, num, protowire.BytesType)
+	p.b = protowire.AppendBytes(p.b, v)
+}
+
+// message appends m, a well-known type such as google.protobuf.Struct.
+func (p *protoBuilder) message(num protowire.Number, m proto.Message) {
+	v, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		p.err = cmp.Or(p.err, err)
+		return
+	}
+	p.bytes(num, v)
+}
+
+// timestamp appends t as a google.protobuf.Timestamp, or nothing if it is
+// zero.
+func (p *protoBuilder) timestamp(num protowire.Number, t time.Time) {
+	if !t.IsZero() {
+		p.message(num, timestamppb.New(t))
+	}
+}
+
+// stringMap appends m as a map<string, string>, ordered by key.
+func (p *protoBuilder) stringMap(num protowire.Number, m map[string]string) {
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		var entry protoBuilder
+		entry.string(1, k)
+		entry.string(2, m[k])
+		p.bytes(num, entry.b)
+	}
+}
+
+// protoFields are the fields of a message in the protobuf binary format by
+// number, every occurrence in order.
+type protoFields map[protowire.Number][]protoValue
+
+// protoValue is one occurrence of a field: its bytes for the bytes wire type,
+// its number otherwise.
+type protoValue struct {
+	bytes []byte
+	num   uint64
+}
+
+// parseProto parses the message b whose fields have the wire types of
+// schema. Fields missing from schema are skipped, as protobuf skips unknown
+// fields.
+func parseProto(b []byte, schema map[protowire.Number]protowire.Type) (protoFields, error) {
+	fields := make(protoFields)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		want, known := schema[num]
+		if known && typ != want {
+			return nil, fmt.Errorf("field %d has wire type %d, expected %d", num, typ, want)
+		}
+
+		var v protoValue
+		switch {
+		case !known:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		case typ == protowire.BytesType:
+			v.bytes, n = protowire.ConsumeBytes(b)
+		case typ == protowire.VarintType:
+			v.num, n = protowire.ConsumeVarint(b)
+		case typ == protowire.Fixed64Type:
+			v.num, n = protowire.ConsumeFixed64(b)
+		default:
+			return nil, fmt.Errorf("field %d has unsupported wire type %d", num, typ)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		if known {
+			fields[num] = append(fields[num], v)
+		}
+	}
+	return fields, nil
+}
+
+// last returns the last occurrence of field num, which wins for a field that
+// is not repeated.
+func (f protoFields) last(num protowire.Number) (protoValue, bool) {
+	values := f[num]
+	if len(values) == 0 {
+		return protoValue{}, false
+	}
+	return values[len(values)-1], true
+}
+
+func (f protoFields) string(num protowire.Number) (string, bool) {
+	v, ok := f.last(num)
+	return string(v.bytes), ok
+}
+
+func (f protoFields) int64(num protowire.Number) (int64, bool) {
+	v, ok := f.last(num)
+	return int64(v.num), ok
+}
+
+func (f protoFields) double(num protowire.Number) float64 {
+	v, _ := f.last(num)
+	return math.Float64frombits(v.num)
+}
+
+func (f protoFields) bool(num protowire.Number) bool {
+	v, _ := f.last(num)
+	return protowire.DecodeBool(v.num)
+}
+
+// message decodes field num into m and reports whether it is set.
+func (f protoFields) message(num protowire.Number, m proto.Message) (bool, error) {
+	v, ok := f.last(num)
+	if !ok {
+		return false, nil
+	}
+	if err := proto.Unmarshal(v.bytes, m); err != nil {
+		return false, fmt.Errorf("field %d: %w", num, err)
+	}
+	return true, nil
+}
+
+// timestamp decodes the google.protobuf.Timestamp of field num, the zero time
+// if it is not set.
+func (f protoFields) timestamp(num protowire.Number) (time.Time, error) {
+	var ts timestamppb.Timestamp
+	if ok, err := f.message(num, &ts); !ok || err != nil {
+		return time.Time{}, err
+	}
+	return ts.AsTime(), nil
+}
+
+// stringMap decodes the map<string, string> of field num, nil if it has no
+// entries.
+func (f protoFields) stringMap(num protowire.Number) (map[string]string, error) {
+	var m map[string]string
+	for _, v := range f[num] {
+		entry, err := parseProto(v.bytes, map[protowire.Number]protowire.Type{
+			1: protowire.BytesType, 2: protowire.BytesType,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("field %d: %w", num, err)
+		}
+		if m == nil {
+			m = make(map[string]string)
+		}
+		key, _ := entry.string(1)
+		m[key], _ = entry.string(2)
+	}
+	return m, nil
+}
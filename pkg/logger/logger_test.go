@@ -480,6 +480,16 @@ func TestLoggerContextValues(t *testing.T) {
 	}
 }
 
+func TestWithOpID(t *testing.T) {
+	if got := GetOpID(context.Background()); got != "" {
+		t.Errorf("expected empty op_id without WithOpID, got %q", got)
+	}
+	ctx := WithOpID(context.Background(), "op-12345")
+	if got := GetOpID(ctx); got != "op-12345" {
+		t.Errorf("expected op_id 'op-12345', got %q", got)
+	}
+}
+
 func TestLoggerSentinelFields(t *testing.T) {
 	var buf bytes.Buffer
 	cfg := &LogConfig{
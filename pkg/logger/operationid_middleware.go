@@ -24,6 +24,19 @@ const (
 	SubsetCtxKey         SubsetKey         = "subset"
 )
 
+// WithOpID adds an operation ID to the context
+func WithOpID(ctx context.Context, opID string) context.Context {
+	return context.WithValue(ctx, OpIDKey, opID)
+}
+
+// GetOpID returns the operation ID in the context, or "" if there is none
+func GetOpID(ctx context.Context) string {
+	if opID, ok := ctx.Value(OpIDKey).(string); ok {
+		return opID
+	}
+	return ""
+}
+
 // WithTraceID adds a trace ID to the context
 func WithTraceID(ctx context.Context, traceID string) context.Context {
 	return context.WithValue(ctx, TraceIDCtxKey, traceID)
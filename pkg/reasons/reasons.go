@@ -0,0 +1,158 @@
+// Package reasons enumerates why the Sentinel publishes or skips a resource.
+//
+// A Reason is reported in several places: the reason label of the
+// events_published_total and resources_skipped_total metrics, the
+// decision_reason log field and span attribute, the reason of decision stream
+// events, and the reason variable available to message_data expressions.
+// Defining every reason here keeps these in sync, and keeps the metric label
+// bounded: details of a failed evaluation are carried next to the reason, not
+// in it.
+package reasons
+
+// Reason is why the Sentinel published or skipped a resource.
+type Reason string
+
+const (
+	// Matched publishes a resource whose message_decision result is true.
+	Matched Reason = "message decision matched"
+	// NotMatched skips a resource whose message_decision result is false.
+	NotMatched Reason = "message decision result is false"
+	// Terminal skips a resource with one of the message_decision
+	// terminal_conditions True; it is never published again.
+	Terminal Reason = "terminal phase"
+	// Maintenance skips a resource that carries the maintenance label.
+	Maintenance Reason = "maintenance"
+	// MaintenanceWindow skips a resource selected by an open message_decision
+	// maintenance window.
+	MaintenanceWindow Reason = "maintenance_window"
+	// ResourcePaused skips a resource that carries the
+	// sentinel.hyperfleet.io/paused label.
+	ResourcePaused Reason = "resource paused"
+	// Paused skips a resource that would have been published while
+	// publishing for its resource type is paused.
+	Paused Reason = "paused"
+	// Backoff skips a resource that would have been published again before
+	// its republish backoff elapsed.
+	Backoff Reason = "republish backoff"
+	// GenerationDrift skips a resource that would have been published while
+	// its generation drift is younger than the generation_drift thresholds.
+	GenerationDrift Reason = "generation drift"
+	// Deferred skips a resource that would have been published after the
+	// publish rate limit was reached; it is published by a later poll cycle.
+	Deferred Reason = "deferred"
+	// Flapping skips a resource that would have been published while its
+	// condition flaps, within flap_detection.publish_interval of its last
+	// publish.
+	Flapping Reason = "flapping"
+	// Duplicate skips a resource that would have been published with the
+	// same generation and reason as a publish within the dedup window, or
+	// whose resource tagging label shows a publish within min_interval.
+	Duplicate Reason = "duplicate"
+	// AwaitingUpdate skips a resource that would have been published again
+	// before its status was updated since its last publish, within the
+	// update_cooldown timeout.
+	AwaitingUpdate Reason = "awaiting update"
+	// BrokerBackpressure skips a resource that would have been published
+	// while publishing is paused after repeated broker errors.
+	BrokerBackpressure Reason = "broker backpressure"
+	// ConditionPublish publishes a resource because a status condition
+	// matched a message_decision condition_actions binding with action publish.
+	ConditionPublish Reason = "condition publish"
+	// StuckDeletion publishes a stuck event for a resource that has been in a
+	// message_decision stuck_deletion phase for longer than its timeout.
+	StuckDeletion Reason = "stuck deletion"
+	// Cascade publishes a resource whose owner was published, when cascade
+	// mode is enabled.
+	Cascade Reason = "cascade"
+	// ConditionSkip skips a resource because a status condition matched a
+	// message_decision condition_actions binding with action skip.
+	ConditionSkip Reason = "condition skip"
+	// PolicyPublish publishes a resource for which the decision_policy
+	// returned publish true.
+	PolicyPublish Reason = "policy publish"
+	// PolicySkip skips a resource for which the decision_policy returned
+	// publish false.
+	PolicySkip Reason = "policy skip"
+	// NilResource skips a missing resource.
+	NilResource Reason = "resource is nil"
+	// ZeroTime skips a resource evaluated without a current time.
+	ZeroTime Reason = "now time is zero"
+	// ParamEvaluationFailed skips a resource for which a message_decision
+	// param failed to evaluate.
+	ParamEvaluationFailed Reason = "param evaluation failed"
+	// ResultEvaluationFailed skips a resource for which the message_decision
+	// result failed to evaluate.
+	ResultEvaluationFailed Reason = "result evaluation failed"
+	// ResultNotBool skips a resource for which the message_decision result
+	// did not evaluate to a bool.
+	ResultNotBool Reason = "result expression did not return bool"
+	// PolicyEvaluationFailed skips a resource for which the decision_policy
+	// could not be queried or returned an invalid decision.
+	PolicyEvaluationFailed Reason = "policy evaluation failed"
+)
+
+// all lists every Reason, in the order of the constants above.
+var all = []Reason{
+	Matched,
+	NotMatched,
+	Terminal,
+	Maintenance,
+	MaintenanceWindow,
+	ResourcePaused,
+	Paused,
+	Backoff,
+	GenerationDrift,
+	Deferred,
+	Flapping,
+	Duplicate,
+	AwaitingUpdate,
+	BrokerBackpressure,
+	ConditionPublish,
+	StuckDeletion,
+	Cascade,
+	ConditionSkip,
+	PolicyPublish,
+	PolicySkip,
+	NilResource,
+	ZeroTime,
+	ParamEvaluationFailed,
+	ResultEvaluationFailed,
+	ResultNotBool,
+	PolicyEvaluationFailed,
+}
+
+// All returns every Reason.
+func All() []Reason {
+	return append([]Reason(nil), all...)
+}
+
+// String returns the reason as reported in metrics, logs, and events.
+func (r Reason) String() string {
+	return string(r)
+}
+
+// Valid reports whether r is one of the defined reasons.
+func (r Reason) Valid() bool {
+	for _, known := range all {
+		if r == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Publishes reports whether a decision with reason r publishes an event.
+func (r Reason) Publishes() bool {
+	return r == Matched || r == ConditionPublish || r == StuckDeletion || r == Cascade || r == PolicyPublish
+}
+
+// Failed reports whether r records a message_decision that could not be
+// evaluated, as opposed to a deliberate skip.
+func (r Reason) Failed() bool {
+	switch r {
+	case NilResource, ZeroTime, ParamEvaluationFailed, ResultEvaluationFailed, ResultNotBool, PolicyEvaluationFailed:
+		return true
+	default:
+		return false
+	}
+}
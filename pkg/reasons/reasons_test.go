@@ -0,0 +1,129 @@
+package reasons
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+// declaredReasons parses reasons.go and returns the names of the declared
+// Reason constants.
+func declaredReasons(t *testing.T) []string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "reasons.go", nil, 0)
+	if err != nil {
+		t.Fatalf("failed to parse reasons.go: %v", err)
+	}
+	var names []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			if ident, ok := vs.Type.(*ast.Ident); !ok || ident.Name != "Reason" {
+				continue
+			}
+			for _, name := range vs.Names {
+				names = append(names, name.Name)
+			}
+		}
+	}
+	return names
+}
+
+func TestAll_Exhaustive(t *testing.T) {
+	declared := declaredReasons(t)
+	if len(declared) == 0 {
+		t.Fatal("found no Reason constants in reasons.go")
+	}
+	if len(declared) != len(All()) {
+		t.Fatalf("reasons.go declares %d reasons %v, but All returns %d; add new reasons to all",
+			len(declared), declared, len(All()))
+	}
+}
+
+func TestAll_Distinct(t *testing.T) {
+	seen := make(map[Reason]bool)
+	for _, r := range All() {
+		if r == "" {
+			t.Error("All contains an empty reason")
+		}
+		if seen[r] {
+			t.Errorf("All contains %q twice", r)
+		}
+		seen[r] = true
+		if !r.Valid() {
+			t.Errorf("expected %q to be valid", r)
+		}
+	}
+}
+
+func TestAll_ReturnsCopy(t *testing.T) {
+	got := All()
+	got[0] = "changed"
+	if All()[0] != Matched {
+		t.Error("modifying the result of All changed the reasons")
+	}
+}
+
+func TestReason_Classification(t *testing.T) {
+	tests := []struct {
+		reason        Reason
+		wantPublishes bool
+		wantFailed    bool
+	}{
+		{reason: Matched, wantPublishes: true},
+		{reason: NotMatched},
+		{reason: Terminal},
+		{reason: Maintenance},
+		{reason: MaintenanceWindow},
+		{reason: ResourcePaused},
+		{reason: Paused},
+		{reason: Backoff},
+		{reason: GenerationDrift},
+		{reason: Deferred},
+		{reason: Flapping},
+		{reason: Duplicate},
+		{reason: AwaitingUpdate},
+		{reason: BrokerBackpressure},
+		{reason: ConditionPublish, wantPublishes: true},
+		{reason: StuckDeletion, wantPublishes: true},
+		{reason: Cascade, wantPublishes: true},
+		{reason: ConditionSkip},
+		{reason: PolicyPublish, wantPublishes: true},
+		{reason: PolicySkip},
+		{reason: NilResource, wantFailed: true},
+		{reason: ZeroTime, wantFailed: true},
+		{reason: ParamEvaluationFailed, wantFailed: true},
+		{reason: ResultEvaluationFailed, wantFailed: true},
+		{reason: ResultNotBool, wantFailed: true},
+		{reason: PolicyEvaluationFailed, wantFailed: true},
+	}
+	if len(tests) != len(All()) {
+		t.Fatalf("expected a case for each of the %d reasons, got %d", len(All()), len(tests))
+	}
+	for _, tt := range tests {
+		t.Run(tt.reason.String(), func(t *testing.T) {
+			if got := tt.reason.Publishes(); got != tt.wantPublishes {
+				t.Errorf("Publishes() = %v, want %v", got, tt.wantPublishes)
+			}
+			if got := tt.reason.Failed(); got != tt.wantFailed {
+				t.Errorf("Failed() = %v, want %v", got, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestReason_Valid(t *testing.T) {
+	for _, r := range []Reason{"", "within_max_age", "message decision matched "} {
+		if r.Valid() {
+			t.Errorf("expected %q to be invalid", r)
+		}
+	}
+}
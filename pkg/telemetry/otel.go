@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/events"
 	"github.com/openshift-hyperfleet/hyperfleet-sentinel/pkg/logger"
 	"go.opentelemetry.io/contrib/propagators/autoprop"
 	"go.opentelemetry.io/otel"
@@ -164,7 +165,7 @@ func SetTraceContext(event *cloudevents.Event, span oteltrace.Span) {
 			span.SpanContext().TraceID().String(),
 			span.SpanContext().SpanID().String(),
 			uint8(span.SpanContext().TraceFlags()))
-		event.SetExtension("traceparent", traceParent)
+		event.SetExtension(events.TraceParentExtension, traceParent)
 	}
 }
 
@@ -129,6 +129,7 @@ func newTestSentinelConfig() *config.SentinelConfig {
 	return &config.SentinelConfig{
 		ResourceType:    "clusters",
 		PollInterval:    100 * time.Millisecond,
+		CycleTimeout:    5 * time.Second, // first publishes to RabbitMQ can outlast the short poll interval
 		MessageDecision: config.DefaultMessageDecision(),
 		Clients: config.ClientsConfig{
 			HyperFleetAPI: &config.HyperFleetAPIConfig{},